@@ -0,0 +1,464 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/direito-lux/billing-service/internal/domain"
+)
+
+// DunningService serviço de aplicação para a régua de cobrança de inadimplentes
+type DunningService struct {
+	dunningRepo         domain.DunningRepository
+	subscriptionRepo    domain.SubscriptionRepository
+	customerRepo        domain.CustomerRepository
+	paymentService      *PaymentService
+	notificationGateway NotificationGatewayInterface
+	tenantGateway       TenantGatewayInterface
+	eventBus            domain.EventBus
+	policy              domain.DunningPolicy
+}
+
+// NewDunningService cria um novo serviço de cobrança
+func NewDunningService(
+	dunningRepo domain.DunningRepository,
+	subscriptionRepo domain.SubscriptionRepository,
+	customerRepo domain.CustomerRepository,
+	paymentService *PaymentService,
+	notificationGateway NotificationGatewayInterface,
+	tenantGateway TenantGatewayInterface,
+	eventBus domain.EventBus,
+	policy domain.DunningPolicy,
+) *DunningService {
+	return &DunningService{
+		dunningRepo:         dunningRepo,
+		subscriptionRepo:    subscriptionRepo,
+		customerRepo:        customerRepo,
+		paymentService:      paymentService,
+		notificationGateway: notificationGateway,
+		tenantGateway:       tenantGateway,
+		eventBus:            eventBus,
+		policy:              policy,
+	}
+}
+
+// RegisterHandlers inscreve o serviço nos eventos de pagamento
+func (s *DunningService) RegisterHandlers(ctx context.Context) error {
+	if err := s.eventBus.Subscribe(ctx, "payment.failed", domain.EventHandlerFunc(s.handlePaymentFailed)); err != nil {
+		return fmt.Errorf("failed to subscribe to payment.failed: %w", err)
+	}
+
+	if err := s.eventBus.Subscribe(ctx, "payment.success", domain.EventHandlerFunc(s.handlePaymentSuccess)); err != nil {
+		return fmt.Errorf("failed to subscribe to payment.success: %w", err)
+	}
+
+	return nil
+}
+
+// handlePaymentFailed trata o evento de falha de pagamento
+func (s *DunningService) handlePaymentFailed(ctx context.Context, event domain.Event) error {
+	failed, ok := event.(*domain.PaymentFailedEvent)
+	if !ok {
+		return fmt.Errorf("unexpected event type: %T", event)
+	}
+
+	return s.StartDunning(ctx, failed.SubscriptionID, failed.PaymentID, failed.FailureReason)
+}
+
+// handlePaymentSuccess trata o evento de pagamento bem-sucedido
+func (s *DunningService) handlePaymentSuccess(ctx context.Context, event domain.Event) error {
+	success, ok := event.(*domain.PaymentSuccessEvent)
+	if !ok {
+		return fmt.Errorf("unexpected event type: %T", event)
+	}
+
+	return s.RecoverDunning(ctx, success.SubscriptionID, success.PaymentID)
+}
+
+// StartDunning inicia a régua de cobrança ou registra nova falha em uma régua existente
+func (s *DunningService) StartDunning(ctx context.Context, subscriptionID, paymentID uuid.UUID, reason string) error {
+	existing, err := s.dunningRepo.GetOpenBySubscriptionID(ctx, subscriptionID)
+	if err == nil && existing != nil {
+		if !existing.IsOpen() {
+			// Assinatura já suspensa: aguarda pagamento para reativar
+			return nil
+		}
+
+		existing.RecordFailure(reason)
+		if err := s.dunningRepo.Update(ctx, existing); err != nil {
+			return fmt.Errorf("failed to update dunning case: %w", err)
+		}
+		return nil
+	}
+
+	subscription, err := s.subscriptionRepo.GetByID(ctx, subscriptionID)
+	if err != nil {
+		return fmt.Errorf("failed to get subscription: %w", err)
+	}
+
+	if subscription.IsCancelled() {
+		return nil
+	}
+
+	dunning := domain.NewDunningCase(subscriptionID, subscription.TenantID, paymentID, s.policy, reason, time.Now())
+
+	if err := s.dunningRepo.Create(ctx, dunning); err != nil {
+		return fmt.Errorf("failed to create dunning case: %w", err)
+	}
+
+	// Publicar evento
+	event := domain.NewDunningStartedEvent(
+		dunning.TenantID,
+		dunning.ID,
+		subscriptionID,
+		paymentID,
+		reason,
+		dunning.NextActionAt,
+	)
+
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		// Log erro mas não falha o processamento
+	}
+
+	return nil
+}
+
+// ProcessDunning executa as etapas vencidas da régua de cobrança
+func (s *DunningService) ProcessDunning(ctx context.Context, limit int) error {
+	now := time.Now()
+
+	dueCases, err := s.dunningRepo.GetDue(ctx, now, limit)
+	if err != nil {
+		return fmt.Errorf("failed to get due dunning cases: %w", err)
+	}
+
+	for _, dunning := range dueCases {
+		if !dunning.IsDue(now) {
+			continue
+		}
+
+		switch dunning.Status {
+		case domain.DunningStatusActive:
+			if err := s.executeStep(ctx, dunning, now); err != nil {
+				// Log erro mas continua processando
+				continue
+			}
+		case domain.DunningStatusGracePeriod:
+			if dunning.IsGraceExpired(now) {
+				if err := s.suspend(ctx, dunning, now); err != nil {
+					// Log erro mas continua processando
+					continue
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// executeStep executa a etapa atual: retentativa de cobrança e aviso ao cliente
+func (s *DunningService) executeStep(ctx context.Context, dunning *domain.DunningCase, now time.Time) error {
+	step, ok := dunning.CurrentStepDefinition(s.policy)
+	if !ok {
+		// Política encolheu desde o início da régua: ir direto para a carência
+		dunning.StartGracePeriod(s.policy, now)
+		return s.dunningRepo.Update(ctx, dunning)
+	}
+
+	stepNumber := dunning.CurrentStep + 1
+
+	// Retentativa de cobrança
+	retryError := ""
+	if step.RetryPayment {
+		if err := s.paymentService.RetryPayment(ctx, dunning.PaymentID); err != nil {
+			retryError = err.Error()
+			dunning.RecordFailure(retryError)
+		}
+	}
+
+	// Aviso ao cliente
+	s.notify(ctx, dunning, step.Channels, s.buildStepMessage(dunning, stepNumber, now))
+
+	graceStarted := dunning.AdvanceStep(s.policy, now)
+
+	if err := s.dunningRepo.Update(ctx, dunning); err != nil {
+		return fmt.Errorf("failed to update dunning case: %w", err)
+	}
+
+	// Publicar eventos
+	event := domain.NewDunningStepExecutedEvent(
+		dunning.TenantID,
+		dunning.ID,
+		dunning.SubscriptionID,
+		stepNumber,
+		step.DayOffset,
+		step.RetryPayment,
+		retryError,
+		step.Channels,
+	)
+
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		// Log erro mas não falha o processamento
+	}
+
+	if graceStarted && dunning.GraceEndsAt != nil {
+		graceEvent := domain.NewDunningGracePeriodStartedEvent(
+			dunning.TenantID,
+			dunning.ID,
+			dunning.SubscriptionID,
+			*dunning.GraceEndsAt,
+		)
+
+		if err := s.eventBus.Publish(ctx, graceEvent); err != nil {
+			// Log erro mas não falha o processamento
+		}
+	}
+
+	return nil
+}
+
+// suspend suspende a assinatura e o tenant após o fim da carência
+func (s *DunningService) suspend(ctx context.Context, dunning *domain.DunningCase, now time.Time) error {
+	subscription, err := s.subscriptionRepo.GetByID(ctx, dunning.SubscriptionID)
+	if err != nil {
+		return fmt.Errorf("failed to get subscription: %w", err)
+	}
+
+	if subscription.IsCancelled() {
+		dunning.Cancel()
+		return s.dunningRepo.Update(ctx, dunning)
+	}
+
+	reason := fmt.Sprintf("Inadimplência: pagamento não regularizado após %d dias", dunning.GetDaysInDunning(now))
+
+	subscription.Suspend(reason)
+	if err := s.subscriptionRepo.Update(ctx, subscription); err != nil {
+		return fmt.Errorf("failed to update subscription: %w", err)
+	}
+
+	// Suspender tenant (acesso somente leitura)
+	if err := s.tenantGateway.SuspendTenant(ctx, dunning.TenantID, reason); err != nil {
+		return fmt.Errorf("failed to suspend tenant: %w", err)
+	}
+
+	dunning.Suspend(now)
+	if err := s.dunningRepo.Update(ctx, dunning); err != nil {
+		return fmt.Errorf("failed to update dunning case: %w", err)
+	}
+
+	s.notify(ctx, dunning, s.finalChannels(), DunningMessage{
+		Subject: "Sua assinatura Direito Lux foi suspensa",
+		Content: "Não identificamos o pagamento da sua assinatura e o acesso foi alterado para somente leitura. " +
+			"Assim que o pagamento for confirmado, o acesso completo será restabelecido automaticamente.",
+	})
+
+	// Publicar evento
+	event := domain.NewDunningSuspendedEvent(
+		dunning.TenantID,
+		dunning.ID,
+		dunning.SubscriptionID,
+		dunning.GetDaysInDunning(now),
+		reason,
+		now,
+	)
+
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		// Log erro mas não falha o processamento
+	}
+
+	return nil
+}
+
+// RecoverDunning encerra a régua e reativa a assinatura após pagamento
+func (s *DunningService) RecoverDunning(ctx context.Context, subscriptionID, paymentID uuid.UUID) error {
+	dunning, err := s.dunningRepo.GetOpenBySubscriptionID(ctx, subscriptionID)
+	if err != nil || dunning == nil {
+		// Sem régua em andamento: nada a fazer
+		return nil
+	}
+
+	if !dunning.CanRecover() {
+		return nil
+	}
+
+	wasSuspended := dunning.Status == domain.DunningStatusSuspended
+	now := time.Now()
+
+	if wasSuspended {
+		subscription, err := s.subscriptionRepo.GetByID(ctx, subscriptionID)
+		if err != nil {
+			return fmt.Errorf("failed to get subscription: %w", err)
+		}
+
+		if subscription.IsSuspended() {
+			subscription.Reactivate()
+			if err := s.subscriptionRepo.Update(ctx, subscription); err != nil {
+				return fmt.Errorf("failed to update subscription: %w", err)
+			}
+		}
+
+		if err := s.tenantGateway.ActivateTenant(ctx, dunning.TenantID); err != nil {
+			return fmt.Errorf("failed to reactivate tenant: %w", err)
+		}
+	}
+
+	dunning.Recover(paymentID, now)
+	if err := s.dunningRepo.Update(ctx, dunning); err != nil {
+		return fmt.Errorf("failed to update dunning case: %w", err)
+	}
+
+	if wasSuspended {
+		s.notify(ctx, dunning, s.finalChannels(), DunningMessage{
+			Subject: "Sua assinatura Direito Lux foi reativada",
+			Content: "Recebemos o seu pagamento e o acesso completo à plataforma foi restabelecido.",
+		})
+	}
+
+	// Publicar evento
+	event := domain.NewDunningRecoveredEvent(
+		dunning.TenantID,
+		dunning.ID,
+		subscriptionID,
+		paymentID,
+		dunning.CurrentStep,
+		wasSuspended,
+		now,
+	)
+
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		// Log erro mas não falha o processamento
+	}
+
+	return nil
+}
+
+// GetDunningCasesByTenant busca processos de cobrança do tenant
+func (s *DunningService) GetDunningCasesByTenant(ctx context.Context, tenantID uuid.UUID) ([]*domain.DunningCase, error) {
+	return s.dunningRepo.GetByTenantID(ctx, tenantID)
+}
+
+// GetPolicy retorna a política de cobrança vigente
+func (s *DunningService) GetPolicy() domain.DunningPolicy {
+	return s.policy
+}
+
+// DunningMessage mensagem enviada em uma etapa da régua
+type DunningMessage struct {
+	Subject string
+	Content string
+}
+
+// buildStepMessage monta a mensagem de aviso de uma etapa
+func (s *DunningService) buildStepMessage(dunning *domain.DunningCase, stepNumber int, now time.Time) DunningMessage {
+	if stepNumber >= len(s.policy.Steps) {
+		return DunningMessage{
+			Subject: "Último aviso: pagamento pendente da assinatura Direito Lux",
+			Content: fmt.Sprintf(
+				"Ainda não identificamos o pagamento da sua assinatura. Sem a regularização em %d dias, "+
+					"o acesso será alterado para somente leitura.",
+				s.policy.GracePeriodDays,
+			),
+		}
+	}
+
+	return DunningMessage{
+		Subject: "Pagamento pendente da assinatura Direito Lux",
+		Content: fmt.Sprintf(
+			"Não conseguimos processar o pagamento da sua assinatura há %d dia(s). "+
+				"Faremos uma nova tentativa de cobrança; verifique seus dados de pagamento.",
+			dunning.GetDaysInDunning(now),
+		),
+	}
+}
+
+// finalChannels retorna os canais usados em avisos fora das etapas (suspensão e reativação)
+func (s *DunningService) finalChannels() []string {
+	if len(s.policy.Steps) == 0 {
+		return []string{"email"}
+	}
+	return s.policy.Steps[len(s.policy.Steps)-1].Channels
+}
+
+// notify envia o aviso ao cliente em cada canal configurado
+func (s *DunningService) notify(ctx context.Context, dunning *domain.DunningCase, channels []string, message DunningMessage) {
+	customers, err := s.customerRepo.GetByTenantID(ctx, dunning.TenantID)
+	if err != nil || len(customers) == 0 {
+		return
+	}
+	customer := customers[0]
+
+	for _, channel := range channels {
+		contact, err := s.contactForChannel(customer, channel)
+		if err != nil {
+			continue
+		}
+
+		request := &NotificationRequest{
+			TenantID:         dunning.TenantID,
+			Type:             "subscription_due",
+			Channel:          channel,
+			Priority:         "high",
+			Subject:          message.Subject,
+			Content:          message.Content,
+			RecipientID:      customer.ID.String(),
+			RecipientType:    "customer",
+			RecipientContact: contact,
+			Variables: map[string]interface{}{
+				"customer_name":   customer.GetDisplayName(),
+				"subscription_id": dunning.SubscriptionID.String(),
+				"dunning_step":    dunning.CurrentStep,
+			},
+		}
+
+		if err := s.notificationGateway.SendNotification(ctx, request); err != nil {
+			// Log erro mas continua com os demais canais
+			continue
+		}
+	}
+}
+
+// contactForChannel retorna o contato do cliente para o canal
+func (s *DunningService) contactForChannel(customer *domain.Customer, channel string) (string, error) {
+	switch channel {
+	case "email":
+		return customer.GetBillingEmail(), nil
+	case "whatsapp", "sms":
+		phone := customer.GetBillingPhone()
+		if phone == "" {
+			return "", errors.New("customer has no phone")
+		}
+		return phone, nil
+	default:
+		return "", fmt.Errorf("unsupported dunning channel: %s", channel)
+	}
+}
+
+// Interfaces dos serviços externos
+
+// NotificationGatewayInterface interface para envio de avisos via notification-service
+type NotificationGatewayInterface interface {
+	SendNotification(ctx context.Context, request *NotificationRequest) error
+}
+
+// TenantGatewayInterface interface para controle de acesso do tenant via tenant-service
+type TenantGatewayInterface interface {
+	SuspendTenant(ctx context.Context, tenantID uuid.UUID, reason string) error
+	ActivateTenant(ctx context.Context, tenantID uuid.UUID) error
+}
+
+// NotificationRequest requisição de notificação
+type NotificationRequest struct {
+	TenantID         uuid.UUID              `json:"tenant_id"`
+	Type             string                 `json:"type"`
+	Channel          string                 `json:"channel"`
+	Priority         string                 `json:"priority"`
+	Subject          string                 `json:"subject"`
+	Content          string                 `json:"content"`
+	RecipientID      string                 `json:"recipient_id"`
+	RecipientType    string                 `json:"recipient_type"`
+	RecipientContact string                 `json:"recipient_contact"`
+	Variables        map[string]interface{} `json:"variables,omitempty"`
+}
@@ -0,0 +1,244 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/direito-lux/billing-service/internal/domain"
+)
+
+// LedgerService serviço de aplicação do razão contábil de faturamento
+type LedgerService struct {
+	ledgerRepo       domain.LedgerRepository
+	invoiceRepo      domain.InvoiceRepository
+	paymentRepo      domain.PaymentRepository
+	subscriptionRepo domain.SubscriptionRepository
+	eventBus         domain.EventBus
+}
+
+// NewLedgerService cria um novo serviço de razão contábil
+func NewLedgerService(
+	ledgerRepo domain.LedgerRepository,
+	invoiceRepo domain.InvoiceRepository,
+	paymentRepo domain.PaymentRepository,
+	subscriptionRepo domain.SubscriptionRepository,
+	eventBus domain.EventBus,
+) *LedgerService {
+	return &LedgerService{
+		ledgerRepo:       ledgerRepo,
+		invoiceRepo:      invoiceRepo,
+		paymentRepo:      paymentRepo,
+		subscriptionRepo: subscriptionRepo,
+		eventBus:         eventBus,
+	}
+}
+
+// RegisterHandlers inscreve o razão nos eventos de fatura, pagamento, reembolso e crédito
+func (s *LedgerService) RegisterHandlers(ctx context.Context) error {
+	handlers := map[string]domain.EventHandlerFunc{
+		"invoice.created":  s.handleInvoiceCreated,
+		"payment.success":  s.handlePaymentSuccess,
+		"payment.refunded": s.handlePaymentRefunded,
+		"credit.granted":   s.handleCreditGranted,
+	}
+
+	for eventType, handler := range handlers {
+		if err := s.eventBus.Subscribe(ctx, eventType, handler); err != nil {
+			return fmt.Errorf("failed to subscribe to %s: %w", eventType, err)
+		}
+	}
+
+	return nil
+}
+
+// handleInvoiceCreated lança a emissão da fatura
+func (s *LedgerService) handleInvoiceCreated(ctx context.Context, event domain.Event) error {
+	created, ok := event.(*domain.InvoiceCreatedEvent)
+	if !ok {
+		return fmt.Errorf("unexpected event type: %T", event)
+	}
+
+	invoice, err := s.invoiceRepo.GetByID(ctx, created.InvoiceID)
+	if err != nil {
+		return fmt.Errorf("failed to get invoice: %w", err)
+	}
+
+	return s.post(ctx, domain.NewInvoiceIssuedEntry(invoice).FromEvent(event.GetID()))
+}
+
+// handlePaymentSuccess lança o recebimento
+func (s *LedgerService) handlePaymentSuccess(ctx context.Context, event domain.Event) error {
+	success, ok := event.(*domain.PaymentSuccessEvent)
+	if !ok {
+		return fmt.Errorf("unexpected event type: %T", event)
+	}
+
+	payment, err := s.paymentRepo.GetByID(ctx, success.PaymentID)
+	if err != nil {
+		return fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	return s.post(ctx, domain.NewPaymentReceivedEntry(payment, success.PaidAt).FromEvent(event.GetID()))
+}
+
+// handlePaymentRefunded lança o reembolso
+func (s *LedgerService) handlePaymentRefunded(ctx context.Context, event domain.Event) error {
+	refunded, ok := event.(*domain.PaymentRefundedEvent)
+	if !ok {
+		return fmt.Errorf("unexpected event type: %T", event)
+	}
+
+	payment, err := s.paymentRepo.GetByID(ctx, refunded.PaymentID)
+	if err != nil {
+		return fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	var invoice *domain.Invoice
+	if payment.InvoiceID != nil {
+		invoice, err = s.invoiceRepo.GetByID(ctx, *payment.InvoiceID)
+		if err != nil {
+			return fmt.Errorf("failed to get invoice: %w", err)
+		}
+	}
+
+	entry := domain.NewPaymentRefundedEntry(payment, invoice, refunded.RefundAmount, refunded.RefundedAt)
+	return s.post(ctx, entry.FromEvent(event.GetID()))
+}
+
+// handleCreditGranted lança o crédito concedido ao cliente
+func (s *LedgerService) handleCreditGranted(ctx context.Context, event domain.Event) error {
+	granted, ok := event.(*domain.CreditGrantedEvent)
+	if !ok {
+		return fmt.Errorf("unexpected event type: %T", event)
+	}
+
+	entry := domain.NewCreditGrantedEntry(granted.TenantID, granted.SubscriptionID, granted.Amount, granted.Reason, granted.GrantedAt)
+	return s.post(ctx, entry.FromEvent(event.GetID()))
+}
+
+// post valida e inclui o lançamento, ignorando eventos já lançados
+func (s *LedgerService) post(ctx context.Context, entry *domain.LedgerEntry) error {
+	if err := entry.Validate(); err != nil {
+		return fmt.Errorf("invalid ledger entry: %w", err)
+	}
+
+	if entry.EventID != nil {
+		exists, err := s.ledgerRepo.ExistsByEventID(ctx, *entry.EventID)
+		if err != nil {
+			return fmt.Errorf("failed to check ledger entry: %w", err)
+		}
+		if exists {
+			return nil
+		}
+	}
+
+	if err := s.ledgerRepo.Append(ctx, entry); err != nil {
+		return fmt.Errorf("failed to append ledger entry: %w", err)
+	}
+
+	return nil
+}
+
+// RecognizeRevenue reconhece a receita diferida proporcional ao período já prestado
+func (s *LedgerService) RecognizeRevenue(ctx context.Context, asOf time.Time) (int64, error) {
+	var recognized int64
+
+	for _, status := range []domain.InvoiceStatus{
+		domain.InvoiceStatusIssued,
+		domain.InvoiceStatusSent,
+		domain.InvoiceStatusPaid,
+		domain.InvoiceStatusOverdue,
+	} {
+		invoices, err := s.invoiceRepo.GetByStatus(ctx, status)
+		if err != nil {
+			return recognized, fmt.Errorf("failed to get invoices: %w", err)
+		}
+
+		for _, invoice := range invoices {
+			amount, err := s.recognizeInvoice(ctx, invoice, asOf)
+			if err != nil {
+				// Log erro mas continua processando
+				continue
+			}
+			recognized += amount
+		}
+	}
+
+	return recognized, nil
+}
+
+// recognizeInvoice lança a diferença entre o valor prestado e o já reconhecido da fatura
+func (s *LedgerService) recognizeInvoice(ctx context.Context, invoice *domain.Invoice, asOf time.Time) (int64, error) {
+	entries, err := s.ledgerRepo.GetByReference(ctx, invoice.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get ledger entries: %w", err)
+	}
+
+	deferred := domain.SumLines(entries, domain.LedgerAccountDeferredRevenue)
+	if deferred.Credits == 0 {
+		// Fatura ainda não lançada no razão
+		return 0, nil
+	}
+
+	earned := domain.EarnedAmount(deferred.Credits, invoice.PeriodStart, invoice.PeriodEnd, asOf)
+	pending := earned - deferred.Debits
+	if pending <= 0 {
+		return 0, nil
+	}
+
+	if err := s.post(ctx, domain.NewRevenueRecognizedEntry(invoice, pending, asOf)); err != nil {
+		return 0, err
+	}
+
+	return pending, nil
+}
+
+// GetEntries busca lançamentos do razão para exportação
+func (s *LedgerService) GetEntries(ctx context.Context, query domain.LedgerQuery) ([]*domain.LedgerEntry, error) {
+	return s.ledgerRepo.Query(ctx, query)
+}
+
+// GetTrialBalance retorna o balancete até a data
+func (s *LedgerService) GetTrialBalance(ctx context.Context, tenantID *uuid.UUID, asOf time.Time) (*domain.TrialBalance, error) {
+	balances, err := s.ledgerRepo.GetBalances(ctx, tenantID, nil, &asOf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ledger balances: %w", err)
+	}
+
+	return domain.NewTrialBalance(asOf, balances), nil
+}
+
+// GetRevenueReport gera o relatório de MRR/ARR, churn e receita diferida do período
+func (s *LedgerService) GetRevenueReport(ctx context.Context, from, to time.Time) (*domain.RevenueReport, error) {
+	if !to.After(from) {
+		return nil, fmt.Errorf("invalid report period: %s - %s", from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+
+	active, err := s.subscriptionRepo.GetByStatus(ctx, domain.SubscriptionStatusActive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active subscriptions: %w", err)
+	}
+
+	cancelled, err := s.subscriptionRepo.GetByStatus(ctx, domain.SubscriptionStatusCancelled)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cancelled subscriptions: %w", err)
+	}
+
+	report := domain.NewRevenueReport(from, to, active, cancelled)
+
+	movements, err := s.ledgerRepo.GetBalances(ctx, nil, &from, &to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ledger movements: %w", err)
+	}
+
+	closing, err := s.ledgerRepo.GetBalances(ctx, nil, nil, &to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ledger balances: %w", err)
+	}
+
+	report.ApplyLedger(movements, closing)
+
+	return report, nil
+}
@@ -0,0 +1,392 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/direito-lux/billing-service/internal/domain"
+)
+
+// ErrNFSeTaxMismatch a fatura foi tributada com alíquota ou código de serviço diferente do município
+var ErrNFSeTaxMismatch = errors.New("invoice ISS does not match the issuer municipality")
+
+// NFSeService serviço de aplicação para emissão de NFS-e
+type NFSeService struct {
+	invoiceRepo domain.InvoiceRepository
+	paymentRepo domain.PaymentRepository
+	provider    NFSeProviderInterface
+	storage     DocumentStorageInterface
+	taxTable    *domain.NFSeTaxTable
+	issuer      NFSeIssuer
+	eventBus    domain.EventBus
+}
+
+// NFSeIssuer dados do prestador do serviço
+type NFSeIssuer struct {
+	CNPJ                  string `json:"cnpj"`
+	MunicipalRegistration string `json:"municipal_registration"`
+	MunicipalityCode      string `json:"municipality_code"`
+}
+
+// NewNFSeService cria um novo serviço de NFS-e
+func NewNFSeService(
+	invoiceRepo domain.InvoiceRepository,
+	paymentRepo domain.PaymentRepository,
+	provider NFSeProviderInterface,
+	storage DocumentStorageInterface,
+	taxTable *domain.NFSeTaxTable,
+	issuer NFSeIssuer,
+	eventBus domain.EventBus,
+) (*NFSeService, error) {
+	if _, ok := taxTable.Get(issuer.MunicipalityCode); !ok {
+		return nil, fmt.Errorf("issuer municipality %s not configured", issuer.MunicipalityCode)
+	}
+
+	return &NFSeService{
+		invoiceRepo: invoiceRepo,
+		paymentRepo: paymentRepo,
+		provider:    provider,
+		storage:     storage,
+		taxTable:    taxTable,
+		issuer:      issuer,
+		eventBus:    eventBus,
+	}, nil
+}
+
+// RegisterHandlers inscreve o serviço nos eventos de fatura e reembolso
+func (s *NFSeService) RegisterHandlers(ctx context.Context) error {
+	if err := s.eventBus.Subscribe(ctx, "invoice.paid", domain.EventHandlerFunc(s.handleInvoicePaid)); err != nil {
+		return fmt.Errorf("failed to subscribe to invoice.paid: %w", err)
+	}
+
+	if err := s.eventBus.Subscribe(ctx, "payment.refunded", domain.EventHandlerFunc(s.handlePaymentRefunded)); err != nil {
+		return fmt.Errorf("failed to subscribe to payment.refunded: %w", err)
+	}
+
+	return nil
+}
+
+// handleInvoicePaid emite a NFS-e quando a fatura é paga
+func (s *NFSeService) handleInvoicePaid(ctx context.Context, event domain.Event) error {
+	paid, ok := event.(*domain.InvoicePaidEvent)
+	if !ok {
+		return fmt.Errorf("unexpected event type: %T", event)
+	}
+
+	_, err := s.IssueForInvoice(ctx, paid.InvoiceID)
+	return err
+}
+
+// handlePaymentRefunded cancela a NFS-e quando o pagamento é estornado integralmente
+func (s *NFSeService) handlePaymentRefunded(ctx context.Context, event domain.Event) error {
+	refunded, ok := event.(*domain.PaymentRefundedEvent)
+	if !ok {
+		return fmt.Errorf("unexpected event type: %T", event)
+	}
+
+	// Reembolso parcial mantém a nota
+	if refunded.RefundAmount < refunded.OriginalAmount {
+		return nil
+	}
+
+	payment, err := s.paymentRepo.GetByID(ctx, refunded.PaymentID)
+	if err != nil {
+		return fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	if payment.InvoiceID == nil {
+		return nil
+	}
+
+	reason := refunded.RefundReason
+	if reason == "" {
+		reason = "Serviço não prestado - pagamento estornado"
+	}
+
+	return s.CancelForInvoice(ctx, *payment.InvoiceID, reason)
+}
+
+// ApplyMunicipalTax aplica a alíquota de ISS e o código de serviço do município emissor.
+// Deve ser chamado na criação da fatura, antes da cobrança.
+func (s *NFSeService) ApplyMunicipalTax(invoice *domain.Invoice) {
+	municipality, _ := s.taxTable.Get(s.issuer.MunicipalityCode)
+	invoice.ApplyISSRate(municipality.ISSRate, municipality.ServiceCode)
+}
+
+// IssueForInvoice emite a NFS-e de uma fatura paga
+func (s *NFSeService) IssueForInvoice(ctx context.Context, invoiceID uuid.UUID) (*domain.Invoice, error) {
+	invoice, err := s.invoiceRepo.GetByID(ctx, invoiceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invoice: %w", err)
+	}
+
+	if invoice.HasNFE() {
+		if invoice.NFEXMLURL == nil && !invoice.IsNFECancelled() {
+			return s.retryDocuments(ctx, invoice)
+		}
+		return invoice, nil
+	}
+
+	if !invoice.CanIssueNFE() {
+		return nil, errors.New("invoice is not eligible for NFS-e issuance")
+	}
+
+	// A fatura já foi cobrada: a nota não pode sair com valores diferentes dos pagos
+	municipality, _ := s.taxTable.Get(s.issuer.MunicipalityCode)
+	if invoice.ISSRate != municipality.ISSRate || invoice.ServiceCode != municipality.ServiceCode {
+		err := fmt.Errorf("%w: invoice has %.4f/%s, %s requires %.4f/%s", ErrNFSeTaxMismatch,
+			invoice.ISSRate, invoice.ServiceCode, municipality.City, municipality.ISSRate, municipality.ServiceCode)
+		return nil, s.markFailed(ctx, invoice, err)
+	}
+
+	result, err := s.provider.IssueNFSe(ctx, s.buildIssueRequest(invoice, municipality))
+	if err != nil {
+		return nil, s.markFailed(ctx, invoice, fmt.Errorf("failed to issue NFS-e: %w", err))
+	}
+
+	invoice.SetNFEData(result.Number, result.VerificationCode, result.URL, domain.NFEStatusIssued)
+
+	// Armazenar XML e PDF da nota
+	xmlURL, pdfURL, err := s.storeDocuments(ctx, invoice, result)
+	if err != nil {
+		// A nota foi emitida; os documentos são baixados novamente no reprocessamento
+		invoice.IncrementRetry(err.Error())
+	} else {
+		invoice.SetNFEDocuments(xmlURL, pdfURL)
+	}
+
+	if err := s.invoiceRepo.Update(ctx, invoice); err != nil {
+		return nil, fmt.Errorf("failed to update invoice: %w", err)
+	}
+
+	event := domain.NewNFEIssuedEvent(
+		invoice.TenantID,
+		invoice.ID,
+		invoice.SubscriptionID,
+		result.Number,
+		result.VerificationCode,
+		result.URL,
+		invoice.TotalAmount,
+		invoice.CustomerEmail,
+	)
+
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		// Log erro mas não falha a emissão
+	}
+
+	return invoice, nil
+}
+
+// CancelForInvoice cancela a NFS-e de uma fatura
+func (s *NFSeService) CancelForInvoice(ctx context.Context, invoiceID uuid.UUID, reason string) error {
+	invoice, err := s.invoiceRepo.GetByID(ctx, invoiceID)
+	if err != nil {
+		return fmt.Errorf("failed to get invoice: %w", err)
+	}
+
+	if !invoice.HasNFE() || invoice.IsNFECancelled() {
+		return nil
+	}
+
+	if err := s.provider.CancelNFSe(ctx, s.referenceFor(invoice), reason); err != nil {
+		return fmt.Errorf("failed to cancel NFS-e: %w", err)
+	}
+
+	invoice.CancelNFE(reason)
+
+	if err := s.invoiceRepo.Update(ctx, invoice); err != nil {
+		return fmt.Errorf("failed to update invoice: %w", err)
+	}
+
+	event := domain.NewNFECancelledEvent(
+		invoice.TenantID,
+		invoice.ID,
+		invoice.SubscriptionID,
+		*invoice.NFENumber,
+		reason,
+		*invoice.NFECancelledAt,
+	)
+
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		// Log erro mas não falha o cancelamento
+	}
+
+	return nil
+}
+
+// retryDocuments tenta novamente armazenar os documentos de uma nota já emitida
+func (s *NFSeService) retryDocuments(ctx context.Context, invoice *domain.Invoice) (*domain.Invoice, error) {
+	xmlURL, pdfURL, err := s.storeDocuments(ctx, invoice, &NFSeIssueResult{Number: *invoice.NFENumber})
+	if err != nil {
+		invoice.IncrementRetry(err.Error())
+		if updateErr := s.invoiceRepo.Update(ctx, invoice); updateErr != nil {
+			return nil, fmt.Errorf("%w (failed to update invoice: %v)", err, updateErr)
+		}
+		return nil, err
+	}
+
+	invoice.SetNFEDocuments(xmlURL, pdfURL)
+
+	if err := s.invoiceRepo.Update(ctx, invoice); err != nil {
+		return nil, fmt.Errorf("failed to update invoice: %w", err)
+	}
+
+	return invoice, nil
+}
+
+// markFailed registra a falha de emissão na fatura e retorna o erro
+func (s *NFSeService) markFailed(ctx context.Context, invoice *domain.Invoice, err error) error {
+	invoice.MarkNFEFailed(err.Error())
+
+	if updateErr := s.invoiceRepo.Update(ctx, invoice); updateErr != nil {
+		return fmt.Errorf("%w (failed to update invoice: %v)", err, updateErr)
+	}
+
+	return err
+}
+
+// RetryPendingNFSe reprocessa faturas pagas sem NFS-e ou sem documentos armazenados
+func (s *NFSeService) RetryPendingNFSe(ctx context.Context, limit int) (int, error) {
+	invoices, err := s.invoiceRepo.GetPendingNFE(ctx, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get pending invoices: %w", err)
+	}
+
+	issued := 0
+	for _, invoice := range invoices {
+		if invoice.RetryCount >= 3 {
+			continue
+		}
+
+		if _, err := s.IssueForInvoice(ctx, invoice.ID); err != nil {
+			// Log erro mas continua processando
+			continue
+		}
+		issued++
+	}
+
+	return issued, nil
+}
+
+// buildIssueRequest monta a requisição de emissão para o provedor
+func (s *NFSeService) buildIssueRequest(invoice *domain.Invoice, municipality domain.NFSeMunicipality) *NFSeIssueRequest {
+	request := &NFSeIssueRequest{
+		Reference:          s.referenceFor(invoice),
+		IssueDate:          time.Now(),
+		IssuerCNPJ:         s.issuer.CNPJ,
+		IssuerRegistration: s.issuer.MunicipalRegistration,
+		IssuerMunicipality: municipality.IBGECode,
+		CustomerName:       invoice.CustomerName,
+		CustomerEmail:      invoice.CustomerEmail,
+		CustomerDocument:   invoice.CustomerDocument,
+		CustomerAddress:    invoice.CustomerAddress,
+		ServiceCode:        municipality.ServiceCode,
+		MunicipalTaxCode:   municipality.MunicipalTaxCode,
+		CNAE:               municipality.CNAE,
+		Description:        fmt.Sprintf("%s - Fatura %s (%s a %s)", municipality.ServiceDescription, invoice.Number, invoice.PeriodStart.Format("02/01/2006"), invoice.PeriodEnd.Format("02/01/2006")),
+		Amount:             invoice.Amount - invoice.DiscountAmount,
+		ISSRate:            municipality.ISSRate,
+		ISSAmount:          invoice.TaxAmount,
+	}
+
+	if invoice.CustomerAddress != nil {
+		if customerMunicipality, ok := s.taxTable.FindByCity(invoice.CustomerAddress.City, invoice.CustomerAddress.State); ok {
+			request.CustomerMunicipality = customerMunicipality.IBGECode
+		}
+	}
+
+	return request
+}
+
+// storeDocuments baixa e armazena o XML e o PDF da nota
+func (s *NFSeService) storeDocuments(ctx context.Context, invoice *domain.Invoice, result *NFSeIssueResult) (string, string, error) {
+	xml := result.XML
+	if len(xml) == 0 {
+		data, err := s.provider.DownloadXML(ctx, s.referenceFor(invoice))
+		if err != nil {
+			return "", "", fmt.Errorf("failed to download NFS-e XML: %w", err)
+		}
+		xml = data
+	}
+
+	pdf := result.PDF
+	if len(pdf) == 0 {
+		data, err := s.provider.DownloadPDF(ctx, s.referenceFor(invoice))
+		if err != nil {
+			return "", "", fmt.Errorf("failed to download NFS-e PDF: %w", err)
+		}
+		pdf = data
+	}
+
+	basePath := fmt.Sprintf("%s/%s/nfse-%s", invoice.TenantID, invoice.IssuedAt.Format("2006/01"), result.Number)
+
+	xmlURL, err := s.storage.Store(ctx, basePath+".xml", "application/xml", xml)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to store NFS-e XML: %w", err)
+	}
+
+	pdfURL, err := s.storage.Store(ctx, basePath+".pdf", "application/pdf", pdf)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to store NFS-e PDF: %w", err)
+	}
+
+	return xmlURL, pdfURL, nil
+}
+
+// referenceFor retorna a referência idempotente da nota no provedor
+func (s *NFSeService) referenceFor(invoice *domain.Invoice) string {
+	return invoice.ID.String()
+}
+
+// Interfaces para provedores externos
+
+// InvoiceTaxApplier aplica a tributação municipal às faturas criadas
+type InvoiceTaxApplier interface {
+	ApplyMunicipalTax(invoice *domain.Invoice)
+}
+
+// NFSeProviderInterface interface para provedores de emissão de NFS-e
+type NFSeProviderInterface interface {
+	IssueNFSe(ctx context.Context, request *NFSeIssueRequest) (*NFSeIssueResult, error)
+	CancelNFSe(ctx context.Context, reference, reason string) error
+	DownloadXML(ctx context.Context, reference string) ([]byte, error)
+	DownloadPDF(ctx context.Context, reference string) ([]byte, error)
+}
+
+// DocumentStorageInterface interface para armazenamento de documentos fiscais
+type DocumentStorageInterface interface {
+	Store(ctx context.Context, path, contentType string, data []byte) (string, error)
+}
+
+// NFSeIssueRequest requisição de emissão de NFS-e
+type NFSeIssueRequest struct {
+	Reference            string          `json:"reference"`
+	IssueDate            time.Time       `json:"issue_date"`
+	IssuerCNPJ           string          `json:"issuer_cnpj"`
+	IssuerRegistration   string          `json:"issuer_registration"`
+	IssuerMunicipality   string          `json:"issuer_municipality"`
+	CustomerName         string          `json:"customer_name"`
+	CustomerEmail        string          `json:"customer_email"`
+	CustomerDocument     string          `json:"customer_document"`
+	CustomerAddress      *domain.Address `json:"customer_address"`
+	CustomerMunicipality string          `json:"customer_municipality"`
+	ServiceCode          string          `json:"service_code"`
+	MunicipalTaxCode     string          `json:"municipal_tax_code"`
+	CNAE                 string          `json:"cnae"`
+	Description          string          `json:"description"`
+	Amount               int64           `json:"amount"` // em centavos
+	ISSRate              float64         `json:"iss_rate"`
+	ISSAmount            int64           `json:"iss_amount"` // em centavos
+}
+
+// NFSeIssueResult resultado da emissão de NFS-e
+type NFSeIssueResult struct {
+	Number           string `json:"number"`
+	VerificationCode string `json:"verification_code"`
+	URL              string `json:"url"`
+	XML              []byte `json:"-"`
+	PDF              []byte `json:"-"`
+}
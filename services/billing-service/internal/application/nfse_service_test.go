@@ -0,0 +1,258 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/direito-lux/billing-service/internal/domain"
+)
+
+// fakeInvoiceRepo repositório de faturas em memória
+type fakeInvoiceRepo struct {
+	domain.InvoiceRepository
+	invoices  map[uuid.UUID]*domain.Invoice
+	updates   int
+	updateErr error
+}
+
+func (r *fakeInvoiceRepo) Create(ctx context.Context, invoice *domain.Invoice) error {
+	r.invoices[invoice.ID] = invoice
+	return nil
+}
+
+func (r *fakeInvoiceRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Invoice, error) {
+	invoice, ok := r.invoices[id]
+	if !ok {
+		return nil, errors.New("invoice not found")
+	}
+	return invoice, nil
+}
+
+func (r *fakeInvoiceRepo) Update(ctx context.Context, invoice *domain.Invoice) error {
+	r.updates++
+	return r.updateErr
+}
+
+// fakeNFSeProvider provedor de NFS-e que registra as emissões
+type fakeNFSeProvider struct {
+	requests []*NFSeIssueRequest
+	issueErr error
+}
+
+func (p *fakeNFSeProvider) IssueNFSe(ctx context.Context, request *NFSeIssueRequest) (*NFSeIssueResult, error) {
+	if p.issueErr != nil {
+		return nil, p.issueErr
+	}
+	p.requests = append(p.requests, request)
+	return &NFSeIssueResult{
+		Number:           "2025000123",
+		VerificationCode: "ABCD-1234",
+		URL:              "https://nfse.example.com/2025000123",
+		XML:              []byte("<nfse/>"),
+		PDF:              []byte("%PDF"),
+	}, nil
+}
+
+func (p *fakeNFSeProvider) CancelNFSe(ctx context.Context, reference, reason string) error {
+	return nil
+}
+
+func (p *fakeNFSeProvider) DownloadXML(ctx context.Context, reference string) ([]byte, error) {
+	return []byte("<nfse/>"), nil
+}
+
+func (p *fakeNFSeProvider) DownloadPDF(ctx context.Context, reference string) ([]byte, error) {
+	return []byte("%PDF"), nil
+}
+
+// fakeDocumentStorage armazenamento de documentos em memória
+type fakeDocumentStorage struct {
+	storeErr error
+}
+
+func (s *fakeDocumentStorage) Store(ctx context.Context, path, contentType string, data []byte) (string, error) {
+	if s.storeErr != nil {
+		return "", s.storeErr
+	}
+	return "https://storage.example.com/" + path, nil
+}
+
+// fakeEventBus barramento que registra os eventos publicados
+type fakeEventBus struct {
+	published []domain.Event
+}
+
+func (b *fakeEventBus) Publish(ctx context.Context, event domain.Event) error {
+	b.published = append(b.published, event)
+	return nil
+}
+
+func (b *fakeEventBus) Subscribe(ctx context.Context, eventType string, handler domain.EventHandler) error {
+	return nil
+}
+
+// nfseFixture serviço de NFS-e com dependências em memória e uma fatura paga
+type nfseFixture struct {
+	service  *NFSeService
+	repo     *fakeInvoiceRepo
+	provider *fakeNFSeProvider
+	storage  *fakeDocumentStorage
+	eventBus *fakeEventBus
+	invoice  *domain.Invoice
+}
+
+func newNFSeFixture(t *testing.T) *nfseFixture {
+	t.Helper()
+
+	table, err := domain.NewNFSeTaxTable(domain.DefaultNFSeMunicipalities())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	f := &nfseFixture{
+		repo:     &fakeInvoiceRepo{invoices: make(map[uuid.UUID]*domain.Invoice)},
+		provider: &fakeNFSeProvider{},
+		storage:  &fakeDocumentStorage{},
+		eventBus: &fakeEventBus{},
+	}
+
+	f.service, err = NewNFSeService(f.repo, nil, f.provider, f.storage, table, NFSeIssuer{
+		CNPJ:                  "12345678000190",
+		MunicipalRegistration: "1234567",
+		MunicipalityCode:      "4106902",
+	}, f.eventBus)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	f.invoice = domain.NewInvoice(uuid.New(), uuid.New(), 19990, time.Now().AddDate(0, -1, 0), time.Now())
+	f.service.ApplyMunicipalTax(f.invoice)
+	f.invoice.GenerateNumber()
+	f.invoice.MarkAsPaid(uuid.New())
+	f.repo.invoices[f.invoice.ID] = f.invoice
+
+	return f
+}
+
+func TestNewNFSeServiceRequiresIssuerMunicipality(t *testing.T) {
+	table, _ := domain.NewNFSeTaxTable(domain.DefaultNFSeMunicipalities())
+
+	_, err := NewNFSeService(nil, nil, nil, nil, table, NFSeIssuer{MunicipalityCode: "3550308"}, nil)
+	if err == nil {
+		t.Fatal("expected error for unconfigured issuer municipality")
+	}
+}
+
+func TestNFSeServiceIssueForInvoice(t *testing.T) {
+	f := newNFSeFixture(t)
+	total := f.invoice.TotalAmount
+
+	invoice, err := f.service.IssueForInvoice(context.Background(), f.invoice.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !invoice.HasNFE() || invoice.NFEXMLURL == nil || invoice.NFEPDFURL == nil {
+		t.Fatalf("expected NFS-e with stored documents, got %+v", invoice)
+	}
+	if invoice.TotalAmount != total {
+		t.Errorf("expected totals to be preserved: got %d, want %d", invoice.TotalAmount, total)
+	}
+
+	request := f.provider.requests[0]
+	if request.ISSAmount != invoice.TaxAmount || request.ServiceCode != "01.05" {
+		t.Errorf("expected request with invoice ISS, got %+v", request)
+	}
+	if len(f.eventBus.published) != 1 || f.eventBus.published[0].GetType() != "nfe.issued" {
+		t.Errorf("expected nfe.issued event, got %v", f.eventBus.published)
+	}
+
+	// Fatura com nota emitida não é emitida de novo
+	if _, err := f.service.IssueForInvoice(context.Background(), f.invoice.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(f.provider.requests) != 1 {
+		t.Errorf("expected a single issuance, got %d", len(f.provider.requests))
+	}
+}
+
+func TestNFSeServiceRejectsTaxMismatch(t *testing.T) {
+	f := newNFSeFixture(t)
+	f.invoice.ApplyISSRate(0.05, "01.05")
+	f.invoice.MarkAsPaid(uuid.New())
+	total := f.invoice.TotalAmount
+
+	_, err := f.service.IssueForInvoice(context.Background(), f.invoice.ID)
+	if !errors.Is(err, ErrNFSeTaxMismatch) {
+		t.Fatalf("expected tax mismatch, got %v", err)
+	}
+
+	if len(f.provider.requests) != 0 {
+		t.Error("expected no issuance for mismatched invoice")
+	}
+	if f.invoice.TotalAmount != total || f.invoice.ISSRate != 0.05 {
+		t.Errorf("expected paid invoice totals to be preserved, got %d at %.2f", f.invoice.TotalAmount, f.invoice.ISSRate)
+	}
+	if f.invoice.NFEStatus == nil || *f.invoice.NFEStatus != domain.NFEStatusFailed || f.repo.updates != 1 {
+		t.Errorf("expected failure to be recorded, got status %v after %d updates", f.invoice.NFEStatus, f.repo.updates)
+	}
+}
+
+func TestNFSeServiceReportsUpdateErrors(t *testing.T) {
+	providerErr := errors.New("provider unavailable")
+	updateErr := errors.New("database unavailable")
+
+	f := newNFSeFixture(t)
+	f.provider.issueErr = providerErr
+	f.repo.updateErr = updateErr
+
+	_, err := f.service.IssueForInvoice(context.Background(), f.invoice.ID)
+	if !errors.Is(err, providerErr) {
+		t.Fatalf("expected provider error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), updateErr.Error()) {
+		t.Errorf("expected update error to be reported, got %v", err)
+	}
+	if f.invoice.RetryCount != 1 {
+		t.Errorf("expected retry to be counted, got %d", f.invoice.RetryCount)
+	}
+}
+
+func TestNFSeServiceRetriesDocuments(t *testing.T) {
+	f := newNFSeFixture(t)
+	f.storage.storeErr = errors.New("bucket unavailable")
+
+	// Nota emitida, documentos pendentes
+	invoice, err := f.service.IssueForInvoice(context.Background(), f.invoice.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if invoice.NFEXMLURL != nil || invoice.RetryCount != 1 {
+		t.Fatalf("expected pending documents after storage failure, got %+v", invoice)
+	}
+
+	// Reprocessamento com falha ao salvar a fatura
+	f.repo.updateErr = errors.New("database unavailable")
+	if _, err := f.service.IssueForInvoice(context.Background(), f.invoice.ID); !errors.Is(err, f.storage.storeErr) {
+		t.Fatalf("expected storage error, got %v", err)
+	}
+
+	// Reprocessamento bem-sucedido armazena os documentos sem nova emissão
+	f.storage.storeErr = nil
+	f.repo.updateErr = nil
+	invoice, err = f.service.IssueForInvoice(context.Background(), f.invoice.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if invoice.NFEXMLURL == nil || invoice.NFEPDFURL == nil {
+		t.Errorf("expected stored documents, got %+v", invoice)
+	}
+	if len(f.provider.requests) != 1 {
+		t.Errorf("expected a single issuance, got %d", len(f.provider.requests))
+	}
+}
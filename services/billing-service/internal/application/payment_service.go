@@ -16,8 +16,11 @@ type PaymentService struct {
 	subscriptionRepo domain.SubscriptionRepository
 	invoiceRepo      domain.InvoiceRepository
 	customerRepo     domain.CustomerRepository
+	dunningRepo      domain.DunningRepository
 	asaasGateway     AsaasGatewayInterface
 	cryptoGateway    CryptoGatewayInterface
+	pixGateway       PixGatewayInterface
+	pixExpiration    time.Duration
 	eventBus         domain.EventBus
 }
 
@@ -27,8 +30,11 @@ func NewPaymentService(
 	subscriptionRepo domain.SubscriptionRepository,
 	invoiceRepo domain.InvoiceRepository,
 	customerRepo domain.CustomerRepository,
+	dunningRepo domain.DunningRepository,
 	asaasGateway AsaasGatewayInterface,
 	cryptoGateway CryptoGatewayInterface,
+	pixGateway PixGatewayInterface,
+	pixExpiration time.Duration,
 	eventBus domain.EventBus,
 ) *PaymentService {
 	return &PaymentService{
@@ -36,8 +42,11 @@ func NewPaymentService(
 		subscriptionRepo: subscriptionRepo,
 		invoiceRepo:      invoiceRepo,
 		customerRepo:     customerRepo,
+		dunningRepo:      dunningRepo,
 		asaasGateway:     asaasGateway,
 		cryptoGateway:    cryptoGateway,
+		pixGateway:       pixGateway,
+		pixExpiration:    pixExpiration,
 		eventBus:         eventBus,
 	}
 }
@@ -119,6 +128,8 @@ func (s *PaymentService) processPaymentViaGateway(ctx context.Context, payment *
 
 	if payment.IsCrypto() {
 		return s.processCryptoPayment(ctx, payment, customer)
+	} else if payment.IsPix() && s.pixGateway != nil {
+		return s.processPixPayment(ctx, payment, customer)
 	} else {
 		return s.processAsaasPayment(ctx, payment, customer)
 	}
@@ -338,6 +349,37 @@ func (s *PaymentService) markInvoiceAsPaid(ctx context.Context, invoiceID, payme
 		return fmt.Errorf("failed to update invoice: %w", err)
 	}
 
+	// Publicar evento (dispara a emissão da NFS-e)
+	event := domain.NewInvoicePaidEvent(
+		invoice.TenantID,
+		invoice.ID,
+		invoice.SubscriptionID,
+		paymentID,
+		invoice.Number,
+		invoice.TotalAmount,
+		*invoice.PaidAt,
+	)
+
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		// Log erro mas não falha o processamento
+	}
+
+	return nil
+}
+
+// markInvoiceAsRefunded marca fatura como reembolsada
+func (s *PaymentService) markInvoiceAsRefunded(ctx context.Context, invoiceID uuid.UUID) error {
+	invoice, err := s.invoiceRepo.GetByID(ctx, invoiceID)
+	if err != nil {
+		return fmt.Errorf("failed to get invoice: %w", err)
+	}
+
+	invoice.MarkAsRefunded()
+
+	if err := s.invoiceRepo.Update(ctx, invoice); err != nil {
+		return fmt.Errorf("failed to update invoice: %w", err)
+	}
+
 	return nil
 }
 
@@ -350,6 +392,11 @@ func (s *PaymentService) RetryFailedPayments(ctx context.Context) error {
 	}
 
 	for _, payment := range failedPayments {
+		// Assinaturas em régua de cobrança são retentadas pelo DunningService
+		if s.inOpenDunning(ctx, payment) {
+			continue
+		}
+
 		if payment.CanRetry() {
 			// Tentar processar novamente
 			if err := s.processPaymentViaGateway(ctx, payment); err != nil {
@@ -369,6 +416,40 @@ func (s *PaymentService) RetryFailedPayments(ctx context.Context) error {
 	return nil
 }
 
+// inOpenDunning verifica se o pagamento pertence a uma régua de cobrança em andamento
+func (s *PaymentService) inOpenDunning(ctx context.Context, payment *domain.Payment) bool {
+	if s.dunningRepo == nil {
+		return false
+	}
+
+	dunning, err := s.dunningRepo.GetOpenBySubscriptionID(ctx, payment.SubscriptionID)
+	return err == nil && dunning != nil && dunning.IsOpen()
+}
+
+// RetryPayment tenta cobrar novamente um pagamento específico, fora do backoff padrão
+func (s *PaymentService) RetryPayment(ctx context.Context, paymentID uuid.UUID) error {
+	payment, err := s.paymentRepo.GetByID(ctx, paymentID)
+	if err != nil {
+		return fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	if payment.IsSuccessful() {
+		return errors.New("payment is already successful")
+	}
+
+	// Preparar nova tentativa
+	payment.ResetForRetry()
+
+	// Processar novamente via gateway
+	if err := s.processPaymentViaGateway(ctx, payment); err != nil {
+		payment.MarkAsFailed(err.Error())
+		s.paymentRepo.Update(ctx, payment)
+		return fmt.Errorf("failed to retry payment: %w", err)
+	}
+
+	return nil
+}
+
 // RefundPayment processa reembolso de pagamento
 func (s *PaymentService) RefundPayment(ctx context.Context, paymentID uuid.UUID, refundAmount int64, reason string) error {
 	payment, err := s.paymentRepo.GetByID(ctx, paymentID)
@@ -386,6 +467,13 @@ func (s *PaymentService) RefundPayment(ctx context.Context, paymentID uuid.UUID,
 		return errors.New("crypto payments cannot be refunded automatically")
 	}
 
+	// Processar devolução PIX
+	if payment.IsPix() && payment.PixEndToEndID != nil && s.pixGateway != nil {
+		if err := s.pixGateway.RefundPix(ctx, *payment.PixEndToEndID, payment.ID.String(), refundAmount); err != nil {
+			return fmt.Errorf("failed to refund PIX payment: %w", err)
+		}
+	}
+
 	// Processar reembolso via ASAAS
 	if payment.AsaasPaymentID != nil {
 		if err := s.asaasGateway.RefundPayment(ctx, *payment.AsaasPaymentID, refundAmount); err != nil {
@@ -401,6 +489,13 @@ func (s *PaymentService) RefundPayment(ctx context.Context, paymentID uuid.UUID,
 		return fmt.Errorf("failed to update payment: %w", err)
 	}
 
+	// Reembolso total estorna a fatura
+	if payment.InvoiceID != nil && refundAmount >= payment.Amount {
+		if err := s.markInvoiceAsRefunded(ctx, *payment.InvoiceID); err != nil {
+			// Log erro mas não falha o processamento
+		}
+	}
+
 	// Publicar evento
 	event := domain.NewPaymentRefundedEvent(
 		payment.TenantID,
@@ -0,0 +1,216 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/direito-lux/billing-service/internal/domain"
+)
+
+// Status da cobrança imediata na API Pix
+const (
+	PixChargeStatusActive    = "ATIVA"
+	PixChargeStatusCompleted = "CONCLUIDA"
+	PixChargeStatusRemoved   = "REMOVIDA_PELO_USUARIO_RECEBEDOR"
+)
+
+// ErrPixChargeNotCompleted cobrança PIX ainda não consta como paga no PSP
+var ErrPixChargeNotCompleted = errors.New("pix charge is not completed at the PSP")
+
+// processPixPayment cria a cobrança PIX dinâmica com QR Code de uso único
+func (s *PaymentService) processPixPayment(ctx context.Context, payment *domain.Payment, customer *domain.Customer) error {
+	request := &PixChargeRequest{
+		TxID:          domain.NewPixTxID(payment.ID, payment.RetryCount),
+		Amount:        payment.Amount,
+		Expiration:    s.pixExpiration,
+		PayerName:     customer.Name,
+		PayerDocument: customer.Document,
+		Description:   fmt.Sprintf("Assinatura Direito Lux - %s", payment.ID.String()[:8]),
+	}
+
+	charge, err := s.pixGateway.CreatePixCharge(ctx, request)
+	if err != nil {
+		return fmt.Errorf("failed to create PIX charge: %w", err)
+	}
+
+	if !domain.ValidatePixBRCode(charge.BRCode) {
+		return errors.New("PSP returned an invalid PIX BR Code")
+	}
+
+	payment.SetPixCharge(charge.TxID, charge.BRCode, charge.QRCodeImage, charge.ExpiresAt)
+
+	// Salvar alterações
+	if err := s.paymentRepo.Update(ctx, payment); err != nil {
+		return fmt.Errorf("failed to update payment: %w", err)
+	}
+
+	return nil
+}
+
+// PixReceivedCommand comando de confirmação de PIX recebido (webhook do PSP)
+type PixReceivedCommand struct {
+	TxID       string    `json:"txid"`
+	EndToEndID string    `json:"end_to_end_id"`
+	Amount     int64     `json:"amount"` // em centavos; informativo, a conciliação usa o valor do PSP
+	PaidAt     time.Time `json:"paid_at"`
+}
+
+// ProcessPixReceived confirma um PIX recebido e concilia o valor com a cobrança.
+// O valor e o E2E conciliados são os informados pelo PSP na consulta da cobrança, não os do
+// webhook, e cada pagamento é conciliado uma única vez.
+func (s *PaymentService) ProcessPixReceived(ctx context.Context, cmd PixReceivedCommand) (domain.PixReconciliationResult, error) {
+	payment, err := s.paymentRepo.GetByPixTxID(ctx, cmd.TxID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	// Pagamento já conciliado: webhook repetido, ainda que com outro E2E
+	if result, done := pixReconciled(payment); done {
+		return result, nil
+	}
+
+	// Confirmar no PSP antes de liberar a assinatura
+	charge, err := s.pixGateway.GetPixCharge(ctx, cmd.TxID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get PIX charge: %w", err)
+	}
+
+	if charge.Status != PixChargeStatusCompleted {
+		return "", ErrPixChargeNotCompleted
+	}
+
+	payment.RecordPixReceipt(charge.EndToEndID, charge.PaidAmount)
+
+	result := domain.ReconcilePixAmount(payment.Amount, charge.PaidAmount)
+
+	switch result {
+	case domain.PixReconciliationUnderpaid:
+		// Valor insuficiente não libera a assinatura
+		payment.MarkAsPartial(charge.PaidAmount)
+
+		if err := s.paymentRepo.Update(ctx, payment); err != nil {
+			return "", fmt.Errorf("failed to update payment: %w", err)
+		}
+	default:
+		if err := s.paymentRepo.Update(ctx, payment); err != nil {
+			return "", fmt.Errorf("failed to update payment: %w", err)
+		}
+
+		if err := s.ProcessPaymentSuccess(ctx, payment.ID, charge.EndToEndID); err != nil {
+			return "", err
+		}
+	}
+
+	if result != domain.PixReconciliationMatched {
+		event := domain.NewPaymentAmountMismatchEvent(
+			payment.TenantID,
+			payment.ID,
+			payment.SubscriptionID,
+			payment.Amount,
+			charge.PaidAmount,
+			result,
+			charge.EndToEndID,
+		)
+
+		if err := s.eventBus.Publish(ctx, event); err != nil {
+			// Log erro mas não falha o processamento
+		}
+	}
+
+	return result, nil
+}
+
+// pixReconciled retorna a conciliação de um pagamento PIX já processado. Um pagamento com o
+// recebimento registrado mas ainda pendente não conta como conciliado, para que uma falha no
+// meio do processamento seja retomada no próximo webhook.
+func pixReconciled(payment *domain.Payment) (domain.PixReconciliationResult, bool) {
+	if !payment.IsSuccessful() && payment.Status != domain.PaymentStatusPartial {
+		return "", false
+	}
+
+	if payment.ReceivedAmount == nil {
+		return domain.PixReconciliationMatched, true
+	}
+	return domain.ReconcilePixAmount(payment.Amount, *payment.ReceivedAmount), true
+}
+
+// ExpirePixPayments expira cobranças PIX cujo QR Code venceu sem pagamento
+func (s *PaymentService) ExpirePixPayments(ctx context.Context, limit int) (int, error) {
+	now := time.Now()
+
+	payments, err := s.paymentRepo.GetExpiredPix(ctx, now, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get expired PIX payments: %w", err)
+	}
+
+	expired := 0
+	for _, payment := range payments {
+		if !payment.IsExpired() {
+			continue
+		}
+
+		// Remover a cobrança no PSP para impedir pagamento tardio
+		if payment.PixTxID != nil {
+			if err := s.pixGateway.CancelPixCharge(ctx, *payment.PixTxID); err != nil {
+				// Log erro mas continua: a cobrança já expirou no PSP
+			}
+		}
+
+		payment.ExpirePayment()
+
+		if err := s.paymentRepo.Update(ctx, payment); err != nil {
+			// Log erro mas continua processando
+			continue
+		}
+
+		event := domain.NewPaymentExpiredEvent(
+			payment.TenantID,
+			payment.ID,
+			payment.SubscriptionID,
+			payment.Amount,
+			payment.PaymentMethod,
+			now,
+		)
+
+		if err := s.eventBus.Publish(ctx, event); err != nil {
+			// Log erro mas não falha o processamento
+		}
+
+		expired++
+	}
+
+	return expired, nil
+}
+
+// PixGatewayInterface interface para PSP com API Pix (cobranças imediatas)
+type PixGatewayInterface interface {
+	CreatePixCharge(ctx context.Context, request *PixChargeRequest) (*PixChargeResponse, error)
+	GetPixCharge(ctx context.Context, txID string) (*PixChargeResponse, error)
+	CancelPixCharge(ctx context.Context, txID string) error
+	RefundPix(ctx context.Context, endToEndID, refundID string, amount int64) error
+}
+
+// PixChargeRequest requisição de cobrança PIX
+type PixChargeRequest struct {
+	TxID          string        `json:"txid"`
+	Amount        int64         `json:"amount"` // em centavos
+	Expiration    time.Duration `json:"expiration"`
+	PayerName     string        `json:"payer_name"`
+	PayerDocument string        `json:"payer_document"`
+	Description   string        `json:"description"`
+}
+
+// PixChargeResponse resposta de cobrança PIX
+type PixChargeResponse struct {
+	TxID        string     `json:"txid"`
+	Status      string     `json:"status"`
+	Location    string     `json:"location"`
+	BRCode      string     `json:"br_code"`
+	QRCodeImage string     `json:"qr_code_image"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	PaidAmount  int64      `json:"paid_amount"`
+	EndToEndID  string     `json:"end_to_end_id"`
+	PaidAt      *time.Time `json:"paid_at"`
+}
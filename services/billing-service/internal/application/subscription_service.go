@@ -16,6 +16,7 @@ type SubscriptionService struct {
 	planRepo         domain.PlanRepository
 	customerRepo     domain.CustomerRepository
 	paymentGateway   PaymentGatewayInterface
+	usageBilling     *UsageBillingService
 	eventBus         domain.EventBus
 }
 
@@ -25,6 +26,7 @@ func NewSubscriptionService(
 	planRepo domain.PlanRepository,
 	customerRepo domain.CustomerRepository,
 	paymentGateway PaymentGatewayInterface,
+	usageBilling *UsageBillingService,
 	eventBus domain.EventBus,
 ) *SubscriptionService {
 	return &SubscriptionService{
@@ -32,6 +34,7 @@ func NewSubscriptionService(
 		planRepo:         planRepo,
 		customerRepo:     customerRepo,
 		paymentGateway:   paymentGateway,
+		usageBilling:     usageBilling,
 		eventBus:         eventBus,
 	}
 }
@@ -266,7 +269,20 @@ func (s *SubscriptionService) ChangeSubscriptionPlan(ctx context.Context, subscr
 	}
 
 	// Se há diferença de valor, criar cobrança/crédito
-	if newAmount > remainingValue {
+	if newRemainingValue := subscription.GetRemainingValue(); remainingValue > newRemainingValue {
+		// Downgrade: o valor não utilizado do plano anterior vira crédito do cliente
+		event := domain.NewCreditGrantedEvent(
+			subscription.TenantID,
+			subscription.ID,
+			remainingValue-newRemainingValue,
+			"Crédito de prorrata por mudança de plano",
+			time.Now(),
+		)
+
+		if err := s.eventBus.Publish(ctx, event); err != nil {
+			// Log erro mas não falha o processamento
+		}
+	} else if newAmount > remainingValue {
 		// Criar cobrança complementar
 		additionalAmount := newAmount - remainingValue
 		// TODO: implementar cobrança complementar
@@ -404,11 +420,24 @@ func (s *SubscriptionService) processBilling(ctx context.Context, subscription *
 		return fmt.Errorf("customer not found for tenant: %s", subscription.TenantID)
 	}
 
+	// Fatura do período com o excedente de uso pendente; cobra o total com ISS e descontos
+	amount := subscription.Amount
+	var invoiceID *uuid.UUID
+	if s.usageBilling != nil {
+		invoice, err := s.usageBilling.CreateCycleInvoice(ctx, subscription)
+		if err != nil {
+			return fmt.Errorf("failed to create cycle invoice: %w", err)
+		}
+		amount = invoice.TotalAmount
+		invoiceID = &invoice.ID
+	}
+
 	// Criar cobrança via gateway
 	paymentRequest := &PaymentRequest{
 		SubscriptionID: subscription.ID,
 		TenantID:       subscription.TenantID,
-		Amount:         subscription.Amount,
+		InvoiceID:      invoiceID,
+		Amount:         amount,
 		PaymentMethod:  subscription.PaymentMethod,
 		CustomerData:   customer[0],
 	}
@@ -431,7 +460,7 @@ func (s *SubscriptionService) processBilling(ctx context.Context, subscription *
 		subscription.TenantID,
 		payment.ID,
 		subscription.ID,
-		subscription.Amount,
+		amount,
 		subscription.PaymentMethod,
 		"BRL",
 		payment.DueDate,
@@ -456,6 +485,7 @@ type PaymentGatewayInterface interface {
 type PaymentRequest struct {
 	SubscriptionID uuid.UUID           `json:"subscription_id"`
 	TenantID       uuid.UUID           `json:"tenant_id"`
+	InvoiceID      *uuid.UUID          `json:"invoice_id"` // gravado no Payment criado: a baixa quita a fatura
 	Amount         int64               `json:"amount"`
 	PaymentMethod  domain.PaymentMethod `json:"payment_method"`
 	CustomerData   *domain.Customer    `json:"customer_data"`
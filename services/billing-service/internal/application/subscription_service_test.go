@@ -0,0 +1,97 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/direito-lux/billing-service/internal/domain"
+)
+
+// fakeSubscriptionRepo repositório de assinaturas que aceita as atualizações
+type fakeSubscriptionRepo struct {
+	domain.SubscriptionRepository
+}
+
+func (r *fakeSubscriptionRepo) Update(ctx context.Context, subscription *domain.Subscription) error {
+	return nil
+}
+
+// fakeCustomerRepo repositório com um único cliente
+type fakeCustomerRepo struct {
+	domain.CustomerRepository
+	customer *domain.Customer
+}
+
+func (r *fakeCustomerRepo) GetByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*domain.Customer, error) {
+	return []*domain.Customer{r.customer}, nil
+}
+
+// fakeOverageRepo tenant sem cobrança por excedente configurada
+type fakeOverageRepo struct {
+	domain.OverageSettingsRepository
+}
+
+func (r *fakeOverageRepo) GetByTenantID(ctx context.Context, tenantID uuid.UUID) (*domain.OverageSettings, error) {
+	return nil, errors.New("overage settings not found")
+}
+
+// fakeUsageChargeRepo cobranças de uso pendentes em memória
+type fakeUsageChargeRepo struct {
+	domain.UsageChargeRepository
+	pending []*domain.UsageCharge
+}
+
+func (r *fakeUsageChargeRepo) GetPendingByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*domain.UsageCharge, error) {
+	return r.pending, nil
+}
+
+// fakePaymentGateway gateway que registra as cobranças e grava a fatura no pagamento
+type fakePaymentGateway struct {
+	PaymentGatewayInterface
+	requests []*PaymentRequest
+}
+
+func (g *fakePaymentGateway) CreatePayment(ctx context.Context, request *PaymentRequest) (*domain.Payment, error) {
+	g.requests = append(g.requests, request)
+	payment := domain.NewPayment(request.SubscriptionID, request.TenantID, request.Amount, request.PaymentMethod, "BRL")
+	payment.InvoiceID = request.InvoiceID
+	return payment, nil
+}
+
+func TestSubscriptionServiceProcessBillingChargesInvoiceTotal(t *testing.T) {
+	subscription := domain.NewSubscription(uuid.New(), uuid.New(), domain.BillingCycleMonthly, 19990, domain.PaymentMethodPix)
+	subscription.CurrentPeriodStart = time.Now().AddDate(0, -1, 0)
+	subscription.CurrentPeriodEnd = time.Now()
+
+	invoices := &fakeInvoiceRepo{invoices: make(map[uuid.UUID]*domain.Invoice)}
+	customers := &fakeCustomerRepo{customer: &domain.Customer{TenantID: subscription.TenantID, Name: "Silva Advogados"}}
+	usageBilling := NewUsageBillingService(&fakeUsageChargeRepo{}, &fakeOverageRepo{}, nil, nil, customers,
+		invoices, nil, nil, &fakeEventBus{}, 0)
+	gateway := &fakePaymentGateway{}
+	service := NewSubscriptionService(&fakeSubscriptionRepo{}, nil, customers, gateway, usageBilling, &fakeEventBus{})
+
+	if err := service.processBilling(context.Background(), subscription); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(invoices.invoices) != 1 || len(gateway.requests) != 1 {
+		t.Fatalf("expected one invoice and one charge, got %d and %d", len(invoices.invoices), len(gateway.requests))
+	}
+	var invoice *domain.Invoice
+	for _, created := range invoices.invoices {
+		invoice = created
+	}
+
+	// Cobra o total com ISS, e o pagamento fica vinculado à fatura para a baixa
+	request := gateway.requests[0]
+	if invoice.TaxAmount == 0 || request.Amount != invoice.TotalAmount {
+		t.Errorf("expected charge of invoice total %d, got %d", invoice.TotalAmount, request.Amount)
+	}
+	if request.InvoiceID == nil || *request.InvoiceID != invoice.ID {
+		t.Errorf("expected charge linked to invoice %s, got %v", invoice.ID, request.InvoiceID)
+	}
+}
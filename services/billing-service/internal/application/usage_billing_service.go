@@ -0,0 +1,347 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/direito-lux/billing-service/internal/domain"
+)
+
+// UsageBillingService serviço de aplicação para cobrança por excedente de quotas
+type UsageBillingService struct {
+	usageChargeRepo    domain.UsageChargeRepository
+	overageRepo        domain.OverageSettingsRepository
+	subscriptionRepo   domain.SubscriptionRepository
+	planRepo           domain.PlanRepository
+	customerRepo       domain.CustomerRepository
+	invoiceRepo        domain.InvoiceRepository
+	usageGateway       UsageGatewayInterface
+	taxApplier         InvoiceTaxApplier
+	eventBus           domain.EventBus
+	defaultSpendingCap int64
+}
+
+// NewUsageBillingService cria um novo serviço de cobrança por excedente
+func NewUsageBillingService(
+	usageChargeRepo domain.UsageChargeRepository,
+	overageRepo domain.OverageSettingsRepository,
+	subscriptionRepo domain.SubscriptionRepository,
+	planRepo domain.PlanRepository,
+	customerRepo domain.CustomerRepository,
+	invoiceRepo domain.InvoiceRepository,
+	usageGateway UsageGatewayInterface,
+	taxApplier InvoiceTaxApplier,
+	eventBus domain.EventBus,
+	defaultSpendingCap int64,
+) *UsageBillingService {
+	return &UsageBillingService{
+		usageChargeRepo:    usageChargeRepo,
+		overageRepo:        overageRepo,
+		subscriptionRepo:   subscriptionRepo,
+		planRepo:           planRepo,
+		customerRepo:       customerRepo,
+		invoiceRepo:        invoiceRepo,
+		usageGateway:       usageGateway,
+		taxApplier:         taxApplier,
+		eventBus:           eventBus,
+		defaultSpendingCap: defaultSpendingCap,
+	}
+}
+
+// ConfigureOverageCommand comando para configurar a cobrança por excedente
+type ConfigureOverageCommand struct {
+	TenantID    uuid.UUID `json:"tenant_id"`
+	Enabled     bool      `json:"enabled"`
+	SpendingCap *int64    `json:"spending_cap"` // em centavos; nil usa o teto padrão, zero remove o teto
+}
+
+// ConfigureOverage ativa ou desativa a cobrança por excedente do tenant
+func (s *UsageBillingService) ConfigureOverage(ctx context.Context, cmd ConfigureOverageCommand) (*domain.OverageSettings, error) {
+	settings, err := s.overageRepo.GetByTenantID(ctx, cmd.TenantID)
+	if err != nil || settings == nil {
+		settings = domain.NewOverageSettings(cmd.TenantID)
+	}
+
+	if cmd.Enabled && !settings.Enabled {
+		// Ao ativar, o uso anterior não é cobrado retroativamente
+		snapshot, err := s.usageGateway.GetOverageUsage(ctx, cmd.TenantID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tenant usage: %w", err)
+		}
+		settings.Advance(snapshot)
+	}
+
+	spendingCap := s.defaultSpendingCap
+	if cmd.SpendingCap != nil {
+		spendingCap = *cmd.SpendingCap
+	}
+
+	if err := settings.Configure(cmd.Enabled, spendingCap); err != nil {
+		return nil, err
+	}
+
+	// Novo teto pode liberar ou bloquear o período atual
+	if cmd.Enabled {
+		if subscription, err := s.subscriptionRepo.GetCurrentByTenantID(ctx, cmd.TenantID); err == nil {
+			periodTotal, err := s.usageChargeRepo.SumByPeriod(ctx, cmd.TenantID, subscription.CurrentPeriodStart)
+			if err != nil {
+				return nil, fmt.Errorf("failed to sum usage charges: %w", err)
+			}
+
+			if settings.CapReached(periodTotal) {
+				settings.Block()
+			} else {
+				settings.Unblock()
+			}
+		}
+	}
+
+	if err := s.usageGateway.SetOverageMode(ctx, cmd.TenantID, settings.Enabled, settings.Blocked); err != nil {
+		return nil, fmt.Errorf("failed to update tenant overage mode: %w", err)
+	}
+
+	if err := s.overageRepo.Save(ctx, settings); err != nil {
+		return nil, fmt.Errorf("failed to save overage settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// GetOverageSettings retorna a configuração de excedente do tenant
+func (s *UsageBillingService) GetOverageSettings(ctx context.Context, tenantID uuid.UUID) (*domain.OverageSettings, error) {
+	return s.overageRepo.GetByTenantID(ctx, tenantID)
+}
+
+// SyncAllUsage tarifa o excedente de todos os tenants com cobrança por uso ativa
+func (s *UsageBillingService) SyncAllUsage(ctx context.Context) error {
+	settingsList, err := s.overageRepo.GetEnabled(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get overage settings: %w", err)
+	}
+
+	for _, settings := range settingsList {
+		if _, err := s.syncUsage(ctx, settings); err != nil {
+			// Log erro mas continua processando
+			continue
+		}
+	}
+
+	return nil
+}
+
+// SyncUsage tarifa o excedente do tenant desde a última sincronização
+func (s *UsageBillingService) SyncUsage(ctx context.Context, tenantID uuid.UUID) ([]*domain.UsageCharge, error) {
+	settings, err := s.overageRepo.GetByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get overage settings: %w", err)
+	}
+
+	return s.syncUsage(ctx, settings)
+}
+
+// syncUsage consome o snapshot de quotas do tenant-service e gera as cobranças
+func (s *UsageBillingService) syncUsage(ctx context.Context, settings *domain.OverageSettings) ([]*domain.UsageCharge, error) {
+	if !settings.Enabled {
+		return nil, nil
+	}
+
+	subscription, err := s.subscriptionRepo.GetCurrentByTenantID(ctx, settings.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscription: %w", err)
+	}
+
+	plan, err := s.planRepo.GetByID(ctx, subscription.PlanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get plan: %w", err)
+	}
+
+	snapshot, err := s.usageGateway.GetOverageUsage(ctx, settings.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant usage: %w", err)
+	}
+
+	periodTotal, err := s.usageChargeRepo.SumByPeriod(ctx, settings.TenantID, subscription.CurrentPeriodStart)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum usage charges: %w", err)
+	}
+
+	delta := settings.Delta(snapshot)
+
+	var charges []*domain.UsageCharge
+	for _, metric := range []domain.UsageMetric{domain.UsageMetricDataJudQueries, domain.UsageMetricAIQueries} {
+		quantity := delta[metric]
+		unitPrice := plan.GetOveragePrice(metric)
+
+		billable := domain.RateUsage(quantity, unitPrice, settings.RemainingCap(periodTotal))
+		if billable == 0 {
+			continue
+		}
+
+		charge := domain.NewUsageCharge(
+			settings.TenantID,
+			subscription.ID,
+			metric,
+			billable,
+			unitPrice,
+			subscription.CurrentPeriodStart,
+			subscription.CurrentPeriodEnd,
+		)
+
+		if err := s.usageChargeRepo.Create(ctx, charge); err != nil {
+			return charges, fmt.Errorf("failed to create usage charge: %w", err)
+		}
+
+		periodTotal += charge.Amount
+		charges = append(charges, charge)
+
+		event := domain.NewUsageChargedEvent(
+			charge.TenantID,
+			charge.ID,
+			subscription.ID,
+			metric,
+			charge.Quantity,
+			charge.UnitPrice,
+			charge.Amount,
+			periodTotal,
+		)
+
+		if err := s.eventBus.Publish(ctx, event); err != nil {
+			// Log erro mas não falha o processamento
+		}
+	}
+
+	settings.Advance(snapshot)
+
+	// Teto atingido: volta a bloquear até o próximo período
+	if !settings.Blocked && settings.CapReached(periodTotal) {
+		if err := s.usageGateway.SetOverageMode(ctx, settings.TenantID, true, true); err != nil {
+			return charges, fmt.Errorf("failed to block tenant overage: %w", err)
+		}
+
+		settings.Block()
+
+		event := domain.NewUsageCapReachedEvent(
+			settings.TenantID,
+			subscription.ID,
+			settings.SpendingCap,
+			periodTotal,
+			subscription.CurrentPeriodEnd,
+		)
+
+		if err := s.eventBus.Publish(ctx, event); err != nil {
+			// Log erro mas não falha o processamento
+		}
+	}
+
+	if err := s.overageRepo.Save(ctx, settings); err != nil {
+		return charges, fmt.Errorf("failed to save overage settings: %w", err)
+	}
+
+	return charges, nil
+}
+
+// CreateCycleInvoice cria a fatura do período com a assinatura e o excedente pendente
+func (s *UsageBillingService) CreateCycleInvoice(ctx context.Context, subscription *domain.Subscription) (*domain.Invoice, error) {
+	settings, err := s.overageRepo.GetByTenantID(ctx, subscription.TenantID)
+	if err == nil && settings != nil && settings.Enabled {
+		// Captura o uso até o fechamento do período
+		if _, err := s.syncUsage(ctx, settings); err != nil {
+			// Log erro mas não falha: o restante entra na próxima fatura
+		}
+	}
+
+	invoice := domain.NewInvoice(
+		subscription.ID,
+		subscription.TenantID,
+		subscription.Amount,
+		subscription.CurrentPeriodStart,
+		subscription.CurrentPeriodEnd,
+	)
+
+	customers, err := s.customerRepo.GetByTenantID(ctx, subscription.TenantID)
+	if err != nil || len(customers) == 0 {
+		return nil, fmt.Errorf("customer not found for tenant: %s", subscription.TenantID)
+	}
+
+	customer := customers[0]
+	invoice.SetCustomerData(customer.Name, customer.Email, customer.Document, customer.Phone, customer.Address)
+
+	charges, err := s.usageChargeRepo.GetPendingByTenantID(ctx, subscription.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending usage charges: %w", err)
+	}
+
+	for _, charge := range charges {
+		invoice.AddItem(charge.ToInvoiceItem())
+	}
+
+	// ISS do município emissor, antes da cobrança: a NFS-e é emitida com os mesmos valores
+	if s.taxApplier != nil {
+		s.taxApplier.ApplyMunicipalTax(invoice)
+	}
+
+	invoice.GenerateNumber()
+	invoice.Issue()
+
+	if err := s.invoiceRepo.Create(ctx, invoice); err != nil {
+		return nil, fmt.Errorf("failed to create invoice: %w", err)
+	}
+
+	for _, charge := range charges {
+		charge.MarkAsInvoiced(invoice.ID)
+
+		if err := s.usageChargeRepo.Update(ctx, charge); err != nil {
+			return nil, fmt.Errorf("failed to update usage charge: %w", err)
+		}
+	}
+
+	// Novo período: libera o excedente bloqueado pelo teto
+	if settings != nil && settings.Blocked {
+		if err := s.unblock(ctx, settings); err != nil {
+			// Log erro mas não falha o faturamento
+		}
+	}
+
+	event := domain.NewInvoiceCreatedEvent(
+		invoice.TenantID,
+		invoice.ID,
+		invoice.SubscriptionID,
+		invoice.Number,
+		invoice.TotalAmount,
+		invoice.DueDate,
+		invoice.CustomerEmail,
+	)
+
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		// Log erro mas não falha o processamento
+	}
+
+	return invoice, nil
+}
+
+// unblock libera o excedente do tenant
+func (s *UsageBillingService) unblock(ctx context.Context, settings *domain.OverageSettings) error {
+	if !settings.Enabled {
+		return errors.New("overage billing is disabled")
+	}
+
+	if err := s.usageGateway.SetOverageMode(ctx, settings.TenantID, true, false); err != nil {
+		return fmt.Errorf("failed to unblock tenant overage: %w", err)
+	}
+
+	settings.Unblock()
+
+	if err := s.overageRepo.Save(ctx, settings); err != nil {
+		return fmt.Errorf("failed to save overage settings: %w", err)
+	}
+
+	return nil
+}
+
+// UsageGatewayInterface interface para consulta de uso e controle de excedente no tenant-service
+type UsageGatewayInterface interface {
+	GetOverageUsage(ctx context.Context, tenantID uuid.UUID) (*domain.UsageSnapshot, error)
+	SetOverageMode(ctx context.Context, tenantID uuid.UUID, enabled, blocked bool) error
+}
@@ -0,0 +1,226 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DunningPolicy política de cobrança de inadimplência (régua de cobrança)
+type DunningPolicy struct {
+	Steps           []DunningStep `json:"steps"`
+	GracePeriodDays int           `json:"grace_period_days"`
+}
+
+// DunningStep etapa da régua de cobrança
+type DunningStep struct {
+	DayOffset    int      `json:"day_offset"`    // dias após a primeira falha (D+N)
+	RetryPayment bool     `json:"retry_payment"` // tentar cobrar novamente nesta etapa
+	Channels     []string `json:"channels"`      // email, whatsapp
+}
+
+// DunningStatus enumeração dos status do processo de cobrança
+type DunningStatus string
+
+const (
+	DunningStatusActive      DunningStatus = "active"
+	DunningStatusGracePeriod DunningStatus = "grace_period"
+	DunningStatusSuspended   DunningStatus = "suspended"
+	DunningStatusRecovered   DunningStatus = "recovered"
+	DunningStatusCancelled   DunningStatus = "cancelled"
+)
+
+// DefaultDunningPolicy retorna a régua padrão: D+1, D+3 e D+7 com 3 dias de carência
+func DefaultDunningPolicy() DunningPolicy {
+	policy, _ := NewDunningPolicy([]int{1, 3, 7}, []string{"email", "whatsapp"}, 3)
+	return policy
+}
+
+// NewDunningPolicy cria uma política com retentativas nos dias informados
+func NewDunningPolicy(retryDays []int, channels []string, gracePeriodDays int) (DunningPolicy, error) {
+	if len(retryDays) == 0 {
+		return DunningPolicy{}, errors.New("dunning policy requires at least one step")
+	}
+
+	if gracePeriodDays < 0 {
+		return DunningPolicy{}, errors.New("grace period cannot be negative")
+	}
+
+	days := make([]int, len(retryDays))
+	copy(days, retryDays)
+	sort.Ints(days)
+
+	steps := make([]DunningStep, 0, len(days))
+	for i, day := range days {
+		if day <= 0 {
+			return DunningPolicy{}, errors.New("dunning step day offset must be positive")
+		}
+		if i > 0 && day == days[i-1] {
+			return DunningPolicy{}, errors.New("dunning step day offsets must be unique")
+		}
+
+		steps = append(steps, DunningStep{
+			DayOffset:    day,
+			RetryPayment: true,
+			Channels:     channels,
+		})
+	}
+
+	return DunningPolicy{
+		Steps:           steps,
+		GracePeriodDays: gracePeriodDays,
+	}, nil
+}
+
+// GetStep retorna a etapa pelo índice
+func (p DunningPolicy) GetStep(index int) (DunningStep, bool) {
+	if index < 0 || index >= len(p.Steps) {
+		return DunningStep{}, false
+	}
+	return p.Steps[index], true
+}
+
+// DunningCase representa o processo de cobrança de uma assinatura inadimplente
+type DunningCase struct {
+	ID             uuid.UUID     `json:"id"`
+	SubscriptionID uuid.UUID     `json:"subscription_id"`
+	TenantID       uuid.UUID     `json:"tenant_id"`
+	PaymentID      uuid.UUID     `json:"payment_id"`
+	Status         DunningStatus `json:"status"`
+
+	// Progresso na régua
+	CurrentStep       int        `json:"current_step"` // etapas já executadas
+	StartedAt         time.Time  `json:"started_at"`   // data da primeira falha
+	NextActionAt      *time.Time `json:"next_action_at"`
+	LastAttemptAt     *time.Time `json:"last_attempt_at"`
+	LastFailureReason *string    `json:"last_failure_reason"`
+
+	// Desfecho
+	GraceEndsAt       *time.Time `json:"grace_ends_at"`
+	SuspendedAt       *time.Time `json:"suspended_at"`
+	RecoveredAt       *time.Time `json:"recovered_at"`
+	RecoveryPaymentID *uuid.UUID `json:"recovery_payment_id"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewDunningCase inicia um processo de cobrança a partir de uma falha de pagamento
+func NewDunningCase(subscriptionID, tenantID, paymentID uuid.UUID, policy DunningPolicy, failureReason string, failedAt time.Time) *DunningCase {
+	now := time.Now()
+
+	dunning := &DunningCase{
+		ID:                uuid.New(),
+		SubscriptionID:    subscriptionID,
+		TenantID:          tenantID,
+		PaymentID:         paymentID,
+		Status:            DunningStatusActive,
+		CurrentStep:       0,
+		StartedAt:         failedAt,
+		LastFailureReason: &failureReason,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+
+	dunning.scheduleNext(policy)
+
+	return dunning
+}
+
+// scheduleNext agenda a próxima etapa ou o início da carência
+func (d *DunningCase) scheduleNext(policy DunningPolicy) {
+	if step, ok := policy.GetStep(d.CurrentStep); ok {
+		next := d.StartedAt.AddDate(0, 0, step.DayOffset)
+		d.NextActionAt = &next
+		return
+	}
+
+	d.NextActionAt = nil
+}
+
+// CurrentStepDefinition retorna a etapa pendente de execução
+func (d *DunningCase) CurrentStepDefinition(policy DunningPolicy) (DunningStep, bool) {
+	return policy.GetStep(d.CurrentStep)
+}
+
+// IsOpen verifica se o processo ainda está em andamento
+func (d *DunningCase) IsOpen() bool {
+	return d.Status == DunningStatusActive || d.Status == DunningStatusGracePeriod
+}
+
+// IsDue verifica se há ação pendente no momento informado
+func (d *DunningCase) IsDue(now time.Time) bool {
+	return d.IsOpen() && d.NextActionAt != nil && !now.Before(*d.NextActionAt)
+}
+
+// RecordFailure registra uma nova falha de pagamento
+func (d *DunningCase) RecordFailure(reason string) {
+	d.LastFailureReason = &reason
+	d.UpdatedAt = time.Now()
+}
+
+// AdvanceStep registra a execução da etapa atual e agenda a próxima.
+// Retorna true quando a régua terminou e o período de carência foi iniciado.
+func (d *DunningCase) AdvanceStep(policy DunningPolicy, now time.Time) bool {
+	d.CurrentStep++
+	d.LastAttemptAt = &now
+	d.UpdatedAt = now
+
+	if d.CurrentStep < len(policy.Steps) {
+		d.scheduleNext(policy)
+		return false
+	}
+
+	d.StartGracePeriod(policy, now)
+	return true
+}
+
+// StartGracePeriod inicia o período de carência após a última etapa
+func (d *DunningCase) StartGracePeriod(policy DunningPolicy, now time.Time) {
+	graceEnd := now.AddDate(0, 0, policy.GracePeriodDays)
+	d.Status = DunningStatusGracePeriod
+	d.GraceEndsAt = &graceEnd
+	d.NextActionAt = &graceEnd
+	d.UpdatedAt = now
+}
+
+// IsGraceExpired verifica se o período de carência terminou
+func (d *DunningCase) IsGraceExpired(now time.Time) bool {
+	return d.Status == DunningStatusGracePeriod && d.GraceEndsAt != nil && !now.Before(*d.GraceEndsAt)
+}
+
+// Suspend encerra a régua suspendendo a assinatura
+func (d *DunningCase) Suspend(now time.Time) {
+	d.Status = DunningStatusSuspended
+	d.SuspendedAt = &now
+	d.NextActionAt = nil
+	d.UpdatedAt = now
+}
+
+// Recover encerra a régua após pagamento bem-sucedido
+func (d *DunningCase) Recover(paymentID uuid.UUID, now time.Time) {
+	d.Status = DunningStatusRecovered
+	d.RecoveredAt = &now
+	d.RecoveryPaymentID = &paymentID
+	d.NextActionAt = nil
+	d.UpdatedAt = now
+}
+
+// Cancel encerra a régua sem desfecho de cobrança (ex.: assinatura cancelada)
+func (d *DunningCase) Cancel() {
+	d.Status = DunningStatusCancelled
+	d.NextActionAt = nil
+	d.UpdatedAt = time.Now()
+}
+
+// CanRecover verifica se um pagamento pode encerrar a régua
+func (d *DunningCase) CanRecover() bool {
+	return d.IsOpen() || d.Status == DunningStatusSuspended
+}
+
+// GetDaysInDunning retorna há quantos dias a assinatura está inadimplente
+func (d *DunningCase) GetDaysInDunning(now time.Time) int {
+	return int(now.Sub(d.StartedAt).Hours() / 24)
+}
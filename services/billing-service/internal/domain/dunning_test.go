@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func newTestDunningCase(t *testing.T, failedAt time.Time) (*DunningCase, DunningPolicy) {
+	t.Helper()
+
+	policy := DefaultDunningPolicy()
+	dunning := NewDunningCase(uuid.New(), uuid.New(), uuid.New(), policy, "card declined", failedAt)
+	return dunning, policy
+}
+
+func TestNewDunningPolicyValidation(t *testing.T) {
+	policy, err := NewDunningPolicy([]int{7, 1, 3}, []string{"email"}, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i, day := range []int{1, 3, 7} {
+		if policy.Steps[i].DayOffset != day {
+			t.Errorf("step %d: expected D+%d, got D+%d", i, day, policy.Steps[i].DayOffset)
+		}
+	}
+
+	invalid := map[string]struct {
+		days  []int
+		grace int
+	}{
+		"no steps":       {nil, 3},
+		"negative grace": {[]int{1}, -1},
+		"zero offset":    {[]int{0, 3}, 3},
+		"duplicated day": {[]int{3, 3}, 3},
+	}
+	for name, tc := range invalid {
+		if _, err := NewDunningPolicy(tc.days, nil, tc.grace); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestDunningCaseStepsUntilGracePeriod(t *testing.T) {
+	failedAt := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	dunning, policy := newTestDunningCase(t, failedAt)
+
+	if dunning.Status != DunningStatusActive || dunning.CurrentStep != 0 {
+		t.Fatalf("expected active case at step 0, got %s at step %d", dunning.Status, dunning.CurrentStep)
+	}
+	if !dunning.NextActionAt.Equal(failedAt.AddDate(0, 0, 1)) {
+		t.Fatalf("expected first action at D+1, got %v", dunning.NextActionAt)
+	}
+	if dunning.IsDue(failedAt) {
+		t.Error("expected case not to be due before D+1")
+	}
+	if !dunning.IsDue(failedAt.AddDate(0, 0, 1)) {
+		t.Error("expected case to be due at D+1")
+	}
+
+	// D+1 e D+3 agendam a próxima etapa
+	for _, next := range []int{3, 7} {
+		now := *dunning.NextActionAt
+		if dunning.AdvanceStep(policy, now) {
+			t.Fatalf("expected policy to continue after step %d", dunning.CurrentStep)
+		}
+		if !dunning.NextActionAt.Equal(failedAt.AddDate(0, 0, next)) {
+			t.Errorf("expected next action at D+%d, got %v", next, dunning.NextActionAt)
+		}
+		if dunning.LastAttemptAt == nil || !dunning.LastAttemptAt.Equal(now) {
+			t.Errorf("expected last attempt at %v, got %v", now, dunning.LastAttemptAt)
+		}
+	}
+
+	// D+7 encerra a régua e inicia a carência
+	lastStep := *dunning.NextActionAt
+	if !dunning.AdvanceStep(policy, lastStep) {
+		t.Fatal("expected policy to finish after the last step")
+	}
+
+	graceEnd := lastStep.AddDate(0, 0, policy.GracePeriodDays)
+	if dunning.Status != DunningStatusGracePeriod {
+		t.Fatalf("expected grace period, got %s", dunning.Status)
+	}
+	if !dunning.GraceEndsAt.Equal(graceEnd) || !dunning.NextActionAt.Equal(graceEnd) {
+		t.Errorf("expected grace to end at %v, got %v", graceEnd, dunning.GraceEndsAt)
+	}
+	if _, ok := dunning.CurrentStepDefinition(policy); ok {
+		t.Error("expected no pending step during grace period")
+	}
+	if !dunning.IsOpen() {
+		t.Error("expected case to remain open during grace period")
+	}
+	if dunning.IsGraceExpired(graceEnd.Add(-time.Second)) {
+		t.Error("expected grace not to be expired before its end")
+	}
+	if !dunning.IsGraceExpired(graceEnd) {
+		t.Error("expected grace to be expired at its end")
+	}
+	if got := dunning.GetDaysInDunning(graceEnd); got != 10 {
+		t.Errorf("expected 10 days in dunning, got %d", got)
+	}
+}
+
+func TestDunningCaseSuspendAndRecover(t *testing.T) {
+	now := time.Now()
+	dunning, policy := newTestDunningCase(t, now.AddDate(0, 0, -10))
+	dunning.StartGracePeriod(policy, now)
+
+	dunning.Suspend(now)
+	if dunning.Status != DunningStatusSuspended || dunning.SuspendedAt == nil {
+		t.Fatalf("expected suspended case, got %s", dunning.Status)
+	}
+	if dunning.IsOpen() || dunning.IsDue(now) || dunning.NextActionAt != nil {
+		t.Error("expected suspended case to have no pending action")
+	}
+	if !dunning.CanRecover() {
+		t.Fatal("expected suspended case to be recoverable")
+	}
+
+	paymentID := uuid.New()
+	dunning.Recover(paymentID, now)
+	if dunning.Status != DunningStatusRecovered {
+		t.Fatalf("expected recovered case, got %s", dunning.Status)
+	}
+	if dunning.RecoveryPaymentID == nil || *dunning.RecoveryPaymentID != paymentID {
+		t.Errorf("expected recovery payment %s, got %v", paymentID, dunning.RecoveryPaymentID)
+	}
+	if dunning.CanRecover() {
+		t.Error("expected recovered case not to be recoverable again")
+	}
+}
+
+func TestDunningCaseCancel(t *testing.T) {
+	dunning, _ := newTestDunningCase(t, time.Now())
+
+	dunning.RecordFailure("insufficient funds")
+	if *dunning.LastFailureReason != "insufficient funds" {
+		t.Errorf("expected last failure to be recorded, got %s", *dunning.LastFailureReason)
+	}
+
+	dunning.Cancel()
+	if dunning.Status != DunningStatusCancelled || dunning.IsOpen() || dunning.CanRecover() {
+		t.Errorf("expected cancelled case to be closed, got %s", dunning.Status)
+	}
+}
@@ -241,6 +241,50 @@ func NewPaymentRefundedEvent(tenantID, paymentID, subscriptionID uuid.UUID, orig
 	}
 }
 
+// PaymentExpiredEvent evento de expiração de cobrança
+type PaymentExpiredEvent struct {
+	BaseEvent
+	PaymentID      uuid.UUID `json:"payment_id"`
+	SubscriptionID uuid.UUID `json:"subscription_id"`
+	Amount         int64     `json:"amount"`
+	PaymentMethod  string    `json:"payment_method"`
+	ExpiredAt      time.Time `json:"expired_at"`
+}
+
+func NewPaymentExpiredEvent(tenantID, paymentID, subscriptionID uuid.UUID, amount int64, paymentMethod PaymentMethod, expiredAt time.Time) *PaymentExpiredEvent {
+	return &PaymentExpiredEvent{
+		BaseEvent:      NewBaseEvent("payment.expired", tenantID, nil),
+		PaymentID:      paymentID,
+		SubscriptionID: subscriptionID,
+		Amount:         amount,
+		PaymentMethod:  string(paymentMethod),
+		ExpiredAt:      expiredAt,
+	}
+}
+
+// PaymentAmountMismatchEvent evento de divergência entre valor esperado e recebido
+type PaymentAmountMismatchEvent struct {
+	BaseEvent
+	PaymentID      uuid.UUID `json:"payment_id"`
+	SubscriptionID uuid.UUID `json:"subscription_id"`
+	ExpectedAmount int64     `json:"expected_amount"`
+	ReceivedAmount int64     `json:"received_amount"`
+	Result         string    `json:"result"` // underpaid, overpaid
+	TransactionID  string    `json:"transaction_id"`
+}
+
+func NewPaymentAmountMismatchEvent(tenantID, paymentID, subscriptionID uuid.UUID, expectedAmount, receivedAmount int64, result PixReconciliationResult, transactionID string) *PaymentAmountMismatchEvent {
+	return &PaymentAmountMismatchEvent{
+		BaseEvent:      NewBaseEvent("payment.amount_mismatch", tenantID, nil),
+		PaymentID:      paymentID,
+		SubscriptionID: subscriptionID,
+		ExpectedAmount: expectedAmount,
+		ReceivedAmount: receivedAmount,
+		Result:         string(result),
+		TransactionID:  transactionID,
+	}
+}
+
 // Eventos de Fatura
 
 // InvoiceCreatedEvent evento de criação de fatura
@@ -339,6 +383,203 @@ func NewNFEIssuedEvent(tenantID, invoiceID, subscriptionID uuid.UUID, nfeNumber,
 	}
 }
 
+// NFECancelledEvent evento de cancelamento de NFS-e
+type NFECancelledEvent struct {
+	BaseEvent
+	InvoiceID      uuid.UUID `json:"invoice_id"`
+	SubscriptionID uuid.UUID `json:"subscription_id"`
+	NFENumber      string    `json:"nfe_number"`
+	Reason         string    `json:"reason"`
+	CancelledAt    time.Time `json:"cancelled_at"`
+}
+
+func NewNFECancelledEvent(tenantID, invoiceID, subscriptionID uuid.UUID, nfeNumber, reason string, cancelledAt time.Time) *NFECancelledEvent {
+	return &NFECancelledEvent{
+		BaseEvent:      NewBaseEvent("nfe.cancelled", tenantID, nil),
+		InvoiceID:      invoiceID,
+		SubscriptionID: subscriptionID,
+		NFENumber:      nfeNumber,
+		Reason:         reason,
+		CancelledAt:    cancelledAt,
+	}
+}
+
+// Eventos de Cobrança (Dunning)
+
+// DunningStartedEvent evento de início da régua de cobrança
+type DunningStartedEvent struct {
+	BaseEvent
+	DunningID      uuid.UUID  `json:"dunning_id"`
+	SubscriptionID uuid.UUID  `json:"subscription_id"`
+	PaymentID      uuid.UUID  `json:"payment_id"`
+	FailureReason  string     `json:"failure_reason"`
+	NextActionAt   *time.Time `json:"next_action_at"`
+}
+
+func NewDunningStartedEvent(tenantID, dunningID, subscriptionID, paymentID uuid.UUID, failureReason string, nextActionAt *time.Time) *DunningStartedEvent {
+	return &DunningStartedEvent{
+		BaseEvent:      NewBaseEvent("dunning.started", tenantID, nil),
+		DunningID:      dunningID,
+		SubscriptionID: subscriptionID,
+		PaymentID:      paymentID,
+		FailureReason:  failureReason,
+		NextActionAt:   nextActionAt,
+	}
+}
+
+// DunningStepExecutedEvent evento de execução de uma etapa da régua
+type DunningStepExecutedEvent struct {
+	BaseEvent
+	DunningID      uuid.UUID `json:"dunning_id"`
+	SubscriptionID uuid.UUID `json:"subscription_id"`
+	Step           int       `json:"step"`
+	DayOffset      int       `json:"day_offset"`
+	PaymentRetried bool      `json:"payment_retried"`
+	RetryError     string    `json:"retry_error,omitempty"`
+	Channels       []string  `json:"channels"`
+}
+
+func NewDunningStepExecutedEvent(tenantID, dunningID, subscriptionID uuid.UUID, step, dayOffset int, paymentRetried bool, retryError string, channels []string) *DunningStepExecutedEvent {
+	return &DunningStepExecutedEvent{
+		BaseEvent:      NewBaseEvent("dunning.step_executed", tenantID, nil),
+		DunningID:      dunningID,
+		SubscriptionID: subscriptionID,
+		Step:           step,
+		DayOffset:      dayOffset,
+		PaymentRetried: paymentRetried,
+		RetryError:     retryError,
+		Channels:       channels,
+	}
+}
+
+// DunningGracePeriodStartedEvent evento de início do período de carência
+type DunningGracePeriodStartedEvent struct {
+	BaseEvent
+	DunningID      uuid.UUID `json:"dunning_id"`
+	SubscriptionID uuid.UUID `json:"subscription_id"`
+	GraceEndsAt    time.Time `json:"grace_ends_at"`
+}
+
+func NewDunningGracePeriodStartedEvent(tenantID, dunningID, subscriptionID uuid.UUID, graceEndsAt time.Time) *DunningGracePeriodStartedEvent {
+	return &DunningGracePeriodStartedEvent{
+		BaseEvent:      NewBaseEvent("dunning.grace_period_started", tenantID, nil),
+		DunningID:      dunningID,
+		SubscriptionID: subscriptionID,
+		GraceEndsAt:    graceEndsAt,
+	}
+}
+
+// DunningSuspendedEvent evento de suspensão por inadimplência
+type DunningSuspendedEvent struct {
+	BaseEvent
+	DunningID      uuid.UUID `json:"dunning_id"`
+	SubscriptionID uuid.UUID `json:"subscription_id"`
+	DaysInDunning  int       `json:"days_in_dunning"`
+	Reason         string    `json:"reason"`
+	SuspendedAt    time.Time `json:"suspended_at"`
+}
+
+func NewDunningSuspendedEvent(tenantID, dunningID, subscriptionID uuid.UUID, daysInDunning int, reason string, suspendedAt time.Time) *DunningSuspendedEvent {
+	return &DunningSuspendedEvent{
+		BaseEvent:      NewBaseEvent("dunning.suspended", tenantID, nil),
+		DunningID:      dunningID,
+		SubscriptionID: subscriptionID,
+		DaysInDunning:  daysInDunning,
+		Reason:         reason,
+		SuspendedAt:    suspendedAt,
+	}
+}
+
+// DunningRecoveredEvent evento de regularização do pagamento durante a régua
+type DunningRecoveredEvent struct {
+	BaseEvent
+	DunningID      uuid.UUID `json:"dunning_id"`
+	SubscriptionID uuid.UUID `json:"subscription_id"`
+	PaymentID      uuid.UUID `json:"payment_id"`
+	StepsExecuted  int       `json:"steps_executed"`
+	WasSuspended   bool      `json:"was_suspended"`
+	RecoveredAt    time.Time `json:"recovered_at"`
+}
+
+func NewDunningRecoveredEvent(tenantID, dunningID, subscriptionID, paymentID uuid.UUID, stepsExecuted int, wasSuspended bool, recoveredAt time.Time) *DunningRecoveredEvent {
+	return &DunningRecoveredEvent{
+		BaseEvent:      NewBaseEvent("dunning.recovered", tenantID, nil),
+		DunningID:      dunningID,
+		SubscriptionID: subscriptionID,
+		PaymentID:      paymentID,
+		StepsExecuted:  stepsExecuted,
+		WasSuspended:   wasSuspended,
+		RecoveredAt:    recoveredAt,
+	}
+}
+
+// Eventos de Crédito
+
+// CreditGrantedEvent evento de crédito concedido ao cliente
+type CreditGrantedEvent struct {
+	BaseEvent
+	SubscriptionID uuid.UUID `json:"subscription_id"`
+	Amount         int64     `json:"amount"`
+	Reason         string    `json:"reason"`
+	GrantedAt      time.Time `json:"granted_at"`
+}
+
+func NewCreditGrantedEvent(tenantID, subscriptionID uuid.UUID, amount int64, reason string, grantedAt time.Time) *CreditGrantedEvent {
+	return &CreditGrantedEvent{
+		BaseEvent:      NewBaseEvent("credit.granted", tenantID, nil),
+		SubscriptionID: subscriptionID,
+		Amount:         amount,
+		Reason:         reason,
+		GrantedAt:      grantedAt,
+	}
+}
+
+// Eventos de Cobrança por Uso
+
+// UsageChargedEvent evento de excedente tarifado para a próxima fatura
+type UsageChargedEvent struct {
+	BaseEvent
+	ChargeID       uuid.UUID `json:"charge_id"`
+	SubscriptionID uuid.UUID `json:"subscription_id"`
+	Metric         string    `json:"metric"`
+	Quantity       int64     `json:"quantity"`
+	UnitPrice      int64     `json:"unit_price"`
+	Amount         int64     `json:"amount"`
+	PeriodTotal    int64     `json:"period_total"`
+}
+
+func NewUsageChargedEvent(tenantID, chargeID, subscriptionID uuid.UUID, metric UsageMetric, quantity, unitPrice, amount, periodTotal int64) *UsageChargedEvent {
+	return &UsageChargedEvent{
+		BaseEvent:      NewBaseEvent("usage.charged", tenantID, nil),
+		ChargeID:       chargeID,
+		SubscriptionID: subscriptionID,
+		Metric:         string(metric),
+		Quantity:       quantity,
+		UnitPrice:      unitPrice,
+		Amount:         amount,
+		PeriodTotal:    periodTotal,
+	}
+}
+
+// UsageCapReachedEvent evento de teto de gastos com excedente atingido
+type UsageCapReachedEvent struct {
+	BaseEvent
+	SubscriptionID uuid.UUID `json:"subscription_id"`
+	SpendingCap    int64     `json:"spending_cap"`
+	PeriodTotal    int64     `json:"period_total"`
+	PeriodEnd      time.Time `json:"period_end"`
+}
+
+func NewUsageCapReachedEvent(tenantID, subscriptionID uuid.UUID, spendingCap, periodTotal int64, periodEnd time.Time) *UsageCapReachedEvent {
+	return &UsageCapReachedEvent{
+		BaseEvent:      NewBaseEvent("usage.cap_reached", tenantID, nil),
+		SubscriptionID: subscriptionID,
+		SpendingCap:    spendingCap,
+		PeriodTotal:    periodTotal,
+		PeriodEnd:      periodEnd,
+	}
+}
+
 // Eventos de Cliente
 
 // CustomerCreatedEvent evento de criação de cliente
@@ -20,6 +20,9 @@ type Invoice struct {
 	DiscountAmount    int64         `json:"discount_amount"`
 	TotalAmount       int64         `json:"total_amount"`
 	
+	// Itens adicionais (excedente de uso), além da assinatura
+	Items             []InvoiceItem `json:"items"`
+	
 	// Período da fatura
 	PeriodStart       time.Time     `json:"period_start"`
 	PeriodEnd         time.Time     `json:"period_end"`
@@ -38,6 +41,14 @@ type Invoice struct {
 	NFEURL            *string       `json:"nfe_url"`
 	NFEStatus         *string       `json:"nfe_status"`
 	NFEIssuedAt       *time.Time    `json:"nfe_issued_at"`
+	NFEXMLURL         *string       `json:"nfe_xml_url"`
+	NFEPDFURL         *string       `json:"nfe_pdf_url"`
+	NFECancelledAt    *time.Time    `json:"nfe_cancelled_at"`
+	NFECancelReason   *string       `json:"nfe_cancel_reason"`
+	
+	// Tributação (ISS)
+	ISSRate           float64       `json:"iss_rate"`
+	ServiceCode       string        `json:"service_code"`
 	
 	// Dados do cliente (para NF-e)
 	CustomerName      string        `json:"customer_name"`
@@ -76,6 +87,16 @@ const (
 	InvoiceStatusRefunded   InvoiceStatus = "refunded"
 )
 
+// Status da NFS-e
+const (
+	NFEStatusIssued    = "issued"
+	NFEStatusCancelled = "cancelled"
+	NFEStatusFailed    = "failed"
+)
+
+// DefaultISSRate alíquota de ISS padrão (2% para Curitiba)
+const DefaultISSRate = 0.02
+
 // Address representa um endereço
 type Address struct {
 	Street       string `json:"street"`
@@ -97,9 +118,10 @@ func NewInvoice(subscriptionID, tenantID uuid.UUID, amount int64, periodStart, p
 		SubscriptionID: subscriptionID,
 		TenantID:       tenantID,
 		Amount:         amount,
-		TaxAmount:      calculateISS(amount), // ISS 2% para Curitiba
+		TaxAmount:      calculateISS(amount, DefaultISSRate), // ISS 2% para Curitiba
 		DiscountAmount: 0,
-		TotalAmount:    amount + calculateISS(amount),
+		TotalAmount:    amount + calculateISS(amount, DefaultISSRate),
+		ISSRate:        DefaultISSRate,
 		PeriodStart:    periodStart,
 		PeriodEnd:      periodEnd,
 		DueDate:        now.AddDate(0, 0, 7), // 7 dias para pagamento
@@ -125,9 +147,18 @@ func NewInvoice(subscriptionID, tenantID uuid.UUID, amount int64, periodStart, p
 	}
 }
 
-// calculateISS calcula o ISS (2% para Curitiba)
-func calculateISS(amount int64) int64 {
-	return int64(float64(amount) * 0.02)
+// calculateISS calcula o ISS pela alíquota do município
+func calculateISS(amount int64, rate float64) int64 {
+	return int64(float64(amount) * rate)
+}
+
+// ApplyISSRate recalcula o ISS com a alíquota e o código de serviço do município
+func (i *Invoice) ApplyISSRate(rate float64, serviceCode string) {
+	i.ISSRate = rate
+	i.ServiceCode = serviceCode
+	i.TaxAmount = calculateISS(i.Amount, rate)
+	i.TotalAmount = i.Amount + i.TaxAmount - i.DiscountAmount
+	i.UpdatedAt = time.Now()
 }
 
 // SetCustomerData define os dados do cliente
@@ -191,6 +222,40 @@ func (i *Invoice) SetNFEData(number, key, url, status string) {
 	i.UpdatedAt = now
 }
 
+// SetNFEDocuments define os endereços do XML e do PDF armazenados da NFS-e
+func (i *Invoice) SetNFEDocuments(xmlURL, pdfURL string) {
+	i.NFEXMLURL = &xmlURL
+	i.NFEPDFURL = &pdfURL
+	i.UpdatedAt = time.Now()
+}
+
+// CancelNFE registra o cancelamento da NFS-e
+func (i *Invoice) CancelNFE(reason string) {
+	now := time.Now()
+	status := NFEStatusCancelled
+	i.NFEStatus = &status
+	i.NFECancelledAt = &now
+	i.NFECancelReason = &reason
+	i.UpdatedAt = now
+}
+
+// MarkNFEFailed registra falha na emissão da NFS-e
+func (i *Invoice) MarkNFEFailed(errorMsg string) {
+	status := NFEStatusFailed
+	i.NFEStatus = &status
+	i.IncrementRetry(errorMsg)
+}
+
+// IsNFECancelled verifica se a NFS-e foi cancelada
+func (i *Invoice) IsNFECancelled() bool {
+	return i.NFEStatus != nil && *i.NFEStatus == NFEStatusCancelled
+}
+
+// CanIssueNFE verifica se a fatura paga ainda precisa de NFS-e
+func (i *Invoice) CanIssueNFE() bool {
+	return i.IsPaid() && !i.HasNFE() && i.RetryCount < 3
+}
+
 // SetAsaasData define os dados do ASAAS
 func (i *Invoice) SetAsaasData(invoiceID string) {
 	i.AsaasInvoiceID = &invoiceID
@@ -286,18 +351,40 @@ func (i *Invoice) ApplyDiscount(discountAmount int64) {
 	i.UpdatedAt = time.Now()
 }
 
+// AddItem lança um item adicional na fatura e recalcula os totais
+func (i *Invoice) AddItem(item InvoiceItem) {
+	i.Items = append(i.Items, item)
+	i.Amount += item.TotalPrice
+	i.TaxAmount = calculateISS(i.Amount, i.ISSRate)
+	i.TotalAmount = i.Amount + i.TaxAmount - i.DiscountAmount
+	i.UpdatedAt = time.Now()
+}
+
+// GetItemsAmount retorna o valor dos itens adicionais
+func (i *Invoice) GetItemsAmount() int64 {
+	var total int64
+	for _, item := range i.Items {
+		total += item.TotalPrice
+	}
+	return total
+}
+
 // GetInvoiceItems retorna os itens da fatura
 func (i *Invoice) GetInvoiceItems() []InvoiceItem {
-	return []InvoiceItem{
+	subscriptionAmount := i.Amount - i.GetItemsAmount()
+
+	items := []InvoiceItem{
 		{
 			Description: "Assinatura Direito Lux",
 			Quantity:    1,
-			UnitPrice:   i.Amount,
-			TotalPrice:  i.Amount,
+			UnitPrice:   subscriptionAmount,
+			TotalPrice:  subscriptionAmount,
 			PeriodStart: i.PeriodStart,
 			PeriodEnd:   i.PeriodEnd,
 		},
 	}
+
+	return append(items, i.Items...)
 }
 
 // InvoiceItem representa um item da fatura
@@ -0,0 +1,261 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LedgerAccount conta contábil do razão de faturamento
+type LedgerAccount string
+
+const (
+	// Ativo (saldo devedor)
+	LedgerAccountCash               LedgerAccount = "cash"
+	LedgerAccountAccountsReceivable LedgerAccount = "accounts_receivable"
+
+	// Passivo (saldo credor)
+	LedgerAccountDeferredRevenue LedgerAccount = "deferred_revenue"
+	LedgerAccountTaxPayable      LedgerAccount = "tax_payable"
+	LedgerAccountCustomerCredits LedgerAccount = "customer_credits"
+
+	// Receita (saldo credor)
+	LedgerAccountRevenue LedgerAccount = "revenue"
+
+	// Redutoras de receita (saldo devedor)
+	LedgerAccountDiscounts      LedgerAccount = "discounts"
+	LedgerAccountRefunds        LedgerAccount = "refunds"
+	LedgerAccountCreditsGranted LedgerAccount = "credits_granted"
+)
+
+// IsDebitNormal verifica se a conta tem saldo natural devedor
+func (a LedgerAccount) IsDebitNormal() bool {
+	switch a {
+	case LedgerAccountCash, LedgerAccountAccountsReceivable,
+		LedgerAccountDiscounts, LedgerAccountRefunds, LedgerAccountCreditsGranted:
+		return true
+	default:
+		return false
+	}
+}
+
+// LedgerEntryType enumeração dos tipos de lançamento
+type LedgerEntryType string
+
+const (
+	LedgerEntryInvoiceIssued     LedgerEntryType = "invoice_issued"
+	LedgerEntryPaymentReceived   LedgerEntryType = "payment_received"
+	LedgerEntryPaymentRefunded   LedgerEntryType = "payment_refunded"
+	LedgerEntryRevenueRecognized LedgerEntryType = "revenue_recognized"
+	LedgerEntryCreditGranted     LedgerEntryType = "credit_granted"
+)
+
+// LedgerLine partida de um lançamento (débito ou crédito em uma conta)
+type LedgerLine struct {
+	Account LedgerAccount `json:"account"`
+	Debit   int64         `json:"debit"`  // em centavos
+	Credit  int64         `json:"credit"` // em centavos
+}
+
+// LedgerEntry lançamento contábil de partidas dobradas. O razão é somente de inclusão:
+// correções são feitas por novos lançamentos, nunca alterando os existentes.
+type LedgerEntry struct {
+	ID            uuid.UUID       `json:"id"`
+	TenantID      uuid.UUID       `json:"tenant_id"`
+	Type          LedgerEntryType `json:"type"`
+	ReferenceType string          `json:"reference_type"` // invoice, payment, subscription
+	ReferenceID   uuid.UUID       `json:"reference_id"`
+	EventID       *uuid.UUID      `json:"event_id"` // evento de origem, para idempotência
+	Description   string          `json:"description"`
+	Lines         []LedgerLine    `json:"lines"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// NewLedgerEntry cria um lançamento sem partidas
+func NewLedgerEntry(tenantID uuid.UUID, entryType LedgerEntryType, referenceType string, referenceID uuid.UUID, description string, occurredAt time.Time) *LedgerEntry {
+	return &LedgerEntry{
+		ID:            uuid.New(),
+		TenantID:      tenantID,
+		Type:          entryType,
+		ReferenceType: referenceType,
+		ReferenceID:   referenceID,
+		Description:   description,
+		OccurredAt:    occurredAt,
+		CreatedAt:     time.Now(),
+	}
+}
+
+// FromEvent registra o evento que originou o lançamento
+func (e *LedgerEntry) FromEvent(eventID uuid.UUID) *LedgerEntry {
+	e.EventID = &eventID
+	return e
+}
+
+// Debit adiciona uma partida de débito (valores zerados são ignorados)
+func (e *LedgerEntry) Debit(account LedgerAccount, amount int64) *LedgerEntry {
+	if amount != 0 {
+		e.Lines = append(e.Lines, LedgerLine{Account: account, Debit: amount})
+	}
+	return e
+}
+
+// Credit adiciona uma partida de crédito (valores zerados são ignorados)
+func (e *LedgerEntry) Credit(account LedgerAccount, amount int64) *LedgerEntry {
+	if amount != 0 {
+		e.Lines = append(e.Lines, LedgerLine{Account: account, Credit: amount})
+	}
+	return e
+}
+
+// Total retorna o valor total debitado
+func (e *LedgerEntry) Total() int64 {
+	var total int64
+	for _, line := range e.Lines {
+		total += line.Debit
+	}
+	return total
+}
+
+// Validate verifica se o lançamento está balanceado
+func (e *LedgerEntry) Validate() error {
+	if len(e.Lines) < 2 {
+		return errors.New("ledger entry requires at least two lines")
+	}
+
+	var debits, credits int64
+	for _, line := range e.Lines {
+		if line.Debit < 0 || line.Credit < 0 {
+			return fmt.Errorf("negative amount on account %s", line.Account)
+		}
+		if (line.Debit == 0) == (line.Credit == 0) {
+			return fmt.Errorf("line on account %s must be either debit or credit", line.Account)
+		}
+		debits += line.Debit
+		credits += line.Credit
+	}
+
+	if debits != credits {
+		return fmt.Errorf("unbalanced ledger entry: debits %d, credits %d", debits, credits)
+	}
+
+	return nil
+}
+
+// NewInvoiceIssuedEntry lança a fatura: o valor do serviço fica como receita diferida até
+// ser reconhecido ao longo do período
+func NewInvoiceIssuedEntry(invoice *Invoice) *LedgerEntry {
+	entry := NewLedgerEntry(invoice.TenantID, LedgerEntryInvoiceIssued, "invoice", invoice.ID,
+		fmt.Sprintf("Fatura %s emitida", invoice.Number), invoice.IssuedAt)
+
+	return entry.
+		Debit(LedgerAccountAccountsReceivable, invoice.TotalAmount).
+		Debit(LedgerAccountDiscounts, invoice.DiscountAmount).
+		Credit(LedgerAccountDeferredRevenue, invoice.Amount).
+		Credit(LedgerAccountTaxPayable, invoice.TaxAmount)
+}
+
+// NewPaymentReceivedEntry lança o recebimento. Pagamentos sem fatura são reconhecidos
+// como receita no recebimento.
+func NewPaymentReceivedEntry(payment *Payment, paidAt time.Time) *LedgerEntry {
+	entry := NewLedgerEntry(payment.TenantID, LedgerEntryPaymentReceived, "payment", payment.ID,
+		fmt.Sprintf("Pagamento %s recebido", payment.ID.String()[:8]), paidAt)
+
+	entry.Debit(LedgerAccountCash, payment.Amount)
+	if payment.InvoiceID != nil {
+		return entry.Credit(LedgerAccountAccountsReceivable, payment.Amount)
+	}
+	return entry.Credit(LedgerAccountRevenue, payment.Amount)
+}
+
+// NewPaymentRefundedEntry lança o reembolso, estornando o ISS proporcional da fatura
+func NewPaymentRefundedEntry(payment *Payment, invoice *Invoice, refundAmount int64, refundedAt time.Time) *LedgerEntry {
+	entry := NewLedgerEntry(payment.TenantID, LedgerEntryPaymentRefunded, "payment", payment.ID,
+		fmt.Sprintf("Reembolso do pagamento %s", payment.ID.String()[:8]), refundedAt)
+
+	var taxPortion int64
+	if invoice != nil && invoice.TotalAmount > 0 {
+		taxPortion = refundAmount * invoice.TaxAmount / invoice.TotalAmount
+	}
+
+	return entry.
+		Debit(LedgerAccountTaxPayable, taxPortion).
+		Debit(LedgerAccountRefunds, refundAmount-taxPortion).
+		Credit(LedgerAccountCash, refundAmount)
+}
+
+// NewRevenueRecognizedEntry transfere receita diferida para receita
+func NewRevenueRecognizedEntry(invoice *Invoice, amount int64, asOf time.Time) *LedgerEntry {
+	entry := NewLedgerEntry(invoice.TenantID, LedgerEntryRevenueRecognized, "invoice", invoice.ID,
+		fmt.Sprintf("Receita reconhecida da fatura %s", invoice.Number), asOf)
+
+	return entry.
+		Debit(LedgerAccountDeferredRevenue, amount).
+		Credit(LedgerAccountRevenue, amount)
+}
+
+// NewCreditGrantedEntry lança um crédito concedido ao cliente (ex.: prorrata de downgrade)
+func NewCreditGrantedEntry(tenantID, subscriptionID uuid.UUID, amount int64, reason string, grantedAt time.Time) *LedgerEntry {
+	entry := NewLedgerEntry(tenantID, LedgerEntryCreditGranted, "subscription", subscriptionID, reason, grantedAt)
+
+	return entry.
+		Debit(LedgerAccountCreditsGranted, amount).
+		Credit(LedgerAccountCustomerCredits, amount)
+}
+
+// EarnedAmount calcula quanto do valor da fatura já foi prestado até a data
+func EarnedAmount(amount int64, periodStart, periodEnd, asOf time.Time) int64 {
+	if !asOf.After(periodStart) {
+		return 0
+	}
+	if !asOf.Before(periodEnd) || !periodEnd.After(periodStart) {
+		return amount
+	}
+
+	elapsed := asOf.Sub(periodStart)
+	total := periodEnd.Sub(periodStart)
+	return int64(float64(amount) * float64(elapsed) / float64(total))
+}
+
+// LedgerBalance saldo de uma conta
+type LedgerBalance struct {
+	Account LedgerAccount `json:"account"`
+	Debits  int64         `json:"debits"`
+	Credits int64         `json:"credits"`
+}
+
+// Balance retorna o saldo pelo lado natural da conta
+func (b LedgerBalance) Balance() int64 {
+	if b.Account.IsDebitNormal() {
+		return b.Debits - b.Credits
+	}
+	return b.Credits - b.Debits
+}
+
+// SumLines soma as partidas de uma conta nos lançamentos
+func SumLines(entries []*LedgerEntry, account LedgerAccount) LedgerBalance {
+	balance := LedgerBalance{Account: account}
+	for _, entry := range entries {
+		for _, line := range entry.Lines {
+			if line.Account == account {
+				balance.Debits += line.Debit
+				balance.Credits += line.Credit
+			}
+		}
+	}
+	return balance
+}
+
+// LedgerQuery filtros para busca de lançamentos
+type LedgerQuery struct {
+	TenantID *uuid.UUID        `json:"tenant_id"`
+	Types    []LedgerEntryType `json:"types"`
+	From     *time.Time        `json:"from"`
+	To       *time.Time        `json:"to"`
+
+	// Paginação
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestInvoiceIssuedEntryIsBalanced(t *testing.T) {
+	invoice := &Invoice{
+		ID:             uuid.New(),
+		TenantID:       uuid.New(),
+		Number:         "INV-202507-00001",
+		Amount:         19990,
+		DiscountAmount: 1000,
+		TaxAmount:      999,
+		TotalAmount:    19989,
+		IssuedAt:       time.Now(),
+	}
+
+	entry := NewInvoiceIssuedEntry(invoice)
+	if err := entry.Validate(); err != nil {
+		t.Fatalf("expected balanced entry: %v", err)
+	}
+
+	deferred := SumLines([]*LedgerEntry{entry}, LedgerAccountDeferredRevenue)
+	if deferred.Balance() != invoice.Amount {
+		t.Errorf("deferred revenue = %d, want %d", deferred.Balance(), invoice.Amount)
+	}
+}
+
+func TestPaymentRefundedEntryReversesTax(t *testing.T) {
+	invoiceID := uuid.New()
+	invoice := &Invoice{ID: invoiceID, Amount: 10000, TaxAmount: 500, TotalAmount: 10500}
+	payment := &Payment{ID: uuid.New(), TenantID: uuid.New(), InvoiceID: &invoiceID, Amount: 10500}
+
+	entry := NewPaymentRefundedEntry(payment, invoice, 10500, time.Now())
+	if err := entry.Validate(); err != nil {
+		t.Fatalf("expected balanced entry: %v", err)
+	}
+
+	tax := SumLines([]*LedgerEntry{entry}, LedgerAccountTaxPayable)
+	if tax.Debits != 500 {
+		t.Errorf("tax reversal = %d, want 500", tax.Debits)
+	}
+}
+
+func TestLedgerEntryValidateRejectsUnbalanced(t *testing.T) {
+	entry := NewLedgerEntry(uuid.New(), LedgerEntryCreditGranted, "subscription", uuid.New(), "", time.Now()).
+		Debit(LedgerAccountCreditsGranted, 100).
+		Credit(LedgerAccountCustomerCredits, 90)
+
+	if err := entry.Validate(); err == nil {
+		t.Fatal("expected unbalanced entry to be rejected")
+	}
+}
+
+func TestEarnedAmount(t *testing.T) {
+	start := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 30)
+
+	cases := []struct {
+		asOf     time.Time
+		expected int64
+	}{
+		{start.AddDate(0, 0, -1), 0},
+		{start.AddDate(0, 0, 15), 5000},
+		{end, 10000},
+		{end.AddDate(0, 0, 1), 10000},
+	}
+
+	for _, c := range cases {
+		if earned := EarnedAmount(10000, start, end, c.asOf); earned != c.expected {
+			t.Errorf("EarnedAmount(%s) = %d, want %d", c.asOf.Format("2006-01-02"), earned, c.expected)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Limites de alíquota do ISS definidos pela LC 116/2003 e LC 157/2016
+const (
+	MinISSRate = 0.02
+	MaxISSRate = 0.05
+)
+
+// NFSeMunicipality configuração fiscal de um município emissor de NFS-e
+type NFSeMunicipality struct {
+	IBGECode           string  `json:"ibge_code"`
+	City               string  `json:"city"`
+	State              string  `json:"state"`
+	ISSRate            float64 `json:"iss_rate"`
+	ServiceCode        string  `json:"service_code"`       // item da lista de serviços da LC 116/2003
+	MunicipalTaxCode   string  `json:"municipal_tax_code"` // código de tributação do município
+	CNAE               string  `json:"cnae"`
+	ServiceDescription string  `json:"service_description"` // discriminação do serviço na nota
+}
+
+// Validate valida a configuração do município
+func (m NFSeMunicipality) Validate() error {
+	if len(m.IBGECode) != 7 {
+		return fmt.Errorf("invalid IBGE code for %s: %s", m.City, m.IBGECode)
+	}
+
+	if m.ISSRate < MinISSRate || m.ISSRate > MaxISSRate {
+		return fmt.Errorf("ISS rate for %s must be between %.2f and %.2f", m.City, MinISSRate, MaxISSRate)
+	}
+
+	if m.ServiceCode == "" {
+		return fmt.Errorf("service code is required for %s", m.City)
+	}
+
+	return nil
+}
+
+// DefaultNFSeMunicipalities retorna a configuração padrão (sede em Curitiba)
+func DefaultNFSeMunicipalities() []NFSeMunicipality {
+	return []NFSeMunicipality{
+		{
+			IBGECode:           "4106902",
+			City:               "Curitiba",
+			State:              "PR",
+			ISSRate:            0.02,
+			ServiceCode:        "01.05",
+			MunicipalTaxCode:   "010500101",
+			CNAE:               "6203100",
+			ServiceDescription: "Licenciamento de uso de software - Assinatura Direito Lux",
+		},
+	}
+}
+
+// NFSeTaxTable tabela de alíquotas e códigos de serviço por município
+type NFSeTaxTable struct {
+	municipalities map[string]NFSeMunicipality
+}
+
+// NewNFSeTaxTable cria a tabela validando cada município
+func NewNFSeTaxTable(municipalities []NFSeMunicipality) (*NFSeTaxTable, error) {
+	if len(municipalities) == 0 {
+		return nil, errors.New("at least one municipality is required")
+	}
+
+	table := &NFSeTaxTable{
+		municipalities: make(map[string]NFSeMunicipality, len(municipalities)),
+	}
+
+	for _, m := range municipalities {
+		if err := m.Validate(); err != nil {
+			return nil, err
+		}
+		if _, exists := table.municipalities[m.IBGECode]; exists {
+			return nil, fmt.Errorf("duplicated municipality: %s", m.IBGECode)
+		}
+		table.municipalities[m.IBGECode] = m
+	}
+
+	return table, nil
+}
+
+// Get busca o município pelo código IBGE
+func (t *NFSeTaxTable) Get(ibgeCode string) (NFSeMunicipality, bool) {
+	m, ok := t.municipalities[ibgeCode]
+	return m, ok
+}
+
+// FindByCity busca o município pelo nome da cidade e UF
+func (t *NFSeTaxTable) FindByCity(city, state string) (NFSeMunicipality, bool) {
+	for _, m := range t.municipalities {
+		if strings.EqualFold(m.City, city) && strings.EqualFold(m.State, state) {
+			return m, true
+		}
+	}
+	return NFSeMunicipality{}, false
+}
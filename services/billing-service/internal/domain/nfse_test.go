@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestNFSeMunicipalityValidate(t *testing.T) {
+	valid := DefaultNFSeMunicipalities()[0]
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("expected default municipality to be valid: %v", err)
+	}
+
+	invalid := map[string]func(m *NFSeMunicipality){
+		"short IBGE code":    func(m *NFSeMunicipality) { m.IBGECode = "410690" },
+		"ISS below minimum":  func(m *NFSeMunicipality) { m.ISSRate = 0.015 },
+		"ISS above maximum":  func(m *NFSeMunicipality) { m.ISSRate = 0.06 },
+		"empty service code": func(m *NFSeMunicipality) { m.ServiceCode = "" },
+	}
+	for name, mutate := range invalid {
+		m := valid
+		mutate(&m)
+		if err := m.Validate(); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+
+	for _, rate := range []float64{MinISSRate, MaxISSRate} {
+		m := valid
+		m.ISSRate = rate
+		if err := m.Validate(); err != nil {
+			t.Errorf("expected ISS rate %.2f to be valid: %v", rate, err)
+		}
+	}
+}
+
+func TestNFSeTaxTable(t *testing.T) {
+	curitiba := DefaultNFSeMunicipalities()[0]
+	saoPaulo := NFSeMunicipality{
+		IBGECode:    "3550308",
+		City:        "São Paulo",
+		State:       "SP",
+		ISSRate:     0.029,
+		ServiceCode: "01.05",
+	}
+
+	table, err := NewNFSeTaxTable([]NFSeMunicipality{curitiba, saoPaulo})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if m, ok := table.Get("3550308"); !ok || m.ISSRate != 0.029 {
+		t.Errorf("expected São Paulo by IBGE code, got %+v", m)
+	}
+	if m, ok := table.FindByCity("CURITIBA", "pr"); !ok || m.IBGECode != curitiba.IBGECode {
+		t.Errorf("expected Curitiba by city, got %+v", m)
+	}
+	if _, ok := table.FindByCity("Curitiba", "SP"); ok {
+		t.Error("expected city lookup to match the state")
+	}
+
+	if _, err := NewNFSeTaxTable(nil); err == nil {
+		t.Error("expected error for empty table")
+	}
+	if _, err := NewNFSeTaxTable([]NFSeMunicipality{curitiba, curitiba}); err == nil {
+		t.Error("expected error for duplicated municipality")
+	}
+	saoPaulo.ServiceCode = ""
+	if _, err := NewNFSeTaxTable([]NFSeMunicipality{saoPaulo}); err == nil {
+		t.Error("expected error for invalid municipality")
+	}
+}
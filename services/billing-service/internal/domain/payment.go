@@ -50,6 +50,14 @@ type Payment struct {
 	CryptoTxHash      *string       `json:"crypto_tx_hash"`
 	ExchangeRate      *float64      `json:"exchange_rate"`
 	
+	// PIX específico
+	PixTxID           *string       `json:"pix_txid"`
+	PixEndToEndID     *string       `json:"pix_end_to_end_id"`
+	PixBRCode         *string       `json:"pix_br_code"`       // Pix Copia e Cola (payload EMV)
+	PixQRCodeImage    *string       `json:"pix_qr_code_image"` // PNG em base64, quando fornecido pelo PSP
+	PixExpiresAt      *time.Time    `json:"pix_expires_at"`
+	ReceivedAmount    *int64        `json:"received_amount"`   // valor efetivamente recebido, em centavos
+	
 	CreatedAt         time.Time     `json:"created_at"`
 	UpdatedAt         time.Time     `json:"updated_at"`
 }
@@ -85,19 +93,23 @@ func NewPayment(subscriptionID, tenantID uuid.UUID, amount int64, method Payment
 	}
 	
 	// Definir data de vencimento baseada no método
+	dueDate := defaultDueDate(method, now)
+	payment.DueDate = &dueDate
+	
+	return payment
+}
+
+// defaultDueDate calcula a data de vencimento padrão de cada método
+func defaultDueDate(method PaymentMethod, from time.Time) time.Time {
 	switch method {
 	case PaymentMethodBoleto:
-		dueDate := now.AddDate(0, 0, 7) // 7 dias para boleto
-		payment.DueDate = &dueDate
+		return from.AddDate(0, 0, 7) // 7 dias para boleto
 	case PaymentMethodPix:
-		dueDate := now.AddDate(0, 0, 1) // 1 dia para PIX
-		payment.DueDate = &dueDate
+		return from.AddDate(0, 0, 1) // 1 dia para PIX
 	default:
 		// Cartão e cripto processam imediatamente
-		payment.DueDate = &now
+		return from
 	}
-	
-	return payment
 }
 
 // MarkAsPaid marca o pagamento como pago
@@ -123,6 +135,18 @@ func (p *Payment) MarkAsFailed(reason string) {
 	p.NextRetryAt = &nextRetry
 }
 
+// ResetForRetry prepara o pagamento para uma nova tentativa de cobrança
+func (p *Payment) ResetForRetry() {
+	now := time.Now()
+	dueDate := defaultDueDate(p.PaymentMethod, now)
+	p.Status = PaymentStatusPending
+	p.CancelReason = nil
+	p.NextRetryAt = nil
+	p.LastAttemptAt = &now
+	p.DueDate = &dueDate
+	p.UpdatedAt = now
+}
+
 // MarkAsCancelled marca o pagamento como cancelado
 func (p *Payment) MarkAsCancelled(reason string) {
 	now := time.Now()
@@ -163,6 +187,38 @@ func (p *Payment) SetCryptoData(address, amount, txHash string, exchangeRate flo
 	p.UpdatedAt = time.Now()
 }
 
+// SetPixCharge define os dados da cobrança PIX dinâmica
+func (p *Payment) SetPixCharge(txID, brCode, qrCodeImage string, expiresAt time.Time) {
+	p.PixTxID = &txID
+	p.PixBRCode = &brCode
+	if qrCodeImage != "" {
+		p.PixQRCodeImage = &qrCodeImage
+	}
+	p.PixExpiresAt = &expiresAt
+	p.DueDate = &expiresAt // a cobrança expira junto com o QR Code
+	p.GatewayReference = &txID
+	p.UpdatedAt = time.Now()
+}
+
+// RecordPixReceipt registra o PIX recebido
+func (p *Payment) RecordPixReceipt(endToEndID string, receivedAmount int64) {
+	p.PixEndToEndID = &endToEndID
+	p.ReceivedAmount = &receivedAmount
+	p.UpdatedAt = time.Now()
+}
+
+// MarkAsPartial marca o pagamento como parcialmente pago
+func (p *Payment) MarkAsPartial(receivedAmount int64) {
+	p.Status = PaymentStatusPartial
+	p.ReceivedAmount = &receivedAmount
+	p.UpdatedAt = time.Now()
+}
+
+// IsPix verifica se é um pagamento PIX
+func (p *Payment) IsPix() bool {
+	return p.PaymentMethod == PaymentMethodPix
+}
+
 // IsExpired verifica se o pagamento está expirado
 func (p *Payment) IsExpired() bool {
 	return p.DueDate != nil && time.Now().After(*p.DueDate) && p.Status == PaymentStatusPending
@@ -0,0 +1,219 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Identificadores do BR Code (padrão EMV QRCPS-MPM do Banco Central)
+const (
+	pixGUI           = "br.gov.bcb.pix"
+	pixMerchantCode  = "0000"
+	pixCurrencyBRL   = "986"
+	pixCountryCode   = "BR"
+	pixMaxNameLength = 25
+	pixMaxCityLength = 15
+	pixStaticTxID    = "***"
+	pixMinTxIDLength = 26
+	pixMaxTxIDLength = 35
+)
+
+// PixBRCode dados para geração do BR Code (Pix Copia e Cola)
+type PixBRCode struct {
+	Key          string // chave Pix (QR estático)
+	Location     string // URL do payload da cobrança, sem esquema (QR dinâmico)
+	MerchantName string
+	MerchantCity string
+	Amount       int64 // em centavos; zero permite valor livre
+	TxID         string
+}
+
+// IsDynamic verifica se o BR Code aponta para uma cobrança (QR dinâmico)
+func (b PixBRCode) IsDynamic() bool {
+	return b.Location != ""
+}
+
+// Payload gera o payload EMV do BR Code com o CRC16 ao final
+func (b PixBRCode) Payload() (string, error) {
+	if b.Key == "" && b.Location == "" {
+		return "", errors.New("pix key or location is required")
+	}
+
+	if b.MerchantName == "" || b.MerchantCity == "" {
+		return "", errors.New("merchant name and city are required")
+	}
+
+	account := emvField("00", pixGUI)
+	if b.IsDynamic() {
+		account += emvField("25", strings.TrimPrefix(strings.TrimPrefix(b.Location, "https://"), "http://"))
+	} else {
+		account += emvField("01", b.Key)
+	}
+
+	txID := b.TxID
+	if txID == "" || b.IsDynamic() {
+		// No QR dinâmico o txid é obtido pela location
+		txID = pixStaticTxID
+	}
+
+	var payload strings.Builder
+	payload.WriteString(emvField("00", "01"))
+	if b.IsDynamic() {
+		payload.WriteString(emvField("01", "12")) // uso único
+	}
+	payload.WriteString(emvField("26", account))
+	payload.WriteString(emvField("52", pixMerchantCode))
+	payload.WriteString(emvField("53", pixCurrencyBRL))
+	if b.Amount > 0 {
+		payload.WriteString(emvField("54", FormatPixAmount(b.Amount)))
+	}
+	payload.WriteString(emvField("58", pixCountryCode))
+	payload.WriteString(emvField("59", truncateEMV(normalizeEMV(b.MerchantName), pixMaxNameLength)))
+	payload.WriteString(emvField("60", truncateEMV(normalizeEMV(b.MerchantCity), pixMaxCityLength)))
+	payload.WriteString(emvField("62", emvField("05", txID)))
+	payload.WriteString("6304")
+
+	data := payload.String()
+	return data + fmt.Sprintf("%04X", crc16CCITT([]byte(data))), nil
+}
+
+// ValidatePixBRCode verifica o CRC de um payload Pix Copia e Cola
+func ValidatePixBRCode(payload string) bool {
+	if len(payload) < 8 || payload[len(payload)-8:len(payload)-4] != "6304" {
+		return false
+	}
+
+	data := payload[:len(payload)-4]
+	return strings.EqualFold(payload[len(payload)-4:], fmt.Sprintf("%04X", crc16CCITT([]byte(data))))
+}
+
+// NewPixTxID gera o identificador da cobrança Pix a partir do pagamento e da tentativa
+// (26 a 35 caracteres alfanuméricos, conforme a API Pix). Cada nova tentativa
+// precisa de um txid próprio, pois cobranças expiradas não podem ser reutilizadas.
+func NewPixTxID(paymentID uuid.UUID, attempt int) string {
+	return fmt.Sprintf("%s%02d", strings.ReplaceAll(paymentID.String(), "-", ""), attempt%100)
+}
+
+// ValidatePixTxID valida o formato do txid de cobranças imediatas
+func ValidatePixTxID(txID string) error {
+	if len(txID) < pixMinTxIDLength || len(txID) > pixMaxTxIDLength {
+		return fmt.Errorf("pix txid must have between %d and %d characters", pixMinTxIDLength, pixMaxTxIDLength)
+	}
+
+	for _, r := range txID {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return errors.New("pix txid must be alphanumeric")
+		}
+	}
+
+	return nil
+}
+
+// FormatPixAmount formata centavos no padrão da API Pix ("123.45")
+func FormatPixAmount(amount int64) string {
+	return fmt.Sprintf("%d.%02d", amount/100, amount%100)
+}
+
+// ParsePixAmount converte o valor da API Pix ("123.45") para centavos sem arredondamento de ponto flutuante
+func ParsePixAmount(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, errors.New("empty pix amount")
+	}
+
+	units, cents, hasCents := strings.Cut(value, ".")
+	if hasCents {
+		if len(cents) == 1 {
+			cents += "0"
+		}
+		if len(cents) != 2 {
+			return 0, fmt.Errorf("invalid pix amount: %s", value)
+		}
+	} else {
+		cents = "00"
+	}
+
+	var amount int64
+	for _, r := range units + cents {
+		if r < '0' || r > '9' {
+			return 0, fmt.Errorf("invalid pix amount: %s", value)
+		}
+		amount = amount*10 + int64(r-'0')
+	}
+
+	return amount, nil
+}
+
+// PixReconciliationResult resultado da conciliação do valor recebido
+type PixReconciliationResult string
+
+const (
+	PixReconciliationMatched   PixReconciliationResult = "matched"
+	PixReconciliationUnderpaid PixReconciliationResult = "underpaid"
+	PixReconciliationOverpaid  PixReconciliationResult = "overpaid"
+)
+
+// ReconcilePixAmount compara o valor recebido com o valor esperado (em centavos)
+func ReconcilePixAmount(expected, received int64) PixReconciliationResult {
+	switch {
+	case received == expected:
+		return PixReconciliationMatched
+	case received < expected:
+		return PixReconciliationUnderpaid
+	default:
+		return PixReconciliationOverpaid
+	}
+}
+
+// emvField monta um campo TLV (ID + tamanho com 2 dígitos + valor)
+func emvField(id, value string) string {
+	return fmt.Sprintf("%s%02d%s", id, len(value), value)
+}
+
+// normalizeEMV remove acentos e caracteres fora do conjunto permitido no BR Code
+func normalizeEMV(value string) string {
+	replacer := strings.NewReplacer(
+		"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+		"Á", "A", "À", "A", "Â", "A", "Ã", "A", "Ä", "A",
+		"é", "e", "ê", "e", "É", "E", "Ê", "E",
+		"í", "i", "Í", "I",
+		"ó", "o", "ô", "o", "õ", "o", "Ó", "O", "Ô", "O", "Õ", "O",
+		"ú", "u", "ü", "u", "Ú", "U", "Ü", "U",
+		"ç", "c", "Ç", "C",
+	)
+
+	var b strings.Builder
+	for _, r := range replacer.Replace(value) {
+		if r >= 0x20 && r <= 0x7E {
+			b.WriteRune(r)
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// truncateEMV limita o tamanho de um campo do BR Code
+func truncateEMV(value string, max int) string {
+	if len(value) > max {
+		return value[:max]
+	}
+	return value
+}
+
+// crc16CCITT calcula o CRC16-CCITT (polinômio 0x1021, valor inicial 0xFFFF)
+func crc16CCITT(data []byte) uint16 {
+	crc := uint16(0xFFFF)
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
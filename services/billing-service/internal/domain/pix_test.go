@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestPixBRCodeStaticMatchesBacenExample(t *testing.T) {
+	// Exemplo do Manual de Padrões para Iniciação do Pix (Banco Central)
+	expected := "00020126580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-4266554400005204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63041D3D"
+
+	payload, err := PixBRCode{
+		Key:          "123e4567-e12b-12d1-a456-426655440000",
+		MerchantName: "Fulano de Tal",
+		MerchantCity: "BRASILIA",
+	}.Payload()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if payload != expected {
+		t.Fatalf("payload mismatch\n got: %s\nwant: %s", payload, expected)
+	}
+
+	if !ValidatePixBRCode(payload) {
+		t.Fatal("expected payload CRC to be valid")
+	}
+}
+
+func TestPixBRCodeDynamic(t *testing.T) {
+	payload, err := PixBRCode{
+		Location:     "https://pix.example.com/qr/v2/9d36b84f",
+		MerchantName: "Direito Lux Tecnologia Jurídica LTDA",
+		MerchantCity: "São José dos Pinhais",
+		Amount:       19990,
+	}.Payload()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, field := range []string{"010212", "2530pix.example.com/qr/v2/9d36b84f", "5406199.90", "62070503***"} {
+		if !strings.Contains(payload, field) {
+			t.Errorf("expected payload to contain %q: %s", field, payload)
+		}
+	}
+
+	if !ValidatePixBRCode(payload) {
+		t.Fatal("expected payload CRC to be valid")
+	}
+
+	if ValidatePixBRCode(payload[:len(payload)-1] + "0") {
+		t.Fatal("expected tampered payload to be invalid")
+	}
+}
+
+func TestNewPixTxID(t *testing.T) {
+	txID := NewPixTxID(uuid.New(), 3)
+
+	if err := ValidatePixTxID(txID); err != nil {
+		t.Fatalf("generated txid is invalid: %v", err)
+	}
+
+	if !strings.HasSuffix(txID, "03") {
+		t.Fatalf("expected attempt suffix in txid: %s", txID)
+	}
+}
+
+func TestParsePixAmount(t *testing.T) {
+	cases := map[string]int64{
+		"199.90": 19990,
+		"0.01":   1,
+		"10":     1000,
+		"10.5":   1050,
+	}
+
+	for value, expected := range cases {
+		amount, err := ParsePixAmount(value)
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", value, err)
+		}
+		if amount != expected {
+			t.Errorf("ParsePixAmount(%s) = %d, want %d", value, amount, expected)
+		}
+	}
+
+	for _, value := range []string{"", "1.234", "-1.00", "abc"} {
+		if _, err := ParsePixAmount(value); err == nil {
+			t.Errorf("expected error for %q", value)
+		}
+	}
+}
+
+func TestReconcilePixAmount(t *testing.T) {
+	if ReconcilePixAmount(19990, 19990) != PixReconciliationMatched {
+		t.Error("expected matched")
+	}
+	if ReconcilePixAmount(19990, 19900) != PixReconciliationUnderpaid {
+		t.Error("expected underpaid")
+	}
+	if ReconcilePixAmount(19990, 20000) != PixReconciliationOverpaid {
+		t.Error("expected overpaid")
+	}
+}
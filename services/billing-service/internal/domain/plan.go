@@ -23,6 +23,10 @@ type Plan struct {
 	MaxAIRequests    int `json:"max_ai_requests"`
 	MaxBotCommands   int `json:"max_bot_commands"`
 	
+	// Preços de excedente (cobrança por uso, em centavos por consulta)
+	DataJudOveragePrice int64 `json:"datajud_overage_price"`
+	AIOveragePrice      int64 `json:"ai_overage_price"`
+	
 	// Funcionalidades
 	HasWhatsApp      bool `json:"has_whatsapp"`
 	HasTelegram      bool `json:"has_telegram"`
@@ -88,6 +92,18 @@ func (p *Plan) GetPriceFormatted(cycle BillingCycle) float64 {
 	return float64(p.GetPrice(cycle)) / 100
 }
 
+// GetOveragePrice retorna o preço unitário de excedente da métrica
+func (p *Plan) GetOveragePrice(metric UsageMetric) int64 {
+	switch metric {
+	case UsageMetricDataJudQueries:
+		return p.DataJudOveragePrice
+	case UsageMetricAIQueries:
+		return p.AIOveragePrice
+	default:
+		return 0
+	}
+}
+
 // IsEnterprise verifica se é um plano enterprise
 func (p *Plan) IsEnterprise() bool {
 	return p.Name == string(PlanTypeEnterprise)
@@ -137,6 +153,8 @@ func GetDefaultPlans() []*Plan {
 			MaxUsers:         2,
 			MaxAIRequests:    10,
 			MaxBotCommands:   0,
+			DataJudOveragePrice: 10, // R$ 0,10 por consulta
+			AIOveragePrice:      50, // R$ 0,50 por consulta
 			HasWhatsApp:      true,
 			HasTelegram:      true,
 			HasMCPBot:        false,
@@ -163,6 +181,8 @@ func GetDefaultPlans() []*Plan {
 			MaxUsers:         5,
 			MaxAIRequests:    50,
 			MaxBotCommands:   200,
+			DataJudOveragePrice: 8, // R$ 0,08 por consulta
+			AIOveragePrice:      40, // R$ 0,40 por consulta
 			HasWhatsApp:      true,
 			HasTelegram:      true,
 			HasMCPBot:        true,
@@ -189,6 +209,8 @@ func GetDefaultPlans() []*Plan {
 			MaxUsers:         15,
 			MaxAIRequests:    200,
 			MaxBotCommands:   1000,
+			DataJudOveragePrice: 5, // R$ 0,05 por consulta
+			AIOveragePrice:      30, // R$ 0,30 por consulta
 			HasWhatsApp:      true,
 			HasTelegram:      true,
 			HasMCPBot:        true,
@@ -215,6 +237,8 @@ func GetDefaultPlans() []*Plan {
 			MaxUsers:         -1, // Ilimitado
 			MaxAIRequests:    -1, // Ilimitado
 			MaxBotCommands:   -1, // Ilimitado
+			DataJudOveragePrice: 3, // R$ 0,03 por consulta
+			AIOveragePrice:      20, // R$ 0,20 por consulta
 			HasWhatsApp:      true,
 			HasTelegram:      true,
 			HasMCPBot:        true,
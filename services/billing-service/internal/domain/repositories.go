@@ -99,6 +99,12 @@ type PaymentRepository interface {
 	// GetByNOWPaymentID busca pagamento por ID do NOWPayments
 	GetByNOWPaymentID(ctx context.Context, nowPaymentID string) (*Payment, error)
 	
+	// GetByPixTxID busca pagamento pelo txid da cobrança PIX
+	GetByPixTxID(ctx context.Context, txID string) (*Payment, error)
+	
+	// GetExpiredPix busca cobranças PIX pendentes com QR Code expirado
+	GetExpiredPix(ctx context.Context, before time.Time, limit int) ([]*Payment, error)
+	
 	// Update atualiza um pagamento
 	Update(ctx context.Context, payment *Payment) error
 	
@@ -138,6 +144,9 @@ type InvoiceRepository interface {
 	// GetByNFE busca fatura por número da NF-e
 	GetByNFE(ctx context.Context, nfeNumber string) (*Invoice, error)
 	
+	// GetPendingNFE busca faturas pagas sem NFS-e emitida ou sem XML/PDF armazenados
+	GetPendingNFE(ctx context.Context, limit int) ([]*Invoice, error)
+	
 	// Update atualiza uma fatura
 	Update(ctx context.Context, invoice *Invoice) error
 	
@@ -178,6 +187,75 @@ type CustomerRepository interface {
 	GetStats(ctx context.Context, tenantID *uuid.UUID) (*CustomerStats, error)
 }
 
+// DunningRepository interface do repositório de processos de cobrança
+type DunningRepository interface {
+	// Create cria um novo processo de cobrança
+	Create(ctx context.Context, dunning *DunningCase) error
+	
+	// GetByID busca um processo de cobrança por ID
+	GetByID(ctx context.Context, id uuid.UUID) (*DunningCase, error)
+	
+	// GetOpenBySubscriptionID busca o processo em andamento ou suspenso da assinatura
+	GetOpenBySubscriptionID(ctx context.Context, subscriptionID uuid.UUID) (*DunningCase, error)
+	
+	// GetByTenantID busca processos de cobrança por tenant
+	GetByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*DunningCase, error)
+	
+	// GetDue busca processos com ação pendente até a data informada
+	GetDue(ctx context.Context, before time.Time, limit int) ([]*DunningCase, error)
+	
+	// Update atualiza um processo de cobrança
+	Update(ctx context.Context, dunning *DunningCase) error
+}
+
+// UsageChargeRepository interface do repositório de cobranças de excedente
+type UsageChargeRepository interface {
+	// Create cria uma nova cobrança de excedente
+	Create(ctx context.Context, charge *UsageCharge) error
+	
+	// GetPendingByTenantID busca cobranças ainda não faturadas do tenant
+	GetPendingByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*UsageCharge, error)
+	
+	// GetByInvoiceID busca cobranças lançadas em uma fatura
+	GetByInvoiceID(ctx context.Context, invoiceID uuid.UUID) ([]*UsageCharge, error)
+	
+	// SumByPeriod soma o valor cobrado do tenant no período de assinatura
+	SumByPeriod(ctx context.Context, tenantID uuid.UUID, periodStart time.Time) (int64, error)
+	
+	// Update atualiza uma cobrança de excedente
+	Update(ctx context.Context, charge *UsageCharge) error
+}
+
+// OverageSettingsRepository interface do repositório de configurações de excedente
+type OverageSettingsRepository interface {
+	// GetByTenantID busca a configuração do tenant
+	GetByTenantID(ctx context.Context, tenantID uuid.UUID) (*OverageSettings, error)
+	
+	// GetEnabled busca as configurações com cobrança por excedente ativa
+	GetEnabled(ctx context.Context) ([]*OverageSettings, error)
+	
+	// Save cria ou atualiza a configuração
+	Save(ctx context.Context, settings *OverageSettings) error
+}
+
+// LedgerRepository interface do razão contábil (somente inclusão)
+type LedgerRepository interface {
+	// Append inclui um lançamento balanceado; lançamentos nunca são alterados ou removidos
+	Append(ctx context.Context, entry *LedgerEntry) error
+	
+	// ExistsByEventID verifica se o evento de origem já foi lançado
+	ExistsByEventID(ctx context.Context, eventID uuid.UUID) (bool, error)
+	
+	// GetByReference busca os lançamentos de uma fatura, pagamento ou assinatura
+	GetByReference(ctx context.Context, referenceID uuid.UUID) ([]*LedgerEntry, error)
+	
+	// Query busca lançamentos com filtros
+	Query(ctx context.Context, query LedgerQuery) ([]*LedgerEntry, error)
+	
+	// GetBalances soma débitos e créditos por conta no intervalo (limites nulos são abertos)
+	GetBalances(ctx context.Context, tenantID *uuid.UUID, from, to *time.Time) ([]LedgerBalance, error)
+}
+
 // Estruturas de estatísticas
 
 // SubscriptionStats estatísticas de assinaturas
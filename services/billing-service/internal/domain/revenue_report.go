@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"time"
+)
+
+// RevenueReport relatório financeiro de receita recorrente, churn e receita diferida
+type RevenueReport struct {
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"`
+
+	// Receita recorrente (ao fim do período, em centavos)
+	MRR                 int64 `json:"mrr"`
+	ARR                 int64 `json:"arr"`
+	ActiveSubscriptions int64 `json:"active_subscriptions"`
+	NewSubscriptions    int64 `json:"new_subscriptions"`
+	NewMRR              int64 `json:"new_mrr"`
+
+	// Churn no período
+	ChurnedSubscriptions int64   `json:"churned_subscriptions"`
+	ChurnedMRR           int64   `json:"churned_mrr"`
+	CustomerChurnRate    float64 `json:"customer_churn_rate"` // %
+	RevenueChurnRate     float64 `json:"revenue_churn_rate"`  // %
+
+	// Razão contábil no período
+	RecognizedRevenue int64 `json:"recognized_revenue"`
+	Discounts         int64 `json:"discounts"`
+	Refunds           int64 `json:"refunds"`
+	CreditsGranted    int64 `json:"credits_granted"`
+	NetRevenue        int64 `json:"net_revenue"`
+
+	// Saldos ao fim do período
+	DeferredRevenue    int64 `json:"deferred_revenue"`
+	AccountsReceivable int64 `json:"accounts_receivable"`
+	CustomerCredits    int64 `json:"customer_credits"`
+	TaxPayable         int64 `json:"tax_payable"`
+
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// MonthlyRecurringAmount normaliza o valor da assinatura para um mês
+func MonthlyRecurringAmount(subscription *Subscription) int64 {
+	if subscription.BillingCycle == BillingCycleYearly {
+		return subscription.Amount / 12
+	}
+	return subscription.Amount
+}
+
+// NewRevenueReport calcula as métricas recorrentes a partir das assinaturas ativas e canceladas
+func NewRevenueReport(periodStart, periodEnd time.Time, active, cancelled []*Subscription) *RevenueReport {
+	report := &RevenueReport{
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		GeneratedAt: time.Now(),
+	}
+
+	var mrrAtStart, activeAtStart int64
+
+	for _, subscription := range active {
+		if subscription.IsInTrial() || !subscription.CreatedAt.Before(periodEnd) {
+			continue
+		}
+
+		mrr := MonthlyRecurringAmount(subscription)
+		report.MRR += mrr
+		report.ActiveSubscriptions++
+
+		if subscription.CreatedAt.Before(periodStart) {
+			mrrAtStart += mrr
+			activeAtStart++
+		} else {
+			report.NewSubscriptions++
+			report.NewMRR += mrr
+		}
+	}
+
+	for _, subscription := range cancelled {
+		if subscription.CancelledAt == nil || subscription.CancelledAt.Before(periodStart) || !subscription.CancelledAt.Before(periodEnd) {
+			continue
+		}
+		if !subscription.CreatedAt.Before(periodStart) {
+			// Entrou e saiu no mesmo período: não compõe a base inicial
+			continue
+		}
+
+		mrr := MonthlyRecurringAmount(subscription)
+		report.ChurnedSubscriptions++
+		report.ChurnedMRR += mrr
+		mrrAtStart += mrr
+		activeAtStart++
+	}
+
+	report.ARR = report.MRR * 12
+
+	if activeAtStart > 0 {
+		report.CustomerChurnRate = float64(report.ChurnedSubscriptions) / float64(activeAtStart) * 100
+	}
+	if mrrAtStart > 0 {
+		report.RevenueChurnRate = float64(report.ChurnedMRR) / float64(mrrAtStart) * 100
+	}
+
+	return report
+}
+
+// ApplyLedger preenche os valores contábeis com os movimentos do período e os saldos finais
+func (r *RevenueReport) ApplyLedger(movements, closing []LedgerBalance) {
+	for _, balance := range movements {
+		switch balance.Account {
+		case LedgerAccountRevenue:
+			r.RecognizedRevenue = balance.Balance()
+		case LedgerAccountDiscounts:
+			r.Discounts = balance.Balance()
+		case LedgerAccountRefunds:
+			r.Refunds = balance.Balance()
+		case LedgerAccountCreditsGranted:
+			r.CreditsGranted = balance.Balance()
+		}
+	}
+
+	for _, balance := range closing {
+		switch balance.Account {
+		case LedgerAccountDeferredRevenue:
+			r.DeferredRevenue = balance.Balance()
+		case LedgerAccountAccountsReceivable:
+			r.AccountsReceivable = balance.Balance()
+		case LedgerAccountCustomerCredits:
+			r.CustomerCredits = balance.Balance()
+		case LedgerAccountTaxPayable:
+			r.TaxPayable = balance.Balance()
+		}
+	}
+
+	r.NetRevenue = r.RecognizedRevenue - r.Discounts - r.Refunds - r.CreditsGranted
+}
+
+// TrialBalance balancete do razão
+type TrialBalance struct {
+	AsOf         time.Time       `json:"as_of"`
+	Accounts     []LedgerBalance `json:"accounts"`
+	TotalDebits  int64           `json:"total_debits"`
+	TotalCredits int64           `json:"total_credits"`
+	Balanced     bool            `json:"balanced"`
+}
+
+// NewTrialBalance monta o balancete a partir dos saldos das contas
+func NewTrialBalance(asOf time.Time, balances []LedgerBalance) *TrialBalance {
+	trial := &TrialBalance{AsOf: asOf, Accounts: balances}
+	for _, balance := range balances {
+		trial.TotalDebits += balance.Debits
+		trial.TotalCredits += balance.Credits
+	}
+	trial.Balanced = trial.TotalDebits == trial.TotalCredits
+	return trial
+}
@@ -135,6 +135,14 @@ func (s *Subscription) Suspend(reason string) {
 	s.UpdatedAt = time.Now()
 }
 
+// Reactivate reativa uma assinatura suspensa após regularização do pagamento
+func (s *Subscription) Reactivate() {
+	s.Status = SubscriptionStatusActive
+	s.CancelReason = nil
+	s.RetryCount = 0
+	s.UpdatedAt = time.Now()
+}
+
 // Cancel cancela a assinatura
 func (s *Subscription) Cancel(reason string, cancelledBy uuid.UUID) {
 	now := time.Now()
@@ -207,6 +215,11 @@ func (s *Subscription) IsCancelled() bool {
 	return s.Status == SubscriptionStatusCancelled
 }
 
+// IsSuspended verifica se a assinatura está suspensa
+func (s *Subscription) IsSuspended() bool {
+	return s.Status == SubscriptionStatusSuspended
+}
+
 // IsExpired verifica se a assinatura está expirada
 func (s *Subscription) IsExpired() bool {
 	return s.Status == SubscriptionStatusExpired || 
@@ -0,0 +1,231 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UsageMetric enumeração das métricas faturáveis por excedente
+type UsageMetric string
+
+const (
+	UsageMetricDataJudQueries UsageMetric = "datajud_queries"
+	UsageMetricAIQueries      UsageMetric = "ai_queries"
+)
+
+// Description retorna a descrição da métrica na fatura
+func (m UsageMetric) Description() string {
+	switch m {
+	case UsageMetricDataJudQueries:
+		return "Consultas DataJud excedentes"
+	case UsageMetricAIQueries:
+		return "Consultas de IA excedentes"
+	default:
+		return string(m)
+	}
+}
+
+// UsageSnapshot contadores acumulados de excedente informados pelo tenant-service.
+// Os totais nunca são resetados; o faturamento cobra a diferença desde o último snapshot.
+type UsageSnapshot struct {
+	TenantID            uuid.UUID `json:"tenant_id"`
+	DataJudOverageTotal int64     `json:"datajud_overage_total"`
+	AIOverageTotal      int64     `json:"ai_overage_total"`
+	CapturedAt          time.Time `json:"captured_at"`
+}
+
+// UsageChargeStatus enumeração dos status da cobrança de uso
+type UsageChargeStatus string
+
+const (
+	UsageChargeStatusPending  UsageChargeStatus = "pending"
+	UsageChargeStatusInvoiced UsageChargeStatus = "invoiced"
+)
+
+// UsageCharge cobrança de excedente aguardando a próxima fatura
+type UsageCharge struct {
+	ID             uuid.UUID         `json:"id"`
+	TenantID       uuid.UUID         `json:"tenant_id"`
+	SubscriptionID uuid.UUID         `json:"subscription_id"`
+	InvoiceID      *uuid.UUID        `json:"invoice_id"`
+	Metric         UsageMetric       `json:"metric"`
+	Quantity       int64             `json:"quantity"`
+	UnitPrice      int64             `json:"unit_price"` // em centavos
+	Amount         int64             `json:"amount"`     // em centavos
+	Status         UsageChargeStatus `json:"status"`
+
+	// Período de assinatura em que o uso ocorreu
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"`
+
+	InvoicedAt *time.Time `json:"invoiced_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// NewUsageCharge cria uma cobrança de excedente tarifada pelo preço do plano
+func NewUsageCharge(tenantID, subscriptionID uuid.UUID, metric UsageMetric, quantity, unitPrice int64, periodStart, periodEnd time.Time) *UsageCharge {
+	now := time.Now()
+
+	return &UsageCharge{
+		ID:             uuid.New(),
+		TenantID:       tenantID,
+		SubscriptionID: subscriptionID,
+		Metric:         metric,
+		Quantity:       quantity,
+		UnitPrice:      unitPrice,
+		Amount:         quantity * unitPrice,
+		Status:         UsageChargeStatusPending,
+		PeriodStart:    periodStart,
+		PeriodEnd:      periodEnd,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// MarkAsInvoiced vincula a cobrança à fatura em que foi lançada
+func (c *UsageCharge) MarkAsInvoiced(invoiceID uuid.UUID) {
+	now := time.Now()
+	c.InvoiceID = &invoiceID
+	c.Status = UsageChargeStatusInvoiced
+	c.InvoicedAt = &now
+	c.UpdatedAt = now
+}
+
+// IsPending verifica se a cobrança ainda não foi faturada
+func (c *UsageCharge) IsPending() bool {
+	return c.Status == UsageChargeStatusPending
+}
+
+// ToInvoiceItem converte a cobrança em item da fatura
+func (c *UsageCharge) ToInvoiceItem() InvoiceItem {
+	return InvoiceItem{
+		Description: c.Metric.Description(),
+		Quantity:    int(c.Quantity),
+		UnitPrice:   c.UnitPrice,
+		TotalPrice:  c.Amount,
+		PeriodStart: c.PeriodStart,
+		PeriodEnd:   c.PeriodEnd,
+	}
+}
+
+// OverageSettings configuração da cobrança por excedente do tenant
+type OverageSettings struct {
+	TenantID    uuid.UUID `json:"tenant_id"`
+	Enabled     bool      `json:"enabled"`
+	SpendingCap int64     `json:"spending_cap"` // em centavos por período; zero = sem teto
+
+	// Bloqueio ao atingir o teto de gastos
+	Blocked   bool       `json:"blocked"`
+	BlockedAt *time.Time `json:"blocked_at"`
+
+	// Últimos totais acumulados já tarifados
+	LastDataJudTotal int64      `json:"last_datajud_total"`
+	LastAITotal      int64      `json:"last_ai_total"`
+	LastSyncedAt     *time.Time `json:"last_synced_at"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewOverageSettings cria a configuração de excedente (desativada) do tenant
+func NewOverageSettings(tenantID uuid.UUID) *OverageSettings {
+	now := time.Now()
+
+	return &OverageSettings{
+		TenantID:  tenantID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Configure ativa ou desativa a cobrança por excedente e define o teto de gastos
+func (o *OverageSettings) Configure(enabled bool, spendingCap int64) error {
+	if spendingCap < 0 {
+		return errors.New("spending cap cannot be negative")
+	}
+
+	o.Enabled = enabled
+	o.SpendingCap = spendingCap
+	o.UpdatedAt = time.Now()
+	return nil
+}
+
+// Delta calcula o uso ainda não tarifado desde o último snapshot
+func (o *OverageSettings) Delta(snapshot *UsageSnapshot) map[UsageMetric]int64 {
+	delta := make(map[UsageMetric]int64)
+
+	if diff := snapshot.DataJudOverageTotal - o.LastDataJudTotal; diff > 0 {
+		delta[UsageMetricDataJudQueries] = diff
+	}
+
+	if diff := snapshot.AIOverageTotal - o.LastAITotal; diff > 0 {
+		delta[UsageMetricAIQueries] = diff
+	}
+
+	return delta
+}
+
+// Advance registra o snapshot como tarifado
+func (o *OverageSettings) Advance(snapshot *UsageSnapshot) {
+	o.LastDataJudTotal = snapshot.DataJudOverageTotal
+	o.LastAITotal = snapshot.AIOverageTotal
+	o.LastSyncedAt = &snapshot.CapturedAt
+	o.UpdatedAt = time.Now()
+}
+
+// HasCap verifica se há teto de gastos configurado
+func (o *OverageSettings) HasCap() bool {
+	return o.SpendingCap > 0
+}
+
+// RemainingCap retorna quanto ainda pode ser cobrado no período
+func (o *OverageSettings) RemainingCap(periodTotal int64) int64 {
+	if !o.HasCap() {
+		return -1
+	}
+
+	if remaining := o.SpendingCap - periodTotal; remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// CapReached verifica se o total do período atingiu o teto de gastos
+func (o *OverageSettings) CapReached(periodTotal int64) bool {
+	return o.HasCap() && periodTotal >= o.SpendingCap
+}
+
+// Block volta a bloquear o tenant ao atingir o teto
+func (o *OverageSettings) Block() {
+	now := time.Now()
+	o.Blocked = true
+	o.BlockedAt = &now
+	o.UpdatedAt = now
+}
+
+// Unblock libera o excedente para um novo período
+func (o *OverageSettings) Unblock() {
+	o.Blocked = false
+	o.BlockedAt = nil
+	o.UpdatedAt = time.Now()
+}
+
+// RateUsage tarifa a quantidade respeitando o saldo do teto de gastos.
+// Retorna a quantidade cobrada; o uso além do teto não é cobrado.
+func RateUsage(quantity, unitPrice, remainingCap int64) int64 {
+	if quantity <= 0 || unitPrice <= 0 {
+		return 0
+	}
+
+	if remainingCap < 0 { // Sem teto
+		return quantity
+	}
+
+	if affordable := remainingCap / unitPrice; affordable < quantity {
+		return affordable
+	}
+	return quantity
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestRateUsageRespectsSpendingCap(t *testing.T) {
+	cases := []struct {
+		quantity, unitPrice, remainingCap, expected int64
+	}{
+		{100, 10, -1, 100}, // sem teto
+		{100, 10, 5000, 100},
+		{100, 10, 505, 50}, // uso além do teto não é cobrado
+		{100, 10, 0, 0},
+		{100, 0, -1, 0}, // plano sem preço de excedente
+	}
+
+	for _, c := range cases {
+		if got := RateUsage(c.quantity, c.unitPrice, c.remainingCap); got != c.expected {
+			t.Errorf("RateUsage(%d, %d, %d) = %d, want %d", c.quantity, c.unitPrice, c.remainingCap, got, c.expected)
+		}
+	}
+}
+
+func TestOverageSettingsDeltaAndCap(t *testing.T) {
+	settings := NewOverageSettings(uuid.New())
+	if err := settings.Configure(true, 1000); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	settings.Advance(&UsageSnapshot{DataJudOverageTotal: 10, AIOverageTotal: 4})
+
+	delta := settings.Delta(&UsageSnapshot{DataJudOverageTotal: 25, AIOverageTotal: 4})
+	if delta[UsageMetricDataJudQueries] != 15 {
+		t.Errorf("expected 15 DataJud queries, got %d", delta[UsageMetricDataJudQueries])
+	}
+	if _, ok := delta[UsageMetricAIQueries]; ok {
+		t.Error("expected no AI delta")
+	}
+
+	if settings.RemainingCap(800) != 200 || settings.CapReached(800) {
+		t.Error("expected 200 remaining below the cap")
+	}
+	if !settings.CapReached(1000) || settings.RemainingCap(1200) != 0 {
+		t.Error("expected cap reached")
+	}
+}
+
+func TestInvoiceAddItemRecalculatesTotals(t *testing.T) {
+	invoice := NewInvoice(uuid.New(), uuid.New(), 10000, time.Now(), time.Now())
+
+	charge := NewUsageCharge(invoice.TenantID, invoice.SubscriptionID, UsageMetricAIQueries, 20, 50, invoice.PeriodStart, invoice.PeriodEnd)
+	invoice.AddItem(charge.ToInvoiceItem())
+
+	if invoice.Amount != 11000 {
+		t.Fatalf("expected amount 11000, got %d", invoice.Amount)
+	}
+	if invoice.TaxAmount != 220 || invoice.TotalAmount != 11220 {
+		t.Fatalf("unexpected totals: tax %d total %d", invoice.TaxAmount, invoice.TotalAmount)
+	}
+
+	items := invoice.GetInvoiceItems()
+	if len(items) != 2 || items[0].TotalPrice != 10000 || items[1].TotalPrice != 1000 {
+		t.Fatalf("unexpected invoice items: %+v", items)
+	}
+}
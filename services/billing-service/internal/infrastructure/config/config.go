@@ -36,6 +36,18 @@ type Config struct {
 
 	// External Services
 	Services ExternalServicesConfig
+
+	// Dunning
+	Dunning DunningConfig
+
+	// NFS-e
+	NFSe NFSeConfig
+
+	// PIX
+	Pix PixConfig
+
+	// Cobrança por excedente de uso
+	Usage UsageConfig
 }
 
 // DatabaseConfig configuração do banco de dados
@@ -123,6 +135,7 @@ type ExternalServicesConfig struct {
 	DataJudServiceURL      string        `envconfig:"DATAJUD_SERVICE_URL" default:"http://datajud-service:8080"`
 	NotificationServiceURL string        `envconfig:"NOTIFICATION_SERVICE_URL" default:"http://notification-service:8080"`
 	AIServiceURL           string        `envconfig:"AI_SERVICE_URL" default:"http://ai-service:8000"`
+	InternalServiceToken   string        `envconfig:"INTERNAL_SERVICE_TOKEN"`
 	
 	// Timeouts
 	DefaultTimeout time.Duration `envconfig:"EXTERNAL_SERVICE_TIMEOUT" default:"30s"`
@@ -135,6 +148,52 @@ type ExternalServicesConfig struct {
 	CircuitBreakerFailureThreshold uint32       `envconfig:"CIRCUIT_BREAKER_FAILURE_THRESHOLD" default:"5"`
 }
 
+// DunningConfig configuração da régua de cobrança de inadimplentes
+type DunningConfig struct {
+	RetryDays       []int         `envconfig:"DUNNING_RETRY_DAYS" default:"1,3,7"`
+	Channels        []string      `envconfig:"DUNNING_CHANNELS" default:"email,whatsapp"`
+	GracePeriodDays int           `envconfig:"DUNNING_GRACE_PERIOD_DAYS" default:"3"`
+	CheckInterval   time.Duration `envconfig:"DUNNING_CHECK_INTERVAL" default:"1h"`
+	BatchSize       int           `envconfig:"DUNNING_BATCH_SIZE" default:"100"`
+}
+
+// NFSeConfig configuração da emissão de NFS-e
+type NFSeConfig struct {
+	Provider           string        `envconfig:"NFSE_PROVIDER" default:"stub"` // stub, focusnfe
+	APIURL             string        `envconfig:"NFSE_API_URL" default:"https://homologacao.focusnfe.com.br"`
+	APIToken           string        `envconfig:"NFSE_API_TOKEN"`
+	IssuerCNPJ         string        `envconfig:"NFSE_ISSUER_CNPJ" default:"12345678000190"`
+	IssuerRegistration string        `envconfig:"NFSE_ISSUER_MUNICIPAL_REGISTRATION"`
+	MunicipalityCode   string        `envconfig:"NFSE_MUNICIPALITY_CODE" default:"4106902"` // Curitiba (IBGE)
+	MunicipalitiesFile string        `envconfig:"NFSE_MUNICIPALITIES_FILE"`
+	StoragePath        string        `envconfig:"NFSE_STORAGE_PATH" default:"./data/nfse"`
+	StorageBaseURL     string        `envconfig:"NFSE_STORAGE_BASE_URL" default:"http://localhost:8089/nfse"`
+	PollInterval       time.Duration `envconfig:"NFSE_POLL_INTERVAL" default:"2s"`
+	PollTimeout        time.Duration `envconfig:"NFSE_POLL_TIMEOUT" default:"60s"`
+}
+
+// PixConfig configuração das cobranças PIX (API Pix do Banco Central)
+type PixConfig struct {
+	Provider       string        `envconfig:"PIX_PROVIDER" default:"fake"` // fake, api
+	APIURL         string        `envconfig:"PIX_API_URL"`
+	OAuthURL       string        `envconfig:"PIX_OAUTH_URL"`
+	ClientID       string        `envconfig:"PIX_CLIENT_ID"`
+	ClientSecret   string        `envconfig:"PIX_CLIENT_SECRET"`
+	CertFile       string        `envconfig:"PIX_CERT_FILE"` // certificado mTLS do PSP
+	KeyFile        string        `envconfig:"PIX_KEY_FILE"`
+	Key            string        `envconfig:"PIX_KEY"` // chave Pix recebedora
+	MerchantName   string        `envconfig:"PIX_MERCHANT_NAME" default:"Direito Lux"`
+	MerchantCity   string        `envconfig:"PIX_MERCHANT_CITY" default:"Curitiba"`
+	Expiration     time.Duration `envconfig:"PIX_EXPIRATION" default:"1h"`
+	ExpiryInterval time.Duration `envconfig:"PIX_EXPIRY_CHECK_INTERVAL" default:"5m"`
+}
+
+// UsageConfig configuração da cobrança por excedente de quotas
+type UsageConfig struct {
+	SyncInterval       time.Duration `envconfig:"USAGE_SYNC_INTERVAL" default:"15m"`
+	DefaultSpendingCap int64         `envconfig:"USAGE_DEFAULT_SPENDING_CAP" default:"50000"` // em centavos (R$ 500,00)
+}
+
 // Load carrega configurações das variáveis de ambiente
 func Load() (*Config, error) {
 	var cfg Config
@@ -184,6 +243,38 @@ func (c *Config) validate() error {
 		return fmt.Errorf("environment inválido: %s", c.Environment)
 	}
 
+	// Validar régua de cobrança
+	if len(c.Dunning.RetryDays) == 0 {
+		return fmt.Errorf("régua de cobrança sem etapas")
+	}
+
+	if c.Dunning.GracePeriodDays < 0 {
+		return fmt.Errorf("período de carência inválido: %d", c.Dunning.GracePeriodDays)
+	}
+
+	// Validar cobrança por excedente
+	if c.Usage.SyncInterval <= 0 {
+		return fmt.Errorf("intervalo de sincronização de uso inválido: %s", c.Usage.SyncInterval)
+	}
+
+	if c.Usage.DefaultSpendingCap < 0 {
+		return fmt.Errorf("teto de gastos padrão inválido: %d", c.Usage.DefaultSpendingCap)
+	}
+
+	// Validar PIX
+	if c.Pix.Provider != "fake" && (c.Pix.APIURL == "" || c.Pix.ClientID == "" || c.Pix.Key == "") {
+		return fmt.Errorf("PIX_API_URL, PIX_CLIENT_ID e PIX_KEY são obrigatórios para o provedor %s", c.Pix.Provider)
+	}
+
+	if c.Pix.Expiration <= 0 {
+		return fmt.Errorf("expiração do PIX inválida: %s", c.Pix.Expiration)
+	}
+
+	// Validar emissão de NFS-e
+	if c.NFSe.Provider != "stub" && c.NFSe.APIToken == "" {
+		return fmt.Errorf("token do provedor de NFS-e é obrigatório para %s", c.NFSe.Provider)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,63 @@
+package gateways
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/direito-lux/billing-service/internal/domain"
+	"github.com/direito-lux/billing-service/internal/infrastructure/config"
+)
+
+// LocalDocumentStorage armazena documentos fiscais no sistema de arquivos
+type LocalDocumentStorage struct {
+	basePath string
+	baseURL  string
+}
+
+// NewLocalDocumentStorage cria novo armazenamento local de documentos
+func NewLocalDocumentStorage(cfg *config.Config) *LocalDocumentStorage {
+	return &LocalDocumentStorage{
+		basePath: cfg.NFSe.StoragePath,
+		baseURL:  strings.TrimRight(cfg.NFSe.StorageBaseURL, "/"),
+	}
+}
+
+// Store grava o documento e retorna o endereço público
+func (s *LocalDocumentStorage) Store(ctx context.Context, path, contentType string, data []byte) (string, error) {
+	clean := filepath.Clean("/" + path)
+	fullPath := filepath.Join(s.basePath, clean)
+
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create storage directory: %w", err)
+	}
+
+	if err := os.WriteFile(fullPath, data, 0o644); err != nil {
+		return "", fmt.Errorf("failed to write document: %w", err)
+	}
+
+	return s.baseURL + filepath.ToSlash(clean), nil
+}
+
+// LoadNFSeTaxTable carrega a tabela de municípios do arquivo configurado
+// ou utiliza a configuração padrão (Curitiba)
+func LoadNFSeTaxTable(cfg *config.Config) (*domain.NFSeTaxTable, error) {
+	municipalities := domain.DefaultNFSeMunicipalities()
+
+	if cfg.NFSe.MunicipalitiesFile != "" {
+		data, err := os.ReadFile(cfg.NFSe.MunicipalitiesFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read municipalities file: %w", err)
+		}
+
+		municipalities = nil
+		if err := json.Unmarshal(data, &municipalities); err != nil {
+			return nil, fmt.Errorf("failed to parse municipalities file: %w", err)
+		}
+	}
+
+	return domain.NewNFSeTaxTable(municipalities)
+}
@@ -0,0 +1,371 @@
+package gateways
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/direito-lux/billing-service/internal/application"
+	"github.com/direito-lux/billing-service/internal/infrastructure/config"
+)
+
+// Status de processamento da NFS-e no Focus NFe
+const (
+	focusStatusProcessing = "processando_autorizacao"
+	focusStatusAuthorized = "autorizado"
+	focusStatusError      = "erro_autorizacao"
+	focusStatusCancelled  = "cancelado"
+)
+
+// NewNFSeProvider cria o provedor de NFS-e configurado
+func NewNFSeProvider(cfg *config.Config) (application.NFSeProviderInterface, error) {
+	switch cfg.NFSe.Provider {
+	case "stub":
+		return NewStubNFSeProvider(), nil
+	case "focusnfe":
+		return NewFocusNFSeProvider(cfg), nil
+	default:
+		return nil, fmt.Errorf("unsupported NFS-e provider: %s", cfg.NFSe.Provider)
+	}
+}
+
+// FocusNFSeProvider adaptador para a API de NFS-e do Focus NFe
+type FocusNFSeProvider struct {
+	baseURL      string
+	token        string
+	pollInterval time.Duration
+	pollTimeout  time.Duration
+	client       *http.Client
+}
+
+// NewFocusNFSeProvider cria novo cliente do Focus NFe
+func NewFocusNFSeProvider(cfg *config.Config) *FocusNFSeProvider {
+	return &FocusNFSeProvider{
+		baseURL:      strings.TrimRight(cfg.NFSe.APIURL, "/"),
+		token:        cfg.NFSe.APIToken,
+		pollInterval: cfg.NFSe.PollInterval,
+		pollTimeout:  cfg.NFSe.PollTimeout,
+		client: &http.Client{
+			Timeout: cfg.Services.DefaultTimeout,
+		},
+	}
+}
+
+// focusNFSe representação da nota na API do Focus NFe
+type focusNFSe struct {
+	DataEmissao string         `json:"data_emissao"`
+	Prestador   focusPrestador `json:"prestador"`
+	Tomador     focusTomador   `json:"tomador"`
+	Servico     focusServico   `json:"servico"`
+}
+
+type focusPrestador struct {
+	CNPJ               string `json:"cnpj"`
+	InscricaoMunicipal string `json:"inscricao_municipal,omitempty"`
+	CodigoMunicipio    string `json:"codigo_municipio"`
+}
+
+type focusTomador struct {
+	CPF         string         `json:"cpf,omitempty"`
+	CNPJ        string         `json:"cnpj,omitempty"`
+	RazaoSocial string         `json:"razao_social"`
+	Email       string         `json:"email,omitempty"`
+	Endereco    *focusEndereco `json:"endereco,omitempty"`
+}
+
+type focusEndereco struct {
+	Logradouro      string `json:"logradouro"`
+	Numero          string `json:"numero"`
+	Complemento     string `json:"complemento,omitempty"`
+	Bairro          string `json:"bairro"`
+	CodigoMunicipio string `json:"codigo_municipio,omitempty"`
+	UF              string `json:"uf"`
+	CEP             string `json:"cep"`
+}
+
+type focusServico struct {
+	Aliquota                  float64 `json:"aliquota"`
+	Discriminacao             string  `json:"discriminacao"`
+	ISSRetido                 bool    `json:"iss_retido"`
+	ItemListaServico          string  `json:"item_lista_servico"`
+	CodigoTributarioMunicipio string  `json:"codigo_tributario_municipio,omitempty"`
+	CodigoCNAE                string  `json:"codigo_cnae,omitempty"`
+	ValorServicos             float64 `json:"valor_servicos"`
+	ValorISS                  float64 `json:"valor_iss"`
+}
+
+// focusStatusResponse resposta de consulta da nota
+type focusStatusResponse struct {
+	Status               string       `json:"status"`
+	Numero               string       `json:"numero"`
+	CodigoVerificacao    string       `json:"codigo_verificacao"`
+	URL                  string       `json:"url"`
+	URLDanfse            string       `json:"url_danfse"`
+	CaminhoXMLNotaFiscal string       `json:"caminho_xml_nota_fiscal"`
+	Erros                []focusError `json:"erros"`
+	Mensagem             string       `json:"mensagem"`
+}
+
+type focusError struct {
+	Codigo   string `json:"codigo"`
+	Mensagem string `json:"mensagem"`
+}
+
+// IssueNFSe envia a nota e aguarda a autorização da prefeitura
+func (p *FocusNFSeProvider) IssueNFSe(ctx context.Context, request *application.NFSeIssueRequest) (*application.NFSeIssueResult, error) {
+	endpoint := fmt.Sprintf("%s/v2/nfse?ref=%s", p.baseURL, url.QueryEscape(request.Reference))
+
+	resp, err := p.do(ctx, http.MethodPost, endpoint, toFocusNFSe(request))
+	if err != nil {
+		return nil, err
+	}
+
+	// Status diferente de processamento ou autorizada indica rejeição imediata
+	if resp.Status != focusStatusProcessing && resp.Status != focusStatusAuthorized && resp.Status != "" {
+		return nil, resp.err()
+	}
+
+	status, err := p.waitAuthorization(ctx, request.Reference)
+	if err != nil {
+		return nil, err
+	}
+
+	return &application.NFSeIssueResult{
+		Number:           status.Numero,
+		VerificationCode: status.CodigoVerificacao,
+		URL:              status.URL,
+	}, nil
+}
+
+// waitAuthorization consulta a nota até a autorização ou o tempo limite
+func (p *FocusNFSeProvider) waitAuthorization(ctx context.Context, reference string) (*focusStatusResponse, error) {
+	deadline := time.Now().Add(p.pollTimeout)
+
+	for {
+		status, err := p.getStatus(ctx, reference)
+		if err != nil {
+			return nil, err
+		}
+
+		switch status.Status {
+		case focusStatusAuthorized:
+			return status, nil
+		case focusStatusError:
+			return nil, status.err()
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("NFS-e %s still processing after %s", reference, p.pollTimeout)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(p.pollInterval):
+		}
+	}
+}
+
+// CancelNFSe cancela a nota na prefeitura
+func (p *FocusNFSeProvider) CancelNFSe(ctx context.Context, reference, reason string) error {
+	endpoint := fmt.Sprintf("%s/v2/nfse/%s", p.baseURL, url.PathEscape(reference))
+
+	resp, err := p.do(ctx, http.MethodDelete, endpoint, map[string]string{"justificativa": reason})
+	if err != nil {
+		return err
+	}
+
+	if resp.Status != focusStatusCancelled {
+		return resp.err()
+	}
+
+	return nil
+}
+
+// DownloadXML baixa o XML da nota autorizada
+func (p *FocusNFSeProvider) DownloadXML(ctx context.Context, reference string) ([]byte, error) {
+	status, err := p.getStatus(ctx, reference)
+	if err != nil {
+		return nil, err
+	}
+
+	if status.CaminhoXMLNotaFiscal == "" {
+		return nil, fmt.Errorf("NFS-e %s has no XML available", reference)
+	}
+
+	return p.download(ctx, p.baseURL+status.CaminhoXMLNotaFiscal)
+}
+
+// DownloadPDF baixa o PDF (DANFSe) da nota autorizada
+func (p *FocusNFSeProvider) DownloadPDF(ctx context.Context, reference string) ([]byte, error) {
+	status, err := p.getStatus(ctx, reference)
+	if err != nil {
+		return nil, err
+	}
+
+	pdfURL := status.URLDanfse
+	if pdfURL == "" {
+		pdfURL = status.URL
+	}
+
+	if pdfURL == "" {
+		return nil, fmt.Errorf("NFS-e %s has no PDF available", reference)
+	}
+
+	return p.download(ctx, pdfURL)
+}
+
+// getStatus consulta a situação da nota
+func (p *FocusNFSeProvider) getStatus(ctx context.Context, reference string) (*focusStatusResponse, error) {
+	endpoint := fmt.Sprintf("%s/v2/nfse/%s", p.baseURL, url.PathEscape(reference))
+	return p.do(ctx, http.MethodGet, endpoint, nil)
+}
+
+// do executa uma chamada à API do Focus NFe
+func (p *FocusNFSeProvider) do(ctx context.Context, method, endpoint string, payload interface{}) (*focusStatusResponse, error) {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(p.token, "")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call NFS-e provider: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read NFS-e provider response: %w", err)
+	}
+
+	var status focusStatusResponse
+	if len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, &status); err != nil {
+			return nil, fmt.Errorf("failed to decode NFS-e provider response: %w", err)
+		}
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, fmt.Errorf("NFS-e provider returned status %d: %w", resp.StatusCode, status.err())
+	}
+
+	return &status, nil
+}
+
+// download baixa um arquivo do provedor
+func (p *FocusNFSeProvider) download(ctx context.Context, fileURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if strings.HasPrefix(fileURL, p.baseURL) {
+		req.SetBasicAuth(p.token, "")
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download NFS-e file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, fmt.Errorf("NFS-e file download returned status %d", resp.StatusCode)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// err converte a resposta de erro do provedor
+func (r *focusStatusResponse) err() error {
+	if len(r.Erros) > 0 {
+		messages := make([]string, 0, len(r.Erros))
+		for _, e := range r.Erros {
+			messages = append(messages, fmt.Sprintf("%s: %s", e.Codigo, e.Mensagem))
+		}
+		return fmt.Errorf("NFS-e rejected: %s", strings.Join(messages, "; "))
+	}
+
+	if r.Mensagem != "" {
+		return fmt.Errorf("NFS-e rejected: %s", r.Mensagem)
+	}
+
+	return fmt.Errorf("unexpected NFS-e status: %s", r.Status)
+}
+
+// toFocusNFSe converte a requisição para o formato do Focus NFe
+func toFocusNFSe(request *application.NFSeIssueRequest) *focusNFSe {
+	tomador := focusTomador{
+		RazaoSocial: request.CustomerName,
+		Email:       request.CustomerEmail,
+	}
+
+	document := onlyDigits(request.CustomerDocument)
+	if len(document) == 14 {
+		tomador.CNPJ = document
+	} else {
+		tomador.CPF = document
+	}
+
+	if address := request.CustomerAddress; address != nil {
+		tomador.Endereco = &focusEndereco{
+			Logradouro:      address.Street,
+			Numero:          address.Number,
+			Complemento:     address.Complement,
+			Bairro:          address.Neighborhood,
+			CodigoMunicipio: request.CustomerMunicipality,
+			UF:              address.State,
+			CEP:             onlyDigits(address.ZipCode),
+		}
+	}
+
+	return &focusNFSe{
+		DataEmissao: request.IssueDate.Format(time.RFC3339),
+		Prestador: focusPrestador{
+			CNPJ:               onlyDigits(request.IssuerCNPJ),
+			InscricaoMunicipal: request.IssuerRegistration,
+			CodigoMunicipio:    request.IssuerMunicipality,
+		},
+		Tomador: tomador,
+		Servico: focusServico{
+			Aliquota:                  request.ISSRate * 100,
+			Discriminacao:             request.Description,
+			ISSRetido:                 false,
+			ItemListaServico:          strings.ReplaceAll(request.ServiceCode, ".", ""),
+			CodigoTributarioMunicipio: request.MunicipalTaxCode,
+			CodigoCNAE:                request.CNAE,
+			ValorServicos:             float64(request.Amount) / 100,
+			ValorISS:                  float64(request.ISSAmount) / 100,
+		},
+	}
+}
+
+// onlyDigits remove a formatação de documentos e CEP
+func onlyDigits(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
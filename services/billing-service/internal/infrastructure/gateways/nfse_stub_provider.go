@@ -0,0 +1,179 @@
+package gateways
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/direito-lux/billing-service/internal/application"
+)
+
+// StubNFSeProvider provedor local de NFS-e para desenvolvimento e testes.
+// Emite notas fictícias em memória, sem comunicação com a prefeitura.
+type StubNFSeProvider struct {
+	mu       sync.Mutex
+	sequence int
+	notes    map[string]*stubNFSe
+}
+
+type stubNFSe struct {
+	request   *application.NFSeIssueRequest
+	result    *application.NFSeIssueResult
+	cancelled bool
+}
+
+// NewStubNFSeProvider cria novo provedor local de NFS-e
+func NewStubNFSeProvider() *StubNFSeProvider {
+	return &StubNFSeProvider{
+		notes: make(map[string]*stubNFSe),
+	}
+}
+
+// IssueNFSe emite uma nota fictícia (idempotente pela referência)
+func (p *StubNFSeProvider) IssueNFSe(ctx context.Context, request *application.NFSeIssueRequest) (*application.NFSeIssueResult, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if note, exists := p.notes[request.Reference]; exists {
+		return note.result, nil
+	}
+
+	p.sequence++
+	number := fmt.Sprintf("%d%06d", request.IssueDate.Year(), p.sequence)
+	code := strings.ToUpper(strings.ReplaceAll(request.Reference, "-", "")[:8])
+
+	result := &application.NFSeIssueResult{
+		Number:           number,
+		VerificationCode: code,
+		URL:              fmt.Sprintf("https://nfse.local/%s/%s", request.IssuerMunicipality, number),
+	}
+	result.XML = p.renderXML(request, result)
+	result.PDF = p.renderPDF(request, result)
+
+	p.notes[request.Reference] = &stubNFSe{
+		request: request,
+		result:  result,
+	}
+
+	return result, nil
+}
+
+// CancelNFSe cancela a nota fictícia
+func (p *StubNFSeProvider) CancelNFSe(ctx context.Context, reference, reason string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	note, exists := p.notes[reference]
+	if !exists {
+		return fmt.Errorf("NFS-e not found: %s", reference)
+	}
+
+	note.cancelled = true
+	return nil
+}
+
+// DownloadXML retorna o XML da nota fictícia
+func (p *StubNFSeProvider) DownloadXML(ctx context.Context, reference string) ([]byte, error) {
+	note, err := p.get(reference)
+	if err != nil {
+		return nil, err
+	}
+	return note.result.XML, nil
+}
+
+// DownloadPDF retorna o PDF da nota fictícia
+func (p *StubNFSeProvider) DownloadPDF(ctx context.Context, reference string) ([]byte, error) {
+	note, err := p.get(reference)
+	if err != nil {
+		return nil, err
+	}
+	return note.result.PDF, nil
+}
+
+// IsCancelled verifica se a nota fictícia foi cancelada
+func (p *StubNFSeProvider) IsCancelled(reference string) bool {
+	note, err := p.get(reference)
+	return err == nil && note.cancelled
+}
+
+func (p *StubNFSeProvider) get(reference string) (*stubNFSe, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	note, exists := p.notes[reference]
+	if !exists {
+		return nil, fmt.Errorf("NFS-e not found: %s", reference)
+	}
+	return note, nil
+}
+
+// renderXML gera um XML simplificado no layout ABRASF
+func (p *StubNFSeProvider) renderXML(request *application.NFSeIssueRequest, result *application.NFSeIssueResult) []byte {
+	return []byte(fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<CompNfse>
+  <Nfse>
+    <InfNfse>
+      <Numero>%s</Numero>
+      <CodigoVerificacao>%s</CodigoVerificacao>
+      <DataEmissao>%s</DataEmissao>
+      <Servico>
+        <Valores>
+          <ValorServicos>%.2f</ValorServicos>
+          <ValorIss>%.2f</ValorIss>
+          <Aliquota>%.4f</Aliquota>
+        </Valores>
+        <ItemListaServico>%s</ItemListaServico>
+        <Discriminacao>%s</Discriminacao>
+        <CodigoMunicipio>%s</CodigoMunicipio>
+      </Servico>
+      <PrestadorServico>
+        <Cnpj>%s</Cnpj>
+      </PrestadorServico>
+      <TomadorServico>
+        <CpfCnpj>%s</CpfCnpj>
+        <RazaoSocial>%s</RazaoSocial>
+      </TomadorServico>
+    </InfNfse>
+  </Nfse>
+</CompNfse>
+`,
+		result.Number,
+		result.VerificationCode,
+		request.IssueDate.Format(time.RFC3339),
+		float64(request.Amount)/100,
+		float64(request.ISSAmount)/100,
+		request.ISSRate,
+		request.ServiceCode,
+		xmlEscape(request.Description),
+		request.IssuerMunicipality,
+		onlyDigits(request.IssuerCNPJ),
+		onlyDigits(request.CustomerDocument),
+		xmlEscape(request.CustomerName),
+	))
+}
+
+// renderPDF gera um PDF mínimo com os dados principais da nota
+func (p *StubNFSeProvider) renderPDF(request *application.NFSeIssueRequest, result *application.NFSeIssueResult) []byte {
+	text := fmt.Sprintf("NFS-e %s - Codigo %s - Valor R$ %.2f", result.Number, result.VerificationCode, float64(request.Amount)/100)
+	stream := fmt.Sprintf("BT /F1 12 Tf 50 750 Td (%s) Tj ET", text)
+
+	return []byte(fmt.Sprintf(`%%PDF-1.4
+1 0 obj << /Type /Catalog /Pages 2 0 R >> endobj
+2 0 obj << /Type /Pages /Kids [3 0 R] /Count 1 >> endobj
+3 0 obj << /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Contents 4 0 R /Resources << /Font << /F1 5 0 R >> >> >> endobj
+4 0 obj << /Length %d >> stream
+%s
+endstream endobj
+5 0 obj << /Type /Font /Subtype /Type1 /BaseFont /Helvetica >> endobj
+trailer << /Root 1 0 R >>
+%%%%EOF
+`, len(stream), stream))
+}
+
+// xmlEscape escapa caracteres reservados do XML
+func xmlEscape(value string) string {
+	replacer := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;", "'", "&apos;")
+	return replacer.Replace(value)
+}
@@ -0,0 +1,63 @@
+package gateways
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/direito-lux/billing-service/internal/application"
+	"github.com/direito-lux/billing-service/internal/infrastructure/config"
+)
+
+// NotificationGateway cliente HTTP do notification-service
+type NotificationGateway struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+// NewNotificationGateway cria novo cliente do notification-service
+func NewNotificationGateway(cfg *config.Config) *NotificationGateway {
+	return &NotificationGateway{
+		baseURL: cfg.Services.NotificationServiceURL,
+		token:   cfg.Services.InternalServiceToken,
+		client: &http.Client{
+			Timeout: cfg.Services.DefaultTimeout,
+		},
+	}
+}
+
+// SendNotification cria uma notificação no notification-service
+func (g *NotificationGateway) SendNotification(ctx context.Context, request *application.NotificationRequest) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/v1/notifications", g.baseURL)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Tenant-ID", request.TenantID.String())
+	req.Header.Set("Authorization", "Bearer "+g.token)
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call notification service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("notification service returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
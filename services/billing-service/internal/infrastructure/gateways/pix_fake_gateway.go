@@ -0,0 +1,159 @@
+package gateways
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/direito-lux/billing-service/internal/application"
+	"github.com/direito-lux/billing-service/internal/domain"
+)
+
+// FakePixGateway PSP PIX em memória para desenvolvimento e testes.
+// Gera BR Codes válidos e permite simular o pagamento das cobranças.
+type FakePixGateway struct {
+	key          string
+	merchantName string
+	merchantCity string
+
+	mu       sync.Mutex
+	charges  map[string]*application.PixChargeResponse
+	refunds  map[string]int64
+	sequence int
+}
+
+// NewFakePixGateway cria novo PSP PIX em memória
+func NewFakePixGateway(key, merchantName, merchantCity string) *FakePixGateway {
+	if key == "" {
+		key = "pix@direitolux.com.br"
+	}
+
+	return &FakePixGateway{
+		key:          key,
+		merchantName: merchantName,
+		merchantCity: merchantCity,
+		charges:      make(map[string]*application.PixChargeResponse),
+		refunds:      make(map[string]int64),
+	}
+}
+
+// CreatePixCharge cria uma cobrança em memória
+func (g *FakePixGateway) CreatePixCharge(ctx context.Context, request *application.PixChargeRequest) (*application.PixChargeResponse, error) {
+	if err := domain.ValidatePixTxID(request.TxID); err != nil {
+		return nil, err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, exists := g.charges[request.TxID]; exists {
+		return nil, fmt.Errorf("PIX charge already exists: %s", request.TxID)
+	}
+
+	location := fmt.Sprintf("pix.local/qr/v2/%s", request.TxID)
+	brCode, err := domain.PixBRCode{
+		Location:     location,
+		MerchantName: g.merchantName,
+		MerchantCity: g.merchantCity,
+		Amount:       request.Amount,
+	}.Payload()
+	if err != nil {
+		return nil, err
+	}
+
+	charge := &application.PixChargeResponse{
+		TxID:      request.TxID,
+		Status:    application.PixChargeStatusActive,
+		Location:  location,
+		BRCode:    brCode,
+		ExpiresAt: time.Now().Add(request.Expiration),
+	}
+	g.charges[request.TxID] = charge
+
+	copied := *charge
+	return &copied, nil
+}
+
+// GetPixCharge consulta uma cobrança em memória
+func (g *FakePixGateway) GetPixCharge(ctx context.Context, txID string) (*application.PixChargeResponse, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	charge, exists := g.charges[txID]
+	if !exists {
+		return nil, fmt.Errorf("PIX charge not found: %s", txID)
+	}
+
+	copied := *charge
+	return &copied, nil
+}
+
+// CancelPixCharge remove uma cobrança ativa
+func (g *FakePixGateway) CancelPixCharge(ctx context.Context, txID string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	charge, exists := g.charges[txID]
+	if !exists {
+		return fmt.Errorf("PIX charge not found: %s", txID)
+	}
+
+	if charge.Status == application.PixChargeStatusActive {
+		charge.Status = application.PixChargeStatusRemoved
+	}
+	return nil
+}
+
+// RefundPix registra a devolução
+func (g *FakePixGateway) RefundPix(ctx context.Context, endToEndID, refundID string, amount int64) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.refunds[endToEndID] += amount
+	return nil
+}
+
+// SimulatePayment simula o pagamento de uma cobrança e retorna o comando
+// equivalente ao webhook que o PSP enviaria
+func (g *FakePixGateway) SimulatePayment(txID string, amount int64) (*application.PixReceivedCommand, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	charge, exists := g.charges[txID]
+	if !exists {
+		return nil, fmt.Errorf("PIX charge not found: %s", txID)
+	}
+
+	if charge.Status != application.PixChargeStatusActive {
+		return nil, fmt.Errorf("PIX charge is not active: %s", charge.Status)
+	}
+
+	if time.Now().After(charge.ExpiresAt) {
+		return nil, fmt.Errorf("PIX charge expired: %s", txID)
+	}
+
+	g.sequence++
+	now := time.Now()
+	endToEndID := fmt.Sprintf("E00000000%s%011d", now.Format("200601021504"), g.sequence)
+
+	charge.Status = application.PixChargeStatusCompleted
+	charge.PaidAmount = amount
+	charge.EndToEndID = endToEndID
+	charge.PaidAt = &now
+
+	return &application.PixReceivedCommand{
+		TxID:       txID,
+		EndToEndID: endToEndID,
+		Amount:     amount,
+		PaidAt:     now,
+	}, nil
+}
+
+// RefundedAmount retorna o total devolvido para um PIX
+func (g *FakePixGateway) RefundedAmount(endToEndID string) int64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.refunds[endToEndID]
+}
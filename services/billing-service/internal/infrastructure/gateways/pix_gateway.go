@@ -0,0 +1,311 @@
+package gateways
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/direito-lux/billing-service/internal/application"
+	"github.com/direito-lux/billing-service/internal/domain"
+	"github.com/direito-lux/billing-service/internal/infrastructure/config"
+)
+
+// NewPixGateway cria o gateway PIX configurado
+func NewPixGateway(cfg *config.Config) (application.PixGatewayInterface, error) {
+	switch cfg.Pix.Provider {
+	case "fake":
+		return NewFakePixGateway(cfg.Pix.Key, cfg.Pix.MerchantName, cfg.Pix.MerchantCity), nil
+	case "api":
+		return NewPixAPIGateway(cfg)
+	default:
+		return nil, fmt.Errorf("unsupported PIX provider: %s", cfg.Pix.Provider)
+	}
+}
+
+// PixAPIGateway cliente da API Pix padronizada pelo Banco Central (cobranças imediatas)
+type PixAPIGateway struct {
+	baseURL      string
+	oauthURL     string
+	clientID     string
+	clientSecret string
+	key          string
+	merchantName string
+	merchantCity string
+	client       *http.Client
+
+	mu          sync.Mutex
+	accessToken string
+	tokenExpiry time.Time
+}
+
+// NewPixAPIGateway cria novo cliente da API Pix com autenticação mTLS
+func NewPixAPIGateway(cfg *config.Config) (*PixAPIGateway, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if cfg.Pix.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.Pix.CertFile, cfg.Pix.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load PIX certificate: %w", err)
+		}
+		transport.TLSClientConfig = &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			MinVersion:   tls.VersionTLS12,
+		}
+	}
+
+	oauthURL := cfg.Pix.OAuthURL
+	if oauthURL == "" {
+		oauthURL = strings.TrimRight(cfg.Pix.APIURL, "/") + "/oauth/token"
+	}
+
+	return &PixAPIGateway{
+		baseURL:      strings.TrimRight(cfg.Pix.APIURL, "/"),
+		oauthURL:     oauthURL,
+		clientID:     cfg.Pix.ClientID,
+		clientSecret: cfg.Pix.ClientSecret,
+		key:          cfg.Pix.Key,
+		merchantName: cfg.Pix.MerchantName,
+		merchantCity: cfg.Pix.MerchantCity,
+		client: &http.Client{
+			Timeout:   cfg.Services.DefaultTimeout,
+			Transport: transport,
+		},
+	}, nil
+}
+
+// pixCob representação da cobrança imediata na API Pix
+type pixCob struct {
+	TxID       string `json:"txid,omitempty"`
+	Status     string `json:"status,omitempty"`
+	Calendario struct {
+		Criacao   *time.Time `json:"criacao,omitempty"`
+		Expiracao int        `json:"expiracao"`
+	} `json:"calendario"`
+	Devedor            *pixDevedor   `json:"devedor,omitempty"`
+	Valor              pixValor      `json:"valor"`
+	Chave              string        `json:"chave,omitempty"`
+	SolicitacaoPagador string        `json:"solicitacaoPagador,omitempty"`
+	Location           string        `json:"location,omitempty"`
+	PixCopiaECola      string        `json:"pixCopiaECola,omitempty"`
+	Pix                []pixRecebido `json:"pix,omitempty"`
+}
+
+type pixDevedor struct {
+	CPF  string `json:"cpf,omitempty"`
+	CNPJ string `json:"cnpj,omitempty"`
+	Nome string `json:"nome"`
+}
+
+type pixValor struct {
+	Original string `json:"original"`
+}
+
+type pixRecebido struct {
+	EndToEndID string    `json:"endToEndId"`
+	TxID       string    `json:"txid"`
+	Valor      string    `json:"valor"`
+	Horario    time.Time `json:"horario"`
+}
+
+// CreatePixCharge cria a cobrança imediata (PUT /v2/cob/{txid})
+func (g *PixAPIGateway) CreatePixCharge(ctx context.Context, request *application.PixChargeRequest) (*application.PixChargeResponse, error) {
+	if err := domain.ValidatePixTxID(request.TxID); err != nil {
+		return nil, err
+	}
+
+	cob := pixCob{
+		Valor:              pixValor{Original: domain.FormatPixAmount(request.Amount)},
+		Chave:              g.key,
+		SolicitacaoPagador: request.Description,
+	}
+	cob.Calendario.Expiracao = int(request.Expiration.Seconds())
+
+	if request.PayerName != "" {
+		devedor := &pixDevedor{Nome: request.PayerName}
+		document := onlyDigits(request.PayerDocument)
+		if len(document) == 14 {
+			devedor.CNPJ = document
+		} else {
+			devedor.CPF = document
+		}
+		cob.Devedor = devedor
+	}
+
+	var response pixCob
+	if err := g.do(ctx, http.MethodPut, "/v2/cob/"+url.PathEscape(request.TxID), cob, &response); err != nil {
+		return nil, err
+	}
+
+	return g.toChargeResponse(&response)
+}
+
+// GetPixCharge consulta a cobrança (GET /v2/cob/{txid})
+func (g *PixAPIGateway) GetPixCharge(ctx context.Context, txID string) (*application.PixChargeResponse, error) {
+	var response pixCob
+	if err := g.do(ctx, http.MethodGet, "/v2/cob/"+url.PathEscape(txID), nil, &response); err != nil {
+		return nil, err
+	}
+
+	return g.toChargeResponse(&response)
+}
+
+// CancelPixCharge remove a cobrança (PATCH /v2/cob/{txid})
+func (g *PixAPIGateway) CancelPixCharge(ctx context.Context, txID string) error {
+	payload := map[string]string{"status": application.PixChargeStatusRemoved}
+	return g.do(ctx, http.MethodPatch, "/v2/cob/"+url.PathEscape(txID), payload, nil)
+}
+
+// RefundPix solicita a devolução (PUT /v2/pix/{e2eid}/devolucao/{id})
+func (g *PixAPIGateway) RefundPix(ctx context.Context, endToEndID, refundID string, amount int64) error {
+	path := fmt.Sprintf("/v2/pix/%s/devolucao/%s", url.PathEscape(endToEndID), url.PathEscape(strings.ReplaceAll(refundID, "-", "")))
+	payload := map[string]string{"valor": domain.FormatPixAmount(amount)}
+	return g.do(ctx, http.MethodPut, path, payload, nil)
+}
+
+// toChargeResponse converte a cobrança da API Pix
+func (g *PixAPIGateway) toChargeResponse(cob *pixCob) (*application.PixChargeResponse, error) {
+	amount, err := domain.ParsePixAmount(cob.Valor.Original)
+	if err != nil {
+		return nil, err
+	}
+
+	brCode := cob.PixCopiaECola
+	if brCode == "" {
+		// PSPs anteriores à versão 2.1 da API não retornam o Copia e Cola
+		brCode, err = domain.PixBRCode{
+			Location:     cob.Location,
+			MerchantName: g.merchantName,
+			MerchantCity: g.merchantCity,
+			Amount:       amount,
+		}.Payload()
+		if err != nil {
+			return nil, fmt.Errorf("failed to build PIX BR Code: %w", err)
+		}
+	}
+
+	createdAt := time.Now()
+	if cob.Calendario.Criacao != nil {
+		createdAt = *cob.Calendario.Criacao
+	}
+
+	response := &application.PixChargeResponse{
+		TxID:      cob.TxID,
+		Status:    cob.Status,
+		Location:  cob.Location,
+		BRCode:    brCode,
+		ExpiresAt: createdAt.Add(time.Duration(cob.Calendario.Expiracao) * time.Second),
+	}
+
+	for _, pix := range cob.Pix {
+		received, err := domain.ParsePixAmount(pix.Valor)
+		if err != nil {
+			return nil, err
+		}
+		paidAt := pix.Horario
+		response.PaidAmount += received
+		response.EndToEndID = pix.EndToEndID
+		response.PaidAt = &paidAt
+	}
+
+	return response, nil
+}
+
+// do executa uma chamada autenticada à API Pix
+func (g *PixAPIGateway) do(ctx context.Context, method, path string, payload, result interface{}) error {
+	token, err := g.getAccessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, g.baseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call PIX API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("PIX API returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	if result != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, result); err != nil {
+			return fmt.Errorf("failed to decode PIX API response: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// getAccessToken obtém (e mantém em cache) o token OAuth2 client credentials
+func (g *PixAPIGateway) getAccessToken(ctx context.Context) (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.accessToken != "" && time.Now().Before(g.tokenExpiry) {
+		return g.accessToken, nil
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("scope", "cob.write cob.read pix.write pix.read")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.oauthURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(g.clientID, g.clientSecret)
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to authenticate on PIX API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		respBody, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("PIX API authentication returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("failed to decode PIX API token: %w", err)
+	}
+
+	// Renovar um minuto antes de expirar
+	g.accessToken = token.AccessToken
+	g.tokenExpiry = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - time.Minute)
+
+	return g.accessToken, nil
+}
@@ -0,0 +1,114 @@
+package gateways
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/direito-lux/billing-service/internal/domain"
+	"github.com/direito-lux/billing-service/internal/infrastructure/config"
+)
+
+// TenantGateway cliente HTTP do tenant-service
+type TenantGateway struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+// NewTenantGateway cria novo cliente do tenant-service
+func NewTenantGateway(cfg *config.Config) *TenantGateway {
+	return &TenantGateway{
+		baseURL: cfg.Services.TenantServiceURL,
+		token:   cfg.Services.InternalServiceToken,
+		client: &http.Client{
+			Timeout: cfg.Services.DefaultTimeout,
+		},
+	}
+}
+
+// SuspendTenant suspende o tenant (acesso somente leitura)
+func (g *TenantGateway) SuspendTenant(ctx context.Context, tenantID uuid.UUID, reason string) error {
+	payload := map[string]string{"reason": reason}
+	url := fmt.Sprintf("%s/api/v1/tenants/%s/suspend", g.baseURL, tenantID.String())
+	return g.do(ctx, http.MethodPost, url, payload, nil)
+}
+
+// ActivateTenant reativa o tenant
+func (g *TenantGateway) ActivateTenant(ctx context.Context, tenantID uuid.UUID) error {
+	url := fmt.Sprintf("%s/api/v1/tenants/%s/activate", g.baseURL, tenantID.String())
+	return g.do(ctx, http.MethodPost, url, nil, nil)
+}
+
+// GetOverageUsage obtém os contadores acumulados de excedente do tenant
+func (g *TenantGateway) GetOverageUsage(ctx context.Context, tenantID uuid.UUID) (*domain.UsageSnapshot, error) {
+	var usage struct {
+		DataJudOverageTotal int64 `json:"datajud_overage_total"`
+		AIOverageTotal      int64 `json:"ai_overage_total"`
+	}
+
+	url := fmt.Sprintf("%s/api/v1/tenants/%s/quotas/usage", g.baseURL, tenantID.String())
+	if err := g.do(ctx, http.MethodGet, url, nil, &usage); err != nil {
+		return nil, err
+	}
+
+	return &domain.UsageSnapshot{
+		TenantID:            tenantID,
+		DataJudOverageTotal: usage.DataJudOverageTotal,
+		AIOverageTotal:      usage.AIOverageTotal,
+		CapturedAt:          time.Now(),
+	}, nil
+}
+
+// SetOverageMode configura a cobrança por excedente nas quotas do tenant
+func (g *TenantGateway) SetOverageMode(ctx context.Context, tenantID uuid.UUID, enabled, blocked bool) error {
+	payload := map[string]bool{"enabled": enabled, "blocked": blocked}
+	url := fmt.Sprintf("%s/api/v1/tenants/%s/quotas/overage", g.baseURL, tenantID.String())
+	return g.do(ctx, http.MethodPut, url, payload, nil)
+}
+
+// do executa uma chamada ao tenant-service
+func (g *TenantGateway) do(ctx context.Context, method, url string, payload, result interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+g.token)
+	req.Header.Set("X-User-ID", "billing-service")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call tenant service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("tenant service returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	if result != nil {
+		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+			return fmt.Errorf("failed to decode tenant service response: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -15,6 +15,7 @@ type BillingHandler struct {
 	subscriptionService *application.SubscriptionService
 	paymentService      *application.PaymentService
 	onboardingService   *application.OnboardingService
+	usageBillingService *application.UsageBillingService
 }
 
 // NewBillingHandler cria um novo handler de billing
@@ -22,11 +23,13 @@ func NewBillingHandler(
 	subscriptionService *application.SubscriptionService,
 	paymentService *application.PaymentService,
 	onboardingService *application.OnboardingService,
+	usageBillingService *application.UsageBillingService,
 ) *BillingHandler {
 	return &BillingHandler{
 		subscriptionService: subscriptionService,
 		paymentService:      paymentService,
 		onboardingService:   onboardingService,
+		usageBillingService: usageBillingService,
 	}
 }
 
@@ -340,6 +343,51 @@ func (h *BillingHandler) ValidateDocument(w http.ResponseWriter, r *http.Request
 	})
 }
 
+// GetOverageSettings busca a configuração de cobrança por excedente do tenant
+func (h *BillingHandler) GetOverageSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenantID, ok := ctx.Value("tenant_id").(uuid.UUID)
+	if !ok {
+		h.writeError(w, http.StatusUnauthorized, "Tenant ID not found")
+		return
+	}
+
+	settings, err := h.usageBillingService.GetOverageSettings(ctx, tenantID)
+	if err != nil {
+		h.writeError(w, http.StatusNotFound, "Overage settings not found")
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, settings)
+}
+
+// ConfigureOverage ativa ou desativa a cobrança por excedente e define o teto de gastos
+func (h *BillingHandler) ConfigureOverage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var cmd application.ConfigureOverageCommand
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	tenantID, ok := ctx.Value("tenant_id").(uuid.UUID)
+	if !ok {
+		h.writeError(w, http.StatusUnauthorized, "Tenant ID not found")
+		return
+	}
+	cmd.TenantID = tenantID
+
+	settings, err := h.usageBillingService.ConfigureOverage(ctx, cmd)
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, settings)
+}
+
 // GetSubscriptionStats retorna estatísticas de assinaturas
 func (h *BillingHandler) GetSubscriptionStats(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -422,6 +470,10 @@ func (h *BillingHandler) RegisterRoutes(router *mux.Router) {
 	router.HandleFunc("/payments/{id}/refund", h.RefundPayment).Methods("POST")
 	router.HandleFunc("/payments/stats", h.GetPaymentStats).Methods("GET")
 
+	// Cobrança por excedente
+	router.HandleFunc("/overage", h.GetOverageSettings).Methods("GET")
+	router.HandleFunc("/overage", h.ConfigureOverage).Methods("PUT")
+
 	// Onboarding
 	router.HandleFunc("/onboarding", h.StartOnboarding).Methods("POST")
 	router.HandleFunc("/onboarding/status", h.GetOnboardingStatus).Methods("GET")
@@ -0,0 +1,308 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/google/uuid"
+	"github.com/direito-lux/billing-service/internal/application"
+	"github.com/direito-lux/billing-service/internal/domain"
+)
+
+// FinanceHandler handler para relatórios financeiros e exportação do razão
+type FinanceHandler struct {
+	ledgerService *application.LedgerService
+}
+
+// NewFinanceHandler cria novo handler financeiro
+func NewFinanceHandler(ledgerService *application.LedgerService) *FinanceHandler {
+	return &FinanceHandler{
+		ledgerService: ledgerService,
+	}
+}
+
+// GetRevenueReport retorna MRR/ARR, churn e receita diferida do período
+func (h *FinanceHandler) GetRevenueReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	from, to, err := h.parsePeriod(r)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := h.ledgerService.GetRevenueReport(ctx, from, to)
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if !h.wantsCSV(r) {
+		h.writeJSON(w, http.StatusOK, report)
+		return
+	}
+
+	rows := [][]string{
+		{"metric", "value"},
+		{"period_start", report.PeriodStart.Format(time.RFC3339)},
+		{"period_end", report.PeriodEnd.Format(time.RFC3339)},
+		{"mrr", formatCents(report.MRR)},
+		{"arr", formatCents(report.ARR)},
+		{"active_subscriptions", strconv.FormatInt(report.ActiveSubscriptions, 10)},
+		{"new_subscriptions", strconv.FormatInt(report.NewSubscriptions, 10)},
+		{"new_mrr", formatCents(report.NewMRR)},
+		{"churned_subscriptions", strconv.FormatInt(report.ChurnedSubscriptions, 10)},
+		{"churned_mrr", formatCents(report.ChurnedMRR)},
+		{"customer_churn_rate", strconv.FormatFloat(report.CustomerChurnRate, 'f', 2, 64)},
+		{"revenue_churn_rate", strconv.FormatFloat(report.RevenueChurnRate, 'f', 2, 64)},
+		{"recognized_revenue", formatCents(report.RecognizedRevenue)},
+		{"discounts", formatCents(report.Discounts)},
+		{"refunds", formatCents(report.Refunds)},
+		{"credits_granted", formatCents(report.CreditsGranted)},
+		{"net_revenue", formatCents(report.NetRevenue)},
+		{"deferred_revenue", formatCents(report.DeferredRevenue)},
+		{"accounts_receivable", formatCents(report.AccountsReceivable)},
+		{"customer_credits", formatCents(report.CustomerCredits)},
+		{"tax_payable", formatCents(report.TaxPayable)},
+	}
+
+	h.writeCSV(w, "revenue-report", rows)
+}
+
+// GetLedgerEntries exporta os lançamentos do razão
+func (h *FinanceHandler) GetLedgerEntries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	from, to, err := h.parsePeriod(r)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	query := domain.LedgerQuery{
+		From: &from,
+		To:   &to,
+	}
+
+	if tenantIDStr := r.URL.Query().Get("tenant_id"); tenantIDStr != "" {
+		tenantID, err := uuid.Parse(tenantIDStr)
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, "Invalid tenant ID")
+			return
+		}
+		query.TenantID = &tenantID
+	}
+
+	if types := r.URL.Query().Get("types"); types != "" {
+		for _, entryType := range strings.Split(types, ",") {
+			query.Types = append(query.Types, domain.LedgerEntryType(strings.TrimSpace(entryType)))
+		}
+	}
+
+	if limit, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && limit > 0 {
+		query.Limit = limit
+	}
+	if offset, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil && offset > 0 {
+		query.Offset = offset
+	}
+
+	entries, err := h.ledgerService.GetEntries(ctx, query)
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if !h.wantsCSV(r) {
+		h.writeJSON(w, http.StatusOK, entries)
+		return
+	}
+
+	// Uma linha por partida
+	rows := [][]string{{
+		"entry_id", "occurred_at", "tenant_id", "type", "reference_type", "reference_id",
+		"description", "account", "debit", "credit",
+	}}
+	for _, entry := range entries {
+		for _, line := range entry.Lines {
+			rows = append(rows, []string{
+				entry.ID.String(),
+				entry.OccurredAt.Format(time.RFC3339),
+				entry.TenantID.String(),
+				string(entry.Type),
+				entry.ReferenceType,
+				entry.ReferenceID.String(),
+				entry.Description,
+				string(line.Account),
+				formatCents(line.Debit),
+				formatCents(line.Credit),
+			})
+		}
+	}
+
+	h.writeCSV(w, "ledger", rows)
+}
+
+// GetTrialBalance retorna o balancete do razão
+func (h *FinanceHandler) GetTrialBalance(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	asOf := time.Now()
+	if asOfStr := r.URL.Query().Get("as_of"); asOfStr != "" {
+		parsed, err := parseDate(asOfStr)
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, "Invalid as_of date")
+			return
+		}
+		asOf = parsed
+	}
+
+	var tenantID *uuid.UUID
+	if tenantIDStr := r.URL.Query().Get("tenant_id"); tenantIDStr != "" {
+		tid, err := uuid.Parse(tenantIDStr)
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, "Invalid tenant ID")
+			return
+		}
+		tenantID = &tid
+	}
+
+	trial, err := h.ledgerService.GetTrialBalance(ctx, tenantID, asOf)
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if !h.wantsCSV(r) {
+		h.writeJSON(w, http.StatusOK, trial)
+		return
+	}
+
+	rows := [][]string{{"account", "debits", "credits", "balance"}}
+	for _, balance := range trial.Accounts {
+		rows = append(rows, []string{
+			string(balance.Account),
+			formatCents(balance.Debits),
+			formatCents(balance.Credits),
+			formatCents(balance.Balance()),
+		})
+	}
+	rows = append(rows, []string{"total", formatCents(trial.TotalDebits), formatCents(trial.TotalCredits), ""})
+
+	h.writeCSV(w, "trial-balance", rows)
+}
+
+// RecognizeRevenue reconhece a receita diferida prestada até a data
+func (h *FinanceHandler) RecognizeRevenue(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	asOf := time.Now()
+	if asOfStr := r.URL.Query().Get("as_of"); asOfStr != "" {
+		parsed, err := parseDate(asOfStr)
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, "Invalid as_of date")
+			return
+		}
+		asOf = parsed
+	}
+
+	recognized, err := h.ledgerService.RecognizeRevenue(ctx, asOf)
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"as_of":      asOf,
+		"recognized": recognized,
+	})
+}
+
+// parsePeriod lê o período from/to da query (padrão: mês corrente)
+func (h *FinanceHandler) parsePeriod(r *http.Request) (time.Time, time.Time, error) {
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	to := from.AddDate(0, 1, 0)
+
+	if fromStr := r.URL.Query().Get("from"); fromStr != "" {
+		parsed, err := parseDate(fromStr)
+		if err != nil {
+			return from, to, fmt.Errorf("invalid from date: %s", fromStr)
+		}
+		from = parsed
+	}
+
+	if toStr := r.URL.Query().Get("to"); toStr != "" {
+		parsed, err := parseDate(toStr)
+		if err != nil {
+			return from, to, fmt.Errorf("invalid to date: %s", toStr)
+		}
+		to = parsed
+	}
+
+	if !to.After(from) {
+		return from, to, fmt.Errorf("to must be after from")
+	}
+
+	return from, to, nil
+}
+
+// wantsCSV verifica se a exportação foi solicitada em CSV
+func (h *FinanceHandler) wantsCSV(r *http.Request) bool {
+	return strings.EqualFold(r.URL.Query().Get("format"), "csv")
+}
+
+// parseDate aceita datas no formato 2006-01-02 ou RFC3339
+func parseDate(value string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// formatCents formata valores em centavos como decimal (ex.: 19990 -> 199.90)
+func formatCents(amount int64) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, amount/100, amount%100)
+}
+
+// writeCSV escreve resposta CSV para download
+func (h *FinanceHandler) writeCSV(w http.ResponseWriter, name string, rows [][]string) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s-%s.csv", name, time.Now().Format("20060102")))
+	w.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(w)
+	writer.WriteAll(rows)
+}
+
+// writeJSON escreve resposta JSON
+func (h *FinanceHandler) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+// writeError escreve resposta de erro
+func (h *FinanceHandler) writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
+
+// RegisterRoutes registra as rotas financeiras (uso interno da equipe financeira)
+func (h *FinanceHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/finance/reports/revenue", h.GetRevenueReport).Methods("GET")
+	router.HandleFunc("/finance/ledger", h.GetLedgerEntries).Methods("GET")
+	router.HandleFunc("/finance/trial-balance", h.GetTrialBalance).Methods("GET")
+	router.HandleFunc("/finance/revenue/recognize", h.RecognizeRevenue).Methods("POST")
+}
@@ -139,6 +139,53 @@ func (h *WebhookHandler) CryptoWebhook(w http.ResponseWriter, r *http.Request) {
 	h.writeSuccess(w, "Webhook processed successfully")
 }
 
+// PixWebhook processa notificações de PIX recebido (padrão da API Pix do Banco Central)
+func (h *WebhookHandler) PixWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Ler body do webhook
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, "Failed to read request body")
+		return
+	}
+
+	// Parse do webhook PIX
+	var webhook PixWebhookPayload
+	if err := json.Unmarshal(body, &webhook); err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid JSON payload")
+		return
+	}
+
+	// A autenticidade é garantida pelo mTLS do PSP e pela consulta da cobrança
+	for _, pix := range webhook.Pix {
+		// PIX sem txid não pertence a uma cobrança dinâmica
+		if pix.TxID == "" {
+			continue
+		}
+
+		amount, err := domain.ParsePixAmount(pix.Valor)
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		cmd := application.PixReceivedCommand{
+			TxID:       pix.TxID,
+			EndToEndID: pix.EndToEndID,
+			Amount:     amount,
+			PaidAt:     pix.Horario,
+		}
+
+		if _, err := h.paymentService.ProcessPixReceived(ctx, cmd); err != nil {
+			h.writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	h.writeSuccess(w, "Webhook processed successfully")
+}
+
 // processAsaasPaymentConfirmed processa pagamento confirmado no ASAAS
 func (h *WebhookHandler) processAsaasPaymentConfirmed(ctx context.Context, webhook AsaasWebhookPayload) error {
 	// Buscar pagamento por ID externo
@@ -338,6 +385,9 @@ func (h *WebhookHandler) RegisterRoutes(router *mux.Router) {
 	router.HandleFunc("/webhooks/asaas", h.AsaasWebhook).Methods("POST")
 	router.HandleFunc("/webhooks/crypto", h.CryptoWebhook).Methods("POST")
 	router.HandleFunc("/webhooks/crypto/{payment_id}", h.CryptoWebhook).Methods("POST")
+	// O PSP acrescenta "/pix" à URL cadastrada do webhook
+	router.HandleFunc("/webhooks/pix", h.PixWebhook).Methods("POST")
+	router.HandleFunc("/webhooks/pix/pix", h.PixWebhook).Methods("POST")
 }
 
 // Estruturas de payload dos webhooks
@@ -379,4 +429,15 @@ type CryptoWebhookPayload struct {
 	ExchangeRate    float64 `json:"exchange_rate"`
 	CreatedAt       time.Time `json:"created_at"`
 	UpdatedAt       time.Time `json:"updated_at"`
-}
\ No newline at end of file
+}
+
+// PixWebhookPayload payload do webhook da API Pix
+type PixWebhookPayload struct {
+	Pix []struct {
+		EndToEndID  string    `json:"endToEndId"`
+		TxID        string    `json:"txid"`
+		Valor       string    `json:"valor"`
+		Horario     time.Time `json:"horario"`
+		InfoPagador string    `json:"infoPagador"`
+	} `json:"pix"`
+}
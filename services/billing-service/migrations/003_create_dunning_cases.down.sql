@@ -0,0 +1,12 @@
+-- Migration: Drop dunning cases table
+-- Created: 2025-07-18
+
+DROP TRIGGER IF EXISTS update_dunning_cases_updated_at ON dunning_cases;
+
+DROP INDEX IF EXISTS idx_dunning_cases_open_subscription;
+DROP INDEX IF EXISTS idx_dunning_cases_next_action_at;
+DROP INDEX IF EXISTS idx_dunning_cases_status;
+DROP INDEX IF EXISTS idx_dunning_cases_tenant_id;
+DROP INDEX IF EXISTS idx_dunning_cases_subscription_id;
+
+DROP TABLE IF EXISTS dunning_cases;
@@ -0,0 +1,37 @@
+-- Migration: Create dunning cases table
+-- Created: 2025-07-18
+
+-- Create dunning_cases table (régua de cobrança de inadimplentes)
+CREATE TABLE IF NOT EXISTS dunning_cases (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    subscription_id UUID NOT NULL REFERENCES subscriptions(id),
+    tenant_id UUID NOT NULL,
+    payment_id UUID NOT NULL REFERENCES payments(id),
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'grace_period', 'suspended', 'recovered', 'cancelled')),
+    
+    -- Progresso na régua
+    current_step INTEGER NOT NULL DEFAULT 0,
+    started_at TIMESTAMP NOT NULL,
+    next_action_at TIMESTAMP,
+    last_attempt_at TIMESTAMP,
+    last_failure_reason TEXT,
+    
+    -- Desfecho
+    grace_ends_at TIMESTAMP,
+    suspended_at TIMESTAMP,
+    recovered_at TIMESTAMP,
+    recovery_payment_id UUID REFERENCES payments(id),
+    
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_dunning_cases_subscription_id ON dunning_cases(subscription_id);
+CREATE INDEX IF NOT EXISTS idx_dunning_cases_tenant_id ON dunning_cases(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_dunning_cases_status ON dunning_cases(status);
+CREATE INDEX IF NOT EXISTS idx_dunning_cases_next_action_at ON dunning_cases(next_action_at) WHERE status IN ('active', 'grace_period');
+
+-- Apenas um processo em aberto por assinatura
+CREATE UNIQUE INDEX IF NOT EXISTS idx_dunning_cases_open_subscription ON dunning_cases(subscription_id) WHERE status IN ('active', 'grace_period', 'suspended');
+
+CREATE TRIGGER update_dunning_cases_updated_at BEFORE UPDATE ON dunning_cases FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,11 @@
+-- Migration: Remove NFS-e fields from invoices
+-- Created: 2025-07-18
+
+DROP INDEX IF EXISTS idx_invoices_pending_nfe;
+
+ALTER TABLE invoices DROP COLUMN IF EXISTS service_code;
+ALTER TABLE invoices DROP COLUMN IF EXISTS iss_rate;
+ALTER TABLE invoices DROP COLUMN IF EXISTS nfe_cancel_reason;
+ALTER TABLE invoices DROP COLUMN IF EXISTS nfe_cancelled_at;
+ALTER TABLE invoices DROP COLUMN IF EXISTS nfe_pdf_url;
+ALTER TABLE invoices DROP COLUMN IF EXISTS nfe_xml_url;
@@ -0,0 +1,15 @@
+-- Migration: Add NFS-e fields to invoices
+-- Created: 2025-07-18
+
+-- Documentos e cancelamento da NFS-e
+ALTER TABLE invoices ADD COLUMN IF NOT EXISTS nfe_xml_url TEXT;
+ALTER TABLE invoices ADD COLUMN IF NOT EXISTS nfe_pdf_url TEXT;
+ALTER TABLE invoices ADD COLUMN IF NOT EXISTS nfe_cancelled_at TIMESTAMP;
+ALTER TABLE invoices ADD COLUMN IF NOT EXISTS nfe_cancel_reason TEXT;
+
+-- Tributação (ISS) por município
+ALTER TABLE invoices ADD COLUMN IF NOT EXISTS iss_rate NUMERIC(5,4) NOT NULL DEFAULT 0.02;
+ALTER TABLE invoices ADD COLUMN IF NOT EXISTS service_code VARCHAR(10);
+
+-- Faturas pagas aguardando emissão da NFS-e
+CREATE INDEX IF NOT EXISTS idx_invoices_pending_nfe ON invoices(paid_at) WHERE status = 'paid' AND (nfe_number IS NULL OR nfe_xml_url IS NULL);
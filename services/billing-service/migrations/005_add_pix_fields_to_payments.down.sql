@@ -0,0 +1,12 @@
+-- Migration: Remove PIX fields from payments
+-- Created: 2025-07-18
+
+DROP INDEX IF EXISTS idx_payments_pix_expires_at;
+DROP INDEX IF EXISTS idx_payments_pix_txid;
+
+ALTER TABLE payments DROP COLUMN IF EXISTS received_amount;
+ALTER TABLE payments DROP COLUMN IF EXISTS pix_expires_at;
+ALTER TABLE payments DROP COLUMN IF EXISTS pix_qr_code_image;
+ALTER TABLE payments DROP COLUMN IF EXISTS pix_br_code;
+ALTER TABLE payments DROP COLUMN IF EXISTS pix_end_to_end_id;
+ALTER TABLE payments DROP COLUMN IF EXISTS pix_txid;
@@ -0,0 +1,15 @@
+-- Migration: Add PIX fields to payments
+-- Created: 2025-07-18
+
+-- Cobrança PIX dinâmica
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS pix_txid VARCHAR(35);
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS pix_end_to_end_id VARCHAR(32);
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS pix_br_code TEXT;
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS pix_qr_code_image TEXT;
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS pix_expires_at TIMESTAMP;
+
+-- Conciliação do valor recebido
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS received_amount BIGINT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payments_pix_txid ON payments(pix_txid) WHERE pix_txid IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_payments_pix_expires_at ON payments(pix_expires_at) WHERE status = 'pending' AND pix_txid IS NOT NULL;
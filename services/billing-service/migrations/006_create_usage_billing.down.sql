@@ -0,0 +1,12 @@
+-- Migration: Drop usage-based overage billing
+-- Created: 2025-07-18
+
+DROP TRIGGER IF EXISTS update_usage_charges_updated_at ON usage_charges;
+DROP TRIGGER IF EXISTS update_overage_settings_updated_at ON overage_settings;
+
+DROP TABLE IF EXISTS usage_charges;
+DROP TABLE IF EXISTS overage_settings;
+
+ALTER TABLE invoices DROP COLUMN IF EXISTS items;
+ALTER TABLE plans DROP COLUMN IF EXISTS ai_overage_price;
+ALTER TABLE plans DROP COLUMN IF EXISTS datajud_overage_price;
@@ -0,0 +1,63 @@
+-- Migration: Create usage-based overage billing
+-- Created: 2025-07-18
+
+-- Preços de excedente por plano (em centavos por consulta)
+ALTER TABLE plans ADD COLUMN IF NOT EXISTS datajud_overage_price BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE plans ADD COLUMN IF NOT EXISTS ai_overage_price BIGINT NOT NULL DEFAULT 0;
+
+UPDATE plans SET datajud_overage_price = 10, ai_overage_price = 50 WHERE name = 'starter';
+UPDATE plans SET datajud_overage_price = 8, ai_overage_price = 40 WHERE name = 'professional';
+UPDATE plans SET datajud_overage_price = 5, ai_overage_price = 30 WHERE name = 'business';
+UPDATE plans SET datajud_overage_price = 3, ai_overage_price = 20 WHERE name = 'enterprise';
+
+-- Itens adicionais da fatura (excedente de uso)
+ALTER TABLE invoices ADD COLUMN IF NOT EXISTS items JSONB NOT NULL DEFAULT '[]';
+
+-- Configuração da cobrança por excedente do tenant
+CREATE TABLE IF NOT EXISTS overage_settings (
+    tenant_id UUID PRIMARY KEY,
+    enabled BOOLEAN NOT NULL DEFAULT false,
+    spending_cap BIGINT NOT NULL DEFAULT 0 CHECK (spending_cap >= 0), -- em centavos; 0 = sem teto
+    
+    -- Bloqueio ao atingir o teto
+    blocked BOOLEAN NOT NULL DEFAULT false,
+    blocked_at TIMESTAMP,
+    
+    -- Últimos totais acumulados já tarifados
+    last_datajud_total BIGINT NOT NULL DEFAULT 0,
+    last_ai_total BIGINT NOT NULL DEFAULT 0,
+    last_synced_at TIMESTAMP,
+    
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_overage_settings_enabled ON overage_settings(enabled) WHERE enabled = true;
+
+-- Cobranças de excedente aguardando a próxima fatura
+CREATE TABLE IF NOT EXISTS usage_charges (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL,
+    subscription_id UUID NOT NULL REFERENCES subscriptions(id),
+    invoice_id UUID REFERENCES invoices(id),
+    metric VARCHAR(30) NOT NULL CHECK (metric IN ('datajud_queries', 'ai_queries')),
+    quantity BIGINT NOT NULL CHECK (quantity > 0),
+    unit_price BIGINT NOT NULL, -- em centavos
+    amount BIGINT NOT NULL,     -- em centavos
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'invoiced')),
+    
+    -- Período de assinatura em que o uso ocorreu
+    period_start TIMESTAMP NOT NULL,
+    period_end TIMESTAMP NOT NULL,
+    
+    invoiced_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_usage_charges_tenant_period ON usage_charges(tenant_id, period_start);
+CREATE INDEX IF NOT EXISTS idx_usage_charges_pending ON usage_charges(tenant_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_usage_charges_invoice_id ON usage_charges(invoice_id);
+
+CREATE TRIGGER update_overage_settings_updated_at BEFORE UPDATE ON overage_settings FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_usage_charges_updated_at BEFORE UPDATE ON usage_charges FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,9 @@
+-- Migration: Drop double-entry billing ledger
+-- Created: 2025-07-18
+
+DROP TRIGGER IF EXISTS prevent_ledger_lines_changes ON ledger_lines;
+DROP TRIGGER IF EXISTS prevent_ledger_entries_changes ON ledger_entries;
+DROP FUNCTION IF EXISTS prevent_ledger_changes();
+
+DROP TABLE IF EXISTS ledger_lines;
+DROP TABLE IF EXISTS ledger_entries;
@@ -0,0 +1,47 @@
+-- Migration: Create double-entry billing ledger
+-- Created: 2025-07-18
+
+-- Lançamentos contábeis (somente inclusão)
+CREATE TABLE IF NOT EXISTS ledger_entries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL,
+    type VARCHAR(30) NOT NULL CHECK (type IN ('invoice_issued', 'payment_received', 'payment_refunded', 'revenue_recognized', 'credit_granted')),
+    reference_type VARCHAR(20) NOT NULL CHECK (reference_type IN ('invoice', 'payment', 'subscription')),
+    reference_id UUID NOT NULL,
+    event_id UUID UNIQUE, -- evento de origem, garante idempotência
+    description TEXT,
+    occurred_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_tenant_id ON ledger_entries(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_reference ON ledger_entries(reference_id);
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_occurred_at ON ledger_entries(occurred_at);
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_type ON ledger_entries(type);
+
+-- Partidas dos lançamentos
+CREATE TABLE IF NOT EXISTS ledger_lines (
+    id BIGSERIAL PRIMARY KEY,
+    entry_id UUID NOT NULL REFERENCES ledger_entries(id),
+    account VARCHAR(30) NOT NULL CHECK (account IN (
+        'cash', 'accounts_receivable', 'deferred_revenue', 'tax_payable', 'customer_credits',
+        'revenue', 'discounts', 'refunds', 'credits_granted'
+    )),
+    debit BIGINT NOT NULL DEFAULT 0 CHECK (debit >= 0),   -- em centavos
+    credit BIGINT NOT NULL DEFAULT 0 CHECK (credit >= 0), -- em centavos
+    CHECK ((debit = 0) <> (credit = 0))
+);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_lines_entry_id ON ledger_lines(entry_id);
+CREATE INDEX IF NOT EXISTS idx_ledger_lines_account ON ledger_lines(account);
+
+-- O razão é somente de inclusão: correções são feitas por novos lançamentos
+CREATE OR REPLACE FUNCTION prevent_ledger_changes()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'ledger is append-only: % on % is not allowed', TG_OP, TG_TABLE_NAME;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER prevent_ledger_entries_changes BEFORE UPDATE OR DELETE ON ledger_entries FOR EACH ROW EXECUTE FUNCTION prevent_ledger_changes();
+CREATE TRIGGER prevent_ledger_lines_changes BEFORE UPDATE OR DELETE ON ledger_lines FOR EACH ROW EXECUTE FUNCTION prevent_ledger_changes();
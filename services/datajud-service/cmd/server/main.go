@@ -13,9 +13,13 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
 	"github.com/direito-lux/datajud-service/internal/application"
 	"github.com/direito-lux/datajud-service/internal/domain"
+	"github.com/direito-lux/datajud-service/internal/infrastructure/cache"
 	"github.com/direito-lux/datajud-service/internal/infrastructure/config"
+	"github.com/direito-lux/datajud-service/internal/infrastructure/logging"
+	"github.com/direito-lux/datajud-service/internal/infrastructure/ratelimit"
 	httpClient "github.com/direito-lux/datajud-service/internal/infrastructure/http"
 	"github.com/direito-lux/datajud-service/internal/infrastructure/handlers"
 )
@@ -55,10 +59,22 @@ func main() {
 	}
 	defer httpClientInstance.Close()
 	
+	// Cache distribuído entre as réplicas
+	distributedCache, closeCache := newDistributedCache(cfg)
+	defer closeCache()
+	
+	// Quotas de rate limiting compartilhadas entre as réplicas
+	rateLimits, closeRateLimits := newRateLimitStore(cfg)
+	defer closeRateLimits()
+	
 	// Para testes rápidos, usar serviço simplificado
 	dataJudService := &SimpleDataJudService{
-		httpClient: httpClientInstance,
-		config:     cfg.GetDataJudDomainConfig(),
+		httpClient:  httpClientInstance,
+		config:      cfg.GetDataJudDomainConfig(),
+		cache:       distributedCache,
+		cacheConfig: cfg.GetDataJudCacheConfig(),
+		coalescer:   application.NewRequestCoalescer(nil),
+		rateLimits:  rateLimits,
 	}
 	
 	// Criar handler HTTP
@@ -135,11 +151,218 @@ func initializeDependencies(cfg *config.Config) error {
 	return nil
 }
 
+// newDistributedCache conecta o cache Redis quando habilitado. Sem Redis disponível o serviço
+// segue consultando o DataJud sem cache.
+func newDistributedCache(cfg *config.Config) (domain.DistributedCache, func()) {
+	cacheConfig := cfg.GetDataJudCacheConfig()
+	if !cacheConfig.Enabled || !cacheConfig.RedisEnabled {
+		return nil, func() {}
+	}
+	
+	client, err := newRedisClient(cfg)
+	if err != nil {
+		log.Printf("⚠️  Redis indisponível, cache distribuído desativado: %v", err)
+		return nil, func() {}
+	}
+	
+	logger, err := logging.NewLogger(cfg.LogLevel, cfg.Environment)
+	if err != nil {
+		log.Printf("⚠️  Erro ao criar logger do cache: %v", err)
+		client.Close()
+		return nil, func() {}
+	}
+	
+	log.Printf("🗄️  Cache distribuído no Redis %s", cfg.GetRedisAddr())
+	redisCache := cache.NewRedisCache(client, cache.RedisCacheConfig{
+		Prefix:               cacheConfig.RedisPrefix,
+		CompressionThreshold: cacheConfig.CompressionThreshold,
+		LoadLockTTL:          cacheConfig.LoadLockTTL,
+		LoadWait:             cacheConfig.LoadWait,
+	}, logger)
+	
+	stopCleanup := make(chan struct{})
+	go runCacheCleanup(redisCache, cacheConfig.CleanupInterval, stopCleanup)
+	
+	return redisCache, func() {
+		close(stopCleanup)
+		client.Close()
+		logger.Sync()
+	}
+}
+
+// runCacheCleanup remove periodicamente do Redis os metadados e índices das entradas expiradas
+// e atualiza a ocupação exibida nas estatísticas do cache
+func runCacheCleanup(redisCache *cache.RedisCache, interval time.Duration, stop <-chan struct{}) {
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	
+	for {
+		removed, err := redisCache.CleanupExpired()
+		if err != nil {
+			log.Printf("⚠️  Erro na limpeza do cache distribuído: %v", err)
+		} else if removed > 0 {
+			log.Printf("🧹 %d entradas expiradas removidas do cache distribuído", removed)
+		}
+		
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// newRateLimitStore conecta as quotas de rate limiting no Redis quando habilitado. Sem Redis
+// disponível as quotas continuam valendo, por réplica, no armazenamento local.
+func newRateLimitStore(cfg *config.Config) (domain.RateLimitStore, func()) {
+	rateLimitConfig := cfg.GetDataJudRateLimitConfig()
+	if !rateLimitConfig.Enabled || !rateLimitConfig.RedisEnabled {
+		log.Println("🚦 Rate limiting local (por réplica)")
+		return domain.NewMemoryRateLimitStore(), func() {}
+	}
+	
+	client, err := newRedisClient(cfg)
+	if err != nil {
+		log.Printf("⚠️  Redis indisponível, rate limiting local (por réplica): %v", err)
+		return domain.NewMemoryRateLimitStore(), func() {}
+	}
+	
+	logger, err := logging.NewLogger(cfg.LogLevel, cfg.Environment)
+	if err != nil {
+		log.Printf("⚠️  Erro ao criar logger do rate limiting, usando rate limiting local: %v", err)
+		client.Close()
+		return domain.NewMemoryRateLimitStore(), func() {}
+	}
+	
+	log.Printf("🚦 Rate limiting distribuído no Redis %s", cfg.GetRedisAddr())
+	store := ratelimit.NewRedisStore(client, ratelimit.RedisStoreConfig{
+		Prefix: rateLimitConfig.RedisPrefix,
+	}, logger)
+	
+	return store, func() {
+		client.Close()
+		logger.Sync()
+	}
+}
+
+// newRedisClient conecta ao Redis e verifica a conexão
+func newRedisClient(cfg *config.Config) (*redis.Client, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:         cfg.GetRedisAddr(),
+		Password:     cfg.Redis.Password,
+		DB:           cfg.Redis.Database,
+		PoolSize:     cfg.Redis.PoolSize,
+		MinIdleConns: cfg.Redis.MinIdleConns,
+		DialTimeout:  cfg.Redis.DialTimeout,
+		ReadTimeout:  cfg.Redis.ReadTimeout,
+		WriteTimeout: cfg.Redis.WriteTimeout,
+	})
+	
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Redis.DialTimeout)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, err
+	}
+	
+	return client, nil
+}
 
 // SimpleDataJudService implementação simplificada para testes rápidos
 type SimpleDataJudService struct {
-	httpClient application.HTTPClient
-	config     domain.DataJudConfig
+	httpClient  application.HTTPClient
+	config      domain.DataJudConfig
+	cache       domain.DistributedCache
+	cacheConfig config.DataJudCacheConfig
+	coalescer   *application.RequestCoalescer
+	rateLimits  domain.RateLimitStore
+}
+
+// reserveQuota reserva a consulta ao DataJud nos limites do CNPJ, do tenant e global de uma vez
+func (s *SimpleDataJudService) reserveQuota(ctx context.Context, req *domain.DataJudRequest, provider *domain.CNPJProvider) (*domain.RateLimitReservation, error) {
+	reservation, err := s.rateLimits.Reserve(ctx, []domain.RateLimitRule{
+		domain.NewCNPJRateLimiter(provider.CNPJ).Rule(),
+		domain.NewTenantRateLimiter(req.TenantID, s.config.GlobalRateLimit, s.config.RateWindowSize).Rule(),
+		domain.NewGlobalRateLimiter(s.config.GlobalRateLimit*10, s.config.RateWindowSize).Rule(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao verificar rate limiting: %w", err)
+	}
+	if !reservation.Status.Allowed {
+		return nil, domain.ErrRateLimitExceeded
+	}
+	return reservation, nil
+}
+
+// execute reserva a quota e executa a chamada ao DataJud. Quando a chamada falha antes de chegar
+// ao DataJud a quota reservada é devolvida.
+func (s *SimpleDataJudService) execute(ctx context.Context, req *domain.DataJudRequest, provider *domain.CNPJProvider, call func(ctx context.Context) (*domain.DataJudResponse, error)) (*domain.DataJudResponse, error) {
+	reservation, err := s.reserveQuota(ctx, req, provider)
+	if err != nil {
+		return nil, err
+	}
+	
+	response, err := call(ctx)
+	if application.RequestNotSent(err) {
+		if refundErr := s.rateLimits.Refund(context.WithoutCancel(ctx), reservation); refundErr != nil {
+			log.Printf("⚠️  Erro ao devolver quota da requisição %s: %v", req.ID, refundErr)
+		}
+	}
+	return response, err
+}
+
+// query executa a consulta compartilhada com as requisições equivalentes em andamento de
+// qualquer tenant, passando pelo cache distribuído quando configurado. Consultas sem cache não
+// são compartilhadas.
+func (s *SimpleDataJudService) query(ctx context.Context, req *domain.DataJudRequest, useCache bool, ttl time.Duration, execute func(ctx context.Context) (*domain.DataJudResponse, error)) (*domain.DataJudResponse, bool, error) {
+	if !useCache {
+		response, err := execute(ctx)
+		return response, false, err
+	}
+	
+	response, fromCache, _, err := s.coalescer.Do(ctx, req, func(ctx context.Context) (*domain.DataJudResponse, bool, error) {
+		return s.queryCache(ctx, req, useCache, ttl, execute)
+	})
+	return response, fromCache, err
+}
+
+// queryCache executa a consulta passando pelo cache distribuído, quando configurado: consultas
+// simultâneas da mesma chave em qualquer réplica executam uma única chamada ao DataJud
+func (s *SimpleDataJudService) queryCache(ctx context.Context, req *domain.DataJudRequest, useCache bool, ttl time.Duration, execute func(ctx context.Context) (*domain.DataJudResponse, error)) (*domain.DataJudResponse, bool, error) {
+	if s.cache == nil || !useCache {
+		response, err := execute(ctx)
+		return response, false, err
+	}
+	
+	entry, cached, err := s.cache.GetOrLoad(ctx, req.CacheKey, func(ctx context.Context) (*domain.CacheEntry, error) {
+		response, err := execute(ctx)
+		if err != nil {
+			return nil, err
+		}
+		
+		entry, err := domain.NewCacheEntry(req.CacheKey, response, int(ttl.Seconds()), req.TenantID, req.Type)
+		if err != nil {
+			return nil, err
+		}
+		entry.SetProcessNumber(req.ProcessNumber)
+		entry.SetCourtID(req.CourtID)
+		return entry, nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	
+	response, ok := entry.Value.(*domain.DataJudResponse)
+	if !ok {
+		return nil, false, fmt.Errorf("formato inválido no cache")
+	}
+	if cached {
+		response.FromCache = true
+	}
+	return response, cached, nil
 }
 
 // QueryProcess implementação simplificada de consulta de processo
@@ -166,7 +389,11 @@ func (s *SimpleDataJudService) QueryProcess(ctx context.Context, req *applicatio
 	}
 
 	// Executar consulta HTTP diretamente
-	response, err := s.httpClient.QueryProcess(ctx, datajudReq, provider)
+	response, fromCache, err := s.query(ctx, datajudReq, req.UseCache, s.cacheConfig.ProcessTTL, func(ctx context.Context) (*domain.DataJudResponse, error) {
+		return s.execute(ctx, datajudReq, provider, func(ctx context.Context) (*domain.DataJudResponse, error) {
+			return s.httpClient.QueryProcess(ctx, datajudReq, provider)
+		})
+	})
 	if err != nil {
 		return &application.ProcessQueryResponse{
 			RequestID: datajudReq.ID,
@@ -175,6 +402,16 @@ func (s *SimpleDataJudService) QueryProcess(ctx context.Context, req *applicatio
 		}, err
 	}
 
+	if fromCache {
+		return &application.ProcessQueryResponse{
+			RequestID: datajudReq.ID,
+			Status:    "completed",
+			Data:      response.ProcessData,
+			FromCache: true,
+			CachedAt:  &response.ReceivedAt,
+		}, nil
+	}
+
 	return &application.ProcessQueryResponse{
 		RequestID: datajudReq.ID,
 		Status:    "completed",
@@ -204,7 +441,11 @@ func (s *SimpleDataJudService) QueryMovements(ctx context.Context, req *applicat
 		CNPJ: "00000000000000",
 	}
 
-	response, err := s.httpClient.QueryMovements(ctx, datajudReq, provider)
+	response, fromCache, err := s.query(ctx, datajudReq, req.UseCache, s.cacheConfig.MovementTTL, func(ctx context.Context) (*domain.DataJudResponse, error) {
+		return s.execute(ctx, datajudReq, provider, func(ctx context.Context) (*domain.DataJudResponse, error) {
+			return s.httpClient.QueryMovements(ctx, datajudReq, provider)
+		})
+	})
 	if err != nil {
 		return &application.MovementQueryResponse{
 			RequestID: datajudReq.ID,
@@ -213,6 +454,16 @@ func (s *SimpleDataJudService) QueryMovements(ctx context.Context, req *applicat
 		}, err
 	}
 
+	if fromCache {
+		return &application.MovementQueryResponse{
+			RequestID: datajudReq.ID,
+			Status:    "completed",
+			Data:      response.MovementData,
+			FromCache: true,
+			CachedAt:  &response.ReceivedAt,
+		}, nil
+	}
+
 	return &application.MovementQueryResponse{
 		RequestID: datajudReq.ID,
 		Status:    "completed",
@@ -69,51 +69,149 @@ func (cm *CacheManager) Get(key string) (*domain.CacheEntry, error) {
 
 // Set armazena entrada no cache
 func (cm *CacheManager) Set(key string, value interface{}, ttl int, tenantID uuid.UUID, requestType domain.RequestType) error {
+	entry, err := cm.newEntry(key, value, ttl, tenantID, requestType)
+	if err != nil {
+		return err
+	}
+
+	return cm.store(entry)
+}
+
+// Store armazena a resposta da requisição, com o processo e o tribunal da requisição
+func (cm *CacheManager) Store(req *domain.DataJudRequest, response *domain.DataJudResponse) error {
+	entry, err := cm.newRequestEntry(req, response)
+	if err != nil {
+		return err
+	}
+
+	return cm.store(entry)
+}
+
+// Load retorna a resposta em cache da requisição ou executa load e armazena a resposta. Com
+// cache distribuído, consultas simultâneas da mesma chave (inclusive em outras réplicas)
+// executam load uma única vez. fromCache indica resposta que não veio desta execução de load.
+func (cm *CacheManager) Load(ctx context.Context, req *domain.DataJudRequest, load func(ctx context.Context) (*domain.DataJudResponse, error)) (response *domain.DataJudResponse, fromCache bool, err error) {
+	distributed, ok := cm.cache.(domain.DistributedCache)
+	if !ok || !req.UseCache {
+		response, err := load(ctx)
+		if err == nil && req.UseCache && response != nil {
+			cm.Store(req, response)
+		}
+		return response, false, err
+	}
+
+	var loaded *domain.CacheEntry
+	entry, cached, err := distributed.GetOrLoad(ctx, req.CacheKey, func(ctx context.Context) (*domain.CacheEntry, error) {
+		response, err := load(ctx)
+		if err != nil || response == nil {
+			return nil, err
+		}
+
+		if err := cm.enforceCapacityLimits(); err != nil {
+			return nil, fmt.Errorf("erro ao verificar capacidade: %w", err)
+		}
+		loaded, err = cm.newRequestEntry(req, response)
+		return loaded, err
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	if entry == nil {
+		return nil, false, nil
+	}
+
+	// A entrada gravada por esta carga é contabilizada como set
+	if loaded != nil && entry == loaded {
+		cm.recordStored(entry)
+	}
+
+	response, ok = entry.Value.(*domain.DataJudResponse)
+	if !ok {
+		return nil, false, fmt.Errorf("formato inválido no cache")
+	}
+	if cached {
+		response.FromCache = true
+	}
+
+	return response, cached, nil
+}
+
+// newEntry cria a entrada com TTL padrão e metadados do valor
+func (cm *CacheManager) newEntry(key string, value interface{}, ttl int, tenantID uuid.UUID, requestType domain.RequestType) (*domain.CacheEntry, error) {
 	if ttl <= 0 {
 		ttl = cm.config.DefaultCacheTTL
 	}
 
+	entry, err := domain.NewCacheEntry(key, value, ttl, tenantID, requestType)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar entrada de cache: %w", err)
+	}
+
+	// Definir metadados específicos baseado no tipo
+	entry.SetMetadataFromValue()
+
+	return entry, nil
+}
+
+// newRequestEntry cria a entrada da resposta com os metadados da requisição
+func (cm *CacheManager) newRequestEntry(req *domain.DataJudRequest, response *domain.DataJudResponse) (*domain.CacheEntry, error) {
+	entry, err := cm.newEntry(req.CacheKey, response, req.CacheTTL, req.TenantID, req.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.ProcessNumber == "" && req.ProcessNumber != "" {
+		entry.SetProcessNumber(req.ProcessNumber)
+	}
+	if req.CourtID != "" {
+		entry.SetCourtID(req.CourtID)
+	}
+
+	return entry, nil
+}
+
+// store grava a entrada, mantendo os metadados quando o cache é distribuído
+func (cm *CacheManager) store(entry *domain.CacheEntry) error {
 	// Verificar limites antes de adicionar
 	if err := cm.enforceCapacityLimits(); err != nil {
 		return fmt.Errorf("erro ao verificar capacidade: %w", err)
 	}
 
-	entry, err := domain.NewCacheEntry(key, value, ttl, tenantID, requestType)
+	var err error
+	if distributed, ok := cm.cache.(domain.DistributedCache); ok {
+		err = distributed.SetEntry(entry)
+	} else {
+		err = cm.cache.Set(entry.Key, entry.Value, entry.TTL)
+	}
 	if err != nil {
-		return fmt.Errorf("erro ao criar entrada de cache: %w", err)
+		return err
 	}
 
-	// Definir metadados específicos baseado no tipo
-	cm.setCacheMetadata(entry, value)
+	cm.recordStored(entry)
+	return nil
+}
 
-	err = cm.cache.Set(entry.Key, entry.Value, entry.TTL)
-	
+// recordStored contabiliza o set e publica o evento de cache stored
+func (cm *CacheManager) recordStored(entry *domain.CacheEntry) {
 	cm.stats.mu.Lock()
-	if err == nil {
-		cm.stats.TotalSets++
-	}
+	cm.stats.TotalSets++
 	cm.stats.mu.Unlock()
 
-	// Publicar evento de cache stored
-	if err == nil {
-		event := &domain.DataJudCacheStored{
-			BaseEvent: domain.BaseEvent{
-				ID:          uuid.New(),
-				Type:        "datajud.cache.stored",
-				AggregateID: tenantID,
-				OccurredAt:  time.Now(),
-				Version:     1,
-				Metadata:    make(map[string]interface{}),
-			},
-			CacheKey:    key,
-			RequestType: requestType,
-			TTL:         ttl,
-			Size:        entry.Size,
-		}
-		cm.repos.EventStore.SaveEvent(context.Background(), event)
+	event := &domain.DataJudCacheStored{
+		BaseEvent: domain.BaseEvent{
+			ID:          uuid.New(),
+			Type:        "datajud.cache.stored",
+			AggregateID: entry.TenantID,
+			OccurredAt:  time.Now(),
+			Version:     1,
+			Metadata:    make(map[string]interface{}),
+		},
+		CacheKey:    entry.Key,
+		RequestType: entry.RequestType,
+		TTL:         entry.TTL,
+		Size:        entry.Size,
 	}
-
-	return err
+	cm.repos.EventStore.SaveEvent(context.Background(), event)
 }
 
 // Delete remove entrada do cache
@@ -141,16 +239,37 @@ func (cm *CacheManager) Clear() error {
 
 // GetByProcessNumber obtém entradas de cache por número de processo
 func (cm *CacheManager) GetByProcessNumber(processNumber string) ([]*domain.CacheEntry, error) {
-	return cm.repos.Cache.FindByRequestType(domain.RequestTypeProcess)
+	if distributed, ok := cm.cache.(domain.DistributedCache); ok {
+		return distributed.FindByProcessNumber(processNumber)
+	}
+
+	entries, err := cm.repos.Cache.FindByRequestType(domain.RequestTypeProcess)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]*domain.CacheEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.ProcessNumber == processNumber {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered, nil
 }
 
 // GetByTenant obtém entradas de cache por tenant
 func (cm *CacheManager) GetByTenant(tenantID uuid.UUID) ([]*domain.CacheEntry, error) {
+	if distributed, ok := cm.cache.(domain.DistributedCache); ok {
+		return distributed.FindByTenantID(tenantID)
+	}
 	return cm.repos.Cache.FindByTenantID(tenantID)
 }
 
 // GetByRequestType obtém entradas por tipo de requisição
 func (cm *CacheManager) GetByRequestType(requestType domain.RequestType) ([]*domain.CacheEntry, error) {
+	if distributed, ok := cm.cache.(domain.DistributedCache); ok {
+		return distributed.FindByRequestType(requestType)
+	}
 	return cm.repos.Cache.FindByRequestType(requestType)
 }
 
@@ -162,6 +281,11 @@ func (cm *CacheManager) GetStats() (*domain.CacheStats, error) {
 		return nil, err
 	}
 
+	// Cache distribuído já agrega hits e misses de todas as réplicas
+	if _, ok := cm.cache.(domain.DistributedCache); ok {
+		return baseStats, nil
+	}
+
 	// Adicionar estatísticas do manager
 	cm.stats.mu.RLock()
 	baseStats.HitCount = cm.stats.TotalHits
@@ -271,9 +395,14 @@ func (cm *CacheManager) GetStatsByRequestType() (map[domain.RequestType]map[stri
 // CleanupExpired remove entradas expiradas
 func (cm *CacheManager) CleanupExpired() (int, error) {
 	// Cache em memória não tem método CleanupExpired
-	// Esta funcionalidade seria implementada em cache baseado em repositório
 	count := 0
-	
+	if distributed, ok := cm.cache.(domain.DistributedCache); ok {
+		var err error
+		if count, err = distributed.CleanupExpired(); err != nil {
+			return 0, err
+		}
+	}
+
 	cm.stats.mu.Lock()
 	cm.stats.TotalEvictions += int64(count)
 	cm.stats.mu.Unlock()
@@ -437,18 +566,6 @@ func (cm *CacheManager) enforceCapacityLimits() error {
 	return nil
 }
 
-// setCacheMetadata define metadados específicos da entrada
-func (cm *CacheManager) setCacheMetadata(entry *domain.CacheEntry, value interface{}) {
-	switch response := value.(type) {
-	case *domain.DataJudResponse:
-		if response.ProcessData != nil {
-			entry.SetProcessNumber(response.ProcessData.Number)
-		}
-	case *domain.ProcessResponseData:
-		entry.SetProcessNumber(response.Number)
-	}
-}
-
 // getHitRatio calcula taxa de acerto
 func (cm *CacheManager) getHitRatio() float64 {
 	total := cm.stats.TotalHits + cm.stats.TotalMisses
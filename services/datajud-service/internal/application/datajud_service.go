@@ -29,6 +29,7 @@ type DataJudService struct {
 	domainService   domain.DomainService
 	config          domain.DataJudConfig
 	httpClient      HTTPClient // Interface para o cliente HTTP
+	coalescer       *RequestCoalescer
 }
 
 // NewDataJudService cria nova instância do serviço
@@ -42,7 +43,7 @@ func NewDataJudService(
 	config domain.DataJudConfig,
 	httpClient HTTPClient,
 ) *DataJudService {
-	return &DataJudService{
+	service := &DataJudService{
 		repos:            repos,
 		poolManager:      poolManager,
 		rateLimitManager: rateLimitManager,
@@ -52,6 +53,9 @@ func NewDataJudService(
 		config:           config,
 		httpClient:       httpClient,
 	}
+	service.coalescer = NewRequestCoalescer(service.completeCoalesced)
+
+	return service
 }
 
 // QueryProcess consulta um processo específico
@@ -88,7 +92,7 @@ func (s *DataJudService) QueryProcess(ctx context.Context, req *ProcessQueryRequ
 	}
 
 	// Executar consulta
-	response, err := s.executeRequest(ctx, datajudReq)
+	response, fromCache, err := s.coalescedQuery(ctx, datajudReq, req.UseCache)
 	if err != nil {
 		return &ProcessQueryResponse{
 			RequestID: datajudReq.ID,
@@ -97,6 +101,16 @@ func (s *DataJudService) QueryProcess(ctx context.Context, req *ProcessQueryRequ
 		}, err
 	}
 
+	if fromCache {
+		return &ProcessQueryResponse{
+			RequestID: datajudReq.ID,
+			Status:    "completed",
+			Data:      response.ProcessData,
+			FromCache: true,
+			CachedAt:  &response.ReceivedAt,
+		}, nil
+	}
+
 	return &ProcessQueryResponse{
 		RequestID: datajudReq.ID,
 		Status:    "completed",
@@ -138,7 +152,7 @@ func (s *DataJudService) QueryMovements(ctx context.Context, req *MovementQueryR
 		}
 	}
 
-	response, err := s.executeRequest(ctx, datajudReq)
+	response, fromCache, err := s.coalescedQuery(ctx, datajudReq, req.UseCache)
 	if err != nil {
 		return &MovementQueryResponse{
 			RequestID: datajudReq.ID,
@@ -147,6 +161,16 @@ func (s *DataJudService) QueryMovements(ctx context.Context, req *MovementQueryR
 		}, err
 	}
 
+	if fromCache {
+		return &MovementQueryResponse{
+			RequestID: datajudReq.ID,
+			Status:    "completed",
+			Data:      response.MovementData,
+			FromCache: true,
+			CachedAt:  &response.ReceivedAt,
+		}, nil
+	}
+
 	return &MovementQueryResponse{
 		RequestID: datajudReq.ID,
 		Status:    "completed",
@@ -187,8 +211,14 @@ func (s *DataJudService) BulkQuery(ctx context.Context, req *BulkQueryRequest) (
 		datajudReq.SetProcessNumber(query.ProcessNumber)
 		datajudReq.SetCourtID(query.CourtID)
 
-		// Executar consulta
-		datajudResp, err := s.executeRequest(ctx, datajudReq)
+		// Executar consulta, compartilhada com consultas equivalentes de outros tenants
+		datajudResp, _, _, err := s.coalescer.Do(ctx, datajudReq, func(ctx context.Context) (*domain.DataJudResponse, bool, error) {
+			response, err := s.executeRequest(ctx, datajudReq)
+			if err == nil && datajudReq.UseCache {
+				s.cacheManager.Store(datajudReq, response)
+			}
+			return response, false, err
+		})
 		if err != nil {
 			result.Status = "failed"
 			result.Error = err.Error()
@@ -236,12 +266,19 @@ func (s *DataJudService) executeRequest(ctx context.Context, req *domain.DataJud
 		return nil, fmt.Errorf("nenhum CNPJ disponível: %w", err)
 	}
 
-	// 3. Verificar rate limiting do CNPJ
-	rateLimiter := s.rateLimitManager.GetCNPJLimiter(provider.CNPJ)
-	status := rateLimiter.Allow()
-	if !status.Allowed {
+	// 3. Reservar quota do CNPJ, do tenant e global de uma vez
+	reservation, err := s.rateLimitManager.Reserve(ctx, provider.CNPJ, req.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao verificar rate limiting: %w", err)
+	}
+	if !reservation.Status.Allowed {
+		status := reservation.Status
+		limitType, limitKey := domain.RateLimitCNPJ, provider.CNPJ
+		if reservation.DeniedBy != nil {
+			limitType, limitKey = reservation.DeniedBy.Type, reservation.DeniedBy.Key
+		}
 		// Tentar outro CNPJ
-		provider, err = s.findAlternativeCNPJ(ctx, pool, req)
+		alternative, alternativeReservation, err := s.findAlternativeCNPJ(ctx, pool, req)
 		if err != nil {
 			event := &domain.RateLimitExceeded{
 				BaseEvent: domain.BaseEvent{
@@ -252,8 +289,8 @@ func (s *DataJudService) executeRequest(ctx context.Context, req *domain.DataJud
 					Version:     1,
 					Metadata:    make(map[string]interface{}),
 				},
-				LimitType:     domain.RateLimitCNPJ,
-				Key:           provider.CNPJ,
+				LimitType:     limitType,
+				Key:           limitKey,
 				RequestsUsed:  status.RequestsUsed,
 				RequestsLimit: status.RequestsLimit,
 				ResetTime:     status.ResetTime,
@@ -261,6 +298,7 @@ func (s *DataJudService) executeRequest(ctx context.Context, req *domain.DataJud
 			s.repos.EventStore.SaveEvent(ctx, event)
 			return nil, domain.ErrRateLimitExceeded
 		}
+		provider, reservation = alternative, alternativeReservation
 	}
 
 	// 4. Atualizar requisição com provider selecionado
@@ -269,6 +307,7 @@ func (s *DataJudService) executeRequest(ctx context.Context, req *domain.DataJud
 
 	// 5. Salvar requisição
 	if err := s.repos.DataJudRequest.Save(req); err != nil {
+		s.rateLimitManager.Refund(ctx, reservation)
 		return nil, fmt.Errorf("erro ao salvar requisição: %w", err)
 	}
 
@@ -300,6 +339,11 @@ func (s *DataJudService) executeRequest(ctx context.Context, req *domain.DataJud
 		return err
 	})
 
+	// Circuit breaker aberto ou falha de conexão: a consulta não chegou ao DataJud, a quota volta
+	if !result.Allowed || RequestNotSent(result.Error) {
+		s.rateLimitManager.Refund(ctx, reservation)
+	}
+
 	// 7. Processar resultado
 	if result.Success {
 		// Usar quota do provider
@@ -310,11 +354,6 @@ func (s *DataJudService) executeRequest(ctx context.Context, req *domain.DataJud
 		req.Complete(response)
 		s.repos.DataJudRequest.Update(req)
 
-		// Salvar no cache se configurado
-		if req.UseCache && response != nil {
-			s.cacheManager.Set(req.CacheKey, response, req.CacheTTL, req.TenantID, req.Type)
-		}
-
 		// Publicar evento de sucesso
 		event := domain.NewDataJudRequestCompleted(
 			req.ID,
@@ -377,6 +416,67 @@ func (s *DataJudService) executeRequest(ctx context.Context, req *domain.DataJud
 	}
 }
 
+// coalescedQuery executa a requisição após um cache miss, compartilhando a consulta com as
+// requisições equivalentes em andamento de qualquer tenant. Consultas sem cache não são
+// compartilhadas: quem pede dados atualizados não recebe a resposta de uma consulta em andamento.
+func (s *DataJudService) coalescedQuery(ctx context.Context, req *domain.DataJudRequest, useCache bool) (*domain.DataJudResponse, bool, error) {
+	if !useCache {
+		return s.queryWithCache(ctx, req, useCache)
+	}
+
+	response, fromCache, _, err := s.coalescer.Do(ctx, req, func(ctx context.Context) (*domain.DataJudResponse, bool, error) {
+		return s.queryWithCache(ctx, req, useCache)
+	})
+	return response, fromCache, err
+}
+
+// completeCoalesced registra as requisições atendidas pela consulta de outra requisição e
+// atribui a quota consumida, cobrada de um único CNPJ, proporcionalmente entre os tenants
+func (s *DataJudService) completeCoalesced(ctx context.Context, leader *domain.DataJudRequest, requests []*domain.DataJudRequest, response *domain.DataJudResponse, fromCache bool, err error) {
+	if len(requests) < 2 || err != nil {
+		return
+	}
+
+	for _, request := range requests[1:] {
+		if leader.CNPJProviderID != nil {
+			request.SetCNPJProvider(*leader.CNPJProviderID)
+		}
+		request.Complete(response.CopyFor(request))
+		s.repos.DataJudRequest.Save(request)
+	}
+
+	// Resposta do cache não consumiu quota
+	if fromCache {
+		return
+	}
+	s.repos.EventStore.SaveEvent(ctx, domain.NewDataJudRequestCoalesced(leader, requests, 1))
+}
+
+// GetCoalescingStats retorna estatísticas de coalescência das requisições
+func (s *DataJudService) GetCoalescingStats() map[string]interface{} {
+	return s.coalescer.GetStats()
+}
+
+// queryWithCache executa a requisição após um cache miss. Com cache, consultas simultâneas da
+// mesma chave compartilham uma única execução e a resposta é armazenada; sem cache, a consulta
+// é sempre executada e a resposta apenas armazenada.
+func (s *DataJudService) queryWithCache(ctx context.Context, req *domain.DataJudRequest, useCache bool) (*domain.DataJudResponse, bool, error) {
+	if useCache {
+		return s.cacheManager.Load(ctx, req, func(ctx context.Context) (*domain.DataJudResponse, error) {
+			return s.executeRequest(ctx, req)
+		})
+	}
+
+	response, err := s.executeRequest(ctx, req)
+	if err != nil {
+		return nil, false, err
+	}
+	if req.UseCache {
+		s.cacheManager.Store(req, response)
+	}
+	return response, false, nil
+}
+
 // checkCache verifica se existe entrada no cache
 func (s *DataJudService) checkCache(ctx context.Context, req *domain.DataJudRequest) (*domain.DataJudResponse, error) {
 	entry, err := s.cacheManager.Get(req.CacheKey)
@@ -431,24 +531,25 @@ func (s *DataJudService) checkCache(ctx context.Context, req *domain.DataJudRequ
 	return response, nil
 }
 
-// findAlternativeCNPJ busca um CNPJ alternativo com quota disponível
-func (s *DataJudService) findAlternativeCNPJ(ctx context.Context, pool *domain.CNPJPool, req *domain.DataJudRequest) (*domain.CNPJProvider, error) {
+// findAlternativeCNPJ busca um CNPJ alternativo com quota disponível, já reservando a quota
+func (s *DataJudService) findAlternativeCNPJ(ctx context.Context, pool *domain.CNPJPool, req *domain.DataJudRequest) (*domain.CNPJProvider, *domain.RateLimitReservation, error) {
 	providers := pool.GetAllProviders()
 	
 	for _, provider := range providers {
-		if !provider.IsActive {
+		if !provider.IsActive || !provider.CanMakeRequest() {
 			continue
 		}
 
-		rateLimiter := s.rateLimitManager.GetCNPJLimiter(provider.CNPJ)
-		status := rateLimiter.GetStatus()
-		
-		if status.Allowed && provider.CanMakeRequest() {
-			return provider, nil
+		reservation, err := s.rateLimitManager.Reserve(ctx, provider.CNPJ, req.TenantID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("erro ao verificar rate limiting: %w", err)
+		}
+		if reservation.Status.Allowed {
+			return provider, reservation, nil
 		}
 	}
 
-	return nil, fmt.Errorf("nenhum CNPJ alternativo disponível")
+	return nil, nil, fmt.Errorf("nenhum CNPJ alternativo disponível")
 }
 
 // calculateRetryDelay calcula delay exponencial para retry
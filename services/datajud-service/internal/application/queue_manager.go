@@ -25,6 +25,10 @@ type QueueManager struct {
 	normalQueue   *RequestQueue
 	lowQueue      *RequestQueue
 	
+	// Requisições na fila por chave de coalescência, para incorporar requisições equivalentes
+	pending       map[string]*domain.DataJudRequest
+	pendingMu     sync.Mutex
+	
 	// Workers
 	workers       []*QueueWorker
 	workerCount   int
@@ -66,6 +70,7 @@ type QueueStatistics struct {
 	TotalFailed     int64 `json:"total_failed"`
 	TotalCompleted  int64 `json:"total_completed"`
 	TotalRetries    int64 `json:"total_retries"`
+	TotalMerged     int64 `json:"total_merged"`
 	AvgProcessTime  float64 `json:"avg_process_time_ms"`
 	StartTime       time.Time `json:"start_time"`
 	mu              sync.RWMutex
@@ -87,6 +92,7 @@ func NewQueueManager(
 		circuitManager:   circuitManager,
 		cacheManager:     cacheManager,
 		config:           config,
+		pending:          make(map[string]*domain.DataJudRequest),
 		stopChan:         make(chan bool),
 		workerCount:      5, // Configurável
 		stats: &QueueStatistics{
@@ -161,27 +167,59 @@ func (qm *QueueManager) Stop() {
 	qm.isRunning = false
 }
 
-// Enqueue adiciona requisição à fila apropriada
+// Enqueue adiciona requisição à fila apropriada. Requisição equivalente (mesmo tipo, processo
+// e tribunal) já aguardando na fila incorpora a nova, que é concluída junto com ela.
 func (qm *QueueManager) Enqueue(ctx context.Context, request *domain.DataJudRequest) error {
 	// Salvar requisição no banco
 	if err := qm.repos.DataJudRequest.Save(request); err != nil {
 		return fmt.Errorf("erro ao salvar requisição: %w", err)
 	}
 
-	// Adicionar à fila baseada na prioridade
-	queue := qm.getQueueByPriority(request.Priority)
-	if err := queue.Enqueue(request); err != nil {
+	merged, err := qm.enqueue(request)
+	if err != nil {
 		return fmt.Errorf("erro ao adicionar à fila: %w", err)
 	}
 
 	// Atualizar estatísticas
 	qm.stats.mu.Lock()
 	qm.stats.TotalQueued++
+	if merged {
+		qm.stats.TotalMerged++
+	}
 	qm.stats.mu.Unlock()
 
 	return nil
 }
 
+// enqueue incorpora a requisição à equivalente pendente ou a adiciona à fila da prioridade
+func (qm *QueueManager) enqueue(request *domain.DataJudRequest) (bool, error) {
+	qm.pendingMu.Lock()
+	defer qm.pendingMu.Unlock()
+
+	key := request.CoalescingKey()
+	if existing, ok := qm.pending[key]; ok && existing.ID != request.ID {
+		previousPriority := existing.Priority
+		existing.Merge(request)
+
+		// Prioridade elevada pela requisição incorporada: mover de fila
+		if existing.Priority != previousPriority && qm.getQueueByPriority(previousPriority).Remove(existing.ID) {
+			if err := qm.getQueueByPriority(existing.Priority).Enqueue(existing); err != nil {
+				existing.Priority = previousPriority
+				qm.getQueueByPriority(previousPriority).Enqueue(existing)
+			}
+		}
+		qm.repos.DataJudRequest.Update(existing)
+		return true, nil
+	}
+
+	// Adicionar à fila baseada na prioridade
+	if err := qm.getQueueByPriority(request.Priority).Enqueue(request); err != nil {
+		return false, err
+	}
+	qm.pending[key] = request
+	return false, nil
+}
+
 // Dequeue obtém próxima requisição para processamento
 func (qm *QueueManager) Dequeue() *domain.DataJudRequest {
 	// Processar por ordem de prioridade: urgent -> high -> normal -> low
@@ -192,8 +230,16 @@ func (qm *QueueManager) Dequeue() *domain.DataJudRequest {
 		qm.lowQueue,
 	}
 
+	// Requisição retirada da fila não incorpora mais requisições equivalentes
+	qm.pendingMu.Lock()
+	defer qm.pendingMu.Unlock()
+
 	for _, queue := range queues {
 		if request := queue.Dequeue(); request != nil {
+			key := request.CoalescingKey()
+			if qm.pending[key] == request {
+				delete(qm.pending, key)
+			}
 			return request
 		}
 	}
@@ -225,6 +271,7 @@ func (qm *QueueManager) GetQueueStats() map[string]interface{} {
 		"total_completed":    qm.stats.TotalCompleted,
 		"total_failed":       qm.stats.TotalFailed,
 		"total_retries":      qm.stats.TotalRetries,
+		"total_merged":       qm.stats.TotalMerged,
 		"avg_process_time":   qm.stats.AvgProcessTime,
 		"queue_sizes": map[string]int{
 			"urgent":  qm.urgentQueue.Size(),
@@ -252,10 +299,11 @@ func (qm *QueueManager) ProcessRequest(ctx context.Context, request *domain.Data
 		cachedResponse, err := qm.cacheManager.Get(request.CacheKey)
 		if err == nil && cachedResponse != nil {
 			// Cache hit - completar requisição
-			response := cachedResponse.Value.(*domain.DataJudResponse)
+			response := cachedResponse.Value.(*domain.DataJudResponse).CopyFor(request)
 			response.FromCache = true
 			request.Complete(response)
 			qm.repos.DataJudRequest.Update(request)
+			qm.completeCoalesced(ctx, request, nil)
 			
 			qm.updateProcessingStats(true, time.Since(startTime))
 			return nil
@@ -278,12 +326,15 @@ func (qm *QueueManager) ProcessRequest(ctx context.Context, request *domain.Data
 		return err
 	}
 
-	// Verificar rate limiting
-	status, err := qm.rateLimitManager.CheckAllowance(provider.CNPJ, request.TenantID)
-	if err != nil || !status.Allowed {
+	// Verificar rate limiting (CNPJ, tenant e global reservados de uma vez)
+	reservation, err := qm.rateLimitManager.Reserve(ctx, provider.CNPJ, request.TenantID)
+	if err != nil || !reservation.Status.Allowed {
 		// Tentar agendar para mais tarde
 		if request.CanRetry() {
-			retryDelay := status.RetryAfter
+			var retryDelay time.Duration
+			if reservation != nil {
+				retryDelay = reservation.Status.RetryAfter
+			}
 			if retryDelay < time.Minute {
 				retryDelay = time.Minute
 			}
@@ -307,10 +358,16 @@ func (qm *QueueManager) ProcessRequest(ctx context.Context, request *domain.Data
 		return qm.executeDataJudRequest(ctx, request, provider)
 	})
 
+	// Circuit breaker aberto ou falha de conexão: a consulta não chegou ao DataJud, a quota volta
+	if !result.Allowed || RequestNotSent(result.Error) {
+		qm.rateLimitManager.Refund(ctx, reservation)
+	}
+
 	if result.Success {
-		// Sucesso - usar quota do provider
+		// Sucesso - usar quota do provider, uma única vez para as requisições incorporadas
 		provider.UseQuota(1)
 		qm.repos.CNPJProvider.Update(provider)
+		qm.completeCoalesced(ctx, request, provider)
 		qm.updateProcessingStats(true, time.Since(startTime))
 	} else {
 		// Falha - verificar se deve retry
@@ -384,17 +441,38 @@ func (qm *QueueManager) loadPendingRequests(ctx context.Context) error {
 	}
 
 	for _, request := range requests {
-		queue := qm.getQueueByPriority(request.Priority)
-		queue.Enqueue(request)
+		qm.enqueue(request)
 	}
 
 	return nil
 }
 
-// failRequest marca requisição como falhada
+// completeCoalesced conclui as requisições incorporadas com a resposta da requisição e, quando
+// a consulta consumiu quota do provider, atribui o custo proporcionalmente entre os tenants
+func (qm *QueueManager) completeCoalesced(ctx context.Context, request *domain.DataJudRequest, provider *domain.CNPJProvider) {
+	if len(request.Coalesced) == 0 {
+		return
+	}
+
+	for _, coalesced := range request.Coalesced {
+		if provider != nil {
+			coalesced.SetCNPJProvider(provider.ID)
+		}
+		coalesced.Complete(request.Response.CopyFor(coalesced))
+		qm.repos.DataJudRequest.Update(coalesced)
+	}
+
+	if provider != nil {
+		qm.repos.EventStore.SaveEvent(ctx, domain.NewDataJudRequestCoalesced(request, request.Requests(), 1))
+	}
+}
+
+// failRequest marca requisição como falhada, junto com as requisições incorporadas
 func (qm *QueueManager) failRequest(request *domain.DataJudRequest, errorCode, errorMessage string) {
-	request.Fail(errorCode, errorMessage)
-	qm.repos.DataJudRequest.Update(request)
+	for _, r := range request.Requests() {
+		r.Fail(errorCode, errorMessage)
+		qm.repos.DataJudRequest.Update(r)
+	}
 }
 
 // calculateRetryDelay calcula delay para retry
@@ -520,6 +598,20 @@ func (rq *RequestQueue) Dequeue() *domain.DataJudRequest {
 	return request
 }
 
+// Remove retira a requisição da fila, retornando se estava na fila
+func (rq *RequestQueue) Remove(id uuid.UUID) bool {
+	rq.mu.Lock()
+	defer rq.mu.Unlock()
+
+	for i, request := range rq.requests {
+		if request.ID == id {
+			rq.requests = append(rq.requests[:i], rq.requests[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
 // Size retorna tamanho da fila
 func (rq *RequestQueue) Size() int {
 	rq.mu.RLock()
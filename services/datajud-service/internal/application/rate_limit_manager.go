@@ -2,6 +2,9 @@ package application
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net"
 	"sync"
 	"time"
 
@@ -9,9 +12,11 @@ import (
 	"github.com/direito-lux/datajud-service/internal/domain"
 )
 
-// RateLimitManager gerencia limitadores de taxa
+// RateLimitManager gerencia limitadores de taxa. Os limiters guardam configuração (janela,
+// ativação); o uso de quota fica no store, compartilhado entre as réplicas quando distribuído.
 type RateLimitManager struct {
 	repos     *domain.Repositories
+	store     domain.RateLimitStore
 	limiters  map[string]*domain.RateLimiter // Cache de limiters por chave
 	mu        sync.RWMutex
 	config    domain.DataJudConfig
@@ -19,10 +24,16 @@ type RateLimitManager struct {
 	stopCleanup   chan bool
 }
 
-// NewRateLimitManager cria novo gerenciador de rate limiting
-func NewRateLimitManager(repos *domain.Repositories, config domain.DataJudConfig) *RateLimitManager {
+// NewRateLimitManager cria novo gerenciador de rate limiting. Sem store, o uso de quota fica
+// na memória desta réplica.
+func NewRateLimitManager(repos *domain.Repositories, config domain.DataJudConfig, store domain.RateLimitStore) *RateLimitManager {
+	if store == nil {
+		store = domain.NewMemoryRateLimitStore()
+	}
+
 	manager := &RateLimitManager{
 		repos:     repos,
+		store:     store,
 		limiters:  make(map[string]*domain.RateLimiter),
 		config:    config,
 		stopCleanup: make(chan bool),
@@ -119,56 +130,67 @@ func (rm *RateLimitManager) GetGlobalLimiter() *domain.RateLimiter {
 	return limiter
 }
 
-// CheckAllowance verifica se uma requisição pode ser feita considerando todos os limiters
-func (rm *RateLimitManager) CheckAllowance(cnpj string, tenantID uuid.UUID) (domain.RateLimitStatus, error) {
-	// 1. Verificar limite do CNPJ (mais restritivo)
-	cnpjLimiter := rm.GetCNPJLimiter(cnpj)
-	cnpjStatus := cnpjLimiter.Allow()
-	if !cnpjStatus.Allowed {
-		return cnpjStatus, nil
+// CheckAllowance reserva uma requisição nos limites do CNPJ, do tenant e global de uma vez:
+// se algum estiver esgotado, nenhum é consumido
+func (rm *RateLimitManager) CheckAllowance(ctx context.Context, cnpj string, tenantID uuid.UUID) (domain.RateLimitStatus, error) {
+	reservation, err := rm.Reserve(ctx, cnpj, tenantID)
+	if err != nil {
+		return domain.RateLimitStatus{}, err
 	}
+	return reservation.Status, nil
+}
 
-	// 2. Verificar limite do tenant
-	tenantLimiter := rm.GetTenantLimiter(tenantID)
-	tenantStatus := tenantLimiter.Allow()
-	if !tenantStatus.Allowed {
-		// Reverter o uso do CNPJ já que foi negado pelo tenant
-		rm.revertCNPJUsage(cnpjLimiter)
-		return tenantStatus, nil
-	}
+// Reserve reserva uma requisição nos limites do CNPJ, do tenant e global. A reserva concedida
+// pode ser devolvida com Refund se a consulta não chegar ao DataJud.
+func (rm *RateLimitManager) Reserve(ctx context.Context, cnpj string, tenantID uuid.UUID) (*domain.RateLimitReservation, error) {
+	return rm.reserve(ctx,
+		rm.GetCNPJLimiter(cnpj),
+		rm.GetTenantLimiter(tenantID),
+		rm.GetGlobalLimiter(),
+	)
+}
 
-	// 3. Verificar limite global
-	globalLimiter := rm.GetGlobalLimiter()
-	globalStatus := globalLimiter.Allow()
-	if !globalStatus.Allowed {
-		// Reverter usos anteriores
-		rm.revertCNPJUsage(cnpjLimiter)
-		rm.revertTenantUsage(tenantLimiter)
-		return globalStatus, nil
+// Refund devolve exatamente a quota consumida por uma reserva concedida
+func (rm *RateLimitManager) Refund(ctx context.Context, reservation *domain.RateLimitReservation) error {
+	if !reservation.Granted() {
+		return nil
 	}
+	if err := rm.store.Refund(ctx, reservation); err != nil {
+		return fmt.Errorf("failed to refund rate limit reservation: %w", err)
+	}
+	return nil
+}
 
-	// Salvar alterações nos limiters
-	rm.saveLimiterUpdates(cnpjLimiter, tenantLimiter, globalLimiter)
+// RequestNotSent indica se a consulta falhou antes de chegar ao DataJud: sem conexão ou resolução
+// de nome, a requisição não consumiu a quota do CNPJ no DataJud e a reserva deve ser devolvida
+func RequestNotSent(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
 
-	// Retornar o status mais restritivo (CNPJ)
-	return cnpjStatus, nil
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
 }
 
 // GetCNPJStatus obtém status do CNPJ sem consumir quota
 func (rm *RateLimitManager) GetCNPJStatus(cnpj string) domain.RateLimitStatus {
-	limiter := rm.GetCNPJLimiter(cnpj)
-	return limiter.GetStatus()
+	return rm.status(context.Background(), rm.GetCNPJLimiter(cnpj))
 }
 
 // GetTenantStatus obtém status do tenant sem consumir quota
 func (rm *RateLimitManager) GetTenantStatus(tenantID uuid.UUID) domain.RateLimitStatus {
-	limiter := rm.GetTenantLimiter(tenantID)
-	return limiter.GetStatus()
+	return rm.status(context.Background(), rm.GetTenantLimiter(tenantID))
 }
 
 // ResetCNPJUsage reseta o uso de um CNPJ específico
 func (rm *RateLimitManager) ResetCNPJUsage(ctx context.Context, cnpj string) error {
 	limiter := rm.GetCNPJLimiter(cnpj)
+	previous := rm.status(ctx, limiter)
+
+	if err := rm.store.Reset(ctx, limiter.Rule()); err != nil {
+		return fmt.Errorf("failed to reset rate limit: %w", err)
+	}
 	limiter.Reset()
 	
 	if err := rm.repos.RateLimiter.Update(limiter); err != nil {
@@ -187,7 +209,7 @@ func (rm *RateLimitManager) ResetCNPJUsage(ctx context.Context, cnpj string) err
 		},
 		LimitType:     domain.RateLimitCNPJ,
 		Key:           cnpj,
-		PreviousUsage: limiter.GetRemainingQuota(),
+		PreviousUsage: previous.RequestsUsed,
 		NewLimit:      limiter.Window.MaxRequests,
 	}
 	rm.repos.EventStore.SaveEvent(ctx, event)
@@ -203,6 +225,9 @@ func (rm *RateLimitManager) ResetAllCNPJUsage(ctx context.Context) error {
 	resetCount := 0
 	for _, limiter := range rm.limiters {
 		if limiter.Type == domain.RateLimitCNPJ {
+			if err := rm.store.Reset(ctx, limiter.Rule()); err != nil {
+				return fmt.Errorf("failed to reset rate limit: %w", err)
+			}
 			limiter.Reset()
 			rm.repos.RateLimiter.Update(limiter)
 			resetCount++
@@ -215,7 +240,8 @@ func (rm *RateLimitManager) ResetAllCNPJUsage(ctx context.Context) error {
 	return nil
 }
 
-// UpdateCNPJLimit atualiza o limite de um CNPJ
+// UpdateCNPJLimit atualiza o limite de um CNPJ. O uso no store é guardado em tempo (TAT), então
+// a fração já usada da janela se mantém com o novo limite.
 func (rm *RateLimitManager) UpdateCNPJLimit(cnpj string, newLimit int) error {
 	limiter := rm.GetCNPJLimiter(cnpj)
 	
@@ -248,7 +274,7 @@ func (rm *RateLimitManager) GetLimiterStats(limitType domain.RateLimitType, key
 		return nil, nil
 	}
 
-	return limiter.GetStats(), nil
+	return rm.limiterStats(context.Background(), limiter), nil
 }
 
 // GetAllCNPJStats obtém estatísticas de todos os CNPJs
@@ -258,7 +284,7 @@ func (rm *RateLimitManager) GetAllCNPJStats() ([]map[string]interface{}, error)
 	rm.mu.RLock()
 	for _, limiter := range rm.limiters {
 		if limiter.Type == domain.RateLimitCNPJ {
-			stats = append(stats, limiter.GetStats())
+			stats = append(stats, rm.limiterStats(context.Background(), limiter))
 		}
 	}
 	rm.mu.RUnlock()
@@ -301,8 +327,8 @@ func (rm *RateLimitManager) GetQuotaAvailability(cnpjs []string) map[string]int
 	availability := make(map[string]int)
 
 	for _, cnpj := range cnpjs {
-		limiter := rm.GetCNPJLimiter(cnpj)
-		availability[cnpj] = limiter.GetRemainingQuota()
+		status := rm.GetCNPJStatus(cnpj)
+		availability[cnpj] = status.RequestsLimit - status.RequestsUsed
 	}
 
 	return availability
@@ -315,7 +341,7 @@ func (rm *RateLimitManager) GetTopUsedCNPJs(limit int) []map[string]interface{}
 	rm.mu.RLock()
 	for _, limiter := range rm.limiters {
 		if limiter.Type == domain.RateLimitCNPJ {
-			stats := limiter.GetStats()
+			stats := rm.limiterStats(context.Background(), limiter)
 			cnpjStats = append(cnpjStats, stats)
 		}
 	}
@@ -369,25 +395,52 @@ func (rm *RateLimitManager) cleanupExpiredLimiters() {
 	rm.repos.RateLimiter.CleanupExpired()
 }
 
-// revertCNPJUsage reverte o uso de quota do CNPJ (método auxiliar)
-func (rm *RateLimitManager) revertCNPJUsage(limiter *domain.RateLimiter) {
-	// Implementação simplificada: seria necessário um método específico no domain
-	// Por ora, apenas resetamos o último request
-	if len(limiter.Requests) > 0 {
-		limiter.Requests = limiter.Requests[:len(limiter.Requests)-1]
+// reserve reserva uma requisição em todos os limiters de uma vez. Um limiter desativado nega
+// a reserva sem consumir quota dos demais.
+func (rm *RateLimitManager) reserve(ctx context.Context, limiters ...*domain.RateLimiter) (*domain.RateLimitReservation, error) {
+	rules := make([]domain.RateLimitRule, len(limiters))
+	for i, limiter := range limiters {
+		if !limiter.IsActive {
+			return domain.NewRateLimitReservation(
+				[]domain.RateLimitRule{limiter.Rule()},
+				[]domain.RateLimitStatus{limiter.Allow()},
+			), nil
+		}
+		rules[i] = limiter.Rule()
 	}
+
+	reservation, err := rm.store.Reserve(ctx, rules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve rate limit: %w", err)
+	}
+
+	return reservation, nil
 }
 
-// revertTenantUsage reverte o uso de quota do tenant (método auxiliar)
-func (rm *RateLimitManager) revertTenantUsage(limiter *domain.RateLimiter) {
-	if len(limiter.Requests) > 0 {
-		limiter.Requests = limiter.Requests[:len(limiter.Requests)-1]
+// status obtém o uso de quota do limiter no store. Se o store estiver indisponível, usa o
+// estado local do limiter.
+func (rm *RateLimitManager) status(ctx context.Context, limiter *domain.RateLimiter) domain.RateLimitStatus {
+	status, err := rm.store.Status(ctx, limiter.Rule())
+	if err != nil {
+		return limiter.GetStatus()
 	}
+	if !limiter.IsActive {
+		status.Allowed = false
+	}
+	return status
 }
 
-// saveLimiterUpdates salva as atualizações dos limiters
-func (rm *RateLimitManager) saveLimiterUpdates(limiters ...*domain.RateLimiter) {
-	for _, limiter := range limiters {
-		rm.repos.RateLimiter.Update(limiter)
+// limiterStats estatísticas do limiter com o uso de quota do store
+func (rm *RateLimitManager) limiterStats(ctx context.Context, limiter *domain.RateLimiter) map[string]interface{} {
+	stats := limiter.GetStats()
+	status := rm.status(ctx, limiter)
+
+	stats["requests_used"] = status.RequestsUsed
+	stats["requests_limit"] = status.RequestsLimit
+	stats["remaining_quota"] = status.RequestsLimit - status.RequestsUsed
+	stats["reset_time"] = status.ResetTime
+	if status.RequestsLimit > 0 {
+		stats["usage_percentage"] = float64(status.RequestsUsed) / float64(status.RequestsLimit) * 100
 	}
-}
\ No newline at end of file
+	return stats
+}
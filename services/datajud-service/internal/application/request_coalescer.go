@@ -0,0 +1,135 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/direito-lux/datajud-service/internal/domain"
+)
+
+// CoalescedCallFunc executa a consulta da requisição líder. fromCache indica resposta obtida
+// sem consumir quota do DataJud.
+type CoalescedCallFunc func(ctx context.Context) (response *domain.DataJudResponse, fromCache bool, err error)
+
+// CoalescedCompleteFunc recebe a requisição líder, todas as requisições atendidas (a líder
+// primeiro) e o resultado, ao fim de uma consulta compartilhada. A resposta é compartilhada com
+// as requisições em espera: deve ser copiada com CopyFor antes de ser alterada.
+type CoalescedCompleteFunc func(ctx context.Context, leader *domain.DataJudRequest, requests []*domain.DataJudRequest, response *domain.DataJudResponse, fromCache bool, err error)
+
+// RequestCoalescer agrupa requisições equivalentes em andamento (mesmo tipo, processo e
+// tribunal, de qualquer tenant) em uma única consulta, entregando o resultado a todas
+type RequestCoalescer struct {
+	onComplete CoalescedCompleteFunc
+	calls      map[string]*coalescedCall
+	stats      *CoalescerStatistics
+	mu         sync.Mutex
+}
+
+// CoalescerStatistics estatísticas de coalescência
+type CoalescerStatistics struct {
+	TotalCalls     int64 `json:"total_calls"`
+	TotalCoalesced int64 `json:"total_coalesced"`
+	mu             sync.RWMutex
+}
+
+// coalescedCall consulta em andamento e as requisições que aguardam o resultado
+type coalescedCall struct {
+	done      chan struct{}
+	requests  []*domain.DataJudRequest
+	response  *domain.DataJudResponse
+	fromCache bool
+	err       error
+}
+
+// NewRequestCoalescer cria novo agrupador de requisições
+func NewRequestCoalescer(onComplete CoalescedCompleteFunc) *RequestCoalescer {
+	return &RequestCoalescer{
+		onComplete: onComplete,
+		calls:      make(map[string]*coalescedCall),
+		stats:      &CoalescerStatistics{},
+	}
+}
+
+// Do executa call para a requisição ou aguarda a consulta equivalente já em andamento. A
+// consulta não é interrompida quando quem a iniciou desiste: as demais requisições continuam
+// aguardando. shared indica resultado de consulta iniciada por outra requisição.
+func (rc *RequestCoalescer) Do(ctx context.Context, req *domain.DataJudRequest, call CoalescedCallFunc) (response *domain.DataJudResponse, fromCache bool, shared bool, err error) {
+	key := req.CoalescingKey()
+
+	rc.mu.Lock()
+	current, shared := rc.calls[key]
+	if shared {
+		current.requests = append(current.requests, req)
+	} else {
+		current = &coalescedCall{
+			done:     make(chan struct{}),
+			requests: []*domain.DataJudRequest{req},
+		}
+		rc.calls[key] = current
+		go rc.run(context.WithoutCancel(ctx), key, current, call)
+	}
+	rc.mu.Unlock()
+
+	rc.stats.mu.Lock()
+	if shared {
+		rc.stats.TotalCoalesced++
+	} else {
+		rc.stats.TotalCalls++
+	}
+	rc.stats.mu.Unlock()
+
+	select {
+	case <-current.done:
+	case <-ctx.Done():
+		return nil, false, shared, ctx.Err()
+	}
+
+	if current.err != nil {
+		return nil, false, shared, current.err
+	}
+
+	// Cada requisição recebe sua cópia da resposta
+	result := current.response.CopyFor(req)
+	result.FromCache = current.fromCache
+	return result, current.fromCache, shared, nil
+}
+
+// run executa a consulta e notifica o resultado com todas as requisições atendidas
+func (rc *RequestCoalescer) run(ctx context.Context, key string, current *coalescedCall, call CoalescedCallFunc) {
+	response, fromCache, err := call(ctx)
+	if err == nil && response == nil {
+		err = fmt.Errorf("resposta vazia do DataJud para %s", key)
+	}
+
+	rc.mu.Lock()
+	delete(rc.calls, key)
+	requests := current.requests
+	rc.mu.Unlock()
+
+	current.response, current.fromCache, current.err = response, fromCache, err
+	close(current.done)
+
+	if rc.onComplete != nil {
+		rc.onComplete(ctx, requests[0], requests, response, fromCache, err)
+	}
+}
+
+// InFlight retorna a quantidade de consultas em andamento
+func (rc *RequestCoalescer) InFlight() int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return len(rc.calls)
+}
+
+// GetStats retorna estatísticas de coalescência
+func (rc *RequestCoalescer) GetStats() map[string]interface{} {
+	rc.stats.mu.RLock()
+	defer rc.stats.mu.RUnlock()
+
+	return map[string]interface{}{
+		"total_calls":     rc.stats.TotalCalls,
+		"total_coalesced": rc.stats.TotalCoalesced,
+		"in_flight":       rc.InFlight(),
+	}
+}
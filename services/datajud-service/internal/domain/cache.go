@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"context"
 	"encoding/json"
 	"time"
 
@@ -66,6 +67,28 @@ type Cache interface {
 	GetStats() (*CacheStats, error)
 }
 
+// CacheLoader consulta o valor de um cache miss e retorna a entrada a gravar (nil: nada a gravar)
+type CacheLoader func(ctx context.Context) (*CacheEntry, error)
+
+// DistributedCache cache compartilhado entre as réplicas (Redis), com índices secundários por
+// tenant, número do processo e tipo de requisição, estatísticas agregadas e proteção contra
+// consultas simultâneas da mesma chave
+type DistributedCache interface {
+	Cache
+
+	// SetEntry grava a entrada com os metadados usados nos índices
+	SetEntry(entry *CacheEntry) error
+
+	// GetOrLoad retorna a entrada do cache ou executa load uma única vez por chave entre as
+	// réplicas; as demais chamadas aguardam a entrada gravada. cached indica entrada já gravada.
+	GetOrLoad(ctx context.Context, key string, load CacheLoader) (entry *CacheEntry, cached bool, err error)
+
+	FindByTenantID(tenantID uuid.UUID) ([]*CacheEntry, error)
+	FindByProcessNumber(processNumber string) ([]*CacheEntry, error)
+	FindByRequestType(requestType RequestType) ([]*CacheEntry, error)
+	CleanupExpired() (int, error)
+}
+
 // NewCacheEntry cria uma nova entrada de cache
 func NewCacheEntry(key string, value interface{}, ttl int, tenantID uuid.UUID, requestType RequestType) (*CacheEntry, error) {
 	now := time.Now()
@@ -153,6 +176,20 @@ func (c *CacheEntry) SetCourtID(courtID string) {
 	c.UpdatedAt = time.Now()
 }
 
+// SetMetadataFromValue define o número do processo a partir da resposta armazenada
+func (c *CacheEntry) SetMetadataFromValue() {
+	switch response := c.Value.(type) {
+	case *DataJudResponse:
+		if response.ProcessData != nil && response.ProcessData.Number != "" {
+			c.SetProcessNumber(response.ProcessData.Number)
+		}
+	case *ProcessResponseData:
+		if response.Number != "" {
+			c.SetProcessNumber(response.Number)
+		}
+	}
+}
+
 // GetMetadata retorna metadados da entrada
 func (c *CacheEntry) GetMetadata() map[string]interface{} {
 	return map[string]interface{}{
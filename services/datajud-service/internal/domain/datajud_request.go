@@ -83,6 +83,9 @@ type DataJudRequest struct {
 	Response        *DataJudResponse `json:"response,omitempty"`
 	ErrorMessage    string           `json:"error_message,omitempty"`
 	ErrorCode       string           `json:"error_code,omitempty"`
+	
+	// Requisições equivalentes incorporadas, concluídas junto com esta
+	Coalesced       []*DataJudRequest `json:"-"`
 }
 
 // DataJudResponse representa a resposta da API DataJud
@@ -263,6 +266,11 @@ func (r *DataJudRequest) Retry(retryAfter time.Duration) error {
 	return nil
 }
 
+// Requests retorna esta requisição seguida das requisições incorporadas
+func (r *DataJudRequest) Requests() []*DataJudRequest {
+	return append([]*DataJudRequest{r}, r.Coalesced...)
+}
+
 // CanRetry verifica se a requisição pode ser reexecutada
 func (r *DataJudRequest) CanRetry() bool {
 	if r.RetryCount >= r.MaxRetries {
@@ -0,0 +1,235 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RateLimitRule limite avaliado em uma reserva: tipo, chave e janela.
+//
+// Os limites usam GCRA (Generic Cell Rate Algorithm): cada chave guarda apenas o instante
+// teórico de chegada (TAT) e cada requisição o adianta em janela/limite. Até MaxRequests
+// requisições cabem de uma vez e a quota volta continuamente ao longo da janela, como em uma
+// janela deslizante, com memória constante por chave.
+type RateLimitRule struct {
+	Type   RateLimitType   `json:"type"`
+	Key    string          `json:"key"`
+	Window RateLimitWindow `json:"window"`
+}
+
+// RateLimitReservation uso de quota reservado de uma vez em um conjunto de limites: ou todos
+// os limites consumiram a requisição, ou nenhum
+type RateLimitReservation struct {
+	ID         uuid.UUID         `json:"id"`
+	Rules      []RateLimitRule   `json:"rules"`
+	Statuses   []RateLimitStatus `json:"statuses"`
+	Status     RateLimitStatus   `json:"status"`              // Status do limite mais restritivo
+	DeniedBy   *RateLimitRule    `json:"denied_by,omitempty"` // Limite que negou a reserva
+	ReservedAt time.Time         `json:"reserved_at"`
+	Refunded   bool              `json:"refunded"`
+}
+
+// RateLimitStore armazenamento das janelas de rate limiting, compartilhado entre as réplicas
+type RateLimitStore interface {
+	// Reserve consome uma requisição em todos os limites ou, se algum estiver esgotado, em nenhum
+	Reserve(ctx context.Context, rules []RateLimitRule) (*RateLimitReservation, error)
+	// Refund devolve exatamente a quota consumida por uma reserva concedida
+	Refund(ctx context.Context, reservation *RateLimitReservation) error
+	Status(ctx context.Context, rule RateLimitRule) (RateLimitStatus, error)
+	Reset(ctx context.Context, rule RateLimitRule) error
+}
+
+// StoreKey retorna a chave do limite no armazenamento
+func (r RateLimitRule) StoreKey() string {
+	return fmt.Sprintf("%s:%s", r.Type, r.Key)
+}
+
+// EmissionInterval retorna quanto cada requisição adianta o TAT: a janela dividida pelo limite
+func (r RateLimitRule) EmissionInterval() time.Duration {
+	if r.Window.MaxRequests <= 0 {
+		return r.Window.WindowSize
+	}
+
+	interval := r.Window.WindowSize / time.Duration(r.Window.MaxRequests)
+	if interval <= 0 {
+		return time.Nanosecond
+	}
+	return interval
+}
+
+// Admit aplica o GCRA ao TAT atual: retorna o novo TAT e se a requisição cabe na janela. Quando
+// negada, o TAT retornado é o atual.
+func (r RateLimitRule) Admit(tat, now time.Time) (time.Time, bool) {
+	if tat.Before(now) {
+		tat = now
+	}
+	if r.Window.MaxRequests <= 0 {
+		return tat, false
+	}
+
+	next := tat.Add(r.EmissionInterval())
+	if next.Sub(now) > r.Window.WindowSize {
+		return tat, false
+	}
+	return next, true
+}
+
+// Release devolve ao TAT exatamente uma requisição admitida. Quota que já voltou com o tempo
+// não é devolvida de novo.
+func (r RateLimitRule) Release(tat, now time.Time) time.Time {
+	tat = tat.Add(-r.EmissionInterval())
+	if tat.Before(now) {
+		return time.Time{}
+	}
+	return tat
+}
+
+// StatusAt calcula o status do limite a partir do TAT
+func (r RateLimitRule) StatusAt(tat, now time.Time, allowed bool) RateLimitStatus {
+	if tat.Before(now) {
+		tat = now
+	}
+
+	interval := r.EmissionInterval()
+	backlog := tat.Sub(now)
+	used := int((backlog + interval - 1) / interval)
+	if used > r.Window.MaxRequests {
+		used = r.Window.MaxRequests
+	}
+
+	status := RateLimitStatus{
+		Allowed:       allowed,
+		RequestsUsed:  used,
+		RequestsLimit: r.Window.MaxRequests,
+		ResetTime:     tat,
+		WindowSize:    r.Window.WindowSize,
+	}
+	if !allowed {
+		if retryAfter := backlog + interval - r.Window.WindowSize; retryAfter > 0 {
+			status.RetryAfter = retryAfter
+		}
+	}
+	return status
+}
+
+// NewRateLimitReservation cria reserva com o status de cada limite. A reserva só é concedida
+// quando todos permitem; o status resumido é o do limite que negou com maior espera ou, se
+// concedida, o do limite com menos quota restante.
+func NewRateLimitReservation(rules []RateLimitRule, statuses []RateLimitStatus) *RateLimitReservation {
+	reservation := &RateLimitReservation{
+		ID:         uuid.New(),
+		Rules:      rules,
+		Statuses:   statuses,
+		Status:     RateLimitStatus{Allowed: true},
+		ReservedAt: time.Now(),
+	}
+
+	for i, status := range statuses {
+		if !status.Allowed {
+			if reservation.DeniedBy == nil || status.RetryAfter > reservation.Status.RetryAfter {
+				reservation.DeniedBy = &reservation.Rules[i]
+				reservation.Status = status
+			}
+			continue
+		}
+		if reservation.DeniedBy == nil && (i == 0 || remaining(status) < remaining(reservation.Status)) {
+			reservation.Status = status
+		}
+	}
+
+	return reservation
+}
+
+// Granted indica se a reserva consumiu quota e ainda não foi devolvida
+func (r *RateLimitReservation) Granted() bool {
+	return r != nil && r.Status.Allowed && !r.Refunded
+}
+
+// remaining retorna a quota restante do status
+func remaining(status RateLimitStatus) int {
+	return status.RequestsLimit - status.RequestsUsed
+}
+
+// MemoryRateLimitStore armazenamento local de rate limiting, para uma única réplica
+type MemoryRateLimitStore struct {
+	tats map[string]time.Time
+	mu   sync.Mutex
+}
+
+// NewMemoryRateLimitStore cria armazenamento local de rate limiting
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		tats: make(map[string]time.Time),
+	}
+}
+
+// Reserve consome uma requisição em todos os limites ou em nenhum
+func (s *MemoryRateLimitStore) Reserve(ctx context.Context, rules []RateLimitRule) (*RateLimitReservation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	tats := make([]time.Time, len(rules))
+	statuses := make([]RateLimitStatus, len(rules))
+	for i, rule := range rules {
+		tat, allowed := rule.Admit(s.tats[rule.StoreKey()], now)
+		tats[i] = tat
+		statuses[i] = rule.StatusAt(tat, now, allowed)
+	}
+
+	reservation := NewRateLimitReservation(rules, statuses)
+	if reservation.Status.Allowed {
+		for i, rule := range rules {
+			s.tats[rule.StoreKey()] = tats[i]
+		}
+	}
+
+	return reservation, nil
+}
+
+// Refund devolve exatamente a quota consumida pela reserva
+func (s *MemoryRateLimitStore) Refund(ctx context.Context, reservation *RateLimitReservation) error {
+	if !reservation.Granted() {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for _, rule := range reservation.Rules {
+		key := rule.StoreKey()
+		if tat := rule.Release(s.tats[key], now); tat.IsZero() {
+			delete(s.tats, key)
+		} else {
+			s.tats[key] = tat
+		}
+	}
+	reservation.Refunded = true
+
+	return nil
+}
+
+// Status retorna o status do limite sem consumir quota
+func (s *MemoryRateLimitStore) Status(ctx context.Context, rule RateLimitRule) (RateLimitStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	tat := s.tats[rule.StoreKey()]
+	_, allowed := rule.Admit(tat, now)
+	return rule.StatusAt(tat, now, allowed), nil
+}
+
+// Reset devolve toda a quota do limite
+func (s *MemoryRateLimitStore) Reset(ctx context.Context, rule RateLimitRule) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.tats, rule.StoreKey())
+	return nil
+}
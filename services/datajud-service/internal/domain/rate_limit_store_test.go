@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRule cria limite de teste
+func newTestRule(limitType RateLimitType, key string, maxRequests int) RateLimitRule {
+	return RateLimitRule{
+		Type:   limitType,
+		Key:    key,
+		Window: RateLimitWindow{WindowSize: time.Minute, MaxRequests: maxRequests},
+	}
+}
+
+// TestRateLimitRuleAdmit testa a janela do GCRA: rajada até o limite e quota de volta com o tempo
+func TestRateLimitRuleAdmit(t *testing.T) {
+	rule := newTestRule(RateLimitCNPJ, "12345678000190", 3)
+	now := time.Now()
+
+	var tat time.Time
+	for i := 0; i < 3; i++ {
+		var allowed bool
+		tat, allowed = rule.Admit(tat, now)
+		require.True(t, allowed, "requisição %d deve caber na janela", i+1)
+	}
+
+	_, allowed := rule.Admit(tat, now)
+	assert.False(t, allowed)
+
+	status := rule.StatusAt(tat, now, false)
+	assert.Equal(t, 3, status.RequestsUsed)
+	assert.Equal(t, 20*time.Second, status.RetryAfter)
+
+	// Depois de um intervalo de emissão, uma requisição volta
+	later := now.Add(rule.EmissionInterval())
+	_, allowed = rule.Admit(tat, later)
+	assert.True(t, allowed)
+	assert.Equal(t, 2, rule.StatusAt(tat, later, true).RequestsUsed)
+
+	// A devolução desfaz exatamente uma requisição
+	tat = rule.Release(tat, now)
+	assert.Equal(t, 2, rule.StatusAt(tat, now, true).RequestsUsed)
+
+	_, allowed = newTestRule(RateLimitCNPJ, "12345678000190", 0).Admit(time.Time{}, now)
+	assert.False(t, allowed)
+}
+
+// TestMemoryRateLimitStoreReserve testa a reserva atômica entre limites e a devolução exata
+func TestMemoryRateLimitStoreReserve(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryRateLimitStore()
+	cnpj := newTestRule(RateLimitCNPJ, "12345678000190", 10)
+	tenant := newTestRule(RateLimitTenant, uuid.New().String(), 1)
+
+	first, err := store.Reserve(ctx, []RateLimitRule{cnpj, tenant})
+	require.NoError(t, err)
+	assert.True(t, first.Granted())
+	assert.Nil(t, first.DeniedBy)
+	assert.Equal(t, 1, first.Status.RequestsUsed)
+	assert.Equal(t, 1, first.Status.RequestsLimit, "status resumido é o do limite mais restritivo")
+
+	// Tenant esgotado: nenhum limite é consumido
+	denied, err := store.Reserve(ctx, []RateLimitRule{cnpj, tenant})
+	require.NoError(t, err)
+	assert.False(t, denied.Granted())
+	require.NotNil(t, denied.DeniedBy)
+	assert.Equal(t, RateLimitTenant, denied.DeniedBy.Type)
+
+	status, err := store.Status(ctx, cnpj)
+	require.NoError(t, err)
+	assert.Equal(t, 1, status.RequestsUsed)
+
+	// Devolução exata, apenas uma vez
+	require.NoError(t, store.Refund(ctx, first))
+	require.NoError(t, store.Refund(ctx, first))
+	assert.True(t, first.Refunded)
+
+	status, err = store.Status(ctx, cnpj)
+	require.NoError(t, err)
+	assert.Equal(t, 0, status.RequestsUsed)
+
+	again, err := store.Reserve(ctx, []RateLimitRule{cnpj, tenant})
+	require.NoError(t, err)
+	assert.True(t, again.Granted())
+}
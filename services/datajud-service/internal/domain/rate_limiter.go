@@ -29,7 +29,7 @@ type RateLimiter struct {
 	Type        RateLimitType   `json:"type"`
 	Key         string          `json:"key"`          // Chave identificadora (CNPJ, tenant_id, etc)
 	Window      RateLimitWindow `json:"window"`
-	Requests    []time.Time     `json:"requests"`     // Timestamps das requisições
+	TAT         time.Time       `json:"tat"`          // Instante teórico de chegada (GCRA)
 	IsActive    bool            `json:"is_active"`
 	CreatedAt   time.Time       `json:"created_at"`
 	UpdatedAt   time.Time       `json:"updated_at"`
@@ -65,7 +65,6 @@ func NewRateLimiter(limitType RateLimitType, key string, window RateLimitWindow)
 		Type:      limitType,
 		Key:       key,
 		Window:    window,
-		Requests:  make([]time.Time, 0),
 		IsActive:  true,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
@@ -108,6 +107,15 @@ func NewGlobalRateLimiter(maxRequests int, windowSize time.Duration) *RateLimite
 	)
 }
 
+// Rule retorna o limite avaliado por este rate limiter
+func (rl *RateLimiter) Rule() RateLimitRule {
+	return RateLimitRule{
+		Type:   rl.Type,
+		Key:    rl.Key,
+		Window: rl.Window,
+	}
+}
+
 // Allow verifica se uma requisição pode ser feita
 func (rl *RateLimiter) Allow() RateLimitStatus {
 	rl.mu.Lock()
@@ -125,46 +133,24 @@ func (rl *RateLimiter) Allow() RateLimitStatus {
 	}
 
 	now := time.Now()
-	windowStart := now.Add(-rl.Window.WindowSize)
-
-	// Remove requisições antigas (fora da janela)
-	rl.cleanupOldRequests(windowStart)
-
-	requestsInWindow := len(rl.Requests)
-	allowed := requestsInWindow < rl.Window.MaxRequests
-
+	rule := rl.Rule()
+	tat, allowed := rule.Admit(rl.TAT, now)
 	if allowed {
-		// Adiciona a requisição atual
-		rl.Requests = append(rl.Requests, now)
+		rl.TAT = tat
 		rl.LastUsedAt = &now
 		rl.UpdatedAt = now
-		requestsInWindow++
 	}
 
-	// Calcula quando o limite será resetado
-	var resetTime time.Time
-	var retryAfter time.Duration
+	return rule.StatusAt(tat, now, allowed)
+}
 
-	if len(rl.Requests) > 0 {
-		oldestRequest := rl.Requests[0]
-		resetTime = oldestRequest.Add(rl.Window.WindowSize)
-		if resetTime.Before(now) {
-			resetTime = now.Add(rl.Window.WindowSize)
-		}
-		retryAfter = resetTime.Sub(now)
-	} else {
-		resetTime = now.Add(rl.Window.WindowSize)
-		retryAfter = 0
-	}
+// Refund devolve exatamente uma requisição concedida por Allow
+func (rl *RateLimiter) Refund() {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
 
-	return RateLimitStatus{
-		Allowed:       allowed,
-		RequestsUsed:  requestsInWindow,
-		RequestsLimit: rl.Window.MaxRequests,
-		ResetTime:     resetTime,
-		RetryAfter:    retryAfter,
-		WindowSize:    rl.Window.WindowSize,
-	}
+	rl.TAT = rl.Rule().Release(rl.TAT, time.Now())
+	rl.UpdatedAt = time.Now()
 }
 
 // GetStatus retorna o status atual sem consumir quota
@@ -173,47 +159,10 @@ func (rl *RateLimiter) GetStatus() RateLimitStatus {
 	defer rl.mu.RUnlock()
 
 	now := time.Now()
-	windowStart := now.Add(-rl.Window.WindowSize)
-
-	// Conta requisições na janela atual (sem remover)
-	requestsInWindow := 0
-	for _, reqTime := range rl.Requests {
-		if reqTime.After(windowStart) {
-			requestsInWindow++
-		}
-	}
-
-	allowed := requestsInWindow < rl.Window.MaxRequests && rl.IsActive
+	rule := rl.Rule()
+	_, allowed := rule.Admit(rl.TAT, now)
 
-	// Calcula resetTime
-	var resetTime time.Time
-	var retryAfter time.Duration
-
-	if len(rl.Requests) > 0 {
-		// Encontra a requisição mais antiga na janela
-		for _, reqTime := range rl.Requests {
-			if reqTime.After(windowStart) {
-				resetTime = reqTime.Add(rl.Window.WindowSize)
-				break
-			}
-		}
-		if resetTime.IsZero() || resetTime.Before(now) {
-			resetTime = now.Add(rl.Window.WindowSize)
-		}
-		retryAfter = resetTime.Sub(now)
-	} else {
-		resetTime = now.Add(rl.Window.WindowSize)
-		retryAfter = 0
-	}
-
-	return RateLimitStatus{
-		Allowed:       allowed,
-		RequestsUsed:  requestsInWindow,
-		RequestsLimit: rl.Window.MaxRequests,
-		ResetTime:     resetTime,
-		RetryAfter:    retryAfter,
-		WindowSize:    rl.Window.WindowSize,
-	}
+	return rule.StatusAt(rl.TAT, now, allowed && rl.IsActive)
 }
 
 // Reset limpa todas as requisições
@@ -221,21 +170,21 @@ func (rl *RateLimiter) Reset() {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
-	rl.Requests = make([]time.Time, 0)
+	rl.TAT = time.Time{}
 	rl.UpdatedAt = time.Now()
 }
 
-// SetWindow atualiza a janela de rate limiting
+// SetWindow atualiza a janela de rate limiting, preservando as requisições já usadas
 func (rl *RateLimiter) SetWindow(window RateLimitWindow) {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
-	rl.Window = window
-	rl.UpdatedAt = time.Now()
+	now := time.Now()
+	used := rl.Rule().StatusAt(rl.TAT, now, true).RequestsUsed
 
-	// Limpa requisições antigas com a nova janela
-	windowStart := time.Now().Add(-window.WindowSize)
-	rl.cleanupOldRequests(windowStart)
+	rl.Window = window
+	rl.TAT = now.Add(time.Duration(used) * rl.Rule().EmissionInterval())
+	rl.UpdatedAt = now
 }
 
 // Activate ativa o rate limiter
@@ -285,19 +234,6 @@ func (rl *RateLimiter) IsQuotaExhausted() bool {
 	return !rl.GetStatus().Allowed
 }
 
-// cleanupOldRequests remove requisições antigas (deve ser chamado com lock)
-func (rl *RateLimiter) cleanupOldRequests(windowStart time.Time) {
-	validRequests := make([]time.Time, 0, len(rl.Requests))
-	
-	for _, reqTime := range rl.Requests {
-		if reqTime.After(windowStart) {
-			validRequests = append(validRequests, reqTime)
-		}
-	}
-	
-	rl.Requests = validRequests
-}
-
 // GetKey retorna a chave do rate limiter
 func (rl *RateLimiter) GetKey() string {
 	return fmt.Sprintf("%s:%s", rl.Type, rl.Key)
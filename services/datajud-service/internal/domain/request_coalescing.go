@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// coalescingBaseParameters parâmetros que já fazem parte da chave de coalescência
+var coalescingBaseParameters = map[string]bool{
+	"process_number": true,
+	"court_id":       true,
+}
+
+// TenantCostShare parcela do custo de uma consulta ao DataJud atribuída ao tenant
+type TenantCostShare struct {
+	TenantID uuid.UUID `json:"tenant_id"`
+	Requests int       `json:"requests"`
+	Share    float64   `json:"share"` // Fração da quota consumida
+}
+
+// DataJudRequestCoalesced evento de consulta única ao DataJud atendendo várias requisições
+type DataJudRequestCoalesced struct {
+	BaseEvent
+	CoalescingKey  string            `json:"coalescing_key"`
+	CNPJProviderID *uuid.UUID        `json:"cnpj_provider_id,omitempty"`
+	RequestIDs     []uuid.UUID       `json:"request_ids"`
+	QuotaUsed      int               `json:"quota_used"`
+	CostShares     []TenantCostShare `json:"cost_shares"`
+}
+
+// NormalizeProcessNumber mantém apenas os dígitos do número CNJ, para que números com e sem
+// máscara sejam tratados como o mesmo processo
+func NormalizeProcessNumber(processNumber string) string {
+	var b strings.Builder
+	for _, r := range processNumber {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// CoalescingKey chave que identifica requisições equivalentes de qualquer tenant: tipo, número
+// do processo normalizado, tribunal e uso do cache. Parâmetros adicionais (página, período)
+// entram como hash. Requisições sem cache não são atendidas por requisições com cache.
+func (r *DataJudRequest) CoalescingKey() string {
+	key := fmt.Sprintf("%s:%s:%s", r.Type, NormalizeProcessNumber(r.ProcessNumber), strings.ToUpper(strings.TrimSpace(r.CourtID)))
+
+	extra := make(map[string]interface{})
+	for name, value := range r.Parameters {
+		if !coalescingBaseParameters[name] {
+			extra[name] = value
+		}
+	}
+	if len(extra) > 0 {
+		jsonData, _ := json.Marshal(extra)
+		key = fmt.Sprintf("%s:%x", key, md5.Sum(jsonData))
+	}
+
+	if !r.UseCache {
+		key += ":nocache"
+	}
+	return key
+}
+
+// CopyFor retorna uma cópia da resposta compartilhada para a requisição, que pode receber campos
+// próprios (requisição, origem no cache) sem afetar as demais requisições atendidas
+func (resp *DataJudResponse) CopyFor(request *DataJudRequest) *DataJudResponse {
+	copied := *resp
+	copied.RequestID = request.ID
+	copied.Headers = make(map[string]string, len(resp.Headers))
+	for name, value := range resp.Headers {
+		copied.Headers[name] = value
+	}
+	return &copied
+}
+
+// Merge incorpora a requisição equivalente (e as que ela já havia incorporado), que passa a ser
+// concluída junto com esta. A requisição resultante assume a maior prioridade entre as duas.
+func (r *DataJudRequest) Merge(other *DataJudRequest) {
+	r.Coalesced = append(r.Coalesced, other)
+	r.Coalesced = append(r.Coalesced, other.Coalesced...)
+	other.Coalesced = nil
+
+	if other.Priority > r.Priority {
+		r.Priority = other.Priority
+	}
+	r.UpdatedAt = time.Now()
+}
+
+// AttributeQuotaCost divide a quota consumida entre os tenants das requisições atendidas,
+// proporcionalmente à quantidade de requisições de cada um
+func AttributeQuotaCost(requests []*DataJudRequest, quota int) []TenantCostShare {
+	if len(requests) == 0 {
+		return []TenantCostShare{}
+	}
+
+	shares := make([]TenantCostShare, 0, len(requests))
+	positions := make(map[uuid.UUID]int)
+	for _, request := range requests {
+		position, ok := positions[request.TenantID]
+		if !ok {
+			position = len(shares)
+			positions[request.TenantID] = position
+			shares = append(shares, TenantCostShare{TenantID: request.TenantID})
+		}
+		shares[position].Requests++
+	}
+
+	for i := range shares {
+		shares[i].Share = float64(quota) * float64(shares[i].Requests) / float64(len(requests))
+	}
+
+	return shares
+}
+
+// NewDataJudRequestCoalesced cria evento da consulta executada pela requisição líder em nome
+// das demais requisições equivalentes
+func NewDataJudRequestCoalesced(leader *DataJudRequest, requests []*DataJudRequest, quotaUsed int) *DataJudRequestCoalesced {
+	requestIDs := make([]uuid.UUID, len(requests))
+	for i, request := range requests {
+		requestIDs[i] = request.ID
+	}
+
+	return &DataJudRequestCoalesced{
+		BaseEvent: BaseEvent{
+			ID:          uuid.New(),
+			Type:        "datajud.request.coalesced",
+			AggregateID: leader.ID,
+			OccurredAt:  time.Now(),
+			Version:     1,
+			Metadata:    make(map[string]interface{}),
+		},
+		CoalescingKey:  leader.CoalescingKey(),
+		CNPJProviderID: leader.CNPJProviderID,
+		RequestIDs:     requestIDs,
+		QuotaUsed:      quotaUsed,
+		CostShares:     AttributeQuotaCost(requests, quotaUsed),
+	}
+}
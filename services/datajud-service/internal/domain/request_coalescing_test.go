@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// newProcessRequest cria requisição de processo para o tenant
+func newProcessRequest(tenantID uuid.UUID, processNumber, courtID string, priority RequestPriority) *DataJudRequest {
+	request := NewDataJudRequest(tenantID, uuid.New(), RequestTypeProcess, priority)
+	request.SetProcessNumber(processNumber)
+	request.SetCourtID(courtID)
+	return request
+}
+
+// TestCoalescingKey testa a equivalência de requisições entre tenants
+func TestCoalescingKey(t *testing.T) {
+	masked := newProcessRequest(uuid.New(), "0001234-56.2023.8.26.0100", "tjsp", PriorityNormal)
+	digits := newProcessRequest(uuid.New(), "00012345620238260100", "TJSP", PriorityHigh)
+	otherCourt := newProcessRequest(uuid.New(), "00012345620238260100", "TJRJ", PriorityNormal)
+
+	assert.Equal(t, "process:00012345620238260100:TJSP", masked.CoalescingKey())
+	assert.Equal(t, masked.CoalescingKey(), digits.CoalescingKey())
+	assert.NotEqual(t, masked.CoalescingKey(), otherCourt.CoalescingKey())
+
+	// Páginas diferentes de movimentações não são equivalentes
+	page1 := NewDataJudRequest(uuid.New(), uuid.New(), RequestTypeMovement, PriorityNormal)
+	page1.SetProcessNumber("00012345620238260100")
+	page1.SetParameter("page", 1)
+	page2 := NewDataJudRequest(uuid.New(), uuid.New(), RequestTypeMovement, PriorityNormal)
+	page2.SetProcessNumber("00012345620238260100")
+	page2.SetParameter("page", 2)
+	assert.NotEqual(t, page1.CoalescingKey(), page2.CoalescingKey())
+
+	// Requisições sem cache não são atendidas por requisições com cache
+	fresh := newProcessRequest(uuid.New(), "00012345620238260100", "TJSP", PriorityNormal)
+	fresh.UseCache = false
+	assert.Equal(t, "process:00012345620238260100:TJSP:nocache", fresh.CoalescingKey())
+	assert.NotEqual(t, masked.CoalescingKey(), fresh.CoalescingKey())
+}
+
+// TestDataJudResponseCopyFor testa a cópia da resposta compartilhada para cada requisição
+func TestDataJudResponseCopyFor(t *testing.T) {
+	leader := newProcessRequest(uuid.New(), "00012345620238260100", "TJSP", PriorityNormal)
+	other := newProcessRequest(uuid.New(), "00012345620238260100", "TJSP", PriorityNormal)
+	response := &DataJudResponse{
+		ID:        uuid.New(),
+		RequestID: leader.ID,
+		Headers:   map[string]string{"X-Request-ID": "abc"},
+	}
+
+	copied := response.CopyFor(other)
+	copied.FromCache = true
+	copied.Headers["X-Cache"] = "HIT"
+
+	assert.Equal(t, other.ID, copied.RequestID)
+	assert.Equal(t, response.ID, copied.ID)
+	assert.Equal(t, leader.ID, response.RequestID)
+	assert.False(t, response.FromCache)
+	assert.NotContains(t, response.Headers, "X-Cache")
+}
+
+// TestDataJudRequestMerge testa a incorporação de requisições equivalentes
+func TestDataJudRequestMerge(t *testing.T) {
+	leader := newProcessRequest(uuid.New(), "00012345620238260100", "TJSP", PriorityLow)
+	other := newProcessRequest(uuid.New(), "00012345620238260100", "TJSP", PriorityNormal)
+	nested := newProcessRequest(uuid.New(), "00012345620238260100", "TJSP", PriorityUrgent)
+
+	other.Merge(nested)
+	leader.Merge(other)
+
+	assert.Equal(t, []*DataJudRequest{leader, other, nested}, leader.Requests())
+	assert.Empty(t, other.Coalesced)
+	assert.Equal(t, PriorityUrgent, leader.Priority)
+}
+
+// TestAttributeQuotaCost testa a divisão proporcional da quota entre tenants
+func TestAttributeQuotaCost(t *testing.T) {
+	tenantA, tenantB := uuid.New(), uuid.New()
+	requests := []*DataJudRequest{
+		newProcessRequest(tenantA, "00012345620238260100", "TJSP", PriorityNormal),
+		newProcessRequest(tenantB, "00012345620238260100", "TJSP", PriorityNormal),
+		newProcessRequest(tenantA, "00012345620238260100", "TJSP", PriorityNormal),
+		newProcessRequest(tenantA, "00012345620238260100", "TJSP", PriorityNormal),
+	}
+
+	shares := AttributeQuotaCost(requests, 1)
+
+	assert.Equal(t, []TenantCostShare{
+		{TenantID: tenantA, Requests: 3, Share: 0.75},
+		{TenantID: tenantB, Requests: 1, Share: 0.25},
+	}, shares)
+	assert.Empty(t, AttributeQuotaCost(nil, 1))
+}
@@ -0,0 +1,849 @@
+package cache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/datajud-service/internal/domain"
+)
+
+// Formatos do valor gravado no Redis, identificados pelo primeiro byte
+const (
+	formatJSON = 'j'
+	formatGzip = 'z'
+)
+
+// Tipos do valor armazenado, usados para reconstruir o valor na leitura
+const (
+	valueTypeResponse = "datajud_response"
+	valueTypeProcess  = "process_data"
+	valueTypeMovement = "movement_data"
+	valueTypeParty    = "party_data"
+	valueTypeJSON     = "json"
+)
+
+// Campos do hash de estatísticas compartilhado entre as réplicas
+const (
+	statHits      = "hits"
+	statMisses    = "misses"
+	statSets      = "sets"
+	statEvictions = "evictions"
+	statExpired   = "expired"
+)
+
+// Campos de ocupação do hash de estatísticas, recalculados a cada CleanupExpired
+const (
+	statEntries = "entries"
+	statBytes   = "bytes"
+	statOldest  = "oldest" // unix ms da criação da entrada mais antiga
+	statNewest  = "newest" // unix ms da criação da entrada mais recente
+)
+
+// scanBatchSize quantidade de campos lidos por iteração ao percorrer o hash de metadados
+const scanBatchSize = 500
+
+// loadPollInterval intervalo de consulta enquanto outra réplica carrega a mesma chave
+const loadPollInterval = 100 * time.Millisecond
+
+// releaseLockScript remove a trava apenas se ainda pertence a quem a adquiriu
+var releaseLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// RedisCacheConfig configuração do cache distribuído
+type RedisCacheConfig struct {
+	// Prefix prefixo de todas as chaves do cache
+	Prefix string
+	// CompressionThreshold tamanho em bytes a partir do qual o valor é gravado com gzip (0 desativa)
+	CompressionThreshold int
+	// LoadLockTTL validade da trava de carga de uma chave
+	LoadLockTTL time.Duration
+	// LoadWait tempo máximo aguardando a carga de outra réplica antes de consultar diretamente
+	LoadWait time.Duration
+}
+
+// RedisCache implementação de domain.DistributedCache sobre o Redis. As entradas ficam em
+// chaves com expiração; metadados, índices secundários e estatísticas ficam em estruturas
+// compartilhadas para que todas as réplicas enxerguem o mesmo estado.
+type RedisCache struct {
+	client redis.UniversalClient
+	config RedisCacheConfig
+	logger *zap.Logger
+
+	mu    sync.Mutex
+	loads map[string]*cacheLoad
+}
+
+// cacheLoad carga em andamento nesta réplica, compartilhada pelas chamadas da mesma chave
+type cacheLoad struct {
+	done  chan struct{}
+	entry *domain.CacheEntry
+	err   error
+}
+
+// cacheRecord entrada serializada no Redis
+type cacheRecord struct {
+	ID            uuid.UUID          `json:"id"`
+	Key           string             `json:"key"`
+	ValueType     string             `json:"value_type"`
+	Value         json.RawMessage    `json:"value"`
+	TTL           int                `json:"ttl"`
+	ExpiresAt     time.Time          `json:"expires_at"`
+	Size          int64              `json:"size"`
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+	RequestType   domain.RequestType `json:"request_type"`
+	ProcessNumber string             `json:"process_number,omitempty"`
+	CourtID       string             `json:"court_id,omitempty"`
+	TenantID      uuid.UUID          `json:"tenant_id"`
+}
+
+// cacheMeta metadados da entrada no hash de metadados, usados nas estatísticas e na limpeza
+// dos índices
+type cacheMeta struct {
+	Size          int64              `json:"size"`
+	TenantID      uuid.UUID          `json:"tenant_id"`
+	ProcessNumber string             `json:"process_number,omitempty"`
+	RequestType   domain.RequestType `json:"request_type"`
+	CreatedAt     time.Time          `json:"created_at"`
+	ExpiresAt     time.Time          `json:"expires_at"`
+}
+
+// NewRedisCache cria o cache distribuído
+func NewRedisCache(client redis.UniversalClient, config RedisCacheConfig, logger *zap.Logger) *RedisCache {
+	if config.Prefix == "" {
+		config.Prefix = "datajud:cache:"
+	}
+	if config.LoadLockTTL <= 0 {
+		config.LoadLockTTL = 30 * time.Second
+	}
+	if config.LoadWait <= 0 {
+		config.LoadWait = 10 * time.Second
+	}
+
+	return &RedisCache{
+		client: client,
+		config: config,
+		logger: logger,
+		loads:  make(map[string]*cacheLoad),
+	}
+}
+
+// Get obtém a entrada, registrando hit ou miss nas estatísticas compartilhadas
+func (c *RedisCache) Get(key string) (*domain.CacheEntry, error) {
+	ctx := context.Background()
+
+	entry, err := c.lookup(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry == nil {
+		c.client.HIncrBy(ctx, c.statsKey(), statMisses, 1)
+		return nil, nil
+	}
+
+	pipe := c.client.Pipeline()
+	pipe.HIncrBy(ctx, c.statsKey(), statHits, 1)
+	hits := pipe.HIncrBy(ctx, c.hitsKey(), key, 1)
+	if _, err := pipe.Exec(ctx); err != nil {
+		c.logger.Warn("Failed to record cache hit", zap.String("key", key), zap.Error(err))
+	} else {
+		entry.HitCount = hits.Val()
+		now := time.Now()
+		entry.LastHitAt = &now
+	}
+
+	return entry, nil
+}
+
+// Set armazena o valor. O tipo da requisição é obtido da chave (datajud:<tipo>:<hash>) e o
+// número do processo do próprio valor; use SetEntry para informar tenant e demais metadados.
+func (c *RedisCache) Set(key string, value interface{}, ttl int) error {
+	entry, err := domain.NewCacheEntry(key, value, ttl, uuid.Nil, requestTypeFromKey(key))
+	if err != nil {
+		return fmt.Errorf("failed to create cache entry: %w", err)
+	}
+	entry.SetMetadataFromValue()
+
+	return c.SetEntry(entry)
+}
+
+// SetEntry grava a entrada, os metadados e os índices secundários em uma transação
+func (c *RedisCache) SetEntry(entry *domain.CacheEntry) error {
+	if entry.TTL <= 0 {
+		return fmt.Errorf("invalid cache TTL for %s: %d", entry.Key, entry.TTL)
+	}
+
+	data, size, err := c.encode(entry)
+	if err != nil {
+		return err
+	}
+	if entry.Size == 0 {
+		entry.Size = size
+	}
+
+	meta, err := json.Marshal(cacheMeta{
+		Size:          entry.Size,
+		TenantID:      entry.TenantID,
+		ProcessNumber: entry.ProcessNumber,
+		RequestType:   entry.RequestType,
+		CreatedAt:     entry.CreatedAt,
+		ExpiresAt:     entry.ExpiresAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache metadata: %w", err)
+	}
+
+	ctx := context.Background()
+	_, err = c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, c.entryKey(entry.Key), data, time.Duration(entry.TTL)*time.Second)
+		pipe.HSet(ctx, c.metaKey(), entry.Key, meta)
+		pipe.ZAdd(ctx, c.expiryKey(), redis.Z{Score: float64(entry.ExpiresAt.UnixMilli()), Member: entry.Key})
+		pipe.HDel(ctx, c.hitsKey(), entry.Key)
+		for _, index := range c.indexKeys(entry.TenantID, entry.ProcessNumber, entry.RequestType) {
+			pipe.SAdd(ctx, index, entry.Key)
+		}
+		pipe.HIncrBy(ctx, c.statsKey(), statSets, 1)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store cache entry %s: %w", entry.Key, err)
+	}
+
+	return nil
+}
+
+// Delete remove a entrada e as referências nos índices
+func (c *RedisCache) Delete(key string) error {
+	ctx := context.Background()
+
+	removed, err := c.remove(ctx, []string{key}, false)
+	if err != nil {
+		return err
+	}
+	if removed > 0 {
+		c.client.HIncrBy(ctx, c.statsKey(), statEvictions, int64(removed))
+	}
+
+	return nil
+}
+
+// Exists verifica se a entrada existe e não expirou
+func (c *RedisCache) Exists(key string) bool {
+	count, err := c.client.Exists(context.Background(), c.entryKey(key)).Result()
+	return err == nil && count > 0
+}
+
+// Clear remove todas as chaves do cache, inclusive índices e estatísticas
+func (c *RedisCache) Clear() error {
+	ctx := context.Background()
+
+	iter := c.client.Scan(ctx, 0, c.config.Prefix+"*", 500).Iterator()
+	batch := make([]string, 0, 500)
+	for iter.Next(ctx) {
+		batch = append(batch, iter.Val())
+		if len(batch) == cap(batch) {
+			if err := c.client.Unlink(ctx, batch...).Err(); err != nil {
+				return fmt.Errorf("failed to clear cache: %w", err)
+			}
+			batch = batch[:0]
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("failed to scan cache keys: %w", err)
+	}
+	if len(batch) > 0 {
+		if err := c.client.Unlink(ctx, batch...).Err(); err != nil {
+			return fmt.Errorf("failed to clear cache: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetStats retorna as estatísticas agregadas de todas as réplicas. Os contadores são
+// atualizados a cada operação; a ocupação (entradas, tamanho, mais antiga e mais recente) é a
+// calculada na última CleanupExpired, para não percorrer os metadados a cada chamada.
+func (c *RedisCache) GetStats() (*domain.CacheStats, error) {
+	counters, err := c.client.HGetAll(context.Background(), c.statsKey()).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cache stats: %w", err)
+	}
+
+	stats := &domain.CacheStats{
+		TotalEntries:  parseCounter(counters[statEntries]),
+		TotalSize:     parseCounter(counters[statBytes]),
+		HitCount:      parseCounter(counters[statHits]),
+		MissCount:     parseCounter(counters[statMisses]),
+		EvictionCount: parseCounter(counters[statEvictions]),
+		ExpiredCount:  parseCounter(counters[statExpired]),
+	}
+	if total := stats.HitCount + stats.MissCount; total > 0 {
+		stats.HitRatio = float64(stats.HitCount) / float64(total) * 100
+	}
+	if stats.TotalEntries > 0 {
+		stats.AverageEntrySize = stats.TotalSize / stats.TotalEntries
+		stats.OldestEntry = parseTimestamp(counters[statOldest])
+		stats.NewestEntry = parseTimestamp(counters[statNewest])
+	}
+
+	return stats, nil
+}
+
+// FindByTenantID lista as entradas do tenant
+func (c *RedisCache) FindByTenantID(tenantID uuid.UUID) ([]*domain.CacheEntry, error) {
+	return c.findByIndex(c.tenantIndexKey(tenantID), func(entry *domain.CacheEntry) bool {
+		return entry.TenantID == tenantID
+	})
+}
+
+// FindByProcessNumber lista as entradas do processo, com ou sem máscara no número
+func (c *RedisCache) FindByProcessNumber(processNumber string) ([]*domain.CacheEntry, error) {
+	normalized := domain.NormalizeProcessNumber(processNumber)
+	if normalized == "" {
+		return []*domain.CacheEntry{}, nil
+	}
+
+	return c.findByIndex(c.processIndexKey(processNumber), func(entry *domain.CacheEntry) bool {
+		return domain.NormalizeProcessNumber(entry.ProcessNumber) == normalized
+	})
+}
+
+// FindByRequestType lista as entradas do tipo de requisição
+func (c *RedisCache) FindByRequestType(requestType domain.RequestType) ([]*domain.CacheEntry, error) {
+	return c.findByIndex(c.typeIndexKey(requestType), func(entry *domain.CacheEntry) bool {
+		return entry.RequestType == requestType
+	})
+}
+
+// CleanupExpired remove metadados e referências nos índices das entradas que o Redis já
+// expirou e recalcula a ocupação exibida em GetStats. Sem a limpeza periódica, o hash de
+// metadados, o sorted set de expiração e os índices crescem indefinidamente. Retorna a
+// quantidade de entradas removidas.
+func (c *RedisCache) CleanupExpired() (int, error) {
+	ctx := context.Background()
+
+	keys, err := c.client.ZRangeByScore(ctx, c.expiryKey(), &redis.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatInt(time.Now().UnixMilli(), 10),
+	}).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to find expired cache entries: %w", err)
+	}
+
+	removed := 0
+	if len(keys) > 0 {
+		if removed, err = c.remove(ctx, keys, true); err != nil {
+			return 0, err
+		}
+		if removed > 0 {
+			c.client.HIncrBy(ctx, c.statsKey(), statExpired, int64(removed))
+		}
+	}
+
+	if err := c.refreshOccupancy(ctx); err != nil {
+		return removed, err
+	}
+
+	return removed, nil
+}
+
+// refreshOccupancy percorre o hash de metadados em lotes e grava a ocupação das entradas
+// válidas no hash de estatísticas
+func (c *RedisCache) refreshOccupancy(ctx context.Context) error {
+	var entries, size, oldest, newest int64
+	now := time.Now()
+
+	var cursor uint64
+	for {
+		fields, next, err := c.client.HScan(ctx, c.metaKey(), cursor, "", scanBatchSize).Result()
+		if err != nil {
+			return fmt.Errorf("failed to scan cache metadata: %w", err)
+		}
+
+		// HSCAN retorna campo e valor alternados
+		for i := 1; i < len(fields); i += 2 {
+			var meta cacheMeta
+			if err := json.Unmarshal([]byte(fields[i]), &meta); err != nil || now.After(meta.ExpiresAt) {
+				continue
+			}
+
+			entries++
+			size += meta.Size
+			createdAt := meta.CreatedAt.UnixMilli()
+			if oldest == 0 || createdAt < oldest {
+				oldest = createdAt
+			}
+			if createdAt > newest {
+				newest = createdAt
+			}
+		}
+
+		if cursor = next; cursor == 0 {
+			break
+		}
+	}
+
+	err := c.client.HSet(ctx, c.statsKey(),
+		statEntries, entries,
+		statBytes, size,
+		statOldest, oldest,
+		statNewest, newest,
+	).Err()
+	if err != nil {
+		return fmt.Errorf("failed to store cache occupancy: %w", err)
+	}
+
+	return nil
+}
+
+// GetOrLoad retorna a entrada do cache ou executa load uma única vez por chave. Nesta réplica
+// as chamadas simultâneas compartilham a mesma carga; entre réplicas, uma trava no Redis faz
+// as demais aguardarem a entrada gravada por até LoadWait antes de consultar diretamente.
+func (c *RedisCache) GetOrLoad(ctx context.Context, key string, load domain.CacheLoader) (*domain.CacheEntry, bool, error) {
+	entry, err := c.Get(key)
+	if err != nil {
+		// Redis indisponível não impede a consulta
+		c.logger.Warn("Cache unavailable, loading without cache", zap.String("key", key), zap.Error(err))
+		entry, err := load(ctx)
+		return entry, false, err
+	}
+	if entry != nil {
+		return entry, true, nil
+	}
+
+	c.mu.Lock()
+	if current, ok := c.loads[key]; ok {
+		c.mu.Unlock()
+
+		select {
+		case <-current.done:
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		}
+		// A carga compartilhada foi cancelada pelo contexto de quem a iniciou
+		if current.err != nil && (errors.Is(current.err, context.Canceled) || errors.Is(current.err, context.DeadlineExceeded)) {
+			return c.GetOrLoad(ctx, key, load)
+		}
+		return current.entry, current.err == nil && current.entry != nil, current.err
+	}
+
+	current := &cacheLoad{done: make(chan struct{})}
+	c.loads[key] = current
+	c.mu.Unlock()
+
+	var cached bool
+	current.entry, cached, current.err = c.loadDistributed(ctx, key, load)
+
+	c.mu.Lock()
+	delete(c.loads, key)
+	c.mu.Unlock()
+	close(current.done)
+
+	return current.entry, cached, current.err
+}
+
+// loadDistributed adquire a trava da chave e executa a carga, ou aguarda a réplica que a detém
+func (c *RedisCache) loadDistributed(ctx context.Context, key string, load domain.CacheLoader) (*domain.CacheEntry, bool, error) {
+	deadline := time.Now().Add(c.config.LoadWait)
+
+	for {
+		token := uuid.New().String()
+		acquired, err := c.client.SetNX(ctx, c.lockKey(key), token, c.config.LoadLockTTL).Result()
+		if err != nil {
+			c.logger.Warn("Failed to acquire cache load lock", zap.String("key", key), zap.Error(err))
+			return c.loadAndStore(ctx, key, load)
+		}
+
+		if acquired {
+			defer c.releaseLock(key, token)
+
+			// Outra réplica pode ter gravado a entrada entre o miss e a trava
+			if entry, err := c.lookup(ctx, key); err == nil && entry != nil {
+				return entry, true, nil
+			}
+			return c.loadAndStore(ctx, key, load)
+		}
+
+		// Outra réplica está carregando a chave
+		for {
+			if time.Now().After(deadline) {
+				c.logger.Warn("Timed out waiting for cache load", zap.String("key", key))
+				return c.loadAndStore(ctx, key, load)
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil, false, ctx.Err()
+			case <-time.After(loadPollInterval):
+			}
+
+			if entry, err := c.lookup(ctx, key); err == nil && entry != nil {
+				return entry, true, nil
+			}
+
+			// Trava liberada sem entrada gravada: a carga falhou, tenta assumir
+			locked, err := c.client.Exists(ctx, c.lockKey(key)).Result()
+			if err == nil && locked == 0 {
+				break
+			}
+		}
+	}
+}
+
+// loadAndStore executa a carga e grava a entrada retornada
+func (c *RedisCache) loadAndStore(ctx context.Context, key string, load domain.CacheLoader) (*domain.CacheEntry, bool, error) {
+	entry, err := load(ctx)
+	if err != nil || entry == nil {
+		return entry, false, err
+	}
+
+	if err := c.SetEntry(entry); err != nil {
+		c.logger.Error("Failed to store loaded cache entry", zap.String("key", key), zap.Error(err))
+	}
+
+	return entry, false, nil
+}
+
+// releaseLock libera a trava de carga se ainda pertencer a esta carga
+func (c *RedisCache) releaseLock(key, token string) {
+	if err := releaseLockScript.Run(context.Background(), c.client, []string{c.lockKey(key)}, token).Err(); err != nil {
+		c.logger.Warn("Failed to release cache load lock", zap.String("key", key), zap.Error(err))
+	}
+}
+
+// lookup lê a entrada sem registrar estatísticas
+func (c *RedisCache) lookup(ctx context.Context, key string) (*domain.CacheEntry, error) {
+	data, err := c.client.Get(ctx, c.entryKey(key)).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cache entry %s: %w", key, err)
+	}
+
+	return c.decode(data)
+}
+
+// findByIndex lê as entradas referenciadas pelo índice, removendo do índice as que expiraram
+// ou não correspondem mais ao filtro
+func (c *RedisCache) findByIndex(index string, match func(*domain.CacheEntry) bool) ([]*domain.CacheEntry, error) {
+	ctx := context.Background()
+
+	keys, err := c.client.SMembers(ctx, index).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache index %s: %w", index, err)
+	}
+
+	entries := make([]*domain.CacheEntry, 0, len(keys))
+	if len(keys) == 0 {
+		return entries, nil
+	}
+
+	entryKeys := make([]string, len(keys))
+	for i, key := range keys {
+		entryKeys[i] = c.entryKey(key)
+	}
+	values, err := c.client.MGet(ctx, entryKeys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache entries: %w", err)
+	}
+
+	var stale []interface{}
+	for i, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			stale = append(stale, keys[i])
+			continue
+		}
+
+		entry, err := c.decode([]byte(data))
+		if err != nil {
+			c.logger.Warn("Skipping undecodable cache entry", zap.String("key", keys[i]), zap.Error(err))
+			continue
+		}
+		if !match(entry) {
+			stale = append(stale, keys[i])
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	if len(stale) > 0 {
+		c.client.SRem(ctx, index, stale...)
+	}
+
+	return entries, nil
+}
+
+// remove apaga as entradas, os metadados e as referências nos índices. Com expiredOnly, ignora
+// as chaves regravadas depois de expirar. Retorna a quantidade de entradas que tinham metadados.
+func (c *RedisCache) remove(ctx context.Context, keys []string, expiredOnly bool) (int, error) {
+	metas, err := c.client.HMGet(ctx, c.metaKey(), keys...).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read cache metadata: %w", err)
+	}
+
+	now := time.Now()
+	removed := 0
+	_, err = c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			raw, hasMeta := metas[i].(string)
+			var meta cacheMeta
+			validMeta := hasMeta && json.Unmarshal([]byte(raw), &meta) == nil
+			if expiredOnly && validMeta && meta.ExpiresAt.After(now) {
+				continue
+			}
+			if hasMeta {
+				removed++
+			}
+
+			pipe.Del(ctx, c.entryKey(key))
+			pipe.HDel(ctx, c.metaKey(), key)
+			pipe.HDel(ctx, c.hitsKey(), key)
+			pipe.ZRem(ctx, c.expiryKey(), key)
+			if !validMeta {
+				continue
+			}
+			for _, index := range c.indexKeys(meta.TenantID, meta.ProcessNumber, meta.RequestType) {
+				pipe.SRem(ctx, index, key)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to remove cache entries: %w", err)
+	}
+
+	return removed, nil
+}
+
+// encode serializa a entrada, comprimindo quando excede o limite configurado. Retorna também
+// o tamanho do valor sem compressão.
+func (c *RedisCache) encode(entry *domain.CacheEntry) ([]byte, int64, error) {
+	value, err := json.Marshal(entry.Value)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to marshal cache value: %w", err)
+	}
+
+	record, err := json.Marshal(cacheRecord{
+		ID:            entry.ID,
+		Key:           entry.Key,
+		ValueType:     valueTypeOf(entry.Value),
+		Value:         value,
+		TTL:           entry.TTL,
+		ExpiresAt:     entry.ExpiresAt,
+		Size:          entry.Size,
+		CreatedAt:     entry.CreatedAt,
+		UpdatedAt:     entry.UpdatedAt,
+		RequestType:   entry.RequestType,
+		ProcessNumber: entry.ProcessNumber,
+		CourtID:       entry.CourtID,
+		TenantID:      entry.TenantID,
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to marshal cache entry: %w", err)
+	}
+
+	if c.config.CompressionThreshold <= 0 || len(record) < c.config.CompressionThreshold {
+		return append([]byte{formatJSON}, record...), int64(len(value)), nil
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte(formatGzip)
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(record); err != nil {
+		return nil, 0, fmt.Errorf("failed to compress cache entry: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, 0, fmt.Errorf("failed to compress cache entry: %w", err)
+	}
+
+	return buf.Bytes(), int64(len(value)), nil
+}
+
+// decode reconstrói a entrada gravada por encode
+func (c *RedisCache) decode(data []byte) (*domain.CacheEntry, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("empty cache entry")
+	}
+
+	payload := data[1:]
+	switch data[0] {
+	case formatJSON:
+	case formatGzip:
+		reader, err := gzip.NewReader(bytes.NewReader(payload))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress cache entry: %w", err)
+		}
+		defer reader.Close()
+
+		payload, err = io.ReadAll(reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress cache entry: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown cache entry format %q", data[0])
+	}
+
+	var record cacheRecord
+	if err := json.Unmarshal(payload, &record); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cache entry: %w", err)
+	}
+
+	value, err := decodeValue(record.ValueType, record.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.CacheEntry{
+		ID:            record.ID,
+		Key:           record.Key,
+		Value:         value,
+		TTL:           record.TTL,
+		ExpiresAt:     record.ExpiresAt,
+		Size:          record.Size,
+		CreatedAt:     record.CreatedAt,
+		UpdatedAt:     record.UpdatedAt,
+		RequestType:   record.RequestType,
+		ProcessNumber: record.ProcessNumber,
+		CourtID:       record.CourtID,
+		TenantID:      record.TenantID,
+	}, nil
+}
+
+// valueTypeOf identifica o tipo do valor armazenado
+func valueTypeOf(value interface{}) string {
+	switch value.(type) {
+	case *domain.DataJudResponse:
+		return valueTypeResponse
+	case *domain.ProcessResponseData:
+		return valueTypeProcess
+	case *domain.MovementResponseData:
+		return valueTypeMovement
+	case *domain.PartyResponseData:
+		return valueTypeParty
+	default:
+		return valueTypeJSON
+	}
+}
+
+// decodeValue reconstrói o valor com o tipo original
+func decodeValue(valueType string, raw json.RawMessage) (interface{}, error) {
+	var value interface{}
+	switch valueType {
+	case valueTypeResponse:
+		value = &domain.DataJudResponse{}
+	case valueTypeProcess:
+		value = &domain.ProcessResponseData{}
+	case valueTypeMovement:
+		value = &domain.MovementResponseData{}
+	case valueTypeParty:
+		value = &domain.PartyResponseData{}
+	default:
+		var generic interface{}
+		if err := json.Unmarshal(raw, &generic); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal cache value: %w", err)
+		}
+		return generic, nil
+	}
+
+	if err := json.Unmarshal(raw, value); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cache value: %w", err)
+	}
+	return value, nil
+}
+
+// requestTypeFromKey obtém o tipo da requisição de chaves no formato datajud:<tipo>:<hash>
+func requestTypeFromKey(key string) domain.RequestType {
+	parts := strings.SplitN(key, ":", 3)
+	if len(parts) == 3 && parts[0] == "datajud" {
+		return domain.RequestType(parts[1])
+	}
+	return ""
+}
+
+// parseCounter converte o contador do hash de estatísticas
+func parseCounter(value string) int64 {
+	n, _ := strconv.ParseInt(value, 10, 64)
+	return n
+}
+
+// parseTimestamp converte o instante em unix ms do hash de estatísticas
+func parseTimestamp(value string) *time.Time {
+	ms := parseCounter(value)
+	if ms == 0 {
+		return nil
+	}
+
+	t := time.UnixMilli(ms)
+	return &t
+}
+
+// indexKeys índices secundários aplicáveis à entrada
+func (c *RedisCache) indexKeys(tenantID uuid.UUID, processNumber string, requestType domain.RequestType) []string {
+	keys := make([]string, 0, 3)
+	if tenantID != uuid.Nil {
+		keys = append(keys, c.tenantIndexKey(tenantID))
+	}
+	if domain.NormalizeProcessNumber(processNumber) != "" {
+		keys = append(keys, c.processIndexKey(processNumber))
+	}
+	if requestType != "" {
+		keys = append(keys, c.typeIndexKey(requestType))
+	}
+	return keys
+}
+
+func (c *RedisCache) entryKey(key string) string {
+	return c.config.Prefix + "entry:" + key
+}
+
+func (c *RedisCache) lockKey(key string) string {
+	return c.config.Prefix + "lock:" + key
+}
+
+func (c *RedisCache) metaKey() string {
+	return c.config.Prefix + "meta"
+}
+
+func (c *RedisCache) expiryKey() string {
+	return c.config.Prefix + "expiry"
+}
+
+func (c *RedisCache) hitsKey() string {
+	return c.config.Prefix + "hits"
+}
+
+func (c *RedisCache) statsKey() string {
+	return c.config.Prefix + "stats"
+}
+
+func (c *RedisCache) tenantIndexKey(tenantID uuid.UUID) string {
+	return c.config.Prefix + "idx:tenant:" + tenantID.String()
+}
+
+func (c *RedisCache) processIndexKey(processNumber string) string {
+	return c.config.Prefix + "idx:process:" + domain.NormalizeProcessNumber(processNumber)
+}
+
+func (c *RedisCache) typeIndexKey(requestType domain.RequestType) string {
+	return c.config.Prefix + "idx:type:" + string(requestType)
+}
@@ -0,0 +1,304 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/datajud-service/internal/domain"
+)
+
+// newTestEntry cria entrada com resposta de processo
+func newTestEntry(t *testing.T, title string) *domain.CacheEntry {
+	response := &domain.DataJudResponse{
+		ID:         uuid.New(),
+		StatusCode: 200,
+		ProcessData: &domain.ProcessResponseData{
+			Found:  true,
+			Number: "0001234-56.2023.8.26.0100",
+			Title:  title,
+			Court:  "TJSP",
+		},
+	}
+
+	entry, err := domain.NewCacheEntry("datajud:process:abc", response, 3600, uuid.New(), domain.RequestTypeProcess)
+	require.NoError(t, err)
+	entry.SetMetadataFromValue()
+	entry.SetCourtID("TJSP")
+	return entry
+}
+
+// TestRedisCacheCodec testa a serialização com e sem compressão
+func TestRedisCacheCodec(t *testing.T) {
+	c := NewRedisCache(nil, RedisCacheConfig{CompressionThreshold: 1024}, zap.NewNop())
+
+	tests := []struct {
+		name   string
+		title  string
+		format byte
+	}{
+		{"pequena sem compressão", "Ação de cobrança", formatJSON},
+		{"grande com compressão", strings.Repeat("Ação de cobrança ", 200), formatGzip},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := newTestEntry(t, tt.title)
+
+			data, size, err := c.encode(entry)
+			require.NoError(t, err)
+			assert.Equal(t, tt.format, data[0])
+			assert.Equal(t, entry.Size, size)
+
+			decoded, err := c.decode(data)
+			require.NoError(t, err)
+			assert.Equal(t, entry.Key, decoded.Key)
+			assert.Equal(t, entry.TenantID, decoded.TenantID)
+			assert.Equal(t, entry.ProcessNumber, decoded.ProcessNumber)
+			assert.Equal(t, entry.CourtID, decoded.CourtID)
+			assert.Equal(t, domain.RequestTypeProcess, decoded.RequestType)
+			assert.True(t, entry.ExpiresAt.Equal(decoded.ExpiresAt))
+
+			response, ok := decoded.Value.(*domain.DataJudResponse)
+			require.True(t, ok, "valor deve voltar como *domain.DataJudResponse")
+			assert.Equal(t, tt.title, response.ProcessData.Title)
+		})
+	}
+
+	_, err := c.decode([]byte("x{}"))
+	assert.Error(t, err)
+}
+
+// TestRedisCacheKeys testa as chaves derivadas dos metadados
+func TestRedisCacheKeys(t *testing.T) {
+	c := NewRedisCache(nil, RedisCacheConfig{}, zap.NewNop())
+
+	assert.Equal(t, domain.RequestTypeMovement, requestTypeFromKey("datajud:movement:0a1b"))
+	assert.Equal(t, domain.RequestType(""), requestTypeFromKey("outra:chave"))
+
+	assert.Equal(t, c.processIndexKey("0001234-56.2023.8.26.0100"), c.processIndexKey("00012345620238260100"))
+	assert.Equal(t, "datajud:cache:idx:process:00012345620238260100", c.processIndexKey("0001234-56.2023.8.26.0100"))
+
+	assert.Len(t, c.indexKeys(uuid.Nil, "", domain.RequestTypeProcess), 1)
+	assert.Len(t, c.indexKeys(uuid.New(), "0001234-56.2023.8.26.0100", domain.RequestTypeProcess), 3)
+}
+
+// newStandInCache cache sobre o servidor RESP local
+func newStandInCache(t *testing.T, config RedisCacheConfig) (*RedisCache, *redisStandIn, redis.UniversalClient) {
+	server, client := newRedisStandIn(t)
+	return NewRedisCache(client, config, zap.NewNop()), server, client
+}
+
+// TestRedisCacheSetEntryAndIndexes testa a gravação, os índices secundários e a remoção
+func TestRedisCacheSetEntryAndIndexes(t *testing.T) {
+	c, server, _ := newStandInCache(t, RedisCacheConfig{})
+
+	process := newTestEntry(t, "Ação de cobrança")
+	movement := newTestEntry(t, "Ação de cobrança")
+	movement.Key = "datajud:movement:def"
+	movement.RequestType = domain.RequestTypeMovement
+	movement.ProcessNumber = "0009999-11.2024.8.26.0100"
+	movement.TenantID = process.TenantID
+
+	require.NoError(t, c.SetEntry(process))
+	require.NoError(t, c.SetEntry(movement))
+
+	byTenant, err := c.FindByTenantID(process.TenantID)
+	require.NoError(t, err)
+	assert.Len(t, byTenant, 2)
+
+	for _, number := range []string{"0001234-56.2023.8.26.0100", "00012345620238260100"} {
+		byProcess, err := c.FindByProcessNumber(number)
+		require.NoError(t, err)
+		require.Len(t, byProcess, 1, number)
+		assert.Equal(t, process.Key, byProcess[0].Key)
+	}
+
+	byType, err := c.FindByRequestType(domain.RequestTypeMovement)
+	require.NoError(t, err)
+	require.Len(t, byType, 1)
+	assert.Equal(t, movement.Key, byType[0].Key)
+
+	// Entrada expirada pelo Redis sai do índice na próxima consulta
+	server.expire(c.entryKey(movement.Key))
+	byTenant, err = c.FindByTenantID(process.TenantID)
+	require.NoError(t, err)
+	require.Len(t, byTenant, 1)
+	assert.Equal(t, process.Key, byTenant[0].Key)
+	assert.Equal(t, []string{process.Key}, server.setMembers(c.tenantIndexKey(process.TenantID)))
+
+	// Delete remove entrada, metadados, expiração e referências nos índices
+	require.NoError(t, c.Delete(process.Key))
+	assert.False(t, c.Exists(process.Key))
+	assert.NotContains(t, server.hashFields(c.metaKey()), process.Key)
+	assert.NotContains(t, server.zsetMembers(c.expiryKey()), process.Key)
+	assert.Empty(t, server.setMembers(c.tenantIndexKey(process.TenantID)))
+	assert.Empty(t, server.setMembers(c.processIndexKey(process.ProcessNumber)))
+	assert.Empty(t, server.setMembers(c.typeIndexKey(domain.RequestTypeProcess)))
+	assert.Equal(t, "1", server.hashFields(c.statsKey())[statEvictions])
+}
+
+// TestRedisCacheCleanupExpired testa a limpeza das estruturas compartilhadas e a ocupação
+// exibida nas estatísticas
+func TestRedisCacheCleanupExpired(t *testing.T) {
+	c, server, _ := newStandInCache(t, RedisCacheConfig{})
+	ctx := context.Background()
+
+	expired := newTestEntry(t, "Ação de cobrança")
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+	require.NoError(t, c.SetEntry(expired))
+	server.expire(c.entryKey(expired.Key))
+
+	live := newTestEntry(t, "Ação de despejo")
+	live.Key = "datajud:process:def"
+	require.NoError(t, c.SetEntry(live))
+
+	// Chave regravada depois de expirar não é removida pela limpeza
+	removed, err := c.remove(ctx, []string{live.Key}, true)
+	require.NoError(t, err)
+	assert.Zero(t, removed)
+
+	removed, err = c.CleanupExpired()
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	assert.Equal(t, []string{live.Key}, server.zsetMembers(c.expiryKey()))
+	assert.NotContains(t, server.hashFields(c.metaKey()), expired.Key)
+	assert.Contains(t, server.hashFields(c.metaKey()), live.Key)
+	assert.Empty(t, server.setMembers(c.tenantIndexKey(expired.TenantID)))
+	assert.Equal(t, []string{live.Key}, server.setMembers(c.processIndexKey(live.ProcessNumber)))
+
+	// GetStats lê apenas o hash de estatísticas
+	scans := server.commandCount("HSCAN")
+	stats, err := c.GetStats()
+	require.NoError(t, err)
+	assert.Equal(t, scans, server.commandCount("HSCAN"))
+	assert.Zero(t, server.commandCount("HVALS"))
+
+	assert.Equal(t, int64(1), stats.TotalEntries)
+	assert.Equal(t, live.Size, stats.TotalSize)
+	assert.Equal(t, live.Size, stats.AverageEntrySize)
+	assert.Equal(t, int64(1), stats.ExpiredCount)
+	require.NotNil(t, stats.OldestEntry)
+	assert.Equal(t, live.CreatedAt.UnixMilli(), stats.OldestEntry.UnixMilli())
+	assert.Equal(t, live.CreatedAt.UnixMilli(), stats.NewestEntry.UnixMilli())
+}
+
+// TestRedisCacheGetOrLoad testa a carga única por chave, a espera pela carga de outra réplica
+// e a retomada da carga quando a outra réplica não grava a entrada
+func TestRedisCacheGetOrLoad(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("carga única na réplica", func(t *testing.T) {
+		c, server, _ := newStandInCache(t, RedisCacheConfig{})
+		entry := newTestEntry(t, "Ação de cobrança")
+
+		var loads int32
+		load := func(ctx context.Context) (*domain.CacheEntry, error) {
+			atomic.AddInt32(&loads, 1)
+			time.Sleep(50 * time.Millisecond)
+			return entry, nil
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				loaded, _, err := c.GetOrLoad(ctx, entry.Key, load)
+				assert.NoError(t, err)
+				assert.NotNil(t, loaded)
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&loads))
+		assert.Equal(t, 1, server.commandCount("EVAL"), "trava deve ser liberada")
+
+		cached, hit, err := c.GetOrLoad(ctx, entry.Key, load)
+		require.NoError(t, err)
+		assert.True(t, hit)
+		assert.Equal(t, entry.Key, cached.Key)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&loads))
+	})
+
+	t.Run("aguarda a entrada gravada por outra réplica", func(t *testing.T) {
+		c, _, client := newStandInCache(t, RedisCacheConfig{})
+		entry := newTestEntry(t, "Ação de cobrança")
+		require.NoError(t, client.Set(ctx, c.lockKey(entry.Key), "outra-replica", time.Minute).Err())
+
+		other := NewRedisCache(client, RedisCacheConfig{}, zap.NewNop())
+		go func() {
+			time.Sleep(150 * time.Millisecond)
+			assert.NoError(t, other.SetEntry(entry))
+		}()
+
+		loaded, hit, err := c.GetOrLoad(ctx, entry.Key, func(ctx context.Context) (*domain.CacheEntry, error) {
+			t.Error("carga não deve ser executada enquanto outra réplica carrega a chave")
+			return entry, nil
+		})
+		require.NoError(t, err)
+		assert.True(t, hit)
+		assert.Equal(t, entry.Key, loaded.Key)
+	})
+
+	t.Run("assume a carga quando a trava é liberada sem entrada", func(t *testing.T) {
+		c, _, client := newStandInCache(t, RedisCacheConfig{})
+		entry := newTestEntry(t, "Ação de cobrança")
+		require.NoError(t, client.Set(ctx, c.lockKey(entry.Key), "outra-replica", time.Minute).Err())
+
+		go func() {
+			time.Sleep(150 * time.Millisecond)
+			assert.NoError(t, client.Del(ctx, c.lockKey(entry.Key)).Err())
+		}()
+
+		var loads int32
+		_, hit, err := c.GetOrLoad(ctx, entry.Key, func(ctx context.Context) (*domain.CacheEntry, error) {
+			atomic.AddInt32(&loads, 1)
+			return entry, nil
+		})
+		require.NoError(t, err)
+		assert.False(t, hit)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&loads))
+		assert.True(t, c.Exists(entry.Key))
+		assert.Zero(t, client.Exists(ctx, c.lockKey(entry.Key)).Val(), "trava assumida deve ser liberada")
+	})
+
+	t.Run("consulta diretamente após LoadWait", func(t *testing.T) {
+		c, _, client := newStandInCache(t, RedisCacheConfig{LoadWait: 200 * time.Millisecond})
+		entry := newTestEntry(t, "Ação de cobrança")
+		require.NoError(t, client.Set(ctx, c.lockKey(entry.Key), "outra-replica", time.Minute).Err())
+
+		_, hit, err := c.GetOrLoad(ctx, entry.Key, func(ctx context.Context) (*domain.CacheEntry, error) {
+			return entry, nil
+		})
+		require.NoError(t, err)
+		assert.False(t, hit)
+		assert.True(t, c.Exists(entry.Key))
+		assert.Equal(t, "outra-replica", client.Get(ctx, c.lockKey(entry.Key)).Val(), "trava de outra réplica não deve ser liberada")
+	})
+
+	t.Run("falha na carga libera a trava sem gravar", func(t *testing.T) {
+		c, _, client := newStandInCache(t, RedisCacheConfig{})
+		entry := newTestEntry(t, "Ação de cobrança")
+		loadErr := errors.New("datajud indisponível")
+
+		_, hit, err := c.GetOrLoad(ctx, entry.Key, func(ctx context.Context) (*domain.CacheEntry, error) {
+			return nil, loadErr
+		})
+		assert.ErrorIs(t, err, loadErr)
+		assert.False(t, hit)
+		assert.False(t, c.Exists(entry.Key))
+		assert.Zero(t, client.Exists(ctx, c.lockKey(entry.Key)).Val())
+	})
+}
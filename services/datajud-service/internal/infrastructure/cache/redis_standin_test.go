@@ -0,0 +1,493 @@
+package cache
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+// redisStandIn servidor RESP2 local com os comandos usados pelo RedisCache. Guarda strings com
+// expiração, hashes, sorted sets e sets em memória e conta os comandos recebidos.
+type redisStandIn struct {
+	listener net.Listener
+
+	mu       sync.Mutex
+	strings  map[string]string
+	expires  map[string]time.Time
+	hashes   map[string]map[string]string
+	zsets    map[string]map[string]float64
+	sets     map[string]map[string]struct{}
+	commands map[string]int
+}
+
+// statusReply resposta simples (+OK)
+type statusReply string
+
+// errorReply resposta de erro (-ERR ...)
+type errorReply string
+
+// newRedisStandIn sobe o servidor e retorna um cliente conectado, encerrados ao fim do teste
+func newRedisStandIn(t *testing.T) (*redisStandIn, redis.UniversalClient) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := &redisStandIn{
+		listener: listener,
+		strings:  make(map[string]string),
+		expires:  make(map[string]time.Time),
+		hashes:   make(map[string]map[string]string),
+		zsets:    make(map[string]map[string]float64),
+		sets:     make(map[string]map[string]struct{}),
+		commands: make(map[string]int),
+	}
+	go s.accept()
+
+	client := redis.NewClient(&redis.Options{Addr: listener.Addr().String(), Protocol: 2})
+	t.Cleanup(func() {
+		client.Close()
+		listener.Close()
+	})
+
+	return s, client
+}
+
+func (s *redisStandIn) accept() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.serve(conn)
+	}
+}
+
+// serve atende a conexão; entre MULTI e EXEC os comandos são enfileirados e executados juntos
+func (s *redisStandIn) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	writer := bufio.NewWriter(conn)
+
+	var queued [][]string
+	inTx := false
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+
+		var reply interface{}
+		switch name := strings.ToUpper(args[0]); {
+		case name == "MULTI":
+			inTx, queued = true, nil
+			reply = statusReply("OK")
+		case name == "EXEC":
+			replies := make([]interface{}, len(queued))
+			s.mu.Lock()
+			for i, cmd := range queued {
+				replies[i] = s.execute(cmd)
+			}
+			s.mu.Unlock()
+			inTx, queued = false, nil
+			reply = replies
+		case inTx:
+			queued = append(queued, args)
+			reply = statusReply("QUEUED")
+		default:
+			s.mu.Lock()
+			reply = s.execute(args)
+			s.mu.Unlock()
+		}
+
+		writeReply(writer, reply)
+		if err := writer.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// execute aplica o comando; chamado com s.mu travado
+func (s *redisStandIn) execute(args []string) interface{} {
+	name := strings.ToUpper(args[0])
+	s.commands[name]++
+
+	switch name {
+	case "PING":
+		return statusReply("PONG")
+	case "CLIENT":
+		return statusReply("OK")
+	case "GET":
+		if value, ok := s.get(args[1]); ok {
+			return value
+		}
+		return nil
+	case "SET":
+		return s.set(args[1:])
+	case "MGET":
+		values := make([]interface{}, 0, len(args)-1)
+		for _, key := range args[1:] {
+			if value, ok := s.get(key); ok {
+				values = append(values, value)
+			} else {
+				values = append(values, nil)
+			}
+		}
+		return values
+	case "DEL", "UNLINK":
+		var removed int64
+		for _, key := range args[1:] {
+			if s.delete(key) {
+				removed++
+			}
+		}
+		return removed
+	case "EXISTS":
+		var count int64
+		for _, key := range args[1:] {
+			if _, ok := s.get(key); ok {
+				count++
+			}
+		}
+		return count
+	case "HSET":
+		hash := s.hash(args[1])
+		var added int64
+		for i := 2; i+1 < len(args); i += 2 {
+			if _, ok := hash[args[i]]; !ok {
+				added++
+			}
+			hash[args[i]] = args[i+1]
+		}
+		return added
+	case "HDEL":
+		var removed int64
+		for _, field := range args[2:] {
+			if _, ok := s.hashes[args[1]][field]; ok {
+				delete(s.hashes[args[1]], field)
+				removed++
+			}
+		}
+		return removed
+	case "HINCRBY":
+		hash := s.hash(args[1])
+		current, _ := strconv.ParseInt(hash[args[2]], 10, 64)
+		delta, _ := strconv.ParseInt(args[3], 10, 64)
+		hash[args[2]] = strconv.FormatInt(current+delta, 10)
+		return current + delta
+	case "HGETALL":
+		values := make([]interface{}, 0, 2*len(s.hashes[args[1]]))
+		for field, value := range s.hashes[args[1]] {
+			values = append(values, field, value)
+		}
+		return values
+	case "HMGET":
+		values := make([]interface{}, 0, len(args)-2)
+		for _, field := range args[2:] {
+			if value, ok := s.hashes[args[1]][field]; ok {
+				values = append(values, value)
+			} else {
+				values = append(values, nil)
+			}
+		}
+		return values
+	case "HSCAN":
+		// Retorna o hash inteiro em uma iteração (cursor 0)
+		values := make([]interface{}, 0, 2*len(s.hashes[args[1]]))
+		for field, value := range s.hashes[args[1]] {
+			values = append(values, field, value)
+		}
+		return []interface{}{"0", values}
+	case "HVALS":
+		values := make([]interface{}, 0, len(s.hashes[args[1]]))
+		for _, value := range s.hashes[args[1]] {
+			values = append(values, value)
+		}
+		return values
+	case "ZADD":
+		zset, ok := s.zsets[args[1]]
+		if !ok {
+			zset = make(map[string]float64)
+			s.zsets[args[1]] = zset
+		}
+		var added int64
+		for i := 2; i+1 < len(args); i += 2 {
+			score, _ := strconv.ParseFloat(args[i], 64)
+			if _, ok := zset[args[i+1]]; !ok {
+				added++
+			}
+			zset[args[i+1]] = score
+		}
+		return added
+	case "ZRANGEBYSCORE":
+		min, max := parseScore(args[2]), parseScore(args[3])
+		members := make([]string, 0)
+		for member, score := range s.zsets[args[1]] {
+			if score >= min && score <= max {
+				members = append(members, member)
+			}
+		}
+		sort.Slice(members, func(i, j int) bool {
+			return s.zsets[args[1]][members[i]] < s.zsets[args[1]][members[j]]
+		})
+		values := make([]interface{}, len(members))
+		for i, member := range members {
+			values[i] = member
+		}
+		return values
+	case "ZREM":
+		var removed int64
+		for _, member := range args[2:] {
+			if _, ok := s.zsets[args[1]][member]; ok {
+				delete(s.zsets[args[1]], member)
+				removed++
+			}
+		}
+		return removed
+	case "SADD":
+		set, ok := s.sets[args[1]]
+		if !ok {
+			set = make(map[string]struct{})
+			s.sets[args[1]] = set
+		}
+		var added int64
+		for _, member := range args[2:] {
+			if _, ok := set[member]; !ok {
+				added++
+			}
+			set[member] = struct{}{}
+		}
+		return added
+	case "SREM":
+		var removed int64
+		for _, member := range args[2:] {
+			if _, ok := s.sets[args[1]][member]; ok {
+				delete(s.sets[args[1]], member)
+				removed++
+			}
+		}
+		return removed
+	case "SMEMBERS":
+		values := make([]interface{}, 0, len(s.sets[args[1]]))
+		for member := range s.sets[args[1]] {
+			values = append(values, member)
+		}
+		return values
+	case "EVALSHA":
+		return errorReply("NOSCRIPT No matching script. Please use EVAL.")
+	case "EVAL":
+		// Único script do cache: releaseLockScript (DEL da trava se o valor for o token)
+		if value, ok := s.get(args[3]); ok && value == args[4] {
+			s.delete(args[3])
+			return int64(1)
+		}
+		return int64(0)
+	default:
+		return errorReply(fmt.Sprintf("ERR unknown command '%s'", args[0]))
+	}
+}
+
+// set atende SET key value [EX s|PX ms] [NX]
+func (s *redisStandIn) set(args []string) interface{} {
+	key, value := args[0], args[1]
+	var ttl time.Duration
+	nx := false
+	for i := 2; i < len(args); i++ {
+		switch strings.ToUpper(args[i]) {
+		case "EX":
+			seconds, _ := strconv.Atoi(args[i+1])
+			ttl = time.Duration(seconds) * time.Second
+			i++
+		case "PX":
+			ms, _ := strconv.Atoi(args[i+1])
+			ttl = time.Duration(ms) * time.Millisecond
+			i++
+		case "NX":
+			nx = true
+		}
+	}
+
+	if _, exists := s.get(key); exists && nx {
+		return nil
+	}
+
+	s.strings[key] = value
+	delete(s.expires, key)
+	if ttl > 0 {
+		s.expires[key] = time.Now().Add(ttl)
+	}
+	return statusReply("OK")
+}
+
+// get lê a string, descartando-a se já expirou
+func (s *redisStandIn) get(key string) (string, bool) {
+	if expiresAt, ok := s.expires[key]; ok && !time.Now().Before(expiresAt) {
+		delete(s.strings, key)
+		delete(s.expires, key)
+	}
+	value, ok := s.strings[key]
+	return value, ok
+}
+
+func (s *redisStandIn) delete(key string) bool {
+	_, isString := s.get(key)
+	_, isHash := s.hashes[key]
+	_, isZSet := s.zsets[key]
+	_, isSet := s.sets[key]
+
+	delete(s.strings, key)
+	delete(s.expires, key)
+	delete(s.hashes, key)
+	delete(s.zsets, key)
+	delete(s.sets, key)
+	return isString || isHash || isZSet || isSet
+}
+
+func (s *redisStandIn) hash(key string) map[string]string {
+	hash, ok := s.hashes[key]
+	if !ok {
+		hash = make(map[string]string)
+		s.hashes[key] = hash
+	}
+	return hash
+}
+
+// expire remove a string como o Redis faria ao fim do TTL
+func (s *redisStandIn) expire(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.strings, key)
+	delete(s.expires, key)
+}
+
+// hashFields campos do hash
+func (s *redisStandIn) hashFields(key string) map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fields := make(map[string]string, len(s.hashes[key]))
+	for field, value := range s.hashes[key] {
+		fields[field] = value
+	}
+	return fields
+}
+
+// zsetMembers membros do sorted set
+func (s *redisStandIn) zsetMembers(key string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	members := make([]string, 0, len(s.zsets[key]))
+	for member := range s.zsets[key] {
+		members = append(members, member)
+	}
+	sort.Strings(members)
+	return members
+}
+
+// setMembers membros do set
+func (s *redisStandIn) setMembers(key string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	members := make([]string, 0, len(s.sets[key]))
+	for member := range s.sets[key] {
+		members = append(members, member)
+	}
+	sort.Strings(members)
+	return members
+}
+
+// commandCount quantidade de vezes que o comando foi recebido
+func (s *redisStandIn) commandCount(name string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.commands[name]
+}
+
+// parseScore converte os limites do ZRANGEBYSCORE
+func parseScore(value string) float64 {
+	switch value {
+	case "-inf":
+		return math.Inf(-1)
+	case "+inf", "inf":
+		return math.Inf(1)
+	}
+	score, _ := strconv.ParseFloat(value, 64)
+	return score
+}
+
+// readCommand lê um comando RESP (array de bulk strings)
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := readLine(reader)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(line, "*") {
+		return nil, fmt.Errorf("unexpected command line %q", line)
+	}
+
+	count, err := strconv.Atoi(line[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, count)
+	for i := range args {
+		header, err := readLine(reader)
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimPrefix(header, "$"))
+		if err != nil {
+			return nil, err
+		}
+
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return nil, err
+		}
+		args[i] = string(data[:size])
+	}
+	return args, nil
+}
+
+func readLine(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(line, "\r\n"), nil
+}
+
+// writeReply serializa a resposta em RESP2
+func writeReply(writer *bufio.Writer, reply interface{}) {
+	switch v := reply.(type) {
+	case nil:
+		writer.WriteString("$-1\r\n")
+	case statusReply:
+		fmt.Fprintf(writer, "+%s\r\n", v)
+	case errorReply:
+		fmt.Fprintf(writer, "-%s\r\n", v)
+	case int64:
+		fmt.Fprintf(writer, ":%d\r\n", v)
+	case string:
+		fmt.Fprintf(writer, "$%d\r\n%s\r\n", len(v), v)
+	case []interface{}:
+		fmt.Fprintf(writer, "*%d\r\n", len(v))
+		for _, item := range v {
+			writeReply(writer, item)
+		}
+	}
+}
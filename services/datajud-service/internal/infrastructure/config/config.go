@@ -164,6 +164,10 @@ type DataJudConfig struct {
 	RateLimitBurst      int           `envconfig:"DATAJUD_RATE_LIMIT_BURST" default:"10"`
 	RateLimitWindow     time.Duration `envconfig:"DATAJUD_RATE_LIMIT_WINDOW" default:"1m"`
 	
+	// Rate limiting distribuído (Redis): quotas de CNPJ, tenant e global compartilhadas entre as réplicas
+	RateLimitRedisEnabled bool   `envconfig:"DATAJUD_RATE_LIMIT_REDIS_ENABLED" default:"false"`
+	RateLimitRedisPrefix  string `envconfig:"DATAJUD_RATE_LIMIT_REDIS_PREFIX" default:"datajud:ratelimit:"`
+	
 	// Circuit breaker
 	CircuitBreakerEnabled         bool          `envconfig:"DATAJUD_CIRCUIT_BREAKER_ENABLED" default:"true"`
 	CircuitBreakerMaxRequests     uint32        `envconfig:"DATAJUD_CIRCUIT_BREAKER_MAX_REQUESTS" default:"3"`
@@ -179,6 +183,14 @@ type DataJudConfig struct {
 	CacheMaxSize      int           `envconfig:"DATAJUD_CACHE_MAX_SIZE" default:"10000"`
 	CacheMaxMemory    int64         `envconfig:"DATAJUD_CACHE_MAX_MEMORY" default:"104857600"` // 100MB
 	
+	// Cache distribuído (Redis) compartilhado entre as réplicas
+	CacheRedisEnabled         bool          `envconfig:"DATAJUD_CACHE_REDIS_ENABLED" default:"false"`
+	CacheRedisPrefix          string        `envconfig:"DATAJUD_CACHE_REDIS_PREFIX" default:"datajud:cache:"`
+	CacheCompressionThreshold int           `envconfig:"DATAJUD_CACHE_COMPRESSION_THRESHOLD" default:"4096"` // bytes
+	CacheLoadLockTTL          time.Duration `envconfig:"DATAJUD_CACHE_LOAD_LOCK_TTL" default:"30s"`
+	CacheLoadWait             time.Duration `envconfig:"DATAJUD_CACHE_LOAD_WAIT" default:"10s"`
+	CacheCleanupInterval      time.Duration `envconfig:"DATAJUD_CACHE_CLEANUP_INTERVAL" default:"5m"`
+	
 	// Pool de CNPJs (legacy, será removido)
 	CNPJPoolEnabled   bool   `envconfig:"DATAJUD_CNPJ_POOL_ENABLED" default:"false"`
 	CNPJPoolSize      int    `envconfig:"DATAJUD_CNPJ_POOL_SIZE" default:"1"`
@@ -331,6 +343,10 @@ func (c *Config) ValidateDataJudConfig() error {
 		if c.DataJud.CacheMaxMemory <= 0 {
 			return fmt.Errorf("DATAJUD_CACHE_MAX_MEMORY deve ser maior que 0")
 		}
+		
+		if c.DataJud.CacheCompressionThreshold < 0 {
+			return fmt.Errorf("DATAJUD_CACHE_COMPRESSION_THRESHOLD não pode ser negativo")
+		}
 	}
 	
 	return nil
@@ -372,6 +388,9 @@ func (c *Config) GetDataJudRateLimitConfig() DataJudRateLimitConfig {
 		RPM:        c.DataJud.RateLimitRPM,
 		Burst:      c.DataJud.RateLimitBurst,
 		Window:     c.DataJud.RateLimitWindow,
+
+		RedisEnabled: c.DataJud.RateLimitRedisEnabled,
+		RedisPrefix:  c.DataJud.RateLimitRedisPrefix,
 	}
 }
 
@@ -381,6 +400,10 @@ type DataJudRateLimitConfig struct {
 	RPM     int
 	Burst   int
 	Window  time.Duration
+
+	// Rate limiting distribuído
+	RedisEnabled bool
+	RedisPrefix  string
 }
 
 // GetDataJudCacheConfig retorna configuração de cache
@@ -392,6 +415,13 @@ func (c *Config) GetDataJudCacheConfig() DataJudCacheConfig {
 		MovementTTL: c.DataJud.CacheMovementTTL,
 		MaxSize:     c.DataJud.CacheMaxSize,
 		MaxMemory:   c.DataJud.CacheMaxMemory,
+
+		RedisEnabled:         c.DataJud.CacheRedisEnabled,
+		RedisPrefix:          c.DataJud.CacheRedisPrefix,
+		CompressionThreshold: c.DataJud.CacheCompressionThreshold,
+		LoadLockTTL:          c.DataJud.CacheLoadLockTTL,
+		LoadWait:             c.DataJud.CacheLoadWait,
+		CleanupInterval:      c.DataJud.CacheCleanupInterval,
 	}
 }
 
@@ -403,6 +433,14 @@ type DataJudCacheConfig struct {
 	MovementTTL time.Duration
 	MaxSize     int
 	MaxMemory   int64
+
+	// Cache distribuído
+	RedisEnabled         bool
+	RedisPrefix          string
+	CompressionThreshold int
+	LoadLockTTL          time.Duration
+	LoadWait             time.Duration
+	CleanupInterval      time.Duration
 }
 
 // GetDataJudCircuitBreakerConfig retorna configuração de circuit breaker
@@ -429,7 +467,7 @@ func (c *Config) GetDataJudDomainConfig() domain.DataJudConfig {
 		DefaultCacheTTL:      int(c.DataJud.CacheDefaultTTL.Seconds()),
 		MaxCacheSize:         c.DataJud.CacheMaxMemory,
 		MaxCacheEntries:      int64(c.DataJud.CacheMaxSize),
-		CacheCleanupInterval: 5 * time.Minute,
+		CacheCleanupInterval: c.DataJud.CacheCleanupInterval,
 		CBFailureThreshold:   int(c.DataJud.CircuitBreakerFailureThreshold),
 		CBSuccessThreshold:   3,
 		CBTimeout:            c.DataJud.CircuitBreakerTimeout,
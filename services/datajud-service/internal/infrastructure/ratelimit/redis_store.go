@@ -0,0 +1,214 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/datajud-service/internal/domain"
+)
+
+// reserveScript aplica o GCRA a todos os limites de uma vez: só grava os novos TATs se todos
+// permitirem. O relógio é o do Redis, comum a todas as réplicas. Valores em microssegundos.
+//
+// KEYS: TAT de cada limite; ARGV: intervalo de emissão e janela de cada limite.
+// Retorno: {agora, concedida, TAT e admissão de cada limite}.
+var reserveScript = redis.NewScript(`
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000000 + tonumber(time[2])
+local granted = 1
+local tats = {}
+local admitted = {}
+
+for i, key in ipairs(KEYS) do
+	local interval = tonumber(ARGV[i * 2 - 1])
+	local window = tonumber(ARGV[i * 2])
+	local tat = tonumber(redis.call("GET", key) or "0")
+	if tat < now then
+		tat = now
+	end
+	if window > 0 and tat + interval - now <= window then
+		tats[i] = tat + interval
+		admitted[i] = 1
+	else
+		tats[i] = tat
+		admitted[i] = 0
+		granted = 0
+	end
+end
+
+if granted == 1 then
+	for i, key in ipairs(KEYS) do
+		local ttl = math.ceil((tats[i] - now) / 1000) + 1
+		redis.call("SET", key, string.format("%d", tats[i]), "PX", string.format("%d", ttl))
+	end
+end
+
+local result = {now, granted}
+for i = 1, #KEYS do
+	table.insert(result, tats[i])
+	table.insert(result, admitted[i])
+end
+return result
+`)
+
+// refundScript devolve um intervalo de emissão de cada limite. Quota que já voltou com o tempo
+// não é devolvida de novo.
+//
+// KEYS: TAT de cada limite; ARGV: intervalo de emissão cobrado de cada limite.
+var refundScript = redis.NewScript(`
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000000 + tonumber(time[2])
+
+for i, key in ipairs(KEYS) do
+	local tat = tonumber(redis.call("GET", key) or "0") - tonumber(ARGV[i])
+	if tat <= now then
+		redis.call("DEL", key)
+	else
+		local ttl = math.ceil((tat - now) / 1000) + 1
+		redis.call("SET", key, string.format("%d", tat), "PX", string.format("%d", ttl))
+	end
+end
+return 1
+`)
+
+// statusScript retorna o relógio do Redis e o TAT do limite
+var statusScript = redis.NewScript(`
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000000 + tonumber(time[2])
+return {now, tonumber(redis.call("GET", KEYS[1]) or "0")}
+`)
+
+// RedisStoreConfig configuração do rate limiting distribuído
+type RedisStoreConfig struct {
+	// Prefix prefixo de todas as chaves de rate limiting
+	Prefix string
+}
+
+// RedisStore implementação de domain.RateLimitStore sobre o Redis. Cada limite ocupa uma única
+// chave com o TAT do GCRA, que expira quando a quota volta por completo.
+type RedisStore struct {
+	client redis.UniversalClient
+	config RedisStoreConfig
+	logger *zap.Logger
+}
+
+// NewRedisStore cria o armazenamento distribuído de rate limiting
+func NewRedisStore(client redis.UniversalClient, config RedisStoreConfig, logger *zap.Logger) *RedisStore {
+	if config.Prefix == "" {
+		config.Prefix = "datajud:ratelimit:"
+	}
+
+	return &RedisStore{
+		client: client,
+		config: config,
+		logger: logger,
+	}
+}
+
+// Reserve consome uma requisição em todos os limites ou, se algum estiver esgotado, em nenhum
+func (s *RedisStore) Reserve(ctx context.Context, rules []domain.RateLimitRule) (*domain.RateLimitReservation, error) {
+	if len(rules) == 0 {
+		return domain.NewRateLimitReservation(rules, nil), nil
+	}
+
+	keys := make([]string, len(rules))
+	args := make([]interface{}, 0, len(rules)*2)
+	for i, rule := range rules {
+		keys[i] = s.key(rule)
+		args = append(args, rule.EmissionInterval().Microseconds(), windowMicroseconds(rule))
+	}
+
+	values, err := reserveScript.Run(ctx, s.client, keys, args...).Int64Slice()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao reservar quota no Redis: %w", err)
+	}
+	if len(values) != 2+len(rules)*2 {
+		return nil, fmt.Errorf("resposta inesperada do Redis ao reservar quota: %v", values)
+	}
+
+	now := time.UnixMicro(values[0])
+	statuses := make([]domain.RateLimitStatus, len(rules))
+	for i, rule := range rules {
+		tat := time.UnixMicro(values[2+i*2])
+		statuses[i] = rule.StatusAt(tat, now, values[3+i*2] == 1)
+	}
+
+	reservation := domain.NewRateLimitReservation(rules, statuses)
+	if reservation.Status.Allowed != (values[1] == 1) {
+		return nil, fmt.Errorf("resposta inconsistente do Redis ao reservar quota: %v", values)
+	}
+
+	return reservation, nil
+}
+
+// Refund devolve exatamente a quota consumida por uma reserva concedida
+func (s *RedisStore) Refund(ctx context.Context, reservation *domain.RateLimitReservation) error {
+	if !reservation.Granted() || len(reservation.Rules) == 0 {
+		return nil
+	}
+
+	keys := make([]string, len(reservation.Rules))
+	args := make([]interface{}, len(reservation.Rules))
+	for i, rule := range reservation.Rules {
+		keys[i] = s.key(rule)
+		args[i] = rule.EmissionInterval().Microseconds()
+	}
+
+	if err := refundScript.Run(ctx, s.client, keys, args...).Err(); err != nil {
+		return fmt.Errorf("erro ao devolver quota no Redis: %w", err)
+	}
+	reservation.Refunded = true
+
+	s.logger.Debug("Quota devolvida",
+		zap.String("reservation_id", reservation.ID.String()),
+		zap.Int("limits", len(reservation.Rules)),
+	)
+
+	return nil
+}
+
+// Status retorna o status do limite sem consumir quota
+func (s *RedisStore) Status(ctx context.Context, rule domain.RateLimitRule) (domain.RateLimitStatus, error) {
+	values, err := statusScript.Run(ctx, s.client, []string{s.key(rule)}).Int64Slice()
+	if err != nil {
+		return domain.RateLimitStatus{}, fmt.Errorf("erro ao consultar quota no Redis: %w", err)
+	}
+	if len(values) != 2 {
+		return domain.RateLimitStatus{}, fmt.Errorf("resposta inesperada do Redis ao consultar quota: %v", values)
+	}
+
+	now := time.UnixMicro(values[0])
+	var tat time.Time
+	if values[1] > 0 {
+		tat = time.UnixMicro(values[1])
+	}
+	_, allowed := rule.Admit(tat, now)
+
+	return rule.StatusAt(tat, now, allowed), nil
+}
+
+// Reset devolve toda a quota do limite
+func (s *RedisStore) Reset(ctx context.Context, rule domain.RateLimitRule) error {
+	if err := s.client.Del(ctx, s.key(rule)).Err(); err != nil {
+		return fmt.Errorf("erro ao resetar quota no Redis: %w", err)
+	}
+	return nil
+}
+
+// windowMicroseconds retorna a janela do limite em microssegundos; janela zero nega tudo
+func windowMicroseconds(rule domain.RateLimitRule) int64 {
+	if rule.Window.MaxRequests <= 0 {
+		return 0
+	}
+	return rule.Window.WindowSize.Microseconds()
+}
+
+// key retorna a chave do limite. O prefixo entre chaves é uma hash tag: no Redis Cluster todos
+// os limites ficam no mesmo slot, o que permite reservá-los no mesmo script.
+func (s *RedisStore) key(rule domain.RateLimitRule) string {
+	return fmt.Sprintf("{%s}%s", s.config.Prefix, rule.StoreKey())
+}
@@ -13,6 +13,8 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/direito-lux/mcp-service/internal/domain"
+	"github.com/direito-lux/mcp-service/internal/domain/tools"
+	"github.com/direito-lux/mcp-service/internal/infrastructure/clients"
 	"github.com/direito-lux/mcp-service/internal/infrastructure/config"
 	"github.com/direito-lux/mcp-service/internal/infrastructure/database"
 	"github.com/direito-lux/mcp-service/internal/infrastructure/logging"
@@ -21,6 +23,7 @@ import (
 	"github.com/direito-lux/mcp-service/internal/infrastructure/messaging"
 	"github.com/direito-lux/mcp-service/internal/infrastructure/tracing"
 	"github.com/direito-lux/mcp-service/internal/infrastructure/http"
+	"github.com/direito-lux/mcp-service/internal/infrastructure/llm"
 	"github.com/direito-lux/mcp-service/internal/application/services"
 )
 
@@ -82,8 +85,13 @@ func main() {
 		}),
 
 		// Tool Registry
-		fx.Provide(func() *domain.ToolRegistry {
-			return domain.NewToolRegistry()
+		fx.Provide(func(cfg *config.Config, logger *zap.Logger) (*domain.ToolRegistry, error) {
+			registry := domain.NewToolRegistry()
+			processes := clients.NewProcessServiceClient(cfg.Services.ProcessServiceURL, cfg.Services.DefaultTimeout, logger)
+			if err := tools.RegisterProcessTools(registry, processes); err != nil {
+				return nil, err
+			}
+			return registry, nil
 		}),
 
 		fx.Provide(func(
@@ -95,13 +103,23 @@ func main() {
 			return services.NewToolService(logger, metrics, eventBus, toolRegistry)
 		}),
 
+		// Assistente dos bots de mensagem
+		fx.Provide(func(
+			cfg *config.Config,
+			logger *zap.Logger,
+			toolRegistry *domain.ToolRegistry,
+		) *services.AssistantService {
+			return services.NewAssistantService(logger, llm.NewModel(cfg, logger), toolRegistry)
+		}),
+
 		// HTTP Server
 		fx.Provide(func(
 			cfg *config.Config,
 			logger *zap.Logger,
 			metrics *metrics.Metrics,
+			assistant *services.AssistantService,
 		) *http.Server {
-			return http.NewServer(cfg, logger, metrics)
+			return http.NewServer(cfg, logger, metrics, assistant)
 		}),
 
 		// Lifecycle hooks
@@ -8,10 +8,15 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/direito-lux/mcp-service/internal/application/services"
+	"github.com/direito-lux/mcp-service/internal/domain"
+	"github.com/direito-lux/mcp-service/internal/domain/tools"
+	"github.com/direito-lux/mcp-service/internal/infrastructure/clients"
 	"github.com/direito-lux/mcp-service/internal/infrastructure/config"
 	"github.com/direito-lux/mcp-service/internal/infrastructure/database"
 	"github.com/direito-lux/mcp-service/internal/infrastructure/events"
 	"github.com/direito-lux/mcp-service/internal/infrastructure/http"
+	"github.com/direito-lux/mcp-service/internal/infrastructure/llm"
 	"github.com/direito-lux/mcp-service/internal/infrastructure/logging"
 	"github.com/direito-lux/mcp-service/internal/infrastructure/messaging"
 	"github.com/direito-lux/mcp-service/internal/infrastructure/metrics"
@@ -81,6 +86,13 @@ func main() {
 			events.NewEventBus,
 		),
 
+		// Assistente dos bots de mensagem
+		fx.Provide(
+			newToolRegistry,
+			llm.NewModel,
+			services.NewAssistantService,
+		),
+
 		// HTTP Server
 		fx.Provide(http.NewServer),
 		
@@ -114,6 +126,16 @@ func main() {
 	logger.Info("MCP Service parado com sucesso")
 }
 
+// newToolRegistry registra as ferramentas de processos, consultadas no process-service
+func newToolRegistry(cfg *config.Config, logger *zap.Logger) (*domain.ToolRegistry, error) {
+	registry := domain.NewToolRegistry()
+	processes := clients.NewProcessServiceClient(cfg.Services.ProcessServiceURL, cfg.Services.DefaultTimeout, logger)
+	if err := tools.RegisterProcessTools(registry, processes); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
 // registerHooks registra hooks do ciclo de vida da aplicação
 func registerHooks(
 	lc fx.Lifecycle,
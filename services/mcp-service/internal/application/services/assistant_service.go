@@ -0,0 +1,221 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/mcp-service/internal/domain"
+	"github.com/direito-lux/mcp-service/internal/infrastructure/http/dto"
+)
+
+const (
+	// maxAssistantConversations acima deste número as conversas expiradas são descartadas
+	maxAssistantConversations = 1024
+
+	assistantFallbackReply = "Não consegui concluir a consulta. Tente reformular a pergunta ou envie o número do processo."
+)
+
+// assistantKey conversa identificada pelo canal e pelo ID externo (telefone ou chat)
+type assistantKey struct {
+	channel    string
+	externalID string
+}
+
+// assistantConversation sessão MCP com o contexto da conversa; mu serializa as mensagens do chat
+type assistantConversation struct {
+	mu      sync.Mutex
+	session *domain.MCPSession
+	context *domain.ConversationContext
+}
+
+// AssistantService conduz as conversas dos bots de mensagem: o modelo escolhe as ferramentas,
+// o serviço as executa no tenant da sessão e devolve a resposta final
+type AssistantService struct {
+	logger   *zap.Logger
+	model    domain.AssistantModel
+	registry *domain.ToolRegistry
+
+	mu            sync.Mutex
+	conversations map[assistantKey]*assistantConversation // Em memória, como as sessões MCP
+}
+
+// NewAssistantService cria nova instância do serviço
+func NewAssistantService(
+	logger *zap.Logger,
+	model domain.AssistantModel,
+	registry *domain.ToolRegistry,
+) *AssistantService {
+	return &AssistantService{
+		logger:        logger,
+		model:         model,
+		registry:      registry,
+		conversations: make(map[assistantKey]*assistantConversation),
+	}
+}
+
+// HandleMessage responde a mensagem do usuário vinculado, mantendo o contexto por chat
+func (s *AssistantService) HandleMessage(ctx context.Context, req dto.AssistantMessageRequest) (*dto.AssistantMessageResponse, error) {
+	tenantID, err := uuid.Parse(req.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("tenant_id inválido: %w", err)
+	}
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("user_id inválido: %w", err)
+	}
+
+	conversation := s.conversation(tenantID, userID, req.Channel, req.ExternalID)
+	conversation.mu.Lock()
+	defer conversation.mu.Unlock()
+
+	conversation.session.UpdateInteraction()
+	conversation.context.AddMessage("user", strings.TrimSpace(req.Text))
+
+	tools := s.tools()
+	var toolsUsed []string
+	for round := 0; round < domain.AssistantMaxToolRounds; round++ {
+		decision, err := s.model.Decide(ctx, &domain.AssistantTurn{
+			Channel: req.Channel,
+			History: recentHistory(conversation.context),
+			Tools:   tools,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("erro ao consultar modelo do assistente: %w", err)
+		}
+
+		if len(decision.ToolCalls) == 0 {
+			reply := strings.TrimSpace(decision.Reply)
+			if reply == "" {
+				reply = assistantFallbackReply
+			}
+			conversation.context.AddMessage("assistant", reply)
+			return &dto.AssistantMessageResponse{
+				SessionID: conversation.session.ID.String(),
+				Reply:     reply,
+				ToolsUsed: toolsUsed,
+			}, nil
+		}
+
+		message := domain.Message{
+			ID:        uuid.New(),
+			Role:      "assistant",
+			Content:   decision.Reply,
+			Timestamp: time.Now(),
+		}
+		for _, call := range decision.ToolCalls {
+			message.ToolCalls = append(message.ToolCalls, s.execute(conversation.session, tools, call))
+			toolsUsed = append(toolsUsed, call.ToolName)
+		}
+		conversation.context.History = append(conversation.context.History, message)
+	}
+
+	s.logger.Warn("Assistente excedeu o limite de rodadas de ferramentas",
+		zap.String("session_id", conversation.session.ID.String()),
+		zap.Strings("tools_used", toolsUsed))
+
+	conversation.context.AddMessage("assistant", assistantFallbackReply)
+	return &dto.AssistantMessageResponse{
+		SessionID: conversation.session.ID.String(),
+		Reply:     assistantFallbackReply,
+		ToolsUsed: toolsUsed,
+	}, nil
+}
+
+// conversation obtém a conversa do chat; chat expirado ou vinculado a outro usuário recomeça
+func (s *AssistantService) conversation(tenantID, userID uuid.UUID, channel, externalID string) *assistantConversation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.conversations) >= maxAssistantConversations {
+		for key, conversation := range s.conversations {
+			if conversation.session.IsExpired() {
+				delete(s.conversations, key)
+			}
+		}
+	}
+
+	key := assistantKey{channel: channel, externalID: externalID}
+	conversation, ok := s.conversations[key]
+	if ok && !conversation.session.IsExpired() &&
+		conversation.session.TenantID == tenantID && conversation.session.UserID == userID {
+		return conversation
+	}
+
+	session := domain.NewMCPSession(tenantID, userID, channel, externalID)
+	conversation = &assistantConversation{
+		session: session,
+		context: domain.NewConversationContext(session.ID),
+	}
+	s.conversations[key] = conversation
+
+	s.logger.Info("Sessão do assistente criada",
+		zap.String("session_id", session.ID.String()),
+		zap.String("channel", channel),
+		zap.String("tenant_id", tenantID.String()))
+
+	return conversation
+}
+
+// tools ferramentas do assistente registradas
+func (s *AssistantService) tools() []*domain.Tool {
+	tools := make([]*domain.Tool, 0, len(domain.AssistantTools))
+	for _, name := range domain.AssistantTools {
+		if tool, err := s.registry.GetTool(name); err == nil {
+			tools = append(tools, tool)
+		}
+	}
+	return tools
+}
+
+// execute executa a ferramenta escolhida pelo modelo no tenant da sessão. Falhas vão para o
+// resultado, para o modelo explicar ao usuário em vez de interromper a conversa.
+func (s *AssistantService) execute(session *domain.MCPSession, tools []*domain.Tool, call domain.ToolCall) domain.ToolCall {
+	call.ExecutedAt = time.Now()
+	session.IncrementCommandCount()
+
+	var tool *domain.Tool
+	for _, offered := range tools {
+		if offered.Name == call.ToolName {
+			tool = offered
+		}
+	}
+	if tool == nil {
+		call.Error = fmt.Sprintf("ferramenta %s não disponível", call.ToolName)
+		return call
+	}
+
+	// Cópia: os parâmetros do modelo ficam no histórico sem o tenant
+	params := make(map[string]interface{}, len(call.Parameters)+1)
+	for name, value := range call.Parameters {
+		params[name] = value
+	}
+	params[domain.ToolParamTenantID] = session.TenantID.String()
+
+	result, err := tool.Execute(params)
+	if err != nil {
+		s.logger.Warn("Falha ao executar ferramenta do assistente",
+			zap.String("session_id", session.ID.String()),
+			zap.String("tool", call.ToolName),
+			zap.Error(err))
+		call.Error = err.Error()
+		return call
+	}
+
+	call.Result = result
+	return call
+}
+
+// recentHistory janela recente do histórico, começando sempre por uma mensagem do usuário
+func recentHistory(conversation *domain.ConversationContext) []domain.Message {
+	history := conversation.GetRecentHistory(domain.AssistantHistoryWindow)
+	for len(history) > 0 && history[0].Role != "user" {
+		history = history[1:]
+	}
+	return history
+}
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// AssistantMaxToolRounds rodadas de ferramentas por mensagem antes de desistir da resposta
+	AssistantMaxToolRounds = 4
+	// AssistantHistoryWindow mensagens anteriores enviadas ao modelo como contexto
+	AssistantHistoryWindow = 20
+
+	// ToolParamTenantID parâmetro reservado com o tenant da sessão, injetado pelo assistente e
+	// nunca escolhido pelo modelo
+	ToolParamTenantID = "tenant_id"
+)
+
+// AssistantTools ferramentas somente leitura oferecidas ao assistente nos bots de mensagem
+var AssistantTools = []string{"process_search", "process_details"}
+
+var (
+	// ErrProcessNotFound processo inexistente ou de outro tenant
+	ErrProcessNotFound = errors.New("process not found")
+	// ErrAssistantUnavailable modelo ou serviço consultado indisponível
+	ErrAssistantUnavailable = errors.New("assistant unavailable")
+)
+
+// AssistantTurn contexto enviado ao modelo a cada rodada: histórico recente (com os resultados
+// das ferramentas já executadas) e as ferramentas disponíveis
+type AssistantTurn struct {
+	Channel string
+	History []Message
+	Tools   []*Tool
+}
+
+// AssistantDecision próxima ação escolhida pelo modelo: chamar ferramentas ou responder
+type AssistantDecision struct {
+	Reply     string
+	ToolCalls []ToolCall
+}
+
+// AssistantModel interface do modelo de linguagem que conduz a conversa
+type AssistantModel interface {
+	Decide(ctx context.Context, turn *AssistantTurn) (*AssistantDecision, error)
+}
+
+// ProcessRecord processo do tenant retornado pelo process-service
+type ProcessRecord struct {
+	ID                string     `json:"id"`
+	Number            string     `json:"number"`
+	Title             string     `json:"title"`
+	Status            string     `json:"status"`
+	CourtID           string     `json:"court_id"`
+	LastMovementAt    *time.Time `json:"last_movement_at,omitempty"`
+	LastMovementTitle *string    `json:"last_movement_title,omitempty"`
+	LastMovementDate  *time.Time `json:"last_movement_date,omitempty"`
+}
+
+// ProcessQuery filtros da busca de processos
+type ProcessQuery struct {
+	Search string
+	Status string
+	Limit  int
+}
+
+// ProcessCatalog interface para consulta dos processos do tenant
+type ProcessCatalog interface {
+	Search(ctx context.Context, tenantID uuid.UUID, query ProcessQuery) ([]*ProcessRecord, error)
+	// FindByNumber busca pelo número CNJ formatado; ErrProcessNotFound quando não é do tenant
+	FindByNumber(ctx context.Context, tenantID uuid.UUID, number string) (*ProcessRecord, error)
+}
+
+// cnjNumberPattern número CNJ com ou sem pontuação, não colado a outros dígitos
+var cnjNumberPattern = regexp.MustCompile(`(?:^|\D)(\d{7})-?(\d{2})\.?(\d{4})\.?(\d)\.?(\d{2})\.?(\d{4})(?:\D|$)`)
+
+// FindCNJNumber encontra o primeiro número CNJ no texto e o retorna formatado
+// (NNNNNNN-DD.AAAA.J.TR.OOOO)
+func FindCNJNumber(text string) (string, bool) {
+	m := cnjNumberPattern.FindStringSubmatch(text)
+	if m == nil {
+		return "", false
+	}
+	return fmt.Sprintf("%s-%s.%s.%s.%s.%s", m[1], m[2], m[3], m[4], m[5], m[6]), true
+}
+
+// TenantFromToolParams recupera o tenant injetado nos parâmetros da ferramenta
+func TenantFromToolParams(params map[string]interface{}) (uuid.UUID, error) {
+	value, _ := params[ToolParamTenantID].(string)
+	tenantID, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("tenant da sessão ausente nos parâmetros da ferramenta")
+	}
+	return tenantID, nil
+}
@@ -1,14 +1,17 @@
 package tools
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/direito-lux/mcp-service/internal/domain"
 )
 
-// RegisterProcessTools registra as ferramentas de processos
-func RegisterProcessTools(registry *domain.ToolRegistry) error {
+// RegisterProcessTools registra as ferramentas de processos; busca e detalhes consultam o
+// process-service pelo catálogo, com o tenant injetado em tenant_id
+func RegisterProcessTools(registry *domain.ToolRegistry, processes domain.ProcessCatalog) error {
 	tools := []*domain.Tool{
 		{
 			Name:         "process_search",
@@ -16,15 +19,13 @@ func RegisterProcessTools(registry *domain.ToolRegistry) error {
 			Category:     "process",
 			RequiredPlan: "professional",
 			Parameters: []domain.ToolParameter{
+				{Name: "query", Type: "string", Description: "Texto livre buscado no número, título e descrição", Required: false},
 				{Name: "client_name", Type: "string", Description: "Nome do cliente", Required: false},
 				{Name: "process_number", Type: "string", Description: "Número do processo", Required: false},
 				{Name: "status", Type: "string", Description: "Status do processo", Required: false, 
 					Enum: []string{"active", "archived", "suspended"}},
-				{Name: "date_range", Type: "object", Description: "Intervalo de datas", Required: false},
-				{Name: "court", Type: "string", Description: "Tribunal", Required: false},
-				{Name: "lawyer", Type: "string", Description: "Advogado responsável", Required: false},
 			},
-			Handler: handleProcessSearch,
+			Handler: processSearchHandler(processes),
 		},
 		{
 			Name:         "process_monitor",
@@ -62,13 +63,11 @@ func RegisterProcessTools(registry *domain.ToolRegistry) error {
 			Category:     "process",
 			RequiredPlan: "professional",
 			Parameters: []domain.ToolParameter{
-				{Name: "process_id", Type: "string", Description: "ID ou número do processo", Required: true},
-				{Name: "include_movements", Type: "boolean", Description: "Incluir movimentações", 
-					Required: false, Default: true},
-				{Name: "include_parties", Type: "boolean", Description: "Incluir partes", 
+				{Name: "process_id", Type: "string", Description: "Número CNJ do processo", Required: true},
+				{Name: "include_movements", Type: "boolean", Description: "Incluir a última movimentação", 
 					Required: false, Default: true},
 			},
-			Handler: handleProcessDetails,
+			Handler: processDetailsHandler(processes),
 		},
 		{
 			Name:         "process_update_status",
@@ -95,37 +94,44 @@ func RegisterProcessTools(registry *domain.ToolRegistry) error {
 	return nil
 }
 
-// handleProcessSearch busca processos
-func handleProcessSearch(params map[string]interface{}) (interface{}, error) {
-	// TODO: Implementar integração real com Process Service
-	// Por enquanto, retornar dados mock
-	
-	result := map[string]interface{}{
-		"total": 15,
-		"processes": []map[string]interface{}{
-			{
-				"id":            "proc-123",
-				"number":        "1001234-56.2024.8.26.0100",
-				"client_name":   "João Silva",
-				"status":        "active",
-				"court":         "TJ-SP",
-				"last_movement": "Petição protocolada",
-				"updated_at":    time.Now().Add(-2 * 24 * time.Hour).Format(time.RFC3339),
-			},
-			{
-				"id":            "proc-124",
-				"number":        "2001234-78.2024.8.26.0200",
-				"client_name":   "Maria Santos",
-				"status":        "active",
-				"court":         "TJ-SP",
-				"last_movement": "Audiência agendada",
-				"updated_at":    time.Now().Add(-5 * 24 * time.Hour).Format(time.RFC3339),
-			},
-		},
-		"filters_applied": params,
+// toolLookupTimeout tempo máximo de cada consulta ao process-service feita por uma ferramenta
+const toolLookupTimeout = 10 * time.Second
+
+// processSearchHandler busca processos do tenant por texto livre, cliente, número ou status
+func processSearchHandler(processes domain.ProcessCatalog) func(params map[string]interface{}) (interface{}, error) {
+	return func(params map[string]interface{}) (interface{}, error) {
+		tenantID, err := domain.TenantFromToolParams(params)
+		if err != nil {
+			return nil, err
+		}
+
+		query := domain.ProcessQuery{Limit: 10}
+		for _, name := range []string{"process_number", "client_name", "query"} {
+			if value, ok := params[name].(string); ok && value != "" {
+				query.Search = value
+				break
+			}
+		}
+		if number, ok := domain.FindCNJNumber(query.Search); ok {
+			query.Search = number
+		}
+		if status, ok := params["status"].(string); ok {
+			query.Status = status
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), toolLookupTimeout)
+		defer cancel()
+
+		found, err := processes.Search(ctx, tenantID, query)
+		if err != nil {
+			return nil, err
+		}
+
+		return map[string]interface{}{
+			"total":     len(found),
+			"processes": found,
+		}, nil
 	}
-	
-	return result, nil
 }
 
 // handleProcessMonitor configura monitoramento
@@ -174,70 +180,44 @@ func handleProcessCreate(params map[string]interface{}) (interface{}, error) {
 	return result, nil
 }
 
-// handleProcessDetails obtém detalhes do processo
-func handleProcessDetails(params map[string]interface{}) (interface{}, error) {
-	processID := params["process_id"].(string)
-	includeMovements := true
-	includeParties := true
-	
-	if val, ok := params["include_movements"].(bool); ok {
-		includeMovements = val
-	}
-	if val, ok := params["include_parties"].(bool); ok {
-		includeParties = val
-	}
-	
-	// TODO: Implementar integração real com Process Service
-	
-	result := map[string]interface{}{
-		"process": map[string]interface{}{
-			"id":              processID,
-			"number":          "1001234-56.2024.8.26.0100",
-			"client_name":     "João Silva",
-			"status":          "active",
-			"court":           "TJ-SP",
-			"judge":           "Dr. Carlos Pereira",
-			"value":           150000.00,
-			"subject":         "Indenização por danos morais",
-			"distribution_date": "2024-01-15",
-		},
-	}
-	
-	if includeMovements {
-		result["movements"] = []map[string]interface{}{
-			{
-				"id":          "mov-001",
-				"date":        "2024-06-15",
-				"description": "Petição protocolada",
-				"content":     "Petição inicial protocolada com pedido de tutela de urgência",
-			},
-			{
-				"id":          "mov-002",
-				"date":        "2024-06-10",
-				"description": "Decisão judicial",
-				"content":     "Tutela de urgência deferida parcialmente",
-			},
+// processDetailsHandler obtém o processo do tenant pelo número CNJ
+func processDetailsHandler(processes domain.ProcessCatalog) func(params map[string]interface{}) (interface{}, error) {
+	return func(params map[string]interface{}) (interface{}, error) {
+		tenantID, err := domain.TenantFromToolParams(params)
+		if err != nil {
+			return nil, err
 		}
-	}
-	
-	if includeParties {
-		result["parties"] = []map[string]interface{}{
-			{
-				"type":     "author",
-				"name":     "João Silva",
-				"document": "123.456.789-00",
-				"lawyer":   "Dra. Ana Costa - OAB/SP 123456",
-			},
-			{
-				"type":     "defendant",
-				"name":     "Empresa XYZ Ltda",
-				"document": "12.345.678/0001-90",
-				"lawyer":   "Dr. Pedro Santos - OAB/SP 654321",
-			},
+
+		value, _ := params["process_id"].(string)
+		number, ok := domain.FindCNJNumber(value)
+		if !ok {
+			return nil, fmt.Errorf("informe o número CNJ do processo (NNNNNNN-DD.AAAA.J.TR.OOOO)")
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), toolLookupTimeout)
+		defer cancel()
+
+		process, err := processes.FindByNumber(ctx, tenantID, number)
+		if errors.Is(err, domain.ErrProcessNotFound) {
+			return map[string]interface{}{
+				"found":  false,
+				"number": number,
+			}, nil
+		}
+		if err != nil {
+			return nil, err
 		}
+
+		if include, ok := params["include_movements"].(bool); ok && !include {
+			process.LastMovementTitle = nil
+			process.LastMovementDate = nil
+		}
+
+		return map[string]interface{}{
+			"found":   true,
+			"process": process,
+		}, nil
 	}
-	
-	return result, nil
 }
 
 // handleProcessUpdateStatus atualiza status do processo
@@ -0,0 +1,110 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/mcp-service/internal/domain"
+)
+
+// ProcessServiceClient cliente HTTP do process-service (domain.ProcessCatalog)
+type ProcessServiceClient struct {
+	baseURL string
+	client  *http.Client
+	logger  *zap.Logger
+}
+
+// NewProcessServiceClient cria nova instância do cliente
+func NewProcessServiceClient(baseURL string, timeout time.Duration, logger *zap.Logger) *ProcessServiceClient {
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	return &ProcessServiceClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: timeout},
+		logger:  logger,
+	}
+}
+
+// processListResponse resposta de GET /api/v1/processes
+type processListResponse struct {
+	Data  []*domain.ProcessRecord `json:"data"`
+	Total int                     `json:"total"`
+}
+
+// processResponse resposta de GET /api/v1/processes/number/:number
+type processResponse struct {
+	Data domain.ProcessRecord `json:"data"`
+}
+
+// Search busca os processos do tenant
+func (c *ProcessServiceClient) Search(ctx context.Context, tenantID uuid.UUID, query domain.ProcessQuery) ([]*domain.ProcessRecord, error) {
+	values := url.Values{}
+	if query.Search != "" {
+		values.Set("search", query.Search)
+	}
+	if query.Status != "" {
+		values.Set("status", query.Status)
+	}
+	if query.Limit > 0 {
+		values.Set("limit", strconv.Itoa(query.Limit))
+	}
+
+	var response processListResponse
+	if err := c.get(ctx, tenantID, "/api/v1/processes?"+values.Encode(), &response); err != nil {
+		return nil, fmt.Errorf("erro ao buscar processos: %w", err)
+	}
+	return response.Data, nil
+}
+
+// FindByNumber busca o processo do tenant pelo número CNJ
+func (c *ProcessServiceClient) FindByNumber(ctx context.Context, tenantID uuid.UUID, number string) (*domain.ProcessRecord, error) {
+	var response processResponse
+	path := "/api/v1/processes/number/" + url.PathEscape(number)
+	if err := c.get(ctx, tenantID, path, &response); err != nil {
+		return nil, fmt.Errorf("erro ao buscar processo %s: %w", number, err)
+	}
+	return &response.Data, nil
+}
+
+// get executa a consulta no contexto do tenant
+func (c *ProcessServiceClient) get(ctx context.Context, tenantID uuid.UUID, path string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return fmt.Errorf("erro ao criar requisição: %w", err)
+	}
+	req.Header.Set("X-Tenant-ID", tenantID.String())
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrAssistantUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return domain.ErrProcessNotFound
+	case resp.StatusCode != http.StatusOK:
+		c.logger.Warn("Falha na consulta ao process-service",
+			zap.String("path", path),
+			zap.Int("status_code", resp.StatusCode))
+		return fmt.Errorf("%w: process-service status %d", domain.ErrAssistantUnavailable, resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("erro ao decodificar resposta: %w", err)
+	}
+
+	return nil
+}
@@ -182,6 +182,11 @@ type MCPConfig struct {
 	ToolsEnabled          bool `envconfig:"MCP_TOOLS_ENABLED" default:"true"`
 	ContextMemoryEnabled  bool `envconfig:"MCP_CONTEXT_MEMORY_ENABLED" default:"true"`
 	QuotaEnforcementEnabled bool `envconfig:"MCP_QUOTA_ENFORCEMENT_ENABLED" default:"true"`
+
+	// Assistente dos bots de mensagem: modelo "local" (regras, sem custo) ou "claude"
+	AssistantModel string `envconfig:"MCP_ASSISTANT_MODEL" default:"local"`
+	// Token que o notification-service envia ao encaminhar mensagens; vazio aceita qualquer Bearer
+	AssistantServiceToken string `envconfig:"MCP_ASSISTANT_SERVICE_TOKEN"`
 	
 	// Monitoring
 	MetricsEnabled bool `envconfig:"MCP_METRICS_ENABLED" default:"true"`
@@ -275,6 +280,11 @@ func (c *Config) validate() error {
 		return fmt.Errorf("modelo Claude inválido: %s", c.Claude.Model)
 	}
 
+	// Validar modelo do assistente
+	if c.MCP.AssistantModel != "local" && c.MCP.AssistantModel != "claude" {
+		return fmt.Errorf("modelo do assistente inválido: %s", c.MCP.AssistantModel)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,18 @@
+package dto
+
+// AssistantMessageRequest mensagem de um usuário vinculado encaminhada pelo notification-service
+type AssistantMessageRequest struct {
+	TenantID   string `json:"tenant_id" binding:"required,uuid"`
+	UserID     string `json:"user_id" binding:"required,uuid"`
+	Channel    string `json:"channel" binding:"required,oneof=whatsapp telegram"`
+	ExternalID string `json:"external_id" binding:"required"` // telefone do WhatsApp ou chat do Telegram
+	Text       string `json:"text" binding:"required,max=4096"`
+}
+
+// AssistantMessageResponse resposta do assistente em Markdown leve (**negrito**, listas com "-");
+// o canal de origem converte para a sua formatação e divide em mensagens
+type AssistantMessageResponse struct {
+	SessionID string   `json:"session_id"`
+	Reply     string   `json:"reply"`
+	ToolsUsed []string `json:"tools_used,omitempty"`
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/mcp-service/internal/application/services"
+	"github.com/direito-lux/mcp-service/internal/domain"
+	"github.com/direito-lux/mcp-service/internal/infrastructure/http/dto"
+)
+
+// AssistantMessage responde a mensagem encaminhada pelo notification-service (WhatsApp e
+// Telegram). Apenas o notification-service pode chamar: sem serviceToken configurado, a rota
+// responde 503, já que o tenant e o usuário do corpo não seriam verificáveis.
+func AssistantMessage(assistant *services.AssistantService, serviceToken string, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if serviceToken == "" {
+			logger.Warn("Mensagem do assistente recusada: MCP_ASSISTANT_SERVICE_TOKEN não configurado")
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"error": "Assistant service token not configured",
+			})
+			return
+		}
+
+		received := c.GetHeader("Authorization")
+		if subtle.ConstantTimeCompare([]byte(received), []byte("Bearer "+serviceToken)) != 1 {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Invalid service token",
+			})
+			return
+		}
+
+		var req dto.AssistantMessageRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid request format",
+				"details": err.Error(),
+			})
+			return
+		}
+
+		response, err := assistant.HandleMessage(c.Request.Context(), req)
+		if err != nil {
+			logger.Error("Falha ao responder mensagem do assistente",
+				zap.String("channel", req.Channel),
+				zap.String("tenant_id", req.TenantID),
+				zap.Error(err))
+
+			status := http.StatusInternalServerError
+			if errors.Is(err, domain.ErrAssistantUnavailable) {
+				status = http.StatusServiceUnavailable
+			}
+			c.JSON(status, gin.H{
+				"error": "Assistant unavailable",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 
+	"github.com/direito-lux/mcp-service/internal/application/services"
 	"github.com/direito-lux/mcp-service/internal/infrastructure/config"
 	"github.com/direito-lux/mcp-service/internal/infrastructure/metrics"
 	"github.com/direito-lux/mcp-service/internal/infrastructure/http/middleware"
@@ -21,10 +22,11 @@ type Server struct {
 	metrics    *metrics.Metrics
 	server     *http.Server
 	router     *gin.Engine
+	assistant  *services.AssistantService
 }
 
 // NewServer cria nova instância do servidor HTTP
-func NewServer(cfg *config.Config, logger *zap.Logger, metrics *metrics.Metrics) *Server {
+func NewServer(cfg *config.Config, logger *zap.Logger, metrics *metrics.Metrics, assistant *services.AssistantService) *Server {
 	// Configurar modo do Gin
 	if cfg.IsProduction() {
 		gin.SetMode(gin.ReleaseMode)
@@ -35,10 +37,11 @@ func NewServer(cfg *config.Config, logger *zap.Logger, metrics *metrics.Metrics)
 	router := gin.New()
 
 	server := &Server{
-		config:  cfg,
-		logger:  logger,
-		metrics: metrics,
-		router:  router,
+		config:    cfg,
+		logger:    logger,
+		metrics:   metrics,
+		router:    router,
+		assistant: assistant,
 	}
 
 	// Configurar middlewares
@@ -131,6 +134,9 @@ func (s *Server) setupRoutes() {
 			conversations.DELETE("/:id", handlers.DeleteConversation())
 		}
 
+		// Assistente dos bots do notification-service (WhatsApp e Telegram)
+		api.POST("/assistant/messages", handlers.AssistantMessage(s.assistant, s.config.MCP.AssistantServiceToken, s.logger))
+
 		// Bot Integrations
 		bots := api.Group("/bots")
 		{
@@ -0,0 +1,286 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/mcp-service/internal/domain"
+	"github.com/direito-lux/mcp-service/internal/infrastructure/config"
+)
+
+// claudeSystemPrompt instruções do assistente; a formatação é convertida pelo canal de origem
+const claudeSystemPrompt = `Você é o assistente do Direito Lux, plataforma de acompanhamento de processos jurídicos, conversando pelo %s com um advogado do escritório.
+Responda sempre em português, de forma breve e objetiva.
+Use as ferramentas para consultar os processos do escritório e responda apenas com os dados retornados por elas; nunca invente números, partes, prazos ou movimentações.
+Se faltar informação para a consulta (por exemplo, o número do processo), peça ao usuário.
+Formatação: apenas **negrito** e listas com "- ". Não use títulos, tabelas nem links.`
+
+// ClaudeModel modelo da API Messages da Anthropic com uso de ferramentas
+type ClaudeModel struct {
+	config config.ClaudeConfig
+	client *http.Client
+	logger *zap.Logger
+}
+
+// NewClaudeModel cria nova instância do modelo
+func NewClaudeModel(cfg config.ClaudeConfig, logger *zap.Logger) *ClaudeModel {
+	return &ClaudeModel{
+		config: cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+		logger: logger,
+	}
+}
+
+// claudeRequest corpo de POST /v1/messages
+type claudeRequest struct {
+	Model     string       `json:"model"`
+	MaxTokens int          `json:"max_tokens"`
+	System    string       `json:"system"`
+	Messages  []claudeTurn `json:"messages"`
+	Tools     []claudeTool `json:"tools,omitempty"`
+}
+
+// claudeTurn mensagem do usuário ou do assistente
+type claudeTurn struct {
+	Role    string        `json:"role"`
+	Content []claudeBlock `json:"content"`
+}
+
+// claudeBlock bloco de conteúdo: text, tool_use ou tool_result
+type claudeBlock struct {
+	Type      string          `json:"type"`
+	Text      string          `json:"text,omitempty"`
+	ID        string          `json:"id,omitempty"`
+	Name      string          `json:"name,omitempty"`
+	Input     json.RawMessage `json:"input,omitempty"`
+	ToolUseID string          `json:"tool_use_id,omitempty"`
+	Content   string          `json:"content,omitempty"`
+	IsError   bool            `json:"is_error,omitempty"`
+}
+
+// claudeTool definição de ferramenta com JSON Schema dos parâmetros
+type claudeTool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	InputSchema map[string]interface{} `json:"input_schema"`
+}
+
+// claudeResponse resposta de POST /v1/messages
+type claudeResponse struct {
+	Content    []claudeBlock `json:"content"`
+	StopReason string        `json:"stop_reason"`
+	Error      *struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// Decide envia o histórico e as ferramentas ao Claude e converte a resposta em decisão
+func (m *ClaudeModel) Decide(ctx context.Context, turn *domain.AssistantTurn) (*domain.AssistantDecision, error) {
+	request := claudeRequest{
+		Model:     m.config.Model,
+		MaxTokens: m.config.MaxTokens,
+		System:    fmt.Sprintf(claudeSystemPrompt, channelName(turn.Channel)),
+		Messages:  claudeMessages(turn.History),
+	}
+	for _, tool := range turn.Tools {
+		request.Tools = append(request.Tools, claudeToolDefinition(tool))
+	}
+
+	response, err := m.send(ctx, &request)
+	if err != nil {
+		return nil, err
+	}
+
+	decision := &domain.AssistantDecision{}
+	var reply []string
+	for _, block := range response.Content {
+		switch block.Type {
+		case "text":
+			reply = append(reply, block.Text)
+		case "tool_use":
+			params := map[string]interface{}{}
+			if len(block.Input) > 0 {
+				if err := json.Unmarshal(block.Input, &params); err != nil {
+					return nil, fmt.Errorf("erro ao decodificar parâmetros de %s: %w", block.Name, err)
+				}
+			}
+			decision.ToolCalls = append(decision.ToolCalls, domain.ToolCall{
+				ID:         block.ID,
+				ToolName:   block.Name,
+				Parameters: params,
+			})
+		}
+	}
+	decision.Reply = strings.Join(reply, "\n\n")
+
+	return decision, nil
+}
+
+// send chama a API, repetindo em limite de taxa, sobrecarga e erros do servidor
+func (m *ClaudeModel) send(ctx context.Context, request *claudeRequest) (*claudeResponse, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao serializar requisição: %w", err)
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= m.config.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(time.Duration(attempt) * time.Second):
+			}
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(m.config.BaseURL, "/")+"/v1/messages", bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("erro ao criar requisição: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("x-api-key", m.config.APIKey)
+		req.Header.Set("anthropic-version", m.config.Version)
+
+		resp, err := m.client.Do(req)
+		if err != nil {
+			lastErr = fmt.Errorf("%w: %v", domain.ErrAssistantUnavailable, err)
+			continue
+		}
+
+		var response claudeResponse
+		decodeErr := json.NewDecoder(resp.Body).Decode(&response)
+		resp.Body.Close()
+
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			lastErr = fmt.Errorf("%w: API do Claude retornou status %d", domain.ErrAssistantUnavailable, resp.StatusCode)
+			m.logger.Warn("Falha temporária na API do Claude",
+				zap.Int("status_code", resp.StatusCode),
+				zap.Int("attempt", attempt+1))
+			continue
+		}
+		if resp.StatusCode != http.StatusOK {
+			if response.Error != nil {
+				return nil, fmt.Errorf("erro da API do Claude: %s (%s)", response.Error.Message, response.Error.Type)
+			}
+			return nil, fmt.Errorf("API do Claude retornou status %d", resp.StatusCode)
+		}
+		if decodeErr != nil {
+			return nil, fmt.Errorf("erro ao decodificar resposta: %w", decodeErr)
+		}
+
+		return &response, nil
+	}
+
+	return nil, lastErr
+}
+
+// claudeMessages converte o histórico: chamadas de ferramenta viram blocos tool_use do assistente
+// seguidos dos tool_result do usuário. Mensagens consecutivas do mesmo papel são unidas.
+func claudeMessages(history []domain.Message) []claudeTurn {
+	var turns []claudeTurn
+	add := func(role string, blocks ...claudeBlock) {
+		if n := len(turns); n > 0 && turns[n-1].Role == role {
+			turns[n-1].Content = append(turns[n-1].Content, blocks...)
+			return
+		}
+		turns = append(turns, claudeTurn{Role: role, Content: blocks})
+	}
+
+	for _, message := range history {
+		role := "user"
+		if message.Role == "assistant" {
+			role = "assistant"
+		}
+
+		var blocks []claudeBlock
+		if message.Content != "" {
+			blocks = append(blocks, claudeBlock{Type: "text", Text: message.Content})
+		}
+		var results []claudeBlock
+		for _, call := range message.ToolCalls {
+			input, _ := json.Marshal(call.Parameters)
+			if call.Parameters == nil {
+				input = []byte("{}")
+			}
+			blocks = append(blocks, claudeBlock{Type: "tool_use", ID: call.ID, Name: call.ToolName, Input: input})
+			results = append(results, claudeToolResult(call))
+		}
+
+		if len(blocks) > 0 {
+			add(role, blocks...)
+		}
+		if len(results) > 0 {
+			add("user", results...)
+		}
+	}
+
+	return turns
+}
+
+// claudeToolResult resultado da ferramenta em JSON, ou a mensagem de erro
+func claudeToolResult(call domain.ToolCall) claudeBlock {
+	block := claudeBlock{Type: "tool_result", ToolUseID: call.ID}
+	if call.Error != "" {
+		block.Content = call.Error
+		block.IsError = true
+		return block
+	}
+
+	content, err := json.Marshal(call.Result)
+	if err != nil {
+		block.Content = "resultado inválido"
+		block.IsError = true
+		return block
+	}
+	block.Content = string(content)
+	return block
+}
+
+// claudeToolDefinition converte os parâmetros da ferramenta em JSON Schema
+func claudeToolDefinition(tool *domain.Tool) claudeTool {
+	properties := map[string]interface{}{}
+	required := []string{}
+	for _, param := range tool.Parameters {
+		property := map[string]interface{}{
+			"type":        param.Type,
+			"description": param.Description,
+		}
+		if len(param.Enum) > 0 {
+			property["enum"] = param.Enum
+		}
+		properties[param.Name] = property
+		if param.Required {
+			required = append(required, param.Name)
+		}
+	}
+
+	return claudeTool{
+		Name:        tool.Name,
+		Description: tool.Description,
+		InputSchema: map[string]interface{}{
+			"type":       "object",
+			"properties": properties,
+			"required":   required,
+		},
+	}
+}
+
+// channelName nome do canal no prompt
+func channelName(channel string) string {
+	switch channel {
+	case "whatsapp":
+		return "WhatsApp"
+	case "telegram":
+		return "Telegram"
+	default:
+		return channel
+	}
+}
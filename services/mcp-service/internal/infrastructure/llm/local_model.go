@@ -0,0 +1,210 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/direito-lux/mcp-service/internal/domain"
+)
+
+const (
+	// localMaxListed processos listados na resposta da busca
+	localMaxListed = 5
+)
+
+// localSearchMarkers expressões após as quais vem o termo buscado ("processos do cliente Maria")
+var localSearchMarkers = []string{"cliente ", "sobre ", "buscar ", "busque ", "procurar ", "procure ", "pesquisar "}
+
+// localSearchKeywords palavras que indicam uma busca de processos
+var localSearchKeywords = []string{"processo", "cliente", "buscar", "busque", "procur", "pesquis", "listar", "ativos", "suspensos", "arquivados"}
+
+// localStatusKeywords filtro de status reconhecido na pergunta
+var localStatusKeywords = map[string]string{
+	"ativos":     "active",
+	"suspensos":  "suspended",
+	"arquivados": "archived",
+}
+
+// LocalModel modelo baseado em regras, sem chamadas externas: número CNJ consulta o processo,
+// pedidos de busca consultam a lista. Usado em desenvolvimento, testes e quando não há chave da
+// API do Claude.
+type LocalModel struct{}
+
+// NewLocalModel cria nova instância do modelo local
+func NewLocalModel() *LocalModel {
+	return &LocalModel{}
+}
+
+// Decide escolhe a ferramenta pela última mensagem do usuário ou resume o resultado dela
+func (m *LocalModel) Decide(ctx context.Context, turn *domain.AssistantTurn) (*domain.AssistantDecision, error) {
+	if len(turn.History) == 0 {
+		return &domain.AssistantDecision{Reply: localHelp}, nil
+	}
+
+	last := turn.History[len(turn.History)-1]
+	if last.Role == "assistant" && len(last.ToolCalls) > 0 {
+		return &domain.AssistantDecision{Reply: summarizeToolCalls(last.ToolCalls)}, nil
+	}
+
+	text := strings.ToLower(last.Content)
+
+	if number, ok := domain.FindCNJNumber(text); ok && offers(turn.Tools, "process_details") {
+		return toolDecision("process_details", map[string]interface{}{"process_id": number}), nil
+	}
+
+	if containsAny(text, localSearchKeywords) && offers(turn.Tools, "process_search") {
+		params := map[string]interface{}{}
+		if term := searchTerm(text); term != "" {
+			params["query"] = term
+		}
+		for keyword, status := range localStatusKeywords {
+			if strings.Contains(text, keyword) {
+				params["status"] = status
+			}
+		}
+		return toolDecision("process_search", params), nil
+	}
+
+	return &domain.AssistantDecision{Reply: localHelp}, nil
+}
+
+// localHelp resposta para mensagens que o modelo local não reconhece
+const localHelp = "Posso consultar os processos do escritório para você.\n\n" +
+	"- Envie o **número CNJ** para ver o status e a última movimentação\n" +
+	"- Peça, por exemplo, \"processos do cliente Maria\" ou \"processos suspensos\""
+
+// toolDecision decisão de chamar uma única ferramenta
+func toolDecision(name string, params map[string]interface{}) *domain.AssistantDecision {
+	return &domain.AssistantDecision{ToolCalls: []domain.ToolCall{{
+		ID:         "local_" + strings.ReplaceAll(uuid.New().String(), "-", ""),
+		ToolName:   name,
+		Parameters: params,
+	}}}
+}
+
+// offers verifica se a ferramenta está disponível na rodada
+func offers(tools []*domain.Tool, name string) bool {
+	for _, tool := range tools {
+		if tool.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// containsAny verifica se o texto contém alguma das palavras
+func containsAny(text string, words []string) bool {
+	for _, word := range words {
+		if strings.Contains(text, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// searchTerm extrai o termo após a última expressão de busca ("... do cliente Maria Silva?")
+func searchTerm(text string) string {
+	start := -1
+	for _, marker := range localSearchMarkers {
+		if i := strings.LastIndex(text, marker); i >= 0 && i+len(marker) > start {
+			start = i + len(marker)
+		}
+	}
+	if start < 0 {
+		return ""
+	}
+	return strings.Trim(text[start:], " ?!.,;:\"'")
+}
+
+// localToolResult campos dos resultados de process_search e process_details
+type localToolResult struct {
+	Total     int                     `json:"total"`
+	Processes []*domain.ProcessRecord `json:"processes"`
+	Found     bool                    `json:"found"`
+	Number    string                  `json:"number"`
+	Process   *domain.ProcessRecord   `json:"process"`
+}
+
+// summarizeToolCalls resposta com o resultado das ferramentas chamadas
+func summarizeToolCalls(calls []domain.ToolCall) string {
+	var parts []string
+	for _, call := range calls {
+		if call.Error != "" {
+			parts = append(parts, "Não consegui consultar os processos agora. Tente novamente em instantes.")
+			continue
+		}
+
+		// Ida e volta em JSON: o resultado tem a mesma forma que o modelo remoto recebe
+		var result localToolResult
+		if data, err := json.Marshal(call.Result); err == nil {
+			json.Unmarshal(data, &result)
+		}
+
+		switch call.ToolName {
+		case "process_details":
+			parts = append(parts, summarizeProcess(result))
+		case "process_search":
+			parts = append(parts, summarizeSearch(result))
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// summarizeProcess detalhes de um processo
+func summarizeProcess(result localToolResult) string {
+	if !result.Found || result.Process == nil {
+		return fmt.Sprintf("Não encontrei o processo %s entre os processos monitorados do escritório.", result.Number)
+	}
+
+	p := result.Process
+	var text strings.Builder
+	fmt.Fprintf(&text, "**%s**\n%s\n\n**Status:** %s", p.Title, p.Number, statusLabel(p.Status))
+	if p.LastMovementTitle != nil {
+		text.WriteString("\n**Última movimentação")
+		if p.LastMovementDate != nil {
+			fmt.Fprintf(&text, " (%s)", p.LastMovementDate.Format("02/01/2006"))
+		}
+		fmt.Fprintf(&text, ":** %s", *p.LastMovementTitle)
+	}
+	return text.String()
+}
+
+// summarizeSearch lista dos processos encontrados
+func summarizeSearch(result localToolResult) string {
+	if len(result.Processes) == 0 {
+		return "Não encontrei processos com esses critérios."
+	}
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "Encontrei **%d** processo(s):\n", len(result.Processes))
+	for i, p := range result.Processes {
+		if i == localMaxListed {
+			fmt.Fprintf(&text, "\n… e mais %d. Envie o número de um deles para ver os detalhes.", len(result.Processes)-localMaxListed)
+			break
+		}
+		fmt.Fprintf(&text, "\n- **%s** — %s (%s)", p.Number, p.Title, statusLabel(p.Status))
+	}
+	return text.String()
+}
+
+// statusLabel status do processo em português
+func statusLabel(status string) string {
+	switch status {
+	case "active":
+		return "ativo"
+	case "suspended":
+		return "suspenso"
+	case "finished":
+		return "concluído"
+	case "archived":
+		return "arquivado"
+	case "canceled":
+		return "cancelado"
+	default:
+		return status
+	}
+}
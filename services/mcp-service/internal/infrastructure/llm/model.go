@@ -0,0 +1,17 @@
+// Package llm modelos de linguagem que conduzem as conversas do assistente
+package llm
+
+import (
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/mcp-service/internal/domain"
+	"github.com/direito-lux/mcp-service/internal/infrastructure/config"
+)
+
+// NewModel cria o modelo do assistente configurado em MCP_ASSISTANT_MODEL
+func NewModel(cfg *config.Config, logger *zap.Logger) domain.AssistantModel {
+	if cfg.MCP.AssistantModel == "claude" {
+		return NewClaudeModel(cfg.Claude, logger)
+	}
+	return NewLocalModel()
+}
@@ -4,9 +4,6 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -17,7 +14,10 @@ import (
 
 	"github.com/direito-lux/notification-service/internal/application/services"
 	"github.com/direito-lux/notification-service/internal/domain"
+	"github.com/direito-lux/notification-service/internal/infrastructure/clients"
 	"github.com/direito-lux/notification-service/internal/infrastructure/config"
+	"github.com/direito-lux/notification-service/internal/infrastructure/events"
+	"github.com/direito-lux/notification-service/internal/infrastructure/metrics"
 	httpinfra "github.com/direito-lux/notification-service/internal/infrastructure/http"
 	"github.com/direito-lux/notification-service/internal/infrastructure/providers"
 	"github.com/direito-lux/notification-service/internal/infrastructure/repository"
@@ -37,20 +37,62 @@ func main() {
 		// Repositories
 		fx.Provide(NewNotificationRepository),
 		fx.Provide(NewTemplateRepository),
+		fx.Provide(NewTemplateVersionRepository),
 		fx.Provide(NewPreferenceRepository),
+		fx.Provide(NewDeliveryHistoryRepository),
+		fx.Provide(NewSuppressionRepository),
+		fx.Provide(NewDeliverySettingsRepository),
+		fx.Provide(NewPushSubscriptionRepository),
+		fx.Provide(NewWhatsAppSessionRepository),
+		fx.Provide(NewWhatsAppTemplateRepository),
+		fx.Provide(NewTelegramLinkRepository),
+		fx.Provide(NewWhatsAppLinkRepository),
+		fx.Provide(NewProcessWatcherRepository),
+		fx.Provide(NewMovementAlertRepository),
+		fx.Provide(NewConsentRepository),
+		fx.Provide(NewEmailSenderDomainRepository),
+		fx.Provide(NewWebhookSubscriptionRepository),
+		fx.Provide(NewWebhookDeliveryRepository),
 		
 		// Providers
 		fx.Provide(NewEmailProvider),
 		fx.Provide(NewWhatsAppProvider),
 		fx.Provide(NewTelegramProvider),
+		fx.Provide(NewSMSGateway),
+		fx.Provide(NewSMSProvider),
+		fx.Provide(NewPushProvider),
 		fx.Provide(NewProviderMap),
 		
-		// Queue (implementação simples em memória por enquanto)
-		fx.Provide(NewInMemoryQueue),
+		// Queue
+		fx.Provide(NewNotificationQueue),
+		fx.Provide(NewDispatchLimiter),
+		fx.Provide(NewFallbackPolicies),
+		
+		// Metrics
+		fx.Provide(metrics.NewMetrics),
 		
 		// Services
+		fx.Provide(NewFallbackService),
+		fx.Provide(NewDeliveryWindowService),
+		fx.Provide(NewDigestService),
+		fx.Provide(NewEngagementTracker),
+		fx.Provide(NewConsentService),
+		fx.Provide(NewChannelCosts),
+		fx.Provide(NewEmailSenderDomainService),
+		fx.Provide(NewWhatsAppTemplateCatalog),
+		fx.Provide(NewWhatsAppTemplateService),
+		fx.Provide(NewProcessDirectory),
+		fx.Provide(NewConversationalAssistant),
+		fx.Provide(NewTelegramBotService),
+		fx.Provide(NewWhatsAppAssistantService),
 		fx.Provide(NewNotificationService),
 		fx.Provide(NewTemplateService),
+		fx.Provide(NewDeliveryStatusService),
+		fx.Provide(NewNotificationWorkerPool),
+		fx.Provide(NewMovementAlertService),
+		fx.Provide(NewMovementConsumer),
+		fx.Provide(NewWebhookService),
+		fx.Provide(NewWebhookEventConsumer),
 		
 		// HTTP Server
 		fx.Provide(NewGinRouter),
@@ -58,8 +100,15 @@ func main() {
 		
 		// Lifecycle
 		fx.Invoke(StartHTTPServer),
+		fx.Invoke(StopEmailProvider),
+		fx.Invoke(StartNotificationWorkers),
+		fx.Invoke(StartDigestService),
+		fx.Invoke(StartWhatsAppTemplateSync),
+		fx.Invoke(StartMovementConsumer),
+		fx.Invoke(StartWebhooks),
 	)
 
+	// app.Run trata SIGINT/SIGTERM e executa os hooks de parada (HTTP e workers da fila)
 	app.Run()
 }
 
@@ -112,31 +161,109 @@ func NewTemplateRepository(db *sqlx.DB, logger *zap.Logger) domain.NotificationT
 	return repository.NewPostgresTemplateRepository(db, logger)
 }
 
+// NewTemplateVersionRepository cria repositório de versões de templates
+func NewTemplateVersionRepository(db *sqlx.DB, logger *zap.Logger) domain.TemplateVersionRepository {
+	return repository.NewPostgresTemplateVersionRepository(db, logger)
+}
+
 // NewPreferenceRepository cria repositório de preferências
 func NewPreferenceRepository(db *sqlx.DB, logger *zap.Logger) domain.NotificationPreferenceRepository {
 	return repository.NewPostgresPreferenceRepository(db, logger)
 }
 
+// NewDeliveryHistoryRepository cria repositório do histórico de entrega
+func NewDeliveryHistoryRepository(db *sqlx.DB, logger *zap.Logger) domain.DeliveryHistoryRepository {
+	return repository.NewPostgresDeliveryHistoryRepository(db, logger)
+}
+
+// NewSuppressionRepository cria repositório da lista de supressão
+func NewSuppressionRepository(db *sqlx.DB, logger *zap.Logger) domain.SuppressionRepository {
+	return repository.NewPostgresSuppressionRepository(db, logger)
+}
+
+// NewDeliverySettingsRepository cria repositório de janelas de entrega
+func NewDeliverySettingsRepository(db *sqlx.DB, logger *zap.Logger) domain.DeliverySettingsRepository {
+	return repository.NewPostgresDeliverySettingsRepository(db, logger)
+}
+
+// NewPushSubscriptionRepository cria repositório de inscrições Web Push
+func NewPushSubscriptionRepository(db *sqlx.DB, logger *zap.Logger) domain.PushSubscriptionRepository {
+	return repository.NewPostgresPushSubscriptionRepository(db, logger)
+}
+
+// NewWhatsAppSessionRepository cria repositório da janela de atendimento do WhatsApp
+func NewWhatsAppSessionRepository(db *sqlx.DB, logger *zap.Logger) domain.WhatsAppSessionRepository {
+	return repository.NewPostgresWhatsAppSessionRepository(db, logger)
+}
+
+// NewWhatsAppTemplateRepository cria repositório dos templates aprovados do WhatsApp
+func NewWhatsAppTemplateRepository(db *sqlx.DB, logger *zap.Logger) domain.WhatsAppTemplateRepository {
+	return repository.NewPostgresWhatsAppTemplateRepository(db, logger)
+}
+
+// NewTelegramLinkRepository cria repositório de vínculos do bot do Telegram
+func NewTelegramLinkRepository(db *sqlx.DB, logger *zap.Logger) domain.TelegramLinkRepository {
+	return repository.NewPostgresTelegramLinkRepository(db, logger)
+}
+
+// NewWhatsAppLinkRepository cria repositório de vínculos dos contatos do WhatsApp
+func NewWhatsAppLinkRepository(db *sqlx.DB, logger *zap.Logger) domain.WhatsAppLinkRepository {
+	return repository.NewPostgresWhatsAppLinkRepository(db, logger)
+}
+
+// NewProcessWatcherRepository cria repositório dos destinatários dos alertas de movimentação
+func NewProcessWatcherRepository(db *sqlx.DB, logger *zap.Logger) domain.ProcessWatcherRepository {
+	return repository.NewPostgresProcessWatcherRepository(db, logger)
+}
+
+// NewMovementAlertRepository cria repositório de deduplicação dos alertas de movimentação
+func NewMovementAlertRepository(db *sqlx.DB, logger *zap.Logger) domain.MovementAlertRepository {
+	return repository.NewPostgresMovementAlertRepository(db, logger)
+}
+
+// NewConsentRepository cria repositório do histórico de consentimento
+func NewConsentRepository(db *sqlx.DB, logger *zap.Logger) domain.ConsentRepository {
+	return repository.NewPostgresConsentRepository(db, logger)
+}
+
+// NewEmailSenderDomainRepository cria repositório dos remetentes com domínio próprio dos tenants
+func NewEmailSenderDomainRepository(db *sqlx.DB, logger *zap.Logger) domain.EmailSenderDomainRepository {
+	return repository.NewPostgresEmailSenderDomainRepository(db, logger)
+}
+
+// NewWebhookSubscriptionRepository cria repositório das inscrições de webhook dos tenants
+func NewWebhookSubscriptionRepository(db *sqlx.DB, logger *zap.Logger) domain.WebhookSubscriptionRepository {
+	return repository.NewPostgresWebhookSubscriptionRepository(db, logger)
+}
+
+// NewWebhookDeliveryRepository cria repositório das entregas dos webhooks
+func NewWebhookDeliveryRepository(db *sqlx.DB, logger *zap.Logger) domain.WebhookDeliveryRepository {
+	return repository.NewPostgresWebhookDeliveryRepository(db, logger)
+}
+
 // NewEmailProvider cria provedor de email
-func NewEmailProvider(cfg *config.Config, logger *zap.Logger) domain.NotificationProvider {
-	emailConfig := providers.EmailConfig{
-		Host:        cfg.SMTP.Host,
-		Port:        cfg.SMTP.Port,
-		Username:    cfg.SMTP.Username,
-		Password:    cfg.SMTP.Password,
-		FromEmail:   cfg.SMTP.FromEmail,
-		FromName:    cfg.SMTP.FromName,
-		UseTLS:      cfg.SMTP.UseTLS,
-		UseStartTLS: cfg.SMTP.UseStartTLS,
-		Timeout:     30,
-		MaxRetries:  3,
-		RateLimit:   60,
-	}
-	return providers.NewEmailProvider(emailConfig, logger)
+func NewEmailProvider(cfg *config.Config, senders domain.EmailSenderDomainRepository, logger *zap.Logger) *providers.EmailProvider {
+	return providers.NewEmailProvider(providers.NewEmailProviderConfig(cfg.SMTP), senders, logger)
+}
+
+// StopEmailProvider fecha as conexões SMTP do pool no shutdown
+func StopEmailProvider(lc fx.Lifecycle, emailProvider *providers.EmailProvider, logger *zap.Logger) {
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			logger.Info("Closing SMTP connections")
+			emailProvider.Close()
+			return nil
+		},
+	})
 }
 
 // NewWhatsAppProvider cria provedor WhatsApp
-func NewWhatsAppProvider(cfg *config.Config, logger *zap.Logger) domain.NotificationProvider {
+func NewWhatsAppProvider(
+	cfg *config.Config,
+	sessions domain.WhatsAppSessionRepository,
+	templates domain.WhatsAppTemplateRepository,
+	logger *zap.Logger,
+) domain.NotificationProvider {
 	whatsappConfig := providers.WhatsAppConfig{
 		AccessToken:   cfg.WhatsApp.AccessToken,
 		PhoneNumberID: cfg.WhatsApp.PhoneNumberID,
@@ -146,12 +273,17 @@ func NewWhatsAppProvider(cfg *config.Config, logger *zap.Logger) domain.Notifica
 		MaxRetries:    3,
 		RateLimit:     60,
 	}
-	return providers.NewWhatsAppProvider(whatsappConfig, logger)
+	return providers.NewWhatsAppProvider(whatsappConfig, sessions, templates, logger)
 }
 
 // NewTelegramProvider cria provedor Telegram
 func NewTelegramProvider(cfg *config.Config, logger *zap.Logger) domain.NotificationProvider {
-	telegramConfig := providers.TelegramConfig{
+	return providers.NewTelegramProvider(newTelegramConfig(cfg), logger)
+}
+
+// newTelegramConfig configuração do Telegram compartilhada pelo provedor e pelo bot
+func newTelegramConfig(cfg *config.Config) providers.TelegramConfig {
+	return providers.TelegramConfig{
 		BotToken:      cfg.Telegram.BotToken,
 		WebhookURL:    cfg.Telegram.WebhookURL,
 		WebhookSecret: cfg.Telegram.WebhookSecret,
@@ -160,148 +292,529 @@ func NewTelegramProvider(cfg *config.Config, logger *zap.Logger) domain.Notifica
 		RateLimit:     30,
 		ParseMode:     "HTML",
 	}
-	return providers.NewTelegramProvider(telegramConfig, logger)
+}
+
+// NewSMSGateway cria adaptador do gateway de SMS (nil quando SMS_GATEWAY_URL não está configurada)
+func NewSMSGateway(cfg *config.Config, logger *zap.Logger) (providers.SMSGateway, error) {
+	if cfg.SMS.GatewayURL == "" {
+		logger.Warn("SMS provider not configured - missing gateway URL")
+		return nil, nil
+	}
+	return providers.NewSMSGateway(cfg.SMS, logger)
+}
+
+// NewSMSProvider cria provedor SMS
+func NewSMSProvider(cfg *config.Config, gateway providers.SMSGateway, logger *zap.Logger) *providers.SMSProvider {
+	if gateway == nil {
+		return nil
+	}
+	return providers.NewSMSProvider(providers.NewSMSProviderConfig(cfg.SMS), gateway, logger)
+}
+
+// NewPushProvider cria provedor Web Push (nil quando as chaves VAPID não estão configuradas)
+func NewPushProvider(cfg *config.Config, subscriptions domain.PushSubscriptionRepository, logger *zap.Logger) (*providers.PushProvider, error) {
+	if !cfg.Push.Enabled() {
+		logger.Warn("Push provider not configured - missing VAPID keys")
+		return nil, nil
+	}
+	return providers.NewPushProvider(providers.NewPushProviderConfig(cfg.Push), subscriptions, logger)
 }
 
 // NewProviderMap cria mapa de provedores
 func NewProviderMap(
-	emailProvider domain.NotificationProvider,
+	emailProvider *providers.EmailProvider,
 	whatsappProvider domain.NotificationProvider,
 	telegramProvider domain.NotificationProvider,
+	smsProvider *providers.SMSProvider,
+	pushProvider *providers.PushProvider,
 ) map[domain.NotificationChannel]domain.NotificationProvider {
-	return map[domain.NotificationChannel]domain.NotificationProvider{
+	providerMap := map[domain.NotificationChannel]domain.NotificationProvider{
 		domain.NotificationChannelEmail:    emailProvider,
 		domain.NotificationChannelWhatsApp: whatsappProvider,
 		domain.NotificationChannelTelegram: telegramProvider,
 	}
+	if smsProvider != nil {
+		providerMap[domain.NotificationChannelSMS] = smsProvider
+	}
+	if pushProvider != nil {
+		providerMap[domain.NotificationChannelPush] = pushProvider
+	}
+	return providerMap
 }
 
-// InMemoryQueue implementação simples de fila em memória
-type InMemoryQueue struct {
-	queues map[domain.NotificationChannel]chan *domain.Notification
-	logger *zap.Logger
+// NewNotificationQueue cria fila durável de notificações no PostgreSQL
+func NewNotificationQueue(
+	cfg *config.Config,
+	db *sqlx.DB,
+	notificationRepo domain.NotificationRepository,
+	logger *zap.Logger,
+) domain.NotificationQueue {
+	return repository.NewPostgresNotificationQueue(db, notificationRepo, cfg.Queue.VisibilityTimeout, logger)
 }
 
-// NewInMemoryQueue cria nova fila em memória
-func NewInMemoryQueue(logger *zap.Logger) domain.NotificationQueue {
-	return &InMemoryQueue{
-		queues: make(map[domain.NotificationChannel]chan *domain.Notification),
-		logger: logger,
+// NewDispatchLimiter cria limitador de taxa por provedor e por tenant
+func NewDispatchLimiter(
+	cfg *config.Config,
+	providers map[domain.NotificationChannel]domain.NotificationProvider,
+) *services.DispatchLimiter {
+	return services.NewDispatchLimiter(providers, cfg.Queue.TenantRateLimit)
+}
+
+// NewFallbackPolicies cria políticas de fallback de canal (nil quando desativado)
+func NewFallbackPolicies(cfg *config.Config) *domain.FallbackPolicies {
+	if !cfg.Fallback.Enabled {
+		return nil
 	}
+	return domain.DefaultFallbackPolicies(cfg.Fallback.StepTimeout)
+}
+
+// NewFallbackService cria serviço de cadeias de fallback
+func NewFallbackService(
+	notificationRepo domain.NotificationRepository,
+	queue domain.NotificationQueue,
+	providers map[domain.NotificationChannel]domain.NotificationProvider,
+	policies *domain.FallbackPolicies,
+	logger *zap.Logger,
+) *services.FallbackService {
+	return services.NewFallbackService(notificationRepo, queue, providers, policies, logger)
 }
 
-func (q *InMemoryQueue) Enqueue(ctx context.Context, notification *domain.Notification) error {
-	if _, exists := q.queues[notification.Channel]; !exists {
-		q.queues[notification.Channel] = make(chan *domain.Notification, 1000)
+// NewNotificationService cria serviço de notificações
+func NewNotificationService(
+	notificationRepo domain.NotificationRepository,
+	templateRepo domain.NotificationTemplateRepository,
+	versionRepo domain.TemplateVersionRepository,
+	preferenceRepo domain.NotificationPreferenceRepository,
+	suppressionRepo domain.SuppressionRepository,
+	queue domain.NotificationQueue,
+	providers map[domain.NotificationChannel]domain.NotificationProvider,
+	limiter *services.DispatchLimiter,
+	fallback *services.FallbackService,
+	windows *services.DeliveryWindowService,
+	tracker *services.EngagementTracker,
+	consents *services.ConsentService,
+	costs domain.ChannelCosts,
+	logger *zap.Logger,
+) *services.NotificationService {
+	return services.NewNotificationService(
+		notificationRepo,
+		templateRepo,
+		versionRepo,
+		preferenceRepo,
+		suppressionRepo,
+		queue,
+		providers,
+		limiter,
+		fallback,
+		windows,
+		tracker,
+		consents,
+		costs,
+		logger,
+	)
+}
+
+// NewChannelCosts cria a tabela de custo por mensagem de cada canal
+func NewChannelCosts(cfg *config.Config) domain.ChannelCosts {
+	return domain.ChannelCosts{
+		Currency: cfg.Cost.Currency,
+		PerMessage: map[domain.NotificationChannel]float64{
+			domain.NotificationChannelEmail:    cfg.Cost.Email,
+			domain.NotificationChannelWhatsApp: cfg.Cost.WhatsApp,
+			domain.NotificationChannelTelegram: cfg.Cost.Telegram,
+			domain.NotificationChannelSMS:      cfg.Cost.SMS,
+			domain.NotificationChannelPush:     cfg.Cost.Push,
+		},
 	}
-	
-	select {
-	case q.queues[notification.Channel] <- notification:
+}
+
+// NewEngagementTracker cria rastreador de abertura e clique (nil quando não configurado)
+func NewEngagementTracker(cfg *config.Config, logger *zap.Logger) *services.EngagementTracker {
+	if !cfg.Tracking.Enabled() {
+		logger.Warn("Email engagement tracking not configured - missing tracking base URL or secret")
 		return nil
-	default:
-		return fmt.Errorf("queue full for channel: %s", notification.Channel)
 	}
+	return services.NewEngagementTracker(cfg.Tracking.BaseURL, cfg.Tracking.Secret)
 }
 
-func (q *InMemoryQueue) EnqueueBatch(ctx context.Context, notifications []*domain.Notification) error {
-	for _, notification := range notifications {
-		if err := q.Enqueue(ctx, notification); err != nil {
-			return err
-		}
+// NewConsentService cria o registro de consentimento (LGPD), com link de descadastro nos emails
+// quando configurado e confirmação de SAIR/VOLTAR no WhatsApp
+func NewConsentService(
+	cfg *config.Config,
+	consents domain.ConsentRepository,
+	notificationRepo domain.NotificationRepository,
+	sessions domain.WhatsAppSessionRepository,
+	templates domain.WhatsAppTemplateRepository,
+	logger *zap.Logger,
+) *services.ConsentService {
+	if !cfg.Consent.EnforcementEnabled {
+		logger.Warn("Consent enforcement disabled - notifications are sent without checking consent")
 	}
-	return nil
+
+	var replies domain.WhatsAppReplyClient
+	if cfg.WhatsApp.AccessToken != "" && cfg.WhatsApp.PhoneNumberID != "" {
+		replies = providers.NewWhatsAppProvider(providers.WhatsAppConfig{
+			AccessToken:   cfg.WhatsApp.AccessToken,
+			PhoneNumberID: cfg.WhatsApp.PhoneNumberID,
+			Timeout:       30,
+		}, sessions, templates, logger)
+	}
+
+	return services.NewConsentService(
+		consents,
+		notificationRepo,
+		replies,
+		cfg.Consent.EnforcementEnabled,
+		cfg.Consent.UnsubscribeBaseURL,
+		cfg.Consent.UnsubscribeSecret,
+		logger,
+	)
 }
 
-func (q *InMemoryQueue) EnqueueScheduled(ctx context.Context, notification *domain.Notification, scheduledAt time.Time) error {
-	// Para simplicidade, enfileirar imediatamente
-	// Em produção, usaria um scheduler
-	return q.Enqueue(ctx, notification)
+// NewEmailSenderDomainService cria serviço do remetente com domínio próprio dos tenants
+func NewEmailSenderDomainService(senders domain.EmailSenderDomainRepository, logger *zap.Logger) *services.EmailSenderDomainService {
+	return services.NewEmailSenderDomainService(senders, logger)
 }
 
-func (q *InMemoryQueue) Dequeue(ctx context.Context, channel domain.NotificationChannel) (*domain.Notification, error) {
-	if queue, exists := q.queues[channel]; exists {
-		select {
-		case notification := <-queue:
-			return notification, nil
-		default:
-			return nil, fmt.Errorf("queue empty for channel: %s", channel)
-		}
-	}
-	return nil, fmt.Errorf("queue not found for channel: %s", channel)
+// NewWhatsAppTemplateCatalog cria catálogo de templates aprovados do WhatsApp (nil quando não configurado)
+func NewWhatsAppTemplateCatalog(
+	cfg *config.Config,
+	templates domain.WhatsAppTemplateRepository,
+	logger *zap.Logger,
+) (domain.WhatsAppTemplateCatalog, error) {
+	return providers.NewWhatsAppTemplateCatalog(cfg.WhatsApp, templates, logger)
 }
 
-func (q *InMemoryQueue) DequeueBatch(ctx context.Context, channel domain.NotificationChannel, limit int) ([]*domain.Notification, error) {
-	var notifications []*domain.Notification
-	for i := 0; i < limit; i++ {
-		notification, err := q.Dequeue(ctx, channel)
-		if err != nil {
-			break
-		}
-		notifications = append(notifications, notification)
-	}
-	return notifications, nil
+// NewWhatsAppTemplateService cria serviço de templates aprovados e janela de atendimento do WhatsApp
+func NewWhatsAppTemplateService(
+	cfg *config.Config,
+	templateRepo domain.NotificationTemplateRepository,
+	mappings domain.WhatsAppTemplateRepository,
+	sessions domain.WhatsAppSessionRepository,
+	catalog domain.WhatsAppTemplateCatalog,
+	logger *zap.Logger,
+) *services.WhatsAppTemplateService {
+	return services.NewWhatsAppTemplateService(templateRepo, mappings, sessions, catalog, cfg.WhatsApp.TemplateSyncInterval, logger)
 }
 
-func (q *InMemoryQueue) DequeueByPriority(ctx context.Context, channel domain.NotificationChannel) (*domain.Notification, error) {
-	return q.Dequeue(ctx, channel)
+// NewProcessDirectory cria cliente do process-service usado pelo bot do Telegram
+func NewProcessDirectory(cfg *config.Config, logger *zap.Logger) domain.ProcessDirectory {
+	return clients.NewProcessServiceClient(cfg.ProcessService.URL, cfg.ProcessService.Timeout, logger)
 }
 
-func (q *InMemoryQueue) QueueSize(ctx context.Context, channel domain.NotificationChannel) (int64, error) {
-	if queue, exists := q.queues[channel]; exists {
-		return int64(len(queue)), nil
+// NewConversationalAssistant cria cliente do assistente do mcp-service (nil quando desabilitado)
+func NewConversationalAssistant(cfg *config.Config, logger *zap.Logger) domain.ConversationalAssistant {
+	if !cfg.Assistant.Enabled {
+		return nil
 	}
-	return 0, nil
+	return clients.NewMCPServiceClient(cfg.Assistant.URL, cfg.Assistant.Token, cfg.Assistant.Timeout, logger)
 }
 
-func (q *InMemoryQueue) QueueSizeByPriority(ctx context.Context, channel domain.NotificationChannel, priority domain.NotificationPriority) (int64, error) {
-	return q.QueueSize(ctx, channel)
+// NewTelegramBotService cria o bot interativo do Telegram
+func NewTelegramBotService(
+	cfg *config.Config,
+	links domain.TelegramLinkRepository,
+	processes domain.ProcessDirectory,
+	assistant domain.ConversationalAssistant,
+	logger *zap.Logger,
+) *services.TelegramBotService {
+	return services.NewTelegramBotService(
+		links,
+		processes,
+		providers.NewTelegramProvider(newTelegramConfig(cfg), logger),
+		assistant,
+		cfg.Telegram.BotUsername,
+		cfg.Telegram.WebAppURL,
+		cfg.Telegram.LinkCodeTTL,
+		cfg.Telegram.CommandRateLimit,
+		logger,
+	)
 }
 
-func (q *InMemoryQueue) Clear(ctx context.Context, channel domain.NotificationChannel) error {
-	if queue, exists := q.queues[channel]; exists {
-		for len(queue) > 0 {
-			<-queue
-		}
+// NewWhatsAppAssistantService cria o atendimento do assistente no WhatsApp (nil quando o
+// assistente está desabilitado ou o WhatsApp não está configurado)
+func NewWhatsAppAssistantService(
+	cfg *config.Config,
+	links domain.WhatsAppLinkRepository,
+	codes domain.TelegramLinkRepository,
+	assistant domain.ConversationalAssistant,
+	sessions domain.WhatsAppSessionRepository,
+	templates domain.WhatsAppTemplateRepository,
+	logger *zap.Logger,
+) *services.WhatsAppAssistantService {
+	if assistant == nil || cfg.WhatsApp.AccessToken == "" || cfg.WhatsApp.PhoneNumberID == "" {
+		return nil
 	}
-	return nil
+
+	client := providers.NewWhatsAppProvider(providers.WhatsAppConfig{
+		AccessToken:   cfg.WhatsApp.AccessToken,
+		PhoneNumberID: cfg.WhatsApp.PhoneNumberID,
+		Timeout:       30,
+	}, sessions, templates, logger)
+
+	return services.NewWhatsAppAssistantService(links, codes, assistant, client, cfg.Assistant.WhatsAppRateLimit, logger)
 }
 
-func (q *InMemoryQueue) Health(ctx context.Context) error {
-	return nil
+// NewDeliveryWindowService cria serviço de janelas de entrega
+func NewDeliveryWindowService(
+	settingsRepo domain.DeliverySettingsRepository,
+	preferenceRepo domain.NotificationPreferenceRepository,
+	logger *zap.Logger,
+) *services.DeliveryWindowService {
+	return services.NewDeliveryWindowService(settingsRepo, preferenceRepo, logger)
 }
 
-// NewNotificationService cria serviço de notificações
-func NewNotificationService(
+// NewDigestService cria serviço de envio de resumos
+func NewDigestService(
+	cfg *config.Config,
 	notificationRepo domain.NotificationRepository,
+	queue domain.NotificationQueue,
+	windows *services.DeliveryWindowService,
+	logger *zap.Logger,
+) *services.DigestService {
+	return services.NewDigestService(notificationRepo, queue, windows, cfg.Digest.FlushInterval, cfg.Digest.BatchSize, logger)
+}
+
+// NewTemplateService cria serviço de templates
+func NewTemplateService(
 	templateRepo domain.NotificationTemplateRepository,
-	preferenceRepo domain.NotificationPreferenceRepository,
+	versionRepo domain.TemplateVersionRepository,
+	logger *zap.Logger,
+) *services.TemplateService {
+	return services.NewTemplateService(templateRepo, versionRepo, logger)
+}
+
+// NewDeliveryStatusService cria serviço de recibos de entrega
+func NewDeliveryStatusService(
+	notificationRepo domain.NotificationRepository,
+	historyRepo domain.DeliveryHistoryRepository,
+	suppressionRepo domain.SuppressionRepository,
+	fallback *services.FallbackService,
+	logger *zap.Logger,
+) *services.DeliveryStatusService {
+	return services.NewDeliveryStatusService(notificationRepo, historyRepo, suppressionRepo, fallback, logger)
+}
+
+// NewNotificationWorkerPool cria pool de workers da fila
+func NewNotificationWorkerPool(
+	cfg *config.Config,
+	notificationService *services.NotificationService,
 	queue domain.NotificationQueue,
 	providers map[domain.NotificationChannel]domain.NotificationProvider,
+	limiter *services.DispatchLimiter,
+	metrics *metrics.Metrics,
 	logger *zap.Logger,
-) *services.NotificationService {
-	return services.NewNotificationService(
-		notificationRepo,
-		templateRepo,
-		preferenceRepo,
+) *services.NotificationWorkerPool {
+	return services.NewNotificationWorkerPool(
+		notificationService,
 		queue,
 		providers,
+		limiter,
+		metrics,
+		cfg.Queue.MaxWorkersPerChannel,
+		cfg.Queue.PollInterval,
 		logger,
 	)
 }
 
-// NewTemplateService cria serviço de templates
-func NewTemplateService(
-	templateRepo domain.NotificationTemplateRepository,
+// StartNotificationWorkers inicia os workers da fila e os para no shutdown
+func StartNotificationWorkers(
+	lc fx.Lifecycle,
+	cfg *config.Config,
+	workerPool *services.NotificationWorkerPool,
 	logger *zap.Logger,
-) *services.TemplateService {
-	return services.NewTemplateService(templateRepo, logger)
+) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			logger.Info("Starting notification workers")
+			workerPool.Start()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			logger.Info("Stopping notification workers")
+			stopCtx, cancel := context.WithTimeout(ctx, cfg.Queue.ShutdownTimeout)
+			defer cancel()
+			return workerPool.Stop(stopCtx)
+		},
+	})
+}
+
+// StartDigestService inicia o envio periódico dos resumos
+func StartDigestService(
+	lc fx.Lifecycle,
+	digestService *services.DigestService,
+	logger *zap.Logger,
+) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			logger.Info("Starting notification digests")
+			digestService.Start()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			logger.Info("Stopping notification digests")
+			return digestService.Stop(ctx)
+		},
+	})
+}
+
+// StartWhatsAppTemplateSync inicia a sincronização periódica do status dos templates do WhatsApp
+func StartWhatsAppTemplateSync(
+	lc fx.Lifecycle,
+	whatsAppTemplates *services.WhatsAppTemplateService,
+	logger *zap.Logger,
+) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			logger.Info("Starting WhatsApp template sync")
+			whatsAppTemplates.Start()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			logger.Info("Stopping WhatsApp template sync")
+			return whatsAppTemplates.Stop(ctx)
+		},
+	})
+}
+
+// NewMovementAlertService cria serviço de alertas de movimentação processual
+func NewMovementAlertService(
+	notificationService *services.NotificationService,
+	watchers domain.ProcessWatcherRepository,
+	alerts domain.MovementAlertRepository,
+	preferenceRepo domain.NotificationPreferenceRepository,
+	logger *zap.Logger,
+) *services.MovementAlertService {
+	return services.NewMovementAlertService(notificationService, watchers, alerts, preferenceRepo, logger)
+}
+
+// NewMovementConsumer cria consumidor dos eventos de movimentação do process-service (nil quando
+// desabilitado)
+func NewMovementConsumer(cfg *config.Config, alerts *services.MovementAlertService, logger *zap.Logger) *events.MovementConsumer {
+	if !cfg.MovementAlerts.Enabled {
+		return nil
+	}
+	return events.NewMovementConsumer(cfg.RabbitMQ.URL, cfg.MovementAlerts, alerts, logger)
+}
+
+// StartMovementConsumer inicia o consumo dos eventos de movimentação e o para no shutdown
+func StartMovementConsumer(
+	lc fx.Lifecycle,
+	consumer *events.MovementConsumer,
+	logger *zap.Logger,
+) {
+	if consumer == nil {
+		return
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			logger.Info("Starting movement event consumer")
+			consumer.Start()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			logger.Info("Stopping movement event consumer")
+			return consumer.Stop(ctx)
+		},
+	})
+}
+
+// NewWebhookService cria os webhooks de saída dos tenants, limitados pelo plano no tenant-service
+// (nil quando desabilitado)
+func NewWebhookService(
+	cfg *config.Config,
+	subscriptions domain.WebhookSubscriptionRepository,
+	deliveries domain.WebhookDeliveryRepository,
+	logger *zap.Logger,
+) *services.WebhookService {
+	if !cfg.Webhooks.Enabled {
+		return nil
+	}
+
+	var plans domain.TenantPlanDirectory
+	if cfg.TenantService.URL != "" {
+		plans = clients.NewTenantServiceClient(cfg.TenantService.URL, cfg.TenantService.Timeout, logger)
+	} else {
+		logger.Warn("Tenant service URL not configured - webhook plan limits are not enforced")
+	}
+
+	return services.NewWebhookService(
+		subscriptions,
+		deliveries,
+		providers.NewHTTPWebhookSender(cfg.Webhooks, logger),
+		plans,
+		services.WebhookServiceConfig{
+			RetryPolicy: domain.WebhookRetryPolicy{
+				MaxAttempts: cfg.Webhooks.MaxAttempts,
+				BaseDelay:   cfg.Webhooks.RetryBaseDelay,
+				MaxDelay:    cfg.Webhooks.RetryMaxDelay,
+			},
+			DisableAfterFailures: cfg.Webhooks.DisableAfterFailures,
+			Timeout:              cfg.Webhooks.Timeout,
+			PollInterval:         cfg.Webhooks.PollInterval,
+			BatchSize:            cfg.Webhooks.BatchSize,
+			AllowInsecureURLs:    cfg.Webhooks.AllowInsecureURLs,
+		},
+		logger,
+	)
+}
+
+// NewWebhookEventConsumer cria consumidor dos eventos de processos e cobrança entregues aos
+// webhooks (nil quando desabilitado)
+func NewWebhookEventConsumer(cfg *config.Config, webhooks *services.WebhookService, logger *zap.Logger) *events.WebhookEventConsumer {
+	if webhooks == nil {
+		return nil
+	}
+	return events.NewWebhookEventConsumer(cfg.RabbitMQ.URL, cfg.Webhooks, webhooks, logger)
+}
+
+// StartWebhooks inicia as entregas e o consumo dos eventos dos webhooks e os para no shutdown
+func StartWebhooks(
+	lc fx.Lifecycle,
+	webhooks *services.WebhookService,
+	consumer *events.WebhookEventConsumer,
+	logger *zap.Logger,
+) {
+	if webhooks == nil {
+		return
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			logger.Info("Starting tenant webhooks")
+			webhooks.Start()
+			consumer.Start()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			logger.Info("Stopping tenant webhooks")
+			if err := consumer.Stop(ctx); err != nil {
+				return err
+			}
+			return webhooks.Stop(ctx)
+		},
+	})
 }
 
 // NewGinRouter cria router Gin
 func NewGinRouter(
+	cfg *config.Config,
 	notificationService *services.NotificationService,
 	templateService *services.TemplateService,
+	deliveryService *services.DeliveryStatusService,
+	deliveryWindows *services.DeliveryWindowService,
 	preferenceRepo domain.NotificationPreferenceRepository,
+	smsGateway providers.SMSGateway,
+	pushSubscriptions domain.PushSubscriptionRepository,
+	tracker *services.EngagementTracker,
+	whatsAppTemplates *services.WhatsAppTemplateService,
+	whatsAppAssistant *services.WhatsAppAssistantService,
+	telegramBot *services.TelegramBotService,
+	processWatchers domain.ProcessWatcherRepository,
+	consents *services.ConsentService,
+	emailSenders *services.EmailSenderDomainService,
+	webhooks *services.WebhookService,
 	logger *zap.Logger,
 ) *gin.Engine {
 	// Configurar Gin para produção
@@ -314,7 +827,26 @@ func NewGinRouter(
 		NotificationService: notificationService,
 		TemplateService:     templateService,
 		PreferenceRepo:      preferenceRepo,
-		Logger:              logger,
+		DeliveryService:     deliveryService,
+		DeliveryWindows:     deliveryWindows,
+		EmailWebhookSecret:  cfg.SMTP.WebhookSecret,
+		SMSGateway:          smsGateway,
+		SMSWebhookSecret:    cfg.SMS.WebhookSecret,
+		PushSubscriptions:   pushSubscriptions,
+		VAPIDPublicKey:      cfg.Push.VAPIDPublicKey,
+		Tracker:             tracker,
+		WhatsAppTemplates:   whatsAppTemplates,
+		WhatsAppAssistant:   whatsAppAssistant,
+		TelegramBot:         telegramBot,
+		ProcessWatchers:     processWatchers,
+		Consents:            consents,
+		EmailSenders:        emailSenders,
+		Webhooks:            webhooks,
+
+		TelegramWebhookSecret: cfg.Telegram.WebhookSecret,
+		WhatsAppVerifyToken:   cfg.WhatsApp.VerifyToken,
+		WhatsAppAppSecret:     cfg.WhatsApp.AppSecret,
+		Logger:                logger,
 	}
 	
 	httpinfra.SetupRoutes(router, routerConfig)
@@ -354,14 +886,4 @@ func StartHTTPServer(
 			return server.Shutdown(ctx)
 		},
 	})
-
-	// Aguardar sinal de interrupção
-	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
-	
-	go func() {
-		<-c
-		logger.Info("Received shutdown signal")
-		os.Exit(0)
-	}()
 }
\ No newline at end of file
@@ -10,12 +10,14 @@ import (
 
 	"github.com/direito-lux/notification-service/internal/application/services"
 	"github.com/direito-lux/notification-service/internal/domain"
+	"github.com/direito-lux/notification-service/internal/infrastructure/clients"
 	"github.com/direito-lux/notification-service/internal/infrastructure/config"
 	"github.com/direito-lux/notification-service/internal/infrastructure/database"
 	"github.com/direito-lux/notification-service/internal/infrastructure/events"
 	"github.com/direito-lux/notification-service/internal/infrastructure/http"
 	"github.com/direito-lux/notification-service/internal/infrastructure/logging"
 	"github.com/direito-lux/notification-service/internal/infrastructure/metrics"
+	"github.com/direito-lux/notification-service/internal/infrastructure/providers"
 	"github.com/direito-lux/notification-service/internal/infrastructure/repository"
 	"github.com/direito-lux/notification-service/internal/infrastructure/tracing"
 
@@ -46,7 +48,7 @@ import (
 
 func main() {
 	// Carregar configurações
-	cfg, err := config.Load()
+	cfg, err := config.NewConfig()
 	if err != nil {
 		fmt.Printf("Erro ao carregar configurações: %v\n", err)
 		os.Exit(1)
@@ -90,15 +92,51 @@ func main() {
 		fx.Provide(
 			repository.NewPostgresNotificationRepository,
 			repository.NewPostgresTemplateRepository,
+			repository.NewPostgresTemplateVersionRepository,
 			repository.NewPostgresPreferenceRepository,
+			repository.NewPostgresDeliveryHistoryRepository,
+			repository.NewPostgresSuppressionRepository,
+			repository.NewPostgresDeliverySettingsRepository,
+			repository.NewPostgresPushSubscriptionRepository,
+			repository.NewPostgresWhatsAppSessionRepository,
+			repository.NewPostgresWhatsAppTemplateRepository,
+			repository.NewPostgresTelegramLinkRepository,
+			repository.NewPostgresWhatsAppLinkRepository,
+			repository.NewPostgresProcessWatcherRepository,
+			repository.NewPostgresMovementAlertRepository,
+			repository.NewPostgresConsentRepository,
+			repository.NewPostgresEmailSenderDomainRepository,
+			repository.NewPostgresWebhookSubscriptionRepository,
+			repository.NewPostgresWebhookDeliveryRepository,
 		),
 
 		// Application Services
 		fx.Provide(
 			services.NewNotificationService,
 			services.NewTemplateService,
+			services.NewDeliveryStatusService,
+			services.NewFallbackService,
+			provideFallbackPolicies,
+			services.NewDeliveryWindowService,
+			provideDigestService,
+			provideEngagementTracker,
+			provideConsentService,
+			provideChannelCosts,
+			services.NewEmailSenderDomainService,
+			provideWhatsAppTemplateCatalog,
+			provideWhatsAppTemplateService,
+			provideConversationalAssistant,
+			provideTelegramBotService,
+			provideWhatsAppAssistantService,
+			services.NewMovementAlertService,
+			provideMovementConsumer,
+			provideWebhookService,
+			provideWebhookConsumer,
 			provideNotificationProviders,
+			provideSMSGateway,
 			provideNotificationQueue,
+			provideDispatchLimiter,
+			provideWorkerPool,
 		),
 
 		// HTTP Server
@@ -152,10 +190,262 @@ func provideNotificationProviders() map[domain.NotificationChannel]domain.Notifi
 	}
 }
 
+// provideSMSGateway provides SMS gateway adapter (nil when SMS is not configured)
+func provideSMSGateway(cfg *config.Config, logger *zap.Logger) (providers.SMSGateway, error) {
+	if cfg.SMS.GatewayURL == "" {
+		return nil, nil
+	}
+	return providers.NewSMSGateway(cfg.SMS, logger)
+}
+
 // provideNotificationQueue provides notification queue
-func provideNotificationQueue() domain.NotificationQueue {
-	// Mock queue for now - will be replaced with real implementation
-	return nil
+func provideNotificationQueue(
+	cfg *config.Config,
+	db *sqlx.DB,
+	notificationRepo domain.NotificationRepository,
+	logger *zap.Logger,
+) domain.NotificationQueue {
+	return repository.NewPostgresNotificationQueue(db, notificationRepo, cfg.Queue.VisibilityTimeout, logger)
+}
+
+// provideDispatchLimiter provides per-provider and per-tenant rate limiter
+func provideDispatchLimiter(
+	cfg *config.Config,
+	providers map[domain.NotificationChannel]domain.NotificationProvider,
+) *services.DispatchLimiter {
+	return services.NewDispatchLimiter(providers, cfg.Queue.TenantRateLimit)
+}
+
+// provideEngagementTracker provides email open/click tracker (nil when not configured)
+func provideEngagementTracker(cfg *config.Config) *services.EngagementTracker {
+	return services.NewEngagementTracker(cfg.Tracking.BaseURL, cfg.Tracking.Secret)
+}
+
+// provideChannelCosts provides per-channel message costs for analytics
+func provideChannelCosts(cfg *config.Config) domain.ChannelCosts {
+	return domain.ChannelCosts{
+		Currency: cfg.Cost.Currency,
+		PerMessage: map[domain.NotificationChannel]float64{
+			domain.NotificationChannelEmail:    cfg.Cost.Email,
+			domain.NotificationChannelWhatsApp: cfg.Cost.WhatsApp,
+			domain.NotificationChannelTelegram: cfg.Cost.Telegram,
+			domain.NotificationChannelSMS:      cfg.Cost.SMS,
+			domain.NotificationChannelPush:     cfg.Cost.Push,
+		},
+	}
+}
+
+// provideConsentService provides LGPD consent registry, unsubscribe links and SAIR/VOLTAR replies
+func provideConsentService(
+	cfg *config.Config,
+	consents domain.ConsentRepository,
+	notificationRepo domain.NotificationRepository,
+	logger *zap.Logger,
+) *services.ConsentService {
+	// Confirmação de SAIR/VOLTAR no WhatsApp, sempre dentro da janela de atendimento
+	var replies domain.WhatsAppReplyClient
+	if cfg.WhatsApp.AccessToken != "" && cfg.WhatsApp.PhoneNumberID != "" {
+		replies = providers.NewWhatsAppProvider(providers.WhatsAppConfig{
+			AccessToken:   cfg.WhatsApp.AccessToken,
+			PhoneNumberID: cfg.WhatsApp.PhoneNumberID,
+			Timeout:       30,
+		}, nil, nil, logger)
+	}
+
+	return services.NewConsentService(
+		consents,
+		notificationRepo,
+		replies,
+		cfg.Consent.EnforcementEnabled,
+		cfg.Consent.UnsubscribeBaseURL,
+		cfg.Consent.UnsubscribeSecret,
+		logger,
+	)
+}
+
+// provideWhatsAppTemplateCatalog provides WhatsApp approved template catalog (nil when not configured)
+func provideWhatsAppTemplateCatalog(
+	cfg *config.Config,
+	templates domain.WhatsAppTemplateRepository,
+	logger *zap.Logger,
+) (domain.WhatsAppTemplateCatalog, error) {
+	return providers.NewWhatsAppTemplateCatalog(cfg.WhatsApp, templates, logger)
+}
+
+// provideWhatsAppTemplateService provides WhatsApp template mapping, status sync and session tracking
+func provideWhatsAppTemplateService(
+	cfg *config.Config,
+	templateRepo domain.NotificationTemplateRepository,
+	mappings domain.WhatsAppTemplateRepository,
+	sessions domain.WhatsAppSessionRepository,
+	catalog domain.WhatsAppTemplateCatalog,
+	logger *zap.Logger,
+) *services.WhatsAppTemplateService {
+	return services.NewWhatsAppTemplateService(templateRepo, mappings, sessions, catalog, cfg.WhatsApp.TemplateSyncInterval, logger)
+}
+
+// provideConversationalAssistant provides mcp-service assistant client (nil when disabled)
+func provideConversationalAssistant(cfg *config.Config, logger *zap.Logger) domain.ConversationalAssistant {
+	if !cfg.Assistant.Enabled {
+		return nil
+	}
+	return clients.NewMCPServiceClient(cfg.Assistant.URL, cfg.Assistant.Token, cfg.Assistant.Timeout, logger)
+}
+
+// provideMovementConsumer provides process-service movement event consumer (nil when disabled)
+func provideMovementConsumer(
+	cfg *config.Config,
+	alerts *services.MovementAlertService,
+	logger *zap.Logger,
+) *events.MovementConsumer {
+	if !cfg.MovementAlerts.Enabled {
+		return nil
+	}
+	return events.NewMovementConsumer(cfg.RabbitMQ.URL, cfg.MovementAlerts, alerts, logger)
+}
+
+// provideWebhookService provides tenant outbound webhooks limited by the tenant plan (nil when
+// disabled)
+func provideWebhookService(
+	cfg *config.Config,
+	subscriptions domain.WebhookSubscriptionRepository,
+	deliveries domain.WebhookDeliveryRepository,
+	logger *zap.Logger,
+) *services.WebhookService {
+	if !cfg.Webhooks.Enabled {
+		return nil
+	}
+
+	var plans domain.TenantPlanDirectory
+	if cfg.TenantService.URL != "" {
+		plans = clients.NewTenantServiceClient(cfg.TenantService.URL, cfg.TenantService.Timeout, logger)
+	} else {
+		logger.Warn("Tenant service URL not configured - webhook plan limits are not enforced")
+	}
+
+	return services.NewWebhookService(
+		subscriptions,
+		deliveries,
+		providers.NewHTTPWebhookSender(cfg.Webhooks, logger),
+		plans,
+		services.WebhookServiceConfig{
+			RetryPolicy: domain.WebhookRetryPolicy{
+				MaxAttempts: cfg.Webhooks.MaxAttempts,
+				BaseDelay:   cfg.Webhooks.RetryBaseDelay,
+				MaxDelay:    cfg.Webhooks.RetryMaxDelay,
+			},
+			DisableAfterFailures: cfg.Webhooks.DisableAfterFailures,
+			Timeout:              cfg.Webhooks.Timeout,
+			PollInterval:         cfg.Webhooks.PollInterval,
+			BatchSize:            cfg.Webhooks.BatchSize,
+			AllowInsecureURLs:    cfg.Webhooks.AllowInsecureURLs,
+		},
+		logger,
+	)
+}
+
+// provideWebhookConsumer provides process and billing event consumer for tenant webhooks (nil
+// when disabled)
+func provideWebhookConsumer(
+	cfg *config.Config,
+	webhooks *services.WebhookService,
+	logger *zap.Logger,
+) *events.WebhookEventConsumer {
+	if webhooks == nil {
+		return nil
+	}
+	return events.NewWebhookEventConsumer(cfg.RabbitMQ.URL, cfg.Webhooks, webhooks, logger)
+}
+
+// provideTelegramBotService provides interactive Telegram bot backed by process-service data
+func provideTelegramBotService(
+	cfg *config.Config,
+	links domain.TelegramLinkRepository,
+	assistant domain.ConversationalAssistant,
+	logger *zap.Logger,
+) *services.TelegramBotService {
+	client := providers.NewTelegramProvider(providers.TelegramConfig{
+		BotToken:      cfg.Telegram.BotToken,
+		WebhookURL:    cfg.Telegram.WebhookURL,
+		WebhookSecret: cfg.Telegram.WebhookSecret,
+	}, logger)
+	processes := clients.NewProcessServiceClient(cfg.ProcessService.URL, cfg.ProcessService.Timeout, logger)
+
+	return services.NewTelegramBotService(
+		links,
+		processes,
+		client,
+		assistant,
+		cfg.Telegram.BotUsername,
+		cfg.Telegram.WebAppURL,
+		cfg.Telegram.LinkCodeTTL,
+		cfg.Telegram.CommandRateLimit,
+		logger,
+	)
+}
+
+// provideWhatsAppAssistantService provides WhatsApp conversations with the assistant (nil when
+// the assistant is disabled or WhatsApp is not configured)
+func provideWhatsAppAssistantService(
+	cfg *config.Config,
+	links domain.WhatsAppLinkRepository,
+	codes domain.TelegramLinkRepository,
+	assistant domain.ConversationalAssistant,
+	logger *zap.Logger,
+) *services.WhatsAppAssistantService {
+	if assistant == nil || cfg.WhatsApp.AccessToken == "" || cfg.WhatsApp.PhoneNumberID == "" {
+		return nil
+	}
+
+	// Respostas sempre dentro da janela de atendimento: sem sessões nem templates
+	client := providers.NewWhatsAppProvider(providers.WhatsAppConfig{
+		AccessToken:   cfg.WhatsApp.AccessToken,
+		PhoneNumberID: cfg.WhatsApp.PhoneNumberID,
+		Timeout:       30,
+	}, nil, nil, logger)
+
+	return services.NewWhatsAppAssistantService(links, codes, assistant, client, cfg.Assistant.WhatsAppRateLimit, logger)
+}
+
+// provideFallbackPolicies provides channel fallback policies (nil when disabled)
+func provideFallbackPolicies(cfg *config.Config) *domain.FallbackPolicies {
+	if !cfg.Fallback.Enabled {
+		return nil
+	}
+	return domain.DefaultFallbackPolicies(cfg.Fallback.StepTimeout)
+}
+
+// provideDigestService provides periodic digest sender
+func provideDigestService(
+	cfg *config.Config,
+	notificationRepo domain.NotificationRepository,
+	queue domain.NotificationQueue,
+	windows *services.DeliveryWindowService,
+	logger *zap.Logger,
+) *services.DigestService {
+	return services.NewDigestService(notificationRepo, queue, windows, cfg.Digest.FlushInterval, cfg.Digest.BatchSize, logger)
+}
+
+// provideWorkerPool provides notification queue workers
+func provideWorkerPool(
+	cfg *config.Config,
+	notificationService *services.NotificationService,
+	queue domain.NotificationQueue,
+	providers map[domain.NotificationChannel]domain.NotificationProvider,
+	limiter *services.DispatchLimiter,
+	metrics *metrics.Metrics,
+	logger *zap.Logger,
+) *services.NotificationWorkerPool {
+	return services.NewNotificationWorkerPool(
+		notificationService,
+		queue,
+		providers,
+		limiter,
+		metrics,
+		cfg.Queue.MaxWorkersPerChannel,
+		cfg.Queue.PollInterval,
+		logger,
+	)
 }
 
 // registerHooks registra hooks do ciclo de vida da aplicação
@@ -165,6 +455,13 @@ func registerHooks(
 	server *http.Server,
 	metrics *metrics.Metrics,
 	tracer *tracing.Tracer,
+	cfg *config.Config,
+	workerPool *services.NotificationWorkerPool,
+	digestService *services.DigestService,
+	whatsAppTemplates *services.WhatsAppTemplateService,
+	movementConsumer *events.MovementConsumer,
+	webhooks *services.WebhookService,
+	webhookConsumer *events.WebhookEventConsumer,
 ) {
 	lc.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
@@ -177,10 +474,56 @@ func registerHooks(
 				}
 			}()
 
+			// Iniciar workers da fila de notificações
+			workerPool.Start()
+
+			// Iniciar envio periódico dos resumos
+			digestService.Start()
+
+			// Iniciar sincronização do status dos templates do WhatsApp
+			whatsAppTemplates.Start()
+
+			// Iniciar consumo dos eventos de movimentação do process-service
+			if movementConsumer != nil {
+				movementConsumer.Start()
+			}
+
+			// Iniciar entregas e consumo dos eventos dos webhooks dos tenants
+			if webhooks != nil {
+				webhooks.Start()
+				webhookConsumer.Start()
+			}
+
 			return nil
 		},
 		OnStop: func(ctx context.Context) error {
 			logger.Info("Parando componentes...")
+
+			// Parar workers antes do servidor, aguardando envios em andamento
+			stopCtx, cancel := context.WithTimeout(ctx, cfg.Queue.ShutdownTimeout)
+			defer cancel()
+			if err := digestService.Stop(stopCtx); err != nil {
+				logger.Error("Erro ao parar envio de resumos", zap.Error(err))
+			}
+			if err := whatsAppTemplates.Stop(stopCtx); err != nil {
+				logger.Error("Erro ao parar sincronização de templates do WhatsApp", zap.Error(err))
+			}
+			if movementConsumer != nil {
+				if err := movementConsumer.Stop(stopCtx); err != nil {
+					logger.Error("Erro ao parar consumo de eventos de movimentação", zap.Error(err))
+				}
+			}
+			if webhooks != nil {
+				if err := webhookConsumer.Stop(stopCtx); err != nil {
+					logger.Error("Erro ao parar consumo de eventos dos webhooks", zap.Error(err))
+				}
+				if err := webhooks.Stop(stopCtx); err != nil {
+					logger.Error("Erro ao parar entregas dos webhooks", zap.Error(err))
+				}
+			}
+			if err := workerPool.Stop(stopCtx); err != nil {
+				logger.Error("Erro ao parar workers da fila", zap.Error(err))
+			}
 			
 			// Parar servidor HTTP
 			if err := server.Shutdown(ctx); err != nil {
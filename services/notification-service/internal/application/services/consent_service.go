@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// ConsentService mantém o registro de consentimento (LGPD) das comunicações: registros do web
+// app e das integrações, descadastro pelo link do email, respostas SAIR/VOLTAR e a verificação
+// feita antes de cada envio
+type ConsentService struct {
+	repo             domain.ConsentRepository
+	notificationRepo domain.NotificationRepository
+	replies          domain.WhatsAppReplyClient
+	enforce          bool
+	baseURL          string
+	secret           []byte
+	logger           *zap.Logger
+}
+
+// NewConsentService cria nova instância do serviço. replies pode ser nil quando o WhatsApp não
+// está configurado (SAIR/VOLTAR ficam sem resposta); sem URL base ou segredo os emails são
+// enviados sem link de descadastro. Com enforce desligado os registros são mantidos, mas o envio
+// não é bloqueado.
+func NewConsentService(
+	repo domain.ConsentRepository,
+	notificationRepo domain.NotificationRepository,
+	replies domain.WhatsAppReplyClient,
+	enforce bool,
+	unsubscribeBaseURL string,
+	unsubscribeSecret string,
+	logger *zap.Logger,
+) *ConsentService {
+	service := &ConsentService{
+		repo:             repo,
+		notificationRepo: notificationRepo,
+		replies:          replies,
+		enforce:          enforce,
+		logger:           logger,
+	}
+	if unsubscribeBaseURL != "" && unsubscribeSecret != "" {
+		service.baseURL = strings.TrimRight(unsubscribeBaseURL, "/")
+		service.secret = []byte(unsubscribeSecret)
+	}
+	return service
+}
+
+// ConsentSummary situação do consentimento do contato para a finalidade
+type ConsentSummary struct {
+	Channel domain.NotificationChannel `json:"channel"`
+	Contact string                     `json:"contact"`
+	Purpose domain.ConsentPurpose      `json:"purpose"`
+	Granted bool                       `json:"granted"` // concessão vigente do tenant
+	Revoked bool                       `json:"revoked"` // revogação vigente (do tenant ou SAIR)
+	History []*domain.ConsentRecord    `json:"history"`
+}
+
+// Record grava a concessão ou revogação informada pelo tenant
+func (s *ConsentService) Record(ctx context.Context, record *domain.ConsentRecord) error {
+	return s.repo.Record(ctx, record)
+}
+
+// Status avalia o histórico do contato para a finalidade
+func (s *ConsentService) Status(ctx context.Context, tenantID uuid.UUID, channel domain.NotificationChannel, contact string, purpose domain.ConsentPurpose) (*ConsentSummary, error) {
+	records, err := s.repo.FindByContact(ctx, tenantID, channel, contact)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ConsentSummary{
+		Channel: channel,
+		Contact: domain.NormalizeConsentContact(channel, contact),
+		Purpose: purpose,
+		Granted: domain.EvaluateConsent(records, tenantID, purpose, true) == nil,
+		Revoked: errors.Is(domain.EvaluateConsent(records, tenantID, purpose, false), domain.ErrConsentRevoked),
+		History: records,
+	}, nil
+}
+
+// Export lista o histórico do tenant para auditoria
+func (s *ConsentService) Export(ctx context.Context, tenantID uuid.UUID, filter domain.ConsentExportFilter) ([]*domain.ConsentRecord, error) {
+	return s.repo.Export(ctx, tenantID, filter)
+}
+
+// Check verifica se a notificação pode ser enviada: revogações sempre bloqueiam e clientes
+// finais precisam de concessão vigente. Retorna ErrConsentRevoked ou ErrConsentRequired.
+func (s *ConsentService) Check(ctx context.Context, notification *domain.Notification) error {
+	if !s.enforce {
+		return nil
+	}
+
+	records, err := s.repo.FindByContact(ctx, notification.TenantID, notification.Channel, notification.RecipientContact)
+	if err != nil {
+		return fmt.Errorf("failed to check consent: %w", err)
+	}
+
+	return domain.EvaluateConsent(
+		records,
+		notification.TenantID,
+		domain.ConsentPurposeForType(notification.Type),
+		domain.ConsentRequired(notification.RecipientType, notification.Channel),
+	)
+}
+
+// UnsubscribeURL URL assinada de descadastro da notificação; false quando o link não está
+// configurado
+func (s *ConsentService) UnsubscribeURL(notificationID uuid.UUID) (string, bool) {
+	if s.baseURL == "" {
+		return "", false
+	}
+	query := url.Values{"sig": {s.sign(notificationID)}}
+	return s.baseURL + "/unsubscribe/" + notificationID.String() + "?" + query.Encode(), true
+}
+
+// VerifyUnsubscribe valida a assinatura recebida na rota de descadastro
+func (s *ConsentService) VerifyUnsubscribe(notificationID uuid.UUID, signature string) bool {
+	if s.baseURL == "" {
+		return false
+	}
+	return hmac.Equal([]byte(s.sign(notificationID)), []byte(signature))
+}
+
+// Unsubscribe revoga o consentimento do destinatário da notificação para a finalidade dela,
+// apenas no tenant que enviou
+func (s *ConsentService) Unsubscribe(ctx context.Context, notificationID uuid.UUID, evidence map[string]interface{}) (*domain.ConsentRecord, error) {
+	notification, err := s.notificationRepo.GetByID(ctx, notificationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if evidence == nil {
+		evidence = make(map[string]interface{})
+	}
+	evidence["notification_id"] = notification.ID.String()
+
+	record, err := domain.NewConsentRecord(
+		&notification.TenantID,
+		notification.Channel,
+		notification.RecipientContact,
+		domain.ConsentPurposeForType(notification.Type),
+		domain.ConsentStatusRevoked,
+		domain.ConsentLegalBasisConsent,
+		domain.ConsentSourceUnsubscribeLink,
+		evidence,
+	)
+	if err != nil {
+		return nil, err
+	}
+	record.RecipientID = notification.RecipientID
+
+	if err := s.repo.Record(ctx, record); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Recipient unsubscribed",
+		zap.String("notification_id", notification.ID.String()),
+		zap.String("channel", string(notification.Channel)),
+		zap.String("purpose", string(record.Purpose)))
+	return record, nil
+}
+
+// HandleKeyword registra a resposta SAIR/VOLTAR do contato para todos os tenants e, no WhatsApp,
+// confirma ao contato. Mensagens sem palavra-chave são ignoradas.
+func (s *ConsentService) HandleKeyword(ctx context.Context, channel domain.NotificationChannel, contact string, keyword domain.SMSKeyword, evidence map[string]interface{}) error {
+	var status domain.ConsentStatus
+	var source domain.ConsentSource
+
+	switch keyword {
+	case domain.SMSKeywordOptOut:
+		status = domain.ConsentStatusRevoked
+	case domain.SMSKeywordOptIn:
+		status = domain.ConsentStatusGranted
+	default:
+		return nil
+	}
+
+	switch channel {
+	case domain.NotificationChannelWhatsApp:
+		source = domain.ConsentSourceWhatsAppKeyword
+	default:
+		source = domain.ConsentSourceSMSKeyword
+	}
+
+	record, err := domain.NewConsentRecord(nil, channel, contact, domain.ConsentPurposeAll, status, domain.ConsentLegalBasisConsent, source, evidence)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Record(ctx, record); err != nil {
+		return err
+	}
+
+	if channel == domain.NotificationChannelWhatsApp && s.replies != nil {
+		if err := s.replies.SendText(ctx, record.Contact, domain.ConsentKeywordReply(keyword)); err != nil {
+			s.logger.Warn("Failed to confirm consent keyword", zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// sign assinatura HMAC-SHA256 da notificação
+func (s *ConsentService) sign(notificationID uuid.UUID) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte("unsubscribe"))
+	mac.Write([]byte{0})
+	mac.Write([]byte(notificationID.String()))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// DeliveryStatusService aplica os recibos de entrega dos provedores às notificações
+type DeliveryStatusService struct {
+	notificationRepo domain.NotificationRepository
+	historyRepo      domain.DeliveryHistoryRepository
+	suppressionRepo  domain.SuppressionRepository
+	fallback         *FallbackService
+	logger           *zap.Logger
+}
+
+// NewDeliveryStatusService cria nova instância do serviço
+func NewDeliveryStatusService(
+	notificationRepo domain.NotificationRepository,
+	historyRepo domain.DeliveryHistoryRepository,
+	suppressionRepo domain.SuppressionRepository,
+	fallback *FallbackService,
+	logger *zap.Logger,
+) *DeliveryStatusService {
+	return &DeliveryStatusService{
+		notificationRepo: notificationRepo,
+		historyRepo:      historyRepo,
+		suppressionRepo:  suppressionRepo,
+		fallback:         fallback,
+		logger:           logger,
+	}
+}
+
+// ProcessStatusUpdate registra o evento do provedor e atualiza a notificação correspondente.
+// Eventos de mensagens desconhecidas são ignorados: sem a notificação, o destinatário informado
+// no webhook não é confiável para suprimir o contato.
+func (s *DeliveryStatusService) ProcessStatusUpdate(ctx context.Context, update *domain.DeliveryStatusUpdate) error {
+	if update.OccurredAt.IsZero() {
+		update.OccurredAt = time.Now()
+	}
+
+	notification, err := s.notificationRepo.FindByExternalID(ctx, update.Channel, update.ExternalID)
+	if errors.Is(err, domain.ErrNotificationNotFound) {
+		s.logger.Warn("Delivery status for unknown message",
+			zap.String("channel", string(update.Channel)),
+			zap.String("external_id", update.ExternalID),
+			zap.String("event", string(update.Event)))
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find notification by external ID: %w", err)
+	}
+
+	if err := s.historyRepo.Record(ctx, domain.NewDeliveryRecord(notification.ID, update)); err != nil {
+		return err
+	}
+
+	if notification.ApplyDeliveryEvent(update.Event, update.OccurredAt, update.Reason) {
+		// O código de erro do provedor refina a categoria da falha
+		if notification.Status == domain.NotificationStatusFailed {
+			notification.FailureCategory = update.FailureCategory()
+		}
+
+		// Recibo de entrega encerra a cadeia de fallback; falha escala para o próximo canal
+		if s.fallback != nil {
+			if err := s.fallback.HandleAttemptUpdate(ctx, notification); err != nil {
+				s.logger.Error("Failed to update fallback chain",
+					zap.String("notification_id", notification.ID.String()),
+					zap.Error(err))
+			}
+		}
+
+		if err := s.notificationRepo.Update(ctx, notification); err != nil {
+			return fmt.Errorf("failed to update notification delivery status: %w", err)
+		}
+
+		s.logger.Info("Notification delivery status updated",
+			zap.String("notification_id", notification.ID.String()),
+			zap.String("event", string(update.Event)),
+			zap.String("status", string(notification.Status)))
+	}
+
+	if update.SuppressesContact() {
+		return s.suppress(ctx, update, notification)
+	}
+
+	return nil
+}
+
+// SuppressContact suprime o contato no canal (ex.: usuário bloqueou o bot)
+func (s *DeliveryStatusService) SuppressContact(ctx context.Context, channel domain.NotificationChannel, contact string, reason domain.SuppressionReason) error {
+	return s.suppressionRepo.Suppress(ctx, domain.NewSuppressedContact(channel, contact, reason))
+}
+
+// UnsuppressContact remove o contato da lista de supressão (ex.: usuário desbloqueou o bot)
+func (s *DeliveryStatusService) UnsuppressContact(ctx context.Context, channel domain.NotificationChannel, contact string) error {
+	return s.suppressionRepo.Remove(ctx, channel, contact)
+}
+
+// suppress registra hard bounce ou reclamação do destinatário da notificação na lista de supressão
+func (s *DeliveryStatusService) suppress(ctx context.Context, update *domain.DeliveryStatusUpdate, notification *domain.Notification) error {
+	reason := domain.SuppressionReasonHardBounce
+	if update.Event == domain.DeliveryEventComplained {
+		reason = domain.SuppressionReasonComplaint
+	}
+
+	suppression := domain.NewSuppressedContact(update.Channel, notification.RecipientContact, reason)
+	suppression.NotificationID = &notification.ID
+	if update.Reason != "" {
+		suppression.Details = &update.Reason
+	}
+
+	return s.suppressionRepo.Suppress(ctx, suppression)
+}
+
+// RecordEngagement registra abertura ou clique rastreado pelo próprio serviço. Apenas a
+// primeira ocorrência de cada evento é gravada.
+func (s *DeliveryStatusService) RecordEngagement(ctx context.Context, notificationID uuid.UUID, event domain.DeliveryEvent, at time.Time) error {
+	notification, err := s.notificationRepo.GetByID(ctx, notificationID)
+	if err != nil {
+		return err
+	}
+
+	firstOpen := notification.OpenedAt == nil
+	firstClick := event == domain.DeliveryEventClicked && notification.ClickedAt == nil
+	if !firstOpen && !firstClick {
+		return nil
+	}
+
+	update := &domain.DeliveryStatusUpdate{
+		Channel:    notification.Channel,
+		Event:      event,
+		OccurredAt: at,
+	}
+	if notification.ExternalID != nil {
+		update.ExternalID = *notification.ExternalID
+	}
+	if err := s.historyRepo.Record(ctx, domain.NewDeliveryRecord(notification.ID, update)); err != nil {
+		return err
+	}
+
+	if !notification.ApplyDeliveryEvent(event, at, "") {
+		return nil
+	}
+
+	// Abertura confirma a entrega e encerra a cadeia de fallback
+	if s.fallback != nil {
+		if err := s.fallback.HandleAttemptUpdate(ctx, notification); err != nil {
+			s.logger.Error("Failed to update fallback chain",
+				zap.String("notification_id", notification.ID.String()),
+				zap.Error(err))
+		}
+	}
+
+	if err := s.notificationRepo.Update(ctx, notification); err != nil {
+		return fmt.Errorf("failed to update notification engagement: %w", err)
+	}
+
+	s.logger.Debug("Notification engagement recorded",
+		zap.String("notification_id", notification.ID.String()),
+		zap.String("event", string(event)))
+	return nil
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// fakeDeliveryHistoryRepo histórico de entrega que registra os eventos
+type fakeDeliveryHistoryRepo struct {
+	domain.DeliveryHistoryRepository
+	records []*domain.DeliveryRecord
+}
+
+func (r *fakeDeliveryHistoryRepo) Record(ctx context.Context, record *domain.DeliveryRecord) error {
+	r.records = append(r.records, record)
+	return nil
+}
+
+func TestDeliveryStatusServiceSuppressesOnlyKnownRecipients(t *testing.T) {
+	externalID := "msg-1"
+	notification := &domain.Notification{
+		Channel:          domain.NotificationChannelEmail,
+		Status:           domain.NotificationStatusSent,
+		RecipientContact: "advogado@example.com",
+		ExternalID:       &externalID,
+	}
+
+	tests := []struct {
+		name            string
+		externalID      string
+		wantSuppressed  string
+		wantRecordCount int
+	}{
+		{"known message", "msg-1", "advogado@example.com", 1},
+		{"unknown message", "forged", "", 0},
+	}
+
+	for _, tt := range tests {
+		repo := &fakeNotificationRepo{notifications: []*domain.Notification{notification}}
+		history := &fakeDeliveryHistoryRepo{}
+		suppressions := &fakeSuppressionRepo{}
+		service := NewDeliveryStatusService(repo, history, suppressions, nil, zap.NewNop())
+
+		// O destinatário do webhook é ignorado: vale o contato da notificação
+		err := service.ProcessStatusUpdate(context.Background(), &domain.DeliveryStatusUpdate{
+			Channel:    domain.NotificationChannelEmail,
+			ExternalID: tt.externalID,
+			Event:      domain.DeliveryEventComplained,
+			Recipient:  "outro@example.com",
+		})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+
+		if len(history.records) != tt.wantRecordCount {
+			t.Errorf("%s: expected %d history records, got %d", tt.name, tt.wantRecordCount, len(history.records))
+		}
+		switch {
+		case tt.wantSuppressed == "" && len(suppressions.suppressions) != 0:
+			t.Errorf("%s: expected no suppression, got %s", tt.name, suppressions.suppressions[0].Contact)
+		case tt.wantSuppressed != "" && (len(suppressions.suppressions) != 1 || suppressions.suppressions[0].Contact != tt.wantSuppressed):
+			t.Errorf("%s: expected %s to be suppressed, got %v", tt.name, tt.wantSuppressed, suppressions.suppressions)
+		}
+	}
+}
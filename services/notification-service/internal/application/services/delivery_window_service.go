@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// DeliveryPlan quando a notificação deve sair: DeliverAt adia o envio para a próxima janela
+// permitida e DigestAt agrupa a notificação no resumo. Ambos nil indicam envio imediato.
+type DeliveryPlan struct {
+	DeliverAt *time.Time
+	DigestAt  *time.Time
+}
+
+// DeliveryWindowService aplica horário de silêncio, horário comercial e resumos das
+// preferências do usuário e do tenant
+type DeliveryWindowService struct {
+	settingsRepo   domain.DeliverySettingsRepository
+	preferenceRepo domain.NotificationPreferenceRepository
+	logger         *zap.Logger
+}
+
+// NewDeliveryWindowService cria nova instância do serviço
+func NewDeliveryWindowService(
+	settingsRepo domain.DeliverySettingsRepository,
+	preferenceRepo domain.NotificationPreferenceRepository,
+	logger *zap.Logger,
+) *DeliveryWindowService {
+	return &DeliveryWindowService{
+		settingsRepo:   settingsRepo,
+		preferenceRepo: preferenceRepo,
+		logger:         logger,
+	}
+}
+
+// Plan calcula a entrega da notificação a partir de at (agora ou o agendamento pedido)
+func (s *DeliveryWindowService) Plan(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	recipientID string,
+	notificationType domain.NotificationType,
+	priority domain.NotificationPriority,
+	at time.Time,
+) (DeliveryPlan, error) {
+	userID := recipientUserID(recipientID)
+
+	settings, err := s.ResolveSettings(ctx, tenantID, userID)
+	if err != nil {
+		return DeliveryPlan{}, err
+	}
+
+	if settings.Overrides(priority) {
+		return DeliveryPlan{}, nil
+	}
+
+	if userID != nil && domain.SupportsDigest(notificationType) {
+		preference, err := s.preferenceRepo.FindByUserAndType(ctx, *userID, notificationType)
+		if err != nil && !errors.Is(err, domain.ErrPreferenceNotFound) {
+			return DeliveryPlan{}, err
+		}
+		if preference != nil && preference.Enabled && preference.DigestFrequency != "" && preference.DigestFrequency != domain.DigestFrequencyNone {
+			digestAt := settings.NextDigestTime(preference.DigestFrequency, at)
+			return DeliveryPlan{DigestAt: &digestAt}, nil
+		}
+	}
+
+	deliverAt := settings.NextDeliveryTime(priority, at)
+	if deliverAt.After(at) {
+		return DeliveryPlan{DeliverAt: &deliverAt}, nil
+	}
+
+	return DeliveryPlan{}, nil
+}
+
+// ResolveSettings configuração efetiva: a do usuário (ou o padrão do tenant) com as regras
+// obrigatórias do tenant. Sem configuração salva, usa os valores padrão.
+func (s *DeliveryWindowService) ResolveSettings(ctx context.Context, tenantID uuid.UUID, userID *uuid.UUID) (*domain.DeliverySettings, error) {
+	tenantDefault, err := s.settingsRepo.FindTenantDefault(ctx, tenantID)
+	if err != nil && !errors.Is(err, domain.ErrDeliverySettingsNotFound) {
+		return nil, err
+	}
+
+	if userID != nil {
+		userSettings, err := s.settingsRepo.FindByUser(ctx, tenantID, *userID)
+		if err == nil {
+			return userSettings.WithTenantRules(tenantDefault), nil
+		}
+		if !errors.Is(err, domain.ErrDeliverySettingsNotFound) {
+			return nil, err
+		}
+	}
+
+	if tenantDefault != nil {
+		return tenantDefault, nil
+	}
+	return domain.NewDeliverySettings(tenantID, userID), nil
+}
+
+// GetUserSettings retorna a configuração salva do usuário ou, sem ela, o padrão aplicável
+func (s *DeliveryWindowService) GetUserSettings(ctx context.Context, tenantID, userID uuid.UUID) (*domain.DeliverySettings, error) {
+	settings, err := s.settingsRepo.FindByUser(ctx, tenantID, userID)
+	if err == nil {
+		return settings, nil
+	}
+	if !errors.Is(err, domain.ErrDeliverySettingsNotFound) {
+		return nil, err
+	}
+
+	settings, err = s.GetTenantSettings(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	userSettings := *settings
+	userSettings.ID = uuid.New()
+	userSettings.UserID = &userID
+	return &userSettings, nil
+}
+
+// GetTenantSettings retorna o padrão do tenant ou os valores padrão
+func (s *DeliveryWindowService) GetTenantSettings(ctx context.Context, tenantID uuid.UUID) (*domain.DeliverySettings, error) {
+	settings, err := s.settingsRepo.FindTenantDefault(ctx, tenantID)
+	if errors.Is(err, domain.ErrDeliverySettingsNotFound) {
+		return domain.NewDeliverySettings(tenantID, nil), nil
+	}
+	return settings, err
+}
+
+// SaveSettings valida e salva a configuração do usuário ou o padrão do tenant
+func (s *DeliveryWindowService) SaveSettings(ctx context.Context, settings *domain.DeliverySettings) error {
+	if err := settings.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrPreferenceInvalid, err)
+	}
+
+	if err := s.settingsRepo.Upsert(ctx, settings); err != nil {
+		return err
+	}
+
+	s.logger.Info("Delivery settings updated",
+		zap.String("tenant_id", settings.TenantID.String()),
+		zap.Bool("tenant_default", settings.UserID == nil))
+	return nil
+}
+
+// recipientUserID interpreta o destinatário como usuário quando é um UUID
+func recipientUserID(recipientID string) *uuid.UUID {
+	userID, err := uuid.Parse(recipientID)
+	if err != nil {
+		return nil
+	}
+	return &userID
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// fakeDeliverySettingsRepo configurações em memória: padrão do tenant e do usuário
+type fakeDeliverySettingsRepo struct {
+	domain.DeliverySettingsRepository
+	tenant *domain.DeliverySettings
+	users  map[uuid.UUID]*domain.DeliverySettings
+}
+
+func (r *fakeDeliverySettingsRepo) FindByUser(ctx context.Context, tenantID, userID uuid.UUID) (*domain.DeliverySettings, error) {
+	if settings, ok := r.users[userID]; ok {
+		return settings, nil
+	}
+	return nil, domain.ErrDeliverySettingsNotFound
+}
+
+func (r *fakeDeliverySettingsRepo) FindTenantDefault(ctx context.Context, tenantID uuid.UUID) (*domain.DeliverySettings, error) {
+	if r.tenant == nil {
+		return nil, domain.ErrDeliverySettingsNotFound
+	}
+	return r.tenant, nil
+}
+
+// fakePreferenceRepo preferências em memória
+type fakePreferenceRepo struct {
+	domain.NotificationPreferenceRepository
+	preferences []*domain.NotificationPreference
+}
+
+func (r *fakePreferenceRepo) FindByUserAndType(ctx context.Context, userID uuid.UUID, notificationType domain.NotificationType) (*domain.NotificationPreference, error) {
+	for _, preference := range r.preferences {
+		if preference.UserID == userID && preference.Type == notificationType {
+			return preference, nil
+		}
+	}
+	return nil, domain.ErrPreferenceNotFound
+}
+
+// windowFixture tenant com horário comercial 09:00-17:00 e usuário com silêncio 22:00-07:00,
+// ambos em São Paulo
+type windowFixture struct {
+	tenantID    uuid.UUID
+	userID      uuid.UUID
+	settings    *fakeDeliverySettingsRepo
+	preferences *fakePreferenceRepo
+	windows     *DeliveryWindowService
+	loc         *time.Location
+}
+
+func newWindowFixture(t *testing.T) *windowFixture {
+	t.Helper()
+	loc, err := time.LoadLocation(domain.DefaultTimezone)
+	if err != nil {
+		t.Fatalf("LoadLocation() error = %v", err)
+	}
+
+	tenantID, userID := uuid.New(), uuid.New()
+	tenant := domain.NewDeliverySettings(tenantID, nil)
+	tenant.BusinessHoursOnly = true
+	tenant.BusinessHoursStart = "09:00"
+	tenant.BusinessHoursEnd = "17:00"
+
+	user := domain.NewDeliverySettings(tenantID, &userID)
+	user.QuietHoursEnabled = true
+
+	settings := &fakeDeliverySettingsRepo{tenant: tenant, users: map[uuid.UUID]*domain.DeliverySettings{userID: user}}
+	preferences := &fakePreferenceRepo{}
+	return &windowFixture{
+		tenantID:    tenantID,
+		userID:      userID,
+		settings:    settings,
+		preferences: preferences,
+		windows:     NewDeliveryWindowService(settings, preferences, zap.NewNop()),
+		loc:         loc,
+	}
+}
+
+func TestDeliveryWindowServicePlan(t *testing.T) {
+	f := newWindowFixture(t)
+	f.preferences.preferences = []*domain.NotificationPreference{
+		{UserID: f.userID, Type: domain.NotificationTypeMovementAlert, Enabled: true, DigestFrequency: domain.DigestFrequencyDaily},
+		{UserID: f.userID, Type: domain.NotificationTypeDeadlineReminder, Enabled: true, DigestFrequency: domain.DigestFrequencyDaily},
+	}
+	saturday := time.Date(2025, 3, 15, 10, 0, 0, 0, f.loc)
+	wednesday := time.Date(2025, 3, 12, 12, 0, 0, 0, f.loc)
+
+	tests := []struct {
+		name             string
+		recipientID      string
+		notificationType domain.NotificationType
+		priority         domain.NotificationPriority
+		at               time.Time
+		wantDeliver      *time.Time
+		wantDigest       *time.Time
+	}{
+		{"horário comercial do tenant sobre o usuário", f.userID.String(), domain.NotificationTypeSystemAlert, domain.NotificationPriorityNormal, saturday, timePtr(time.Date(2025, 3, 17, 9, 0, 0, 0, f.loc)), nil},
+		{"dentro da janela", f.userID.String(), domain.NotificationTypeSystemAlert, domain.NotificationPriorityNormal, wednesday, nil, nil},
+		{"prioridade que ignora a janela", f.userID.String(), domain.NotificationTypeSystemAlert, domain.NotificationPriorityCritical, saturday, nil, nil},
+		{"resumo diário", f.userID.String(), domain.NotificationTypeMovementAlert, domain.NotificationPriorityNormal, saturday, nil, timePtr(time.Date(2025, 3, 16, 8, 0, 0, 0, f.loc))},
+		{"tipo sem resumo", f.userID.String(), domain.NotificationTypeDeadlineReminder, domain.NotificationPriorityNormal, wednesday, nil, nil},
+		{"destinatário sem usuário usa o padrão do tenant", "contato-externo", domain.NotificationTypeMovementAlert, domain.NotificationPriorityNormal, wednesday.Add(6 * time.Hour), timePtr(time.Date(2025, 3, 13, 9, 0, 0, 0, f.loc)), nil},
+	}
+
+	for _, tt := range tests {
+		plan, err := f.windows.Plan(context.Background(), f.tenantID, tt.recipientID, tt.notificationType, tt.priority, tt.at)
+		if err != nil {
+			t.Fatalf("%s: Plan() error = %v", tt.name, err)
+		}
+		if !sameTime(plan.DeliverAt, tt.wantDeliver) {
+			t.Errorf("%s: DeliverAt = %v, want %v", tt.name, plan.DeliverAt, tt.wantDeliver)
+		}
+		if !sameTime(plan.DigestAt, tt.wantDigest) {
+			t.Errorf("%s: DigestAt = %v, want %v", tt.name, plan.DigestAt, tt.wantDigest)
+		}
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func sameTime(got, want *time.Time) bool {
+	if got == nil || want == nil {
+		return got == want
+	}
+	return got.Equal(*want)
+}
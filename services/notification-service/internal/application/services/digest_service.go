@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// digestGroupKey agrupa itens do resumo por destinatário e canal
+type digestGroupKey struct {
+	tenantID         string
+	recipientID      string
+	channel          domain.NotificationChannel
+	recipientContact string
+}
+
+// DigestService envia periodicamente os resumos vencidos, uma notificação por destinatário e canal
+type DigestService struct {
+	notificationRepo domain.NotificationRepository
+	queue            domain.NotificationQueue
+	windows          *DeliveryWindowService
+	interval         time.Duration
+	batchSize        int
+	logger           *zap.Logger
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewDigestService cria nova instância do serviço
+func NewDigestService(
+	notificationRepo domain.NotificationRepository,
+	queue domain.NotificationQueue,
+	windows *DeliveryWindowService,
+	interval time.Duration,
+	batchSize int,
+	logger *zap.Logger,
+) *DigestService {
+	return &DigestService{
+		notificationRepo: notificationRepo,
+		queue:            queue,
+		windows:          windows,
+		interval:         interval,
+		batchSize:        batchSize,
+		logger:           logger,
+	}
+}
+
+// Start inicia o envio periódico dos resumos
+func (s *DigestService) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	s.wg.Add(1)
+	go s.run(ctx)
+}
+
+// Stop interrompe o envio periódico e aguarda o ciclo em andamento
+func (s *DigestService) Stop(ctx context.Context) error {
+	if s.cancel == nil {
+		return nil
+	}
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run loop periódico
+func (s *DigestService) run(ctx context.Context) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.FlushDue(ctx, time.Now()); err != nil {
+				s.logger.Error("Failed to flush notification digests", zap.Error(err))
+			}
+		}
+	}
+}
+
+// FlushDue cria e enfileira os resumos vencidos até now. Retorna a quantidade de resumos criados.
+func (s *DigestService) FlushDue(ctx context.Context, now time.Time) (int, error) {
+	items, err := s.notificationRepo.FindDueDigestItems(ctx, now, s.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	// Itens chegam ordenados por destinatário e canal
+	created := 0
+	for start := 0; start < len(items); {
+		key := digestKey(items[start])
+		end := start + 1
+		for end < len(items) && digestKey(items[end]) == key {
+			end++
+		}
+
+		if err := s.sendDigest(ctx, items[start:end], now); err != nil {
+			s.logger.Error("Failed to send notification digest",
+				zap.String("tenant_id", key.tenantID),
+				zap.String("channel", string(key.channel)),
+				zap.Error(err))
+		} else {
+			created++
+		}
+		start = end
+	}
+
+	return created, nil
+}
+
+// sendDigest cria o resumo dos itens, respeitando a janela de entrega do destinatário
+func (s *DigestService) sendDigest(ctx context.Context, items []*domain.Notification, now time.Time) error {
+	digest, err := domain.NewDigestNotification(items)
+	if err != nil {
+		return err
+	}
+
+	plan, err := s.windows.Plan(ctx, digest.TenantID, digest.RecipientID, digest.Type, digest.Priority, now)
+	if err != nil {
+		s.logger.Warn("Failed to resolve delivery window for digest", zap.Error(err))
+	} else if plan.DeliverAt != nil {
+		digest.Status = domain.NotificationStatusScheduled
+		digest.ScheduledAt = plan.DeliverAt
+	}
+
+	if err := s.notificationRepo.Create(ctx, digest); err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		item.MarkAsDigested(digest.ID)
+	}
+	if err := s.notificationRepo.UpdateBatch(ctx, items); err != nil {
+		return err
+	}
+
+	if digest.ScheduledAt != nil {
+		err = s.queue.EnqueueScheduled(ctx, digest, *digest.ScheduledAt)
+	} else {
+		err = s.queue.Enqueue(ctx, digest)
+	}
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("Notification digest created",
+		zap.String("digest_id", digest.ID.String()),
+		zap.String("channel", string(digest.Channel)),
+		zap.Int("items", len(items)))
+
+	return nil
+}
+
+// digestKey chave de agrupamento do item
+func digestKey(notification *domain.Notification) digestGroupKey {
+	return digestGroupKey{
+		tenantID:         notification.TenantID.String(),
+		recipientID:      notification.RecipientID,
+		channel:          notification.Channel,
+		recipientContact: notification.RecipientContact,
+	}
+}
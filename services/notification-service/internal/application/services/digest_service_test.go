@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// newDigestItem item de resumo pendente do destinatário no canal
+func newDigestItem(tenantID uuid.UUID, recipientID string, channel domain.NotificationChannel, contact string) *domain.Notification {
+	return &domain.Notification{
+		ID:               uuid.New(),
+		TenantID:         tenantID,
+		Type:             domain.NotificationTypeMovementAlert,
+		Channel:          channel,
+		Priority:         domain.NotificationPriorityNormal,
+		Status:           domain.NotificationStatusPending,
+		Subject:          "Nova movimentação",
+		Content:          "Decisão publicada no processo.",
+		RecipientID:      recipientID,
+		RecipientContact: contact,
+	}
+}
+
+func TestDigestServiceFlushDue(t *testing.T) {
+	f := newWindowFixture(t)
+	user := f.userID.String()
+
+	tests := []struct {
+		name          string
+		now           time.Time
+		wantScheduled *time.Time
+	}{
+		{"dentro da janela", time.Date(2025, 3, 12, 12, 0, 0, 0, f.loc), nil},
+		{"fim de semana aguarda o horário do tenant", time.Date(2025, 3, 15, 10, 0, 0, 0, f.loc), timePtr(time.Date(2025, 3, 17, 9, 0, 0, 0, f.loc))},
+	}
+
+	for _, tt := range tests {
+		repo := &fakeNotificationRepo{dueDigest: []*domain.Notification{
+			newDigestItem(f.tenantID, user, domain.NotificationChannelEmail, "ana@example.com"),
+			newDigestItem(f.tenantID, user, domain.NotificationChannelEmail, "ana@example.com"),
+			newDigestItem(f.tenantID, user, domain.NotificationChannelTelegram, "123456"),
+		}}
+		queue := &fakeNotificationQueue{}
+		service := NewDigestService(repo, queue, f.windows, time.Minute, 100, zap.NewNop())
+
+		created, err := service.FlushDue(context.Background(), tt.now)
+		if err != nil {
+			t.Fatalf("%s: FlushDue() error = %v", tt.name, err)
+		}
+		if created != 2 || len(repo.created) != 2 {
+			t.Fatalf("%s: FlushDue() created = %d (%d saved), want 2", tt.name, created, len(repo.created))
+		}
+
+		email := repo.created[0]
+		if email.Channel != domain.NotificationChannelEmail || email.Metadata["digest_items"] != 2 || email.ContentHTML == nil {
+			t.Errorf("%s: email digest = %+v, want 2 items rendered as HTML", tt.name, email)
+		}
+		for i, item := range repo.dueDigest {
+			digest := repo.created[0]
+			if i == 2 {
+				digest = repo.created[1]
+			}
+			if item.Status != domain.NotificationStatusDigested || item.DigestID == nil || *item.DigestID != digest.ID {
+				t.Errorf("%s: item %d status = %s, digest = %v, want digested into %s", tt.name, i, item.Status, item.DigestID, digest.ID)
+			}
+		}
+
+		for _, digest := range repo.created {
+			if !sameTime(digest.ScheduledAt, tt.wantScheduled) {
+				t.Errorf("%s: ScheduledAt = %v, want %v", tt.name, digest.ScheduledAt, tt.wantScheduled)
+			}
+			if tt.wantScheduled == nil {
+				continue
+			}
+			if digest.Status != domain.NotificationStatusScheduled || !queue.scheduled[digest.ID].Equal(*tt.wantScheduled) {
+				t.Errorf("%s: digest status = %s, queued at %v, want scheduled at %v", tt.name, digest.Status, queue.scheduled[digest.ID], tt.wantScheduled)
+			}
+		}
+		if tt.wantScheduled == nil && len(queue.enqueued) != 2 {
+			t.Errorf("%s: enqueued = %d, want 2", tt.name, len(queue.enqueued))
+		}
+		if tt.wantScheduled != nil && len(queue.enqueued) != 0 {
+			t.Errorf("%s: enqueued = %d, want 0", tt.name, len(queue.enqueued))
+		}
+	}
+}
+
+func TestDigestServiceFlushDueRespectsBatchSize(t *testing.T) {
+	f := newWindowFixture(t)
+	repo := &fakeNotificationRepo{dueDigest: []*domain.Notification{
+		newDigestItem(f.tenantID, "a", domain.NotificationChannelEmail, "a@example.com"),
+		newDigestItem(f.tenantID, "b", domain.NotificationChannelEmail, "b@example.com"),
+		newDigestItem(f.tenantID, "c", domain.NotificationChannelEmail, "c@example.com"),
+	}}
+	service := NewDigestService(repo, &fakeNotificationQueue{}, f.windows, time.Minute, 2, zap.NewNop())
+
+	created, err := service.FlushDue(context.Background(), time.Date(2025, 3, 12, 12, 0, 0, 0, f.loc))
+	if err != nil {
+		t.Fatalf("FlushDue() error = %v", err)
+	}
+	if created != 2 {
+		t.Errorf("FlushDue() created = %d, want 2", created)
+	}
+	if repo.dueDigest[2].Status != domain.NotificationStatusPending {
+		t.Errorf("item outside the batch status = %s, want pending", repo.dueDigest[2].Status)
+	}
+}
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// EmailSenderDomainService configura o remetente com domínio próprio dos tenants: gera a chave
+// DKIM, informa o registro DNS a publicar e verifica a publicação antes de liberar o domínio
+// para os envios
+type EmailSenderDomainService struct {
+	repo      domain.EmailSenderDomainRepository
+	lookupTXT func(ctx context.Context, name string) ([]string, error)
+	logger    *zap.Logger
+}
+
+// NewEmailSenderDomainService cria nova instância do serviço
+func NewEmailSenderDomainService(repo domain.EmailSenderDomainRepository, logger *zap.Logger) *EmailSenderDomainService {
+	return &EmailSenderDomainService{
+		repo:      repo,
+		lookupTXT: net.DefaultResolver.LookupTXT,
+		logger:    logger,
+	}
+}
+
+// EmailSenderDomainRequest dados do remetente informados pelo tenant
+type EmailSenderDomainRequest struct {
+	FromEmail    string `json:"from_email" binding:"required"`
+	FromName     string `json:"from_name,omitempty"`
+	DKIMSelector string `json:"dkim_selector,omitempty"` // padrão: direitolux
+}
+
+// EmailSenderDomainView remetente do tenant com o registro DKIM a publicar no DNS
+type EmailSenderDomainView struct {
+	*domain.EmailSenderDomain
+	DNSRecord DNSRecord `json:"dns_record"`
+}
+
+// DNSRecord registro DNS a publicar
+type DNSRecord struct {
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Configure cria ou altera o remetente do tenant. Mantendo domínio e seletor, a chave e a
+// verificação são preservadas; caso contrário, uma nova chave é gerada e o domínio precisa ser
+// verificado de novo.
+func (s *EmailSenderDomainService) Configure(ctx context.Context, tenantID uuid.UUID, req *EmailSenderDomainRequest) (*EmailSenderDomainView, error) {
+	senderDomain, err := domain.NewEmailSenderDomain(tenantID, req.FromEmail, req.FromName, req.DKIMSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.repo.GetByTenant(ctx, tenantID)
+	switch {
+	case err == nil && current.Domain == senderDomain.Domain && current.DKIMSelector == senderDomain.DKIMSelector:
+		senderDomain.DKIMPrivateKey = current.DKIMPrivateKey
+		senderDomain.DKIMPublicKey = current.DKIMPublicKey
+		senderDomain.Verified = current.Verified
+		senderDomain.VerifiedAt = current.VerifiedAt
+		senderDomain.CreatedAt = current.CreatedAt
+	case err != nil && !errors.Is(err, domain.ErrEmailSenderDomainNotFound):
+		return nil, err
+	default:
+		privateKey, publicKey, err := generateDKIMKey()
+		if err != nil {
+			return nil, err
+		}
+		senderDomain.DKIMPrivateKey = privateKey
+		senderDomain.DKIMPublicKey = publicKey
+	}
+
+	if err := s.repo.Save(ctx, senderDomain); err != nil {
+		return nil, err
+	}
+
+	return newEmailSenderDomainView(senderDomain), nil
+}
+
+// Get busca o remetente do tenant
+func (s *EmailSenderDomainService) Get(ctx context.Context, tenantID uuid.UUID) (*EmailSenderDomainView, error) {
+	senderDomain, err := s.repo.GetByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return newEmailSenderDomainView(senderDomain), nil
+}
+
+// Verify consulta o registro DKIM no DNS e libera o domínio para os envios. Retorna
+// ErrEmailSenderDomainDNS enquanto o registro não estiver publicado.
+func (s *EmailSenderDomainService) Verify(ctx context.Context, tenantID uuid.UUID) (*EmailSenderDomainView, error) {
+	senderDomain, err := s.repo.GetByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := s.lookupTXT(ctx, senderDomain.DKIMRecordName())
+	if err != nil {
+		s.logger.Info("DKIM record lookup failed",
+			zap.String("record", senderDomain.DKIMRecordName()),
+			zap.Error(err))
+	}
+
+	found := false
+	for _, record := range records {
+		if senderDomain.DKIMRecordMatches(record) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: %s", domain.ErrEmailSenderDomainDNS, senderDomain.DKIMRecordName())
+	}
+
+	if !senderDomain.Verified {
+		senderDomain.MarkVerified()
+		if err := s.repo.Save(ctx, senderDomain); err != nil {
+			return nil, err
+		}
+		s.logger.Info("Email sender domain verified",
+			zap.String("tenant_id", tenantID.String()),
+			zap.String("domain", senderDomain.Domain))
+	}
+
+	return newEmailSenderDomainView(senderDomain), nil
+}
+
+// Delete remove o remetente do tenant; os emails voltam a sair pelo remetente padrão
+func (s *EmailSenderDomainService) Delete(ctx context.Context, tenantID uuid.UUID) error {
+	return s.repo.Delete(ctx, tenantID)
+}
+
+// newEmailSenderDomainView acrescenta o registro DNS ao remetente
+func newEmailSenderDomainView(senderDomain *domain.EmailSenderDomain) *EmailSenderDomainView {
+	return &EmailSenderDomainView{
+		EmailSenderDomain: senderDomain,
+		DNSRecord: DNSRecord{
+			Type:  "TXT",
+			Name:  senderDomain.DKIMRecordName(),
+			Value: senderDomain.DKIMRecordValue(),
+		},
+	}
+}
+
+// generateDKIMKey gera o par de chaves RSA de 2048 bits: a privada em PEM (PKCS#1) e a pública
+// em base64 DER, para o p= do registro DNS
+func generateDKIMKey() (string, string, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate DKIM key: %w", err)
+	}
+
+	publicDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to marshal DKIM public key: %w", err)
+	}
+
+	privatePEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	return strings.TrimSpace(string(privatePEM)), base64.StdEncoding.EncodeToString(publicDER), nil
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// trackedLinkPattern links http(s) em atributos href com aspas duplas
+var trackedLinkPattern = regexp.MustCompile(`(?i)href="(https?://[^"]+)"`)
+
+// EngagementTracker gera e valida as URLs assinadas de rastreamento de abertura e clique dos
+// emails. A assinatura cobre a notificação e o destino do link, impedindo que a rota de clique
+// seja usada como redirecionamento aberto.
+type EngagementTracker struct {
+	baseURL string
+	secret  []byte
+}
+
+// NewEngagementTracker cria o rastreador; retorna nil (rastreamento desativado) sem URL base
+// ou segredo
+func NewEngagementTracker(baseURL, secret string) *EngagementTracker {
+	if baseURL == "" || secret == "" {
+		return nil
+	}
+	return &EngagementTracker{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		secret:  []byte(secret),
+	}
+}
+
+// OpenURL URL do pixel de abertura da notificação
+func (t *EngagementTracker) OpenURL(notificationID uuid.UUID) string {
+	query := url.Values{"sig": {t.sign(notificationID, "")}}
+	return t.baseURL + "/track/open/" + notificationID.String() + "?" + query.Encode()
+}
+
+// ClickURL URL rastreada que redireciona para o destino do link
+func (t *EngagementTracker) ClickURL(notificationID uuid.UUID, target string) string {
+	query := url.Values{"url": {target}, "sig": {t.sign(notificationID, target)}}
+	return t.baseURL + "/track/click/" + notificationID.String() + "?" + query.Encode()
+}
+
+// Verify valida a assinatura recebida na rota de rastreamento (target vazio no pixel)
+func (t *EngagementTracker) Verify(notificationID uuid.UUID, target, signature string) bool {
+	expected := t.sign(notificationID, target)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+// InstrumentHTML reescreve os links do email para a rota de clique e inclui o pixel de abertura
+func (t *EngagementTracker) InstrumentHTML(notificationID uuid.UUID, content string) string {
+	content = trackedLinkPattern.ReplaceAllStringFunc(content, func(match string) string {
+		target := html.UnescapeString(trackedLinkPattern.FindStringSubmatch(match)[1])
+		return `href="` + html.EscapeString(t.ClickURL(notificationID, target)) + `"`
+	})
+
+	pixel := `<img src="` + html.EscapeString(t.OpenURL(notificationID)) +
+		`" width="1" height="1" alt="" style="display:none">`
+
+	if i := strings.LastIndex(strings.ToLower(content), "</body>"); i >= 0 {
+		return content[:i] + pixel + content[i:]
+	}
+	return content + pixel
+}
+
+// sign assinatura HMAC-SHA256 da notificação e do destino
+func (t *EngagementTracker) sign(notificationID uuid.UUID, target string) string {
+	mac := hmac.New(sha256.New, t.secret)
+	mac.Write([]byte(notificationID.String()))
+	mac.Write([]byte{0})
+	mac.Write([]byte(target))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,294 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// FallbackStep canal e contato de uma etapa da cadeia de fallback
+type FallbackStep struct {
+	Channel domain.NotificationChannel
+	Contact string
+}
+
+// FallbackService coordena cadeias de fallback de canal. Cada etapa é uma notificação
+// (tentativa) ligada à raiz; as etapas seguintes ficam agendadas para o fim do timeout
+// da anterior e são canceladas assim que algum canal confirma a entrega.
+type FallbackService struct {
+	notificationRepo domain.NotificationRepository
+	queue            domain.NotificationQueue
+	providers        map[domain.NotificationChannel]domain.NotificationProvider
+	policies         *domain.FallbackPolicies
+	logger           *zap.Logger
+}
+
+// NewFallbackService cria nova instância do serviço
+func NewFallbackService(
+	notificationRepo domain.NotificationRepository,
+	queue domain.NotificationQueue,
+	providers map[domain.NotificationChannel]domain.NotificationProvider,
+	policies *domain.FallbackPolicies,
+	logger *zap.Logger,
+) *FallbackService {
+	return &FallbackService{
+		notificationRepo: notificationRepo,
+		queue:            queue,
+		providers:        providers,
+		policies:         policies,
+		logger:           logger,
+	}
+}
+
+// PlanSteps monta as etapas da política do tipo e prioridade, na ordem da política, apenas
+// com canais que têm contato e provedor configurado. Retorna nil se não houver cadeia (menos
+// de duas etapas).
+func (s *FallbackService) PlanSteps(
+	notificationType domain.NotificationType,
+	priority domain.NotificationPriority,
+	requestedChannel *domain.NotificationChannel,
+	recipientContact string,
+	contacts map[domain.NotificationChannel]string,
+) (*domain.FallbackPolicy, []FallbackStep) {
+	policy := s.policies.Find(notificationType, priority)
+	if policy == nil {
+		return nil, nil
+	}
+
+	steps := make([]FallbackStep, 0, len(policy.Channels))
+	for _, channel := range policy.Channels {
+		contact := contacts[channel]
+		if contact == "" && requestedChannel != nil && *requestedChannel == channel {
+			contact = recipientContact
+		}
+		if contact == "" {
+			continue
+		}
+		if _, exists := s.providers[channel]; !exists {
+			continue
+		}
+		steps = append(steps, FallbackStep{Channel: channel, Contact: contact})
+	}
+
+	if len(steps) < 2 {
+		return nil, nil
+	}
+	return policy, steps
+}
+
+// LinkAttempts liga as tentativas à raiz (primeira) e agenda cada etapa seguinte
+// para o fim do timeout da anterior
+func (s *FallbackService) LinkAttempts(policy *domain.FallbackPolicy, attempts []*domain.Notification) {
+	root := attempts[0]
+	base := root.CreatedAt
+	if root.ScheduledAt != nil {
+		base = *root.ScheduledAt
+	}
+
+	for step, attempt := range attempts {
+		rootID := root.ID
+		attempt.FallbackRootID = &rootID
+		attempt.FallbackStep = step
+
+		if step > 0 {
+			scheduledAt := base.Add(time.Duration(step) * policy.StepTimeout)
+			attempt.Status = domain.NotificationStatusScheduled
+			attempt.ScheduledAt = &scheduledAt
+		}
+	}
+}
+
+// HandleAttemptUpdate reage à mudança de status de uma tentativa: entrega confirmada cancela
+// o restante da cadeia, envio reagenda as etapas seguintes a partir do envio e falha escala
+// imediatamente para o próximo canal. A tentativa recebida é alterada, mas não salva.
+func (s *FallbackService) HandleAttemptUpdate(ctx context.Context, attempt *domain.Notification) error {
+	if !attempt.IsFallbackAttempt() {
+		return nil
+	}
+
+	switch {
+	case attempt.IsDeliveryConfirmed():
+		reason := fmt.Sprintf("entrega confirmada pelo canal %s", attempt.Channel)
+		return s.cancelOthers(ctx, attempt, reason)
+
+	case attempt.Status == domain.NotificationStatusSent:
+		return s.onSent(ctx, attempt)
+
+	case attempt.Status == domain.NotificationStatusFailed:
+		return s.Escalate(ctx, attempt)
+	}
+
+	return nil
+}
+
+// Escalate antecipa a próxima etapa da cadeia após falha da tentativa
+func (s *FallbackService) Escalate(ctx context.Context, attempt *domain.Notification) error {
+	if !attempt.IsFallbackAttempt() {
+		return nil
+	}
+
+	attempts, err := s.notificationRepo.FindFallbackAttempts(ctx, *attempt.FallbackRootID)
+	if err != nil {
+		return err
+	}
+
+	next := nextPendingAttempt(attempts, attempt.FallbackStep)
+	if next == nil {
+		// Última etapa: mantém os reenvios normais do canal
+		return nil
+	}
+
+	// A próxima etapa substitui os reenvios no canal que falhou
+	attempt.RetryCount = attempt.MaxRetries
+	attempt.NextRetryAt = nil
+
+	now := time.Now()
+	next.Status = domain.NotificationStatusPending
+	next.ScheduledAt = &now
+	next.UpdatedAt = now
+	if err := s.notificationRepo.Update(ctx, next); err != nil {
+		return fmt.Errorf("failed to escalate fallback attempt: %w", err)
+	}
+
+	if err := s.queue.Enqueue(ctx, next); err != nil {
+		return err
+	}
+
+	s.logger.Info("Fallback escalated to next channel",
+		zap.String("root_id", attempt.FallbackRootID.String()),
+		zap.String("from_channel", string(attempt.Channel)),
+		zap.String("to_channel", string(next.Channel)),
+		zap.Int("step", next.FallbackStep))
+
+	return nil
+}
+
+// CancelChain cancela as tentativas ainda não enviadas da cadeia
+func (s *FallbackService) CancelChain(ctx context.Context, rootID uuid.UUID) error {
+	attempts, err := s.notificationRepo.FindFallbackAttempts(ctx, rootID)
+	if err != nil {
+		return err
+	}
+
+	for _, attempt := range attempts {
+		if err := s.cancelAttempt(ctx, attempt, "cadeia de fallback cancelada"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// onSent reagenda as etapas seguintes a partir do envio e encerra as anteriores não enviadas,
+// evitando mensagens duplicadas quando uma etapa atrasada sai depois da seguinte
+func (s *FallbackService) onSent(ctx context.Context, attempt *domain.Notification) error {
+	policy := s.policies.Find(attempt.Type, attempt.Priority)
+	if policy == nil {
+		return nil
+	}
+
+	attempts, err := s.notificationRepo.FindFallbackAttempts(ctx, *attempt.FallbackRootID)
+	if err != nil {
+		return err
+	}
+
+	sentAt := time.Now()
+	if attempt.SentAt != nil {
+		sentAt = *attempt.SentAt
+	}
+
+	for _, other := range attempts {
+		if other.ID == attempt.ID {
+			continue
+		}
+
+		if other.FallbackStep < attempt.FallbackStep {
+			if err := s.cancelAttempt(ctx, other, "etapa posterior da cadeia já enviada"); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if other.Status != domain.NotificationStatusScheduled {
+			continue
+		}
+
+		scheduledAt := sentAt.Add(time.Duration(other.FallbackStep-attempt.FallbackStep) * policy.StepTimeout)
+		other.ScheduledAt = &scheduledAt
+		other.UpdatedAt = time.Now()
+		if err := s.notificationRepo.Update(ctx, other); err != nil {
+			return fmt.Errorf("failed to reschedule fallback attempt: %w", err)
+		}
+		if err := s.queue.EnqueueScheduled(ctx, other, scheduledAt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cancelOthers cancela as demais tentativas da cadeia
+func (s *FallbackService) cancelOthers(ctx context.Context, attempt *domain.Notification, reason string) error {
+	attempts, err := s.notificationRepo.FindFallbackAttempts(ctx, *attempt.FallbackRootID)
+	if err != nil {
+		return err
+	}
+
+	for _, other := range attempts {
+		if other.ID == attempt.ID {
+			continue
+		}
+		if err := s.cancelAttempt(ctx, other, reason); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cancelAttempt cancela a tentativa se ela ainda não foi enviada e interrompe reenvios pendentes
+func (s *FallbackService) cancelAttempt(ctx context.Context, attempt *domain.Notification, reason string) error {
+	switch {
+	case attempt.Status == domain.NotificationStatusPending || attempt.Status == domain.NotificationStatusScheduled:
+		attempt.Status = domain.NotificationStatusCancelled
+		attempt.ErrorMessage = &reason
+	case attempt.CanRetry():
+		attempt.RetryCount = attempt.MaxRetries
+		attempt.NextRetryAt = nil
+	default:
+		return nil
+	}
+
+	attempt.UpdatedAt = time.Now()
+	if err := s.notificationRepo.Update(ctx, attempt); err != nil {
+		return fmt.Errorf("failed to cancel fallback attempt: %w", err)
+	}
+
+	// Remove da fila; um envio já em andamento em algum worker não é interrompido
+	if err := s.queue.Ack(ctx, attempt.ID); err != nil {
+		return err
+	}
+
+	s.logger.Info("Fallback attempt cancelled",
+		zap.String("notification_id", attempt.ID.String()),
+		zap.String("channel", string(attempt.Channel)),
+		zap.String("reason", reason))
+
+	return nil
+}
+
+// nextPendingAttempt retorna a próxima etapa ainda não iniciada após step
+func nextPendingAttempt(attempts []*domain.Notification, step int) *domain.Notification {
+	for _, attempt := range attempts {
+		if attempt.FallbackStep <= step {
+			continue
+		}
+		if attempt.Status == domain.NotificationStatusScheduled || attempt.Status == domain.NotificationStatusPending {
+			return attempt
+		}
+	}
+	return nil
+}
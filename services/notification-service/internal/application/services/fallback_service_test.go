@@ -0,0 +1,333 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+const testStepTimeout = 10 * time.Minute
+
+// fallbackFixture serviço de fallback com repositório e fila em memória; o Telegram não tem
+// provedor configurado
+type fallbackFixture struct {
+	service *FallbackService
+	repo    *fakeNotificationRepo
+	queue   *fakeNotificationQueue
+}
+
+func newFallbackFixture() *fallbackFixture {
+	f := &fallbackFixture{
+		repo:  &fakeNotificationRepo{},
+		queue: &fakeNotificationQueue{},
+	}
+
+	providers := map[domain.NotificationChannel]domain.NotificationProvider{
+		domain.NotificationChannelWhatsApp: &fakeProvider{},
+		domain.NotificationChannelEmail:    &fakeProvider{},
+		domain.NotificationChannelSMS:      &fakeProvider{},
+	}
+	f.service = NewFallbackService(f.repo, f.queue, providers, domain.DefaultFallbackPolicies(testStepTimeout), zap.NewNop())
+	return f
+}
+
+// newChain cria as tentativas de um alerta crítico nos canais informados, ligadas à primeira
+func (f *fallbackFixture) newChain(t *testing.T, channels ...domain.NotificationChannel) []*domain.Notification {
+	t.Helper()
+
+	tenantID := uuid.New()
+	attempts := make([]*domain.Notification, len(channels))
+	for i, channel := range channels {
+		attempt, err := domain.NewNotification(
+			domain.NotificationTypeDeadlineReminder,
+			channel,
+			domain.NotificationPriorityCritical,
+			"Prazo", "Prazo vence hoje",
+			"user-1", "user", "contato-"+string(channel),
+			tenantID,
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		attempt.MaxRetries = 3
+		attempts[i] = attempt
+	}
+
+	policy := f.service.policies.Find(domain.NotificationTypeDeadlineReminder, domain.NotificationPriorityCritical)
+	f.service.LinkAttempts(policy, attempts)
+	f.repo.notifications = attempts
+	return attempts
+}
+
+// acked indica se a tentativa foi removida da fila
+func (f *fallbackFixture) acked(attempt *domain.Notification) bool {
+	for _, id := range f.queue.acks {
+		if id == attempt.ID {
+			return true
+		}
+	}
+	return false
+}
+
+func TestFallbackServicePlanSteps(t *testing.T) {
+	email := domain.NotificationChannelEmail
+	allContacts := map[domain.NotificationChannel]string{
+		domain.NotificationChannelWhatsApp: "+5541999998888",
+		domain.NotificationChannelTelegram: "123456",
+		domain.NotificationChannelEmail:    "advogado@example.com",
+		domain.NotificationChannelSMS:      "+5541999998888",
+	}
+
+	tests := []struct {
+		name      string
+		priority  domain.NotificationPriority
+		requested *domain.NotificationChannel
+		recipient string
+		contacts  map[domain.NotificationChannel]string
+		want      []FallbackStep
+	}{
+		{
+			name:     "sem política para a prioridade",
+			priority: domain.NotificationPriorityNormal,
+			contacts: allContacts,
+		},
+		{
+			name:     "ordem da política sem canais sem provedor",
+			priority: domain.NotificationPriorityCritical,
+			contacts: allContacts,
+			want: []FallbackStep{
+				{Channel: domain.NotificationChannelWhatsApp, Contact: "+5541999998888"},
+				{Channel: domain.NotificationChannelEmail, Contact: "advogado@example.com"},
+				{Channel: domain.NotificationChannelSMS, Contact: "+5541999998888"},
+			},
+		},
+		{
+			name:      "contato do canal solicitado completa a cadeia",
+			priority:  domain.NotificationPriorityCritical,
+			requested: &email,
+			recipient: "advogado@example.com",
+			contacts:  map[domain.NotificationChannel]string{domain.NotificationChannelWhatsApp: "+5541999998888"},
+			want: []FallbackStep{
+				{Channel: domain.NotificationChannelWhatsApp, Contact: "+5541999998888"},
+				{Channel: domain.NotificationChannelEmail, Contact: "advogado@example.com"},
+			},
+		},
+		{
+			name:     "uma única etapa não forma cadeia",
+			priority: domain.NotificationPriorityCritical,
+			contacts: map[domain.NotificationChannel]string{domain.NotificationChannelEmail: "advogado@example.com"},
+		},
+	}
+
+	f := newFallbackFixture()
+	for _, tt := range tests {
+		policy, steps := f.service.PlanSteps(domain.NotificationTypeDeadlineReminder, tt.priority, tt.requested, tt.recipient, tt.contacts)
+
+		if len(tt.want) == 0 {
+			if policy != nil || steps != nil {
+				t.Errorf("%s: expected no chain, got %v", tt.name, steps)
+			}
+			continue
+		}
+		if policy == nil || len(steps) != len(tt.want) {
+			t.Fatalf("%s: expected %d steps, got %v", tt.name, len(tt.want), steps)
+		}
+		for i, step := range steps {
+			if step != tt.want[i] {
+				t.Errorf("%s: expected step %d to be %+v, got %+v", tt.name, i, tt.want[i], step)
+			}
+		}
+	}
+}
+
+func TestFallbackServiceLinkAttempts(t *testing.T) {
+	f := newFallbackFixture()
+	attempts := f.newChain(t, domain.NotificationChannelWhatsApp, domain.NotificationChannelEmail, domain.NotificationChannelSMS)
+	root := attempts[0]
+
+	for step, attempt := range attempts {
+		if attempt.FallbackRootID == nil || *attempt.FallbackRootID != root.ID || attempt.FallbackStep != step {
+			t.Errorf("step %d: expected link to root %s, got %v at step %d", step, root.ID, attempt.FallbackRootID, attempt.FallbackStep)
+		}
+	}
+	if root.Status != domain.NotificationStatusPending || root.ScheduledAt != nil {
+		t.Errorf("expected root to stay pending, got %s", root.Status)
+	}
+	for step, attempt := range attempts[1:] {
+		want := root.CreatedAt.Add(time.Duration(step+1) * testStepTimeout)
+		if attempt.Status != domain.NotificationStatusScheduled || attempt.ScheduledAt == nil || !attempt.ScheduledAt.Equal(want) {
+			t.Errorf("step %d: expected scheduled at %v, got %s at %v", step+1, want, attempt.Status, attempt.ScheduledAt)
+		}
+	}
+
+	// Raiz agendada: as etapas partem do agendamento
+	scheduledAt := time.Now().Add(time.Hour)
+	attempts[0].ScheduledAt = &scheduledAt
+	f.service.LinkAttempts(&domain.FallbackPolicy{StepTimeout: testStepTimeout}, attempts)
+	if want := scheduledAt.Add(2 * testStepTimeout); !attempts[2].ScheduledAt.Equal(want) {
+		t.Errorf("expected last step at %v, got %v", want, attempts[2].ScheduledAt)
+	}
+}
+
+func TestFallbackServiceHandleAttemptUpdate(t *testing.T) {
+	sentAt := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name  string
+		setup func(attempts []*domain.Notification) *domain.Notification
+		check func(t *testing.T, f *fallbackFixture, attempts []*domain.Notification)
+	}{
+		{
+			name: "entrega confirmada cancela o restante da cadeia",
+			setup: func(attempts []*domain.Notification) *domain.Notification {
+				attempts[0].Status = domain.NotificationStatusFailed
+				attempts[1].Status = domain.NotificationStatusDelivered
+				return attempts[1]
+			},
+			check: func(t *testing.T, f *fallbackFixture, attempts []*domain.Notification) {
+				if attempts[0].CanRetry() || !f.acked(attempts[0]) {
+					t.Error("expected retries of the failed step to stop")
+				}
+				if attempts[2].Status != domain.NotificationStatusCancelled || !f.acked(attempts[2]) {
+					t.Errorf("expected next step to be cancelled, got %s", attempts[2].Status)
+				}
+				if f.acked(attempts[1]) {
+					t.Error("expected delivered step to be kept")
+				}
+			},
+		},
+		{
+			name: "falha escala imediatamente para o próximo canal",
+			setup: func(attempts []*domain.Notification) *domain.Notification {
+				attempts[0].Status = domain.NotificationStatusFailed
+				return attempts[0]
+			},
+			check: func(t *testing.T, f *fallbackFixture, attempts []*domain.Notification) {
+				next := attempts[1]
+				if next.Status != domain.NotificationStatusPending || next.ScheduledAt == nil || time.Since(*next.ScheduledAt) > time.Second {
+					t.Errorf("expected next step to be pending now, got %s at %v", next.Status, next.ScheduledAt)
+				}
+				if len(f.queue.enqueued) != 1 || f.queue.enqueued[0] != next.ID {
+					t.Errorf("expected next step to be enqueued, got %v", f.queue.enqueued)
+				}
+				if attempts[0].CanRetry() {
+					t.Error("expected next step to replace retries of the failed channel")
+				}
+				if attempts[2].Status != domain.NotificationStatusScheduled {
+					t.Errorf("expected last step to stay scheduled, got %s", attempts[2].Status)
+				}
+			},
+		},
+		{
+			name: "falha na última etapa mantém os reenvios do canal",
+			setup: func(attempts []*domain.Notification) *domain.Notification {
+				attempts[0].Status = domain.NotificationStatusSent
+				attempts[1].Status = domain.NotificationStatusSent
+				attempts[2].Status = domain.NotificationStatusFailed
+				return attempts[2]
+			},
+			check: func(t *testing.T, f *fallbackFixture, attempts []*domain.Notification) {
+				if !attempts[2].CanRetry() || len(f.queue.enqueued) != 0 {
+					t.Error("expected retries to be kept without escalation")
+				}
+			},
+		},
+		{
+			name: "envio reagenda as etapas seguintes a partir do envio",
+			setup: func(attempts []*domain.Notification) *domain.Notification {
+				attempts[0].Status = domain.NotificationStatusSent
+				attempts[0].SentAt = &sentAt
+				return attempts[0]
+			},
+			check: func(t *testing.T, f *fallbackFixture, attempts []*domain.Notification) {
+				for step, attempt := range attempts[1:] {
+					want := sentAt.Add(time.Duration(step+1) * testStepTimeout)
+					if !attempt.ScheduledAt.Equal(want) || !f.queue.scheduled[attempt.ID].Equal(want) {
+						t.Errorf("step %d: expected reschedule at %v, got %v", step+1, want, attempt.ScheduledAt)
+					}
+				}
+			},
+		},
+		{
+			name: "etapa anterior atrasada é cancelada após o envio da seguinte",
+			setup: func(attempts []*domain.Notification) *domain.Notification {
+				attempts[1].Status = domain.NotificationStatusSent
+				attempts[1].SentAt = &sentAt
+				return attempts[1]
+			},
+			check: func(t *testing.T, f *fallbackFixture, attempts []*domain.Notification) {
+				if attempts[0].Status != domain.NotificationStatusCancelled || !f.acked(attempts[0]) {
+					t.Errorf("expected late earlier step to be cancelled, got %s", attempts[0].Status)
+				}
+				if want := sentAt.Add(testStepTimeout); !attempts[2].ScheduledAt.Equal(want) {
+					t.Errorf("expected last step at %v, got %v", want, attempts[2].ScheduledAt)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFallbackFixture()
+			attempts := f.newChain(t, domain.NotificationChannelWhatsApp, domain.NotificationChannelEmail, domain.NotificationChannelSMS)
+
+			if err := f.service.HandleAttemptUpdate(context.Background(), tt.setup(attempts)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			tt.check(t, f, attempts)
+		})
+	}
+}
+
+func TestFallbackServiceIgnoresStandaloneNotifications(t *testing.T) {
+	f := newFallbackFixture()
+	notification := &domain.Notification{ID: uuid.New(), Status: domain.NotificationStatusFailed}
+
+	if err := f.service.HandleAttemptUpdate(context.Background(), notification); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if f.repo.updates != 0 || len(f.queue.enqueued) != 0 {
+		t.Error("expected notification outside a chain to be ignored")
+	}
+}
+
+func TestFallbackServiceCancelAttempt(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     domain.NotificationStatus
+		retryCount int
+		wantStatus domain.NotificationStatus
+		wantAck    bool
+	}{
+		{"pendente", domain.NotificationStatusPending, 0, domain.NotificationStatusCancelled, true},
+		{"agendada", domain.NotificationStatusScheduled, 0, domain.NotificationStatusCancelled, true},
+		{"falha com reenvios", domain.NotificationStatusFailed, 1, domain.NotificationStatusFailed, true},
+		{"falha sem reenvios", domain.NotificationStatusFailed, 3, domain.NotificationStatusFailed, false},
+		{"enviada", domain.NotificationStatusSent, 0, domain.NotificationStatusSent, false},
+		{"entregue", domain.NotificationStatusDelivered, 0, domain.NotificationStatusDelivered, false},
+	}
+
+	for _, tt := range tests {
+		f := newFallbackFixture()
+		attempt := &domain.Notification{ID: uuid.New(), Status: tt.status, RetryCount: tt.retryCount, MaxRetries: 3}
+
+		if err := f.service.cancelAttempt(context.Background(), attempt, "cadeia de fallback cancelada"); err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+
+		if attempt.Status != tt.wantStatus || f.acked(attempt) != tt.wantAck {
+			t.Errorf("%s: expected %s (ack %v), got %s (ack %v)", tt.name, tt.wantStatus, tt.wantAck, attempt.Status, f.acked(attempt))
+		}
+		if tt.wantAck && attempt.CanRetry() {
+			t.Errorf("%s: expected retries to stop", tt.name)
+		}
+		if tt.wantStatus == domain.NotificationStatusCancelled && (attempt.ErrorMessage == nil || *attempt.ErrorMessage == "") {
+			t.Errorf("%s: expected cancellation reason", tt.name)
+		}
+	}
+}
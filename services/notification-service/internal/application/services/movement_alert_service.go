@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// MovementAlertService transforma as movimentações publicadas pelo process-service em alertas
+// movement_alert para os destinatários do processo. Cada destinatário recebe um único alerta por
+// movimentação, no canal preferido (ou na cadeia de fallback, quando crítico), mesmo que a
+// movimentação chegue em mais de um evento.
+type MovementAlertService struct {
+	notifications *NotificationService
+	watchers      domain.ProcessWatcherRepository
+	alerts        domain.MovementAlertRepository
+	preferences   domain.NotificationPreferenceRepository
+	logger        *zap.Logger
+	now           func() time.Time
+}
+
+// NewMovementAlertService cria nova instância do serviço
+func NewMovementAlertService(
+	notifications *NotificationService,
+	watchers domain.ProcessWatcherRepository,
+	alerts domain.MovementAlertRepository,
+	preferences domain.NotificationPreferenceRepository,
+	logger *zap.Logger,
+) *MovementAlertService {
+	return &MovementAlertService{
+		notifications: notifications,
+		watchers:      watchers,
+		alerts:        alerts,
+		preferences:   preferences,
+		logger:        logger,
+		now:           time.Now,
+	}
+}
+
+// HandleMovement cria os alertas da movimentação. Retorna erro quando algum destinatário não
+// pôde ser alertado; os já alertados não são repetidos quando o evento é reprocessado.
+func (s *MovementAlertService) HandleMovement(ctx context.Context, event *domain.MovementEvent) error {
+	watchers, err := s.watchers.FindByProcess(ctx, event.TenantID, event.ProcessID)
+	if err != nil {
+		return fmt.Errorf("failed to find process watchers: %w", err)
+	}
+
+	var failed error
+	alerted := 0
+	for _, watcher := range domain.UniqueProcessWatchers(watchers) {
+		if !watcher.Notifiable() {
+			continue
+		}
+
+		sent, err := s.alert(ctx, event, watcher)
+		if err != nil {
+			s.logger.Error("Failed to create movement alert",
+				zap.String("movement_id", event.MovementID),
+				zap.String("recipient_id", watcher.RecipientID),
+				zap.Error(err))
+			if failed == nil {
+				failed = err
+			}
+			continue
+		}
+		if sent {
+			alerted++
+		}
+	}
+
+	s.logger.Info("Movement processed",
+		zap.String("event_type", event.Type),
+		zap.String("movement_id", event.MovementID),
+		zap.String("process_number", event.ProcessNumber),
+		zap.Int("watchers", len(watchers)),
+		zap.Int("alerted", alerted))
+
+	return failed
+}
+
+// alert cria o alerta para o destinatário; false quando desabilitado por preferência, sem canal
+// ou já alertado
+func (s *MovementAlertService) alert(ctx context.Context, event *domain.MovementEvent, watcher *domain.ProcessWatcher) (bool, error) {
+	var preferred []domain.NotificationChannel
+	if userID, err := uuid.Parse(watcher.RecipientID); err == nil && watcher.Role != domain.ProcessWatcherRoleClient {
+		preference, err := s.preferences.FindByUserAndType(ctx, userID, domain.NotificationTypeMovementAlert)
+		switch {
+		case errors.Is(err, domain.ErrPreferenceNotFound):
+		case err != nil:
+			return false, fmt.Errorf("failed to find preference: %w", err)
+		case !preference.Enabled:
+			return false, nil
+		default:
+			preferred = preference.Channels
+		}
+	}
+
+	channels := domain.MovementAlertChannels(preferred, event.Channels, watcher.Contacts)
+	if len(channels) == 0 {
+		return false, nil
+	}
+
+	claimed, err := s.alerts.Claim(ctx, event.MovementID, watcher.RecipientID, event.Important, s.now())
+	if err != nil {
+		return false, err
+	}
+	if !claimed {
+		return false, nil
+	}
+
+	// O canal preferido é o primeiro; os demais só são usados pela cadeia de fallback dos
+	// alertas críticos
+	contacts := make(map[domain.NotificationChannel]string, len(channels))
+	for _, channel := range channels {
+		contacts[channel] = watcher.Contacts[channel]
+	}
+
+	_, err = s.notifications.CreateNotification(ctx, &CreateNotificationRequest{
+		TenantID:         event.TenantID,
+		Type:             domain.NotificationTypeMovementAlert,
+		Channel:          &channels[0],
+		Priority:         event.Priority(),
+		RecipientID:      watcher.RecipientID,
+		RecipientType:    watcher.RecipientType(),
+		RecipientContact: contacts[channels[0]],
+		Variables:        event.Variables(watcher),
+		Metadata: map[string]interface{}{
+			"event_type":     event.Type,
+			"movement_id":    event.MovementID,
+			"process_id":     event.ProcessID.String(),
+			"process_number": event.ProcessNumber,
+		},
+		FallbackContacts: contacts,
+	})
+	if err != nil {
+		if releaseErr := s.alerts.Release(ctx, event.MovementID, watcher.RecipientID, event.Important); releaseErr != nil {
+			s.logger.Error("Failed to release movement alert", zap.Error(releaseErr))
+		}
+		return false, fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	return true, nil
+}
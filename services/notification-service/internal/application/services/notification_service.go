@@ -2,7 +2,9 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"html"
 	"strings"
 	"time"
 
@@ -16,9 +18,17 @@ import (
 type NotificationService struct {
 	notificationRepo domain.NotificationRepository
 	templateRepo     domain.NotificationTemplateRepository
+	versionRepo      domain.TemplateVersionRepository
 	preferenceRepo   domain.NotificationPreferenceRepository
+	suppressionRepo  domain.SuppressionRepository
 	queue           domain.NotificationQueue
 	providers       map[domain.NotificationChannel]domain.NotificationProvider
+	limiter         *DispatchLimiter
+	fallback        *FallbackService
+	windows         *DeliveryWindowService
+	tracker         *EngagementTracker
+	consents        *ConsentService
+	costs           domain.ChannelCosts
 	logger          *zap.Logger
 }
 
@@ -26,17 +36,33 @@ type NotificationService struct {
 func NewNotificationService(
 	notificationRepo domain.NotificationRepository,
 	templateRepo domain.NotificationTemplateRepository,
+	versionRepo domain.TemplateVersionRepository,
 	preferenceRepo domain.NotificationPreferenceRepository,
+	suppressionRepo domain.SuppressionRepository,
 	queue domain.NotificationQueue,
 	providers map[domain.NotificationChannel]domain.NotificationProvider,
+	limiter *DispatchLimiter,
+	fallback *FallbackService,
+	windows *DeliveryWindowService,
+	tracker *EngagementTracker,
+	consents *ConsentService,
+	costs domain.ChannelCosts,
 	logger *zap.Logger,
 ) *NotificationService {
 	return &NotificationService{
 		notificationRepo: notificationRepo,
 		templateRepo:     templateRepo,
+		versionRepo:      versionRepo,
 		preferenceRepo:   preferenceRepo,
+		suppressionRepo:  suppressionRepo,
 		queue:           queue,
 		providers:       providers,
+		limiter:         limiter,
+		fallback:        fallback,
+		windows:         windows,
+		tracker:         tracker,
+		consents:        consents,
+		costs:           costs,
 		logger:          logger,
 	}
 }
@@ -57,6 +83,13 @@ type CreateNotificationRequest struct {
 	Metadata         map[string]interface{}             `json:"metadata,omitempty"`
 	ScheduledAt      *time.Time                         `json:"scheduled_at,omitempty"`
 	Tags             []string                           `json:"tags,omitempty"`
+	Locale           string                             `json:"locale,omitempty"` // idioma do destinatário; padrão: configurações de entrega
+
+	// Contatos por canal para a cadeia de fallback (aplicada quando há política para o tipo e prioridade)
+	FallbackContacts map[domain.NotificationChannel]string `json:"fallback_contacts,omitempty"`
+
+	// Anexos enviados apenas no email (ex.: PDF do report-service por URL)
+	Attachments []domain.NotificationAttachment `json:"attachments,omitempty"`
 }
 
 // CreateNotification cria uma nova notificação
@@ -65,6 +98,10 @@ func (s *NotificationService) CreateNotification(ctx context.Context, req *Creat
 		zap.String("tenant_id", req.TenantID.String()),
 		zap.String("type", string(req.Type)))
 
+	if err := domain.ValidateAttachments(req.Attachments); err != nil {
+		return nil, err
+	}
+
 	// Validar canais preferidos do usuário se não especificado
 	channels := []domain.NotificationChannel{}
 	if req.Channel != nil {
@@ -89,8 +126,49 @@ func (s *NotificationService) CreateNotification(ctx context.Context, req *Creat
 		}
 	}
 
+	// Janela de entrega do destinatário: horário de silêncio, horário comercial e resumo
+	var digestAt *time.Time
+	if s.windows != nil {
+		at := time.Now()
+		if req.ScheduledAt != nil && req.ScheduledAt.After(at) {
+			at = *req.ScheduledAt
+		}
+
+		plan, err := s.windows.Plan(ctx, req.TenantID, req.RecipientID, req.Type, req.Priority, at)
+		if err != nil {
+			s.logger.Warn("Failed to resolve delivery window, sending without restrictions", zap.Error(err))
+		} else if plan.DeliverAt != nil {
+			deferred := *req
+			deferred.ScheduledAt = plan.DeliverAt
+			req = &deferred
+		} else {
+			digestAt = plan.DigestAt
+		}
+	}
+
 	// Criar notificações para cada canal
 	notifications := make([]*domain.Notification, 0, len(channels))
+
+	// Com política de fallback, os canais são tentados em sequência em vez de em paralelo
+	var policy *domain.FallbackPolicy
+	var steps []FallbackStep
+	if s.fallback != nil && len(req.FallbackContacts) > 0 {
+		policy, steps = s.fallback.PlanSteps(req.Type, req.Priority, req.Channel, req.RecipientContact, req.FallbackContacts)
+	}
+
+	if policy != nil {
+		for _, step := range steps {
+			notification, err := s.createSingleNotification(ctx, req, step.Channel)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create fallback attempt for channel %s: %w", step.Channel, err)
+			}
+			notification.RecipientContact = step.Contact
+			notifications = append(notifications, notification)
+		}
+		s.fallback.LinkAttempts(policy, notifications)
+		channels = nil
+	}
+
 	for _, channel := range channels {
 		notification, err := s.createSingleNotification(ctx, req, channel)
 		if err != nil {
@@ -106,6 +184,13 @@ func (s *NotificationService) CreateNotification(ctx context.Context, req *Creat
 		return nil, fmt.Errorf("failed to create any notification")
 	}
 
+	// Cadeias de fallback não são agrupadas; as demais aguardam o próximo resumo
+	if digestAt != nil && policy == nil {
+		for _, notification := range notifications {
+			notification.Batch(*digestAt)
+		}
+	}
+
 	// Salvar todas as notificações
 	if err := s.notificationRepo.CreateBatch(ctx, notifications); err != nil {
 		return nil, fmt.Errorf("failed to save notifications: %w", err)
@@ -113,6 +198,9 @@ func (s *NotificationService) CreateNotification(ctx context.Context, req *Creat
 
 	// Enfileirar para processamento
 	for _, notification := range notifications {
+		if notification.Status == domain.NotificationStatusBatched {
+			continue
+		}
 		if notification.ScheduledAt != nil {
 			if err := s.queue.EnqueueScheduled(ctx, notification, *notification.ScheduledAt); err != nil {
 				s.logger.Error("Failed to enqueue scheduled notification", 
@@ -153,6 +241,7 @@ func (s *NotificationService) createSingleNotification(ctx context.Context, req
 		MaxRetries:       3,
 		ScheduledAt:      req.ScheduledAt,
 		Tags:             req.Tags,
+		Locale:           req.Locale,
 		CreatedAt:        time.Now(),
 		UpdatedAt:        time.Now(),
 	}
@@ -169,6 +258,13 @@ func (s *NotificationService) createSingleNotification(ctx context.Context, req
 		}
 	}
 
+	if channel == domain.NotificationChannelEmail {
+		notification.Attachments = req.Attachments
+
+		// Link de descadastro no rodapé do email (LGPD)
+		s.addUnsubscribeLink(notification)
+	}
+
 	return notification, nil
 }
 
@@ -191,27 +287,93 @@ func (s *NotificationService) processTemplate(ctx context.Context, notification
 		return fmt.Errorf("template not found: %w", err)
 	}
 
-	// Processar variáveis no template
-	notification.Subject = s.processVariables(template.Subject, notification.Variables)
-	notification.Content = s.processVariables(template.Content, notification.Variables)
+	// Versão publicada no idioma do destinatário, sorteando a variante A/B
+	template = s.resolveTemplateVersion(ctx, notification, template)
+
+	// Renderizar template com o escape do canal; variáveis ausentes ficam vazias
+	rendered, err := template.Render(notification.Variables, false)
+	if err != nil {
+		return fmt.Errorf("failed to render template: %w", err)
+	}
+	if len(rendered.MissingVariables) > 0 {
+		s.logger.Warn("Template rendered with missing variables",
+			zap.String("template_id", template.ID.String()),
+			zap.Strings("missing_variables", rendered.MissingVariables))
+	}
+
+	notification.Subject = rendered.Subject
+	notification.Content = rendered.Content
+	notification.ContentHTML = rendered.ContentHTML
 	notification.TemplateID = &template.ID
 
+	switch notification.Channel {
+	case domain.NotificationChannelEmail:
+		// O provedor envia Content como texto e ContentHTML como a alternativa HTML; templates
+		// só com HTML em Content continuam aceitos
+		if s.tracker != nil {
+			if notification.ContentHTML != nil {
+				instrumented := s.tracker.InstrumentHTML(notification.ID, *notification.ContentHTML)
+				notification.ContentHTML = &instrumented
+			} else if domain.LooksLikeHTML(notification.Content) {
+				notification.Content = s.tracker.InstrumentHTML(notification.ID, notification.Content)
+			}
+		}
+	case domain.NotificationChannelTelegram:
+		// Valores escapados para MarkdownV2; o provedor usa o parse_mode da notificação
+		if notification.Metadata == nil {
+			notification.Metadata = make(map[string]interface{})
+		}
+		notification.Metadata["parse_mode"] = "MarkdownV2"
+	}
+
 	return nil
 }
 
-// processVariables substitui variáveis no texto
-func (s *NotificationService) processVariables(text string, variables map[string]interface{}) string {
-	if variables == nil {
-		return text
+// resolveTemplateVersion escolhe a versão publicada do template para o idioma do destinatário.
+// O idioma vem da requisição ou das configurações de entrega do usuário (ou do tenant). Sem
+// versão aplicável, usa o conteúdo atual do template.
+func (s *NotificationService) resolveTemplateVersion(ctx context.Context, notification *domain.Notification, template *domain.NotificationTemplate) *domain.NotificationTemplate {
+	defaultLocale := template.DefaultLocale
+	if defaultLocale == "" {
+		defaultLocale = domain.DefaultLocale
+	}
+
+	locale := notification.Locale
+	if locale == "" && s.windows != nil {
+		var userID *uuid.UUID
+		if id, err := uuid.Parse(notification.RecipientID); err == nil {
+			userID = &id
+		}
+		if settings, err := s.windows.ResolveSettings(ctx, notification.TenantID, userID); err == nil {
+			locale = settings.Language
+		}
+	}
+	if locale == "" {
+		locale = defaultLocale
+	}
+
+	notification.Locale = defaultLocale
+	if s.versionRepo == nil {
+		return template
 	}
 
-	result := text
-	for key, value := range variables {
-		placeholder := fmt.Sprintf("{{%s}}", key)
-		result = strings.ReplaceAll(result, placeholder, fmt.Sprintf("%v", value))
+	published, err := s.versionRepo.FindPublished(ctx, template.ID)
+	if err != nil {
+		s.logger.Warn("Failed to load template versions, using current content",
+			zap.String("template_id", template.ID.String()),
+			zap.Error(err))
+		return template
+	}
+
+	version := domain.SelectTemplateVersion(published, locale, defaultLocale, notification.RecipientID)
+	if version == nil {
+		return template
 	}
 
-	return result
+	notification.TemplateVersionID = &version.ID
+	notification.TemplateVariant = version.Variant
+	notification.Locale = version.Locale
+	return version.ApplyTo(template)
 }
 
 // SendNotification envia uma notificação imediatamente
@@ -230,6 +392,34 @@ func (s *NotificationService) SendNotification(ctx context.Context, notification
 		return fmt.Errorf("provider unhealthy for channel: %s", notification.Channel)
 	}
 
+	// Contatos suprimidos (hard bounce, reclamação, bloqueio) não recebem mais mensagens
+	suppressed, err := s.suppressionRepo.IsSuppressed(ctx, notification.Channel, notification.RecipientContact)
+	if err != nil {
+		return fmt.Errorf("failed to check suppression list: %w", err)
+	}
+	if suppressed {
+		s.cancelUndeliverable(ctx, notification, domain.ErrContactSuppressed)
+		return domain.ErrContactSuppressed
+	}
+
+	// LGPD: contato que revogou o consentimento (ou cliente sem consentimento) não recebe
+	if s.consents != nil {
+		if err := s.consents.Check(ctx, notification); err != nil {
+			if !errors.Is(err, domain.ErrConsentRevoked) && !errors.Is(err, domain.ErrConsentRequired) {
+				return err
+			}
+			s.cancelUndeliverable(ctx, notification, err)
+			return err
+		}
+	}
+
+	// Limite de taxa por provedor e por tenant: a notificação não é alterada
+	if s.limiter != nil {
+		if err := s.limiter.Allow(notification.Channel, notification.TenantID); err != nil {
+			return err
+		}
+	}
+
 	// Marcar como em processamento
 	notification.Status = domain.NotificationStatusProcessing
 	notification.ProcessingStartedAt = &time.Time{}
@@ -240,17 +430,25 @@ func (s *NotificationService) SendNotification(ctx context.Context, notification
 	}
 
 	// Enviar através do provedor
-	err := provider.Send(ctx, notification)
+	err = provider.Send(ctx, notification)
 	
 	// Atualizar status baseado no resultado
 	now := time.Now()
 	notification.ProcessingFinishedAt = &now
 
-	if err != nil {
+	var rateLimitErr *domain.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		// Provedor sinalizou limite: pausar o canal e devolver para pendente sem contar tentativa
+		if s.limiter != nil {
+			s.limiter.Throttle(notification.Channel, rateLimitErr.RetryAfter)
+		}
+		notification.Status = domain.NotificationStatusPending
+	} else if err != nil {
 		notification.Status = domain.NotificationStatusFailed
 		notification.FailedAt = &now
 		errMsg := err.Error()
 		notification.ErrorMessage = &errMsg
+		notification.FailureCategory = domain.ClassifyFailure(notification.Channel, "", errMsg)
 		notification.RetryCount++
 		
 		// Calcular próximo retry se ainda há tentativas disponíveis
@@ -261,6 +459,22 @@ func (s *NotificationService) SendNotification(ctx context.Context, notification
 	} else {
 		notification.Status = domain.NotificationStatusSent
 		notification.SentAt = &now
+		notification.FailureCategory = ""
+		notification.Cost = s.costs.MessageCost(notification)
+
+		// Provedores sem recibo de entrega confirmam a entrega na própria resposta do envio
+		if provider.ConfirmsDeliveryOnSend() {
+			notification.ApplyDeliveryEvent(domain.DeliveryEventDelivered, now, "")
+		}
+	}
+
+	// Cadeia de fallback: cancelar, reagendar ou escalar as demais tentativas
+	if s.fallback != nil && notification.Status != domain.NotificationStatusPending {
+		if fallbackErr := s.fallback.HandleAttemptUpdate(ctx, notification); fallbackErr != nil {
+			s.logger.Error("Failed to update fallback chain",
+				zap.String("notification_id", notification.ID.String()),
+				zap.Error(fallbackErr))
+		}
 	}
 
 	// Salvar mudanças
@@ -271,6 +485,68 @@ func (s *NotificationService) SendNotification(ctx context.Context, notification
 	return err
 }
 
+// cancelUndeliverable cancela a notificação que não pode ser enviada ao contato (supressão ou
+// falta de consentimento); a cadeia de fallback segue para o próximo canal
+func (s *NotificationService) cancelUndeliverable(ctx context.Context, notification *domain.Notification, reason error) {
+	errMsg := reason.Error()
+	notification.Status = domain.NotificationStatusCancelled
+	notification.ErrorMessage = &errMsg
+	notification.FailureCategory = domain.ClassifyFailure(notification.Channel, "", errMsg)
+	notification.UpdatedAt = time.Now()
+
+	if s.fallback != nil {
+		if err := s.fallback.Escalate(ctx, notification); err != nil {
+			s.logger.Error("Failed to escalate fallback chain", zap.Error(err))
+		}
+	}
+
+	if err := s.notificationRepo.Update(ctx, notification); err != nil {
+		s.logger.Error("Failed to cancel undeliverable notification",
+			zap.String("notification_id", notification.ID.String()),
+			zap.Error(err))
+	}
+}
+
+// addUnsubscribeLink inclui o link de descadastro no rodapé do email e o registra para os
+// headers List-Unsubscribe. Mensagens do serviço (senha, boas-vindas) não levam o link.
+func (s *NotificationService) addUnsubscribeLink(notification *domain.Notification) {
+	if s.consents == nil || domain.ConsentPurposeForType(notification.Type) == domain.ConsentPurposeService {
+		return
+	}
+	unsubscribeURL, ok := s.consents.UnsubscribeURL(notification.ID)
+	if !ok {
+		return
+	}
+
+	htmlFooter := `<p style="font-size:12px;color:#777">Não quer mais receber estes emails? <a href="` +
+		html.EscapeString(unsubscribeURL) + `">Descadastrar</a></p>`
+	if notification.ContentHTML != nil {
+		contentHTML := appendHTMLFooter(*notification.ContentHTML, htmlFooter)
+		notification.ContentHTML = &contentHTML
+	}
+	if domain.LooksLikeHTML(notification.Content) {
+		notification.Content = appendHTMLFooter(notification.Content, htmlFooter)
+	} else {
+		notification.Content += "\n\nNão quer mais receber estes emails? Descadastre-se: " + unsubscribeURL
+	}
+
+	// Cópia: os metadados da requisição são compartilhados entre os canais
+	metadata := make(map[string]interface{}, len(notification.Metadata)+1)
+	for key, value := range notification.Metadata {
+		metadata[key] = value
+	}
+	metadata["list_unsubscribe"] = unsubscribeURL
+	notification.Metadata = metadata
+}
+
+// appendHTMLFooter inclui o rodapé antes do </body>, quando houver
+func appendHTMLFooter(content, footer string) string {
+	if i := strings.LastIndex(strings.ToLower(content), "</body>"); i >= 0 {
+		return content[:i] + footer + content[i:]
+	}
+	return content + footer
+}
+
 // calculateNextRetry calcula o próximo momento de retry
 func (s *NotificationService) calculateNextRetry(retryCount int) time.Time {
 	// Backoff exponencial: 1min, 5min, 15min
@@ -297,12 +573,87 @@ func (s *NotificationService) ListNotifications(ctx context.Context, tenantID uu
 	return s.notificationRepo.FindByTenantID(ctx, tenantID, filters)
 }
 
+// NotificationAttempts notificação lógica com as tentativas da cadeia de fallback
+type NotificationAttempts struct {
+	Notification   *domain.Notification   `json:"notification"`
+	FallbackStatus domain.FallbackStatus  `json:"fallback_status,omitempty"`
+	Attempts       []*domain.Notification `json:"attempts"`
+}
+
+// GetNotificationAttempts busca a notificação lógica e suas tentativas por canal
+func (s *NotificationService) GetNotificationAttempts(ctx context.Context, id uuid.UUID) (*NotificationAttempts, error) {
+	notification, err := s.notificationRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !notification.IsFallbackAttempt() {
+		return &NotificationAttempts{
+			Notification: notification,
+			Attempts:     []*domain.Notification{notification},
+		}, nil
+	}
+
+	attempts, err := s.notificationRepo.FindFallbackAttempts(ctx, *notification.FallbackRootID)
+	if err != nil {
+		return nil, err
+	}
+
+	root := notification
+	for _, attempt := range attempts {
+		if attempt.ID == *notification.FallbackRootID {
+			root = attempt
+		}
+	}
+
+	return &NotificationAttempts{
+		Notification:   root,
+		FallbackStatus: domain.ResolveFallbackStatus(attempts),
+		Attempts:       attempts,
+	}, nil
+}
+
 // GetNotificationStatistics obtém estatísticas de notificações
 func (s *NotificationService) GetNotificationStatistics(ctx context.Context, tenantID uuid.UUID, period time.Duration) (*domain.NotificationStatistics, error) {
 	return s.notificationRepo.GetStatistics(ctx, tenantID, period)
 }
 
-// ProcessPendingNotifications processa notificações pendentes
+// GetNotificationAnalytics obtém o funil, o tempo até a entrega, as falhas por categoria e o
+// custo das notificações, nos totais e por dimensão
+func (s *NotificationService) GetNotificationAnalytics(ctx context.Context, filters domain.AnalyticsFilters) (*domain.NotificationAnalytics, error) {
+	if err := filters.Validate(); err != nil {
+		return nil, err
+	}
+
+	totalsFilters := filters
+	totalsFilters.GroupBy = ""
+	totals, err := s.notificationRepo.GetAnalytics(ctx, totalsFilters)
+	if err != nil {
+		return nil, err
+	}
+
+	analytics := &domain.NotificationAnalytics{
+		Filters:     filters,
+		Currency:    s.costs.Currency,
+		Totals:      &domain.AnalyticsGroup{Key: "total", Failures: map[domain.FailureCategory]int64{}},
+		GeneratedAt: time.Now(),
+	}
+	if len(totals) > 0 {
+		analytics.Totals = totals[0]
+	}
+
+	if filters.GroupBy != "" {
+		analytics.Groups, err = s.notificationRepo.GetAnalytics(ctx, filters)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return analytics, nil
+}
+
+// ProcessPendingNotifications reenfileira notificações pendentes que não chegaram à fila.
+// O envio é feito pelos workers da fila.
 func (s *NotificationService) ProcessPendingNotifications(ctx context.Context, limit int) error {
 	s.logger.Debug("Processing pending notifications", zap.Int("limit", limit))
 
@@ -312,8 +663,8 @@ func (s *NotificationService) ProcessPendingNotifications(ctx context.Context, l
 	}
 
 	for _, notification := range notifications {
-		if err := s.SendNotification(ctx, notification); err != nil {
-			s.logger.Error("Failed to send pending notification", 
+		if err := s.queue.Enqueue(ctx, notification); err != nil {
+			s.logger.Error("Failed to enqueue pending notification", 
 				zap.String("notification_id", notification.ID.String()),
 				zap.Error(err))
 		}
@@ -350,7 +701,7 @@ func (s *NotificationService) ProcessScheduledNotifications(ctx context.Context,
 	return nil
 }
 
-// ProcessRetries processa notificações para reenvio
+// ProcessRetries reenfileira notificações falhadas prontas para reenvio
 func (s *NotificationService) ProcessRetries(ctx context.Context, limit int) error {
 	s.logger.Debug("Processing retry notifications", zap.Int("limit", limit))
 
@@ -360,8 +711,8 @@ func (s *NotificationService) ProcessRetries(ctx context.Context, limit int) err
 	}
 
 	for _, notification := range notifications {
-		if err := s.SendNotification(ctx, notification); err != nil {
-			s.logger.Error("Failed to retry notification", 
+		if err := s.queue.Enqueue(ctx, notification); err != nil {
+			s.logger.Error("Failed to enqueue notification for retry", 
 				zap.String("notification_id", notification.ID.String()),
 				zap.Error(err))
 		}
@@ -391,7 +742,14 @@ func (s *NotificationService) CancelNotification(ctx context.Context, id uuid.UU
 		return fmt.Errorf("notification not found: %w", err)
 	}
 
-	if notification.Status != domain.NotificationStatusPending && notification.Status != domain.NotificationStatusScheduled {
+	// Cancelar a notificação lógica cancela as tentativas ainda não enviadas da cadeia
+	if notification.IsFallbackAttempt() && s.fallback != nil {
+		return s.fallback.CancelChain(ctx, *notification.FallbackRootID)
+	}
+
+	if notification.Status != domain.NotificationStatusPending &&
+		notification.Status != domain.NotificationStatusScheduled &&
+		notification.Status != domain.NotificationStatusBatched {
 		return fmt.Errorf("cannot cancel notification with status: %s", notification.Status)
 	}
 
@@ -0,0 +1,241 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// messagesPerWorker mensagens por minuto atendidas por cada worker do canal
+const messagesPerWorker = 10
+
+// ThrottleRecorder registra notificações reenfileiradas por limite de taxa
+type ThrottleRecorder interface {
+	RecordNotificationThrottled(channel, tenantID, scope string)
+}
+
+// NotificationWorkerPool consome a fila com um pool de workers por canal,
+// dimensionado e limitado pelo rate limit do provedor
+type NotificationWorkerPool struct {
+	notificationService *NotificationService
+	queue               domain.NotificationQueue
+	providers           map[domain.NotificationChannel]domain.NotificationProvider
+	limiter             *DispatchLimiter
+	recorder            ThrottleRecorder
+	maxWorkers          int
+	pollInterval        time.Duration
+	logger              *zap.Logger
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewNotificationWorkerPool cria novo pool de workers
+func NewNotificationWorkerPool(
+	notificationService *NotificationService,
+	queue domain.NotificationQueue,
+	providers map[domain.NotificationChannel]domain.NotificationProvider,
+	limiter *DispatchLimiter,
+	recorder ThrottleRecorder,
+	maxWorkers int,
+	pollInterval time.Duration,
+	logger *zap.Logger,
+) *NotificationWorkerPool {
+	return &NotificationWorkerPool{
+		notificationService: notificationService,
+		queue:               queue,
+		providers:           providers,
+		limiter:             limiter,
+		recorder:            recorder,
+		maxWorkers:          maxWorkers,
+		pollInterval:        pollInterval,
+		logger:              logger,
+	}
+}
+
+// WorkersForRateLimit calcula quantos workers o canal comporta
+func WorkersForRateLimit(rateLimit, maxWorkers int) int {
+	if rateLimit <= 0 {
+		return maxWorkers
+	}
+
+	workers := (rateLimit + messagesPerWorker - 1) / messagesPerWorker
+	if workers > maxWorkers {
+		return maxWorkers
+	}
+	if workers < 1 {
+		return 1
+	}
+	return workers
+}
+
+// Start inicia os workers de todos os canais com provedor configurado
+func (p *NotificationWorkerPool) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+
+	for channel, provider := range p.providers {
+		rateLimit := provider.GetRateLimit()
+		workers := WorkersForRateLimit(rateLimit, p.maxWorkers)
+
+		p.logger.Info("Starting notification workers",
+			zap.String("channel", string(channel)),
+			zap.Int("workers", workers),
+			zap.Int("rate_limit", rateLimit))
+
+		for i := 0; i < workers; i++ {
+			p.wg.Add(1)
+			go p.work(ctx, channel)
+		}
+	}
+}
+
+// Stop para de consumir a fila e aguarda os envios em andamento.
+// Itens não confirmados voltam à fila ao fim do visibility timeout.
+func (p *NotificationWorkerPool) Stop(ctx context.Context) error {
+	if p.cancel == nil {
+		return nil
+	}
+	p.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		p.logger.Info("Notification workers stopped")
+		return nil
+	case <-ctx.Done():
+		p.logger.Warn("Timeout waiting for notification workers")
+		return ctx.Err()
+	}
+}
+
+// work loop de um worker do canal
+func (p *NotificationWorkerPool) work(ctx context.Context, channel domain.NotificationChannel) {
+	defer p.wg.Done()
+
+	for {
+		// Backpressure: não desenfileirar enquanto o provedor não tiver capacidade
+		if p.limiter != nil {
+			if wait := p.limiter.ProviderWait(channel); wait > 0 {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(wait):
+				}
+				continue
+			}
+		}
+
+		notification, err := p.queue.DequeueByPriority(ctx, channel)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			if !errors.Is(err, domain.ErrQueueEmpty) {
+				p.logger.Error("Failed to dequeue notification",
+					zap.String("channel", string(channel)),
+					zap.Error(err))
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(p.pollInterval):
+			}
+			continue
+		}
+
+		// O envio em andamento não é interrompido pelo shutdown
+		p.process(context.WithoutCancel(ctx), notification)
+	}
+}
+
+// process envia a notificação e confirma ou reagenda o item da fila
+func (p *NotificationWorkerPool) process(ctx context.Context, notification *domain.Notification) {
+	switch notification.Status {
+	case domain.NotificationStatusSent,
+		domain.NotificationStatusDelivered,
+		domain.NotificationStatusRead,
+		domain.NotificationStatusCancelled,
+		domain.NotificationStatusExpired:
+		p.ack(ctx, notification)
+		return
+	}
+
+	if notification.IsExpired() {
+		notification.Status = domain.NotificationStatusExpired
+		notification.UpdatedAt = time.Now()
+		if err := p.notificationService.notificationRepo.Update(ctx, notification); err != nil {
+			p.logger.Error("Failed to expire notification",
+				zap.String("notification_id", notification.ID.String()),
+				zap.Error(err))
+		}
+		p.ack(ctx, notification)
+		return
+	}
+
+	err := p.notificationService.SendNotification(ctx, notification)
+	if err == nil || errors.Is(err, domain.ErrContactSuppressed) ||
+		errors.Is(err, domain.ErrConsentRevoked) || errors.Is(err, domain.ErrConsentRequired) {
+		p.ack(ctx, notification)
+		return
+	}
+
+	var rateLimitErr *domain.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		// Limite atingido: reenfileirar com atraso em vez de falhar
+		if p.recorder != nil {
+			p.recorder.RecordNotificationThrottled(string(notification.Channel), notification.TenantID.String(), rateLimitErr.Scope)
+		}
+
+		if err := p.queue.Nack(ctx, notification.ID, time.Now().Add(rateLimitErr.RetryAfter)); err != nil {
+			p.logger.Error("Failed to requeue throttled notification",
+				zap.String("notification_id", notification.ID.String()),
+				zap.Error(err))
+		}
+		return
+	}
+
+	p.logger.Warn("Failed to send queued notification",
+		zap.String("notification_id", notification.ID.String()),
+		zap.String("channel", string(notification.Channel)),
+		zap.Error(err))
+
+	var retryAt time.Time
+	switch {
+	case notification.Status != domain.NotificationStatusFailed:
+		// Provedor indisponível: nada foi enviado, tentar novamente em breve
+		retryAt = time.Now().Add(time.Minute)
+	case notification.CanRetry() && notification.NextRetryAt != nil:
+		retryAt = *notification.NextRetryAt
+	default:
+		// Tentativas esgotadas
+		p.ack(ctx, notification)
+		return
+	}
+
+	if err := p.queue.Nack(ctx, notification.ID, retryAt); err != nil {
+		p.logger.Error("Failed to requeue notification",
+			zap.String("notification_id", notification.ID.String()),
+			zap.Error(err))
+	}
+}
+
+// ack remove a notificação da fila
+func (p *NotificationWorkerPool) ack(ctx context.Context, notification *domain.Notification) {
+	if err := p.queue.Ack(ctx, notification.ID); err != nil {
+		p.logger.Error("Failed to ack notification",
+			zap.String("notification_id", notification.ID.String()),
+			zap.Error(err))
+	}
+}
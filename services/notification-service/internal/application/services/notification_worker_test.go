@@ -0,0 +1,355 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// fakeNotificationRepo repositório em memória que conta as atualizações e registra as notificações
+// criadas
+type fakeNotificationRepo struct {
+	domain.NotificationRepository
+	notifications []*domain.Notification
+	dueDigest     []*domain.Notification
+	created       []*domain.Notification
+	updates       int
+}
+
+func (r *fakeNotificationRepo) Create(ctx context.Context, notification *domain.Notification) error {
+	r.created = append(r.created, notification)
+	return nil
+}
+
+func (r *fakeNotificationRepo) Update(ctx context.Context, notification *domain.Notification) error {
+	r.updates++
+	return nil
+}
+
+func (r *fakeNotificationRepo) UpdateBatch(ctx context.Context, notifications []*domain.Notification) error {
+	r.updates += len(notifications)
+	return nil
+}
+
+func (r *fakeNotificationRepo) FindDueDigestItems(ctx context.Context, dueBefore time.Time, limit int) ([]*domain.Notification, error) {
+	if len(r.dueDigest) > limit {
+		return r.dueDigest[:limit], nil
+	}
+	return r.dueDigest, nil
+}
+
+func (r *fakeNotificationRepo) FindByExternalID(ctx context.Context, channel domain.NotificationChannel, externalID string) (*domain.Notification, error) {
+	for _, notification := range r.notifications {
+		if notification.Channel == channel && notification.ExternalID != nil && *notification.ExternalID == externalID {
+			return notification, nil
+		}
+	}
+	return nil, domain.ErrNotificationNotFound
+}
+
+func (r *fakeNotificationRepo) FindFallbackAttempts(ctx context.Context, rootID uuid.UUID) ([]*domain.Notification, error) {
+	var attempts []*domain.Notification
+	for _, notification := range r.notifications {
+		if notification.FallbackRootID != nil && *notification.FallbackRootID == rootID {
+			attempts = append(attempts, notification)
+		}
+	}
+	return attempts, nil
+}
+
+// fakeSuppressionRepo lista de supressão com um único contato suprimido que registra as novas
+// supressões
+type fakeSuppressionRepo struct {
+	domain.SuppressionRepository
+	suppressed   string
+	suppressions []*domain.SuppressedContact
+}
+
+func (r *fakeSuppressionRepo) IsSuppressed(ctx context.Context, channel domain.NotificationChannel, contact string) (bool, error) {
+	return contact == r.suppressed, nil
+}
+
+func (r *fakeSuppressionRepo) Suppress(ctx context.Context, suppression *domain.SuppressedContact) error {
+	r.suppressions = append(r.suppressions, suppression)
+	return nil
+}
+
+// fakeNotificationQueue fila que registra enfileiramentos, confirmações e reagendamentos
+type fakeNotificationQueue struct {
+	domain.NotificationQueue
+	enqueued  []uuid.UUID
+	scheduled map[uuid.UUID]time.Time
+	acks      []uuid.UUID
+	nacks     []uuid.UUID
+	retryAt   time.Time
+}
+
+func (q *fakeNotificationQueue) Enqueue(ctx context.Context, notification *domain.Notification) error {
+	q.enqueued = append(q.enqueued, notification.ID)
+	return nil
+}
+
+func (q *fakeNotificationQueue) EnqueueScheduled(ctx context.Context, notification *domain.Notification, scheduledAt time.Time) error {
+	if q.scheduled == nil {
+		q.scheduled = make(map[uuid.UUID]time.Time)
+	}
+	q.scheduled[notification.ID] = scheduledAt
+	return nil
+}
+
+func (q *fakeNotificationQueue) Ack(ctx context.Context, notificationID uuid.UUID) error {
+	q.acks = append(q.acks, notificationID)
+	return nil
+}
+
+func (q *fakeNotificationQueue) Nack(ctx context.Context, notificationID uuid.UUID, retryAt time.Time) error {
+	q.nacks = append(q.nacks, notificationID)
+	q.retryAt = retryAt
+	return nil
+}
+
+// fakeProvider provedor com resultado de envio configurável
+type fakeProvider struct {
+	domain.NotificationProvider
+	unhealthy bool
+	sendErr   error
+	sent      int
+}
+
+func (p *fakeProvider) Send(ctx context.Context, notification *domain.Notification) error {
+	p.sent++
+	return p.sendErr
+}
+
+func (p *fakeProvider) IsHealthy(ctx context.Context) bool { return !p.unhealthy }
+
+func (p *fakeProvider) GetRateLimit() int { return 0 }
+
+func (p *fakeProvider) ConfirmsDeliveryOnSend() bool { return false }
+
+// fakeThrottleRecorder registra os escopos das notificações reenfileiradas por limite
+type fakeThrottleRecorder struct {
+	scopes []string
+}
+
+func (r *fakeThrottleRecorder) RecordNotificationThrottled(channel, tenantID, scope string) {
+	r.scopes = append(r.scopes, scope)
+}
+
+// workerFixture pool de workers com dependências em memória e uma notificação pendente
+type workerFixture struct {
+	pool         *NotificationWorkerPool
+	repo         *fakeNotificationRepo
+	queue        *fakeNotificationQueue
+	provider     *fakeProvider
+	recorder     *fakeThrottleRecorder
+	limiter      *DispatchLimiter
+	notification *domain.Notification
+}
+
+func newWorkerFixture(t *testing.T) *workerFixture {
+	t.Helper()
+
+	f := &workerFixture{
+		repo:     &fakeNotificationRepo{},
+		queue:    &fakeNotificationQueue{},
+		provider: &fakeProvider{},
+		recorder: &fakeThrottleRecorder{},
+		limiter:  NewDispatchLimiter(nil, 6),
+	}
+
+	providers := map[domain.NotificationChannel]domain.NotificationProvider{
+		domain.NotificationChannelEmail: f.provider,
+	}
+	service := NewNotificationService(f.repo, nil, nil, nil, &fakeSuppressionRepo{suppressed: "blocked@example.com"},
+		f.queue, providers, f.limiter, nil, nil, nil, nil, domain.ChannelCosts{}, zap.NewNop())
+	f.pool = NewNotificationWorkerPool(service, f.queue, providers, f.limiter, f.recorder, 10, time.Second, zap.NewNop())
+
+	notification, err := domain.NewNotification(
+		domain.NotificationTypeProcessUpdate,
+		domain.NotificationChannelEmail,
+		domain.NotificationPriorityNormal,
+		"Andamento", "Novo andamento no processo",
+		"user-1", "user", "advogado@example.com",
+		uuid.New(),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	notification.MaxRetries = 3
+	f.notification = notification
+
+	return f
+}
+
+// assertAcked confere que a notificação saiu da fila sem reagendamento
+func (f *workerFixture) assertAcked(t *testing.T) {
+	t.Helper()
+	if len(f.queue.acks) != 1 || f.queue.acks[0] != f.notification.ID || len(f.queue.nacks) != 0 {
+		t.Errorf("expected notification to be acked, got %d acks and %d nacks", len(f.queue.acks), len(f.queue.nacks))
+	}
+}
+
+// assertNacked confere que a notificação voltou à fila para o intervalo informado
+func (f *workerFixture) assertNacked(t *testing.T, from, to time.Time) {
+	t.Helper()
+	if len(f.queue.nacks) != 1 || f.queue.nacks[0] != f.notification.ID || len(f.queue.acks) != 0 {
+		t.Fatalf("expected notification to be nacked, got %d acks and %d nacks", len(f.queue.acks), len(f.queue.nacks))
+	}
+	if f.queue.retryAt.Before(from) || f.queue.retryAt.After(to) {
+		t.Errorf("expected retry between %v and %v, got %v", from, to, f.queue.retryAt)
+	}
+}
+
+func TestWorkersForRateLimit(t *testing.T) {
+	tests := []struct {
+		rateLimit  int
+		maxWorkers int
+		want       int
+	}{
+		{0, 10, 10},    // sem limite do provedor
+		{-1, 4, 4},     // limite inválido é tratado como ausente
+		{1, 10, 1},     // mínimo de um worker
+		{10, 10, 1},    // um worker por 10 mensagens/min
+		{11, 10, 2},    // arredonda para cima
+		{60, 10, 6},    // email
+		{1000, 10, 10}, // limitado por maxWorkers
+	}
+
+	for _, tt := range tests {
+		if got := WorkersForRateLimit(tt.rateLimit, tt.maxWorkers); got != tt.want {
+			t.Errorf("WorkersForRateLimit(%d, %d) = %d, want %d", tt.rateLimit, tt.maxWorkers, got, tt.want)
+		}
+	}
+}
+
+func TestNotificationWorkerPoolAcksFinishedNotifications(t *testing.T) {
+	for _, status := range []domain.NotificationStatus{
+		domain.NotificationStatusSent,
+		domain.NotificationStatusDelivered,
+		domain.NotificationStatusRead,
+		domain.NotificationStatusCancelled,
+		domain.NotificationStatusExpired,
+	} {
+		f := newWorkerFixture(t)
+		f.notification.Status = status
+
+		f.pool.process(context.Background(), f.notification)
+
+		f.assertAcked(t)
+		if f.provider.sent != 0 {
+			t.Errorf("%s: expected no send, got %d", status, f.provider.sent)
+		}
+	}
+}
+
+func TestNotificationWorkerPoolExpiresNotification(t *testing.T) {
+	f := newWorkerFixture(t)
+	expiresAt := time.Now().Add(-time.Minute)
+	f.notification.ExpiresAt = &expiresAt
+
+	f.pool.process(context.Background(), f.notification)
+
+	f.assertAcked(t)
+	if f.notification.Status != domain.NotificationStatusExpired || f.repo.updates != 1 {
+		t.Errorf("expected expired notification to be saved, got %s after %d updates", f.notification.Status, f.repo.updates)
+	}
+	if f.provider.sent != 0 {
+		t.Errorf("expected no send, got %d", f.provider.sent)
+	}
+}
+
+func TestNotificationWorkerPoolAcksSentAndSuppressed(t *testing.T) {
+	f := newWorkerFixture(t)
+	f.pool.process(context.Background(), f.notification)
+
+	f.assertAcked(t)
+	if f.notification.Status != domain.NotificationStatusSent {
+		t.Errorf("expected sent notification, got %s", f.notification.Status)
+	}
+
+	// Contato suprimido: cancelada e removida da fila, sem nova tentativa
+	f = newWorkerFixture(t)
+	f.notification.RecipientContact = "blocked@example.com"
+	f.pool.process(context.Background(), f.notification)
+
+	f.assertAcked(t)
+	if f.notification.Status != domain.NotificationStatusCancelled || f.provider.sent != 0 {
+		t.Errorf("expected cancelled notification without send, got %s after %d sends", f.notification.Status, f.provider.sent)
+	}
+}
+
+func TestNotificationWorkerPoolRequeuesThrottled(t *testing.T) {
+	// Limite do tenant: o primeiro envio consome a rajada, o segundo volta para a fila
+	f := newWorkerFixture(t)
+	if err := f.limiter.Allow(domain.NotificationChannelEmail, f.notification.TenantID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	now := time.Now()
+	f.pool.process(context.Background(), f.notification)
+
+	f.assertNacked(t, now.Add(9*time.Second), now.Add(11*time.Second))
+	if f.notification.Status != domain.NotificationStatusPending || f.provider.sent != 0 {
+		t.Errorf("expected pending notification without send, got %s after %d sends", f.notification.Status, f.provider.sent)
+	}
+	if len(f.recorder.scopes) != 1 || f.recorder.scopes[0] != domain.RateLimitScopeTenant {
+		t.Errorf("expected tenant throttle to be recorded, got %v", f.recorder.scopes)
+	}
+
+	// 429 do provedor: pausa o canal e reagenda sem contar tentativa
+	f = newWorkerFixture(t)
+	f.provider.sendErr = domain.NewRateLimitError(domain.RateLimitScopeUpstream, 30*time.Second)
+
+	now = time.Now()
+	f.pool.process(context.Background(), f.notification)
+
+	f.assertNacked(t, now.Add(29*time.Second), now.Add(31*time.Second))
+	if f.notification.Status != domain.NotificationStatusPending || f.notification.RetryCount != 0 {
+		t.Errorf("expected pending notification without retry, got %s with %d retries", f.notification.Status, f.notification.RetryCount)
+	}
+	if wait := f.limiter.ProviderWait(domain.NotificationChannelEmail); wait <= 0 {
+		t.Error("expected channel to be paused after Retry-After")
+	}
+}
+
+func TestNotificationWorkerPoolRetriesFailures(t *testing.T) {
+	// Provedor indisponível: nada foi enviado, nova tentativa em um minuto
+	f := newWorkerFixture(t)
+	f.provider.unhealthy = true
+
+	now := time.Now()
+	f.pool.process(context.Background(), f.notification)
+
+	f.assertNacked(t, now.Add(time.Minute-time.Second), now.Add(time.Minute+time.Second))
+	if f.notification.Status != domain.NotificationStatusPending {
+		t.Errorf("expected pending notification, got %s", f.notification.Status)
+	}
+
+	// Falha no envio com tentativas restantes: reagendada para NextRetryAt
+	f = newWorkerFixture(t)
+	f.provider.sendErr = errors.New("smtp: connection reset")
+	f.pool.process(context.Background(), f.notification)
+
+	if f.notification.NextRetryAt == nil {
+		t.Fatal("expected next retry to be scheduled")
+	}
+	f.assertNacked(t, *f.notification.NextRetryAt, *f.notification.NextRetryAt)
+
+	// Tentativas esgotadas: removida da fila como falha
+	f = newWorkerFixture(t)
+	f.provider.sendErr = errors.New("smtp: connection reset")
+	f.notification.RetryCount = f.notification.MaxRetries - 1
+	f.pool.process(context.Background(), f.notification)
+
+	f.assertAcked(t)
+	if f.notification.Status != domain.NotificationStatusFailed {
+		t.Errorf("expected failed notification, got %s", f.notification.Status)
+	}
+}
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// burstWindow janela de rajada permitida pelo token bucket
+const burstWindow = 10 * time.Second
+
+// TokenBucket limitador token bucket com reposição contínua
+type TokenBucket struct {
+	capacity   float64
+	tokens     float64
+	refillRate float64 // tokens por segundo
+	lastRefill time.Time
+}
+
+// NewTokenBucket cria bucket para o limite em mensagens por minuto
+func NewTokenBucket(perMinute int, now time.Time) *TokenBucket {
+	refillRate := float64(perMinute) / 60
+	capacity := refillRate * burstWindow.Seconds()
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	return &TokenBucket{
+		capacity:   capacity,
+		tokens:     capacity,
+		refillRate: refillRate,
+		lastRefill: now,
+	}
+}
+
+// refill repõe os tokens acumulados desde a última leitura
+func (b *TokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.lastRefill).Seconds(); elapsed > 0 {
+		b.tokens += elapsed * b.refillRate
+		if b.tokens > b.capacity {
+			b.tokens = b.capacity
+		}
+		b.lastRefill = now
+	}
+}
+
+// Wait retorna quanto falta para haver um token disponível (zero se disponível)
+func (b *TokenBucket) Wait(now time.Time) time.Duration {
+	b.refill(now)
+	if b.tokens >= 1 {
+		return 0
+	}
+
+	return time.Duration((1 - b.tokens) / b.refillRate * float64(time.Second))
+}
+
+// Take consome um token
+func (b *TokenBucket) Take(now time.Time) {
+	b.refill(now)
+	b.tokens--
+}
+
+// tenantKey chave do bucket do tenant no canal
+type tenantKey struct {
+	tenantID uuid.UUID
+	channel  domain.NotificationChannel
+}
+
+// DispatchLimiter aplica limites de taxa por provedor e por tenant antes do envio. O estado fica
+// em memória: com várias réplicas, cada uma aplica os limites de forma independente.
+type DispatchLimiter struct {
+	mu              sync.Mutex
+	providerBuckets map[domain.NotificationChannel]*TokenBucket
+	pausedUntil     map[domain.NotificationChannel]time.Time // Retry-After do provedor
+	tenantBuckets   map[tenantKey]*TokenBucket
+	tenantRateLimit int
+	now             func() time.Time
+}
+
+// NewDispatchLimiter cria limitador com o rate limit de cada provedor e o limite por tenant
+// (mensagens por minuto; zero desativa o limite por tenant)
+func NewDispatchLimiter(providers map[domain.NotificationChannel]domain.NotificationProvider, tenantRateLimit int) *DispatchLimiter {
+	limiter := &DispatchLimiter{
+		providerBuckets: make(map[domain.NotificationChannel]*TokenBucket),
+		pausedUntil:     make(map[domain.NotificationChannel]time.Time),
+		tenantBuckets:   make(map[tenantKey]*TokenBucket),
+		tenantRateLimit: tenantRateLimit,
+		now:             time.Now,
+	}
+
+	now := limiter.now()
+	for channel, provider := range providers {
+		if rateLimit := provider.GetRateLimit(); rateLimit > 0 {
+			limiter.providerBuckets[channel] = NewTokenBucket(rateLimit, now)
+		}
+	}
+
+	return limiter
+}
+
+// Allow reserva um envio do tenant no canal ou retorna *domain.RateLimitError
+func (l *DispatchLimiter) Allow(channel domain.NotificationChannel, tenantID uuid.UUID) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+
+	if wait := l.providerWait(channel, now); wait > 0 {
+		return domain.NewRateLimitError(domain.RateLimitScopeProvider, wait)
+	}
+	providerBucket := l.providerBuckets[channel]
+
+	var tenantBucket *TokenBucket
+	if l.tenantRateLimit > 0 {
+		l.evictIdleTenantBuckets(now)
+
+		key := tenantKey{tenantID: tenantID, channel: channel}
+		tenantBucket = l.tenantBuckets[key]
+		if tenantBucket == nil {
+			tenantBucket = NewTokenBucket(l.tenantRateLimit, now)
+			l.tenantBuckets[key] = tenantBucket
+		}
+
+		if wait := tenantBucket.Wait(now); wait > 0 {
+			return domain.NewRateLimitError(domain.RateLimitScopeTenant, wait)
+		}
+	}
+
+	// Só consome quando ambos os limites permitem
+	if providerBucket != nil {
+		providerBucket.Take(now)
+	}
+	if tenantBucket != nil {
+		tenantBucket.Take(now)
+	}
+
+	return nil
+}
+
+// evictIdleTenantBuckets descarta os buckets de tenants sem envios recentes, como os limitadores
+// de comandos do Telegram e do WhatsApp: parado por commandBucketIdle, o bucket já está cheio e
+// recriá-lo dá o mesmo resultado
+func (l *DispatchLimiter) evictIdleTenantBuckets(now time.Time) {
+	if len(l.tenantBuckets) < maxCommandBuckets {
+		return
+	}
+
+	for key, bucket := range l.tenantBuckets {
+		if now.Sub(bucket.lastRefill) >= commandBucketIdle {
+			delete(l.tenantBuckets, key)
+		}
+	}
+}
+
+// ProviderWait retorna quanto o canal precisa aguardar antes do próximo envio
+func (l *DispatchLimiter) ProviderWait(channel domain.NotificationChannel) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.providerWait(channel, l.now())
+}
+
+// providerWait calcula a espera do canal considerando pausa e bucket do provedor
+func (l *DispatchLimiter) providerWait(channel domain.NotificationChannel, now time.Time) time.Duration {
+	if until, paused := l.pausedUntil[channel]; paused {
+		if now.Before(until) {
+			return until.Sub(now)
+		}
+		delete(l.pausedUntil, channel)
+	}
+
+	if bucket := l.providerBuckets[channel]; bucket != nil {
+		return bucket.Wait(now)
+	}
+	return 0
+}
+
+// Throttle pausa o canal após o provedor sinalizar limite (429/Retry-After)
+func (l *DispatchLimiter) Throttle(channel domain.NotificationChannel, retryAfter time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	until := l.now().Add(retryAfter)
+	if until.After(l.pausedUntil[channel]) {
+		l.pausedUntil[channel] = until
+	}
+}
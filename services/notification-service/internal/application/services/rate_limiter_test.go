@@ -0,0 +1,94 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// newTestDispatchLimiter limitador sem provedores e com relógio controlado pelo teste
+func newTestDispatchLimiter(tenantRateLimit int) (*DispatchLimiter, *time.Time) {
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	limiter := NewDispatchLimiter(nil, tenantRateLimit)
+	limiter.now = func() time.Time { return now }
+	return limiter, &now
+}
+
+func TestDispatchLimiterTenantLimit(t *testing.T) {
+	// 6 por minuto: rajada de um envio, um token a cada 10 segundos
+	limiter, now := newTestDispatchLimiter(6)
+	tenantID := uuid.New()
+
+	if err := limiter.Allow(domain.NotificationChannelEmail, tenantID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var rateLimitErr *domain.RateLimitError
+	err := limiter.Allow(domain.NotificationChannelEmail, tenantID)
+	if !errors.As(err, &rateLimitErr) || rateLimitErr.Scope != domain.RateLimitScopeTenant {
+		t.Fatalf("expected tenant rate limit, got %v", err)
+	}
+	if rateLimitErr.RetryAfter != 10*time.Second {
+		t.Errorf("expected retry after 10s, got %s", rateLimitErr.RetryAfter)
+	}
+
+	// Outro canal e outro tenant têm buckets próprios
+	if err := limiter.Allow(domain.NotificationChannelSMS, tenantID); err != nil {
+		t.Errorf("expected other channel to be allowed, got %v", err)
+	}
+	if err := limiter.Allow(domain.NotificationChannelEmail, uuid.New()); err != nil {
+		t.Errorf("expected other tenant to be allowed, got %v", err)
+	}
+
+	*now = now.Add(10 * time.Second)
+	if err := limiter.Allow(domain.NotificationChannelEmail, tenantID); err != nil {
+		t.Errorf("expected token after refill, got %v", err)
+	}
+}
+
+func TestDispatchLimiterEvictsIdleTenantBuckets(t *testing.T) {
+	limiter, now := newTestDispatchLimiter(6)
+
+	for i := 0; i < maxCommandBuckets; i++ {
+		if err := limiter.Allow(domain.NotificationChannelEmail, uuid.New()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	active := uuid.New()
+	*now = now.Add(commandBucketIdle / 2)
+	if err := limiter.Allow(domain.NotificationChannelEmail, active); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Os buckets parados por commandBucketIdle são descartados; o recente continua limitando
+	*now = now.Add(commandBucketIdle / 2)
+	if err := limiter.Allow(domain.NotificationChannelEmail, uuid.New()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := len(limiter.tenantBuckets); got != 2 {
+		t.Errorf("expected idle buckets to be evicted, got %d buckets", got)
+	}
+	if _, ok := limiter.tenantBuckets[tenantKey{tenantID: active, channel: domain.NotificationChannelEmail}]; !ok {
+		t.Error("expected recent bucket to be kept")
+	}
+}
+
+func TestDispatchLimiterThrottle(t *testing.T) {
+	limiter, now := newTestDispatchLimiter(0)
+	limiter.Throttle(domain.NotificationChannelWhatsApp, 30*time.Second)
+
+	var rateLimitErr *domain.RateLimitError
+	err := limiter.Allow(domain.NotificationChannelWhatsApp, uuid.New())
+	if !errors.As(err, &rateLimitErr) || rateLimitErr.Scope != domain.RateLimitScopeProvider {
+		t.Fatalf("expected provider rate limit, got %v", err)
+	}
+
+	*now = now.Add(30 * time.Second)
+	if err := limiter.Allow(domain.NotificationChannelWhatsApp, uuid.New()); err != nil {
+		t.Errorf("expected channel to resume after Retry-After, got %v", err)
+	}
+}
@@ -0,0 +1,621 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+const (
+	defaultAgendaDays = 7
+	maxAgendaDays     = 90
+	// maxAgendaItems prazos listados na mensagem (limite de 4096 caracteres do Telegram)
+	maxAgendaItems = 15
+	// maxAgendaButtons botões de consulta de processo na agenda
+	maxAgendaButtons = 5
+	// commandBucketIdle após um minuto sem uso o bucket está cheio e pode ser descartado
+	commandBucketIdle = time.Minute
+	maxCommandBuckets = 1024
+)
+
+// TelegramLinkCodeResponse código de vinculação para exibir no web app
+type TelegramLinkCodeResponse struct {
+	Code      string    `json:"code"`
+	ExpiresAt time.Time `json:"expires_at"`
+	DeepLink  string    `json:"deep_link,omitempty"` // https://t.me/<bot>?start=<código>
+}
+
+// telegramCommand comando do bot; requiresLink exige chat vinculado a um usuário
+type telegramCommand struct {
+	requiresLink bool
+	handle       func(ctx context.Context, chat *telegramChat, arg string) (*domain.TelegramBotReply, error)
+}
+
+// telegramChat conversa em atendimento; link é nil nos comandos públicos
+type telegramChat struct {
+	id       string
+	username string
+	link     *domain.TelegramLink
+}
+
+// telegramCommandKey chave do limite de taxa por chat e comando
+type telegramCommandKey struct {
+	chatID  string
+	command string
+}
+
+// TelegramBotService atende os comandos do bot do Telegram com os dados do tenant vinculado ao chat
+type TelegramBotService struct {
+	links            domain.TelegramLinkRepository
+	processes        domain.ProcessDirectory
+	client           domain.TelegramBotClient
+	assistant        domain.ConversationalAssistant
+	botUsername      string
+	webAppURL        string
+	linkCodeTTL      time.Duration
+	commandRateLimit int
+	logger           *zap.Logger
+
+	commands map[string]telegramCommand
+	mu       sync.Mutex
+	buckets  map[telegramCommandKey]*TokenBucket
+	now      func() time.Time
+}
+
+// NewTelegramBotService cria nova instância do serviço. commandRateLimit é o limite de cada
+// comando, em comandos por minuto, por chat. Com assistant nil, mensagens livres que não são
+// número de processo recebem a ajuda em vez de irem ao assistente.
+func NewTelegramBotService(
+	links domain.TelegramLinkRepository,
+	processes domain.ProcessDirectory,
+	client domain.TelegramBotClient,
+	assistant domain.ConversationalAssistant,
+	botUsername string,
+	webAppURL string,
+	linkCodeTTL time.Duration,
+	commandRateLimit int,
+	logger *zap.Logger,
+) *TelegramBotService {
+	s := &TelegramBotService{
+		links:            links,
+		processes:        processes,
+		client:           client,
+		assistant:        assistant,
+		botUsername:      strings.TrimPrefix(botUsername, "@"),
+		webAppURL:        strings.TrimRight(webAppURL, "/"),
+		linkCodeTTL:      linkCodeTTL,
+		commandRateLimit: commandRateLimit,
+		logger:           logger,
+		buckets:          make(map[telegramCommandKey]*TokenBucket),
+		now:              time.Now,
+	}
+
+	s.commands = map[string]telegramCommand{
+		"start":       {handle: s.start},
+		"vincular":    {handle: s.linkChat},
+		"help":        {handle: s.help},
+		"ajuda":       {handle: s.help},
+		"configurar":  {handle: s.settings},
+		"desvincular": {requiresLink: true, handle: s.unlinkChat},
+		"status":      {requiresLink: true, handle: s.status},
+		"relatorio":   {requiresLink: true, handle: s.status},
+		"agenda":      {requiresLink: true, handle: s.agenda},
+		"busca":       {requiresLink: true, handle: s.search},
+		"processo":    {requiresLink: true, handle: s.process},
+	}
+	if assistant != nil {
+		s.commands["assistente"] = telegramCommand{requiresLink: true, handle: s.ask}
+	}
+
+	return s
+}
+
+// CreateLinkCode gera o código que o usuário envia ao bot para vincular o chat
+func (s *TelegramBotService) CreateLinkCode(ctx context.Context, tenantID, userID uuid.UUID) (*TelegramLinkCodeResponse, error) {
+	code, err := domain.NewTelegramLinkCode(tenantID, userID, s.linkCodeTTL, s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.links.CreateCode(ctx, code); err != nil {
+		return nil, err
+	}
+
+	response := &TelegramLinkCodeResponse{
+		Code:      code.Code,
+		ExpiresAt: code.ExpiresAt,
+	}
+	if s.botUsername != "" {
+		response.DeepLink = fmt.Sprintf("https://t.me/%s?start=%s", s.botUsername, code.Code)
+	}
+
+	return response, nil
+}
+
+// ListLinks lista os chats vinculados ao usuário
+func (s *TelegramBotService) ListLinks(ctx context.Context, tenantID, userID uuid.UUID) ([]*domain.TelegramLink, error) {
+	return s.links.FindByUser(ctx, tenantID, userID)
+}
+
+// Unlink remove o vínculo do chat pelo web app
+func (s *TelegramBotService) Unlink(ctx context.Context, tenantID, userID uuid.UUID, chatID string) error {
+	return s.links.Unlink(ctx, tenantID, userID, chatID)
+}
+
+// HandleMessage responde a mensagem recebida: comandos (/status, /agenda 15), número CNJ ou,
+// com o assistente habilitado, perguntas em texto livre
+func (s *TelegramBotService) HandleMessage(ctx context.Context, chatID, username, text string) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+
+	var name, arg string
+	if strings.HasPrefix(text, "/") {
+		fields := strings.Fields(text)
+		name = strings.ToLower(strings.TrimPrefix(fields[0], "/"))
+		// Em grupos o comando vem como /status@NomeDoBot
+		if at := strings.Index(name, "@"); at >= 0 {
+			name = name[:at]
+		}
+		arg = strings.Join(fields[1:], " ")
+	} else if number, ok := domain.ParseCNJNumber(text); ok {
+		name, arg = "processo", number
+	} else if s.assistant != nil {
+		name, arg = "assistente", text
+	}
+
+	reply, notice := s.dispatch(ctx, &telegramChat{id: chatID, username: username}, name, arg)
+	if reply == nil {
+		reply = &domain.TelegramBotReply{Text: notice}
+	}
+
+	return s.client.SendReply(ctx, chatID, reply)
+}
+
+// HandleCallback responde o clique em um botão inline (callback_data "comando:argumento")
+func (s *TelegramBotService) HandleCallback(ctx context.Context, chatID, callbackID, data string) error {
+	name, arg, _ := strings.Cut(data, ":")
+
+	reply, notice := s.dispatch(ctx, &telegramChat{id: chatID}, name, arg)
+
+	if err := s.client.AnswerCallback(ctx, callbackID, notice); err != nil {
+		s.logger.Warn("Failed to answer telegram callback", zap.String("chat_id", chatID), zap.Error(err))
+	}
+	if reply == nil {
+		return nil
+	}
+
+	return s.client.SendReply(ctx, chatID, reply)
+}
+
+// dispatch aplica o limite de taxa e a exigência de vínculo e executa o comando. Quando o limite
+// é atingido, retorna apenas o aviso (exibido como alerta do botão ou mensagem).
+func (s *TelegramBotService) dispatch(ctx context.Context, chat *telegramChat, name, arg string) (*domain.TelegramBotReply, string) {
+	// Mensagens não reconhecidas também contam (chave vazia), evitando respostas em massa
+	if wait := s.rateLimited(chat.id, name); wait > 0 {
+		seconds := int(wait.Round(time.Second) / time.Second)
+		if seconds < 1 {
+			seconds = 1
+		}
+		return nil, fmt.Sprintf("⏳ Muitas consultas seguidas. Tente novamente em %d s.", seconds)
+	}
+
+	command, ok := s.commands[name]
+	if !ok {
+		return s.unknown(), ""
+	}
+
+	if command.requiresLink {
+		link, err := s.links.GetByChat(ctx, chat.id)
+		if errors.Is(err, domain.ErrTelegramChatNotLinked) {
+			return s.notLinked(), ""
+		}
+		if err != nil {
+			s.logger.Error("Failed to get telegram link", zap.String("chat_id", chat.id), zap.Error(err))
+			return s.unavailable(), ""
+		}
+		chat.link = link
+	}
+
+	reply, err := command.handle(ctx, chat, arg)
+	if err != nil {
+		s.logger.Error("Telegram bot command failed",
+			zap.String("chat_id", chat.id),
+			zap.String("command", name),
+			zap.Error(err))
+		return s.unavailable(), ""
+	}
+
+	return reply, ""
+}
+
+// rateLimited consome um token do comando no chat; retorna a espera quando não há token
+func (s *TelegramBotService) rateLimited(chatID, command string) time.Duration {
+	if s.commandRateLimit <= 0 {
+		return 0
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if len(s.buckets) >= maxCommandBuckets {
+		for key, bucket := range s.buckets {
+			if now.Sub(bucket.lastRefill) >= commandBucketIdle {
+				delete(s.buckets, key)
+			}
+		}
+	}
+
+	key := telegramCommandKey{chatID: chatID, command: command}
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = NewTokenBucket(s.commandRateLimit, now)
+		s.buckets[key] = bucket
+	}
+
+	if wait := bucket.Wait(now); wait > 0 {
+		return wait
+	}
+	bucket.Take(now)
+	return 0
+}
+
+// start boas-vindas; /start <código> vem do link de vinculação do web app
+func (s *TelegramBotService) start(ctx context.Context, chat *telegramChat, arg string) (*domain.TelegramBotReply, error) {
+	if arg != "" {
+		return s.linkChat(ctx, chat, arg)
+	}
+
+	text := "🏛️ <b>Bem-vindo ao Direito Lux!</b>\n\n" +
+		"🤖 Sou seu assistente para acompanhar os processos do escritório.\n\n"
+
+	link, err := s.links.GetByChat(ctx, chat.id)
+	if err != nil && !errors.Is(err, domain.ErrTelegramChatNotLinked) {
+		return nil, err
+	}
+	if link == nil {
+		text += s.linkInstructions()
+		return &domain.TelegramBotReply{Text: text, Keyboard: s.webAppKeyboard("🔗 Gerar código no Direito Lux", "/configuracoes")}, nil
+	}
+
+	text += "✅ Este chat já está vinculado à sua conta.\n\nDigite /help para ver os comandos."
+	return &domain.TelegramBotReply{Text: text, Keyboard: s.menuKeyboard()}, nil
+}
+
+// linkChat vincula o chat ao usuário dono do código
+func (s *TelegramBotService) linkChat(ctx context.Context, chat *telegramChat, arg string) (*domain.TelegramBotReply, error) {
+	code := domain.NormalizeTelegramLinkCode(arg)
+	if code == "" {
+		return &domain.TelegramBotReply{Text: s.linkInstructions()}, nil
+	}
+
+	now := s.now()
+	linkCode, err := s.links.ConsumeCode(ctx, code, now)
+	if errors.Is(err, domain.ErrTelegramLinkCodeInvalid) {
+		return &domain.TelegramBotReply{
+			Text: "❌ Código inválido ou expirado.\n\nGere um novo código no Direito Lux e envie <code>/vincular CÓDIGO</code>.",
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	link := &domain.TelegramLink{
+		ChatID:   chat.id,
+		TenantID: linkCode.TenantID,
+		UserID:   linkCode.UserID,
+		Username: chat.username,
+		LinkedAt: now,
+	}
+	if err := s.links.Link(ctx, link); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Telegram chat linked",
+		zap.String("chat_id", chat.id),
+		zap.String("tenant_id", link.TenantID.String()),
+		zap.String("user_id", link.UserID.String()))
+
+	return &domain.TelegramBotReply{
+		Text:     "✅ <b>Conta vinculada!</b>\n\nAgora você pode consultar seus processos por aqui. Digite /help para ver os comandos.",
+		Keyboard: s.menuKeyboard(),
+	}, nil
+}
+
+// unlinkChat remove o vínculo pelo próprio chat
+func (s *TelegramBotService) unlinkChat(ctx context.Context, chat *telegramChat, arg string) (*domain.TelegramBotReply, error) {
+	if err := s.links.Unlink(ctx, chat.link.TenantID, chat.link.UserID, chat.id); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Telegram chat unlinked", zap.String("chat_id", chat.id))
+	return &domain.TelegramBotReply{Text: "👋 Chat desvinculado. Para vincular novamente, gere um novo código no Direito Lux."}, nil
+}
+
+// help lista os comandos
+func (s *TelegramBotService) help(ctx context.Context, chat *telegramChat, arg string) (*domain.TelegramBotReply, error) {
+	text := "🆘 <b>Central de Ajuda - Direito Lux</b>\n\n" +
+		"📋 <b>Comandos:</b>\n" +
+		"/status - 📊 Resumo dos processos\n" +
+		"/agenda - 📅 Prazos dos próximos dias (ex.: <code>/agenda 15</code>)\n" +
+		"/busca - 🔍 Consultar processo pelo número\n" +
+		"/vincular - 🔗 Vincular este chat à sua conta\n" +
+		"/desvincular - Remover o vínculo deste chat\n" +
+		"/configurar - ⚙️ Configurar notificações\n\n" +
+		"💡 Envie um número CNJ (ex.: <code>1234567-89.2023.8.26.0001</code>) para consultar o processo."
+	if s.assistant != nil {
+		text += "\n💬 Ou pergunte em texto livre, por exemplo: <i>quais processos de cobrança estão ativos?</i>"
+	}
+
+	return &domain.TelegramBotReply{Text: text, Keyboard: s.menuKeyboard()}, nil
+}
+
+// settings as preferências de notificação são configuradas no web app
+func (s *TelegramBotService) settings(ctx context.Context, chat *telegramChat, arg string) (*domain.TelegramBotReply, error) {
+	return &domain.TelegramBotReply{
+		Text:     "⚙️ <b>Configurações</b>\n\nCanais, frequência dos alertas e horários de silêncio são configurados no Direito Lux.",
+		Keyboard: s.webAppKeyboard("⚙️ Abrir configurações", "/configuracoes"),
+	}, nil
+}
+
+// status resumo dos processos do tenant
+func (s *TelegramBotService) status(ctx context.Context, chat *telegramChat, arg string) (*domain.TelegramBotReply, error) {
+	stats, err := s.processes.GetStats(ctx, chat.link.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	text := fmt.Sprintf("📊 <b>Status dos Processos</b>\n\n"+
+		"📁 <b>Total:</b> %d\n"+
+		"🟢 <b>Ativos:</b> %d\n"+
+		"🟡 <b>Suspensos:</b> %d\n"+
+		"✅ <b>Concluídos:</b> %d\n"+
+		"🗄️ <b>Arquivados:</b> %d\n\n"+
+		"📈 <b>Movimentação recente:</b>\n"+
+		"• Novos nesta semana: %d\n"+
+		"• Novos neste mês: %d\n"+
+		"• Atualizados nos últimos 7 dias: %d",
+		stats.Total, stats.Active, stats.Suspended, stats.Concluded, stats.Archived,
+		stats.ThisWeek, stats.ThisMonth, stats.RecentlyUpdated)
+
+	keyboard := [][]domain.TelegramButton{{
+		{Text: "📅 Prazos", CallbackData: "agenda:" + strconv.Itoa(defaultAgendaDays)},
+		{Text: "🔄 Atualizar", CallbackData: "status"},
+	}}
+	keyboard = append(keyboard, s.webAppKeyboard("🌐 Abrir Direito Lux", "/processos")...)
+
+	return &domain.TelegramBotReply{Text: text, Keyboard: keyboard}, nil
+}
+
+// agenda prazos dos próximos dias (padrão 7)
+func (s *TelegramBotService) agenda(ctx context.Context, chat *telegramChat, arg string) (*domain.TelegramBotReply, error) {
+	days := defaultAgendaDays
+	if arg != "" {
+		if n, err := strconv.Atoi(strings.TrimSpace(arg)); err == nil && n >= 1 && n <= maxAgendaDays {
+			days = n
+		}
+	}
+
+	deadlines, err := s.processes.ListUpcomingDeadlines(ctx, chat.link.TenantID, days)
+	if err != nil {
+		return nil, err
+	}
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "📅 <b>Prazos dos próximos %d dias</b>\n\n", days)
+	if len(deadlines) == 0 {
+		text.WriteString("Nenhum prazo encontrado. 🎉")
+	}
+
+	today := truncateToDay(s.now())
+	var processButtons [][]domain.TelegramButton
+	seen := make(map[string]bool)
+	for i, deadline := range deadlines {
+		if i == maxAgendaItems {
+			fmt.Fprintf(&text, "\n… e mais %d prazos.", len(deadlines)-maxAgendaItems)
+			break
+		}
+
+		due := truncateToDay(deadline.DeadlineDate)
+		fmt.Fprintf(&text, "⏰ <b>%s</b> (%s)\n%s\n<code>%s</code>\n\n",
+			due.Format("02/01/2006"),
+			relativeDays(int(due.Sub(today).Hours()/24)),
+			html.EscapeString(deadline.Title),
+			html.EscapeString(deadline.ProcessNumber))
+
+		if !seen[deadline.ProcessNumber] && len(processButtons) < maxAgendaButtons {
+			seen[deadline.ProcessNumber] = true
+			processButtons = append(processButtons, []domain.TelegramButton{
+				{Text: "🔍 " + deadline.ProcessNumber, CallbackData: "processo:" + deadline.ProcessNumber},
+			})
+		}
+	}
+
+	keyboard := [][]domain.TelegramButton{{
+		{Text: "7 dias", CallbackData: "agenda:7"},
+		{Text: "15 dias", CallbackData: "agenda:15"},
+		{Text: "30 dias", CallbackData: "agenda:30"},
+	}}
+	keyboard = append(keyboard, processButtons...)
+
+	return &domain.TelegramBotReply{Text: strings.TrimSpace(text.String()), Keyboard: keyboard}, nil
+}
+
+// search /busca <número>; sem número, explica como consultar
+func (s *TelegramBotService) search(ctx context.Context, chat *telegramChat, arg string) (*domain.TelegramBotReply, error) {
+	if number, ok := domain.ParseCNJNumber(arg); ok {
+		return s.process(ctx, chat, number)
+	}
+
+	text := "🔍 <b>Consulta de Processos</b>\n\n" +
+		"Envie o número CNJ do processo, com ou sem pontuação:\n" +
+		"<code>1234567-89.2023.8.26.0001</code>\n" +
+		"<code>12345678920238260001</code>"
+	if arg != "" {
+		text = "❌ Número de processo inválido: <code>" + html.EscapeString(arg) + "</code>\n\n" + text
+	}
+
+	return &domain.TelegramBotReply{Text: text}, nil
+}
+
+// process dados do processo do tenant com a última movimentação
+func (s *TelegramBotService) process(ctx context.Context, chat *telegramChat, arg string) (*domain.TelegramBotReply, error) {
+	number, ok := domain.ParseCNJNumber(arg)
+	if !ok {
+		return s.search(ctx, chat, arg)
+	}
+
+	process, err := s.processes.FindByNumber(ctx, chat.link.TenantID, number)
+	if errors.Is(err, domain.ErrProcessNotFound) {
+		return &domain.TelegramBotReply{
+			Text:     "🔍 Processo <code>" + number + "</code> não encontrado entre os processos monitorados do escritório.",
+			Keyboard: s.webAppKeyboard("➕ Cadastrar no Direito Lux", "/processos"),
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "⚖️ <b>%s</b>\n<code>%s</code>\n\n", html.EscapeString(process.Title), html.EscapeString(process.Number))
+	fmt.Fprintf(&text, "📌 <b>Status:</b> %s\n", html.EscapeString(processStatusLabel(process.Status)))
+	if process.LastMovementTitle != nil {
+		text.WriteString("\n📝 <b>Última movimentação</b>")
+		if process.LastMovementDate != nil {
+			fmt.Fprintf(&text, " (%s)", process.LastMovementDate.Format("02/01/2006"))
+		}
+		fmt.Fprintf(&text, "\n%s", html.EscapeString(*process.LastMovementTitle))
+	} else {
+		text.WriteString("\n📝 Nenhuma movimentação registrada.")
+	}
+
+	keyboard := [][]domain.TelegramButton{{
+		{Text: "🔄 Atualizar", CallbackData: "processo:" + process.Number},
+		{Text: "📅 Prazos", CallbackData: "agenda:" + strconv.Itoa(defaultAgendaDays)},
+	}}
+	keyboard = append(keyboard, s.webAppKeyboard("🌐 Ver no Direito Lux", "/processos/"+process.ID)...)
+
+	return &domain.TelegramBotReply{Text: text.String(), Keyboard: keyboard}, nil
+}
+
+// ask encaminha a pergunta ao assistente; respostas longas são enviadas em várias mensagens e a
+// última volta como resposta do comando
+func (s *TelegramBotService) ask(ctx context.Context, chat *telegramChat, arg string) (*domain.TelegramBotReply, error) {
+	if strings.TrimSpace(arg) == "" {
+		return &domain.TelegramBotReply{
+			Text: "💬 Envie sua pergunta em texto livre, por exemplo: <i>qual a última movimentação do processo 1234567-89.2023.8.26.0001?</i>",
+		}, nil
+	}
+
+	answer, err := s.assistant.Reply(ctx, &domain.AssistantMessage{
+		TenantID:   chat.link.TenantID,
+		UserID:     chat.link.UserID,
+		Channel:    domain.NotificationChannelTelegram,
+		ExternalID: chat.id,
+		Text:       arg,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	parts := domain.FormatAssistantReply(domain.NotificationChannelTelegram, answer)
+	if len(parts) == 0 {
+		return s.unknown(), nil
+	}
+	for _, part := range parts[:len(parts)-1] {
+		if err := s.client.SendReply(ctx, chat.id, &domain.TelegramBotReply{Text: part}); err != nil {
+			return nil, err
+		}
+	}
+
+	return &domain.TelegramBotReply{Text: parts[len(parts)-1]}, nil
+}
+
+// unknown resposta para mensagens não reconhecidas
+func (s *TelegramBotService) unknown() *domain.TelegramBotReply {
+	return &domain.TelegramBotReply{
+		Text: "🤖 Não entendi sua mensagem.\n\nDigite /help para ver os comandos ou envie um número de processo.",
+	}
+}
+
+// notLinked resposta para comandos que exigem vínculo
+func (s *TelegramBotService) notLinked() *domain.TelegramBotReply {
+	return &domain.TelegramBotReply{
+		Text:     "🔒 Este chat ainda não está vinculado a uma conta.\n\n" + s.linkInstructions(),
+		Keyboard: s.webAppKeyboard("🔗 Gerar código no Direito Lux", "/configuracoes"),
+	}
+}
+
+// unavailable resposta quando a consulta falha
+func (s *TelegramBotService) unavailable() *domain.TelegramBotReply {
+	return &domain.TelegramBotReply{Text: "⚠️ Não foi possível consultar agora. Tente novamente em instantes."}
+}
+
+// linkInstructions passo a passo da vinculação
+func (s *TelegramBotService) linkInstructions() string {
+	return "🔗 <b>Para vincular sua conta:</b>\n" +
+		"1. No Direito Lux, acesse Configurações → Telegram\n" +
+		"2. Gere um código de vinculação\n" +
+		"3. Envie aqui <code>/vincular CÓDIGO</code>"
+}
+
+// menuKeyboard atalhos dos comandos principais
+func (s *TelegramBotService) menuKeyboard() [][]domain.TelegramButton {
+	return [][]domain.TelegramButton{{
+		{Text: "📊 Status", CallbackData: "status"},
+		{Text: "📅 Prazos", CallbackData: "agenda:" + strconv.Itoa(defaultAgendaDays)},
+	}}
+}
+
+// webAppKeyboard botão de link para o web app; vazio quando a URL não está configurada
+func (s *TelegramBotService) webAppKeyboard(text, path string) [][]domain.TelegramButton {
+	if s.webAppURL == "" {
+		return nil
+	}
+	return [][]domain.TelegramButton{{{Text: text, URL: s.webAppURL + path}}}
+}
+
+// processStatusLabel rótulo do status do process-service
+func processStatusLabel(status string) string {
+	switch status {
+	case "active":
+		return "🟢 Ativo"
+	case "suspended":
+		return "🟡 Suspenso"
+	case "concluded":
+		return "✅ Concluído"
+	case "archived":
+		return "🗄️ Arquivado"
+	default:
+		return status
+	}
+}
+
+// relativeDays distância em dias até o prazo
+func relativeDays(days int) string {
+	switch {
+	case days <= 0:
+		return "hoje"
+	case days == 1:
+		return "amanhã"
+	default:
+		return fmt.Sprintf("em %d dias", days)
+	}
+}
+
+// truncateToDay meia-noite UTC do dia
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.UTC().Year(), t.UTC().Month(), t.UTC().Day(), 0, 0, 0, 0, time.UTC)
+}
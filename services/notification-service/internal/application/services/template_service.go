@@ -15,16 +15,19 @@ import (
 // TemplateService serviço de aplicação para templates
 type TemplateService struct {
 	templateRepo domain.NotificationTemplateRepository
+	versionRepo  domain.TemplateVersionRepository
 	logger       *zap.Logger
 }
 
 // NewTemplateService cria nova instância do serviço
 func NewTemplateService(
 	templateRepo domain.NotificationTemplateRepository,
+	versionRepo domain.TemplateVersionRepository,
 	logger *zap.Logger,
 ) *TemplateService {
 	return &TemplateService{
 		templateRepo: templateRepo,
+		versionRepo:  versionRepo,
 		logger:       logger,
 	}
 }
@@ -64,7 +67,11 @@ func (s *TemplateService) CreateTemplate(ctx context.Context, req *CreateTemplat
 	// Extrair variáveis do conteúdo se não fornecidas
 	variables := req.Variables
 	if len(variables) == 0 {
-		variables = s.extractVariables(req.Subject, req.Content)
+		extracted, err := domain.TemplateVariables(req.Subject, req.Content)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template: %w", err)
+		}
+		variables = extracted
 	}
 
 	template := &domain.NotificationTemplate{
@@ -78,6 +85,7 @@ func (s *TemplateService) CreateTemplate(ctx context.Context, req *CreateTemplat
 		Variables: variables,
 		TenantID:  req.TenantID,
 		IsSystem:  false,
+		DefaultLocale: domain.DefaultLocale,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -86,48 +94,13 @@ func (s *TemplateService) CreateTemplate(ctx context.Context, req *CreateTemplat
 		return nil, fmt.Errorf("failed to create template: %w", err)
 	}
 
-	s.logger.Info("Template created successfully", zap.String("template_id", template.ID.String()))
-	return template, nil
-}
-
-// extractVariables extrai variáveis do formato {{variavel}} do conteúdo
-func (s *TemplateService) extractVariables(subject, content string) []string {
-	variables := make(map[string]bool)
-	text := subject + " " + content
-
-	// Buscar padrão {{variavel}}
-	start := 0
-	for {
-		openIndex := strings.Index(text[start:], "{{")
-		if openIndex == -1 {
-			break
-		}
-		openIndex += start
-
-		closeIndex := strings.Index(text[openIndex:], "}}")
-		if closeIndex == -1 {
-			break
-		}
-		closeIndex += openIndex
-
-		if closeIndex > openIndex+2 {
-			variable := text[openIndex+2 : closeIndex]
-			variable = strings.TrimSpace(variable)
-			if variable != "" {
-				variables[variable] = true
-			}
-		}
-
-		start = closeIndex + 2
-	}
-
-	// Converter para slice
-	result := make([]string, 0, len(variables))
-	for variable := range variables {
-		result = append(result, variable)
+	// Conteúdo inicial é a versão 1 publicada no idioma padrão
+	if _, err := s.publishHeadVersion(ctx, template); err != nil {
+		return nil, err
 	}
 
-	return result
+	s.logger.Info("Template created successfully", zap.String("template_id", template.ID.String()))
+	return template, nil
 }
 
 // GetTemplate busca template por ID
@@ -165,11 +138,14 @@ func (s *TemplateService) UpdateTemplate(ctx context.Context, id uuid.UUID, req
 
 	// Atualizar variáveis se conteúdo mudou
 	if req.Subject != nil || req.Content != nil {
+		variables, err := domain.TemplateVariables(template.Subject, template.Content)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template: %w", err)
+		}
 		if req.Variables != nil {
-			template.Variables = req.Variables
-		} else {
-			template.Variables = s.extractVariables(template.Subject, template.Content)
+			variables = req.Variables
 		}
+		template.Variables = variables
 	}
 
 	template.UpdatedAt = time.Now()
@@ -178,6 +154,14 @@ func (s *TemplateService) UpdateTemplate(ctx context.Context, id uuid.UUID, req
 		return nil, fmt.Errorf("failed to update template: %w", err)
 	}
 
+	// Conteúdo editado não sobrescreve a versão anterior: vira nova versão publicada,
+	// que pode ser revertida com rollback
+	if req.Subject != nil || req.Content != nil {
+		if _, err := s.publishHeadVersion(ctx, template); err != nil {
+			return nil, err
+		}
+	}
+
 	s.logger.Info("Template updated successfully", zap.String("template_id", template.ID.String()))
 	return template, nil
 }
@@ -219,47 +203,31 @@ func (s *TemplateService) GetTemplateByTypeAndChannel(ctx context.Context, notif
 	return s.templateRepo.FindByTypeAndChannel(ctx, notificationType, channel, tenantID)
 }
 
-// PreviewTemplate faz preview de um template com variáveis
-func (s *TemplateService) PreviewTemplate(ctx context.Context, id uuid.UUID, variables map[string]interface{}) (*TemplatePreview, error) {
+// PreviewTemplate faz preview de um template com variáveis. Em modo estrito, variáveis
+// ausentes e filtros inválidos são rejeitados.
+func (s *TemplateService) PreviewTemplate(ctx context.Context, id uuid.UUID, variables map[string]interface{}, strict bool) (*TemplatePreview, error) {
 	template, err := s.templateRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("template not found: %w", err)
 	}
 
-	preview := &TemplatePreview{
-		TemplateID: template.ID,
-		Subject:    s.processVariables(template.Subject, variables),
-		Content:    s.processVariables(template.Content, variables),
-		Variables:  template.Variables,
+	rendered, err := template.Render(variables, strict)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render template: %w", err)
 	}
 
-	// Verificar variáveis não fornecidas
-	missingVars := []string{}
-	for _, variable := range template.Variables {
-		if _, exists := variables[variable]; !exists {
-			missingVars = append(missingVars, variable)
-		}
+	preview := &TemplatePreview{
+		TemplateID:       template.ID,
+		Subject:          rendered.Subject,
+		Content:          rendered.Content,
+		ContentHTML:      rendered.ContentHTML,
+		Variables:        template.Variables,
+		MissingVariables: rendered.MissingVariables,
 	}
-	preview.MissingVariables = missingVars
 
 	return preview, nil
 }
 
-// processVariables substitui variáveis no texto
-func (s *TemplateService) processVariables(text string, variables map[string]interface{}) string {
-	if variables == nil {
-		return text
-	}
-
-	result := text
-	for key, value := range variables {
-		placeholder := fmt.Sprintf("{{%s}}", key)
-		result = strings.ReplaceAll(result, placeholder, fmt.Sprintf("%v", value))
-	}
-
-	return result
-}
-
 // ValidateTemplate valida a estrutura de um template
 func (s *TemplateService) ValidateTemplate(ctx context.Context, req *CreateTemplateRequest) error {
 	// Validar que o conteúdo não está vazio
@@ -267,51 +235,9 @@ func (s *TemplateService) ValidateTemplate(ctx context.Context, req *CreateTempl
 		return fmt.Errorf("template content cannot be empty")
 	}
 
-	// Validar que variáveis no conteúdo estão bem formadas
-	if err := s.validateVariables(req.Subject, req.Content); err != nil {
-		return fmt.Errorf("invalid variables in template: %w", err)
-	}
-
-	return nil
-}
-
-// validateVariables valida se as variáveis estão bem formadas
-func (s *TemplateService) validateVariables(subject, content string) error {
-	text := subject + " " + content
-	
-	// Contar abertura e fechamento de variáveis
-	openCount := strings.Count(text, "{{")
-	closeCount := strings.Count(text, "}}")
-
-	if openCount != closeCount {
-		return fmt.Errorf("mismatched variable delimiters")
-	}
-
-	// Verificar se todas as variáveis estão bem formadas
-	start := 0
-	for {
-		openIndex := strings.Index(text[start:], "{{")
-		if openIndex == -1 {
-			break
-		}
-		openIndex += start
-
-		closeIndex := strings.Index(text[openIndex:], "}}")
-		if closeIndex == -1 {
-			return fmt.Errorf("unclosed variable at position %d", openIndex)
-		}
-		closeIndex += openIndex
-
-		if closeIndex <= openIndex+2 {
-			return fmt.Errorf("empty variable at position %d", openIndex)
-		}
-
-		variable := text[openIndex+2 : closeIndex]
-		if strings.TrimSpace(variable) == "" {
-			return fmt.Errorf("empty variable name at position %d", openIndex)
-		}
-
-		start = closeIndex + 2
+	// Validar sintaxe: variáveis, filtros e blocos if/range
+	if _, err := domain.TemplateVariables(req.Subject, req.Content); err != nil {
+		return fmt.Errorf("invalid template: %w", err)
 	}
 
 	return nil
@@ -336,9 +262,11 @@ func (s *TemplateService) DuplicateTemplate(ctx context.Context, id uuid.UUID, n
 		Status:    domain.TemplateStatusDraft,
 		Subject:   original.Subject,
 		Content:   original.Content,
+		ContentHTML: original.ContentHTML,
 		Variables: make([]string, len(original.Variables)),
 		TenantID:  tenantID,
 		IsSystem:  false,
+		DefaultLocale: original.DefaultLocale,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -349,6 +277,10 @@ func (s *TemplateService) DuplicateTemplate(ctx context.Context, id uuid.UUID, n
 		return nil, fmt.Errorf("failed to duplicate template: %w", err)
 	}
 
+	if _, err := s.publishHeadVersion(ctx, duplicate); err != nil {
+		return nil, err
+	}
+
 	s.logger.Info("Template duplicated successfully", 
 		zap.String("original_id", original.ID.String()),
 		zap.String("duplicate_id", duplicate.ID.String()))
@@ -388,6 +320,7 @@ type TemplatePreview struct {
 	TemplateID       uuid.UUID `json:"template_id"`
 	Subject          string    `json:"subject"`
 	Content          string    `json:"content"`
+	ContentHTML      *string   `json:"content_html,omitempty"`
 	Variables        []string  `json:"variables"`
 	MissingVariables []string  `json:"missing_variables"`
 }
\ No newline at end of file
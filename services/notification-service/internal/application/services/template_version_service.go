@@ -0,0 +1,264 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// CreateTemplateVersionRequest request para criação de versão de template
+type CreateTemplateVersionRequest struct {
+	Locale      string  `json:"locale"`  // padrão: idioma padrão do template
+	Variant     string  `json:"variant"` // padrão: "default"
+	Weight      *int    `json:"weight,omitempty"`
+	Subject     string  `json:"subject" validate:"required"`
+	Content     string  `json:"content" validate:"required"`
+	ContentHTML *string `json:"content_html,omitempty"`
+	Publish     bool    `json:"publish"` // publica imediatamente
+}
+
+// RollbackTemplateRequest request para reverter a versão publicada
+type RollbackTemplateRequest struct {
+	Locale  string `json:"locale"`
+	Variant string `json:"variant"`
+}
+
+// CreateVersion cria nova versão imutável do template, opcionalmente já publicada
+func (s *TemplateService) CreateVersion(ctx context.Context, templateID uuid.UUID, req *CreateTemplateVersionRequest) (*domain.TemplateVersion, error) {
+	template, err := s.getEditableTemplate(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	locale := req.Locale
+	if locale == "" {
+		locale = template.DefaultLocale
+	}
+	weight := domain.DefaultVariantWeight
+	if req.Weight != nil {
+		weight = *req.Weight
+	}
+
+	version, err := domain.NewTemplateVersion(template.ID, locale, req.Variant, weight, req.Subject, req.Content, req.ContentHTML)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.versionRepo.Create(ctx, version); err != nil {
+		return nil, fmt.Errorf("failed to create template version: %w", err)
+	}
+
+	s.logger.Info("Template version created",
+		zap.String("template_id", template.ID.String()),
+		zap.Int("version", version.Version),
+		zap.String("locale", version.Locale),
+		zap.String("variant", version.Variant))
+
+	if req.Publish {
+		if err := s.publish(ctx, template, version); err != nil {
+			return nil, err
+		}
+	}
+
+	return version, nil
+}
+
+// ListVersions lista as versões do template, da mais recente para a mais antiga
+func (s *TemplateService) ListVersions(ctx context.Context, templateID uuid.UUID) ([]*domain.TemplateVersion, error) {
+	if _, err := s.templateRepo.GetByID(ctx, templateID); err != nil {
+		return nil, fmt.Errorf("template not found: %w", err)
+	}
+	return s.versionRepo.FindByTemplate(ctx, templateID)
+}
+
+// PublishVersion publica a versão, substituindo a publicada no mesmo idioma e variante
+func (s *TemplateService) PublishVersion(ctx context.Context, templateID, versionID uuid.UUID) (*domain.TemplateVersion, error) {
+	template, err := s.getEditableTemplate(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := s.getVersion(ctx, templateID, versionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.publish(ctx, template, version); err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+// RollbackVersion republica a versão publicada anteriormente no idioma e variante
+func (s *TemplateService) RollbackVersion(ctx context.Context, templateID uuid.UUID, req *RollbackTemplateRequest) (*domain.TemplateVersion, error) {
+	template, err := s.getEditableTemplate(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	locale := req.Locale
+	if locale == "" {
+		locale = template.DefaultLocale
+	}
+	if locale, err = domain.NormalizeLocale(locale); err != nil {
+		return nil, err
+	}
+	variant := req.Variant
+	if variant == "" {
+		variant = domain.DefaultTemplateVariant
+	}
+
+	versions, err := s.versionRepo.FindByTemplate(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Versões vêm da mais recente para a mais antiga: a alvo é a primeira já publicada
+	// alguma vez e anterior à publicada atualmente
+	current := 0
+	for _, v := range versions {
+		if v.Locale == locale && v.Variant == variant && v.IsPublished() {
+			current = v.Version
+			break
+		}
+	}
+
+	var target *domain.TemplateVersion
+	for _, v := range versions {
+		if v.Locale != locale || v.Variant != variant || v.IsPublished() || v.PublishedAt == nil {
+			continue
+		}
+		if current == 0 || v.Version < current {
+			target = v
+			break
+		}
+	}
+	if target == nil {
+		return nil, fmt.Errorf("%w: nenhuma versão anterior publicada para %s/%s", domain.ErrTemplateVersionNotFound, locale, variant)
+	}
+
+	if err := s.publish(ctx, template, target); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Template version rolled back",
+		zap.String("template_id", templateID.String()),
+		zap.Int("from_version", current),
+		zap.Int("to_version", target.Version))
+	return target, nil
+}
+
+// ArchiveVersion arquiva a versão, retirando-a do sorteio de variantes
+func (s *TemplateService) ArchiveVersion(ctx context.Context, templateID, versionID uuid.UUID) error {
+	if _, err := s.getEditableTemplate(ctx, templateID); err != nil {
+		return err
+	}
+	if _, err := s.getVersion(ctx, templateID, versionID); err != nil {
+		return err
+	}
+	return s.versionRepo.Archive(ctx, versionID)
+}
+
+// UpdateVariantWeight altera o peso da variante no teste A/B (0 pausa a variante)
+func (s *TemplateService) UpdateVariantWeight(ctx context.Context, templateID, versionID uuid.UUID, weight int) (*domain.TemplateVersion, error) {
+	if err := domain.ValidateVariantWeight(weight); err != nil {
+		return nil, err
+	}
+	if _, err := s.getEditableTemplate(ctx, templateID); err != nil {
+		return nil, err
+	}
+
+	version, err := s.getVersion(ctx, templateID, versionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.versionRepo.UpdateWeight(ctx, versionID, weight); err != nil {
+		return nil, err
+	}
+	version.Weight = weight
+	return version, nil
+}
+
+// GetVariantStats métricas de abertura, clique e leitura por versão nos últimos dias
+func (s *TemplateService) GetVariantStats(ctx context.Context, templateID uuid.UUID, days int) ([]*domain.TemplateVariantStats, error) {
+	if _, err := s.templateRepo.GetByID(ctx, templateID); err != nil {
+		return nil, fmt.Errorf("template not found: %w", err)
+	}
+
+	since := time.Now().AddDate(0, 0, -days)
+	return s.versionRepo.GetVariantStats(ctx, templateID, since)
+}
+
+// publishHeadVersion grava o conteúdo atual do template como nova versão publicada
+// no idioma padrão e variante padrão
+func (s *TemplateService) publishHeadVersion(ctx context.Context, template *domain.NotificationTemplate) (*domain.TemplateVersion, error) {
+	version, err := domain.NewTemplateVersion(
+		template.ID, template.DefaultLocale, domain.DefaultTemplateVariant, domain.DefaultVariantWeight,
+		template.Subject, template.Content, template.ContentHTML,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.versionRepo.Create(ctx, version); err != nil {
+		return nil, fmt.Errorf("failed to create template version: %w", err)
+	}
+	if err := s.versionRepo.Publish(ctx, version); err != nil {
+		return nil, fmt.Errorf("failed to publish template version: %w", err)
+	}
+
+	return version, nil
+}
+
+// publish publica a versão; no idioma e variante padrão, o conteúdo do template acompanha a
+// versão para que listagens e clientes antigos vejam o conteúdo em vigor
+func (s *TemplateService) publish(ctx context.Context, template *domain.NotificationTemplate, version *domain.TemplateVersion) error {
+	if err := s.versionRepo.Publish(ctx, version); err != nil {
+		return fmt.Errorf("failed to publish template version: %w", err)
+	}
+
+	if version.Locale != template.DefaultLocale || version.Variant != domain.DefaultTemplateVariant {
+		return nil
+	}
+
+	template.Subject = version.Subject
+	template.Content = version.Content
+	template.ContentHTML = version.ContentHTML
+	template.Variables = version.Variables
+	template.UpdatedAt = time.Now()
+
+	if err := s.templateRepo.Update(ctx, template); err != nil {
+		return fmt.Errorf("failed to update template: %w", err)
+	}
+	return nil
+}
+
+// getEditableTemplate busca template que aceita versões (não é do sistema)
+func (s *TemplateService) getEditableTemplate(ctx context.Context, templateID uuid.UUID) (*domain.NotificationTemplate, error) {
+	template, err := s.templateRepo.GetByID(ctx, templateID)
+	if err != nil {
+		return nil, fmt.Errorf("template not found: %w", err)
+	}
+	if template.IsSystem {
+		return nil, fmt.Errorf("cannot version system template")
+	}
+	return template, nil
+}
+
+// getVersion busca versão garantindo que pertence ao template
+func (s *TemplateService) getVersion(ctx context.Context, templateID, versionID uuid.UUID) (*domain.TemplateVersion, error) {
+	version, err := s.versionRepo.GetByID(ctx, versionID)
+	if err != nil {
+		return nil, err
+	}
+	if version.TemplateID != templateID {
+		return nil, domain.ErrTemplateVersionNotFound
+	}
+	return version, nil
+}
@@ -0,0 +1,446 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// WebhookServiceConfig configurações das entregas dos webhooks
+type WebhookServiceConfig struct {
+	RetryPolicy          domain.WebhookRetryPolicy
+	DisableAfterFailures int
+	Timeout              time.Duration
+	PollInterval         time.Duration
+	BatchSize            int
+	AllowInsecureURLs    bool
+}
+
+// WebhookService gerencia os webhooks de saída dos tenants: cadastro limitado pelo plano,
+// criação das entregas a partir dos eventos recebidos e envio periódico das entregas pendentes,
+// com novas tentativas em backoff exponencial e desativação após falhas seguidas
+type WebhookService struct {
+	subscriptions domain.WebhookSubscriptionRepository
+	deliveries    domain.WebhookDeliveryRepository
+	sender        domain.WebhookSender
+	plans         domain.TenantPlanDirectory // nil: limites do plano não verificados
+	cfg           WebhookServiceConfig
+	logger        *zap.Logger
+	now           func() time.Time
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewWebhookService cria nova instância do serviço
+func NewWebhookService(
+	subscriptions domain.WebhookSubscriptionRepository,
+	deliveries domain.WebhookDeliveryRepository,
+	sender domain.WebhookSender,
+	plans domain.TenantPlanDirectory,
+	cfg WebhookServiceConfig,
+	logger *zap.Logger,
+) *WebhookService {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 2 * time.Second
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 50
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	if cfg.RetryPolicy.MaxAttempts <= 0 {
+		cfg.RetryPolicy.MaxAttempts = 1
+	}
+
+	return &WebhookService{
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+		sender:        sender,
+		plans:         plans,
+		cfg:           cfg,
+		logger:        logger,
+		now:           time.Now,
+	}
+}
+
+// WebhookSubscriptionRequest dados da inscrição informados pelo tenant
+type WebhookSubscriptionRequest struct {
+	URL         string   `json:"url" binding:"required"`
+	Description string   `json:"description,omitempty"`
+	Events      []string `json:"events" binding:"required"`
+}
+
+// WebhookSubscriptionUpdate alteração parcial da inscrição
+type WebhookSubscriptionUpdate struct {
+	URL         *string  `json:"url,omitempty"`
+	Description *string  `json:"description,omitempty"`
+	Events      []string `json:"events,omitempty"`
+	Active      *bool    `json:"active,omitempty"`
+}
+
+// WebhookSubscriptionCreated inscrição criada, com o segredo de assinatura exibido uma única vez
+type WebhookSubscriptionCreated struct {
+	*domain.WebhookSubscription
+	Secret string `json:"secret"`
+}
+
+// WebhookPingResult resultado do evento de teste
+type WebhookPingResult struct {
+	Success        bool   `json:"success"`
+	ResponseStatus int    `json:"response_status,omitempty"`
+	ResponseBody   string `json:"response_body,omitempty"`
+	Error          string `json:"error,omitempty"`
+	DurationMs     int64  `json:"duration_ms"`
+}
+
+// CreateSubscription cadastra a inscrição, respeitando o recurso e o limite de webhooks do plano
+func (s *WebhookService) CreateSubscription(ctx context.Context, tenantID uuid.UUID, req *WebhookSubscriptionRequest) (*WebhookSubscriptionCreated, error) {
+	subscription, err := domain.NewWebhookSubscription(tenantID, req.URL, req.Description, req.Events, s.cfg.AllowInsecureURLs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkPlan(ctx, tenantID); err != nil {
+		return nil, err
+	}
+
+	if err := s.subscriptions.Create(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Webhook subscription created",
+		zap.String("tenant_id", tenantID.String()),
+		zap.String("subscription_id", subscription.ID.String()),
+		zap.Strings("events", subscription.Events))
+
+	return &WebhookSubscriptionCreated{WebhookSubscription: subscription, Secret: subscription.Secret}, nil
+}
+
+// ListSubscriptions lista as inscrições do tenant
+func (s *WebhookService) ListSubscriptions(ctx context.Context, tenantID uuid.UUID) ([]*domain.WebhookSubscription, error) {
+	return s.subscriptions.ListByTenant(ctx, tenantID)
+}
+
+// GetSubscription busca a inscrição do tenant
+func (s *WebhookService) GetSubscription(ctx context.Context, tenantID, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	return s.subscriptions.FindByID(ctx, tenantID, id)
+}
+
+// UpdateSubscription altera a inscrição. Reativar uma inscrição desativada zera as falhas e
+// verifica de novo os limites do plano.
+func (s *WebhookService) UpdateSubscription(ctx context.Context, tenantID, id uuid.UUID, req *WebhookSubscriptionUpdate) (*domain.WebhookSubscription, error) {
+	subscription, err := s.subscriptions.FindByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	if req.URL != nil {
+		if err := domain.ValidateWebhookURL(*req.URL, s.cfg.AllowInsecureURLs); err != nil {
+			return nil, err
+		}
+		subscription.URL = strings.TrimSpace(*req.URL)
+	}
+	if req.Description != nil {
+		subscription.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.Events != nil {
+		if err := domain.ValidateWebhookEventFilters(req.Events); err != nil {
+			return nil, err
+		}
+		subscription.Events = req.Events
+	}
+	if req.Active != nil && *req.Active != subscription.Active {
+		if *req.Active {
+			if err := s.checkPlan(ctx, tenantID); err != nil {
+				return nil, err
+			}
+			subscription.Enable(now)
+		} else {
+			subscription.Disable(now, "desativado pelo tenant")
+		}
+	}
+	subscription.UpdatedAt = now
+
+	if err := s.subscriptions.Update(ctx, subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// DeleteSubscription remove a inscrição e o histórico de entregas
+func (s *WebhookService) DeleteSubscription(ctx context.Context, tenantID, id uuid.UUID) error {
+	return s.subscriptions.Delete(ctx, tenantID, id)
+}
+
+// ListDeliveries lista o histórico de entregas da inscrição
+func (s *WebhookService) ListDeliveries(ctx context.Context, tenantID, subscriptionID uuid.UUID, filter domain.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, error) {
+	if _, err := s.subscriptions.FindByID(ctx, tenantID, subscriptionID); err != nil {
+		return nil, err
+	}
+	return s.deliveries.ListBySubscription(ctx, tenantID, subscriptionID, filter)
+}
+
+// ReplayDelivery cria nova entrega com o mesmo corpo de uma entrega anterior da inscrição
+func (s *WebhookService) ReplayDelivery(ctx context.Context, tenantID, subscriptionID, deliveryID uuid.UUID) (*domain.WebhookDelivery, error) {
+	subscription, err := s.subscriptions.FindByID(ctx, tenantID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if !subscription.Active {
+		return nil, fmt.Errorf("%w: reative a inscrição antes de reenviar", domain.ErrWebhookDisabled)
+	}
+
+	original, err := s.deliveries.FindByID(ctx, tenantID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if original.SubscriptionID != subscription.ID {
+		return nil, domain.ErrWebhookDeliveryNotFound
+	}
+
+	replay := original.Replay()
+	if _, err := s.deliveries.Create(ctx, replay); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Webhook delivery replayed",
+		zap.String("subscription_id", subscription.ID.String()),
+		zap.String("delivery_id", original.ID.String()),
+		zap.String("replay_id", replay.ID.String()))
+
+	return replay, nil
+}
+
+// Ping envia o evento de teste na hora, sem registrar entrega nem contar falhas
+func (s *WebhookService) Ping(ctx context.Context, tenantID, subscriptionID uuid.UUID) (*WebhookPingResult, error) {
+	subscription, err := s.subscriptions.FindByID(ctx, tenantID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(map[string]string{
+		"subscription_id": subscription.ID.String(),
+		"message":         "Evento de teste do Direito Lux",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal ping data: %w", err)
+	}
+
+	delivery, err := domain.NewWebhookDelivery(subscription, &domain.WebhookEvent{
+		ID:         uuid.New(),
+		Type:       domain.WebhookEventPing,
+		TenantID:   tenantID,
+		OccurredAt: s.now(),
+		Data:       data,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+	result := s.sender.Send(sendCtx, subscription.URL, subscription.Secret, delivery)
+
+	return &WebhookPingResult{
+		Success:        result.Succeeded(),
+		ResponseStatus: result.StatusCode,
+		ResponseBody:   result.Body,
+		Error:          result.Error,
+		DurationMs:     result.Duration.Milliseconds(),
+	}, nil
+}
+
+// HandleWebhookEvent cria as entregas do evento para as inscrições ativas do tenant que o
+// assinam. Reprocessar o mesmo evento não duplica as entregas.
+func (s *WebhookService) HandleWebhookEvent(ctx context.Context, event *domain.WebhookEvent) error {
+	subscriptions, err := s.subscriptions.ListByTenant(ctx, event.TenantID)
+	if err != nil {
+		return fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+
+	var failed error
+	for _, subscription := range subscriptions {
+		if !subscription.Accepts(event.Type) {
+			continue
+		}
+
+		delivery, err := domain.NewWebhookDelivery(subscription, event)
+		if err != nil {
+			return err
+		}
+
+		if _, err := s.deliveries.Create(ctx, delivery); err != nil {
+			s.logger.Error("Failed to create webhook delivery",
+				zap.String("subscription_id", subscription.ID.String()),
+				zap.String("event_id", event.ID.String()),
+				zap.Error(err))
+			failed = err
+		}
+	}
+
+	return failed
+}
+
+// Start inicia o envio periódico das entregas pendentes
+func (s *WebhookService) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	s.wg.Add(1)
+	go s.run(ctx)
+}
+
+// Stop interrompe o envio periódico e aguarda o ciclo em andamento
+func (s *WebhookService) Stop(ctx context.Context) error {
+	if s.cancel == nil {
+		return nil
+	}
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run loop periódico
+func (s *WebhookService) run(ctx context.Context) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.DispatchDue(ctx); err != nil {
+				s.logger.Error("Failed to dispatch webhook deliveries", zap.Error(err))
+			}
+		}
+	}
+}
+
+// DispatchDue envia as entregas pendentes vencidas. Retorna a quantidade de tentativas feitas.
+func (s *WebhookService) DispatchDue(ctx context.Context) (int, error) {
+	// O lease cobre a tentativa; se a instância cair, a entrega volta a vencer
+	lease := 2*s.cfg.Timeout + 30*time.Second
+	deliveries, err := s.deliveries.ClaimDue(ctx, s.now(), s.cfg.BatchSize, lease)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+
+	// Inscrições do lote, para que as falhas de várias entregas se acumulem
+	subscriptions := make(map[uuid.UUID]*domain.WebhookSubscription)
+	attempted := 0
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil {
+			break
+		}
+
+		subscription, ok := subscriptions[delivery.SubscriptionID]
+		if !ok {
+			subscription, err = s.subscriptions.FindByID(ctx, delivery.TenantID, delivery.SubscriptionID)
+			if err != nil && !errors.Is(err, domain.ErrWebhookNotFound) {
+				s.logger.Error("Failed to find webhook subscription",
+					zap.String("subscription_id", delivery.SubscriptionID.String()),
+					zap.Error(err))
+				continue
+			}
+			subscriptions[delivery.SubscriptionID] = subscription
+		}
+
+		if subscription == nil || !subscription.Active {
+			delivery.Fail(s.now(), "inscrição desativada")
+			s.updateDelivery(ctx, delivery)
+			continue
+		}
+
+		s.attempt(ctx, subscription, delivery)
+		attempted++
+	}
+
+	return attempted, nil
+}
+
+// attempt envia a entrega e atualiza a entrega e os contadores da inscrição
+func (s *WebhookService) attempt(ctx context.Context, subscription *domain.WebhookSubscription, delivery *domain.WebhookDelivery) {
+	sendCtx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	result := s.sender.Send(sendCtx, subscription.URL, subscription.Secret, delivery)
+	cancel()
+
+	now := s.now()
+	delivery.RecordAttempt(result, s.cfg.RetryPolicy, now)
+	s.updateDelivery(ctx, delivery)
+
+	switch {
+	case result.Succeeded():
+		subscription.RecordSuccess(now)
+	case result.Gone():
+		subscription.RecordFailure(now, 0)
+		subscription.Disable(now, "destino respondeu 410 Gone")
+		s.logger.Warn("Webhook subscription disabled by endpoint",
+			zap.String("subscription_id", subscription.ID.String()))
+	default:
+		if subscription.RecordFailure(now, s.cfg.DisableAfterFailures) {
+			s.logger.Warn("Webhook subscription disabled after consecutive failures",
+				zap.String("subscription_id", subscription.ID.String()),
+				zap.Int("failures", subscription.ConsecutiveFailures))
+		}
+	}
+
+	if err := s.subscriptions.Update(ctx, subscription); err != nil {
+		s.logger.Error("Failed to update webhook subscription",
+			zap.String("subscription_id", subscription.ID.String()),
+			zap.Error(err))
+	}
+}
+
+// updateDelivery grava o resultado da entrega
+func (s *WebhookService) updateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) {
+	if err := s.deliveries.Update(ctx, delivery); err != nil {
+		s.logger.Error("Failed to update webhook delivery",
+			zap.String("delivery_id", delivery.ID.String()),
+			zap.Error(err))
+	}
+}
+
+// checkPlan verifica se o plano do tenant comporta mais uma inscrição ativa
+func (s *WebhookService) checkPlan(ctx context.Context, tenantID uuid.UUID) error {
+	if s.plans == nil {
+		return nil
+	}
+
+	limits, err := s.plans.GetPlanLimits(ctx, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant plan: %w", err)
+	}
+
+	active, err := s.subscriptions.CountActive(ctx, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to count webhook subscriptions: %w", err)
+	}
+
+	return limits.AllowsWebhooks(active)
+}
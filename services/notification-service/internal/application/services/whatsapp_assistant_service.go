@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+const (
+	whatsAppLinkInstructions = "🔗 *Para vincular sua conta:*\n" +
+		"1. No Direito Lux, acesse Configurações → Telegram/WhatsApp\n" +
+		"2. Gere um código de vinculação\n" +
+		"3. Envie aqui: vincular CÓDIGO"
+	whatsAppUnavailableReply = "⚠️ Não foi possível consultar agora. Tente novamente em instantes."
+)
+
+// WhatsAppAssistantService atende as mensagens recebidas no WhatsApp: vinculação do contato com os
+// códigos do web app e, para contatos vinculados, perguntas respondidas pelo assistente
+type WhatsAppAssistantService struct {
+	links     domain.WhatsAppLinkRepository
+	codes     domain.TelegramLinkRepository
+	assistant domain.ConversationalAssistant
+	client    domain.WhatsAppReplyClient
+	rateLimit int
+	logger    *zap.Logger
+
+	mu      sync.Mutex
+	buckets map[string]*TokenBucket
+	now     func() time.Time
+}
+
+// NewWhatsAppAssistantService cria nova instância do serviço. rateLimit é o limite de mensagens
+// por minuto, por contato; mensagens acima do limite são ignoradas.
+func NewWhatsAppAssistantService(
+	links domain.WhatsAppLinkRepository,
+	codes domain.TelegramLinkRepository,
+	assistant domain.ConversationalAssistant,
+	client domain.WhatsAppReplyClient,
+	rateLimit int,
+	logger *zap.Logger,
+) *WhatsAppAssistantService {
+	return &WhatsAppAssistantService{
+		links:     links,
+		codes:     codes,
+		assistant: assistant,
+		client:    client,
+		rateLimit: rateLimit,
+		logger:    logger,
+		buckets:   make(map[string]*TokenBucket),
+		now:       time.Now,
+	}
+}
+
+// HandleInbound responde a mensagem de texto recebida do contato: "vincular CÓDIGO",
+// "desvincular" ou uma pergunta ao assistente
+func (s *WhatsAppAssistantService) HandleInbound(ctx context.Context, from, text string) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+
+	contact := domain.NormalizeWhatsAppContact(from)
+	if s.rateLimited(contact) {
+		s.logger.Debug("WhatsApp assistant rate limited", zap.String("contact", contact))
+		return nil
+	}
+
+	command, arg, _ := strings.Cut(text, " ")
+	switch strings.ToLower(command) {
+	case "vincular":
+		return s.reply(ctx, contact, s.linkContact(ctx, contact, arg))
+	case "desvincular":
+		return s.reply(ctx, contact, s.unlinkContact(ctx, contact))
+	}
+
+	link, err := s.links.GetByContact(ctx, contact)
+	if errors.Is(err, domain.ErrWhatsAppContactNotLinked) {
+		return s.reply(ctx, contact, "🔒 Este número ainda não está vinculado a uma conta do Direito Lux.\n\n"+whatsAppLinkInstructions)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get whatsapp link: %w", err)
+	}
+
+	answer, err := s.assistant.Reply(ctx, &domain.AssistantMessage{
+		TenantID:   link.TenantID,
+		UserID:     link.UserID,
+		Channel:    domain.NotificationChannelWhatsApp,
+		ExternalID: contact,
+		Text:       text,
+	})
+	if err != nil {
+		s.logger.Error("WhatsApp assistant failed", zap.String("contact", contact), zap.Error(err))
+		return s.reply(ctx, contact, whatsAppUnavailableReply)
+	}
+
+	for _, part := range domain.FormatAssistantReply(domain.NotificationChannelWhatsApp, answer) {
+		if err := s.client.SendText(ctx, contact, part); err != nil {
+			return fmt.Errorf("failed to send whatsapp reply: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// linkContact vincula o contato ao usuário dono do código
+func (s *WhatsAppAssistantService) linkContact(ctx context.Context, contact, arg string) string {
+	code := domain.NormalizeTelegramLinkCode(arg)
+	if code == "" {
+		return whatsAppLinkInstructions
+	}
+
+	now := s.now()
+	linkCode, err := s.codes.ConsumeCode(ctx, code, now)
+	if errors.Is(err, domain.ErrTelegramLinkCodeInvalid) {
+		return "❌ Código inválido ou expirado.\n\nGere um novo código no Direito Lux e envie: vincular CÓDIGO"
+	}
+	if err != nil {
+		s.logger.Error("Failed to consume link code", zap.String("contact", contact), zap.Error(err))
+		return whatsAppUnavailableReply
+	}
+
+	link := &domain.WhatsAppLink{
+		Contact:  contact,
+		TenantID: linkCode.TenantID,
+		UserID:   linkCode.UserID,
+		LinkedAt: now,
+	}
+	if err := s.links.Link(ctx, link); err != nil {
+		s.logger.Error("Failed to link whatsapp contact", zap.String("contact", contact), zap.Error(err))
+		return whatsAppUnavailableReply
+	}
+
+	s.logger.Info("WhatsApp contact linked",
+		zap.String("contact", contact),
+		zap.String("tenant_id", link.TenantID.String()),
+		zap.String("user_id", link.UserID.String()))
+
+	return "✅ *Conta vinculada!*\n\nAgora você pode perguntar sobre seus processos por aqui, por exemplo: quais processos de cobrança estão ativos?"
+}
+
+// unlinkContact remove o vínculo pelo próprio contato
+func (s *WhatsAppAssistantService) unlinkContact(ctx context.Context, contact string) string {
+	err := s.links.Unlink(ctx, contact)
+	if errors.Is(err, domain.ErrWhatsAppContactNotLinked) {
+		return "Este número não está vinculado a uma conta."
+	}
+	if err != nil {
+		s.logger.Error("Failed to unlink whatsapp contact", zap.String("contact", contact), zap.Error(err))
+		return whatsAppUnavailableReply
+	}
+
+	s.logger.Info("WhatsApp contact unlinked", zap.String("contact", contact))
+	return "👋 Número desvinculado. Para vincular novamente, gere um novo código no Direito Lux."
+}
+
+// reply envia a mensagem de texto ao contato
+func (s *WhatsAppAssistantService) reply(ctx context.Context, contact, text string) error {
+	if err := s.client.SendText(ctx, contact, text); err != nil {
+		return fmt.Errorf("failed to send whatsapp reply: %w", err)
+	}
+	return nil
+}
+
+// rateLimited consome um token do contato; true quando não há token
+func (s *WhatsAppAssistantService) rateLimited(contact string) bool {
+	if s.rateLimit <= 0 {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if len(s.buckets) >= maxCommandBuckets {
+		for key, bucket := range s.buckets {
+			if now.Sub(bucket.lastRefill) >= commandBucketIdle {
+				delete(s.buckets, key)
+			}
+		}
+	}
+
+	bucket, ok := s.buckets[contact]
+	if !ok {
+		bucket = NewTokenBucket(s.rateLimit, now)
+		s.buckets[contact] = bucket
+	}
+
+	if bucket.Wait(now) > 0 {
+		return true
+	}
+	bucket.Take(now)
+	return false
+}
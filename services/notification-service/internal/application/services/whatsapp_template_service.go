@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// SetWhatsAppTemplateRequest request para mapear um template ao template aprovado do WhatsApp
+type SetWhatsAppTemplateRequest struct {
+	Locale     string   `json:"locale"` // padrão: idioma padrão do template
+	Name       string   `json:"name" validate:"required"`
+	Language   string   `json:"language"` // padrão: derivado do locale (pt-BR → pt_BR)
+	Parameters []string `json:"parameters"`
+}
+
+// WhatsAppTemplateService mantém o mapeamento para templates aprovados do WhatsApp, sincroniza
+// periodicamente o status de aprovação e registra as mensagens recebidas que abrem a janela de
+// atendimento de 24 horas
+type WhatsAppTemplateService struct {
+	templateRepo domain.NotificationTemplateRepository
+	mappings     domain.WhatsAppTemplateRepository
+	sessions     domain.WhatsAppSessionRepository
+	catalog      domain.WhatsAppTemplateCatalog
+	interval     time.Duration
+	logger       *zap.Logger
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewWhatsAppTemplateService cria nova instância do serviço. Sem catálogo, a sincronização
+// periódica fica desativada e o status só muda pelos webhooks da Meta.
+func NewWhatsAppTemplateService(
+	templateRepo domain.NotificationTemplateRepository,
+	mappings domain.WhatsAppTemplateRepository,
+	sessions domain.WhatsAppSessionRepository,
+	catalog domain.WhatsAppTemplateCatalog,
+	interval time.Duration,
+	logger *zap.Logger,
+) *WhatsAppTemplateService {
+	return &WhatsAppTemplateService{
+		templateRepo: templateRepo,
+		mappings:     mappings,
+		sessions:     sessions,
+		catalog:      catalog,
+		interval:     interval,
+		logger:       logger,
+	}
+}
+
+// SetMapping mapeia o template, em um idioma, ao template registrado na Meta. O mapeamento fica
+// pendente até a sincronização confirmar a aprovação.
+func (s *WhatsAppTemplateService) SetMapping(ctx context.Context, templateID uuid.UUID, req *SetWhatsAppTemplateRequest) (*domain.WhatsAppTemplateMapping, error) {
+	template, err := s.templateRepo.GetByID(ctx, templateID)
+	if err != nil {
+		return nil, fmt.Errorf("template not found: %w", err)
+	}
+	if template.Channel != domain.NotificationChannelWhatsApp {
+		return nil, fmt.Errorf("%w: template do canal %s não usa templates do WhatsApp", domain.ErrTemplateInvalid, template.Channel)
+	}
+
+	locale := req.Locale
+	if locale == "" {
+		locale = template.DefaultLocale
+	}
+
+	mapping, err := domain.NewWhatsAppTemplateMapping(template.ID, locale, req.Name, req.Language, req.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.mappings.Upsert(ctx, mapping); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("WhatsApp template mapped",
+		zap.String("template_id", template.ID.String()),
+		zap.String("locale", mapping.Locale),
+		zap.String("name", mapping.Name),
+		zap.String("language", mapping.Language))
+
+	return mapping, nil
+}
+
+// ListMappings lista os templates do WhatsApp mapeados ao template
+func (s *WhatsAppTemplateService) ListMappings(ctx context.Context, templateID uuid.UUID) ([]*domain.WhatsAppTemplateMapping, error) {
+	if _, err := s.templateRepo.GetByID(ctx, templateID); err != nil {
+		return nil, fmt.Errorf("template not found: %w", err)
+	}
+	return s.mappings.FindByTemplate(ctx, templateID)
+}
+
+// DeleteMapping remove o mapeamento do template
+func (s *WhatsAppTemplateService) DeleteMapping(ctx context.Context, templateID, mappingID uuid.UUID) error {
+	return s.mappings.Delete(ctx, templateID, mappingID)
+}
+
+// SyncStatuses atualiza o status de aprovação dos mapeamentos a partir do catálogo da Meta.
+// Retorna a quantidade de mapeamentos atualizados.
+func (s *WhatsAppTemplateService) SyncStatuses(ctx context.Context) (int, error) {
+	if s.catalog == nil {
+		return 0, fmt.Errorf("%w: catálogo de templates do WhatsApp não configurado", domain.ErrConfigMissing)
+	}
+
+	templates, err := s.catalog.ListTemplates(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list whatsapp templates: %w", err)
+	}
+
+	return s.ApplyStatusUpdates(ctx, templates)
+}
+
+// ApplyStatusUpdates aplica o status dos templates registrados (sincronização ou webhook)
+func (s *WhatsAppTemplateService) ApplyStatusUpdates(ctx context.Context, templates []domain.WhatsAppRegisteredTemplate) (int, error) {
+	now := time.Now()
+	updated := 0
+	for _, t := range templates {
+		var reason *string
+		if t.RejectedReason != "" {
+			reason = &t.RejectedReason
+		}
+
+		count, err := s.mappings.UpdateStatusByName(ctx, t.Name, t.Language, t.Status, reason, now)
+		if err != nil {
+			return updated, err
+		}
+		updated += int(count)
+
+		if count > 0 && t.Status != domain.WhatsAppTemplateStatusApproved {
+			s.logger.Warn("WhatsApp template not approved",
+				zap.String("name", t.Name),
+				zap.String("language", t.Language),
+				zap.String("status", string(t.Status)),
+				zap.String("reason", t.RejectedReason))
+		}
+	}
+
+	return updated, nil
+}
+
+// RecordInbound registra a mensagem recebida do contato, abrindo a janela de atendimento
+func (s *WhatsAppTemplateService) RecordInbound(ctx context.Context, from string, at time.Time) error {
+	return s.sessions.RecordInbound(ctx, domain.NormalizeWhatsAppContact(from), at)
+}
+
+// Start inicia a sincronização periódica do status dos templates
+func (s *WhatsAppTemplateService) Start() {
+	if s.catalog == nil || s.interval <= 0 {
+		s.logger.Warn("WhatsApp template sync disabled - template catalog not configured")
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	s.wg.Add(1)
+	go s.run(ctx)
+}
+
+// Stop interrompe a sincronização e aguarda o ciclo em andamento
+func (s *WhatsAppTemplateService) Stop(ctx context.Context) error {
+	if s.cancel == nil {
+		return nil
+	}
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run loop periódico; a primeira sincronização ocorre na partida
+func (s *WhatsAppTemplateService) run(ctx context.Context) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if updated, err := s.SyncStatuses(ctx); err != nil {
+			s.logger.Error("Failed to sync whatsapp template statuses", zap.Error(err))
+		} else {
+			s.logger.Debug("WhatsApp template statuses synced", zap.Int("updated", updated))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FailureCategory categoria normalizada das falhas de envio, comum a todos os canais e
+// provedores
+type FailureCategory string
+
+const (
+	FailureCategoryInvalidRecipient    FailureCategory = "invalid_recipient"    // número, email ou chat inexistente
+	FailureCategoryBlocked             FailureCategory = "blocked"              // destinatário bloqueou ou contato suprimido
+	FailureCategoryOptedOut            FailureCategory = "opted_out"            // consentimento revogado ou descadastro
+	FailureCategoryRateLimited         FailureCategory = "rate_limited"         // limite de taxa do provedor
+	FailureCategoryProviderUnavailable FailureCategory = "provider_unavailable" // provedor fora do ar ou sem resposta
+	FailureCategoryRejected            FailureCategory = "rejected"             // conteúdo recusado (template, tamanho, política)
+	FailureCategoryOther               FailureCategory = "other"
+)
+
+// FailureCategories categorias na ordem de exibição
+var FailureCategories = []FailureCategory{
+	FailureCategoryInvalidRecipient,
+	FailureCategoryBlocked,
+	FailureCategoryOptedOut,
+	FailureCategoryRateLimited,
+	FailureCategoryProviderUnavailable,
+	FailureCategoryRejected,
+	FailureCategoryOther,
+}
+
+// failureCodes códigos de erro conhecidos dos provedores, por canal
+var failureCodes = map[NotificationChannel]map[string]FailureCategory{
+	NotificationChannelWhatsApp: {
+		"131026": FailureCategoryInvalidRecipient, // mensagem não entregável (número sem WhatsApp)
+		"131021": FailureCategoryInvalidRecipient, // destinatário igual ao remetente
+		"131050": FailureCategoryOptedOut,         // usuário parou de receber mensagens de marketing
+		"131049": FailureCategoryBlocked,          // Meta não entregou para preservar o engajamento
+		"130429": FailureCategoryRateLimited,
+		"131048": FailureCategoryRateLimited, // limite por spam
+		"131056": FailureCategoryRateLimited, // limite por par remetente/destinatário
+		"80007":  FailureCategoryRateLimited,
+		"131000": FailureCategoryProviderUnavailable,
+		"131016": FailureCategoryProviderUnavailable,
+		"1":      FailureCategoryProviderUnavailable,
+		"2":      FailureCategoryProviderUnavailable,
+		"131047": FailureCategoryRejected, // fora da janela de 24 horas
+		"132000": FailureCategoryRejected, // parâmetros do template
+		"132001": FailureCategoryRejected, // template inexistente
+		"132015": FailureCategoryRejected, // template pausado
+	},
+	NotificationChannelTelegram: {
+		"403": FailureCategoryBlocked,
+		"429": FailureCategoryRateLimited,
+	},
+}
+
+// failurePatterns padrões das mensagens de erro (em minúsculas), avaliados em ordem
+var failurePatterns = []struct {
+	category FailureCategory
+	pattern  *regexp.Regexp
+}{
+	{FailureCategoryOptedOut, regexp.MustCompile(`consent (revoked|required)|opt-?out|opted out|unsubscribed|descadastr`)},
+	{FailureCategoryRateLimited, regexp.MustCompile(`rate limit|too many requests|throttl|quota|\b429\b|\b4\.7\.28\b`)},
+	{FailureCategoryBlocked, regexp.MustCompile(`blocked|bot was kicked|user is deactivated|contact is suppressed|blacklist|spam|\b554\b`)},
+	{FailureCategoryInvalidRecipient, regexp.MustCompile(`invalid (recipient|phone|number|email|chat)|phone number too|chat not found|user (unknown|not found)|unknown (user|subscriber)|no such (user|recipient|mailbox)|mailbox (unavailable|not found)|does not exist|not a whatsapp user|undeliverable|no push subscriptions|subscriptions expired|push recipient must be|\b55[013]\b|\b5\.1\.[0-3]\b`)},
+	{FailureCategoryProviderUnavailable, regexp.MustCompile(`provider (unavailable|unhealthy|not available)|service unavailable|bad gateway|gateway timeout|timeout|timed out|connection (refused|reset)|no such host|\beof\b|status:? 5\d\d|\b421\b|temporar`)},
+	{FailureCategoryRejected, regexp.MustCompile(`template|too long|too large|payload|attachment|policy|rejected|\b552\b`)},
+}
+
+// ClassifyFailure normaliza o erro de envio na categoria de falha. O código do provedor (quando
+// reportado no webhook) tem precedência sobre o texto da mensagem.
+func ClassifyFailure(channel NotificationChannel, code, message string) FailureCategory {
+	if category, ok := failureCodes[channel][strings.TrimSpace(code)]; ok {
+		return category
+	}
+
+	// Erros síncronos trazem o código no final do texto, como "WhatsApp API error: ... (131026)"
+	message = strings.ToLower(message)
+	if match := providerCodePattern.FindStringSubmatch(message); match != nil {
+		if category, ok := failureCodes[channel][match[1]]; ok {
+			return category
+		}
+	}
+
+	for _, rule := range failurePatterns {
+		if rule.pattern.MatchString(message) {
+			return rule.category
+		}
+	}
+	return FailureCategoryOther
+}
+
+var providerCodePattern = regexp.MustCompile(`\((\d+)\)\s*$`)
+
+// ChannelCosts custo unitário das mensagens por canal, usado na contabilização do envio. O
+// SMS é cobrado por segmento.
+type ChannelCosts struct {
+	Currency   string                          `json:"currency"`
+	PerMessage map[NotificationChannel]float64 `json:"per_message"`
+}
+
+// MessageCost custo do envio da notificação
+func (c ChannelCosts) MessageCost(notification *Notification) float64 {
+	cost := c.PerMessage[notification.Channel]
+	if notification.Channel == NotificationChannelSMS {
+		// O valor vem como int no envio e como float64 depois de relido do banco
+		switch segments := notification.Metadata["sms_segments"].(type) {
+		case int:
+			cost *= float64(segments)
+		case float64:
+			cost *= segments
+		}
+	}
+	return cost
+}
+
+// AnalyticsDimension dimensão de agrupamento das análises
+type AnalyticsDimension string
+
+const (
+	AnalyticsByType     AnalyticsDimension = "type"
+	AnalyticsByChannel  AnalyticsDimension = "channel"
+	AnalyticsByTemplate AnalyticsDimension = "template"
+	AnalyticsByTenant   AnalyticsDimension = "tenant" // apenas na rota administrativa
+)
+
+// ParseAnalyticsDimension valida a dimensão informada; vazio retorna apenas os totais
+func ParseAnalyticsDimension(value string) (AnalyticsDimension, error) {
+	switch dimension := AnalyticsDimension(value); dimension {
+	case "", AnalyticsByType, AnalyticsByChannel, AnalyticsByTemplate, AnalyticsByTenant:
+		return dimension, nil
+	default:
+		return "", fmt.Errorf("%w: group_by deve ser type, channel, template ou tenant", ErrInvalidAnalyticsFilter)
+	}
+}
+
+// AnalyticsFilters filtros das análises de notificações
+type AnalyticsFilters struct {
+	TenantID   *uuid.UUID           `json:"tenant_id,omitempty"` // nil: todos os tenants
+	From       time.Time            `json:"from"`
+	To         time.Time            `json:"to"`
+	Channel    *NotificationChannel `json:"channel,omitempty"`
+	Type       *NotificationType    `json:"type,omitempty"`
+	TemplateID *uuid.UUID           `json:"template_id,omitempty"`
+	GroupBy    AnalyticsDimension   `json:"group_by,omitempty"`
+}
+
+// Validate valida o período e a dimensão
+func (f *AnalyticsFilters) Validate() error {
+	if f.From.IsZero() || f.To.IsZero() || !f.From.Before(f.To) {
+		return fmt.Errorf("%w: período inválido", ErrInvalidAnalyticsFilter)
+	}
+	if f.GroupBy == AnalyticsByTenant && f.TenantID != nil {
+		return fmt.Errorf("%w: agrupamento por tenant exige todos os tenants", ErrInvalidAnalyticsFilter)
+	}
+	return nil
+}
+
+// NotificationFunnel funil criada → enviada → entregue → lida
+type NotificationFunnel struct {
+	Created   int64 `json:"created"`
+	Sent      int64 `json:"sent"`
+	Delivered int64 `json:"delivered"` // confirmadas pelo provedor (inclui lidas)
+	Read      int64 `json:"read"`
+	Failed    int64 `json:"failed"`
+
+	SentRate     float64 `json:"sent_rate"`     // enviadas sobre criadas (%)
+	DeliveryRate float64 `json:"delivery_rate"` // entregues sobre enviadas (%)
+	ReadRate     float64 `json:"read_rate"`     // lidas sobre entregues (%)
+	FailureRate  float64 `json:"failure_rate"`  // falhas sobre criadas (%)
+}
+
+// CalculateRates calcula as taxas de conversão entre as etapas
+func (f *NotificationFunnel) CalculateRates() {
+	f.SentRate = percentage(f.Sent, f.Created)
+	f.DeliveryRate = percentage(f.Delivered, f.Sent)
+	f.ReadRate = percentage(f.Read, f.Delivered)
+	f.FailureRate = percentage(f.Failed, f.Created)
+}
+
+func percentage(part, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total) * 100
+}
+
+// DeliveryLatency tempo até a entrega, do momento previsto de envio (agendamento ou criação)
+// até a confirmação do provedor, em segundos
+type DeliveryLatency struct {
+	Samples int64   `json:"samples"`
+	Average float64 `json:"average_seconds"`
+	P50     float64 `json:"p50_seconds"`
+	P90     float64 `json:"p90_seconds"`
+	P99     float64 `json:"p99_seconds"`
+}
+
+// AnalyticsGroup métricas de um grupo da dimensão (ou dos totais)
+type AnalyticsGroup struct {
+	Key      string                    `json:"key"`             // valor da dimensão (tipo, canal, ID do template ou do tenant)
+	Label    string                    `json:"label,omitempty"` // nome do template
+	Funnel   NotificationFunnel        `json:"funnel"`
+	Latency  DeliveryLatency           `json:"time_to_deliver"`
+	Failures map[FailureCategory]int64 `json:"failures"`
+	Cost     float64                   `json:"cost"`
+}
+
+// NotificationAnalytics análises de notificações para os dashboards do report-service
+type NotificationAnalytics struct {
+	Filters     AnalyticsFilters  `json:"filters"`
+	Currency    string            `json:"currency"`
+	Totals      *AnalyticsGroup   `json:"totals"`
+	Groups      []*AnalyticsGroup `json:"groups,omitempty"`
+	GeneratedAt time.Time         `json:"generated_at"`
+}
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestClassifyFailure(t *testing.T) {
+	tests := []struct {
+		channel NotificationChannel
+		code    string
+		message string
+		want    FailureCategory
+	}{
+		{NotificationChannelWhatsApp, "131026", "Message undeliverable", FailureCategoryInvalidRecipient},
+		{NotificationChannelWhatsApp, "131050", "", FailureCategoryOptedOut},
+		{NotificationChannelWhatsApp, "", "WhatsApp API error: Rate limit hit (130429)", FailureCategoryRateLimited},
+		{NotificationChannelWhatsApp, "", "WhatsApp API error: Template name does not exist (132001)", FailureCategoryRejected},
+		{NotificationChannelTelegram, "403", "Forbidden", FailureCategoryBlocked},
+		{NotificationChannelTelegram, "", "Forbidden: bot was blocked by the user", FailureCategoryBlocked},
+		{NotificationChannelTelegram, "", "Bad Request: chat not found", FailureCategoryInvalidRecipient},
+		{NotificationChannelEmail, "", "550 5.1.1 <x@y.com>: user unknown", FailureCategoryInvalidRecipient},
+		{NotificationChannelEmail, "", "dial tcp: connection refused", FailureCategoryProviderUnavailable},
+		{NotificationChannelSMS, "", "consent revoked for contact", FailureCategoryOptedOut},
+		{NotificationChannelSMS, "", "gateway returned 429 Too Many Requests", FailureCategoryRateLimited},
+		{NotificationChannelPush, "", "no push subscriptions for recipient", FailureCategoryInvalidRecipient},
+		{NotificationChannelEmail, "", "algo inesperado", FailureCategoryOther},
+		{NotificationChannelEmail, "131026", "algo inesperado", FailureCategoryOther}, // código de outro canal
+	}
+
+	for _, tt := range tests {
+		if got := ClassifyFailure(tt.channel, tt.code, tt.message); got != tt.want {
+			t.Errorf("ClassifyFailure(%s, %q, %q) = %s, want %s", tt.channel, tt.code, tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestDeliveryStatusUpdateFailureCategory(t *testing.T) {
+	update := &DeliveryStatusUpdate{Channel: NotificationChannelEmail, Event: DeliveryEventBounced, HardBounce: true, Reason: "algo inesperado"}
+	if got := update.FailureCategory(); got != FailureCategoryInvalidRecipient {
+		t.Errorf("hard bounce FailureCategory() = %s, want invalid_recipient", got)
+	}
+
+	update.HardBounce = false
+	if got := update.FailureCategory(); got != FailureCategoryOther {
+		t.Errorf("soft bounce FailureCategory() = %s, want other", got)
+	}
+}
+
+func TestChannelCostsMessageCost(t *testing.T) {
+	costs := ChannelCosts{PerMessage: map[NotificationChannel]float64{
+		NotificationChannelWhatsApp: 0.05,
+		NotificationChannelSMS:      0.07,
+	}}
+
+	tests := []struct {
+		name         string
+		notification *Notification
+		want         float64
+	}{
+		{"whatsapp", &Notification{Channel: NotificationChannelWhatsApp}, 0.05},
+		{"sms segmentos int", &Notification{Channel: NotificationChannelSMS, Metadata: map[string]interface{}{"sms_segments": 3}}, 0.21},
+		{"sms segmentos lidos do banco", &Notification{Channel: NotificationChannelSMS, Metadata: map[string]interface{}{"sms_segments": float64(2)}}, 0.14},
+		{"sms sem segmentos", &Notification{Channel: NotificationChannelSMS}, 0.07},
+		{"canal sem custo", &Notification{Channel: NotificationChannelTelegram}, 0},
+	}
+
+	for _, tt := range tests {
+		if got := costs.MessageCost(tt.notification); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: MessageCost() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNotificationFunnelCalculateRates(t *testing.T) {
+	funnel := NotificationFunnel{Created: 200, Sent: 180, Delivered: 90, Read: 45, Failed: 20}
+	funnel.CalculateRates()
+
+	if funnel.SentRate != 90 || funnel.DeliveryRate != 50 || funnel.ReadRate != 50 || funnel.FailureRate != 10 {
+		t.Errorf("rates = %+v", funnel)
+	}
+
+	empty := NotificationFunnel{}
+	empty.CalculateRates()
+	if empty.SentRate != 0 || empty.FailureRate != 0 {
+		t.Errorf("empty rates = %+v", empty)
+	}
+}
+
+func TestAnalyticsFiltersValidate(t *testing.T) {
+	now := time.Now()
+	tenantID := uuid.New()
+
+	if _, err := ParseAnalyticsDimension("user"); !errors.Is(err, ErrInvalidAnalyticsFilter) {
+		t.Errorf("ParseAnalyticsDimension(user) error = %v, want ErrInvalidAnalyticsFilter", err)
+	}
+
+	tests := []struct {
+		name    string
+		filters AnalyticsFilters
+		wantErr bool
+	}{
+		{"período válido", AnalyticsFilters{TenantID: &tenantID, From: now.Add(-time.Hour), To: now, GroupBy: AnalyticsByChannel}, false},
+		{"todos os tenants por tenant", AnalyticsFilters{From: now.Add(-time.Hour), To: now, GroupBy: AnalyticsByTenant}, false},
+		{"período invertido", AnalyticsFilters{From: now, To: now.Add(-time.Hour)}, true},
+		{"sem início", AnalyticsFilters{To: now}, true},
+		{"tenant agrupado por tenant", AnalyticsFilters{TenantID: &tenantID, From: now.Add(-time.Hour), To: now, GroupBy: AnalyticsByTenant}, true},
+	}
+
+	for _, tt := range tests {
+		err := tt.filters.Validate()
+		if tt.wantErr != (err != nil) {
+			t.Errorf("%s: Validate() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+		if err != nil && !errors.Is(err, ErrInvalidAnalyticsFilter) {
+			t.Errorf("%s: error = %v, want ErrInvalidAnalyticsFilter", tt.name, err)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"context"
+	"html"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// TelegramMessageLimit caracteres visíveis por mensagem do Telegram (tags HTML não contam);
+	// folga para emojis, que contam como dois
+	TelegramMessageLimit = 4000
+	// WhatsAppTextLimit caracteres do corpo de uma mensagem de texto do WhatsApp
+	WhatsAppTextLimit = 4096
+)
+
+// assistantBoldPattern negrito do Markdown leve das respostas do assistente
+var assistantBoldPattern = regexp.MustCompile(`\*\*([^*\n]+)\*\*`)
+
+// AssistantMessage mensagem de um usuário vinculado encaminhada ao assistente (mcp-service)
+type AssistantMessage struct {
+	TenantID   uuid.UUID
+	UserID     uuid.UUID
+	Channel    NotificationChannel
+	ExternalID string // telefone do WhatsApp ou chat do Telegram
+	Text       string
+}
+
+// ConversationalAssistant interface do assistente conversacional que consulta os processos do
+// tenant; a resposta vem em Markdown leve (**negrito**, listas com "- ")
+type ConversationalAssistant interface {
+	Reply(ctx context.Context, message *AssistantMessage) (string, error)
+}
+
+// WhatsAppLink vínculo entre um contato do WhatsApp e o usuário do tenant
+type WhatsAppLink struct {
+	Contact  string    `json:"contact"` // normalizado (+5511987654321)
+	TenantID uuid.UUID `json:"tenant_id"`
+	UserID   uuid.UUID `json:"user_id"`
+	LinkedAt time.Time `json:"linked_at"`
+}
+
+// WhatsAppReplyClient interface para responder o contato dentro da janela de atendimento
+type WhatsAppReplyClient interface {
+	SendText(ctx context.Context, contact, text string) error
+}
+
+// FormatAssistantReply converte a resposta do assistente para a formatação do canal e divide
+// nas mensagens que o canal aceita
+func FormatAssistantReply(channel NotificationChannel, text string) []string {
+	limit := WhatsAppTextLimit
+	if channel == NotificationChannelTelegram {
+		limit = TelegramMessageLimit
+	}
+
+	// Divide antes de formatar: o negrito nunca fica aberto entre duas mensagens
+	parts := SplitMessage(text, limit)
+	for i, part := range parts {
+		switch channel {
+		case NotificationChannelTelegram:
+			parts[i] = assistantBoldPattern.ReplaceAllString(html.EscapeString(part), "<b>$1</b>")
+		case NotificationChannelWhatsApp:
+			parts[i] = assistantBoldPattern.ReplaceAllString(part, "*$1*")
+		}
+	}
+	return parts
+}
+
+// SplitMessage divide o texto em partes de até limit caracteres, preferindo quebrar entre
+// parágrafos, depois entre linhas e por fim entre palavras
+func SplitMessage(text string, limit int) []string {
+	text = strings.TrimSpace(text)
+	var parts []string
+	for utf8.RuneCountInString(text) > limit {
+		// Prefixo com no máximo limit caracteres
+		end, count := len(text), 0
+		for i := range text {
+			if count == limit {
+				end = i
+				break
+			}
+			count++
+		}
+		head := text[:end]
+
+		cut := -1
+		for _, separator := range []string{"\n\n", "\n", " "} {
+			// Quebras muito no início gerariam partes curtas demais
+			if i := strings.LastIndex(head, separator); i > len(head)/2 {
+				cut = i
+				break
+			}
+		}
+		if cut < 0 {
+			cut = end
+		}
+
+		parts = append(parts, strings.TrimSpace(text[:cut]))
+		text = strings.TrimSpace(text[cut:])
+	}
+
+	if text != "" {
+		parts = append(parts, text)
+	}
+	return parts
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSplitMessage(t *testing.T) {
+	if got := SplitMessage("  curta  ", 100); len(got) != 1 || got[0] != "curta" {
+		t.Errorf("SplitMessage() short = %q", got)
+	}
+
+	// Parágrafos: quebra entre eles
+	first := strings.Repeat("a", 60)
+	second := strings.Repeat("b", 60)
+	got := SplitMessage(first+"\n\n"+second, 100)
+	if len(got) != 2 || got[0] != first || got[1] != second {
+		t.Errorf("SplitMessage() paragraphs = %q", got)
+	}
+
+	// Palavras longas sem separador: corte no limite, sem quebrar caracteres multibyte
+	long := strings.Repeat("ção", 50)
+	got = SplitMessage(long, 40)
+	if strings.Join(got, "") != long {
+		t.Errorf("SplitMessage() lost text: %q", got)
+	}
+	for _, part := range got {
+		if n := utf8.RuneCountInString(part); n > 40 || !utf8.ValidString(part) {
+			t.Errorf("SplitMessage() part with %d runes: %q", n, part)
+		}
+	}
+}
+
+func TestFormatAssistantReply(t *testing.T) {
+	reply := "**Ação de cobrança** <TJSP> & cia\n- **Status:** ativo"
+
+	telegram := FormatAssistantReply(NotificationChannelTelegram, reply)
+	want := "<b>Ação de cobrança</b> &lt;TJSP&gt; &amp; cia\n- <b>Status:</b> ativo"
+	if len(telegram) != 1 || telegram[0] != want {
+		t.Errorf("FormatAssistantReply(telegram) = %q, want %q", telegram, want)
+	}
+
+	whatsApp := FormatAssistantReply(NotificationChannelWhatsApp, reply)
+	want = "*Ação de cobrança* <TJSP> & cia\n- *Status:* ativo"
+	if len(whatsApp) != 1 || whatsApp[0] != want {
+		t.Errorf("FormatAssistantReply(whatsapp) = %q, want %q", whatsApp, want)
+	}
+
+	// Resposta longa: várias mensagens dentro do limite do canal
+	lines := strings.Repeat("- **1234567-89.2024.8.26.0001** — Ação de cobrança\n", 200)
+	parts := FormatAssistantReply(NotificationChannelWhatsApp, lines)
+	if len(parts) < 2 {
+		t.Fatalf("FormatAssistantReply() = %d parts, want split", len(parts))
+	}
+	for _, part := range parts {
+		if utf8.RuneCountInString(part) > WhatsAppTextLimit || strings.Contains(part, "**") {
+			t.Errorf("FormatAssistantReply() invalid part (%d runes)", utf8.RuneCountInString(part))
+		}
+	}
+}
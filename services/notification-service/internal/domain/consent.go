@@ -0,0 +1,220 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ConsentStatus situação do consentimento registrada no histórico
+type ConsentStatus string
+
+const (
+	ConsentStatusGranted ConsentStatus = "granted"
+	ConsentStatusRevoked ConsentStatus = "revoked"
+)
+
+// ConsentPurpose finalidade do tratamento informada ao titular (LGPD, art. 6º, I)
+type ConsentPurpose string
+
+const (
+	ConsentPurposeProcessUpdates ConsentPurpose = "process_updates" // andamentos, prazos e alertas de processos
+	ConsentPurposeBilling        ConsentPurpose = "billing"         // assinatura e cobrança
+	ConsentPurposeService        ConsentPurpose = "service"         // mensagens do serviço (boas-vindas, senha, avisos)
+	ConsentPurposeAll            ConsentPurpose = "all"             // todas as finalidades (ex.: resposta SAIR)
+)
+
+// ConsentLegalBasis base legal do tratamento (LGPD, art. 7º)
+type ConsentLegalBasis string
+
+const (
+	ConsentLegalBasisConsent            ConsentLegalBasis = "consent"             // consentimento do titular
+	ConsentLegalBasisContract           ConsentLegalBasis = "contract"            // execução de contrato
+	ConsentLegalBasisLegalObligation    ConsentLegalBasis = "legal_obligation"    // obrigação legal ou regulatória
+	ConsentLegalBasisLegitimateInterest ConsentLegalBasis = "legitimate_interest" // legítimo interesse
+)
+
+// ConsentSource origem do registro
+type ConsentSource string
+
+const (
+	ConsentSourceWebApp          ConsentSource = "web_app"          // formulário ou aceite no web app
+	ConsentSourceAPI             ConsentSource = "api"              // integração do tenant
+	ConsentSourceImport          ConsentSource = "import"           // importação de base com evidência prévia
+	ConsentSourceUnsubscribeLink ConsentSource = "unsubscribe_link" // link de descadastro do email
+	ConsentSourceSMSKeyword      ConsentSource = "sms_keyword"      // resposta SAIR/VOLTAR por SMS
+	ConsentSourceWhatsAppKeyword ConsentSource = "whatsapp_keyword" // resposta SAIR/VOLTAR pelo WhatsApp
+)
+
+// ConsentRecord registro imutável do histórico de consentimento de um contato em um canal.
+// Registros sem tenant valem para todos os tenants: são as respostas SAIR/VOLTAR recebidas nos
+// números compartilhados, que não identificam o remetente original.
+type ConsentRecord struct {
+	ID          uuid.UUID              `json:"id"`
+	TenantID    *uuid.UUID             `json:"tenant_id,omitempty"`
+	Channel     NotificationChannel    `json:"channel"`
+	Contact     string                 `json:"contact"`
+	RecipientID string                 `json:"recipient_id,omitempty"`
+	Purpose     ConsentPurpose         `json:"purpose"`
+	Status      ConsentStatus          `json:"status"`
+	LegalBasis  ConsentLegalBasis      `json:"legal_basis"`
+	Source      ConsentSource          `json:"source"`
+	Evidence    map[string]interface{} `json:"evidence,omitempty"` // IP, user agent, texto aceito, mensagem recebida
+	CreatedAt   time.Time              `json:"created_at"`
+}
+
+// NewConsentRecord cria registro validando canal, finalidade, situação, base legal e origem
+func NewConsentRecord(
+	tenantID *uuid.UUID,
+	channel NotificationChannel,
+	contact string,
+	purpose ConsentPurpose,
+	status ConsentStatus,
+	legalBasis ConsentLegalBasis,
+	source ConsentSource,
+	evidence map[string]interface{},
+) (*ConsentRecord, error) {
+	if err := ValidateChannel(channel); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrConsentInvalid, err)
+	}
+
+	contact = NormalizeConsentContact(channel, contact)
+	if contact == "" {
+		return nil, fmt.Errorf("%w: contato é obrigatório", ErrConsentInvalid)
+	}
+
+	switch purpose {
+	case ConsentPurposeProcessUpdates, ConsentPurposeBilling, ConsentPurposeService, ConsentPurposeAll:
+	default:
+		return nil, fmt.Errorf("%w: finalidade inválida: %s", ErrConsentInvalid, purpose)
+	}
+
+	switch status {
+	case ConsentStatusGranted, ConsentStatusRevoked:
+	default:
+		return nil, fmt.Errorf("%w: situação inválida: %s", ErrConsentInvalid, status)
+	}
+
+	switch legalBasis {
+	case ConsentLegalBasisConsent, ConsentLegalBasisContract, ConsentLegalBasisLegalObligation, ConsentLegalBasisLegitimateInterest:
+	default:
+		return nil, fmt.Errorf("%w: base legal inválida: %s", ErrConsentInvalid, legalBasis)
+	}
+
+	switch source {
+	case ConsentSourceWebApp, ConsentSourceAPI, ConsentSourceImport, ConsentSourceUnsubscribeLink, ConsentSourceSMSKeyword, ConsentSourceWhatsAppKeyword:
+	default:
+		return nil, fmt.Errorf("%w: origem inválida: %s", ErrConsentInvalid, source)
+	}
+
+	// Concessão exige evidência; a revogação vale mesmo sem ela
+	if status == ConsentStatusGranted && len(evidence) == 0 {
+		return nil, fmt.Errorf("%w: evidência é obrigatória na concessão", ErrConsentInvalid)
+	}
+
+	return &ConsentRecord{
+		ID:         uuid.New(),
+		TenantID:   tenantID,
+		Channel:    channel,
+		Contact:    contact,
+		Purpose:    purpose,
+		Status:     status,
+		LegalBasis: legalBasis,
+		Source:     source,
+		Evidence:   evidence,
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+// NormalizeConsentContact normaliza o contato do registro: como na lista de supressão e, no
+// WhatsApp, em E.164 (o webhook informa o remetente sem "+")
+func NormalizeConsentContact(channel NotificationChannel, contact string) string {
+	contact = strings.TrimSpace(contact)
+	if channel == NotificationChannelWhatsApp && contact != "" {
+		return NormalizeWhatsAppContact(contact)
+	}
+	return NormalizeContact(channel, contact)
+}
+
+// ConsentPurposeForType finalidade da notificação conforme o tipo
+func ConsentPurposeForType(notificationType NotificationType) ConsentPurpose {
+	switch notificationType {
+	case NotificationTypeProcessUpdate, NotificationTypeMovementAlert, NotificationTypeDeadlineReminder, NotificationTypeDigest:
+		return ConsentPurposeProcessUpdates
+	case NotificationTypeTrialExpiring, NotificationTypeSubscriptionDue:
+		return ConsentPurposeBilling
+	default:
+		return ConsentPurposeService
+	}
+}
+
+// ConsentRequired indica se o envio exige consentimento registrado: clientes finais (destinatários
+// externos) por WhatsApp, email e SMS. Nos demais casos, apenas revogações impedem o envio.
+func ConsentRequired(recipientType string, channel NotificationChannel) bool {
+	if recipientType != "external" {
+		return false
+	}
+	switch channel {
+	case NotificationChannelWhatsApp, NotificationChannelEmail, NotificationChannelSMS:
+		return true
+	default:
+		return false
+	}
+}
+
+// EvaluateConsent avalia o histórico do contato (em ordem cronológica) para o tenant e a
+// finalidade. Concessões e revogações do tenant se substituem; revogações sem tenant (SAIR)
+// cancelam tudo o que veio antes, e VOLTAR apenas desfaz a revogação, sem restaurar concessões.
+// Retorna ErrConsentRevoked ou, quando requireGrant, ErrConsentRequired sem concessão vigente.
+func EvaluateConsent(records []*ConsentRecord, tenantID uuid.UUID, purpose ConsentPurpose, requireGrant bool) error {
+	granted, revoked := false, false
+
+	for _, record := range records {
+		if record.Purpose != purpose && record.Purpose != ConsentPurposeAll {
+			continue
+		}
+
+		switch {
+		case record.TenantID == nil && record.Status == ConsentStatusRevoked:
+			granted, revoked = false, true
+		case record.TenantID == nil:
+			revoked = false
+		case *record.TenantID != tenantID:
+			continue
+		case record.Status == ConsentStatusGranted:
+			granted, revoked = true, false
+		default:
+			granted, revoked = false, true
+		}
+	}
+
+	if revoked {
+		return fmt.Errorf("%w: finalidade %s", ErrConsentRevoked, purpose)
+	}
+	if requireGrant && !granted {
+		return fmt.Errorf("%w: finalidade %s", ErrConsentRequired, purpose)
+	}
+	return nil
+}
+
+// ConsentExportFilter filtros da exportação do histórico para auditoria
+type ConsentExportFilter struct {
+	Channel *NotificationChannel
+	Contact string
+	From    *time.Time
+	To      *time.Time
+}
+
+// ConsentKeywordReply resposta enviada ao contato após SAIR/VOLTAR
+func ConsentKeywordReply(keyword SMSKeyword) string {
+	switch keyword {
+	case SMSKeywordOptOut:
+		return "Você não receberá mais mensagens do Direito Lux neste número. Para voltar a receber, responda VOLTAR."
+	case SMSKeywordOptIn:
+		return "Pronto! Você voltará a receber as mensagens autorizadas do Direito Lux neste número."
+	default:
+		return ""
+	}
+}
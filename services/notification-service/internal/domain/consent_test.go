@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNewConsentRecord(t *testing.T) {
+	tenantID := uuid.New()
+	evidence := map[string]interface{}{"policy_version": "2025-01"}
+
+	record, err := NewConsentRecord(&tenantID, NotificationChannelEmail, " Ana@Example.com ", ConsentPurposeProcessUpdates,
+		ConsentStatusGranted, ConsentLegalBasisConsent, ConsentSourceWebApp, evidence)
+	if err != nil {
+		t.Fatalf("NewConsentRecord() error = %v", err)
+	}
+	if record.Contact != "ana@example.com" {
+		t.Errorf("Contact = %q", record.Contact)
+	}
+
+	whatsApp, err := NewConsentRecord(nil, NotificationChannelWhatsApp, "5511987654321", ConsentPurposeAll,
+		ConsentStatusRevoked, ConsentLegalBasisConsent, ConsentSourceWhatsAppKeyword, nil)
+	if err != nil {
+		t.Fatalf("NewConsentRecord() error = %v", err)
+	}
+	if whatsApp.Contact != "+5511987654321" {
+		t.Errorf("WhatsApp contact = %q, want E.164", whatsApp.Contact)
+	}
+
+	invalid := []struct {
+		name       string
+		channel    NotificationChannel
+		contact    string
+		purpose    ConsentPurpose
+		status     ConsentStatus
+		legalBasis ConsentLegalBasis
+		source     ConsentSource
+		evidence   map[string]interface{}
+	}{
+		{"canal desconhecido", "fax", "123", ConsentPurposeBilling, ConsentStatusRevoked, ConsentLegalBasisConsent, ConsentSourceAPI, nil},
+		{"sem contato", NotificationChannelEmail, " ", ConsentPurposeBilling, ConsentStatusRevoked, ConsentLegalBasisConsent, ConsentSourceAPI, nil},
+		{"finalidade desconhecida", NotificationChannelEmail, "a@example.com", "marketing", ConsentStatusRevoked, ConsentLegalBasisConsent, ConsentSourceAPI, nil},
+		{"situação desconhecida", NotificationChannelEmail, "a@example.com", ConsentPurposeBilling, "pending", ConsentLegalBasisConsent, ConsentSourceAPI, nil},
+		{"base legal desconhecida", NotificationChannelEmail, "a@example.com", ConsentPurposeBilling, ConsentStatusRevoked, "vital_interest", ConsentSourceAPI, nil},
+		{"origem desconhecida", NotificationChannelEmail, "a@example.com", ConsentPurposeBilling, ConsentStatusRevoked, ConsentLegalBasisConsent, "crm", nil},
+		{"concessão sem evidência", NotificationChannelEmail, "a@example.com", ConsentPurposeBilling, ConsentStatusGranted, ConsentLegalBasisConsent, ConsentSourceAPI, nil},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewConsentRecord(&tenantID, tt.channel, tt.contact, tt.purpose, tt.status, tt.legalBasis, tt.source, tt.evidence)
+			if !errors.Is(err, ErrConsentInvalid) {
+				t.Errorf("error = %v, want ErrConsentInvalid", err)
+			}
+		})
+	}
+}
+
+func TestConsentRequired(t *testing.T) {
+	tests := []struct {
+		recipientType string
+		channel       NotificationChannel
+		want          bool
+	}{
+		{"external", NotificationChannelWhatsApp, true},
+		{"external", NotificationChannelEmail, true},
+		{"external", NotificationChannelSMS, true},
+		{"external", NotificationChannelPush, false},
+		{"user", NotificationChannelWhatsApp, false},
+	}
+	for _, tt := range tests {
+		if got := ConsentRequired(tt.recipientType, tt.channel); got != tt.want {
+			t.Errorf("ConsentRequired(%q, %s) = %v, want %v", tt.recipientType, tt.channel, got, tt.want)
+		}
+	}
+}
+
+func TestEvaluateConsent(t *testing.T) {
+	tenantID, otherTenantID := uuid.New(), uuid.New()
+
+	record := func(tenant *uuid.UUID, purpose ConsentPurpose, status ConsentStatus) *ConsentRecord {
+		return &ConsentRecord{TenantID: tenant, Purpose: purpose, Status: status}
+	}
+
+	tests := []struct {
+		name         string
+		records      []*ConsentRecord
+		requireGrant bool
+		want         error
+	}{
+		{"sem histórico, sem exigência", nil, false, nil},
+		{"sem histórico, com exigência", nil, true, ErrConsentRequired},
+		{"concessão do tenant", []*ConsentRecord{
+			record(&tenantID, ConsentPurposeProcessUpdates, ConsentStatusGranted),
+		}, true, nil},
+		{"concessão de outro tenant", []*ConsentRecord{
+			record(&otherTenantID, ConsentPurposeProcessUpdates, ConsentStatusGranted),
+		}, true, ErrConsentRequired},
+		{"concessão de outra finalidade", []*ConsentRecord{
+			record(&tenantID, ConsentPurposeBilling, ConsentStatusGranted),
+		}, true, ErrConsentRequired},
+		{"revogação pelo link", []*ConsentRecord{
+			record(&tenantID, ConsentPurposeProcessUpdates, ConsentStatusGranted),
+			record(&tenantID, ConsentPurposeProcessUpdates, ConsentStatusRevoked),
+		}, false, ErrConsentRevoked},
+		{"nova concessão após revogação", []*ConsentRecord{
+			record(&tenantID, ConsentPurposeProcessUpdates, ConsentStatusRevoked),
+			record(&tenantID, ConsentPurposeProcessUpdates, ConsentStatusGranted),
+		}, true, nil},
+		{"SAIR revoga todos os tenants", []*ConsentRecord{
+			record(&tenantID, ConsentPurposeProcessUpdates, ConsentStatusGranted),
+			record(nil, ConsentPurposeAll, ConsentStatusRevoked),
+		}, false, ErrConsentRevoked},
+		{"VOLTAR não restaura a concessão", []*ConsentRecord{
+			record(&tenantID, ConsentPurposeProcessUpdates, ConsentStatusGranted),
+			record(nil, ConsentPurposeAll, ConsentStatusRevoked),
+			record(nil, ConsentPurposeAll, ConsentStatusGranted),
+		}, true, ErrConsentRequired},
+		{"VOLTAR libera quem não exige concessão", []*ConsentRecord{
+			record(nil, ConsentPurposeAll, ConsentStatusRevoked),
+			record(nil, ConsentPurposeAll, ConsentStatusGranted),
+		}, false, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := EvaluateConsent(tt.records, tenantID, ConsentPurposeProcessUpdates, tt.requireGrant)
+			if tt.want == nil && err != nil {
+				t.Errorf("error = %v, want nil", err)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestConsentPurposeForType(t *testing.T) {
+	if got := ConsentPurposeForType(NotificationTypeMovementAlert); got != ConsentPurposeProcessUpdates {
+		t.Errorf("movement_alert = %s", got)
+	}
+	if got := ConsentPurposeForType(NotificationTypeSubscriptionDue); got != ConsentPurposeBilling {
+		t.Errorf("subscription_due = %s", got)
+	}
+	if got := ConsentPurposeForType(NotificationTypePasswordReset); got != ConsentPurposeService {
+		t.Errorf("password_reset = %s", got)
+	}
+}
@@ -0,0 +1,238 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeliveryEvent evento de entrega reportado pelo provedor via webhook
+type DeliveryEvent string
+
+const (
+	DeliveryEventSent       DeliveryEvent = "sent"
+	DeliveryEventDelivered  DeliveryEvent = "delivered"
+	DeliveryEventRead       DeliveryEvent = "read"
+	DeliveryEventFailed     DeliveryEvent = "failed"
+	DeliveryEventBounced    DeliveryEvent = "bounced"
+	DeliveryEventComplained DeliveryEvent = "complained"
+	DeliveryEventOpened     DeliveryEvent = "opened"  // pixel de abertura do email
+	DeliveryEventClicked    DeliveryEvent = "clicked" // link rastreado
+)
+
+// DeliveryStatusUpdate atualização de status recebida de um provedor
+type DeliveryStatusUpdate struct {
+	Channel    NotificationChannel `json:"channel"`
+	ExternalID string              `json:"external_id"` // ID da mensagem no provedor
+	Event      DeliveryEvent       `json:"event"`
+	OccurredAt time.Time           `json:"occurred_at"`
+	Recipient  string              `json:"recipient,omitempty"`
+	HardBounce bool                `json:"hard_bounce,omitempty"` // falha permanente do endereço
+	ErrorCode  string              `json:"error_code,omitempty"`
+	Reason     string              `json:"reason,omitempty"`
+}
+
+// SuppressesContact indica se o evento deve suprimir o contato do destinatário
+func (u *DeliveryStatusUpdate) SuppressesContact() bool {
+	return (u.Event == DeliveryEventBounced && u.HardBounce) || u.Event == DeliveryEventComplained
+}
+
+// FailureCategory categoria da falha reportada; bounce permanente sem causa reconhecida conta
+// como destinatário inválido
+func (u *DeliveryStatusUpdate) FailureCategory() FailureCategory {
+	category := ClassifyFailure(u.Channel, u.ErrorCode, u.Reason)
+	if category == FailureCategoryOther && u.Event == DeliveryEventBounced && u.HardBounce {
+		return FailureCategoryInvalidRecipient
+	}
+	return category
+}
+
+// DeliveryRecord registro do histórico de entrega de uma notificação
+type DeliveryRecord struct {
+	ID             uuid.UUID              `json:"id" db:"id"`
+	NotificationID uuid.UUID              `json:"notification_id" db:"notification_id"`
+	Channel        NotificationChannel    `json:"channel" db:"channel"`
+	Status         DeliveryEvent          `json:"status" db:"status"`
+	ProviderID     string                 `json:"provider_id" db:"provider_id"`
+	DeliveredAt    *time.Time             `json:"delivered_at,omitempty" db:"delivered_at"`
+	FailedAt       *time.Time             `json:"failed_at,omitempty" db:"failed_at"`
+	ErrorCode      *string                `json:"error_code,omitempty" db:"error_code"`
+	ErrorMessage   *string                `json:"error_message,omitempty" db:"error_message"`
+	Metadata       map[string]interface{} `json:"metadata,omitempty" db:"metadata"`
+	CreatedAt      time.Time              `json:"created_at" db:"created_at"`
+}
+
+// NewDeliveryRecord cria registro de histórico a partir da atualização do provedor
+func NewDeliveryRecord(notificationID uuid.UUID, update *DeliveryStatusUpdate) *DeliveryRecord {
+	record := &DeliveryRecord{
+		ID:             uuid.New(),
+		NotificationID: notificationID,
+		Channel:        update.Channel,
+		Status:         update.Event,
+		ProviderID:     update.ExternalID,
+		Metadata:       make(map[string]interface{}),
+		CreatedAt:      time.Now(),
+	}
+
+	occurredAt := update.OccurredAt
+	switch update.Event {
+	case DeliveryEventDelivered, DeliveryEventRead, DeliveryEventOpened, DeliveryEventClicked:
+		record.DeliveredAt = &occurredAt
+	case DeliveryEventFailed, DeliveryEventBounced:
+		record.FailedAt = &occurredAt
+		record.Metadata["hard_bounce"] = update.HardBounce
+	}
+
+	if update.ErrorCode != "" {
+		record.ErrorCode = &update.ErrorCode
+	}
+	if update.Reason != "" {
+		record.ErrorMessage = &update.Reason
+	}
+
+	return record
+}
+
+// SuppressionReason motivo da supressão de um contato
+type SuppressionReason string
+
+const (
+	SuppressionReasonHardBounce SuppressionReason = "hard_bounce"
+	SuppressionReasonComplaint  SuppressionReason = "complaint"
+	SuppressionReasonBlocked    SuppressionReason = "blocked" // usuário bloqueou o bot
+	SuppressionReasonOptOut     SuppressionReason = "opt_out" // usuário respondeu SAIR por SMS
+)
+
+// SuppressedContact contato que não deve mais receber notificações no canal
+type SuppressedContact struct {
+	ID             uuid.UUID           `json:"id" db:"id"`
+	Channel        NotificationChannel `json:"channel" db:"channel"`
+	Contact        string              `json:"contact" db:"contact"`
+	Reason         SuppressionReason   `json:"reason" db:"reason"`
+	Details        *string             `json:"details,omitempty" db:"details"`
+	NotificationID *uuid.UUID          `json:"notification_id,omitempty" db:"notification_id"`
+	CreatedAt      time.Time           `json:"created_at" db:"created_at"`
+}
+
+// NewSuppressedContact cria supressão para o contato no canal
+func NewSuppressedContact(channel NotificationChannel, contact string, reason SuppressionReason) *SuppressedContact {
+	return &SuppressedContact{
+		ID:        uuid.New(),
+		Channel:   channel,
+		Contact:   NormalizeContact(channel, contact),
+		Reason:    reason,
+		CreatedAt: time.Now(),
+	}
+}
+
+// NormalizeContact normaliza o contato para comparação (emails não diferenciam maiúsculas e
+// telefones de SMS são comparados em E.164)
+func NormalizeContact(channel NotificationChannel, contact string) string {
+	contact = strings.TrimSpace(contact)
+	switch channel {
+	case NotificationChannelEmail:
+		return strings.ToLower(contact)
+	case NotificationChannelSMS:
+		if phone, err := NormalizeBrazilianPhone(contact); err == nil {
+			return phone
+		}
+	}
+	return contact
+}
+
+// ApplyDeliveryEvent aplica o evento do provedor ao status da notificação.
+// Os eventos podem chegar fora de ordem: o status só avança (enviada → entregue → lida),
+// eventos atrasados apenas completam os timestamps e falhas posteriores a uma entrega
+// confirmada são ignoradas. Retorna true se houve mudança.
+func (n *Notification) ApplyDeliveryEvent(event DeliveryEvent, at time.Time, reason string) bool {
+	advanced := false
+	changed := false
+
+	switch event {
+	case DeliveryEventSent:
+		if n.SentAt == nil {
+			n.SentAt = &at
+			changed = true
+		}
+		if n.Status == NotificationStatusProcessing || n.Status == NotificationStatusPending {
+			n.Status = NotificationStatusSent
+			advanced = true
+		}
+
+	case DeliveryEventDelivered:
+		if n.DeliveredAt == nil {
+			n.DeliveredAt = &at
+			changed = true
+		}
+		if n.Status != NotificationStatusRead && n.Status != NotificationStatusDelivered {
+			n.Status = NotificationStatusDelivered
+			advanced = true
+		}
+
+	case DeliveryEventRead:
+		// Leitura implica entrega
+		if n.DeliveredAt == nil {
+			n.DeliveredAt = &at
+			changed = true
+		}
+		if n.ReadAt == nil {
+			n.ReadAt = &at
+			changed = true
+		}
+		if n.Status != NotificationStatusRead {
+			n.Status = NotificationStatusRead
+			advanced = true
+		}
+
+	case DeliveryEventOpened, DeliveryEventClicked:
+		// Abertura e clique implicam entrega; o clique implica abertura
+		if n.DeliveredAt == nil {
+			n.DeliveredAt = &at
+			changed = true
+		}
+		if n.OpenedAt == nil {
+			n.OpenedAt = &at
+			changed = true
+		}
+		if event == DeliveryEventClicked && n.ClickedAt == nil {
+			n.ClickedAt = &at
+			changed = true
+		}
+		if n.Status != NotificationStatusRead && n.Status != NotificationStatusDelivered {
+			n.Status = NotificationStatusDelivered
+			advanced = true
+		}
+
+	case DeliveryEventFailed, DeliveryEventBounced:
+		if n.Status == NotificationStatusDelivered || n.Status == NotificationStatusRead {
+			return false
+		}
+		n.Status = NotificationStatusFailed
+		n.FailedAt = &at
+		if reason != "" {
+			n.ErrorMessage = &reason
+		}
+		n.FailureCategory = ClassifyFailure(n.Channel, "", reason)
+		// A mensagem já foi aceita pelo provedor: reenviar geraria duplicatas
+		n.RetryCount = n.MaxRetries
+		n.NextRetryAt = nil
+		advanced = true
+
+	case DeliveryEventComplained:
+		// Reclamação não altera o status da entrega
+		advanced = true
+	}
+
+	if advanced {
+		externalStatus := string(event)
+		n.ExternalStatus = &externalStatus
+	}
+
+	if !advanced && !changed {
+		return false
+	}
+
+	n.UpdatedAt = time.Now()
+	return true
+}
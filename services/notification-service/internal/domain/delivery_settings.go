@@ -0,0 +1,302 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultTimezone fuso horário padrão das janelas de entrega
+const DefaultTimezone = "America/Sao_Paulo"
+
+// maxWindowSteps limite de saltos ao procurar a próxima janela permitida
+const maxWindowSteps = 16
+
+// DigestFrequency frequência do resumo de notificações
+type DigestFrequency string
+
+const (
+	DigestFrequencyNone   DigestFrequency = "none"
+	DigestFrequencyHourly DigestFrequency = "hourly"
+	DigestFrequencyDaily  DigestFrequency = "daily"
+	DigestFrequencyWeekly DigestFrequency = "weekly"
+)
+
+// ValidateDigestFrequency valida frequência do resumo
+func ValidateDigestFrequency(frequency DigestFrequency) error {
+	switch frequency {
+	case DigestFrequencyNone, DigestFrequencyHourly, DigestFrequencyDaily, DigestFrequencyWeekly:
+		return nil
+	default:
+		return fmt.Errorf("frequência de resumo inválida: %s", frequency)
+	}
+}
+
+// SupportsDigest indica se o tipo de notificação pode ser agrupado em resumo
+func SupportsDigest(notificationType NotificationType) bool {
+	return notificationType == NotificationTypeMovementAlert || notificationType == NotificationTypeProcessUpdate
+}
+
+// ValidatePreferenceDigest valida a frequência de resumo para o tipo de notificação
+func ValidatePreferenceDigest(notificationType NotificationType, frequency DigestFrequency) error {
+	if frequency == "" || frequency == DigestFrequencyNone {
+		return nil
+	}
+	if err := ValidateDigestFrequency(frequency); err != nil {
+		return err
+	}
+	if !SupportsDigest(notificationType) {
+		return fmt.Errorf("tipo de notificação não suporta resumo: %s", notificationType)
+	}
+	return nil
+}
+
+// DeliverySettings janela de entrega de um usuário. Sem UserID, representa o padrão do tenant.
+// Horários no formato HH:MM, no fuso horário configurado.
+type DeliverySettings struct {
+	ID                 uuid.UUID              `json:"id"`
+	TenantID           uuid.UUID              `json:"tenant_id"`
+	UserID             *uuid.UUID             `json:"user_id,omitempty"`
+	Timezone           string                 `json:"timezone"`
+	Language           string                 `json:"language"` // idioma dos templates (pt-BR, en, es)
+	QuietHoursEnabled  bool                   `json:"quiet_hours_enabled"`
+	QuietHoursStart    string                 `json:"quiet_hours_start"`
+	QuietHoursEnd      string                 `json:"quiet_hours_end"`
+	BusinessHoursOnly  bool                   `json:"business_hours_only"`
+	BusinessHoursStart string                 `json:"business_hours_start"`
+	BusinessHoursEnd   string                 `json:"business_hours_end"`
+	BusinessDays       []time.Weekday         `json:"business_days"`
+	OverridePriorities []NotificationPriority `json:"override_priorities"` // prioridades que ignoram silêncio e resumo
+	DigestHour         int                    `json:"digest_hour"`         // hora local do resumo diário e semanal
+	DigestWeekday      time.Weekday           `json:"digest_weekday"`      // dia do resumo semanal
+	CreatedAt          time.Time              `json:"created_at"`
+	UpdatedAt          time.Time              `json:"updated_at"`
+}
+
+// NewDeliverySettings cria configuração com os valores padrão: sem silêncio nem restrição de
+// horário, horário comercial de segunda a sexta das 08:00 às 18:00 e notificações críticas
+// sempre entregues imediatamente
+func NewDeliverySettings(tenantID uuid.UUID, userID *uuid.UUID) *DeliverySettings {
+	now := time.Now()
+	return &DeliverySettings{
+		ID:                 uuid.New(),
+		TenantID:           tenantID,
+		UserID:             userID,
+		Timezone:           DefaultTimezone,
+		Language:           DefaultLocale,
+		QuietHoursStart:    "22:00",
+		QuietHoursEnd:      "07:00",
+		BusinessHoursStart: "08:00",
+		BusinessHoursEnd:   "18:00",
+		BusinessDays: []time.Weekday{
+			time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday,
+		},
+		OverridePriorities: []NotificationPriority{NotificationPriorityCritical},
+		DigestHour:         8,
+		DigestWeekday:      time.Monday,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+}
+
+// Validate valida a configuração
+func (s *DeliverySettings) Validate() error {
+	if _, err := time.LoadLocation(s.Timezone); err != nil {
+		return fmt.Errorf("fuso horário inválido: %s", s.Timezone)
+	}
+
+	if _, err := NormalizeLocale(s.Language); err != nil {
+		return fmt.Errorf("idioma inválido: %s", s.Language)
+	}
+
+	for _, clock := range []string{s.QuietHoursStart, s.QuietHoursEnd, s.BusinessHoursStart, s.BusinessHoursEnd} {
+		if _, err := parseClock(clock); err != nil {
+			return err
+		}
+	}
+
+	if s.BusinessHoursOnly {
+		start, _ := parseClock(s.BusinessHoursStart)
+		end, _ := parseClock(s.BusinessHoursEnd)
+		if start >= end {
+			return fmt.Errorf("horário comercial inválido: %s-%s", s.BusinessHoursStart, s.BusinessHoursEnd)
+		}
+		if len(s.BusinessDays) == 0 {
+			return fmt.Errorf("horário comercial sem dias úteis")
+		}
+	}
+
+	for _, day := range s.BusinessDays {
+		if day < time.Sunday || day > time.Saturday {
+			return fmt.Errorf("dia da semana inválido: %d", day)
+		}
+	}
+
+	for _, priority := range s.OverridePriorities {
+		if err := ValidatePriority(priority); err != nil {
+			return err
+		}
+	}
+
+	if s.DigestHour < 0 || s.DigestHour > 23 {
+		return fmt.Errorf("hora do resumo inválida: %d", s.DigestHour)
+	}
+	if s.DigestWeekday < time.Sunday || s.DigestWeekday > time.Saturday {
+		return fmt.Errorf("dia do resumo inválido: %d", s.DigestWeekday)
+	}
+
+	return nil
+}
+
+// Overrides indica se a prioridade ignora horário de silêncio, horário comercial e resumo
+func (s *DeliverySettings) Overrides(priority NotificationPriority) bool {
+	for _, p := range s.OverridePriorities {
+		if p == priority {
+			return true
+		}
+	}
+	return false
+}
+
+// NextDeliveryTime retorna o primeiro instante a partir de now em que a notificação pode ser
+// entregue, respeitando horário de silêncio e horário comercial
+func (s *DeliverySettings) NextDeliveryTime(priority NotificationPriority, now time.Time) time.Time {
+	if s.Overrides(priority) {
+		return now
+	}
+
+	t := now.In(s.location())
+	for i := 0; i < maxWindowSteps; i++ {
+		next, blocked := s.blockedUntil(t)
+		if !blocked {
+			break
+		}
+		t = next
+	}
+
+	return t.In(now.Location())
+}
+
+// NextDigestTime retorna o próximo envio do resumo na frequência informada
+func (s *DeliverySettings) NextDigestTime(frequency DigestFrequency, now time.Time) time.Time {
+	t := now.In(s.location())
+	digestClock := s.DigestHour * 60
+
+	var next time.Time
+	switch frequency {
+	case DigestFrequencyHourly:
+		next = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location()).Add(time.Hour)
+	case DigestFrequencyDaily:
+		next = atClock(t, digestClock)
+		if !next.After(t) {
+			next = atClock(t.AddDate(0, 0, 1), digestClock)
+		}
+	case DigestFrequencyWeekly:
+		days := (int(s.DigestWeekday) - int(t.Weekday()) + 7) % 7
+		next = atClock(t.AddDate(0, 0, days), digestClock)
+		if !next.After(t) {
+			next = atClock(t.AddDate(0, 0, days+7), digestClock)
+		}
+	default:
+		return now
+	}
+
+	return next.In(now.Location())
+}
+
+// WithTenantRules aplica as regras obrigatórias do tenant (horário comercial) sobre a
+// configuração do usuário
+func (s *DeliverySettings) WithTenantRules(tenant *DeliverySettings) *DeliverySettings {
+	if tenant == nil || !tenant.BusinessHoursOnly || s.BusinessHoursOnly {
+		return s
+	}
+
+	merged := *s
+	merged.BusinessHoursOnly = true
+	merged.BusinessHoursStart = tenant.BusinessHoursStart
+	merged.BusinessHoursEnd = tenant.BusinessHoursEnd
+	merged.BusinessDays = tenant.BusinessDays
+	return &merged
+}
+
+// blockedUntil indica se t está fora da janela de entrega e o próximo instante a verificar
+func (s *DeliverySettings) blockedUntil(t time.Time) (time.Time, bool) {
+	minute := t.Hour()*60 + t.Minute()
+
+	if s.QuietHoursEnabled {
+		start, errStart := parseClock(s.QuietHoursStart)
+		end, errEnd := parseClock(s.QuietHoursEnd)
+		if errStart == nil && errEnd == nil && inClockWindow(minute, start, end) {
+			next := atClock(t, end)
+			if !next.After(t) {
+				next = atClock(t.AddDate(0, 0, 1), end)
+			}
+			return next, true
+		}
+	}
+
+	if s.BusinessHoursOnly {
+		start, errStart := parseClock(s.BusinessHoursStart)
+		end, errEnd := parseClock(s.BusinessHoursEnd)
+		if errStart == nil && errEnd == nil {
+			switch {
+			case !s.isBusinessDay(t.Weekday()) || minute >= end:
+				return atClock(t.AddDate(0, 0, 1), start), true
+			case minute < start:
+				return atClock(t, start), true
+			}
+		}
+	}
+
+	return t, false
+}
+
+// isBusinessDay verifica se o dia é útil
+func (s *DeliverySettings) isBusinessDay(day time.Weekday) bool {
+	for _, d := range s.BusinessDays {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// location retorna o fuso horário configurado (padrão se inválido)
+func (s *DeliverySettings) location() *time.Location {
+	timezone := s.Timezone
+	if timezone == "" {
+		timezone = DefaultTimezone
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// parseClock converte HH:MM em minutos desde a meia-noite
+func parseClock(clock string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(clock))
+	if err != nil {
+		return 0, fmt.Errorf("horário inválido (esperado HH:MM): %s", clock)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// inClockWindow verifica se minute está na janela [start, end), que pode cruzar a meia-noite
+func inClockWindow(minute, start, end int) bool {
+	if start == end {
+		return false
+	}
+	if start < end {
+		return minute >= start && minute < end
+	}
+	return minute >= start || minute < end
+}
+
+// atClock retorna o dia de t no horário informado (minutos desde a meia-noite)
+func atClock(t time.Time, minutes int) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), minutes/60, minutes%60, 0, 0, t.Location())
+}
@@ -0,0 +1,191 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// mustLoadLocation carrega o fuso horário do teste
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("LoadLocation(%q) error = %v", name, err)
+	}
+	return loc
+}
+
+func TestNextDeliveryTimeQuietHoursCrossMidnight(t *testing.T) {
+	loc := mustLoadLocation(t, DefaultTimezone)
+	settings := NewDeliverySettings(uuid.New(), nil)
+	settings.QuietHoursEnabled = true // 22:00-07:00
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{"antes do silêncio", time.Date(2025, 3, 10, 21, 59, 0, 0, loc), time.Date(2025, 3, 10, 21, 59, 0, 0, loc)},
+		{"início do silêncio", time.Date(2025, 3, 10, 22, 0, 0, 0, loc), time.Date(2025, 3, 11, 7, 0, 0, 0, loc)},
+		{"antes da meia-noite", time.Date(2025, 3, 10, 23, 30, 0, 0, loc), time.Date(2025, 3, 11, 7, 0, 0, 0, loc)},
+		{"depois da meia-noite", time.Date(2025, 3, 11, 2, 0, 0, 0, loc), time.Date(2025, 3, 11, 7, 0, 0, 0, loc)},
+		{"fim do silêncio", time.Date(2025, 3, 11, 7, 0, 0, 0, loc), time.Date(2025, 3, 11, 7, 0, 0, 0, loc)},
+		{"instante em UTC", time.Date(2025, 3, 11, 2, 0, 0, 0, time.UTC), time.Date(2025, 3, 11, 10, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		got := settings.NextDeliveryTime(NotificationPriorityNormal, tt.now)
+		if !got.Equal(tt.want) || got.Location() != tt.now.Location() {
+			t.Errorf("%s: NextDeliveryTime() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNextDeliveryTimeBusinessDayRollover(t *testing.T) {
+	loc := mustLoadLocation(t, DefaultTimezone)
+	settings := NewDeliverySettings(uuid.New(), nil)
+	settings.BusinessHoursOnly = true // 08:00-18:00, segunda a sexta
+	monday := time.Date(2025, 3, 17, 8, 0, 0, 0, loc)
+
+	tests := []struct {
+		name  string
+		now   time.Time
+		quiet bool
+		want  time.Time
+	}{
+		{"sexta após o expediente", time.Date(2025, 3, 14, 18, 30, 0, 0, loc), false, monday},
+		{"sábado", time.Date(2025, 3, 15, 10, 0, 0, 0, loc), false, monday},
+		{"domingo à noite", time.Date(2025, 3, 16, 23, 0, 0, 0, loc), false, monday},
+		{"segunda antes do expediente", time.Date(2025, 3, 17, 6, 0, 0, 0, loc), false, monday},
+		{"quarta no expediente", time.Date(2025, 3, 12, 12, 0, 0, 0, loc), false, time.Date(2025, 3, 12, 12, 0, 0, 0, loc)},
+		{"sexta no silêncio", time.Date(2025, 3, 14, 23, 0, 0, 0, loc), true, monday},
+	}
+
+	for _, tt := range tests {
+		settings.QuietHoursEnabled = tt.quiet
+		if got := settings.NextDeliveryTime(NotificationPriorityNormal, tt.now); !got.Equal(tt.want) {
+			t.Errorf("%s: NextDeliveryTime() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNextDeliveryTimeOverridePriorities(t *testing.T) {
+	loc := mustLoadLocation(t, DefaultTimezone)
+	now := time.Date(2025, 3, 10, 23, 30, 0, 0, loc)
+	morning := time.Date(2025, 3, 11, 7, 0, 0, 0, loc)
+
+	tests := []struct {
+		name      string
+		overrides []NotificationPriority
+		priority  NotificationPriority
+		want      time.Time
+	}{
+		{"crítica ignora o silêncio por padrão", []NotificationPriority{NotificationPriorityCritical}, NotificationPriorityCritical, now},
+		{"alta aguarda por padrão", []NotificationPriority{NotificationPriorityCritical}, NotificationPriorityHigh, morning},
+		{"alta configurada ignora o silêncio", []NotificationPriority{NotificationPriorityHigh}, NotificationPriorityHigh, now},
+		{"crítica fora da lista aguarda", []NotificationPriority{NotificationPriorityHigh}, NotificationPriorityCritical, morning},
+		{"sem prioridades que ignoram", nil, NotificationPriorityCritical, morning},
+	}
+
+	for _, tt := range tests {
+		settings := NewDeliverySettings(uuid.New(), nil)
+		settings.QuietHoursEnabled = true
+		settings.OverridePriorities = tt.overrides
+
+		if got := settings.NextDeliveryTime(tt.priority, now); !got.Equal(tt.want) {
+			t.Errorf("%s: NextDeliveryTime() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWithTenantRules(t *testing.T) {
+	loc := mustLoadLocation(t, DefaultTimezone)
+	userID := uuid.New()
+
+	tenant := NewDeliverySettings(uuid.New(), nil)
+	tenant.BusinessHoursOnly = true
+	tenant.BusinessHoursStart = "09:00"
+	tenant.BusinessHoursEnd = "17:00"
+	tenant.BusinessDays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday}
+
+	user := NewDeliverySettings(tenant.TenantID, &userID)
+	user.QuietHoursEnabled = true
+	user.QuietHoursStart = "12:00"
+	user.QuietHoursEnd = "14:00"
+
+	merged := user.WithTenantRules(tenant)
+	if !merged.BusinessHoursOnly || merged.BusinessHoursStart != "09:00" || merged.BusinessHoursEnd != "17:00" || len(merged.BusinessDays) != 3 {
+		t.Fatalf("WithTenantRules() = %+v, want tenant business hours", merged)
+	}
+	if user.BusinessHoursOnly {
+		t.Error("WithTenantRules() changed the user settings")
+	}
+
+	// Horário comercial do tenant combinado ao silêncio do usuário
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{"quinta fora dos dias do tenant", time.Date(2025, 3, 13, 10, 0, 0, 0, loc), time.Date(2025, 3, 17, 9, 0, 0, 0, loc)},
+		{"terça antes do horário do tenant", time.Date(2025, 3, 11, 8, 30, 0, 0, loc), time.Date(2025, 3, 11, 9, 0, 0, 0, loc)},
+		{"terça no silêncio do usuário", time.Date(2025, 3, 11, 12, 30, 0, 0, loc), time.Date(2025, 3, 11, 14, 0, 0, 0, loc)},
+	}
+	for _, tt := range tests {
+		if got := merged.NextDeliveryTime(NotificationPriorityNormal, tt.now); !got.Equal(tt.want) {
+			t.Errorf("%s: NextDeliveryTime() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// Usuário com horário comercial próprio ou tenant sem regra: mantém o do usuário
+	user.BusinessHoursOnly = true
+	if got := user.WithTenantRules(tenant); got.BusinessHoursStart != "08:00" {
+		t.Errorf("WithTenantRules() start = %s, want user business hours", got.BusinessHoursStart)
+	}
+	if got := user.WithTenantRules(nil); got != user {
+		t.Error("WithTenantRules(nil) should return the user settings")
+	}
+}
+
+func TestNextDigestTime(t *testing.T) {
+	saoPaulo := mustLoadLocation(t, DefaultTimezone)
+	newYork := mustLoadLocation(t, "America/New_York")
+
+	tests := []struct {
+		name      string
+		loc       *time.Location
+		frequency DigestFrequency
+		weekday   time.Weekday
+		now       time.Time
+		want      time.Time
+	}{
+		// São Paulo (UTC-3): resumo às 08:00 locais, 11:00 UTC
+		{"diário antes da hora", saoPaulo, DigestFrequencyDaily, time.Monday, time.Date(2025, 3, 10, 10, 0, 0, 0, time.UTC), time.Date(2025, 3, 10, 11, 0, 0, 0, time.UTC)},
+		{"diário na hora", saoPaulo, DigestFrequencyDaily, time.Monday, time.Date(2025, 3, 10, 11, 0, 0, 0, time.UTC), time.Date(2025, 3, 11, 11, 0, 0, 0, time.UTC)},
+		{"diário após a meia-noite UTC", saoPaulo, DigestFrequencyDaily, time.Monday, time.Date(2025, 3, 11, 1, 0, 0, 0, time.UTC), time.Date(2025, 3, 11, 11, 0, 0, 0, time.UTC)},
+		{"semanal na terça", saoPaulo, DigestFrequencyWeekly, time.Monday, time.Date(2025, 3, 11, 12, 0, 0, 0, time.UTC), time.Date(2025, 3, 17, 11, 0, 0, 0, time.UTC)},
+		{"semanal no dia após a hora", saoPaulo, DigestFrequencyWeekly, time.Monday, time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC), time.Date(2025, 3, 17, 11, 0, 0, 0, time.UTC)},
+		{"por hora", saoPaulo, DigestFrequencyHourly, time.Monday, time.Date(2025, 3, 10, 10, 15, 0, 0, time.UTC), time.Date(2025, 3, 10, 11, 0, 0, 0, time.UTC)},
+
+		// Nova York: início do horário de verão em 09/03/2025 e fim em 02/11/2025
+		{"diário no início do horário de verão", newYork, DigestFrequencyDaily, time.Monday, time.Date(2025, 3, 8, 14, 0, 0, 0, time.UTC), time.Date(2025, 3, 9, 12, 0, 0, 0, time.UTC)},
+		{"por hora na hora suprimida", newYork, DigestFrequencyHourly, time.Monday, time.Date(2025, 3, 9, 6, 30, 0, 0, time.UTC), time.Date(2025, 3, 9, 7, 0, 0, 0, time.UTC)},
+		{"semanal no fim do horário de verão", newYork, DigestFrequencyWeekly, time.Sunday, time.Date(2025, 10, 30, 12, 0, 0, 0, time.UTC), time.Date(2025, 11, 2, 13, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		settings := NewDeliverySettings(uuid.New(), nil)
+		settings.Timezone = tt.loc.String()
+		settings.DigestWeekday = tt.weekday
+
+		got := settings.NextDigestTime(tt.frequency, tt.now)
+		if !got.Equal(tt.want) {
+			t.Errorf("%s: NextDigestTime() = %v, want %v", tt.name, got, tt.want)
+		}
+		if local := got.In(tt.loc); tt.frequency != DigestFrequencyHourly && local.Hour() != settings.DigestHour {
+			t.Errorf("%s: NextDigestTime() local hour = %d, want %d", tt.name, local.Hour(), settings.DigestHour)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// digestPreviewLength tamanho máximo do trecho de cada item no resumo
+const digestPreviewLength = 160
+
+// Batch coloca a notificação na fila do resumo com envio previsto em digestAt
+func (n *Notification) Batch(digestAt time.Time) {
+	n.Status = NotificationStatusBatched
+	n.DigestAt = &digestAt
+	n.ScheduledAt = nil
+	n.UpdatedAt = time.Now()
+}
+
+// MarkAsDigested registra a inclusão da notificação no resumo
+func (n *Notification) MarkAsDigested(digestID uuid.UUID) {
+	n.Status = NotificationStatusDigested
+	n.DigestID = &digestID
+	n.UpdatedAt = time.Now()
+}
+
+// NewDigestNotification cria a notificação de resumo que agrupa os itens de um mesmo
+// destinatário e canal, com conteúdo renderizado para o canal
+func NewDigestNotification(items []*Notification) (*Notification, error) {
+	if len(items) == 0 {
+		return nil, fmt.Errorf("resumo sem notificações")
+	}
+
+	first := items[0]
+	subject, content, contentHTML := RenderDigest(first.Channel, items)
+
+	now := time.Now()
+	digest := &Notification{
+		ID:               uuid.New(),
+		Type:             NotificationTypeDigest,
+		Channel:          first.Channel,
+		Priority:         NotificationPriorityNormal,
+		Status:           NotificationStatusPending,
+		Subject:          subject,
+		Content:          content,
+		ContentHTML:      contentHTML,
+		RecipientID:      first.RecipientID,
+		RecipientType:    first.RecipientType,
+		RecipientContact: first.RecipientContact,
+		TenantID:         first.TenantID,
+		UserID:           first.UserID,
+		Metadata: map[string]interface{}{
+			"digest_items": len(items),
+		},
+		MaxRetries: 3,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	return digest, nil
+}
+
+// RenderDigest renderiza o resumo: lista em texto para mensageiros e HTML para email
+func RenderDigest(channel NotificationChannel, items []*Notification) (string, string, *string) {
+	subject := fmt.Sprintf("Resumo: %d atualizações dos seus processos", len(items))
+	if len(items) == 1 {
+		subject = "Resumo: 1 atualização dos seus processos"
+	}
+
+	var text strings.Builder
+	text.WriteString(subject)
+	text.WriteString("\n")
+	for _, item := range items {
+		text.WriteString("\n• ")
+		text.WriteString(digestItemTitle(item))
+		if preview := digestItemPreview(item); preview != "" {
+			text.WriteString("\n  ")
+			text.WriteString(preview)
+		}
+	}
+
+	if channel != NotificationChannelEmail {
+		return subject, text.String(), nil
+	}
+
+	var body strings.Builder
+	body.WriteString("<h2>")
+	body.WriteString(html.EscapeString(subject))
+	body.WriteString("</h2>\n<ul>\n")
+	for _, item := range items {
+		body.WriteString("<li><strong>")
+		body.WriteString(html.EscapeString(digestItemTitle(item)))
+		body.WriteString("</strong>")
+		if preview := digestItemPreview(item); preview != "" {
+			body.WriteString("<br>")
+			body.WriteString(html.EscapeString(preview))
+		}
+		body.WriteString("</li>\n")
+	}
+	body.WriteString("</ul>")
+
+	contentHTML := body.String()
+	return subject, text.String(), &contentHTML
+}
+
+// digestItemTitle título do item no resumo
+func digestItemTitle(item *Notification) string {
+	if item.Subject != "" {
+		return item.Subject
+	}
+	if item.Type == NotificationTypeMovementAlert {
+		return "Nova movimentação processual"
+	}
+	return "Atualização de processo"
+}
+
+// digestItemPreview primeira linha do conteúdo, truncada
+func digestItemPreview(item *Notification) string {
+	preview := strings.TrimSpace(item.Content)
+	if i := strings.IndexByte(preview, '\n'); i >= 0 {
+		preview = strings.TrimSpace(preview[:i])
+	}
+	if preview == item.Subject {
+		return ""
+	}
+
+	runes := []rune(preview)
+	if len(runes) > digestPreviewLength {
+		preview = string(runes[:digestPreviewLength]) + "…"
+	}
+	return preview
+}
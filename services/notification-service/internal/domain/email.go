@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Limites dos anexos de email
+const (
+	MaxEmailAttachments = 10
+	// MaxEmailAttachmentsSize soma dos anexos; em base64 a mensagem fica abaixo dos 25 MB aceitos
+	// pelos principais provedores de email
+	MaxEmailAttachmentsSize = 15 * 1024 * 1024
+)
+
+// NotificationAttachment anexo do email. O conteúdo vem embutido (base64 no JSON) ou é baixado da
+// URL no envio, como os PDFs gerados pelo report-service.
+type NotificationAttachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type,omitempty"` // padrão: pela extensão do arquivo
+	Content     []byte `json:"content,omitempty"`
+	URL         string `json:"url,omitempty"`
+}
+
+// ValidateAttachments valida nome, origem e tamanho dos anexos. O tamanho dos anexos por URL só é
+// conhecido no envio.
+func ValidateAttachments(attachments []NotificationAttachment) error {
+	if len(attachments) > MaxEmailAttachments {
+		return fmt.Errorf("%w: máximo de %d anexos", ErrInvalidAttachment, MaxEmailAttachments)
+	}
+
+	total := 0
+	for _, attachment := range attachments {
+		name := strings.TrimSpace(attachment.Filename)
+		if name == "" || strings.ContainsAny(name, "/\\\r\n\"") {
+			return fmt.Errorf("%w: nome de arquivo inválido: %q", ErrInvalidAttachment, attachment.Filename)
+		}
+
+		switch {
+		case len(attachment.Content) > 0 && attachment.URL != "":
+			return fmt.Errorf("%w: %s: informe content ou url, não ambos", ErrInvalidAttachment, name)
+		case len(attachment.Content) > 0:
+			total += len(attachment.Content)
+		case attachment.URL != "":
+			parsed, err := url.Parse(attachment.URL)
+			if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+				return fmt.Errorf("%w: %s: url inválida", ErrInvalidAttachment, name)
+			}
+		default:
+			return fmt.Errorf("%w: %s: sem conteúdo", ErrInvalidAttachment, name)
+		}
+
+		if strings.ContainsAny(attachment.ContentType, "\r\n") {
+			return fmt.Errorf("%w: %s: content_type inválido", ErrInvalidAttachment, name)
+		}
+	}
+
+	if total > MaxEmailAttachmentsSize {
+		return fmt.Errorf("%w: anexos somam %d bytes (máximo %d)", ErrInvalidAttachment, total, MaxEmailAttachmentsSize)
+	}
+	return nil
+}
+
+// DefaultDKIMSelector seletor usado quando o tenant não informa outro
+const DefaultDKIMSelector = "direitolux"
+
+var dkimSelectorPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// EmailSenderDomain domínio próprio do tenant para o remetente dos emails. A chave DKIM é gerada
+// pelo serviço e o domínio só é usado depois que o registro TXT é encontrado no DNS; até lá os
+// emails saem pelo remetente padrão.
+type EmailSenderDomain struct {
+	TenantID       uuid.UUID  `json:"tenant_id"`
+	Domain         string     `json:"domain"`
+	FromEmail      string     `json:"from_email"`
+	FromName       string     `json:"from_name,omitempty"`
+	DKIMSelector   string     `json:"dkim_selector"`
+	DKIMPrivateKey string     `json:"-"`               // PEM (PKCS#1)
+	DKIMPublicKey  string     `json:"dkim_public_key"` // base64 DER, valor do p= no DNS
+	Verified       bool       `json:"verified"`
+	VerifiedAt     *time.Time `json:"verified_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// NewEmailSenderDomain cria o remetente do tenant; o domínio é o do endereço informado
+func NewEmailSenderDomain(tenantID uuid.UUID, fromEmail, fromName, selector string) (*EmailSenderDomain, error) {
+	address, err := mail.ParseAddress(strings.TrimSpace(fromEmail))
+	if err != nil || address.Name != "" {
+		return nil, fmt.Errorf("%w: endereço do remetente inválido: %s", ErrEmailSenderDomainInvalid, fromEmail)
+	}
+
+	at := strings.LastIndex(address.Address, "@")
+	domainName := strings.ToLower(address.Address[at+1:])
+	if !strings.Contains(domainName, ".") {
+		return nil, fmt.Errorf("%w: domínio inválido: %s", ErrEmailSenderDomainInvalid, domainName)
+	}
+
+	selector = strings.ToLower(strings.TrimSpace(selector))
+	if selector == "" {
+		selector = DefaultDKIMSelector
+	}
+	if !dkimSelectorPattern.MatchString(selector) {
+		return nil, fmt.Errorf("%w: seletor DKIM inválido: %s", ErrEmailSenderDomainInvalid, selector)
+	}
+
+	fromName = strings.TrimSpace(fromName)
+	if strings.ContainsAny(fromName, "\r\n") {
+		return nil, fmt.Errorf("%w: nome do remetente inválido", ErrEmailSenderDomainInvalid)
+	}
+
+	now := time.Now()
+	return &EmailSenderDomain{
+		TenantID:     tenantID,
+		Domain:       domainName,
+		FromEmail:    address.Address[:at+1] + domainName,
+		FromName:     fromName,
+		DKIMSelector: selector,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}, nil
+}
+
+// DKIMRecordName nome do registro TXT com a chave pública
+func (d *EmailSenderDomain) DKIMRecordName() string {
+	return d.DKIMSelector + "._domainkey." + d.Domain
+}
+
+// DKIMRecordValue valor do registro TXT com a chave pública
+func (d *EmailSenderDomain) DKIMRecordValue() string {
+	return "v=DKIM1; k=rsa; p=" + d.DKIMPublicKey
+}
+
+// DKIMRecordMatches indica se o registro TXT publicado contém a chave pública do domínio. Os
+// provedores de DNS dividem valores longos em partes, que chegam concatenadas.
+func (d *EmailSenderDomain) DKIMRecordMatches(record string) bool {
+	for _, tag := range strings.Split(record, ";") {
+		name, value, found := strings.Cut(tag, "=")
+		if !found || strings.TrimSpace(name) != "p" {
+			continue
+		}
+		value = strings.Join(strings.Fields(value), "")
+		return value != "" && value == d.DKIMPublicKey
+	}
+	return false
+}
+
+// MarkVerified registra a verificação do registro DNS
+func (d *EmailSenderDomain) MarkVerified() {
+	now := time.Now()
+	d.Verified = true
+	d.VerifiedAt = &now
+	d.UpdatedAt = now
+}
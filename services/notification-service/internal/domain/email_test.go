@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestValidateAttachments(t *testing.T) {
+	tests := []struct {
+		name        string
+		attachments []NotificationAttachment
+		wantErr     bool
+	}{
+		{"inline", []NotificationAttachment{{Filename: "dados.csv", Content: []byte("a;b")}}, false},
+		{"url", []NotificationAttachment{{Filename: "relatório.pdf", URL: "https://files.direitolux.com.br/r/1.pdf"}}, false},
+		{"sem nome", []NotificationAttachment{{Content: []byte("a")}}, true},
+		{"nome com caminho", []NotificationAttachment{{Filename: "../etc/passwd", Content: []byte("a")}}, true},
+		{"nome com quebra de linha", []NotificationAttachment{{Filename: "a\r\nBcc: x@y.com", Content: []byte("a")}}, true},
+		{"conteúdo e url", []NotificationAttachment{{Filename: "a.pdf", Content: []byte("a"), URL: "https://x.com/a.pdf"}}, true},
+		{"sem conteúdo", []NotificationAttachment{{Filename: "a.pdf"}}, true},
+		{"url sem http", []NotificationAttachment{{Filename: "a.pdf", URL: "file:///etc/passwd"}}, true},
+		{"content type com quebra de linha", []NotificationAttachment{{Filename: "a.pdf", ContentType: "text/plain\r\nX: y", Content: []byte("a")}}, true},
+		{"acima do tamanho", []NotificationAttachment{{Filename: "a.bin", Content: make([]byte, MaxEmailAttachmentsSize+1)}}, true},
+		{"acima da quantidade", make([]NotificationAttachment, MaxEmailAttachments+1), true},
+	}
+
+	for _, tt := range tests {
+		err := ValidateAttachments(tt.attachments)
+		if tt.wantErr != (err != nil) {
+			t.Errorf("%s: ValidateAttachments() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+		if err != nil && !errors.Is(err, ErrInvalidAttachment) {
+			t.Errorf("%s: error = %v, want ErrInvalidAttachment", tt.name, err)
+		}
+	}
+}
+
+func TestNewEmailSenderDomain(t *testing.T) {
+	senderDomain, err := NewEmailSenderDomain(uuid.New(), "Avisos@SilvaAdvogados.com.BR", " Silva Advogados ", "")
+	if err != nil {
+		t.Fatalf("NewEmailSenderDomain() error = %v", err)
+	}
+	if senderDomain.Domain != "silvaadvogados.com.br" || senderDomain.FromEmail != "Avisos@silvaadvogados.com.br" {
+		t.Errorf("domain = %q, from = %q", senderDomain.Domain, senderDomain.FromEmail)
+	}
+	if senderDomain.FromName != "Silva Advogados" || senderDomain.Verified {
+		t.Errorf("from name = %q, verified = %v", senderDomain.FromName, senderDomain.Verified)
+	}
+	if got := senderDomain.DKIMRecordName(); got != "direitolux._domainkey.silvaadvogados.com.br" {
+		t.Errorf("DKIMRecordName() = %q", got)
+	}
+
+	for _, tt := range []struct{ email, selector string }{
+		{"Silva <avisos@silva.com.br>", ""},
+		{"avisos@localhost", ""},
+		{"avisos", ""},
+		{"avisos@silva.com.br", "seletor_invalido"},
+	} {
+		if _, err := NewEmailSenderDomain(uuid.New(), tt.email, "", tt.selector); !errors.Is(err, ErrEmailSenderDomainInvalid) {
+			t.Errorf("NewEmailSenderDomain(%q, %q) error = %v, want ErrEmailSenderDomainInvalid", tt.email, tt.selector, err)
+		}
+	}
+}
+
+func TestEmailSenderDomainDKIMRecordMatches(t *testing.T) {
+	senderDomain := &EmailSenderDomain{DKIMPublicKey: "MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA"}
+
+	tests := []struct {
+		record string
+		want   bool
+	}{
+		{senderDomain.DKIMRecordValue(), true},
+		{"v=DKIM1; k=rsa; p=MIIBIjANBgkqhkiG9w0BAQEF AAOCAQ8AMIIBCgKCAQEA", true}, // dividido pelo DNS
+		{"v=DKIM1;p=MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA", true},
+		{"v=DKIM1; k=rsa; p=OUTRACHAVE", false},
+		{"v=DKIM1; k=rsa; p=", false},
+		{"v=spf1 include:_spf.google.com ~all", false},
+	}
+
+	for _, tt := range tests {
+		if got := senderDomain.DKIMRecordMatches(tt.record); got != tt.want {
+			t.Errorf("DKIMRecordMatches(%q) = %v, want %v", tt.record, got, tt.want)
+		}
+	}
+}
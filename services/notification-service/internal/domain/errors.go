@@ -1,6 +1,10 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 // Erros de notificação
 var (
@@ -9,6 +13,9 @@ var (
 	ErrNotificationExpired    = errors.New("notification expired")
 	ErrInvalidNotification    = errors.New("invalid notification")
 	ErrNotificationExists     = errors.New("notification already exists")
+	ErrContactSuppressed      = errors.New("recipient contact is suppressed")
+
+	ErrInvalidAnalyticsFilter = errors.New("invalid analytics filter")
 )
 
 // Erros de template
@@ -17,6 +24,12 @@ var (
 	ErrTemplateInvalid     = errors.New("template invalid")
 	ErrTemplateExists      = errors.New("template already exists")
 	ErrSystemTemplate      = errors.New("cannot modify system template")
+
+	ErrTemplateMissingVariable = errors.New("template variable missing")
+	ErrTemplateVersionNotFound = errors.New("template version not found")
+
+	ErrWhatsAppTemplateRequired        = errors.New("whatsapp session window closed and no approved template mapped")
+	ErrWhatsAppTemplateMappingNotFound = errors.New("whatsapp template mapping not found")
 )
 
 // Erros de preferência
@@ -24,6 +37,52 @@ var (
 	ErrPreferenceNotFound = errors.New("preference not found")
 	ErrPreferenceInvalid  = errors.New("preference invalid")
 	ErrPreferenceExists   = errors.New("preference already exists")
+
+	ErrDeliverySettingsNotFound = errors.New("delivery settings not found")
+
+	ErrPushSubscriptionNotFound = errors.New("push subscription not found")
+	ErrNoPushSubscriptions      = errors.New("user has no push subscriptions")
+
+	ErrTelegramLinkCodeInvalid = errors.New("telegram link code invalid or expired")
+	ErrTelegramChatNotLinked   = errors.New("telegram chat not linked")
+
+	ErrWhatsAppContactNotLinked = errors.New("whatsapp contact not linked")
+)
+
+// Erros de consentimento (LGPD)
+var (
+	ErrConsentRequired = errors.New("recipient consent required")
+	ErrConsentRevoked  = errors.New("recipient consent revoked")
+	ErrConsentInvalid  = errors.New("consent record invalid")
+)
+
+// Erros de email
+var (
+	ErrInvalidAttachment = errors.New("invalid email attachment")
+
+	ErrEmailSenderDomainNotFound = errors.New("email sender domain not found")
+	ErrEmailSenderDomainInvalid  = errors.New("email sender domain invalid")
+	ErrEmailSenderDomainDNS      = errors.New("email sender domain DKIM record not found")
+)
+
+// Erros dos webhooks dos tenants
+var (
+	ErrWebhookNotFound         = errors.New("webhook subscription not found")
+	ErrWebhookInvalid          = errors.New("webhook subscription invalid")
+	ErrWebhookDisabled         = errors.New("webhook subscription disabled")
+	ErrWebhooksNotAllowed      = errors.New("webhooks not available in tenant plan")
+	ErrWebhookQuotaExceeded    = errors.New("webhook quota exceeded")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrInvalidWebhookEvent     = errors.New("invalid webhook event")
+)
+
+// Erros de integração com o process-service
+var (
+	ErrProcessNotFound = errors.New("process not found")
+
+	ErrProcessWatcherNotFound = errors.New("process watcher not found")
+	ErrProcessWatcherInvalid  = errors.New("process watcher invalid")
+	ErrInvalidMovementEvent   = errors.New("invalid movement event")
 )
 
 // Erros de provedor
@@ -34,6 +93,32 @@ var (
 	ErrRateLimitExceeded   = errors.New("rate limit exceeded")
 )
 
+// Escopos de limite de taxa
+const (
+	RateLimitScopeProvider = "provider" // limite local do provedor
+	RateLimitScopeTenant   = "tenant"   // limite local do tenant no canal
+	RateLimitScopeUpstream = "upstream" // 429/Retry-After devolvido pela API do provedor
+)
+
+// RateLimitError limite de taxa atingido, com o tempo sugerido para nova tentativa
+type RateLimitError struct {
+	Scope      string
+	RetryAfter time.Duration
+}
+
+// NewRateLimitError cria erro de limite de taxa
+func NewRateLimitError(scope string, retryAfter time.Duration) *RateLimitError {
+	return &RateLimitError{Scope: scope, RetryAfter: retryAfter}
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%s (%s): retry after %s", ErrRateLimitExceeded, e.Scope, e.RetryAfter)
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return ErrRateLimitExceeded
+}
+
 // Erros de fila
 var (
 	ErrQueueEmpty       = errors.New("queue is empty")
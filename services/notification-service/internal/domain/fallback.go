@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"time"
+)
+
+// FallbackPolicy cadeia de canais tentada em sequência para um tipo e prioridade de notificação.
+// Cada canal aguarda StepTimeout por um recibo de entrega antes de escalar para o próximo.
+type FallbackPolicy struct {
+	Type        NotificationType      `json:"type"`
+	Priority    NotificationPriority  `json:"priority"`
+	Channels    []NotificationChannel `json:"channels"`
+	StepTimeout time.Duration         `json:"step_timeout"`
+}
+
+// fallbackPolicyKey chave de busca da política
+type fallbackPolicyKey struct {
+	notificationType NotificationType
+	priority         NotificationPriority
+}
+
+// FallbackPolicies conjunto de políticas de fallback por tipo e prioridade
+type FallbackPolicies struct {
+	policies map[fallbackPolicyKey]*FallbackPolicy
+}
+
+// NewFallbackPolicies cria conjunto de políticas
+func NewFallbackPolicies(policies ...*FallbackPolicy) *FallbackPolicies {
+	set := &FallbackPolicies{policies: make(map[fallbackPolicyKey]*FallbackPolicy)}
+	for _, policy := range policies {
+		set.policies[fallbackPolicyKey{notificationType: policy.Type, priority: policy.Priority}] = policy
+	}
+	return set
+}
+
+// DefaultFallbackPolicies políticas padrão: alertas críticos de prazo e de processo percorrem
+// WhatsApp → Telegram → Email → SMS; lembretes de prazo de alta prioridade usam WhatsApp → Email
+func DefaultFallbackPolicies(stepTimeout time.Duration) *FallbackPolicies {
+	fullChain := []NotificationChannel{
+		NotificationChannelWhatsApp,
+		NotificationChannelTelegram,
+		NotificationChannelEmail,
+		NotificationChannelSMS,
+	}
+
+	return NewFallbackPolicies(
+		&FallbackPolicy{Type: NotificationTypeDeadlineReminder, Priority: NotificationPriorityCritical, Channels: fullChain, StepTimeout: stepTimeout},
+		&FallbackPolicy{Type: NotificationTypeMovementAlert, Priority: NotificationPriorityCritical, Channels: fullChain, StepTimeout: stepTimeout},
+		&FallbackPolicy{Type: NotificationTypeProcessUpdate, Priority: NotificationPriorityCritical, Channels: fullChain, StepTimeout: stepTimeout},
+		&FallbackPolicy{
+			Type:        NotificationTypeDeadlineReminder,
+			Priority:    NotificationPriorityHigh,
+			Channels:    []NotificationChannel{NotificationChannelWhatsApp, NotificationChannelEmail},
+			StepTimeout: stepTimeout,
+		},
+	)
+}
+
+// Find retorna a política do tipo e prioridade ou nil
+func (p *FallbackPolicies) Find(notificationType NotificationType, priority NotificationPriority) *FallbackPolicy {
+	if p == nil {
+		return nil
+	}
+	return p.policies[fallbackPolicyKey{notificationType: notificationType, priority: priority}]
+}
+
+// FallbackStatus situação consolidada de uma cadeia de fallback
+type FallbackStatus string
+
+const (
+	FallbackStatusInProgress FallbackStatus = "in_progress"
+	FallbackStatusDelivered  FallbackStatus = "delivered"
+	FallbackStatusExhausted  FallbackStatus = "exhausted" // nenhum canal confirmou a entrega
+)
+
+// IsFallbackAttempt indica se a notificação faz parte de uma cadeia de fallback
+func (n *Notification) IsFallbackAttempt() bool {
+	return n.FallbackRootID != nil
+}
+
+// IsFallbackChild indica se a notificação é uma tentativa filha (não a notificação lógica)
+func (n *Notification) IsFallbackChild() bool {
+	return n.FallbackRootID != nil && *n.FallbackRootID != n.ID
+}
+
+// IsDeliveryConfirmed indica se o provedor confirmou a entrega
+func (n *Notification) IsDeliveryConfirmed() bool {
+	return n.Status == NotificationStatusDelivered || n.Status == NotificationStatusRead
+}
+
+// IsFinished indica se a tentativa não terá mais envios (entregue, cancelada, expirada ou sem reenvios)
+func (n *Notification) IsFinished() bool {
+	switch n.Status {
+	case NotificationStatusDelivered, NotificationStatusRead, NotificationStatusCancelled, NotificationStatusExpired:
+		return true
+	case NotificationStatusFailed:
+		return !n.CanRetry()
+	}
+	return false
+}
+
+// ResolveFallbackStatus consolida o status das tentativas da cadeia
+func ResolveFallbackStatus(attempts []*Notification) FallbackStatus {
+	finished := true
+	for _, attempt := range attempts {
+		if attempt.IsDeliveryConfirmed() {
+			return FallbackStatusDelivered
+		}
+		// Enviada sem recibo ainda pode ser confirmada
+		if !attempt.IsFinished() {
+			finished = false
+		}
+	}
+
+	if finished {
+		return FallbackStatusExhausted
+	}
+	return FallbackStatusInProgress
+}
@@ -0,0 +1,231 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Routing keys dos eventos de movimentação publicados pelo process-service
+const (
+	MovementEventCreated           = "movement.created"
+	MovementEventImportantDetected = "movement.important.detected"
+)
+
+// ProcessWatcherRole papel do destinatário no processo
+type ProcessWatcherRole string
+
+const (
+	ProcessWatcherRoleResponsible ProcessWatcherRole = "responsible" // advogado responsável
+	ProcessWatcherRoleWatcher     ProcessWatcherRole = "watcher"     // membro da equipe acompanhando
+	ProcessWatcherRoleClient      ProcessWatcherRole = "client"      // cliente; só recebe com opt-in
+)
+
+// rank precedência do papel quando o mesmo destinatário aparece mais de uma vez
+func (r ProcessWatcherRole) rank() int {
+	switch r {
+	case ProcessWatcherRoleResponsible:
+		return 3
+	case ProcessWatcherRoleWatcher:
+		return 2
+	case ProcessWatcherRoleClient:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// defaultMovementAlertChannels ordem dos canais quando o destinatário não tem preferência
+var defaultMovementAlertChannels = []NotificationChannel{
+	NotificationChannelEmail,
+	NotificationChannelWhatsApp,
+	NotificationChannelTelegram,
+	NotificationChannelPush,
+	NotificationChannelSMS,
+}
+
+// ProcessWatcher destinatário dos alertas de movimentação de um processo. RecipientID é o ID do
+// usuário para advogados e equipe; para clientes, o identificador do cliente no tenant.
+type ProcessWatcher struct {
+	TenantID    uuid.UUID                      `json:"tenant_id"`
+	ProcessID   uuid.UUID                      `json:"process_id"`
+	RecipientID string                         `json:"recipient_id"`
+	Role        ProcessWatcherRole             `json:"role"`
+	Name        string                         `json:"name"`
+	Contacts    map[NotificationChannel]string `json:"contacts"`
+	OptedIn     bool                           `json:"opted_in"`
+	CreatedAt   time.Time                      `json:"created_at"`
+	UpdatedAt   time.Time                      `json:"updated_at"`
+}
+
+// NewProcessWatcher cria destinatário validando papel e contatos
+func NewProcessWatcher(
+	tenantID, processID uuid.UUID,
+	recipientID string,
+	role ProcessWatcherRole,
+	name string,
+	contacts map[NotificationChannel]string,
+	optedIn bool,
+) (*ProcessWatcher, error) {
+	recipientID = strings.TrimSpace(recipientID)
+	if recipientID == "" {
+		return nil, fmt.Errorf("%w: recipient_id é obrigatório", ErrProcessWatcherInvalid)
+	}
+
+	if role.rank() == 0 {
+		return nil, fmt.Errorf("%w: papel inválido: %s", ErrProcessWatcherInvalid, role)
+	}
+
+	normalized := make(map[NotificationChannel]string, len(contacts))
+	for channel, contact := range contacts {
+		if err := ValidateChannel(channel); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrProcessWatcherInvalid, err)
+		}
+		if contact = strings.TrimSpace(contact); contact != "" {
+			normalized[channel] = contact
+		}
+	}
+	if len(normalized) == 0 {
+		return nil, fmt.Errorf("%w: informe ao menos um contato", ErrProcessWatcherInvalid)
+	}
+
+	now := time.Now()
+	return &ProcessWatcher{
+		TenantID:    tenantID,
+		ProcessID:   processID,
+		RecipientID: recipientID,
+		Role:        role,
+		Name:        strings.TrimSpace(name),
+		Contacts:    normalized,
+		OptedIn:     optedIn,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// Notifiable indica se o destinatário recebe alertas: clientes apenas com opt-in
+func (w *ProcessWatcher) Notifiable() bool {
+	if w.Role == ProcessWatcherRoleClient {
+		return w.OptedIn
+	}
+	return true
+}
+
+// RecipientType tipo de destinatário da notificação
+func (w *ProcessWatcher) RecipientType() string {
+	if w.Role == ProcessWatcherRoleClient {
+		return "external"
+	}
+	return "user"
+}
+
+// UniqueProcessWatchers remove destinatários repetidos mantendo o papel de maior precedência
+// (responsável > equipe > cliente) e a ordem da primeira ocorrência
+func UniqueProcessWatchers(watchers []*ProcessWatcher) []*ProcessWatcher {
+	index := make(map[string]int, len(watchers))
+	unique := make([]*ProcessWatcher, 0, len(watchers))
+
+	for _, watcher := range watchers {
+		if i, exists := index[watcher.RecipientID]; exists {
+			if watcher.Role.rank() > unique[i].Role.rank() {
+				unique[i] = watcher
+			}
+			continue
+		}
+		index[watcher.RecipientID] = len(unique)
+		unique = append(unique, watcher)
+	}
+
+	return unique
+}
+
+// MovementAlertChannels canais do alerta para o destinatário, na ordem de preferência do usuário
+// (ou na ordem padrão). Os canais configurados no monitoramento do processo restringem a escolha
+// quando há interseção; apenas canais com contato cadastrado são mantidos.
+func MovementAlertChannels(preferred, processChannels []NotificationChannel, contacts map[NotificationChannel]string) []NotificationChannel {
+	if len(preferred) == 0 {
+		preferred = defaultMovementAlertChannels
+	}
+
+	available := make([]NotificationChannel, 0, len(preferred))
+	seen := make(map[NotificationChannel]bool, len(preferred))
+	for _, channel := range preferred {
+		if seen[channel] || contacts[channel] == "" {
+			continue
+		}
+		seen[channel] = true
+		available = append(available, channel)
+	}
+
+	allowed := make(map[NotificationChannel]bool, len(processChannels))
+	for _, channel := range processChannels {
+		allowed[channel] = true
+	}
+
+	restricted := make([]NotificationChannel, 0, len(available))
+	for _, channel := range available {
+		if allowed[channel] {
+			restricted = append(restricted, channel)
+		}
+	}
+	if len(restricted) > 0 {
+		return restricted
+	}
+
+	return available
+}
+
+// MovementEvent movimentação publicada pelo process-service (movement.created ou
+// movement.important.detected)
+type MovementEvent struct {
+	Type            string                `json:"type"`
+	TenantID        uuid.UUID             `json:"tenant_id"`
+	ProcessID       uuid.UUID             `json:"process_id"`
+	ProcessNumber   string                `json:"process_number"`
+	MovementID      string                `json:"movement_id"`
+	Title           string                `json:"title"`
+	MovementType    string                `json:"movement_type"`
+	Date            *time.Time            `json:"date,omitempty"`
+	Important       bool                  `json:"important"`
+	ImportanceLevel int                   `json:"importance_level"` // 1 a 5
+	Channels        []NotificationChannel `json:"channels,omitempty"`
+	Keywords        []string              `json:"keywords,omitempty"`
+	OccurredAt      time.Time             `json:"occurred_at"`
+}
+
+// Priority prioridade do alerta: movimentações de importância 4 ou 5 são críticas e percorrem a
+// cadeia de fallback; as demais importantes têm prioridade alta
+func (e *MovementEvent) Priority() NotificationPriority {
+	switch {
+	case e.ImportanceLevel >= 4:
+		return NotificationPriorityCritical
+	case e.Important:
+		return NotificationPriorityHigh
+	default:
+		return NotificationPriorityNormal
+	}
+}
+
+// Variables variáveis do template movement_alert para o destinatário
+func (e *MovementEvent) Variables(watcher *ProcessWatcher) map[string]interface{} {
+	variables := map[string]interface{}{
+		"nome":              watcher.Name,
+		"numero_processo":   e.ProcessNumber,
+		"titulo_movimento":  e.Title,
+		"tipo_movimento":    e.MovementType,
+		"importante":        e.Important,
+		"nivel_importancia": strconv.Itoa(e.ImportanceLevel),
+		"palavras_chave":    strings.Join(e.Keywords, ", "),
+	}
+
+	if e.Date != nil && !e.Date.IsZero() {
+		variables["data_movimento"] = *e.Date
+	} else {
+		variables["data_movimento"] = e.OccurredAt
+	}
+
+	return variables
+}
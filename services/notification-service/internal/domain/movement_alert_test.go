@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNewProcessWatcher(t *testing.T) {
+	tenantID, processID := uuid.New(), uuid.New()
+
+	watcher, err := NewProcessWatcher(tenantID, processID, " user-1 ", ProcessWatcherRoleResponsible, "Ana",
+		map[NotificationChannel]string{NotificationChannelEmail: " ana@example.com ", NotificationChannelSMS: ""}, false)
+	if err != nil {
+		t.Fatalf("NewProcessWatcher() error = %v", err)
+	}
+	if watcher.RecipientID != "user-1" {
+		t.Errorf("RecipientID = %q", watcher.RecipientID)
+	}
+	if want := map[NotificationChannel]string{NotificationChannelEmail: "ana@example.com"}; !reflect.DeepEqual(watcher.Contacts, want) {
+		t.Errorf("Contacts = %v, want %v", watcher.Contacts, want)
+	}
+
+	invalid := []struct {
+		name     string
+		role     ProcessWatcherRole
+		contacts map[NotificationChannel]string
+	}{
+		{"papel desconhecido", "owner", map[NotificationChannel]string{NotificationChannelEmail: "a@example.com"}},
+		{"canal desconhecido", ProcessWatcherRoleWatcher, map[NotificationChannel]string{"fax": "123"}},
+		{"sem contato", ProcessWatcherRoleWatcher, map[NotificationChannel]string{NotificationChannelEmail: " "}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewProcessWatcher(tenantID, processID, "user-1", tt.role, "", tt.contacts, false)
+			if !errors.Is(err, ErrProcessWatcherInvalid) {
+				t.Errorf("error = %v, want ErrProcessWatcherInvalid", err)
+			}
+		})
+	}
+}
+
+func TestProcessWatcherNotifiable(t *testing.T) {
+	client := &ProcessWatcher{Role: ProcessWatcherRoleClient}
+	if client.Notifiable() {
+		t.Error("client without opt-in should not be notifiable")
+	}
+	client.OptedIn = true
+	if !client.Notifiable() || client.RecipientType() != "external" {
+		t.Errorf("opted-in client: Notifiable() = %v, RecipientType() = %q", client.Notifiable(), client.RecipientType())
+	}
+
+	watcher := &ProcessWatcher{Role: ProcessWatcherRoleWatcher}
+	if !watcher.Notifiable() || watcher.RecipientType() != "user" {
+		t.Errorf("watcher: Notifiable() = %v, RecipientType() = %q", watcher.Notifiable(), watcher.RecipientType())
+	}
+}
+
+func TestUniqueProcessWatchers(t *testing.T) {
+	watchers := []*ProcessWatcher{
+		{RecipientID: "a", Role: ProcessWatcherRoleWatcher},
+		{RecipientID: "b", Role: ProcessWatcherRoleClient},
+		{RecipientID: "a", Role: ProcessWatcherRoleResponsible},
+		{RecipientID: "b", Role: ProcessWatcherRoleClient},
+		{RecipientID: "a", Role: ProcessWatcherRoleClient},
+	}
+
+	unique := UniqueProcessWatchers(watchers)
+	if len(unique) != 2 {
+		t.Fatalf("len = %d, want 2", len(unique))
+	}
+	if unique[0].RecipientID != "a" || unique[0].Role != ProcessWatcherRoleResponsible {
+		t.Errorf("unique[0] = %+v, want responsible a", unique[0])
+	}
+	if unique[1].RecipientID != "b" {
+		t.Errorf("unique[1] = %+v, want b", unique[1])
+	}
+}
+
+func TestMovementAlertChannels(t *testing.T) {
+	contacts := map[NotificationChannel]string{
+		NotificationChannelEmail:    "ana@example.com",
+		NotificationChannelWhatsApp: "5511999999999",
+		NotificationChannelTelegram: "12345",
+	}
+
+	tests := []struct {
+		name      string
+		preferred []NotificationChannel
+		process   []NotificationChannel
+		want      []NotificationChannel
+	}{
+		{
+			name: "ordem padrão",
+			want: []NotificationChannel{NotificationChannelEmail, NotificationChannelWhatsApp, NotificationChannelTelegram},
+		},
+		{
+			name:      "preferência do usuário sem contato de sms",
+			preferred: []NotificationChannel{NotificationChannelTelegram, NotificationChannelSMS, NotificationChannelEmail},
+			want:      []NotificationChannel{NotificationChannelTelegram, NotificationChannelEmail},
+		},
+		{
+			name:    "restrito aos canais do processo",
+			process: []NotificationChannel{NotificationChannelWhatsApp, NotificationChannelSMS},
+			want:    []NotificationChannel{NotificationChannelWhatsApp},
+		},
+		{
+			name:    "canais do processo sem interseção",
+			process: []NotificationChannel{NotificationChannelSMS},
+			want:    []NotificationChannel{NotificationChannelEmail, NotificationChannelWhatsApp, NotificationChannelTelegram},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MovementAlertChannels(tt.preferred, tt.process, contacts)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MovementAlertChannels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMovementEventPriority(t *testing.T) {
+	tests := []struct {
+		event MovementEvent
+		want  NotificationPriority
+	}{
+		{MovementEvent{}, NotificationPriorityNormal},
+		{MovementEvent{Important: true, ImportanceLevel: 3}, NotificationPriorityHigh},
+		{MovementEvent{Important: true, ImportanceLevel: 4}, NotificationPriorityCritical},
+	}
+
+	for _, tt := range tests {
+		if got := tt.event.Priority(); got != tt.want {
+			t.Errorf("Priority(%+v) = %s, want %s", tt.event, got, tt.want)
+		}
+	}
+}
@@ -20,6 +20,7 @@ const (
 	NotificationTypeSystemAlert       NotificationType = "system_alert"
 	NotificationTypeWelcome          NotificationType = "welcome"
 	NotificationTypePasswordReset    NotificationType = "password_reset"
+	NotificationTypeDigest           NotificationType = "digest" // resumo de notificações agrupadas
 )
 
 // NotificationChannel canal de notificação
@@ -42,9 +43,12 @@ const (
 	NotificationStatusProcessing NotificationStatus = "processing"
 	NotificationStatusSent       NotificationStatus = "sent"
 	NotificationStatusDelivered  NotificationStatus = "delivered"
+	NotificationStatusRead       NotificationStatus = "read"
 	NotificationStatusFailed     NotificationStatus = "failed"
 	NotificationStatusExpired    NotificationStatus = "expired"
 	NotificationStatusCancelled  NotificationStatus = "cancelled"
+	NotificationStatusBatched    NotificationStatus = "batched"  // aguardando o próximo resumo
+	NotificationStatusDigested   NotificationStatus = "digested" // incluída em um resumo
 )
 
 // NotificationPriority prioridade da notificação
@@ -74,16 +78,29 @@ type Notification struct {
 	UserID           *uuid.UUID           `json:"user_id,omitempty" db:"user_id"`
 	ProcessID        *uuid.UUID           `json:"process_id,omitempty" db:"process_id"`
 	TemplateID       *uuid.UUID           `json:"template_id,omitempty" db:"template_id"`
+	TemplateVersionID *uuid.UUID          `json:"template_version_id,omitempty" db:"template_version_id"` // versão do template renderizada
+	TemplateVariant  string               `json:"template_variant,omitempty" db:"template_variant"`       // variante A/B sorteada
+	Locale           string               `json:"locale,omitempty" db:"locale"`                           // idioma do conteúdo renderizado
+	FallbackRootID   *uuid.UUID           `json:"fallback_root_id,omitempty" db:"fallback_root_id"` // notificação lógica da cadeia de fallback (a raiz aponta para si mesma)
+	FallbackStep     int                  `json:"fallback_step,omitempty" db:"fallback_step"`       // posição na cadeia de fallback (0 = raiz)
+	DigestAt         *time.Time           `json:"digest_at,omitempty" db:"digest_at"`               // envio previsto do resumo que agrupa a notificação
+	DigestID         *uuid.UUID           `json:"digest_id,omitempty" db:"digest_id"`               // notificação de resumo em que foi incluída
 	Variables        map[string]interface{} `json:"variables,omitempty" db:"variables"`
 	Metadata         map[string]interface{} `json:"metadata,omitempty" db:"metadata"`
+	Attachments      []NotificationAttachment `json:"attachments,omitempty" db:"attachments"` // anexos do email
 	RetryCount       int                  `json:"retry_count" db:"retry_count"`
 	MaxRetries       int                  `json:"max_retries" db:"max_retries"`
 	NextRetryAt      *time.Time           `json:"next_retry_at,omitempty" db:"next_retry_at"`
 	ScheduledAt      *time.Time           `json:"scheduled_at,omitempty" db:"scheduled_at"`
 	SentAt           *time.Time           `json:"sent_at,omitempty" db:"sent_at"`
 	DeliveredAt      *time.Time           `json:"delivered_at,omitempty" db:"delivered_at"`
+	ReadAt           *time.Time           `json:"read_at,omitempty" db:"read_at"`
+	OpenedAt         *time.Time           `json:"opened_at,omitempty" db:"opened_at"`   // primeira abertura rastreada
+	ClickedAt        *time.Time           `json:"clicked_at,omitempty" db:"clicked_at"` // primeiro clique rastreado
 	FailedAt         *time.Time           `json:"failed_at,omitempty" db:"failed_at"`
 	ErrorMessage     *string              `json:"error_message,omitempty" db:"error_message"`
+	FailureCategory  FailureCategory      `json:"failure_category,omitempty" db:"failure_category"` // categoria normalizada da falha
+	Cost             float64              `json:"cost,omitempty" db:"cost"`                         // custo do envio (moeda configurada)
 	ExternalID       *string              `json:"external_id,omitempty" db:"external_id"`
 	ExternalStatus   *string              `json:"external_status,omitempty" db:"external_status"`
 	ProcessingStartedAt  *time.Time       `json:"processing_started_at,omitempty" db:"processing_started_at"`
@@ -199,6 +216,7 @@ func (n *Notification) MarkAsFailed(errorMessage string) error {
 	n.Status = NotificationStatusFailed
 	n.FailedAt = &now
 	n.ErrorMessage = &errorMessage
+	n.FailureCategory = ClassifyFailure(n.Channel, "", errorMessage)
 	n.RetryCount++
 	n.UpdatedAt = now
 	
@@ -219,6 +237,7 @@ func (n *Notification) ResetForRetry() error {
 	n.Status = NotificationStatusPending
 	n.FailedAt = nil
 	n.ErrorMessage = nil
+	n.FailureCategory = ""
 	n.UpdatedAt = time.Now()
 	
 	return nil
@@ -226,7 +245,7 @@ func (n *Notification) ResetForRetry() error {
 
 // Cancel cancela notificação
 func (n *Notification) Cancel() error {
-	if n.Status == NotificationStatusSent {
+	if n.Status == NotificationStatusSent || n.Status == NotificationStatusDelivered || n.Status == NotificationStatusRead {
 		return errors.New("não é possível cancelar notificação já enviada")
 	}
 
@@ -302,7 +321,8 @@ func ValidateNotificationType(notificationType NotificationType) error {
 		 NotificationTypeSubscriptionDue,
 		 NotificationTypeSystemAlert,
 		 NotificationTypeWelcome,
-		 NotificationTypePasswordReset:
+		 NotificationTypePasswordReset,
+		 NotificationTypeDigest:
 		return nil
 	default:
 		return fmt.Errorf("tipo de notificação inválido: %s", notificationType)
@@ -334,4 +354,20 @@ func ValidatePriority(priority NotificationPriority) error {
 	default:
 		return fmt.Errorf("prioridade inválida: %s", priority)
 	}
-}
\ No newline at end of file
+}
+
+// Rank retorna o peso da prioridade na fila (maior é processado primeiro)
+func (p NotificationPriority) Rank() int {
+	switch p {
+	case NotificationPriorityCritical:
+		return 4
+	case NotificationPriorityHigh:
+		return 3
+	case NotificationPriorityNormal:
+		return 2
+	case NotificationPriorityLow:
+		return 1
+	default:
+		return 0
+	}
+}
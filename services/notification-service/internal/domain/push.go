@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tamanhos das chaves da inscrição Web Push (RFC 8291)
+const (
+	pushP256dhKeyLength = 65 // ponto P-256 não comprimido
+	pushAuthKeyLength   = 16
+)
+
+// PushSubscription inscrição Web Push de um dispositivo (navegador) do usuário
+type PushSubscription struct {
+	ID         uuid.UUID  `json:"id"`
+	TenantID   uuid.UUID  `json:"tenant_id"`
+	UserID     uuid.UUID  `json:"user_id"`
+	Endpoint   string     `json:"endpoint"`
+	P256dh     string     `json:"p256dh"` // chave pública do navegador (base64url)
+	Auth       string     `json:"auth"`   // segredo de autenticação (base64url)
+	DeviceName string     `json:"device_name,omitempty"`
+	UserAgent  string     `json:"user_agent,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// NewPushSubscription cria e valida a inscrição enviada pelo navegador (PushSubscription.toJSON)
+func NewPushSubscription(tenantID, userID uuid.UUID, endpoint, p256dh, auth string) (*PushSubscription, error) {
+	now := time.Now()
+	subscription := &PushSubscription{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		UserID:    userID,
+		Endpoint:  strings.TrimSpace(endpoint),
+		P256dh:    strings.TrimSpace(p256dh),
+		Auth:      strings.TrimSpace(auth),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := subscription.Validate(); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// Validate valida endpoint HTTPS e chaves da inscrição
+func (s *PushSubscription) Validate() error {
+	endpoint, err := url.Parse(s.Endpoint)
+	if err != nil || endpoint.Scheme != "https" || endpoint.Host == "" {
+		return fmt.Errorf("endpoint de push inválido: %s", s.Endpoint)
+	}
+
+	if _, err := s.PublicKey(); err != nil {
+		return err
+	}
+	if _, err := s.AuthSecret(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// PublicKey chave pública P-256 do navegador
+func (s *PushSubscription) PublicKey() ([]byte, error) {
+	key, err := decodePushKey(s.P256dh)
+	if err != nil || len(key) != pushP256dhKeyLength || key[0] != 0x04 {
+		return nil, fmt.Errorf("chave p256dh inválida")
+	}
+	return key, nil
+}
+
+// AuthSecret segredo de autenticação do navegador
+func (s *PushSubscription) AuthSecret() ([]byte, error) {
+	secret, err := decodePushKey(s.Auth)
+	if err != nil || len(secret) != pushAuthKeyLength {
+		return nil, fmt.Errorf("segredo auth inválido")
+	}
+	return secret, nil
+}
+
+// decodePushKey decodifica base64url com ou sem padding (navegadores variam)
+func decodePushKey(value string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+}
@@ -23,12 +23,16 @@ type NotificationRepository interface {
 	FindScheduled(ctx context.Context, scheduledBefore time.Time, limit int) ([]*Notification, error)
 	FindExpired(ctx context.Context, limit int) ([]*Notification, error)
 	FindForRetry(ctx context.Context, limit int) ([]*Notification, error)
+	FindByExternalID(ctx context.Context, channel NotificationChannel, externalID string) (*Notification, error)
+	FindFallbackAttempts(ctx context.Context, rootID uuid.UUID) ([]*Notification, error)
+	FindDueDigestItems(ctx context.Context, dueBefore time.Time, limit int) ([]*Notification, error)
 
 	// Estatísticas
 	CountByTenant(ctx context.Context, tenantID uuid.UUID, filters NotificationFilters) (int64, error)
 	CountByStatus(ctx context.Context, tenantID uuid.UUID, status NotificationStatus) (int64, error)
 	CountByChannel(ctx context.Context, tenantID uuid.UUID, channel NotificationChannel) (int64, error)
 	GetStatistics(ctx context.Context, tenantID uuid.UUID, period time.Duration) (*NotificationStatistics, error)
+	GetAnalytics(ctx context.Context, filters AnalyticsFilters) ([]*AnalyticsGroup, error) // um grupo por valor de filters.GroupBy
 
 	// Operações em lote
 	CreateBatch(ctx context.Context, notifications []*Notification) error
@@ -58,6 +62,23 @@ type NotificationTemplateRepository interface {
 	CountByTenant(ctx context.Context, tenantID uuid.UUID) (int64, error)
 }
 
+// TemplateVersionRepository interface para versões de templates
+type TemplateVersionRepository interface {
+	// Create grava a versão atribuindo o próximo número sequencial do template
+	Create(ctx context.Context, version *TemplateVersion) error
+	GetByID(ctx context.Context, id uuid.UUID) (*TemplateVersion, error)
+	FindByTemplate(ctx context.Context, templateID uuid.UUID) ([]*TemplateVersion, error)
+	FindPublished(ctx context.Context, templateID uuid.UUID) ([]*TemplateVersion, error)
+
+	// Publish publica a versão e arquiva a publicada do mesmo idioma e variante
+	Publish(ctx context.Context, version *TemplateVersion) error
+	Archive(ctx context.Context, id uuid.UUID) error
+	UpdateWeight(ctx context.Context, id uuid.UUID, weight int) error
+
+	// GetVariantStats métricas das notificações enviadas com cada versão desde since
+	GetVariantStats(ctx context.Context, templateID uuid.UUID, since time.Time) ([]*TemplateVariantStats, error)
+}
+
 // NotificationFilters filtros para busca de notificações
 type NotificationFilters struct {
 	Type      *NotificationType     `json:"type,omitempty"`
@@ -70,6 +91,7 @@ type NotificationFilters struct {
 	CreatedBefore *time.Time       `json:"created_before,omitempty"`
 	SentAfter     *time.Time       `json:"sent_after,omitempty"`
 	SentBefore    *time.Time       `json:"sent_before,omitempty"`
+	IncludeAttempts bool           `json:"include_attempts,omitempty"` // incluir tentativas de fallback (filhas)
 	Limit         int              `json:"limit,omitempty"`
 	Offset        int              `json:"offset,omitempty"`
 	OrderBy       string           `json:"order_by,omitempty"`
@@ -96,6 +118,8 @@ type NotificationStatistics struct {
 	TotalSent    int64     `json:"total_sent"`
 	TotalFailed  int64     `json:"total_failed"`
 	TotalPending int64     `json:"total_pending"`
+	TotalDelivered int64   `json:"total_delivered"` // confirmadas pelo provedor (inclui lidas)
+	TotalRead      int64   `json:"total_read"`
 	
 	// Por canal
 	WhatsAppSent int64 `json:"whatsapp_sent"`
@@ -111,7 +135,8 @@ type NotificationStatistics struct {
 	SystemAlerts       int64 `json:"system_alerts"`
 	
 	// Métricas de qualidade
-	DeliveryRate    float64 `json:"delivery_rate"`    // Taxa de entrega (%)
+	DeliveryRate    float64 `json:"delivery_rate"`    // Taxa de entrega confirmada sobre as enviadas (%)
+	ReadRate        float64 `json:"read_rate"`        // Taxa de leitura sobre as entregues (%)
 	ErrorRate       float64 `json:"error_rate"`       // Taxa de erro (%)
 	RetryRate       float64 `json:"retry_rate"`       // Taxa de reenvio (%)
 	AverageRetries  float64 `json:"average_retries"`  // Média de tentativas
@@ -135,6 +160,7 @@ type NotificationPreference struct {
 	Type      NotificationType                           `json:"type" db:"type"`
 	Channels  []NotificationChannel                      `json:"channels" db:"channels"`
 	Enabled   bool                                       `json:"enabled" db:"enabled"`
+	DigestFrequency DigestFrequency                      `json:"digest_frequency" db:"digest_frequency"`
 	CreatedAt time.Time                                  `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time                                  `json:"updated_at" db:"updated_at"`
 }
@@ -156,6 +182,145 @@ type NotificationPreferenceRepository interface {
 	GetUserPreferences(ctx context.Context, userID uuid.UUID) (map[NotificationType][]NotificationChannel, error)
 }
 
+// DeliverySettingsRepository interface para janelas de entrega (horário de silêncio, horário comercial, resumos)
+type DeliverySettingsRepository interface {
+	FindByUser(ctx context.Context, tenantID, userID uuid.UUID) (*DeliverySettings, error)
+	FindTenantDefault(ctx context.Context, tenantID uuid.UUID) (*DeliverySettings, error)
+	Upsert(ctx context.Context, settings *DeliverySettings) error
+}
+
+// DeliveryHistoryRepository interface para o histórico de entrega reportado pelos provedores
+type DeliveryHistoryRepository interface {
+	Record(ctx context.Context, record *DeliveryRecord) error
+	FindByNotificationID(ctx context.Context, notificationID uuid.UUID) ([]*DeliveryRecord, error)
+}
+
+// SuppressionRepository interface para a lista de contatos suprimidos
+type SuppressionRepository interface {
+	// Suppress registra a supressão; contato já suprimido no canal é ignorado
+	Suppress(ctx context.Context, suppression *SuppressedContact) error
+	IsSuppressed(ctx context.Context, channel NotificationChannel, contact string) (bool, error)
+	Remove(ctx context.Context, channel NotificationChannel, contact string) error
+}
+
+// ConsentRepository interface para o histórico de consentimento (LGPD). Os registros são
+// imutáveis: concessões e revogações são sempre novos registros.
+type ConsentRepository interface {
+	Record(ctx context.Context, record *ConsentRecord) error
+	// FindByContact lista, em ordem cronológica, os registros do tenant e os registros sem tenant
+	// (SAIR/VOLTAR) do contato no canal
+	FindByContact(ctx context.Context, tenantID uuid.UUID, channel NotificationChannel, contact string) ([]*ConsentRecord, error)
+	// Export lista os registros do tenant e os registros sem tenant dos contatos do tenant
+	Export(ctx context.Context, tenantID uuid.UUID, filter ConsentExportFilter) ([]*ConsentRecord, error)
+}
+
+// EmailSenderDomainRepository interface para os remetentes com domínio próprio dos tenants
+type EmailSenderDomainRepository interface {
+	// Save cria ou substitui o remetente do tenant
+	Save(ctx context.Context, senderDomain *EmailSenderDomain) error
+	GetByTenant(ctx context.Context, tenantID uuid.UUID) (*EmailSenderDomain, error)
+	Delete(ctx context.Context, tenantID uuid.UUID) error
+}
+
+// PushSubscriptionRepository interface para inscrições Web Push
+type PushSubscriptionRepository interface {
+	// Upsert cria a inscrição ou atualiza as chaves do endpoint já inscrito
+	Upsert(ctx context.Context, subscription *PushSubscription) error
+	FindByUser(ctx context.Context, tenantID, userID uuid.UUID) ([]*PushSubscription, error)
+	Delete(ctx context.Context, tenantID, userID, id uuid.UUID) error
+	// DeleteByEndpoint remove inscrição expirada (404/410 do serviço de push)
+	DeleteByEndpoint(ctx context.Context, endpoint string) error
+	MarkUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error
+}
+
+// WhatsAppSessionRepository interface para a janela de atendimento do WhatsApp
+type WhatsAppSessionRepository interface {
+	// RecordInbound registra mensagem recebida do contato, mantendo a mais recente
+	RecordInbound(ctx context.Context, contact string, at time.Time) error
+	// GetLastInbound retorna nil quando o contato nunca enviou mensagem
+	GetLastInbound(ctx context.Context, contact string) (*time.Time, error)
+}
+
+// WhatsAppTemplateRepository interface para o mapeamento de templates aprovados do WhatsApp
+type WhatsAppTemplateRepository interface {
+	// Upsert cria o mapeamento ou substitui o existente no mesmo template e idioma
+	Upsert(ctx context.Context, mapping *WhatsAppTemplateMapping) error
+	FindByTemplate(ctx context.Context, templateID uuid.UUID) ([]*WhatsAppTemplateMapping, error)
+	FindAll(ctx context.Context) ([]*WhatsAppTemplateMapping, error)
+	// UpdateStatusByName atualiza o status de todos os mapeamentos do template registrado
+	UpdateStatusByName(ctx context.Context, name, language string, status WhatsAppTemplateStatus, reason *string, syncedAt time.Time) (int64, error)
+	Delete(ctx context.Context, templateID, id uuid.UUID) error
+}
+
+// WhatsAppTemplateCatalog interface para consulta dos templates registrados na conta WhatsApp Business
+type WhatsAppTemplateCatalog interface {
+	ListTemplates(ctx context.Context) ([]WhatsAppRegisteredTemplate, error)
+}
+
+// TelegramLinkRepository interface para vinculação de chats do Telegram aos usuários
+type TelegramLinkRepository interface {
+	CreateCode(ctx context.Context, code *TelegramLinkCode) error
+	// ConsumeCode marca o código como usado; ErrTelegramLinkCodeInvalid se inexistente, usado ou expirado
+	ConsumeCode(ctx context.Context, code string, now time.Time) (*TelegramLinkCode, error)
+	// Link vincula o chat, substituindo vínculo anterior do mesmo chat
+	Link(ctx context.Context, link *TelegramLink) error
+	// GetByChat retorna ErrTelegramChatNotLinked quando o chat não está vinculado
+	GetByChat(ctx context.Context, chatID string) (*TelegramLink, error)
+	FindByUser(ctx context.Context, tenantID, userID uuid.UUID) ([]*TelegramLink, error)
+	Unlink(ctx context.Context, tenantID, userID uuid.UUID, chatID string) error
+}
+
+// WhatsAppLinkRepository interface para vinculação de contatos do WhatsApp aos usuários. Os
+// códigos de vinculação são os mesmos do bot do Telegram (TelegramLinkRepository).
+type WhatsAppLinkRepository interface {
+	// Link vincula o contato, substituindo vínculo anterior do mesmo contato
+	Link(ctx context.Context, link *WhatsAppLink) error
+	// GetByContact retorna ErrWhatsAppContactNotLinked quando o contato não está vinculado
+	GetByContact(ctx context.Context, contact string) (*WhatsAppLink, error)
+	Unlink(ctx context.Context, contact string) error
+}
+
+// ProcessWatcherRepository interface para destinatários dos alertas de movimentação dos processos
+type ProcessWatcherRepository interface {
+	// Upsert cria ou substitui o destinatário do processo
+	Upsert(ctx context.Context, watcher *ProcessWatcher) error
+	FindByProcess(ctx context.Context, tenantID, processID uuid.UUID) ([]*ProcessWatcher, error)
+	// Delete retorna ErrProcessWatcherNotFound quando o destinatário não existe
+	Delete(ctx context.Context, tenantID, processID uuid.UUID, recipientID string) error
+}
+
+// MovementAlertRepository interface para deduplicação dos alertas de movimentação: cada
+// destinatário recebe um alerta por movimentação, e um segundo apenas quando a movimentação
+// alertada como comum é depois detectada como importante
+type MovementAlertRepository interface {
+	// Claim reserva o alerta; false quando o destinatário já foi alertado
+	Claim(ctx context.Context, movementID, recipientID string, important bool, at time.Time) (bool, error)
+	// Release desfaz a reserva quando o alerta não pôde ser criado
+	Release(ctx context.Context, movementID, recipientID string, important bool) error
+}
+
+// WebhookSubscriptionRepository interface para as inscrições de webhook dos tenants
+type WebhookSubscriptionRepository interface {
+	Create(ctx context.Context, subscription *WebhookSubscription) error
+	Update(ctx context.Context, subscription *WebhookSubscription) error
+	Delete(ctx context.Context, tenantID, id uuid.UUID) error
+	FindByID(ctx context.Context, tenantID, id uuid.UUID) (*WebhookSubscription, error)
+	ListByTenant(ctx context.Context, tenantID uuid.UUID) ([]*WebhookSubscription, error)
+	CountActive(ctx context.Context, tenantID uuid.UUID) (int, error)
+}
+
+// WebhookDeliveryRepository interface para o histórico e a fila de entregas dos webhooks
+type WebhookDeliveryRepository interface {
+	// Create grava a entrega; false quando o evento já foi registrado para a inscrição
+	Create(ctx context.Context, delivery *WebhookDelivery) (bool, error)
+	Update(ctx context.Context, delivery *WebhookDelivery) error
+	FindByID(ctx context.Context, tenantID, id uuid.UUID) (*WebhookDelivery, error)
+	ListBySubscription(ctx context.Context, tenantID, subscriptionID uuid.UUID, filter WebhookDeliveryFilter) ([]*WebhookDelivery, error)
+	// ClaimDue reserva as entregas pendentes vencidas até now, invisíveis pelo lease para as
+	// demais instâncias
+	ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*WebhookDelivery, error)
+}
+
 // NotificationQueue interface para fila de notificações
 type NotificationQueue interface {
 	// Enfileirar
@@ -168,6 +333,10 @@ type NotificationQueue interface {
 	DequeueBatch(ctx context.Context, channel NotificationChannel, limit int) ([]*Notification, error)
 	DequeueByPriority(ctx context.Context, channel NotificationChannel) (*Notification, error)
 
+	// Confirmação: itens desenfileirados ficam invisíveis até Ack, Nack ou o fim do visibility timeout
+	Ack(ctx context.Context, notificationID uuid.UUID) error
+	Nack(ctx context.Context, notificationID uuid.UUID, retryAt time.Time) error
+
 	// Status da fila
 	QueueSize(ctx context.Context, channel NotificationChannel) (int64, error)
 	QueueSizeByPriority(ctx context.Context, channel NotificationChannel, priority NotificationPriority) (int64, error)
@@ -199,4 +368,5 @@ type NotificationProvider interface {
 	SupportsTemplates() bool
 	GetMaxContentLength() int
 	GetRateLimit() int // mensagens por minuto
+	ConfirmsDeliveryOnSend() bool // envio aceito já é a confirmação de entrega (sem recibo via webhook)
 }
\ No newline at end of file
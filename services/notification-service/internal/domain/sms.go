@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// smsOptOutKeywords palavras-chave de descadastro aceitas em resposta a um SMS
+var smsOptOutKeywords = map[string]bool{
+	"SAIR":         true,
+	"PARAR":        true,
+	"CANCELAR":     true,
+	"DESCADASTRAR": true,
+	"STOP":         true,
+}
+
+// smsOptInKeywords palavras-chave que voltam a habilitar o recebimento de SMS
+var smsOptInKeywords = map[string]bool{
+	"VOLTAR": true,
+	"START":  true,
+}
+
+// SMSKeyword comando reconhecido em uma resposta por SMS
+type SMSKeyword string
+
+const (
+	SMSKeywordNone   SMSKeyword = ""
+	SMSKeywordOptOut SMSKeyword = "opt_out"
+	SMSKeywordOptIn  SMSKeyword = "opt_in"
+)
+
+// ParseSMSKeyword identifica descadastro (SAIR) ou recadastro (VOLTAR) na resposta do usuário.
+// A mensagem deve conter apenas a palavra-chave, sem diferenciar maiúsculas nem pontuação.
+func ParseSMSKeyword(body string) SMSKeyword {
+	word := strings.ToUpper(strings.Trim(strings.TrimSpace(body), ".!?,;: "))
+	switch {
+	case smsOptOutKeywords[word]:
+		return SMSKeywordOptOut
+	case smsOptInKeywords[word]:
+		return SMSKeywordOptIn
+	default:
+		return SMSKeywordNone
+	}
+}
+
+// NormalizeBrazilianPhone normaliza um celular para E.164 (+55DDNNNNNNNNN). Aceita os formatos
+// usuais com ou sem +55, com zero de longa distância e código de operadora, e acrescenta o nono
+// dígito em celulares antigos de 8 dígitos. Números internacionais com + são mantidos; telefones
+// fixos são rejeitados por não receberem SMS.
+func NormalizeBrazilianPhone(phone string) (string, error) {
+	var digits strings.Builder
+	international := false
+	for i, r := range strings.TrimSpace(phone) {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '+' && i == 0:
+			international = true
+		case r == ' ' || r == '-' || r == '(' || r == ')' || r == '.':
+		default:
+			return "", fmt.Errorf("%w: caractere inválido em %q", ErrInvalidPhoneNumber, phone)
+		}
+	}
+
+	number := digits.String()
+	switch {
+	case international && !strings.HasPrefix(number, "55"):
+		if len(number) < 8 || len(number) > 15 {
+			return "", fmt.Errorf("%w: %q", ErrInvalidPhoneNumber, phone)
+		}
+		return "+" + number, nil
+	case international:
+		number = number[2:]
+	case strings.HasPrefix(number, "0"):
+		// 0 + código da operadora + DDD + número, ou apenas 0 + DDD + número
+		number = number[1:]
+		if len(number) == 12 || len(number) == 13 {
+			number = number[2:]
+		}
+	case strings.HasPrefix(number, "55") && (len(number) == 12 || len(number) == 13):
+		number = number[2:]
+	}
+
+	if len(number) != 10 && len(number) != 11 {
+		return "", fmt.Errorf("%w: %q", ErrInvalidPhoneNumber, phone)
+	}
+	if number[0] == '0' || number[1] == '0' {
+		return "", fmt.Errorf("%w: DDD inválido em %q", ErrInvalidPhoneNumber, phone)
+	}
+
+	ddd, subscriber := number[:2], number[2:]
+	switch {
+	case len(subscriber) == 9 && subscriber[0] == '9':
+	case len(subscriber) == 8 && subscriber[0] >= '6':
+		subscriber = "9" + subscriber
+	case len(subscriber) == 8:
+		return "", fmt.Errorf("%w: telefone fixo não recebe SMS: %q", ErrInvalidPhoneNumber, phone)
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidPhoneNumber, phone)
+	}
+
+	return "+55" + ddd + subscriber, nil
+}
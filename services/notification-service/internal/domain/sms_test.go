@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizeBrazilianPhone(t *testing.T) {
+	tests := []struct {
+		phone   string
+		want    string
+		wantErr bool
+	}{
+		{"+55 11 98765-4321", "+5511987654321", false},
+		{"(11) 98765-4321", "+5511987654321", false},
+		{"5511987654321", "+5511987654321", false},
+		{"011987654321", "+5511987654321", false},
+		{"0 21 11 98765-4321", "+5511987654321", false}, // código da operadora
+		{"(55) 99123-4567", "+5555991234567", false},    // DDD 55
+		{"(11) 8765-4321", "+5511987654321", false},     // celular antigo sem nono dígito
+		{"+1 415 555 0100", "+14155550100", false},
+		{"(11) 3456-7890", "", true}, // fixo
+		{"(10) 98765-4321", "", true},
+		{"98765-4321", "", true},
+		{"11 98765-4321 ramal 2", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizeBrazilianPhone(tt.phone)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidPhoneNumber) {
+				t.Errorf("NormalizeBrazilianPhone(%q) error = %v, want ErrInvalidPhoneNumber", tt.phone, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("NormalizeBrazilianPhone(%q) = %q, %v; want %q", tt.phone, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseSMSKeyword(t *testing.T) {
+	tests := map[string]SMSKeyword{
+		"SAIR":       SMSKeywordOptOut,
+		" sair. ":    SMSKeywordOptOut,
+		"Stop":       SMSKeywordOptOut,
+		"voltar":     SMSKeywordOptIn,
+		"quero sair": SMSKeywordNone,
+		"Obrigado!":  SMSKeywordNone,
+	}
+
+	for body, want := range tests {
+		if got := ParseSMSKeyword(body); got != want {
+			t.Errorf("ParseSMSKeyword(%q) = %q, want %q", body, got, want)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultTelegramLinkCodeTTL validade do código de vinculação gerado no web app
+	DefaultTelegramLinkCodeTTL = 10 * time.Minute
+
+	telegramLinkCodeLength = 8
+	// Sem 0/O e 1/I/L, que se confundem ao digitar
+	telegramLinkCodeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+)
+
+// TelegramLinkCode código de uso único que vincula um chat do Telegram ao usuário do web app
+type TelegramLinkCode struct {
+	Code      string     `json:"code"`
+	TenantID  uuid.UUID  `json:"tenant_id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// NewTelegramLinkCode gera código aleatório para o usuário
+func NewTelegramLinkCode(tenantID, userID uuid.UUID, ttl time.Duration, now time.Time) (*TelegramLinkCode, error) {
+	if ttl <= 0 {
+		ttl = DefaultTelegramLinkCodeTTL
+	}
+
+	var code strings.Builder
+	max := big.NewInt(int64(len(telegramLinkCodeAlphabet)))
+	for i := 0; i < telegramLinkCodeLength; i++ {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate link code: %w", err)
+		}
+		code.WriteByte(telegramLinkCodeAlphabet[n.Int64()])
+	}
+
+	return &TelegramLinkCode{
+		Code:      code.String(),
+		TenantID:  tenantID,
+		UserID:    userID,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}, nil
+}
+
+// NormalizeTelegramLinkCode aceita o código digitado em minúsculas, com espaços ou hífens
+func NormalizeTelegramLinkCode(code string) string {
+	var normalized strings.Builder
+	for _, r := range strings.ToUpper(code) {
+		if r != ' ' && r != '-' {
+			normalized.WriteRune(r)
+		}
+	}
+	return normalized.String()
+}
+
+// TelegramLink vínculo entre um chat do Telegram e o usuário do tenant
+type TelegramLink struct {
+	ChatID   string    `json:"chat_id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+	UserID   uuid.UUID `json:"user_id"`
+	Username string    `json:"username,omitempty"`
+	LinkedAt time.Time `json:"linked_at"`
+}
+
+// TelegramButton botão do teclado inline: callback para o próprio bot ou link externo
+type TelegramButton struct {
+	Text         string `json:"text"`
+	CallbackData string `json:"callback_data,omitempty"` // até 64 bytes
+	URL          string `json:"url,omitempty"`
+}
+
+// TelegramBotReply resposta do bot em HTML, com teclado inline opcional
+type TelegramBotReply struct {
+	Text     string
+	Keyboard [][]TelegramButton
+}
+
+// TelegramBotClient interface para responder as conversas do bot
+type TelegramBotClient interface {
+	SendReply(ctx context.Context, chatID string, reply *TelegramBotReply) error
+	// AnswerCallback encerra o indicador de carregamento do botão, com aviso opcional
+	AnswerCallback(ctx context.Context, callbackID, text string) error
+}
+
+// ProcessStats resumo dos processos do tenant
+type ProcessStats struct {
+	Total           int `json:"total"`
+	Active          int `json:"active"`
+	Suspended       int `json:"suspended"`
+	Concluded       int `json:"concluded"`
+	Archived        int `json:"archived"`
+	ThisMonth       int `json:"this_month"`
+	ThisWeek        int `json:"this_week"`
+	RecentlyUpdated int `json:"recently_updated"`
+}
+
+// ProcessDeadline prazo identificado em uma movimentação
+type ProcessDeadline struct {
+	ProcessID     string    `json:"process_id"`
+	ProcessNumber string    `json:"process_number"`
+	ProcessTitle  string    `json:"process_title"`
+	Title         string    `json:"title"`
+	DeadlineDate  time.Time `json:"deadline_date"`
+}
+
+// ProcessSummary dados do processo com a última movimentação
+type ProcessSummary struct {
+	ID                string     `json:"id"`
+	Number            string     `json:"number"`
+	Title             string     `json:"title"`
+	Status            string     `json:"status"`
+	CourtID           string     `json:"court_id"`
+	LastMovementAt    *time.Time `json:"last_movement_at,omitempty"`
+	LastMovementTitle *string    `json:"last_movement_title,omitempty"`
+	LastMovementDate  *time.Time `json:"last_movement_date,omitempty"`
+}
+
+// ProcessDirectory interface para consulta dos processos do tenant (process-service)
+type ProcessDirectory interface {
+	GetStats(ctx context.Context, tenantID uuid.UUID) (*ProcessStats, error)
+	ListUpcomingDeadlines(ctx context.Context, tenantID uuid.UUID, days int) ([]*ProcessDeadline, error)
+	// FindByNumber busca pelo número CNJ formatado; ErrProcessNotFound quando não é do tenant
+	FindByNumber(ctx context.Context, tenantID uuid.UUID, number string) (*ProcessSummary, error)
+}
+
+// ParseCNJNumber reconhece texto que é apenas um número CNJ, com ou sem pontuação, e o retorna
+// formatado (NNNNNNN-DD.AAAA.J.TR.OOOO)
+func ParseCNJNumber(text string) (string, bool) {
+	var digits strings.Builder
+	for _, r := range strings.TrimSpace(text) {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '-' || r == '.' || r == ' ':
+		default:
+			return "", false
+		}
+	}
+
+	d := digits.String()
+	if len(d) != 20 {
+		return "", false
+	}
+	return formatCNJDigits(d), true
+}
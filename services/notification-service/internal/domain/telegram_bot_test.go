@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewTelegramLinkCode(t *testing.T) {
+	now := time.Now()
+
+	code, err := NewTelegramLinkCode(uuid.New(), uuid.New(), 0, now)
+	if err != nil {
+		t.Fatalf("NewTelegramLinkCode() error = %v", err)
+	}
+	if len(code.Code) != telegramLinkCodeLength || strings.Trim(code.Code, telegramLinkCodeAlphabet) != "" {
+		t.Errorf("code = %q, want %d characters from the alphabet", code.Code, telegramLinkCodeLength)
+	}
+	if !code.ExpiresAt.Equal(now.Add(DefaultTelegramLinkCodeTTL)) {
+		t.Errorf("ExpiresAt = %v, want default TTL", code.ExpiresAt)
+	}
+
+	if got := NormalizeTelegramLinkCode(" ab3d-ef7h "); got != "AB3DEF7H" {
+		t.Errorf("NormalizeTelegramLinkCode() = %q", got)
+	}
+}
+
+func TestParseCNJNumber(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		ok    bool
+	}{
+		{"1234567-89.2023.8.26.0001", "1234567-89.2023.8.26.0001", true},
+		{" 12345678920238260001 ", "1234567-89.2023.8.26.0001", true},
+		{"1234567 89 2023 8 26 0001", "1234567-89.2023.8.26.0001", true},
+		{"processo 12345678920238260001", "", false}, // texto livre
+		{"1234567892023826000", "", false},           // 19 dígitos
+		{"(11) 98765-4321", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseCNJNumber(tt.input)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParseCNJNumber(%q) = %q, %v, want %q, %v", tt.input, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -29,6 +29,7 @@ type NotificationTemplate struct {
 	Content     string               `json:"content" db:"content"`
 	ContentHTML *string              `json:"content_html,omitempty" db:"content_html"`
 	Variables   []string             `json:"variables" db:"variables"`
+	DefaultLocale string             `json:"default_locale" db:"default_locale"` // idioma do conteúdo principal e último recurso na escolha da versão
 	TenantID    *uuid.UUID           `json:"tenant_id,omitempty" db:"tenant_id"`
 	IsSystem    bool                 `json:"is_system" db:"is_system"`
 	CreatedAt   time.Time            `json:"created_at" db:"created_at"`
@@ -64,6 +65,11 @@ func NewNotificationTemplate(
 		return nil, errors.New("content é obrigatório")
 	}
 
+	variables, err := TemplateVariables(subject, content)
+	if err != nil {
+		return nil, err
+	}
+
 	now := time.Now()
 	
 	template := &NotificationTemplate{
@@ -74,7 +80,8 @@ func NewNotificationTemplate(
 		Status:    TemplateStatusDraft,
 		Subject:   subject,
 		Content:   content,
-		Variables: extractVariables(subject + " " + content),
+		Variables: variables,
+		DefaultLocale: DefaultLocale,
 		TenantID:  tenantID,
 		IsSystem:  tenantID == nil, // Templates sem tenant são do sistema
 		CreatedAt: now,
@@ -118,10 +125,19 @@ func (t *NotificationTemplate) UpdateContent(subject, content string, contentHTM
 		return errors.New("content é obrigatório")
 	}
 
+	texts := []string{subject, content}
+	if contentHTML != nil {
+		texts = append(texts, *contentHTML)
+	}
+	variables, err := TemplateVariables(texts...)
+	if err != nil {
+		return err
+	}
+
 	t.Subject = subject
 	t.Content = content
 	t.ContentHTML = contentHTML
-	t.Variables = extractVariables(subject + " " + content)
+	t.Variables = variables
 	t.UpdatedAt = time.Now()
 	
 	return nil
@@ -143,79 +159,84 @@ func (t *NotificationTemplate) CanBeUsedByTenant(tenantID uuid.UUID) bool {
 	return t.TenantID != nil && *t.TenantID == tenantID
 }
 
-// RenderSubject renderiza subject com variáveis
-func (t *NotificationTemplate) RenderSubject(variables map[string]interface{}) string {
-	return renderTemplate(t.Subject, variables)
+// RenderedTemplate assunto e conteúdo renderizados
+type RenderedTemplate struct {
+	Subject          string   `json:"subject"`
+	Content          string   `json:"content"`
+	ContentHTML      *string  `json:"content_html,omitempty"`
+	MissingVariables []string `json:"missing_variables"`
 }
 
-// RenderContent renderiza content com variáveis
-func (t *NotificationTemplate) RenderContent(variables map[string]interface{}) string {
-	return renderTemplate(t.Content, variables)
-}
+// Render renderiza assunto e conteúdo com o escape do canal. O assunto não recebe escape; no
+// email, o conteúdo só recebe escape HTML quando é HTML. Em modo estrito, variáveis ausentes e
+// filtros inválidos são erro.
+func (t *NotificationTemplate) Render(variables map[string]interface{}, strict bool) (*RenderedTemplate, error) {
+	rendered := &RenderedTemplate{MissingVariables: []string{}}
 
-// RenderContentHTML renderiza content HTML com variáveis
-func (t *NotificationTemplate) RenderContentHTML(variables map[string]interface{}) *string {
-	if t.ContentHTML == nil {
-		return nil
+	contentEscaping := ChannelEscaping(t.Channel)
+	if t.Channel == NotificationChannelEmail && !LooksLikeHTML(t.Content) {
+		contentEscaping = TemplateEscapingNone
 	}
-	
-	rendered := renderTemplate(*t.ContentHTML, variables)
-	return &rendered
-}
 
-// extractVariables extrai variáveis do template (formato {{variavel}})
-func extractVariables(text string) []string {
-	var variables []string
-	
-	start := 0
-	for {
-		// Procurar início da variável
-		startIndex := strings.Index(text[start:], "{{")
-		if startIndex == -1 {
-			break
-		}
-		startIndex += start
-		
-		// Procurar fim da variável
-		endIndex := strings.Index(text[startIndex:], "}}")
-		if endIndex == -1 {
-			break
+	parts := []templatePart{
+		{t.Subject, TemplateEscapingNone, &rendered.Subject},
+		{t.Content, contentEscaping, &rendered.Content},
+	}
+	if t.ContentHTML != nil {
+		rendered.ContentHTML = new(string)
+		parts = append(parts, templatePart{*t.ContentHTML, TemplateEscapingHTML, rendered.ContentHTML})
+	}
+
+	// Renderiza todas as partes antes de acusar ausentes, para listar todas de uma vez
+	for _, part := range parts {
+		output, missing, err := RenderTemplate(part.source, variables, TemplateRenderOptions{Escaping: part.escaping, Strict: strict})
+		rendered.MissingVariables = appendUnique(rendered.MissingVariables, missing...)
+		if err != nil && !errors.Is(err, ErrTemplateMissingVariable) {
+			return nil, err
 		}
-		endIndex += startIndex
-		
-		// Extrair nome da variável
-		variable := strings.TrimSpace(text[startIndex+2 : endIndex])
-		if variable != "" {
-			// Verificar se já existe
-			found := false
-			for _, v := range variables {
-				if v == variable {
-					found = true
-					break
-				}
-			}
-			if !found {
-				variables = append(variables, variable)
-			}
+		*part.target = output
+	}
+
+	if strict && len(rendered.MissingVariables) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrTemplateMissingVariable, strings.Join(rendered.MissingVariables, ", "))
+	}
+
+	return rendered, nil
+}
+
+// templatePart texto do template e destino da renderização
+type templatePart struct {
+	source   string
+	escaping TemplateEscaping
+	target   *string
+}
+
+// LooksLikeHTML verifica se o conteúdo contém marcação HTML
+func LooksLikeHTML(content string) bool {
+	lower := strings.ToLower(strings.TrimSpace(content))
+	for _, tag := range []string{"<html", "<body", "<div", "<p", "<br", "<span", "<h1", "<h2", "<h3", "<table", "<tr", "<td"} {
+		if strings.Contains(lower, tag) {
+			return true
 		}
-		
-		start = endIndex + 2
 	}
-	
-	return variables
+	return false
 }
 
-// renderTemplate renderiza template substituindo variáveis
-func renderTemplate(template string, variables map[string]interface{}) string {
-	result := template
-	
-	for key, value := range variables {
-		placeholder := fmt.Sprintf("{{%s}}", key)
-		replacement := fmt.Sprintf("%v", value)
-		result = strings.ReplaceAll(result, placeholder, replacement)
+// appendUnique adiciona valores ainda não presentes
+func appendUnique(values []string, items ...string) []string {
+	for _, item := range items {
+		found := false
+		for _, v := range values {
+			if v == item {
+				found = true
+				break
+			}
+		}
+		if !found {
+			values = append(values, item)
+		}
 	}
-	
-	return result
+	return values
 }
 
 // ValidateTemplateStatus valida status do template
@@ -0,0 +1,809 @@
+package domain
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Linguagem dos templates de notificação:
+//
+//	{{nome}}                                 variável; caminhos com ponto: {{processo.numero}}
+//	{{valor | moeda}}                        filtros encadeados com |, argumentos após ":"
+//	{{descricao | truncar:100}}
+//	{{if dias <= 3}}...{{else}}...{{end}}    condição com not, ==, !=, <, <=, >, >=
+//	{{range mov in movimentos}}{{loop.index}}. {{mov.descricao}}{{end}}
+//
+// A linguagem não chama métodos nem funções: apenas lê mapas e listas das variáveis e
+// aplica os filtros registrados em templateFilters. O texto do template é emitido como
+// está; só o valor das variáveis recebe o escape do canal.
+
+const (
+	// maxTemplateOutput tamanho máximo do texto renderizado
+	maxTemplateOutput = 64 * 1024
+	// maxTemplateIterations total de iterações de range por renderização
+	maxTemplateIterations = 1000
+)
+
+// TemplateEscaping escape aplicado ao valor das variáveis conforme o formato do canal
+type TemplateEscaping string
+
+const (
+	TemplateEscapingNone       TemplateEscaping = "none"
+	TemplateEscapingHTML       TemplateEscaping = "html"
+	TemplateEscapingMarkdownV2 TemplateEscaping = "markdown_v2"
+	TemplateEscapingWhatsApp   TemplateEscaping = "whatsapp"
+)
+
+// ChannelEscaping escape do conteúdo de cada canal: HTML no email, MarkdownV2 no Telegram
+// e marcadores de formatação no WhatsApp
+func ChannelEscaping(channel NotificationChannel) TemplateEscaping {
+	switch channel {
+	case NotificationChannelEmail:
+		return TemplateEscapingHTML
+	case NotificationChannelTelegram:
+		return TemplateEscapingMarkdownV2
+	case NotificationChannelWhatsApp:
+		return TemplateEscapingWhatsApp
+	default:
+		return TemplateEscapingNone
+	}
+}
+
+// TemplateRenderOptions opções de renderização
+type TemplateRenderOptions struct {
+	Escaping TemplateEscaping
+	Strict   bool // variável ausente ou filtro inválido é erro
+}
+
+// CompiledTemplate template analisado, pronto para renderizar
+type CompiledTemplate struct {
+	nodes []templateNode
+}
+
+// ParseTemplate analisa o template. Erros de sintaxe envolvem ErrTemplateInvalid.
+func ParseTemplate(source string) (*CompiledTemplate, error) {
+	p := &templateParser{source: source}
+	nodes, end, err := p.parseNodes()
+	if err != nil {
+		return nil, err
+	}
+	if end != "" {
+		return nil, p.errorf("{{%s}} sem bloco aberto", end)
+	}
+	return &CompiledTemplate{nodes: nodes}, nil
+}
+
+// RenderTemplate analisa e renderiza o template, retornando também as variáveis ausentes
+func RenderTemplate(source string, variables map[string]interface{}, opts TemplateRenderOptions) (string, []string, error) {
+	compiled, err := ParseTemplate(source)
+	if err != nil {
+		return "", nil, err
+	}
+	return compiled.Execute(variables, opts)
+}
+
+// TemplateVariables variáveis referenciadas pelos textos, na ordem em que aparecem
+func TemplateVariables(texts ...string) ([]string, error) {
+	var variables []string
+	seen := make(map[string]bool)
+	for _, text := range texts {
+		compiled, err := ParseTemplate(text)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range compiled.Variables() {
+			if !seen[name] {
+				seen[name] = true
+				variables = append(variables, name)
+			}
+		}
+	}
+	return variables, nil
+}
+
+// Variables variáveis de primeiro nível usadas pelo template (sem as variáveis de range)
+func (t *CompiledTemplate) Variables() []string {
+	var variables []string
+	seen := make(map[string]bool)
+
+	var walk func(nodes []templateNode, locals map[string]bool)
+	add := func(path []string, locals map[string]bool) {
+		if len(path) == 0 || locals[path[0]] || seen[path[0]] {
+			return
+		}
+		seen[path[0]] = true
+		variables = append(variables, path[0])
+	}
+	walk = func(nodes []templateNode, locals map[string]bool) {
+		for _, node := range nodes {
+			switch n := node.(type) {
+			case *outputNode:
+				add(n.expr.path, locals)
+			case *ifNode:
+				add(n.cond.left.path, locals)
+				if n.cond.right != nil {
+					add(n.cond.right.path, locals)
+				}
+				walk(n.then, locals)
+				walk(n.otherwise, locals)
+			case *rangeNode:
+				add(n.path, locals)
+				inner := map[string]bool{n.name: true, "loop": true}
+				for name := range locals {
+					inner[name] = true
+				}
+				walk(n.body, inner)
+			}
+		}
+	}
+	walk(t.nodes, map[string]bool{})
+
+	return variables
+}
+
+// Execute renderiza o template. Fora do modo estrito, variáveis ausentes viram texto vazio e
+// filtros que não se aplicam ao valor o deixam inalterado.
+func (t *CompiledTemplate) Execute(variables map[string]interface{}, opts TemplateRenderOptions) (string, []string, error) {
+	r := &templateRenderer{opts: opts, variables: variables}
+	if err := r.render(t.nodes); err != nil {
+		return "", r.missing, err
+	}
+
+	if opts.Strict && len(r.missing) > 0 {
+		return "", r.missing, fmt.Errorf("%w: %s", ErrTemplateMissingVariable, strings.Join(r.missing, ", "))
+	}
+
+	return r.out.String(), r.missing, nil
+}
+
+// templateNode nó da árvore do template
+type templateNode interface{}
+
+type textNode struct {
+	text string
+}
+
+type outputNode struct {
+	expr *templateExpr
+}
+
+type ifNode struct {
+	cond      *templateCondition
+	then      []templateNode
+	otherwise []templateNode
+}
+
+type rangeNode struct {
+	name string
+	path []string
+	body []templateNode
+}
+
+// templateExpr variável seguida de filtros
+type templateExpr struct {
+	raw     string
+	path    []string
+	filters []templateFilterCall
+}
+
+type templateFilterCall struct {
+	name string
+	args []interface{}
+}
+
+// hasDefault indica se a expressão trata valor ausente com o filtro padrao
+func (e *templateExpr) hasDefault() bool {
+	for _, f := range e.filters {
+		if f.name == "padrao" {
+			return true
+		}
+	}
+	return false
+}
+
+// templateOperand variável ou literal de uma condição
+type templateOperand struct {
+	path    []string
+	literal interface{}
+}
+
+type templateCondition struct {
+	negate bool
+	left   templateOperand
+	op     string
+	right  *templateOperand
+}
+
+// templateParser analisador do template
+type templateParser struct {
+	source string
+	pos    int
+}
+
+func (p *templateParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s (posição %d)", ErrTemplateInvalid, fmt.Sprintf(format, args...), p.pos)
+}
+
+// parseNodes lê nós até o fim do texto ou até {{else}}/{{end}}, que é retornado
+func (p *templateParser) parseNodes() ([]templateNode, string, error) {
+	var nodes []templateNode
+
+	for p.pos < len(p.source) {
+		open := strings.Index(p.source[p.pos:], "{{")
+		if open == -1 {
+			nodes = append(nodes, &textNode{text: p.source[p.pos:]})
+			p.pos = len(p.source)
+			break
+		}
+		if open > 0 {
+			nodes = append(nodes, &textNode{text: p.source[p.pos : p.pos+open]})
+		}
+		p.pos += open
+
+		closeIndex := strings.Index(p.source[p.pos+2:], "}}")
+		if closeIndex == -1 {
+			return nil, "", p.errorf("{{ sem }}")
+		}
+		tag := strings.TrimSpace(p.source[p.pos+2 : p.pos+2+closeIndex])
+		tagPos := p.pos
+		p.pos += closeIndex + 4
+
+		tokens, err := tokenizeTemplateTag(tag)
+		if err != nil {
+			p.pos = tagPos
+			return nil, "", p.errorf("%v", err)
+		}
+		if len(tokens) == 0 {
+			p.pos = tagPos
+			return nil, "", p.errorf("variável vazia")
+		}
+
+		switch tokens[0].ident() {
+		case "else", "end":
+			if len(tokens) > 1 {
+				p.pos = tagPos
+				return nil, "", p.errorf("{{%s}} não aceita argumentos", tokens[0].value)
+			}
+			return nodes, tokens[0].value, nil
+
+		case "if":
+			cond, err := parseTemplateCondition(tokens[1:])
+			if err != nil {
+				p.pos = tagPos
+				return nil, "", p.errorf("%v", err)
+			}
+			node := &ifNode{cond: cond}
+			var end string
+			if node.then, end, err = p.parseNodes(); err != nil {
+				return nil, "", err
+			}
+			if end == "else" {
+				if node.otherwise, end, err = p.parseNodes(); err != nil {
+					return nil, "", err
+				}
+			}
+			if end != "end" {
+				p.pos = tagPos
+				return nil, "", p.errorf("{{if}} sem {{end}}")
+			}
+			nodes = append(nodes, node)
+
+		case "range":
+			node, err := parseTemplateRange(tokens[1:])
+			if err != nil {
+				p.pos = tagPos
+				return nil, "", p.errorf("%v", err)
+			}
+			var end string
+			if node.body, end, err = p.parseNodes(); err != nil {
+				return nil, "", err
+			}
+			if end != "end" {
+				p.pos = tagPos
+				return nil, "", p.errorf("{{range}} sem {{end}}")
+			}
+			nodes = append(nodes, node)
+
+		default:
+			expr, err := parseTemplateExpr(tag, tokens)
+			if err != nil {
+				p.pos = tagPos
+				return nil, "", p.errorf("%v", err)
+			}
+			nodes = append(nodes, &outputNode{expr: expr})
+		}
+	}
+
+	return nodes, "", nil
+}
+
+// parseTemplateExpr variável | filtro:arg,arg | filtro
+func parseTemplateExpr(raw string, tokens []templateToken) (*templateExpr, error) {
+	path, err := tokens[0].path()
+	if err != nil {
+		return nil, err
+	}
+	expr := &templateExpr{raw: raw, path: path}
+
+	rest := tokens[1:]
+	for len(rest) > 0 {
+		if !rest[0].is("|") || len(rest) < 2 || rest[1].kind != templateTokenIdent {
+			return nil, fmt.Errorf("esperado | filtro em %q", raw)
+		}
+		call := templateFilterCall{name: rest[1].value}
+		rest = rest[2:]
+
+		if len(rest) > 0 && rest[0].is(":") {
+			rest = rest[1:]
+			for {
+				if len(rest) == 0 || !rest[0].isLiteral() {
+					return nil, fmt.Errorf("argumento inválido para o filtro %s", call.name)
+				}
+				call.args = append(call.args, rest[0].literal())
+				rest = rest[1:]
+				if len(rest) == 0 || !rest[0].is(",") {
+					break
+				}
+				rest = rest[1:]
+			}
+		}
+
+		spec, ok := templateFilters[call.name]
+		if !ok {
+			return nil, fmt.Errorf("filtro desconhecido: %s", call.name)
+		}
+		if len(call.args) < spec.minArgs || len(call.args) > spec.maxArgs {
+			return nil, fmt.Errorf("quantidade de argumentos inválida para o filtro %s", call.name)
+		}
+		expr.filters = append(expr.filters, call)
+	}
+
+	return expr, nil
+}
+
+// parseTemplateCondition [not] operando [operador operando]
+func parseTemplateCondition(tokens []templateToken) (*templateCondition, error) {
+	cond := &templateCondition{}
+	if len(tokens) > 0 && tokens[0].ident() == "not" {
+		cond.negate = true
+		tokens = tokens[1:]
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("{{if}} sem condição")
+	}
+
+	left, err := parseTemplateOperand(tokens[0])
+	if err != nil {
+		return nil, err
+	}
+	cond.left = left
+
+	switch len(tokens) {
+	case 1:
+		return cond, nil
+	case 3:
+		if !tokens[1].isComparison() {
+			return nil, fmt.Errorf("operador inválido: %s", tokens[1].value)
+		}
+		right, err := parseTemplateOperand(tokens[2])
+		if err != nil {
+			return nil, err
+		}
+		cond.op = tokens[1].value
+		cond.right = &right
+		return cond, nil
+	default:
+		return nil, fmt.Errorf("condição inválida")
+	}
+}
+
+func parseTemplateOperand(token templateToken) (templateOperand, error) {
+	if token.isLiteral() {
+		return templateOperand{literal: token.literal()}, nil
+	}
+	path, err := token.path()
+	if err != nil {
+		return templateOperand{}, err
+	}
+	return templateOperand{path: path}, nil
+}
+
+// parseTemplateRange item in lista
+func parseTemplateRange(tokens []templateToken) (*rangeNode, error) {
+	if len(tokens) != 3 || tokens[1].ident() != "in" || tokens[0].kind != templateTokenIdent {
+		return nil, fmt.Errorf("esperado {{range item in lista}}")
+	}
+	name := tokens[0].value
+	if strings.Contains(name, ".") || name == "loop" {
+		return nil, fmt.Errorf("nome inválido para o item do range: %s", name)
+	}
+	path, err := tokens[2].path()
+	if err != nil {
+		return nil, err
+	}
+	return &rangeNode{name: name, path: path}, nil
+}
+
+// templateTokenKind tipo do token de uma tag
+type templateTokenKind int
+
+const (
+	templateTokenIdent templateTokenKind = iota
+	templateTokenNumber
+	templateTokenString
+	templateTokenOperator
+)
+
+type templateToken struct {
+	kind  templateTokenKind
+	value string
+}
+
+func (t templateToken) is(operator string) bool {
+	return t.kind == templateTokenOperator && t.value == operator
+}
+
+func (t templateToken) ident() string {
+	if t.kind != templateTokenIdent {
+		return ""
+	}
+	return t.value
+}
+
+func (t templateToken) isLiteral() bool {
+	return t.kind == templateTokenNumber || t.kind == templateTokenString
+}
+
+func (t templateToken) isComparison() bool {
+	if t.kind != templateTokenOperator {
+		return false
+	}
+	switch t.value {
+	case "==", "!=", "<", "<=", ">", ">=":
+		return true
+	}
+	return false
+}
+
+func (t templateToken) literal() interface{} {
+	if t.kind == templateTokenNumber {
+		f, _ := strconv.ParseFloat(t.value, 64)
+		return f
+	}
+	return t.value
+}
+
+func (t templateToken) path() ([]string, error) {
+	if t.kind != templateTokenIdent {
+		return nil, fmt.Errorf("esperado nome de variável, encontrado %q", t.value)
+	}
+	path := strings.Split(t.value, ".")
+	for _, part := range path {
+		if part == "" {
+			return nil, fmt.Errorf("nome de variável inválido: %s", t.value)
+		}
+	}
+	return path, nil
+}
+
+// tokenizeTemplateTag separa o conteúdo de uma tag em nomes, números, textos e operadores
+func tokenizeTemplateTag(tag string) ([]templateToken, error) {
+	var tokens []templateToken
+	runes := []rune(tag)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '"':
+			var value strings.Builder
+			j := i + 1
+			for ; j < len(runes) && runes[j] != '"'; j++ {
+				if runes[j] == '\\' && j+1 < len(runes) {
+					j++
+				}
+				value.WriteRune(runes[j])
+			}
+			if j >= len(runes) {
+				return nil, fmt.Errorf("texto sem aspas de fechamento")
+			}
+			tokens = append(tokens, templateToken{kind: templateTokenString, value: value.String()})
+			i = j + 1
+
+		case unicode.IsDigit(r) || (r == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			j := i + 1
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			value := string(runes[i:j])
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				return nil, fmt.Errorf("número inválido: %s", value)
+			}
+			tokens = append(tokens, templateToken{kind: templateTokenNumber, value: value})
+			i = j
+
+		case unicode.IsLetter(r) || r == '_':
+			j := i + 1
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_' || runes[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, templateToken{kind: templateTokenIdent, value: string(runes[i:j])})
+			i = j
+
+		default:
+			operator := string(r)
+			if i+1 < len(runes) && runes[i+1] == '=' && strings.ContainsRune("=!<>", r) {
+				operator += "="
+			}
+			switch operator {
+			case "|", ":", ",", "==", "!=", "<", "<=", ">", ">=":
+			default:
+				return nil, fmt.Errorf("caractere inválido: %s", operator)
+			}
+			tokens = append(tokens, templateToken{kind: templateTokenOperator, value: operator})
+			i += len([]rune(operator))
+		}
+	}
+
+	return tokens, nil
+}
+
+// templateScope item do range em andamento
+type templateScope struct {
+	name  string
+	value interface{}
+	loop  map[string]interface{}
+}
+
+// templateRenderer estado de uma renderização
+type templateRenderer struct {
+	opts       TemplateRenderOptions
+	variables  map[string]interface{}
+	scopes     []templateScope
+	out        strings.Builder
+	missing    []string
+	iterations int
+}
+
+func (r *templateRenderer) render(nodes []templateNode) error {
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case *textNode:
+			r.out.WriteString(n.text)
+
+		case *outputNode:
+			value, err := r.evaluate(n.expr)
+			if err != nil {
+				return err
+			}
+			r.out.WriteString(escapeTemplateValue(formatTemplateValue(value), r.opts.Escaping))
+
+		case *ifNode:
+			if r.test(n.cond) {
+				if err := r.render(n.then); err != nil {
+					return err
+				}
+			} else if err := r.render(n.otherwise); err != nil {
+				return err
+			}
+
+		case *rangeNode:
+			if err := r.renderRange(n); err != nil {
+				return err
+			}
+		}
+
+		if r.out.Len() > maxTemplateOutput {
+			return fmt.Errorf("%w: texto renderizado excede %d bytes", ErrTemplateInvalid, maxTemplateOutput)
+		}
+	}
+	return nil
+}
+
+// evaluate resolve a variável e aplica os filtros
+func (r *templateRenderer) evaluate(expr *templateExpr) (interface{}, error) {
+	value, found := r.resolve(expr.path)
+	if !found && !expr.hasDefault() {
+		r.addMissing(strings.Join(expr.path, "."))
+	}
+
+	for _, call := range expr.filters {
+		result, err := templateFilters[call.name].apply(value, call.args)
+		if err != nil {
+			if r.opts.Strict {
+				return nil, fmt.Errorf("%w: filtro %s em %s: %v", ErrTemplateInvalid, call.name, strings.Join(expr.path, "."), err)
+			}
+			continue
+		}
+		value = result
+	}
+
+	return value, nil
+}
+
+// test avalia a condição; variáveis ausentes contam como falso
+func (r *templateRenderer) test(cond *templateCondition) bool {
+	left := r.operand(cond.left)
+
+	result := isTemplateTruthy(left)
+	if cond.right != nil {
+		result = compareTemplateValues(left, cond.op, r.operand(*cond.right))
+	}
+
+	if cond.negate {
+		return !result
+	}
+	return result
+}
+
+func (r *templateRenderer) operand(operand templateOperand) interface{} {
+	if operand.path == nil {
+		return operand.literal
+	}
+	value, _ := r.resolve(operand.path)
+	return value
+}
+
+func (r *templateRenderer) renderRange(n *rangeNode) error {
+	value, found := r.resolve(n.path)
+	if !found {
+		r.addMissing(strings.Join(n.path, "."))
+		return nil
+	}
+
+	list := reflect.ValueOf(value)
+	if list.Kind() != reflect.Slice && list.Kind() != reflect.Array {
+		if r.opts.Strict {
+			return fmt.Errorf("%w: %s não é uma lista", ErrTemplateInvalid, strings.Join(n.path, "."))
+		}
+		return nil
+	}
+
+	for i := 0; i < list.Len(); i++ {
+		r.iterations++
+		if r.iterations > maxTemplateIterations {
+			return fmt.Errorf("%w: range excede %d iterações", ErrTemplateInvalid, maxTemplateIterations)
+		}
+
+		r.scopes = append(r.scopes, templateScope{
+			name:  n.name,
+			value: list.Index(i).Interface(),
+			loop: map[string]interface{}{
+				"index": i + 1,
+				"first": i == 0,
+				"last":  i == list.Len()-1,
+			},
+		})
+		err := r.render(n.body)
+		r.scopes = r.scopes[:len(r.scopes)-1]
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolve busca o caminho nos itens de range em andamento e depois nas variáveis
+func (r *templateRenderer) resolve(path []string) (interface{}, bool) {
+	var value interface{}
+	found := false
+
+	for i := len(r.scopes) - 1; i >= 0 && !found; i-- {
+		scope := r.scopes[i]
+		switch path[0] {
+		case scope.name:
+			value, found = scope.value, true
+		case "loop":
+			value, found = scope.loop, true
+		}
+	}
+	if !found {
+		value, found = r.variables[path[0]]
+	}
+	if !found {
+		return nil, false
+	}
+
+	for _, key := range path[1:] {
+		if value, found = lookupTemplateField(value, key); !found {
+			return nil, false
+		}
+	}
+
+	return value, value != nil
+}
+
+func (r *templateRenderer) addMissing(name string) {
+	for _, m := range r.missing {
+		if m == name {
+			return
+		}
+	}
+	r.missing = append(r.missing, name)
+}
+
+// lookupTemplateField acessa chave de mapa ou índice de lista, sem métodos nem campos de struct
+func lookupTemplateField(value interface{}, key string) (interface{}, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, false
+		}
+		item := v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key()))
+		if !item.IsValid() {
+			return nil, false
+		}
+		return item.Interface(), true
+	case reflect.Slice, reflect.Array:
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 0 || index >= v.Len() {
+			return nil, false
+		}
+		return v.Index(index).Interface(), true
+	}
+	return nil, false
+}
+
+// isTemplateTruthy falso para nil, false, zero, texto vazio e listas ou mapas vazios
+func isTemplateTruthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	}
+	if number, ok := templateNumber(value); ok {
+		return number != 0
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return v.Len() > 0
+	}
+	return true
+}
+
+// compareTemplateValues compara como números quando possível, senão como texto
+func compareTemplateValues(left interface{}, op string, right interface{}) bool {
+	var cmp int
+	if l, ok := templateNumber(left); ok {
+		r, ok := templateNumber(right)
+		if !ok {
+			return op == "!="
+		}
+		switch {
+		case l < r:
+			cmp = -1
+		case l > r:
+			cmp = 1
+		}
+	} else {
+		cmp = strings.Compare(formatTemplateValue(left), formatTemplateValue(right))
+	}
+
+	switch op {
+	case "==":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	return false
+}
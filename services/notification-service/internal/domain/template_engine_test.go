@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	variables := map[string]interface{}{
+		"nome":            "Ana",
+		"numero_processo": "00012345620248260100",
+		"valor":           1234567.5,
+		"dias":            float64(2),
+		"data_movimento":  "2024-05-10T15:30:00Z",
+		"prazo":           "2024-05-10",
+		"processo":        map[string]interface{}{"vara": "2ª Vara Cível"},
+		"movimentos": []interface{}{
+			map[string]interface{}{"descricao": "Sentença"},
+			map[string]interface{}{"descricao": "Intimação"},
+		},
+	}
+
+	tests := []struct {
+		source string
+		want   string
+	}{
+		{"Olá {{nome}}, {{ nome }}!", "Olá Ana, Ana!"},
+		{"{{processo.vara}} / {{movimentos.1.descricao}}", "2ª Vara Cível / Intimação"},
+		{"{{numero_processo | cnj}}", "0001234-56.2024.8.26.0100"},
+		{"{{valor | moeda}}", "R$ 1.234.567,50"},
+		{"{{valor | numero}} / {{dias | numero:1}}", "1.234.567,50 / 2,0"},
+		{"{{data_movimento | data_hora}}", "10/05/2024 12:30"},
+		{"{{prazo | data}} - {{prazo | data_extenso}}", "10/05/2024 - 10 de maio de 2024"},
+		{"{{nome | maiusculas}} {{nome | truncar:2}}", "ANA An…"},
+		{`{{apelido | padrao:"cliente"}}`, "cliente"},
+		{"{{if dias <= 3}}urgente{{else}}normal{{end}}", "urgente"},
+		{"{{if not apelido}}sem apelido{{end}}", "sem apelido"},
+		{`{{if nome == "Ana"}}sim{{end}}`, "sim"},
+		{"{{range mov in movimentos}}{{loop.index}}. {{mov.descricao}}{{if not loop.last}}; {{end}}{{end}}", "1. Sentença; 2. Intimação"},
+	}
+
+	for _, tt := range tests {
+		got, missing, err := RenderTemplate(tt.source, variables, TemplateRenderOptions{Strict: true})
+		if err != nil || got != tt.want || len(missing) != 0 {
+			t.Errorf("RenderTemplate(%q) = %q, %v, %v; want %q", tt.source, got, missing, err, tt.want)
+		}
+	}
+}
+
+func TestRenderTemplateMissingVariables(t *testing.T) {
+	source := "Olá {{nome}}, prazo em {{dias}} dias{{if urgente}}!{{end}}"
+
+	got, missing, err := RenderTemplate(source, map[string]interface{}{"dias": 3}, TemplateRenderOptions{})
+	if err != nil || got != "Olá , prazo em 3 dias" {
+		t.Errorf("RenderTemplate() = %q, %v", got, err)
+	}
+	if len(missing) != 1 || missing[0] != "nome" {
+		t.Errorf("missing = %v, want [nome]", missing)
+	}
+
+	_, _, err = RenderTemplate(source, map[string]interface{}{"dias": 3}, TemplateRenderOptions{Strict: true})
+	if !errors.Is(err, ErrTemplateMissingVariable) {
+		t.Errorf("strict error = %v, want ErrTemplateMissingVariable", err)
+	}
+
+	// Filtro inválido só é erro em modo estrito
+	got, _, err = RenderTemplate("{{n | cnj}}", map[string]interface{}{"n": "123"}, TemplateRenderOptions{})
+	if err != nil || got != "123" {
+		t.Errorf("RenderTemplate() = %q, %v; want raw value", got, err)
+	}
+	if _, _, err = RenderTemplate("{{n | cnj}}", map[string]interface{}{"n": "123"}, TemplateRenderOptions{Strict: true}); !errors.Is(err, ErrTemplateInvalid) {
+		t.Errorf("strict filter error = %v, want ErrTemplateInvalid", err)
+	}
+}
+
+func TestRenderTemplateEscaping(t *testing.T) {
+	variables := map[string]interface{}{"texto": `<b>*a_b*</b> 1.5!`}
+	source := "*Aviso*: {{texto}}"
+
+	tests := []struct {
+		escaping TemplateEscaping
+		want     string
+	}{
+		{TemplateEscapingNone, "*Aviso*: <b>*a_b*</b> 1.5!"},
+		{TemplateEscapingHTML, "*Aviso*: &lt;b&gt;*a_b*&lt;/b&gt; 1.5!"},
+		{TemplateEscapingMarkdownV2, `*Aviso*: <b\>\*a\_b\*</b\> 1\.5\!`},
+		{TemplateEscapingWhatsApp, "*Aviso*: <b>\u200b*\u200ba\u200b_\u200bb\u200b*\u200b</b> 1.5!"},
+	}
+
+	for _, tt := range tests {
+		got, _, err := RenderTemplate(source, variables, TemplateRenderOptions{Escaping: tt.escaping})
+		if err != nil || got != tt.want {
+			t.Errorf("escaping %s = %q, %v; want %q", tt.escaping, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseTemplateErrors(t *testing.T) {
+	sources := []string{
+		"Olá {{nome",
+		"{{}}",
+		"{{valor | desconhecido}}",
+		"{{valor | truncar}}",
+		"{{if dias}}sem fim",
+		"{{range mov movimentos}}{{end}}",
+		"{{end}}",
+		"{{nome @}}",
+	}
+
+	for _, source := range sources {
+		if _, err := ParseTemplate(source); !errors.Is(err, ErrTemplateInvalid) {
+			t.Errorf("ParseTemplate(%q) error = %v, want ErrTemplateInvalid", source, err)
+		}
+	}
+}
+
+func TestTemplateVariables(t *testing.T) {
+	got, err := TemplateVariables(
+		"Processo {{numero | cnj}}",
+		"{{if urgente}}!{{end}}{{range mov in movimentos}}{{mov.descricao}} {{loop.index}} {{nome}}{{end}}",
+	)
+	want := []string{"numero", "urgente", "movimentos", "nome"}
+	if err != nil || len(got) != len(want) {
+		t.Fatalf("TemplateVariables() = %v, %v; want %v", got, err, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("TemplateVariables()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestNotificationTemplateRender(t *testing.T) {
+	html := "<p>Olá {{nome}}</p>"
+	template := &NotificationTemplate{
+		Channel:     NotificationChannelEmail,
+		Subject:     "Processo de {{nome}}",
+		Content:     "Olá {{nome}}, {{detalhe}}",
+		ContentHTML: &html,
+	}
+	variables := map[string]interface{}{"nome": "Ana & Bia"}
+
+	rendered, err := template.Render(variables, false)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if rendered.Subject != "Processo de Ana & Bia" || rendered.Content != "Olá Ana & Bia, " {
+		t.Errorf("Render() = %+v", rendered)
+	}
+	if *rendered.ContentHTML != "<p>Olá Ana &amp; Bia</p>" {
+		t.Errorf("ContentHTML = %q", *rendered.ContentHTML)
+	}
+	if len(rendered.MissingVariables) != 1 || rendered.MissingVariables[0] != "detalhe" {
+		t.Errorf("MissingVariables = %v", rendered.MissingVariables)
+	}
+
+	if _, err := template.Render(variables, true); !errors.Is(err, ErrTemplateMissingVariable) {
+		t.Errorf("strict Render() error = %v, want ErrTemplateMissingVariable", err)
+	}
+}
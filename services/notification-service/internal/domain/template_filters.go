@@ -0,0 +1,292 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// templateFilter filtro aplicável com {{valor | filtro:arg}}. Valor nil passa direto, exceto
+// em padrao.
+type templateFilter struct {
+	minArgs int
+	maxArgs int
+	fn      func(value interface{}, args []interface{}) (interface{}, error)
+}
+
+func (f templateFilter) apply(value interface{}, args []interface{}) (interface{}, error) {
+	return f.fn(value, args)
+}
+
+// templateFilters filtros disponíveis nos templates
+var templateFilters = map[string]templateFilter{
+	"data":         {fn: skipNil(dateFilter("02/01/2006"))},
+	"data_hora":    {fn: skipNil(dateFilter("02/01/2006 15:04"))},
+	"hora":         {fn: skipNil(dateFilter("15:04"))},
+	"data_extenso": {fn: skipNil(longDateFilter)},
+	"moeda":        {fn: skipNil(currencyFilter)},
+	"numero":       {maxArgs: 1, fn: skipNil(numberFilter)},
+	"cnj":          {fn: skipNil(cnjFilter)},
+	"maiusculas":   {fn: skipNil(textFilter(strings.ToUpper))},
+	"minusculas":   {fn: skipNil(textFilter(strings.ToLower))},
+	"truncar":      {minArgs: 1, maxArgs: 1, fn: skipNil(truncateFilter)},
+	"padrao":       {minArgs: 1, maxArgs: 1, fn: defaultFilter},
+}
+
+var portugueseMonths = [...]string{
+	"janeiro", "fevereiro", "março", "abril", "maio", "junho",
+	"julho", "agosto", "setembro", "outubro", "novembro", "dezembro",
+}
+
+// templateDateLayouts formatos aceitos para datas em texto
+var templateDateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"02/01/2006 15:04",
+	"02/01/2006",
+}
+
+func skipNil(fn func(value interface{}, args []interface{}) (interface{}, error)) func(interface{}, []interface{}) (interface{}, error) {
+	return func(value interface{}, args []interface{}) (interface{}, error) {
+		if value == nil {
+			return nil, nil
+		}
+		return fn(value, args)
+	}
+}
+
+// dateFilter formata datas no fuso padrão
+func dateFilter(layout string) func(interface{}, []interface{}) (interface{}, error) {
+	return func(value interface{}, args []interface{}) (interface{}, error) {
+		t, err := templateTime(value)
+		if err != nil {
+			return nil, err
+		}
+		return t.Format(layout), nil
+	}
+}
+
+// longDateFilter 10 de maio de 2024
+func longDateFilter(value interface{}, args []interface{}) (interface{}, error) {
+	t, err := templateTime(value)
+	if err != nil {
+		return nil, err
+	}
+	return fmt.Sprintf("%d de %s de %d", t.Day(), portugueseMonths[t.Month()-1], t.Year()), nil
+}
+
+// currencyFilter R$ 1.234,56
+func currencyFilter(value interface{}, args []interface{}) (interface{}, error) {
+	number, ok := templateNumber(value)
+	if !ok {
+		return nil, fmt.Errorf("valor não numérico: %v", value)
+	}
+	if number < 0 {
+		return "-R$ " + formatDecimalBR(-number, 2), nil
+	}
+	return "R$ " + formatDecimalBR(number, 2), nil
+}
+
+// numberFilter 1.234 ou, com casas decimais, 1.234,50
+func numberFilter(value interface{}, args []interface{}) (interface{}, error) {
+	number, ok := templateNumber(value)
+	if !ok {
+		return nil, fmt.Errorf("valor não numérico: %v", value)
+	}
+
+	decimals := 0
+	if len(args) > 0 {
+		d, ok := templateNumber(args[0])
+		if !ok || d < 0 || d > 10 {
+			return nil, fmt.Errorf("casas decimais inválidas: %v", args[0])
+		}
+		decimals = int(d)
+	} else if number != math.Trunc(number) {
+		decimals = 2
+	}
+
+	if number < 0 {
+		return "-" + formatDecimalBR(-number, decimals), nil
+	}
+	return formatDecimalBR(number, decimals), nil
+}
+
+// cnjFilter NNNNNNN-DD.AAAA.J.TR.OOOO
+func cnjFilter(value interface{}, args []interface{}) (interface{}, error) {
+	var digits strings.Builder
+	for _, r := range formatTemplateValue(value) {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+
+	d := digits.String()
+	if len(d) != 20 {
+		return nil, fmt.Errorf("número CNJ inválido: %v", value)
+	}
+	return formatCNJDigits(d), nil
+}
+
+// formatCNJDigits formata os 20 dígitos do número CNJ
+func formatCNJDigits(d string) string {
+	return fmt.Sprintf("%s-%s.%s.%s.%s.%s", d[0:7], d[7:9], d[9:13], d[13:14], d[14:16], d[16:20])
+}
+
+func textFilter(fn func(string) string) func(interface{}, []interface{}) (interface{}, error) {
+	return func(value interface{}, args []interface{}) (interface{}, error) {
+		return fn(formatTemplateValue(value)), nil
+	}
+}
+
+// truncateFilter limita o texto a N caracteres, com reticências
+func truncateFilter(value interface{}, args []interface{}) (interface{}, error) {
+	limit, ok := templateNumber(args[0])
+	if !ok || limit < 1 {
+		return nil, fmt.Errorf("limite inválido: %v", args[0])
+	}
+
+	runes := []rune(formatTemplateValue(value))
+	if len(runes) <= int(limit) {
+		return string(runes), nil
+	}
+	return strings.TrimRightFunc(string(runes[:int(limit)]), unicode.IsSpace) + "…", nil
+}
+
+// defaultFilter valor usado quando a variável está ausente ou vazia
+func defaultFilter(value interface{}, args []interface{}) (interface{}, error) {
+	if value == nil || formatTemplateValue(value) == "" {
+		return args[0], nil
+	}
+	return value, nil
+}
+
+// templateTime interpreta time.Time ou texto de data; datas sem fuso usam o fuso padrão
+func templateTime(value interface{}) (time.Time, error) {
+	loc, err := time.LoadLocation(DefaultTimezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	switch v := value.(type) {
+	case time.Time:
+		return v.In(loc), nil
+	case *time.Time:
+		if v != nil {
+			return v.In(loc), nil
+		}
+	case string:
+		text := strings.TrimSpace(v)
+		for _, layout := range templateDateLayouts {
+			if layout == time.RFC3339Nano {
+				if t, err := time.Parse(layout, text); err == nil {
+					return t.In(loc), nil
+				}
+				continue
+			}
+			if t, err := time.ParseInLocation(layout, text, loc); err == nil {
+				return t, nil
+			}
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("data inválida: %v", value)
+}
+
+// templateNumber converte números, json.Number e texto numérico
+func templateNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	case nil, bool:
+		return 0, false
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+// formatDecimalBR formata número não negativo com milhar "." e decimal ","
+func formatDecimalBR(number float64, decimals int) string {
+	formatted := strconv.FormatFloat(number, 'f', decimals, 64)
+	integer, fraction, _ := strings.Cut(formatted, ".")
+
+	var grouped strings.Builder
+	for i, r := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			grouped.WriteByte('.')
+		}
+		grouped.WriteRune(r)
+	}
+
+	if fraction != "" {
+		grouped.WriteByte(',')
+		grouped.WriteString(fraction)
+	}
+	return grouped.String()
+}
+
+// formatTemplateValue texto de um valor; números sem notação científica
+func formatTemplateValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case time.Time:
+		t, _ := templateTime(v)
+		return t.Format("02/01/2006 15:04")
+	}
+	return fmt.Sprint(value)
+}
+
+// markdownV2Escaper caracteres reservados do MarkdownV2 do Telegram
+var markdownV2Escaper = strings.NewReplacer(
+	`\`, `\\`, "_", `\_`, "*", `\*`, "[", `\[`, "]", `\]`, "(", `\(`, ")", `\)`,
+	"~", `\~`, "`", "\\`", ">", `\>`, "#", `\#`, "+", `\+`, "-", `\-`, "=", `\=`,
+	"|", `\|`, "{", `\{`, "}", `\}`, ".", `\.`, "!", `\!`,
+)
+
+// whatsAppEscaper o WhatsApp não tem escape; espaços de largura zero ao redor dos marcadores
+// impedem que o valor abra ou feche negrito, itálico, tachado ou monoespaçado
+var whatsAppEscaper = strings.NewReplacer(
+	"*", "\u200b*\u200b", "_", "\u200b_\u200b", "~", "\u200b~\u200b", "`", "\u200b`\u200b",
+)
+
+// escapeTemplateValue aplica o escape do canal ao valor de uma variável
+func escapeTemplateValue(value string, escaping TemplateEscaping) string {
+	switch escaping {
+	case TemplateEscapingHTML:
+		return html.EscapeString(value)
+	case TemplateEscapingMarkdownV2:
+		return markdownV2Escaper.Replace(value)
+	case TemplateEscapingWhatsApp:
+		return whatsAppEscaper.Replace(value)
+	default:
+		return value
+	}
+}
@@ -0,0 +1,251 @@
+package domain
+
+import (
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultLocale idioma padrão dos templates e destinatários
+const DefaultLocale = "pt-BR"
+
+// DefaultTemplateVariant variante usada quando não há teste A/B
+const DefaultTemplateVariant = "default"
+
+// DefaultVariantWeight peso padrão de uma variante no sorteio A/B
+const DefaultVariantWeight = 100
+
+var (
+	localePattern  = regexp.MustCompile(`^([a-zA-Z]{2,3})(?:[-_]([a-zA-Z]{2}|[0-9]{3}))?$`)
+	variantPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+)
+
+// TemplateVersionStatus status da versão do template
+type TemplateVersionStatus string
+
+const (
+	TemplateVersionStatusDraft     TemplateVersionStatus = "draft"
+	TemplateVersionStatusPublished TemplateVersionStatus = "published"
+	TemplateVersionStatusArchived  TemplateVersionStatus = "archived"
+)
+
+// TemplateVersion versão imutável do conteúdo de um template em um idioma e variante.
+// Apenas status e peso mudam depois de criada; editar o conteúdo cria nova versão.
+type TemplateVersion struct {
+	ID          uuid.UUID             `json:"id"`
+	TemplateID  uuid.UUID             `json:"template_id"`
+	Version     int                   `json:"version"` // sequencial por template
+	Locale      string                `json:"locale"`
+	Variant     string                `json:"variant"`
+	Weight      int                   `json:"weight"` // peso no sorteio entre variantes publicadas do idioma
+	Status      TemplateVersionStatus `json:"status"`
+	Subject     string                `json:"subject"`
+	Content     string                `json:"content"`
+	ContentHTML *string               `json:"content_html,omitempty"`
+	Variables   []string              `json:"variables"`
+	CreatedAt   time.Time             `json:"created_at"`
+	PublishedAt *time.Time            `json:"published_at,omitempty"`
+}
+
+// NewTemplateVersion cria versão em rascunho; o número da versão é atribuído pelo repositório
+func NewTemplateVersion(
+	templateID uuid.UUID,
+	locale, variant string,
+	weight int,
+	subject, content string,
+	contentHTML *string,
+) (*TemplateVersion, error) {
+	normalizedLocale, err := NormalizeLocale(locale)
+	if err != nil {
+		return nil, err
+	}
+
+	if variant == "" {
+		variant = DefaultTemplateVariant
+	}
+	if !variantPattern.MatchString(variant) {
+		return nil, fmt.Errorf("%w: variante inválida: %s", ErrTemplateInvalid, variant)
+	}
+
+	if err := ValidateVariantWeight(weight); err != nil {
+		return nil, err
+	}
+
+	if subject == "" || content == "" {
+		return nil, fmt.Errorf("%w: subject e content são obrigatórios", ErrTemplateInvalid)
+	}
+
+	texts := []string{subject, content}
+	if contentHTML != nil {
+		texts = append(texts, *contentHTML)
+	}
+	variables, err := TemplateVariables(texts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TemplateVersion{
+		ID:          uuid.New(),
+		TemplateID:  templateID,
+		Locale:      normalizedLocale,
+		Variant:     variant,
+		Weight:      weight,
+		Status:      TemplateVersionStatusDraft,
+		Subject:     subject,
+		Content:     content,
+		ContentHTML: contentHTML,
+		Variables:   variables,
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+// ValidateVariantWeight valida o peso da variante (0 pausa a variante sem despublicar)
+func ValidateVariantWeight(weight int) error {
+	if weight < 0 || weight > 1000 {
+		return fmt.Errorf("%w: peso da variante deve estar entre 0 e 1000", ErrTemplateInvalid)
+	}
+	return nil
+}
+
+// IsPublished verifica se a versão está publicada
+func (v *TemplateVersion) IsPublished() bool {
+	return v.Status == TemplateVersionStatusPublished
+}
+
+// ApplyTo retorna cópia do template com o conteúdo da versão, para renderização
+func (v *TemplateVersion) ApplyTo(template *NotificationTemplate) *NotificationTemplate {
+	versioned := *template
+	versioned.Subject = v.Subject
+	versioned.Content = v.Content
+	versioned.ContentHTML = v.ContentHTML
+	versioned.Variables = v.Variables
+	return &versioned
+}
+
+// NormalizeLocale normaliza o idioma para o formato BCP 47 curto (pt-BR, en, es-419)
+func NormalizeLocale(locale string) (string, error) {
+	locale = strings.TrimSpace(locale)
+	if locale == "" {
+		return DefaultLocale, nil
+	}
+
+	match := localePattern.FindStringSubmatch(locale)
+	if match == nil {
+		return "", fmt.Errorf("%w: idioma inválido: %s", ErrTemplateInvalid, locale)
+	}
+
+	normalized := strings.ToLower(match[1])
+	if match[2] != "" {
+		normalized += "-" + strings.ToUpper(match[2])
+	}
+	return normalized, nil
+}
+
+// SelectTemplateVersion escolhe a versão publicada para o destinatário: primeiro o idioma
+// pedido, depois o mesmo idioma de outra região (pt-PT → pt-BR) e por fim o idioma padrão do
+// template. Entre variantes do idioma, sorteia pelo peso de forma estável por stickyKey, para
+// que o mesmo destinatário receba sempre a mesma variante. Retorna nil sem versão aplicável.
+func SelectTemplateVersion(published []*TemplateVersion, locale, defaultLocale, stickyKey string) *TemplateVersion {
+	candidates := matchLocale(published, locale)
+	if len(candidates) == 0 {
+		candidates = matchLocale(published, defaultLocale)
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	total := 0
+	for _, v := range candidates {
+		total += v.Weight
+	}
+	if total == 0 {
+		return nil
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(stickyKey))
+	point := int(h.Sum32() % uint32(total))
+
+	for _, v := range candidates {
+		if point < v.Weight {
+			return v
+		}
+		point -= v.Weight
+	}
+	return candidates[len(candidates)-1]
+}
+
+// matchLocale versões publicadas do idioma exato ou, sem elas, do mesmo idioma base
+func matchLocale(published []*TemplateVersion, locale string) []*TemplateVersion {
+	normalized, err := NormalizeLocale(locale)
+	if err != nil {
+		return nil
+	}
+	language := localeLanguage(normalized)
+
+	var exact, sameLanguage []*TemplateVersion
+	for _, v := range published {
+		if !v.IsPublished() {
+			continue
+		}
+		switch {
+		case v.Locale == normalized:
+			exact = append(exact, v)
+		case localeLanguage(v.Locale) == language:
+			sameLanguage = append(sameLanguage, v)
+		}
+	}
+
+	if len(exact) > 0 {
+		return exact
+	}
+	if len(sameLanguage) == 0 {
+		return nil
+	}
+
+	// Outra região do mesmo idioma: usa apenas um locale, para não misturar variantes
+	first := sameLanguage[0].Locale
+	var sameLocale []*TemplateVersion
+	for _, v := range sameLanguage {
+		if v.Locale == first {
+			sameLocale = append(sameLocale, v)
+		}
+	}
+	return sameLocale
+}
+
+// localeLanguage idioma base do locale (pt-BR → pt)
+func localeLanguage(locale string) string {
+	language, _, _ := strings.Cut(locale, "-")
+	return language
+}
+
+// TemplateVariantStats métricas de envio e engajamento de uma versão/variante
+type TemplateVariantStats struct {
+	TemplateVersionID uuid.UUID `json:"template_version_id" db:"template_version_id"`
+	Version           int       `json:"version" db:"version"`
+	Locale            string    `json:"locale" db:"locale"`
+	Variant           string    `json:"variant" db:"variant"`
+	Sent              int64     `json:"sent" db:"sent"`
+	Delivered         int64     `json:"delivered" db:"delivered"`
+	Opened            int64     `json:"opened" db:"opened"`
+	Clicked           int64     `json:"clicked" db:"clicked"`
+	Read              int64     `json:"read" db:"read"`
+	OpenRate          float64   `json:"open_rate"`
+	ClickRate         float64   `json:"click_rate"`
+	ReadRate          float64   `json:"read_rate"`
+}
+
+// CalculateRates calcula as taxas sobre as mensagens enviadas
+func (s *TemplateVariantStats) CalculateRates() {
+	if s.Sent == 0 {
+		return
+	}
+	s.OpenRate = float64(s.Opened) / float64(s.Sent) * 100
+	s.ClickRate = float64(s.Clicked) / float64(s.Sent) * 100
+	s.ReadRate = float64(s.Read) / float64(s.Sent) * 100
+}
@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNormalizeLocale(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", DefaultLocale},
+		{"pt-br", "pt-BR"},
+		{"en_us", "en-US"},
+		{"ES", "es"},
+		{"es-419", "es-419"},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizeLocale(tt.input)
+		if err != nil || got != tt.want {
+			t.Errorf("NormalizeLocale(%q) = %q, %v; want %q", tt.input, got, err, tt.want)
+		}
+	}
+
+	if _, err := NormalizeLocale("português"); !errors.Is(err, ErrTemplateInvalid) {
+		t.Errorf("NormalizeLocale(invalid) error = %v, want ErrTemplateInvalid", err)
+	}
+}
+
+func publishedVersion(t *testing.T, locale, variant string, weight int) *TemplateVersion {
+	t.Helper()
+	v, err := NewTemplateVersion(uuid.New(), locale, variant, weight, "Assunto", "Olá {{nome}}", nil)
+	if err != nil {
+		t.Fatalf("NewTemplateVersion() error = %v", err)
+	}
+	v.Status = TemplateVersionStatusPublished
+	return v
+}
+
+func TestSelectTemplateVersionLocaleFallback(t *testing.T) {
+	ptBR := publishedVersion(t, "pt-BR", "", 100)
+	en := publishedVersion(t, "en", "", 100)
+	archived := publishedVersion(t, "es", "", 100)
+	archived.Status = TemplateVersionStatusArchived
+	published := []*TemplateVersion{ptBR, en, archived}
+
+	tests := []struct {
+		locale string
+		want   *TemplateVersion
+	}{
+		{"pt-BR", ptBR},
+		{"pt-PT", ptBR}, // mesmo idioma, outra região
+		{"en-GB", en},
+		{"es", ptBR}, // só há versão arquivada: idioma padrão
+		{"fr", ptBR},
+	}
+
+	for _, tt := range tests {
+		if got := SelectTemplateVersion(published, tt.locale, DefaultLocale, "user"); got != tt.want {
+			t.Errorf("SelectTemplateVersion(%q) = %v, want %v", tt.locale, got.Locale, tt.want.Locale)
+		}
+	}
+
+	if got := SelectTemplateVersion([]*TemplateVersion{en}, "fr", DefaultLocale, "user"); got != nil {
+		t.Errorf("SelectTemplateVersion() = %v, want nil without default locale", got.Locale)
+	}
+}
+
+func TestSelectTemplateVersionWeightedVariants(t *testing.T) {
+	a := publishedVersion(t, "pt-BR", "a", 75)
+	b := publishedVersion(t, "pt-BR", "b", 25)
+	published := []*TemplateVersion{a, b}
+
+	counts := map[string]int{}
+	for i := 0; i < 4000; i++ {
+		key := fmt.Sprintf("destinatario-%d", i)
+		v := SelectTemplateVersion(published, "pt-BR", DefaultLocale, key)
+		counts[v.Variant]++
+
+		// Mesmo destinatário recebe sempre a mesma variante
+		if again := SelectTemplateVersion(published, "pt-BR", DefaultLocale, key); again != v {
+			t.Fatalf("variant for %s is not sticky", key)
+		}
+	}
+
+	if counts["a"] < 2800 || counts["a"] > 3200 {
+		t.Errorf("variant distribution = %v, want about 75%%/25%%", counts)
+	}
+
+	// Peso 0 pausa a variante
+	b.Weight = 0
+	for i := 0; i < 100; i++ {
+		if v := SelectTemplateVersion(published, "pt-BR", DefaultLocale, fmt.Sprint(i)); v != a {
+			t.Fatalf("paused variant selected")
+		}
+	}
+
+	a.Weight = 0
+	if v := SelectTemplateVersion(published, "pt-BR", DefaultLocale, "x"); v != nil {
+		t.Errorf("SelectTemplateVersion() = %v, want nil with all variants paused", v.Variant)
+	}
+}
+
+func TestNewTemplateVersionValidation(t *testing.T) {
+	templateID := uuid.New()
+
+	if _, err := NewTemplateVersion(templateID, "pt-BR", "Variante A", 100, "s", "c", nil); !errors.Is(err, ErrTemplateInvalid) {
+		t.Errorf("invalid variant error = %v", err)
+	}
+	if _, err := NewTemplateVersion(templateID, "pt-BR", "", 1001, "s", "c", nil); !errors.Is(err, ErrTemplateInvalid) {
+		t.Errorf("invalid weight error = %v", err)
+	}
+	if _, err := NewTemplateVersion(templateID, "pt-BR", "", 100, "s", "{{if x}}", nil); !errors.Is(err, ErrTemplateInvalid) {
+		t.Errorf("invalid content error = %v", err)
+	}
+
+	html := "<p>{{link}}</p>"
+	v, err := NewTemplateVersion(templateID, "en_us", "", 100, "{{assunto}}", "{{nome}}", &html)
+	if err != nil {
+		t.Fatalf("NewTemplateVersion() error = %v", err)
+	}
+	if v.Locale != "en-US" || v.Variant != DefaultTemplateVariant || v.Status != TemplateVersionStatusDraft {
+		t.Errorf("NewTemplateVersion() = %+v", v)
+	}
+	if len(v.Variables) != 3 {
+		t.Errorf("Variables = %v, want [assunto nome link]", v.Variables)
+	}
+}
+
+func TestApplyDeliveryEventEngagement(t *testing.T) {
+	at := time.Now()
+	n := &Notification{Status: NotificationStatusSent}
+
+	if !n.ApplyDeliveryEvent(DeliveryEventClicked, at, "") {
+		t.Fatal("ApplyDeliveryEvent(clicked) = false")
+	}
+	if n.Status != NotificationStatusDelivered || n.DeliveredAt == nil || n.OpenedAt == nil || n.ClickedAt == nil {
+		t.Errorf("after click: status=%s delivered=%v opened=%v clicked=%v", n.Status, n.DeliveredAt, n.OpenedAt, n.ClickedAt)
+	}
+
+	// Abertura posterior ao clique não altera nada
+	if n.ApplyDeliveryEvent(DeliveryEventOpened, at.Add(time.Minute), "") {
+		t.Error("ApplyDeliveryEvent(opened) after click = true")
+	}
+
+	read := &Notification{Status: NotificationStatusRead}
+	read.ApplyDeliveryEvent(DeliveryEventOpened, at, "")
+	if read.Status != NotificationStatusRead {
+		t.Errorf("opened regressed status to %s", read.Status)
+	}
+}
@@ -0,0 +1,472 @@
+package domain
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Eventos entregues aos webhooks dos tenants
+const (
+	WebhookEventProcessCreated          = "process.created"
+	WebhookEventProcessUpdated          = "process.updated"
+	WebhookEventProcessArchived         = "process.archived"
+	WebhookEventProcessSynced           = "process.synced"
+	WebhookEventMovementCreated         = "movement.created"
+	WebhookEventMovementImportant       = "movement.important.detected"
+	WebhookEventDeadlineDetected        = "deadline.detected"
+	WebhookEventInvoiceCreated          = "invoice.created"
+	WebhookEventInvoicePaid             = "invoice.paid"
+	WebhookEventInvoiceOverdue          = "invoice.overdue"
+	WebhookEventPaymentSuccess          = "payment.success"
+	WebhookEventPaymentFailed           = "payment.failed"
+	WebhookEventSubscriptionTrialEnding = "subscription.trial_ending"
+
+	// WebhookEventPing evento de teste enviado pelo endpoint de ping; não entra nos filtros
+	WebhookEventPing = "webhook.ping"
+)
+
+// WebhookEvents eventos que podem ser assinados
+var WebhookEvents = []string{
+	WebhookEventProcessCreated,
+	WebhookEventProcessUpdated,
+	WebhookEventProcessArchived,
+	WebhookEventProcessSynced,
+	WebhookEventMovementCreated,
+	WebhookEventMovementImportant,
+	WebhookEventDeadlineDetected,
+	WebhookEventInvoiceCreated,
+	WebhookEventInvoicePaid,
+	WebhookEventInvoiceOverdue,
+	WebhookEventPaymentSuccess,
+	WebhookEventPaymentFailed,
+	WebhookEventSubscriptionTrialEnding,
+}
+
+// Cabeçalhos das entregas. A assinatura é "t=<unix>,v1=<hex>", com HMAC-SHA256 do segredo sobre
+// "<t>.<corpo>".
+const (
+	WebhookSignatureHeader = "X-DireitoLux-Signature"
+	WebhookEventHeader     = "X-DireitoLux-Event"
+	WebhookDeliveryHeader  = "X-DireitoLux-Delivery"
+)
+
+// MaxWebhookEventFilters quantidade máxima de filtros por inscrição
+const MaxWebhookEventFilters = 20
+
+// maxWebhookResponseBody tamanho da resposta guardado no histórico
+const maxWebhookResponseBody = 1024
+
+// IsWebhookEvent indica se o evento pode ser assinado
+func IsWebhookEvent(eventType string) bool {
+	for _, event := range WebhookEvents {
+		if event == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateWebhookEventFilters valida os filtros: "*", o nome do evento ou um prefixo como
+// "movement.*"
+func ValidateWebhookEventFilters(filters []string) error {
+	if len(filters) == 0 {
+		return fmt.Errorf("%w: informe ao menos um evento", ErrWebhookInvalid)
+	}
+	if len(filters) > MaxWebhookEventFilters {
+		return fmt.Errorf("%w: máximo de %d filtros de eventos", ErrWebhookInvalid, MaxWebhookEventFilters)
+	}
+
+	for _, filter := range filters {
+		matched := false
+		for _, event := range WebhookEvents {
+			if webhookFilterMatches(filter, event) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return fmt.Errorf("%w: evento desconhecido: %s", ErrWebhookInvalid, filter)
+		}
+	}
+	return nil
+}
+
+// webhookFilterMatches compara o filtro com o tipo do evento
+func webhookFilterMatches(filter, eventType string) bool {
+	if filter == "*" || filter == eventType {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(filter, ".*"); ok && prefix != "" {
+		return strings.HasPrefix(eventType, prefix+".")
+	}
+	return false
+}
+
+// ValidateWebhookURL valida o endereço de entrega. Fora do ambiente de desenvolvimento
+// (allowInsecure) exige HTTPS e recusa endereços locais e privados.
+func ValidateWebhookURL(raw string, allowInsecure bool) error {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || parsed.Host == "" || parsed.Hostname() == "" {
+		return fmt.Errorf("%w: URL inválida", ErrWebhookInvalid)
+	}
+	if parsed.User != nil {
+		return fmt.Errorf("%w: URL não pode conter credenciais", ErrWebhookInvalid)
+	}
+
+	switch parsed.Scheme {
+	case "https":
+	case "http":
+		if !allowInsecure {
+			return fmt.Errorf("%w: a URL deve usar HTTPS", ErrWebhookInvalid)
+		}
+	default:
+		return fmt.Errorf("%w: a URL deve usar HTTPS", ErrWebhookInvalid)
+	}
+
+	if !allowInsecure {
+		host := strings.ToLower(parsed.Hostname())
+		if host == "localhost" || strings.HasSuffix(host, ".localhost") || strings.HasSuffix(host, ".internal") {
+			return fmt.Errorf("%w: endereço local não permitido", ErrWebhookInvalid)
+		}
+		if ip := net.ParseIP(host); ip != nil && IsPrivateWebhookAddress(ip) {
+			return fmt.Errorf("%w: endereço privado não permitido", ErrWebhookInvalid)
+		}
+	}
+	return nil
+}
+
+// IsPrivateWebhookAddress indica endereços que não podem receber entregas (loopback, redes
+// privadas, link-local e metadados de nuvem)
+func IsPrivateWebhookAddress(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsMulticast()
+}
+
+// GenerateWebhookSecret gera o segredo de assinatura da inscrição
+func GenerateWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
+
+// SignWebhookPayload assina o corpo da entrega no formato do cabeçalho de assinatura
+func SignWebhookPayload(secret string, timestamp time.Time, body []byte) string {
+	unix := strconv.FormatInt(timestamp.Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unix))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "t=" + unix + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookSubscription inscrição de webhook do tenant
+type WebhookSubscription struct {
+	ID                  uuid.UUID  `json:"id"`
+	TenantID            uuid.UUID  `json:"tenant_id"`
+	URL                 string     `json:"url"`
+	Description         string     `json:"description,omitempty"`
+	Events              []string   `json:"events"`
+	Secret              string     `json:"-"` // exibido apenas na criação
+	Active              bool       `json:"active"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	DisabledAt          *time.Time `json:"disabled_at,omitempty"`
+	DisabledReason      string     `json:"disabled_reason,omitempty"`
+	LastDeliveryAt      *time.Time `json:"last_delivery_at,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+// NewWebhookSubscription cria inscrição ativa validando URL e filtros de eventos
+func NewWebhookSubscription(tenantID uuid.UUID, rawURL, description string, events []string, allowInsecure bool) (*WebhookSubscription, error) {
+	if err := ValidateWebhookURL(rawURL, allowInsecure); err != nil {
+		return nil, err
+	}
+	if err := ValidateWebhookEventFilters(events); err != nil {
+		return nil, err
+	}
+
+	secret, err := GenerateWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &WebhookSubscription{
+		ID:          uuid.New(),
+		TenantID:    tenantID,
+		URL:         strings.TrimSpace(rawURL),
+		Description: strings.TrimSpace(description),
+		Events:      events,
+		Secret:      secret,
+		Active:      true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// Accepts indica se a inscrição deve receber o evento
+func (s *WebhookSubscription) Accepts(eventType string) bool {
+	if !s.Active {
+		return false
+	}
+	for _, filter := range s.Events {
+		if webhookFilterMatches(filter, eventType) {
+			return true
+		}
+	}
+	return false
+}
+
+// RecordSuccess zera as falhas consecutivas após uma entrega aceita
+func (s *WebhookSubscription) RecordSuccess(now time.Time) {
+	s.ConsecutiveFailures = 0
+	s.LastDeliveryAt = &now
+	s.UpdatedAt = now
+}
+
+// RecordFailure conta a tentativa com falha e desativa a inscrição ao atingir o limite de falhas
+// consecutivas. Retorna true quando a inscrição foi desativada.
+func (s *WebhookSubscription) RecordFailure(now time.Time, disableAfter int) bool {
+	s.ConsecutiveFailures++
+	s.LastDeliveryAt = &now
+	s.UpdatedAt = now
+
+	if s.Active && disableAfter > 0 && s.ConsecutiveFailures >= disableAfter {
+		s.Disable(now, fmt.Sprintf("%d falhas consecutivas", s.ConsecutiveFailures))
+		return true
+	}
+	return false
+}
+
+// Disable desativa a inscrição
+func (s *WebhookSubscription) Disable(now time.Time, reason string) {
+	s.Active = false
+	s.DisabledAt = &now
+	s.DisabledReason = reason
+	s.UpdatedAt = now
+}
+
+// Enable reativa a inscrição e zera as falhas
+func (s *WebhookSubscription) Enable(now time.Time) {
+	s.Active = true
+	s.ConsecutiveFailures = 0
+	s.DisabledAt = nil
+	s.DisabledReason = ""
+	s.UpdatedAt = now
+}
+
+// WebhookEvent evento recebido dos outros serviços para entrega aos tenants
+type WebhookEvent struct {
+	ID         uuid.UUID       `json:"id"`
+	Type       string          `json:"type"`
+	TenantID   uuid.UUID       `json:"tenant_id"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// WebhookDeliveryStatus situação da entrega
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"   // aguardando a primeira tentativa ou nova tentativa
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded" // resposta 2xx
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"    // tentativas esgotadas ou inscrição desativada
+)
+
+// WebhookDelivery entrega de um evento a uma inscrição, com o resultado da última tentativa
+type WebhookDelivery struct {
+	ID             uuid.UUID             `json:"id"`
+	SubscriptionID uuid.UUID             `json:"subscription_id"`
+	TenantID       uuid.UUID             `json:"tenant_id"`
+	EventID        uuid.UUID             `json:"event_id"`
+	EventType      string                `json:"event_type"`
+	Payload        json.RawMessage       `json:"payload"` // corpo enviado, igual em todas as tentativas
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	NextAttemptAt  *time.Time            `json:"next_attempt_at,omitempty"`
+	ResponseStatus int                   `json:"response_status,omitempty"`
+	ResponseBody   string                `json:"response_body,omitempty"`
+	LastError      string                `json:"last_error,omitempty"`
+	DurationMs     int64                 `json:"duration_ms"`
+	ReplayOf       *uuid.UUID            `json:"replay_of,omitempty"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty"`
+}
+
+// NewWebhookDelivery cria entrega pendente do evento para a inscrição
+func NewWebhookDelivery(subscription *WebhookSubscription, event *WebhookEvent) (*WebhookDelivery, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal webhook event: %w", err)
+	}
+
+	now := time.Now()
+	return &WebhookDelivery{
+		ID:             uuid.New(),
+		SubscriptionID: subscription.ID,
+		TenantID:       subscription.TenantID,
+		EventID:        event.ID,
+		EventType:      event.Type,
+		Payload:        payload,
+		Status:         WebhookDeliveryPending,
+		NextAttemptAt:  &now,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}, nil
+}
+
+// Replay cria nova entrega pendente com o mesmo corpo
+func (d *WebhookDelivery) Replay() *WebhookDelivery {
+	now := time.Now()
+	return &WebhookDelivery{
+		ID:             uuid.New(),
+		SubscriptionID: d.SubscriptionID,
+		TenantID:       d.TenantID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Payload:        d.Payload,
+		Status:         WebhookDeliveryPending,
+		NextAttemptAt:  &now,
+		ReplayOf:       &d.ID,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// WebhookAttemptResult resultado de uma tentativa de entrega
+type WebhookAttemptResult struct {
+	StatusCode int
+	Body       string
+	Error      string
+	Duration   time.Duration
+}
+
+// Succeeded indica resposta 2xx
+func (r WebhookAttemptResult) Succeeded() bool {
+	return r.Error == "" && r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// Gone indica que o destino pediu o cancelamento da inscrição (410)
+func (r WebhookAttemptResult) Gone() bool {
+	return r.StatusCode == 410
+}
+
+// WebhookRetryPolicy novas tentativas com backoff exponencial
+type WebhookRetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// Delay espera antes da próxima tentativa, após attempt tentativas com falha
+func (p WebhookRetryPolicy) Delay(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if p.MaxDelay > 0 && delay >= p.MaxDelay {
+			return p.MaxDelay
+		}
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return delay
+}
+
+// RecordAttempt registra o resultado da tentativa e agenda a próxima, quando houver. Retorna
+// true quando a entrega terminou (sucesso ou tentativas esgotadas).
+func (d *WebhookDelivery) RecordAttempt(result WebhookAttemptResult, policy WebhookRetryPolicy, now time.Time) bool {
+	d.Attempts++
+	d.ResponseStatus = result.StatusCode
+	d.ResponseBody = truncateWebhookBody(result.Body)
+	d.LastError = result.Error
+	d.DurationMs = result.Duration.Milliseconds()
+	d.UpdatedAt = now
+
+	if result.Succeeded() {
+		d.Status = WebhookDeliverySucceeded
+		d.NextAttemptAt = nil
+		d.DeliveredAt = &now
+		return true
+	}
+
+	if result.Gone() || d.Attempts >= policy.MaxAttempts {
+		d.Fail(now, "")
+		return true
+	}
+
+	next := now.Add(policy.Delay(d.Attempts))
+	d.Status = WebhookDeliveryPending
+	d.NextAttemptAt = &next
+	return false
+}
+
+// Fail encerra a entrega sem novas tentativas
+func (d *WebhookDelivery) Fail(now time.Time, reason string) {
+	d.Status = WebhookDeliveryFailed
+	d.NextAttemptAt = nil
+	if reason != "" {
+		d.LastError = reason
+	}
+	d.UpdatedAt = now
+}
+
+func truncateWebhookBody(body string) string {
+	if len(body) <= maxWebhookResponseBody {
+		return body
+	}
+	return body[:maxWebhookResponseBody]
+}
+
+// WebhookDeliveryFilter filtros do histórico de entregas
+type WebhookDeliveryFilter struct {
+	Status    *WebhookDeliveryStatus
+	EventType string
+	Limit     int
+	Offset    int
+}
+
+// WebhookSender envia a entrega assinada ao destino
+type WebhookSender interface {
+	Send(ctx context.Context, url, secret string, delivery *WebhookDelivery) WebhookAttemptResult
+}
+
+// TenantPlanLimits recursos e limites do plano do tenant relevantes para os webhooks
+type TenantPlanLimits struct {
+	Plan            string
+	WebhooksEnabled bool
+	MaxWebhooks     int // -1: ilimitado
+}
+
+// AllowsWebhooks verifica se o plano comporta mais uma inscrição ativa
+func (l *TenantPlanLimits) AllowsWebhooks(active int) error {
+	if !l.WebhooksEnabled {
+		return fmt.Errorf("%w: plano %s", ErrWebhooksNotAllowed, l.Plan)
+	}
+	if l.MaxWebhooks >= 0 && active >= l.MaxWebhooks {
+		return fmt.Errorf("%w: limite de %d webhooks do plano %s", ErrWebhookQuotaExceeded, l.MaxWebhooks, l.Plan)
+	}
+	return nil
+}
+
+// TenantPlanDirectory consulta o plano do tenant no tenant-service
+type TenantPlanDirectory interface {
+	GetPlanLimits(ctx context.Context, tenantID uuid.UUID) (*TenantPlanLimits, error)
+}
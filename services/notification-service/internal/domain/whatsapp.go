@@ -0,0 +1,169 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WhatsAppSessionWindow janela de atendimento: mensagens livres só podem ser enviadas até 24
+// horas após a última mensagem recebida do contato; fora dela, apenas templates aprovados
+const WhatsAppSessionWindow = 24 * time.Hour
+
+// WhatsAppTemplateStatus status de aprovação do template na Meta
+type WhatsAppTemplateStatus string
+
+const (
+	WhatsAppTemplateStatusPending  WhatsAppTemplateStatus = "PENDING"
+	WhatsAppTemplateStatusApproved WhatsAppTemplateStatus = "APPROVED"
+	WhatsAppTemplateStatusRejected WhatsAppTemplateStatus = "REJECTED"
+	WhatsAppTemplateStatusPaused   WhatsAppTemplateStatus = "PAUSED"
+	WhatsAppTemplateStatusDisabled WhatsAppTemplateStatus = "DISABLED"
+)
+
+var (
+	whatsAppTemplateNamePattern = regexp.MustCompile(`^[a-z0-9_]{1,512}$`)
+	whatsAppSpacesPattern       = regexp.MustCompile(`\s+`)
+)
+
+// WhatsAppTemplateMapping associa um NotificationTemplate, em um idioma, a um template
+// registrado na conta WhatsApp Business. Parameters são as expressões ({{variavel | filtro}} sem
+// as chaves) renderizadas em ordem para {{1}}, {{2}}, ... do corpo do template.
+type WhatsAppTemplateMapping struct {
+	ID             uuid.UUID              `json:"id"`
+	TemplateID     uuid.UUID              `json:"template_id"`
+	Locale         string                 `json:"locale"`
+	Name           string                 `json:"name"`
+	Language       string                 `json:"language"` // código da Meta (pt_BR, en_US)
+	Parameters     []string               `json:"parameters"`
+	Status         WhatsAppTemplateStatus `json:"status"`
+	RejectedReason *string                `json:"rejected_reason,omitempty"`
+	SyncedAt       *time.Time             `json:"synced_at,omitempty"`
+	CreatedAt      time.Time              `json:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+}
+
+// NewWhatsAppTemplateMapping cria mapeamento pendente de sincronização com a Meta. Sem language,
+// usa o código da Meta equivalente ao locale (pt-BR → pt_BR).
+func NewWhatsAppTemplateMapping(templateID uuid.UUID, locale, name, language string, parameters []string) (*WhatsAppTemplateMapping, error) {
+	normalizedLocale, err := NormalizeLocale(locale)
+	if err != nil {
+		return nil, err
+	}
+
+	if !whatsAppTemplateNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("%w: nome de template do WhatsApp inválido: %s", ErrTemplateInvalid, name)
+	}
+
+	if language == "" {
+		language = strings.ReplaceAll(normalizedLocale, "-", "_")
+	}
+
+	for i, parameter := range parameters {
+		if _, err := ParseTemplate("{{" + parameter + "}}"); err != nil {
+			return nil, fmt.Errorf("parâmetro %d: %w", i+1, err)
+		}
+	}
+
+	now := time.Now()
+	return &WhatsAppTemplateMapping{
+		ID:         uuid.New(),
+		TemplateID: templateID,
+		Locale:     normalizedLocale,
+		Name:       name,
+		Language:   language,
+		Parameters: parameters,
+		Status:     WhatsAppTemplateStatusPending,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}, nil
+}
+
+// IsApproved verifica se o template pode ser enviado
+func (m *WhatsAppTemplateMapping) IsApproved() bool {
+	return m.Status == WhatsAppTemplateStatusApproved
+}
+
+// RenderParameters renderiza os parâmetros do corpo com as variáveis da notificação. A Meta
+// rejeita parâmetros vazios e com quebras de linha, então ausência de variável é erro e
+// espaços em sequência viram um só.
+func (m *WhatsAppTemplateMapping) RenderParameters(variables map[string]interface{}) ([]string, error) {
+	values := make([]string, 0, len(m.Parameters))
+	for i, parameter := range m.Parameters {
+		value, _, err := RenderTemplate("{{"+parameter+"}}", variables, TemplateRenderOptions{Strict: true})
+		if err != nil {
+			return nil, fmt.Errorf("parâmetro %d do template %s: %w", i+1, m.Name, err)
+		}
+
+		value = strings.TrimSpace(whatsAppSpacesPattern.ReplaceAllString(value, " "))
+		if value == "" {
+			return nil, fmt.Errorf("%w: parâmetro %d do template %s vazio", ErrTemplateMissingVariable, i+1, m.Name)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// SelectWhatsAppTemplate escolhe o template aprovado para o idioma da notificação: o mesmo
+// locale, depois o mesmo idioma base e por fim qualquer aprovado. Retorna nil sem aprovados.
+func SelectWhatsAppTemplate(mappings []*WhatsAppTemplateMapping, locale string) *WhatsAppTemplateMapping {
+	var sameLanguage, first *WhatsAppTemplateMapping
+	for _, m := range mappings {
+		if !m.IsApproved() {
+			continue
+		}
+		switch {
+		case m.Locale == locale:
+			return m
+		case sameLanguage == nil && localeLanguage(m.Locale) == localeLanguage(locale):
+			sameLanguage = m
+		case first == nil:
+			first = m
+		}
+	}
+
+	if sameLanguage != nil {
+		return sameLanguage
+	}
+	return first
+}
+
+// WhatsAppRegisteredTemplate template registrado na conta WhatsApp Business, com o status da Meta
+type WhatsAppRegisteredTemplate struct {
+	Name           string                 `json:"name"`
+	Language       string                 `json:"language"`
+	Status         WhatsAppTemplateStatus `json:"status"`
+	Category       string                 `json:"category,omitempty"`
+	RejectedReason string                 `json:"rejected_reason,omitempty"`
+}
+
+// WhatsAppSessionOpen verifica se a janela de atendimento de 24 horas está aberta
+func WhatsAppSessionOpen(lastInbound *time.Time, now time.Time) bool {
+	return lastInbound != nil && now.Sub(*lastInbound) < WhatsAppSessionWindow
+}
+
+// NormalizeWhatsAppContact chave do contato para a janela de atendimento. O WhatsApp informa o
+// remetente sem "+" e, em números brasileiros antigos, sem o nono dígito; os dois formatos são
+// levados ao E.164 do cadastro.
+func NormalizeWhatsAppContact(contact string) string {
+	if phone, err := NormalizeBrazilianPhone(contact); err == nil {
+		return phone
+	}
+
+	var digits strings.Builder
+	for _, r := range contact {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+
+	number := digits.String()
+	if !strings.HasPrefix(strings.TrimSpace(contact), "+") && !strings.HasPrefix(number, "55") && len(number) <= 11 {
+		// Fixo brasileiro com DDD, sem código do país
+		number = "55" + number
+	}
+	return "+" + number
+}
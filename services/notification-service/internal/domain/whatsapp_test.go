@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestWhatsAppSessionOpen(t *testing.T) {
+	now := time.Now()
+	recent := now.Add(-23 * time.Hour)
+	expired := now.Add(-WhatsAppSessionWindow)
+
+	if WhatsAppSessionOpen(nil, now) {
+		t.Error("session open without inbound message")
+	}
+	if !WhatsAppSessionOpen(&recent, now) {
+		t.Error("session closed 23h after inbound message")
+	}
+	if WhatsAppSessionOpen(&expired, now) {
+		t.Error("session open 24h after inbound message")
+	}
+}
+
+func TestNormalizeWhatsAppContact(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"+55 (11) 98765-4321", "+5511987654321"},
+		{"5511987654321", "+5511987654321"},
+		{"551187654321", "+5511987654321"}, // wa_id sem o nono dígito
+		{"+14155550123", "+14155550123"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeWhatsAppContact(tt.input); got != tt.want {
+			t.Errorf("NormalizeWhatsAppContact(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestNewWhatsAppTemplateMapping(t *testing.T) {
+	templateID := uuid.New()
+
+	m, err := NewWhatsAppTemplateMapping(templateID, "en_us", "nova_movimentacao", "", []string{"nome", "numero | cnj"})
+	if err != nil {
+		t.Fatalf("NewWhatsAppTemplateMapping() error = %v", err)
+	}
+	if m.Locale != "en-US" || m.Language != "en_US" || m.Status != WhatsAppTemplateStatusPending {
+		t.Errorf("NewWhatsAppTemplateMapping() = %+v", m)
+	}
+
+	if _, err := NewWhatsAppTemplateMapping(templateID, "", "Nova Movimentação", "", nil); !errors.Is(err, ErrTemplateInvalid) {
+		t.Errorf("invalid name error = %v", err)
+	}
+	if _, err := NewWhatsAppTemplateMapping(templateID, "", "prazo", "", []string{"nome |"}); !errors.Is(err, ErrTemplateInvalid) {
+		t.Errorf("invalid parameter error = %v", err)
+	}
+}
+
+func TestWhatsAppTemplateMappingRenderParameters(t *testing.T) {
+	m := &WhatsAppTemplateMapping{Name: "prazo", Parameters: []string{"nome", "descricao"}}
+
+	values, err := m.RenderParameters(map[string]interface{}{
+		"nome":      "Maria",
+		"descricao": "Prazo\n\n  de    contestação",
+	})
+	if err != nil {
+		t.Fatalf("RenderParameters() error = %v", err)
+	}
+	if len(values) != 2 || values[0] != "Maria" || values[1] != "Prazo de contestação" {
+		t.Errorf("RenderParameters() = %q", values)
+	}
+
+	if _, err := m.RenderParameters(map[string]interface{}{"nome": "Maria"}); !errors.Is(err, ErrTemplateMissingVariable) {
+		t.Errorf("missing variable error = %v", err)
+	}
+	if _, err := m.RenderParameters(map[string]interface{}{"nome": "Maria", "descricao": " "}); !errors.Is(err, ErrTemplateMissingVariable) {
+		t.Errorf("empty parameter error = %v", err)
+	}
+}
+
+func TestSelectWhatsAppTemplate(t *testing.T) {
+	ptBR := &WhatsAppTemplateMapping{Locale: "pt-BR", Status: WhatsAppTemplateStatusApproved}
+	ptPT := &WhatsAppTemplateMapping{Locale: "pt-PT", Status: WhatsAppTemplateStatusApproved}
+	en := &WhatsAppTemplateMapping{Locale: "en", Status: WhatsAppTemplateStatusRejected}
+
+	tests := []struct {
+		locale string
+		want   *WhatsAppTemplateMapping
+	}{
+		{"pt-PT", ptPT},
+		{"pt-AO", ptBR}, // mesmo idioma
+		{"en", ptBR},    // versão em inglês rejeitada: primeiro aprovado
+	}
+
+	mappings := []*WhatsAppTemplateMapping{ptBR, ptPT, en}
+	for _, tt := range tests {
+		if got := SelectWhatsAppTemplate(mappings, tt.locale); got != tt.want {
+			t.Errorf("SelectWhatsAppTemplate(%q) = %v, want %v", tt.locale, got.Locale, tt.want.Locale)
+		}
+	}
+
+	if got := SelectWhatsAppTemplate([]*WhatsAppTemplateMapping{en}, "en"); got != nil {
+		t.Errorf("SelectWhatsAppTemplate() = %v, want nil without approved templates", got.Locale)
+	}
+}
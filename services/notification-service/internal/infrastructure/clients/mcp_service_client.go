@@ -0,0 +1,101 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// MCPServiceClient cliente HTTP do assistente do mcp-service (domain.ConversationalAssistant)
+type MCPServiceClient struct {
+	baseURL string
+	token   string
+	client  *http.Client
+	logger  *zap.Logger
+}
+
+// NewMCPServiceClient cria nova instância do cliente
+func NewMCPServiceClient(baseURL, token string, timeout time.Duration, logger *zap.Logger) *MCPServiceClient {
+	if timeout <= 0 {
+		timeout = 12 * time.Second
+	}
+
+	return &MCPServiceClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		client:  &http.Client{Timeout: timeout},
+		logger:  logger,
+	}
+}
+
+// assistantMessageRequest corpo de POST /api/v1/assistant/messages
+type assistantMessageRequest struct {
+	TenantID   string `json:"tenant_id"`
+	UserID     string `json:"user_id"`
+	Channel    string `json:"channel"`
+	ExternalID string `json:"external_id"`
+	Text       string `json:"text"`
+}
+
+// assistantMessageResponse resposta de POST /api/v1/assistant/messages
+type assistantMessageResponse struct {
+	SessionID string   `json:"session_id"`
+	Reply     string   `json:"reply"`
+	ToolsUsed []string `json:"tools_used"`
+}
+
+// Reply encaminha a mensagem ao assistente e retorna a resposta
+func (c *MCPServiceClient) Reply(ctx context.Context, message *domain.AssistantMessage) (string, error) {
+	body, err := json.Marshal(assistantMessageRequest{
+		TenantID:   message.TenantID.String(),
+		UserID:     message.UserID.String(),
+		Channel:    string(message.Channel),
+		ExternalID: message.ExternalID,
+		Text:       message.Text,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal assistant message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/api/v1/assistant/messages", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: mcp service: %v", domain.ErrProviderUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		c.logger.Warn("MCP service request failed",
+			zap.String("channel", string(message.Channel)),
+			zap.Int("status_code", resp.StatusCode))
+		return "", fmt.Errorf("%w: mcp service status %d", domain.ErrProviderUnavailable, resp.StatusCode)
+	}
+
+	var response assistantMessageResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	c.logger.Debug("Assistant replied",
+		zap.String("session_id", response.SessionID),
+		zap.Strings("tools_used", response.ToolsUsed))
+
+	return response.Reply, nil
+}
@@ -0,0 +1,114 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// ProcessServiceClient cliente HTTP do process-service (domain.ProcessDirectory)
+type ProcessServiceClient struct {
+	baseURL string
+	client  *http.Client
+	logger  *zap.Logger
+}
+
+// NewProcessServiceClient cria nova instância do cliente
+func NewProcessServiceClient(baseURL string, timeout time.Duration, logger *zap.Logger) *ProcessServiceClient {
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+
+	return &ProcessServiceClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: timeout},
+		logger:  logger,
+	}
+}
+
+// processStatsResponse resposta de GET /api/v1/processes/stats
+type processStatsResponse struct {
+	Data domain.ProcessStats `json:"data"`
+}
+
+// processDeadlinesResponse resposta de GET /api/v1/processes/deadlines
+type processDeadlinesResponse struct {
+	Data  []*domain.ProcessDeadline `json:"data"`
+	Total int                       `json:"total"`
+}
+
+// processSummaryResponse resposta de GET /api/v1/processes/number/:number
+type processSummaryResponse struct {
+	Data domain.ProcessSummary `json:"data"`
+}
+
+// GetStats retorna o resumo dos processos do tenant
+func (c *ProcessServiceClient) GetStats(ctx context.Context, tenantID uuid.UUID) (*domain.ProcessStats, error) {
+	var response processStatsResponse
+	if err := c.get(ctx, tenantID, "/api/v1/processes/stats", &response); err != nil {
+		return nil, fmt.Errorf("failed to get process stats: %w", err)
+	}
+	return &response.Data, nil
+}
+
+// ListUpcomingDeadlines lista os prazos dos próximos dias
+func (c *ProcessServiceClient) ListUpcomingDeadlines(ctx context.Context, tenantID uuid.UUID, days int) ([]*domain.ProcessDeadline, error) {
+	var response processDeadlinesResponse
+	path := "/api/v1/processes/deadlines?days=" + strconv.Itoa(days)
+	if err := c.get(ctx, tenantID, path, &response); err != nil {
+		return nil, fmt.Errorf("failed to list process deadlines: %w", err)
+	}
+	return response.Data, nil
+}
+
+// FindByNumber busca o processo do tenant pelo número CNJ
+func (c *ProcessServiceClient) FindByNumber(ctx context.Context, tenantID uuid.UUID, number string) (*domain.ProcessSummary, error) {
+	var response processSummaryResponse
+	path := "/api/v1/processes/number/" + url.PathEscape(number)
+	if err := c.get(ctx, tenantID, path, &response); err != nil {
+		return nil, fmt.Errorf("failed to find process by number: %w", err)
+	}
+	return &response.Data, nil
+}
+
+// get executa a consulta no contexto do tenant
+func (c *ProcessServiceClient) get(ctx context.Context, tenantID uuid.UUID, path string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("X-Tenant-ID", tenantID.String())
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return domain.ErrProcessNotFound
+	case resp.StatusCode != http.StatusOK:
+		c.logger.Warn("Process service request failed",
+			zap.String("path", path),
+			zap.Int("status_code", resp.StatusCode))
+		return fmt.Errorf("%w: process service status %d", domain.ErrProviderUnavailable, resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
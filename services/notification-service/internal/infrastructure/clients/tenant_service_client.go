@@ -0,0 +1,82 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// TenantServiceClient cliente HTTP do tenant-service (domain.TenantPlanDirectory)
+type TenantServiceClient struct {
+	baseURL string
+	client  *http.Client
+	logger  *zap.Logger
+}
+
+// NewTenantServiceClient cria nova instância do cliente
+func NewTenantServiceClient(baseURL string, timeout time.Duration, logger *zap.Logger) *TenantServiceClient {
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+
+	return &TenantServiceClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: timeout},
+		logger:  logger,
+	}
+}
+
+// tenantPlanResponse resposta de GET /api/v1/tenant/plan
+type tenantPlanResponse struct {
+	Data struct {
+		Plan     string `json:"plan"`
+		Features struct {
+			WebhooksEnabled bool `json:"webhooks_enabled"`
+		} `json:"features"`
+		Quotas struct {
+			MaxWebhooks int `json:"max_webhooks"`
+		} `json:"quotas"`
+	} `json:"data"`
+}
+
+// GetPlanLimits retorna os recursos e limites do plano do tenant
+func (c *TenantServiceClient) GetPlanLimits(ctx context.Context, tenantID uuid.UUID) (*domain.TenantPlanLimits, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/api/v1/tenant/plan", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("X-Tenant-ID", tenantID.String())
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant plan: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		c.logger.Warn("Tenant service request failed",
+			zap.String("tenant_id", tenantID.String()),
+			zap.Int("status_code", resp.StatusCode))
+		return nil, fmt.Errorf("%w: tenant service status %d", domain.ErrProviderUnavailable, resp.StatusCode)
+	}
+
+	var response tenantPlanResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &domain.TenantPlanLimits{
+		Plan:            response.Data.Plan,
+		WebhooksEnabled: response.Data.Features.WebhooksEnabled,
+		MaxWebhooks:     response.Data.Quotas.MaxWebhooks,
+	}, nil
+}
@@ -40,6 +40,43 @@ type Config struct {
 
 	// Telegram
 	Telegram TelegramConfig
+
+	// SMS
+	SMS SMSConfig
+
+	// Web Push
+	Push PushConfig
+
+	// Fila de notificações
+	Queue QueueConfig
+
+	// Cadeias de fallback de canal
+	Fallback FallbackConfig
+
+	// Resumos de notificações
+	Digest DigestConfig
+
+	// Rastreamento de abertura e clique dos emails
+	Tracking TrackingConfig
+
+	// Process Service (consultas do bot do Telegram)
+	ProcessService ProcessServiceConfig
+
+	// Assistente conversacional (mcp-service) do Telegram e do WhatsApp
+	Assistant AssistantConfig
+
+	// Alertas de movimentação a partir dos eventos do process-service
+	MovementAlerts MovementAlertsConfig
+
+	// Registro de consentimento (LGPD) e descadastro
+	Consent ConsentConfig
+
+	// Custo dos envios por canal
+	Cost CostConfig
+
+	// Webhooks de saída dos tenants e plano no tenant-service
+	Webhooks      WebhooksConfig
+	TenantService TenantServiceConfig
 }
 
 // DatabaseConfig configurações do PostgreSQL
@@ -97,6 +134,24 @@ type SMTPConfig struct {
 	FromName    string `envconfig:"SMTP_FROM_NAME" default:"Direito Lux"`
 	UseTLS      bool   `envconfig:"SMTP_USE_TLS" default:"false"`
 	UseStartTLS bool   `envconfig:"SMTP_USE_STARTTLS" default:"true"`
+
+	// Pool de conexões reaproveitadas entre os envios
+	PoolSize           int `envconfig:"SMTP_POOL_SIZE" default:"4"`
+	PoolIdleTimeout    int `envconfig:"SMTP_POOL_IDLE_TIMEOUT" default:"30"` // segundos
+	MaxMessagesPerConn int `envconfig:"SMTP_MAX_MESSAGES_PER_CONNECTION" default:"100"`
+
+	// DKIM do remetente padrão; a chave aceita o PEM ou o caminho do arquivo. Os domínios próprios
+	// dos tenants têm chave gerada pelo serviço.
+	DKIMDomain     string `envconfig:"SMTP_DKIM_DOMAIN"`
+	DKIMSelector   string `envconfig:"SMTP_DKIM_SELECTOR" default:"direitolux"`
+	DKIMPrivateKey string `envconfig:"SMTP_DKIM_PRIVATE_KEY"`
+
+	// Hosts de onde os anexos por URL podem ser baixados, ex.: storage do report-service
+	AttachmentHosts []string `envconfig:"EMAIL_ATTACHMENT_HOSTS"`
+
+	// Segredo esperado no header X-Webhook-Secret dos webhooks de bounce/reclamação; sem ele, os
+	// webhooks são recusados
+	WebhookSecret string `envconfig:"EMAIL_WEBHOOK_SECRET"`
 }
 
 // WhatsAppConfig configurações do WhatsApp Business API
@@ -105,6 +160,15 @@ type WhatsAppConfig struct {
 	PhoneNumberID string `envconfig:"WHATSAPP_PHONE_NUMBER_ID" required:"true"`
 	WebhookURL    string `envconfig:"WHATSAPP_WEBHOOK_URL"`
 	VerifyToken   string `envconfig:"WHATSAPP_VERIFY_TOKEN" required:"true"`
+	// App secret da Meta: assinatura HMAC-SHA256 conferida em X-Hub-Signature-256
+	AppSecret string `envconfig:"WHATSAPP_APP_SECRET" required:"true"`
+
+	// Templates aprovados usados fora da janela de atendimento de 24 horas. O catálogo
+	// "graph" consulta a conta WhatsApp Business; "stub" aprova os templates mapeados
+	// (desenvolvimento local).
+	BusinessAccountID    string        `envconfig:"WHATSAPP_BUSINESS_ACCOUNT_ID"`
+	TemplateCatalog      string        `envconfig:"WHATSAPP_TEMPLATE_CATALOG" default:"graph"`
+	TemplateSyncInterval time.Duration `envconfig:"WHATSAPP_TEMPLATE_SYNC_INTERVAL" default:"1h"`
 }
 
 // TelegramConfig configurações do Telegram Bot API
@@ -112,6 +176,159 @@ type TelegramConfig struct {
 	BotToken      string `envconfig:"TELEGRAM_BOT_TOKEN" required:"true"`
 	WebhookURL    string `envconfig:"TELEGRAM_WEBHOOK_URL"`
 	WebhookSecret string `envconfig:"TELEGRAM_WEBHOOK_SECRET"`
+
+	// Bot interativo: @username usado no link de vinculação (t.me/<username>?start=<código>)
+	BotUsername      string        `envconfig:"TELEGRAM_BOT_USERNAME"`
+	LinkCodeTTL      time.Duration `envconfig:"TELEGRAM_LINK_CODE_TTL" default:"10m"`
+	CommandRateLimit int           `envconfig:"TELEGRAM_COMMAND_RATE_LIMIT" default:"10"` // comandos por minuto, por chat
+	// URL pública (https) do web app nos botões do bot; sem ela os botões de link são omitidos
+	WebAppURL string `envconfig:"TELEGRAM_WEB_APP_URL"`
+}
+
+// ProcessServiceConfig configurações de acesso ao process-service
+type ProcessServiceConfig struct {
+	URL     string        `envconfig:"PROCESS_SERVICE_URL" default:"http://process-service:8080"`
+	Timeout time.Duration `envconfig:"PROCESS_SERVICE_TIMEOUT" default:"5s"`
+}
+
+// AssistantConfig configurações do assistente conversacional do mcp-service
+type AssistantConfig struct {
+	Enabled bool          `envconfig:"ASSISTANT_ENABLED" default:"false"`
+	URL     string        `envconfig:"MCP_SERVICE_URL" default:"http://mcp-service:8088"`
+	Token   string        `envconfig:"MCP_SERVICE_TOKEN"` // igual a MCP_ASSISTANT_SERVICE_TOKEN do mcp-service
+	// Abaixo do WriteTimeout (15s) do servidor: a resposta é enviada durante o webhook
+	Timeout time.Duration `envconfig:"MCP_SERVICE_TIMEOUT" default:"12s"`
+	// Mensagens por minuto, por contato do WhatsApp
+	WhatsAppRateLimit int `envconfig:"ASSISTANT_WHATSAPP_RATE_LIMIT" default:"10"`
+}
+
+// MovementAlertsConfig configurações do consumo dos eventos de movimentação do process-service
+type MovementAlertsConfig struct {
+	Enabled  bool   `envconfig:"MOVEMENT_ALERTS_ENABLED" default:"true"`
+	Exchange string `envconfig:"MOVEMENT_ALERTS_EXCHANGE" default:"direito-lux.events"` // RABBITMQ_EXCHANGE do process-service
+	Queue    string `envconfig:"MOVEMENT_ALERTS_QUEUE" default:"notification.movement.alerts"`
+	Prefetch int    `envconfig:"MOVEMENT_ALERTS_PREFETCH" default:"10"`
+}
+
+// ConsentConfig configurações do registro de consentimento (LGPD); sem URL base ou segredo os
+// emails são enviados sem link de descadastro
+type ConsentConfig struct {
+	// Bloqueia envios sem consentimento (clientes) ou com consentimento revogado
+	EnforcementEnabled bool `envconfig:"CONSENT_ENFORCEMENT_ENABLED" default:"true"`
+	// URL pública do serviço usada no link de descadastro (ex.: https://api.direitolux.com.br/notifications)
+	UnsubscribeBaseURL string `envconfig:"UNSUBSCRIBE_BASE_URL"`
+	UnsubscribeSecret  string `envconfig:"UNSUBSCRIBE_SECRET"`
+}
+
+// CostConfig preço unitário dos envios por canal, registrado em cada notificação enviada para a
+// contabilização de custos nas análises
+type CostConfig struct {
+	Currency string  `envconfig:"NOTIFICATION_COST_CURRENCY" default:"BRL"`
+	Email    float64 `envconfig:"NOTIFICATION_COST_EMAIL" default:"0"`
+	WhatsApp float64 `envconfig:"NOTIFICATION_COST_WHATSAPP" default:"0"`
+	Telegram float64 `envconfig:"NOTIFICATION_COST_TELEGRAM" default:"0"`
+	SMS      float64 `envconfig:"NOTIFICATION_COST_SMS" default:"0"` // por segmento
+	Push     float64 `envconfig:"NOTIFICATION_COST_PUSH" default:"0"`
+}
+
+// WebhooksConfig configurações dos webhooks de saída dos tenants
+type WebhooksConfig struct {
+	Enabled bool `envconfig:"WEBHOOKS_ENABLED" default:"true"`
+	// Exchanges dos eventos: process-service (direito-lux.events) e billing-service (direito_lux.events)
+	Exchanges []string `envconfig:"WEBHOOKS_EXCHANGES" default:"direito-lux.events,direito_lux.events"`
+	Queue     string   `envconfig:"WEBHOOKS_QUEUE" default:"notification.tenant.webhooks"`
+	Prefetch  int      `envconfig:"WEBHOOKS_PREFETCH" default:"20"`
+
+	// Entrega: timeout por tentativa e novas tentativas em backoff exponencial
+	Timeout        time.Duration `envconfig:"WEBHOOKS_TIMEOUT" default:"10s"`
+	MaxAttempts    int           `envconfig:"WEBHOOKS_MAX_ATTEMPTS" default:"10"`
+	RetryBaseDelay time.Duration `envconfig:"WEBHOOKS_RETRY_BASE_DELAY" default:"1m"`
+	RetryMaxDelay  time.Duration `envconfig:"WEBHOOKS_RETRY_MAX_DELAY" default:"6h"`
+	// Tentativas com falha seguidas que desativam a inscrição
+	DisableAfterFailures int           `envconfig:"WEBHOOKS_DISABLE_AFTER_FAILURES" default:"20"`
+	PollInterval         time.Duration `envconfig:"WEBHOOKS_POLL_INTERVAL" default:"2s"`
+	BatchSize            int           `envconfig:"WEBHOOKS_BATCH_SIZE" default:"50"`
+
+	// Aceita URLs http e endereços locais ou privados (apenas desenvolvimento)
+	AllowInsecureURLs bool `envconfig:"WEBHOOKS_ALLOW_INSECURE_URLS" default:"false"`
+}
+
+// TenantServiceConfig configurações de acesso ao tenant-service; sem URL os limites do plano não
+// são verificados
+type TenantServiceConfig struct {
+	URL     string        `envconfig:"TENANT_SERVICE_URL"`
+	Timeout time.Duration `envconfig:"TENANT_SERVICE_TIMEOUT" default:"5s"`
+}
+
+// SMSConfig configurações do gateway de SMS
+type SMSConfig struct {
+	Gateway           string `envconfig:"SMS_GATEWAY" default:"http"`
+	GatewayURL        string `envconfig:"SMS_GATEWAY_URL"`
+	APIKey            string `envconfig:"SMS_GATEWAY_API_KEY"`
+	SenderID          string `envconfig:"SMS_SENDER_ID"`
+	StatusCallbackURL string `envconfig:"SMS_STATUS_CALLBACK_URL"`
+	MaxSegments       int    `envconfig:"SMS_MAX_SEGMENTS" default:"4"`
+	Transliterate     bool   `envconfig:"SMS_TRANSLITERATE" default:"true"`
+	OptOutHint        string `envconfig:"SMS_OPT_OUT_HINT" default:"Responda SAIR para cancelar."`
+
+	// Segredo esperado no header X-Webhook-Secret dos webhooks de status e de respostas; sem ele,
+	// os webhooks são recusados
+	WebhookSecret string `envconfig:"SMS_WEBHOOK_SECRET"`
+}
+
+// PushConfig configurações do Web Push (VAPID); sem chaves o canal fica desativado
+type PushConfig struct {
+	VAPIDPublicKey  string        `envconfig:"VAPID_PUBLIC_KEY"`
+	VAPIDPrivateKey string        `envconfig:"VAPID_PRIVATE_KEY"`
+	VAPIDSubject    string        `envconfig:"VAPID_SUBJECT" default:"mailto:contato@direitolux.com.br"`
+	TTL             time.Duration `envconfig:"PUSH_TTL" default:"24h"`
+	IconURL         string        `envconfig:"PUSH_ICON_URL"`
+
+	// URL do frontend usada no clique da notificação (página do processo)
+	ClickBaseURL string `envconfig:"PUSH_CLICK_BASE_URL" default:"http://localhost:3000"`
+}
+
+// Enabled indica se as chaves VAPID estão configuradas
+func (c PushConfig) Enabled() bool {
+	return c.VAPIDPublicKey != "" && c.VAPIDPrivateKey != ""
+}
+
+// QueueConfig configurações da fila de notificações
+type QueueConfig struct {
+	VisibilityTimeout    time.Duration `envconfig:"QUEUE_VISIBILITY_TIMEOUT" default:"5m"`
+	PollInterval         time.Duration `envconfig:"QUEUE_POLL_INTERVAL" default:"1s"`
+	MaxWorkersPerChannel int           `envconfig:"QUEUE_MAX_WORKERS_PER_CHANNEL" default:"10"`
+	ShutdownTimeout      time.Duration `envconfig:"QUEUE_SHUTDOWN_TIMEOUT" default:"30s"`
+
+	// Limite por tenant em cada canal (mensagens por minuto; 0 desativa)
+	TenantRateLimit int `envconfig:"NOTIFICATION_TENANT_RATE_LIMIT" default:"30"`
+}
+
+// FallbackConfig configurações das cadeias de fallback de canal
+type FallbackConfig struct {
+	Enabled bool `envconfig:"FALLBACK_ENABLED" default:"true"`
+
+	// Tempo de espera pelo recibo de entrega antes de escalar para o próximo canal
+	StepTimeout time.Duration `envconfig:"FALLBACK_STEP_TIMEOUT" default:"10m"`
+}
+
+// DigestConfig configurações do envio de resumos
+type DigestConfig struct {
+	FlushInterval time.Duration `envconfig:"DIGEST_FLUSH_INTERVAL" default:"1m"`
+	BatchSize     int           `envconfig:"DIGEST_BATCH_SIZE" default:"500"`
+}
+
+// TrackingConfig configurações do rastreamento de abertura e clique; sem URL base ou segredo
+// os emails são enviados sem pixel e sem links rastreados
+type TrackingConfig struct {
+	// URL pública do serviço usada no pixel e nos links (ex.: https://api.direitolux.com.br/notifications)
+	BaseURL string `envconfig:"TRACKING_BASE_URL"`
+	Secret  string `envconfig:"TRACKING_SECRET"`
+}
+
+// Enabled indica se o rastreamento está configurado
+func (c TrackingConfig) Enabled() bool {
+	return c.BaseURL != "" && c.Secret != ""
 }
 
 // Load carrega configuração a partir de variáveis de ambiente
@@ -125,9 +342,18 @@ func Load() (*Config, error) {
 	return &cfg, nil
 }
 
-// NewConfig cria nova instância de configuração
+// NewConfig cria nova instância de configuração validada
 func NewConfig() (*Config, error) {
-	return Load()
+	cfg, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
 }
 
 // Validate valida a configuração
@@ -135,6 +361,35 @@ func (c *Config) Validate() error {
 	if c.Port < 1 || c.Port > 65535 {
 		return fmt.Errorf("porta inválida: %d", c.Port)
 	}
+
+	if c.Queue.VisibilityTimeout <= 0 {
+		return fmt.Errorf("visibility timeout da fila inválido: %s", c.Queue.VisibilityTimeout)
+	}
+
+	if c.Queue.MaxWorkersPerChannel < 1 {
+		return fmt.Errorf("número de workers por canal inválido: %d", c.Queue.MaxWorkersPerChannel)
+	}
+
+	if c.SMS.MaxSegments < 1 {
+		return fmt.Errorf("limite de segmentos de SMS inválido: %d", c.SMS.MaxSegments)
+	}
+
+	if (c.Push.VAPIDPublicKey == "") != (c.Push.VAPIDPrivateKey == "") {
+		return fmt.Errorf("VAPID_PUBLIC_KEY e VAPID_PRIVATE_KEY devem ser configuradas juntas")
+	}
+
+	if c.Digest.FlushInterval <= 0 {
+		return fmt.Errorf("intervalo de envio dos resumos inválido: %s", c.Digest.FlushInterval)
+	}
+
+	if c.Fallback.Enabled && c.Fallback.StepTimeout <= 0 {
+		return fmt.Errorf("timeout da etapa de fallback inválido: %s", c.Fallback.StepTimeout)
+	}
+
+	// O webhook do bot recusa updates sem o secret no header X-Telegram-Bot-Api-Secret-Token
+	if c.Telegram.BotToken != "" && c.Telegram.WebhookSecret == "" {
+		return fmt.Errorf("TELEGRAM_WEBHOOK_SECRET é obrigatório com o bot do Telegram habilitado")
+	}
 	
 	return nil
 }
@@ -0,0 +1,257 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/streadway/amqp"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+	"github.com/direito-lux/notification-service/internal/infrastructure/config"
+)
+
+// movementReconnectDelay intervalo entre tentativas de reconexão ao RabbitMQ
+const movementReconnectDelay = 5 * time.Second
+
+// MovementEventHandler processa as movimentações recebidas do process-service
+type MovementEventHandler interface {
+	HandleMovement(ctx context.Context, event *domain.MovementEvent) error
+}
+
+// MovementConsumer consome os eventos movement.created e movement.important.detected do
+// exchange do process-service. Mensagens com falha são reentregues uma vez; mensagens
+// inválidas são descartadas.
+type MovementConsumer struct {
+	url     string
+	cfg     config.MovementAlertsConfig
+	handler MovementEventHandler
+	logger  *zap.Logger
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewMovementConsumer cria nova instância do consumidor
+func NewMovementConsumer(url string, cfg config.MovementAlertsConfig, handler MovementEventHandler, logger *zap.Logger) *MovementConsumer {
+	return &MovementConsumer{
+		url:     url,
+		cfg:     cfg,
+		handler: handler,
+		logger:  logger,
+	}
+}
+
+// Start inicia o consumo em segundo plano, reconectando quando a conexão cai
+func (c *MovementConsumer) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+
+	c.wg.Add(1)
+	go c.run(ctx)
+}
+
+// Stop interrompe o consumo e aguarda a mensagem em processamento
+func (c *MovementConsumer) Stop(ctx context.Context) error {
+	if c.cancel == nil {
+		return nil
+	}
+	c.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		c.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run loop de conexão e consumo
+func (c *MovementConsumer) run(ctx context.Context) {
+	defer c.wg.Done()
+
+	for {
+		if err := c.consume(ctx); err != nil {
+			c.logger.Error("Movement consumer disconnected", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(movementReconnectDelay):
+		}
+	}
+}
+
+// consume conecta, declara a fila e processa as mensagens até o contexto ser cancelado ou a
+// conexão cair
+func (c *MovementConsumer) consume(ctx context.Context) error {
+	conn, err := amqp.Dial(c.url)
+	if err != nil {
+		return fmt.Errorf("erro ao conectar no RabbitMQ: %w", err)
+	}
+	defer conn.Close()
+
+	channel, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("erro ao criar canal RabbitMQ: %w", err)
+	}
+	defer channel.Close()
+
+	if err := channel.Qos(c.cfg.Prefetch, 0, false); err != nil {
+		return fmt.Errorf("erro ao configurar prefetch: %w", err)
+	}
+
+	if err := channel.ExchangeDeclare(c.cfg.Exchange, "topic", true, false, false, false, nil); err != nil {
+		return fmt.Errorf("erro ao declarar exchange: %w", err)
+	}
+
+	if _, err := channel.QueueDeclare(c.cfg.Queue, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("erro ao declarar fila: %w", err)
+	}
+
+	for _, routingKey := range []string{domain.MovementEventCreated, domain.MovementEventImportantDetected} {
+		if err := channel.QueueBind(c.cfg.Queue, routingKey, c.cfg.Exchange, false, nil); err != nil {
+			return fmt.Errorf("erro ao vincular fila a %s: %w", routingKey, err)
+		}
+	}
+
+	deliveries, err := channel.Consume(c.cfg.Queue, "notification-service", false, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("erro ao consumir fila: %w", err)
+	}
+
+	c.logger.Info("Movement consumer started",
+		zap.String("exchange", c.cfg.Exchange),
+		zap.String("queue", c.cfg.Queue))
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case delivery, ok := <-deliveries:
+			if !ok {
+				return fmt.Errorf("canal de entregas fechado")
+			}
+			c.handle(ctx, delivery)
+		}
+	}
+}
+
+// handle processa a mensagem e confirma, reentrega ou descarta
+func (c *MovementConsumer) handle(ctx context.Context, delivery amqp.Delivery) {
+	event, err := ParseMovementEvent(delivery.Body)
+	if err != nil {
+		c.logger.Warn("Discarding invalid movement event",
+			zap.String("routing_key", delivery.RoutingKey),
+			zap.Error(err))
+		delivery.Nack(false, false)
+		return
+	}
+
+	if err := c.handler.HandleMovement(ctx, event); err != nil {
+		c.logger.Error("Failed to handle movement event",
+			zap.String("movement_id", event.MovementID),
+			zap.Bool("redelivered", delivery.Redelivered),
+			zap.Error(err))
+		delivery.Nack(false, !delivery.Redelivered)
+		return
+	}
+
+	delivery.Ack(false)
+}
+
+// movementEnvelope envelope publicado pelo process-service
+type movementEnvelope struct {
+	EventType string          `json:"event_type"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+// movementPayload campos de MovementCreatedEvent e ImportantMovementDetectedEvent. Em
+// movement.created, "type" é o tipo da movimentação.
+type movementPayload struct {
+	TenantID             string     `json:"tenant_id"`
+	OccurredAt           time.Time  `json:"occurred_at"`
+	ProcessID            string     `json:"process_id"`
+	ProcessNumber        string     `json:"process_number"`
+	MovementID           string     `json:"movement_id"`
+	Type                 string     `json:"type"`
+	Title                string     `json:"title"`
+	Date                 *time.Time `json:"date"`
+	IsImportant          bool       `json:"is_important"`
+	MovementTitle        string     `json:"movement_title"`
+	MovementType         string     `json:"movement_type"`
+	MovementDate         *time.Time `json:"movement_date"`
+	ImportanceLevel      int        `json:"importance_level"`
+	NotificationChannels []string   `json:"notification_channels"`
+	Keywords             []string   `json:"keywords"`
+}
+
+// ParseMovementEvent interpreta o envelope do process-service
+func ParseMovementEvent(body []byte) (*domain.MovementEvent, error) {
+	var envelope movementEnvelope
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidMovementEvent, err)
+	}
+
+	var payload movementPayload
+	if err := json.Unmarshal(envelope.Payload, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidMovementEvent, err)
+	}
+
+	tenantID, err := uuid.Parse(payload.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: tenant_id inválido: %v", domain.ErrInvalidMovementEvent, err)
+	}
+	processID, err := uuid.Parse(payload.ProcessID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: process_id inválido: %v", domain.ErrInvalidMovementEvent, err)
+	}
+	if payload.MovementID == "" {
+		return nil, fmt.Errorf("%w: movement_id ausente", domain.ErrInvalidMovementEvent)
+	}
+
+	event := &domain.MovementEvent{
+		Type:          envelope.EventType,
+		TenantID:      tenantID,
+		ProcessID:     processID,
+		ProcessNumber: payload.ProcessNumber,
+		MovementID:    payload.MovementID,
+		Keywords:      payload.Keywords,
+		OccurredAt:    payload.OccurredAt,
+	}
+
+	switch envelope.EventType {
+	case domain.MovementEventCreated:
+		event.Title = payload.Title
+		event.MovementType = payload.Type
+		event.Date = payload.Date
+		event.Important = payload.IsImportant
+	case domain.MovementEventImportantDetected:
+		event.Title = payload.MovementTitle
+		event.MovementType = payload.MovementType
+		event.Date = payload.MovementDate
+		event.Important = true
+		event.ImportanceLevel = payload.ImportanceLevel
+	default:
+		return nil, fmt.Errorf("%w: tipo de evento não suportado: %s", domain.ErrInvalidMovementEvent, envelope.EventType)
+	}
+
+	for _, channel := range payload.NotificationChannels {
+		if domain.ValidateChannel(domain.NotificationChannel(channel)) == nil {
+			event.Channels = append(event.Channels, domain.NotificationChannel(channel))
+		}
+	}
+
+	return event, nil
+}
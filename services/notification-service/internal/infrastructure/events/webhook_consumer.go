@@ -0,0 +1,320 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/streadway/amqp"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+	"github.com/direito-lux/notification-service/internal/infrastructure/config"
+)
+
+// webhookRoutingKeys routing keys dos eventos entregues aos webhooks. O process-service publica
+// process.synced com a routing key process.sync.completed.
+var webhookRoutingKeys = []string{
+	"process.created",
+	"process.updated",
+	"process.archived",
+	"process.sync.completed",
+	"movement.created",
+	"movement.important.detected",
+	"deadline.detected",
+	"invoice.*",
+	"payment.success",
+	"payment.failed",
+	"subscription.trial_ending",
+}
+
+// webhookEventNamespace namespace dos IDs dos eventos sem ID próprio, derivados do corpo da
+// mensagem para que reentregas do RabbitMQ não dupliquem as entregas
+var webhookEventNamespace = uuid.MustParse("5b0f1c9e-7a52-4d38-9a0e-2f6f1a7d3c41")
+
+// WebhookEventHandler processa os eventos entregues aos webhooks dos tenants
+type WebhookEventHandler interface {
+	HandleWebhookEvent(ctx context.Context, event *domain.WebhookEvent) error
+}
+
+// WebhookEventConsumer consome os eventos de processos, movimentações, prazos e cobrança dos
+// exchanges do process-service e do billing-service. Mensagens com falha são reentregues uma
+// vez; mensagens inválidas são descartadas.
+type WebhookEventConsumer struct {
+	url     string
+	cfg     config.WebhooksConfig
+	handler WebhookEventHandler
+	logger  *zap.Logger
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewWebhookEventConsumer cria nova instância do consumidor
+func NewWebhookEventConsumer(url string, cfg config.WebhooksConfig, handler WebhookEventHandler, logger *zap.Logger) *WebhookEventConsumer {
+	return &WebhookEventConsumer{
+		url:     url,
+		cfg:     cfg,
+		handler: handler,
+		logger:  logger,
+	}
+}
+
+// Start inicia o consumo em segundo plano, reconectando quando a conexão cai
+func (c *WebhookEventConsumer) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+
+	c.wg.Add(1)
+	go c.run(ctx)
+}
+
+// Stop interrompe o consumo e aguarda a mensagem em processamento
+func (c *WebhookEventConsumer) Stop(ctx context.Context) error {
+	if c.cancel == nil {
+		return nil
+	}
+	c.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		c.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run loop de conexão e consumo
+func (c *WebhookEventConsumer) run(ctx context.Context) {
+	defer c.wg.Done()
+
+	for {
+		if err := c.consume(ctx); err != nil {
+			c.logger.Error("Webhook event consumer disconnected", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(movementReconnectDelay):
+		}
+	}
+}
+
+// consume conecta, declara a fila vinculada a cada exchange e processa as mensagens até o
+// contexto ser cancelado ou a conexão cair
+func (c *WebhookEventConsumer) consume(ctx context.Context) error {
+	conn, err := amqp.Dial(c.url)
+	if err != nil {
+		return fmt.Errorf("erro ao conectar no RabbitMQ: %w", err)
+	}
+	defer conn.Close()
+
+	channel, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("erro ao criar canal RabbitMQ: %w", err)
+	}
+	defer channel.Close()
+
+	if err := channel.Qos(c.cfg.Prefetch, 0, false); err != nil {
+		return fmt.Errorf("erro ao configurar prefetch: %w", err)
+	}
+
+	if _, err := channel.QueueDeclare(c.cfg.Queue, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("erro ao declarar fila: %w", err)
+	}
+
+	for _, exchange := range c.cfg.Exchanges {
+		if err := channel.ExchangeDeclare(exchange, "topic", true, false, false, false, nil); err != nil {
+			return fmt.Errorf("erro ao declarar exchange %s: %w", exchange, err)
+		}
+		for _, routingKey := range webhookRoutingKeys {
+			if err := channel.QueueBind(c.cfg.Queue, routingKey, exchange, false, nil); err != nil {
+				return fmt.Errorf("erro ao vincular fila a %s/%s: %w", exchange, routingKey, err)
+			}
+		}
+	}
+
+	deliveries, err := channel.Consume(c.cfg.Queue, "notification-service-webhooks", false, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("erro ao consumir fila: %w", err)
+	}
+
+	c.logger.Info("Webhook event consumer started",
+		zap.Strings("exchanges", c.cfg.Exchanges),
+		zap.String("queue", c.cfg.Queue))
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case delivery, ok := <-deliveries:
+			if !ok {
+				return fmt.Errorf("canal de entregas fechado")
+			}
+			c.handle(ctx, delivery)
+		}
+	}
+}
+
+// handle processa a mensagem e confirma, reentrega ou descarta
+func (c *WebhookEventConsumer) handle(ctx context.Context, delivery amqp.Delivery) {
+	event, err := ParseWebhookEvent(delivery.Body)
+	if err != nil {
+		c.logger.Warn("Discarding invalid webhook event",
+			zap.String("routing_key", delivery.RoutingKey),
+			zap.Error(err))
+		delivery.Nack(false, false)
+		return
+	}
+	if event == nil {
+		// Evento que não é entregue aos webhooks
+		delivery.Ack(false)
+		return
+	}
+
+	if err := c.handler.HandleWebhookEvent(ctx, event); err != nil {
+		c.logger.Error("Failed to handle webhook event",
+			zap.String("event_type", event.Type),
+			zap.String("event_id", event.ID.String()),
+			zap.Bool("redelivered", delivery.Redelivered),
+			zap.Error(err))
+		delivery.Nack(false, !delivery.Redelivered)
+		return
+	}
+
+	delivery.Ack(false)
+}
+
+// webhookMessage campos dos dois formatos de mensagem: o envelope do process-service
+// (event_type, payload) e o BaseEvent do billing-service (id, type, tenant_id, timestamp)
+type webhookMessage struct {
+	EventType  string          `json:"event_type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Payload    json.RawMessage `json:"payload"`
+
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	TenantID  string    `json:"tenant_id"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// processEventBaseFields campos do BaseDomainEvent do process-service, já presentes no evento
+var processEventBaseFields = []string{"version", "aggregate_id", "occurred_at", "tenant_id"}
+
+// billingEventBaseFields campos do BaseEvent do billing-service, já presentes no evento
+var billingEventBaseFields = []string{"id", "tenant_id", "timestamp", "version", "data"}
+
+// ParseWebhookEvent interpreta a mensagem do process-service ou do billing-service. Retorna nil
+// sem erro para eventos que não são entregues aos webhooks.
+func ParseWebhookEvent(body []byte) (*domain.WebhookEvent, error) {
+	var message webhookMessage
+	if err := json.Unmarshal(body, &message); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidWebhookEvent, err)
+	}
+
+	if message.EventType != "" {
+		return parseProcessWebhookEvent(body, message)
+	}
+	return parseBillingWebhookEvent(body, message)
+}
+
+// parseProcessWebhookEvent evento do process-service: os dados são o payload sem os campos base
+func parseProcessWebhookEvent(body []byte, message webhookMessage) (*domain.WebhookEvent, error) {
+	if !domain.IsWebhookEvent(message.EventType) {
+		return nil, nil
+	}
+
+	var payload struct {
+		TenantID string `json:"tenant_id"`
+	}
+	if err := json.Unmarshal(message.Payload, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidWebhookEvent, err)
+	}
+	tenantID, err := uuid.Parse(payload.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: tenant_id inválido: %v", domain.ErrInvalidWebhookEvent, err)
+	}
+
+	data, err := stripWebhookFields(message.Payload, message.EventType, processEventBaseFields)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.WebhookEvent{
+		ID:         uuid.NewSHA1(webhookEventNamespace, body),
+		Type:       message.EventType,
+		TenantID:   tenantID,
+		OccurredAt: message.OccurredAt,
+		Data:       data,
+	}, nil
+}
+
+// parseBillingWebhookEvent evento do billing-service: os dados são os campos do evento sem os
+// campos base
+func parseBillingWebhookEvent(body []byte, message webhookMessage) (*domain.WebhookEvent, error) {
+	if message.Type == "" {
+		return nil, fmt.Errorf("%w: tipo do evento ausente", domain.ErrInvalidWebhookEvent)
+	}
+	if !domain.IsWebhookEvent(message.Type) {
+		return nil, nil
+	}
+
+	tenantID, err := uuid.Parse(message.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: tenant_id inválido: %v", domain.ErrInvalidWebhookEvent, err)
+	}
+
+	eventID, err := uuid.Parse(message.ID)
+	if err != nil {
+		eventID = uuid.NewSHA1(webhookEventNamespace, body)
+	}
+
+	data, err := stripWebhookFields(body, message.Type, billingEventBaseFields)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.WebhookEvent{
+		ID:         eventID,
+		Type:       message.Type,
+		TenantID:   tenantID,
+		OccurredAt: message.Timestamp,
+		Data:       data,
+	}, nil
+}
+
+// stripWebhookFields remove do objeto os campos que já estão no envelope do webhook. "type" só
+// é removido quando repete o tipo do evento: em movement.created é o tipo da movimentação.
+func stripWebhookFields(raw json.RawMessage, eventType string, fields []string) (json.RawMessage, error) {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidWebhookEvent, err)
+	}
+	if object == nil {
+		return nil, fmt.Errorf("%w: dados do evento ausentes", domain.ErrInvalidWebhookEvent)
+	}
+
+	for _, field := range fields {
+		delete(object, field)
+	}
+	var objectType string
+	if json.Unmarshal(object["type"], &objectType) == nil && objectType == eventType {
+		delete(object, "type")
+	}
+
+	data, err := json.Marshal(object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal webhook event data: %w", err)
+	}
+	return data, nil
+}
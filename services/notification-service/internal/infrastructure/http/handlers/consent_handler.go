@@ -0,0 +1,306 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/application/services"
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// ConsentHandler handler do registro de consentimento (LGPD) e do descadastro pelo link do email
+type ConsentHandler struct {
+	consents *services.ConsentService
+	logger   *zap.Logger
+}
+
+// NewConsentHandler cria nova instância do handler
+func NewConsentHandler(consents *services.ConsentService, logger *zap.Logger) *ConsentHandler {
+	return &ConsentHandler{
+		consents: consents,
+		logger:   logger,
+	}
+}
+
+// ConsentRequest corpo da requisição de registro de consentimento
+type ConsentRequest struct {
+	Channel     domain.NotificationChannel `json:"channel" binding:"required"`
+	Contact     string                     `json:"contact" binding:"required"`
+	RecipientID string                     `json:"recipient_id,omitempty"`
+	Purpose     domain.ConsentPurpose      `json:"purpose" binding:"required"`
+	Status      domain.ConsentStatus       `json:"status" binding:"required"`
+	LegalBasis  domain.ConsentLegalBasis   `json:"legal_basis" binding:"required"`
+	Source      domain.ConsentSource       `json:"source,omitempty"`      // web_app, api (padrão) ou import
+	Evidence    map[string]interface{}     `json:"evidence,omitempty"`    // texto aceito, versão da política, etc.
+	OccurredAt  *time.Time                 `json:"occurred_at,omitempty"` // data do aceite, em importações
+}
+
+// RecordConsent registra concessão ou revogação informada pelo tenant. O IP e o user agent da
+// requisição são acrescentados à evidência.
+// @Summary Registrar consentimento
+// @Tags consents
+// @Accept json
+// @Produce json
+// @Param request body ConsentRequest true "Registro de consentimento"
+// @Success 201 {object} domain.ConsentRecord
+// @Failure 400 {object} ErrorResponse
+// @Router /consents [post]
+func (h *ConsentHandler) RecordConsent(c *gin.Context) {
+	tenantID := c.MustGet("tenant_id").(uuid.UUID)
+
+	var req ConsentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	// Link de descadastro e palavras-chave são registrados apenas pelo próprio serviço
+	if req.Source == "" {
+		req.Source = domain.ConsentSourceAPI
+	}
+	if req.Source != domain.ConsentSourceAPI && req.Source != domain.ConsentSourceWebApp && req.Source != domain.ConsentSourceImport {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid consent record",
+			Message: fmt.Sprintf("origem não permitida: %s", req.Source),
+		})
+		return
+	}
+
+	evidence := make(map[string]interface{}, len(req.Evidence)+2)
+	for key, value := range req.Evidence {
+		evidence[key] = value
+	}
+	// O IP da integração não comprova o aceite: concessão sem evidência do tenant é recusada
+	if len(evidence) > 0 || req.Status == domain.ConsentStatusRevoked {
+		evidence["request_ip"] = c.ClientIP()
+		evidence["request_user_agent"] = c.Request.UserAgent()
+	}
+
+	record, err := domain.NewConsentRecord(&tenantID, req.Channel, req.Contact, req.Purpose, req.Status, req.LegalBasis, req.Source, evidence)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid consent record",
+			Message: err.Error(),
+		})
+		return
+	}
+	record.RecipientID = req.RecipientID
+	if req.OccurredAt != nil && req.Source == domain.ConsentSourceImport {
+		record.CreatedAt = *req.OccurredAt
+	}
+
+	if err := h.consents.Record(c.Request.Context(), record); err != nil {
+		h.logger.Error("Failed to record consent", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to record consent",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, record)
+}
+
+// GetConsentStatus situação do consentimento do contato para a finalidade, com o histórico
+// @Summary Consultar consentimento
+// @Tags consents
+// @Produce json
+// @Param channel query string true "Canal"
+// @Param contact query string true "Contato"
+// @Param purpose query string false "Finalidade (padrão: process_updates)"
+// @Success 200 {object} services.ConsentSummary
+// @Failure 400 {object} ErrorResponse
+// @Router /consents/status [get]
+func (h *ConsentHandler) GetConsentStatus(c *gin.Context) {
+	tenantID := c.MustGet("tenant_id").(uuid.UUID)
+
+	channel := domain.NotificationChannel(c.Query("channel"))
+	contact := c.Query("contact")
+	if err := domain.ValidateChannel(channel); err != nil || contact == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: "channel e contact são obrigatórios",
+		})
+		return
+	}
+	purpose := domain.ConsentPurpose(c.DefaultQuery("purpose", string(domain.ConsentPurposeProcessUpdates)))
+
+	summary, err := h.consents.Status(c.Request.Context(), tenantID, channel, contact, purpose)
+	if err != nil {
+		h.logger.Error("Failed to get consent status", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to get consent status",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+// ExportConsents exporta o histórico de consentimento do tenant para auditoria
+// @Summary Exportar histórico de consentimento
+// @Tags consents
+// @Produce json,text/csv
+// @Param format query string false "json (padrão) ou csv"
+// @Param channel query string false "Canal"
+// @Param contact query string false "Contato"
+// @Param from query string false "Início (RFC3339)"
+// @Param to query string false "Fim (RFC3339)"
+// @Success 200 {array} domain.ConsentRecord
+// @Failure 400 {object} ErrorResponse
+// @Router /consents/export [get]
+func (h *ConsentHandler) ExportConsents(c *gin.Context) {
+	tenantID := c.MustGet("tenant_id").(uuid.UUID)
+
+	filter := domain.ConsentExportFilter{Contact: c.Query("contact")}
+	if channelStr := c.Query("channel"); channelStr != "" {
+		channel := domain.NotificationChannel(channelStr)
+		filter.Channel = &channel
+	}
+	for param, target := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid request",
+				Message: fmt.Sprintf("%s inválido: %v", param, err),
+			})
+			return
+		}
+		*target = &parsed
+	}
+
+	records, err := h.consents.Export(c.Request.Context(), tenantID, filter)
+	if err != nil {
+		h.logger.Error("Failed to export consents", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to export consents",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if c.Query("format") != "csv" {
+		c.JSON(http.StatusOK, records)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="consents-%s.csv"`, time.Now().Format("20060102")))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Status(http.StatusOK)
+
+	writer := csv.NewWriter(c.Writer)
+	writer.Write([]string{"id", "tenant_id", "channel", "contact", "recipient_id", "purpose", "status", "legal_basis", "source", "evidence", "created_at"})
+	for _, record := range records {
+		tenant := ""
+		if record.TenantID != nil {
+			tenant = record.TenantID.String()
+		}
+		evidence, _ := json.Marshal(record.Evidence)
+		writer.Write([]string{
+			record.ID.String(),
+			tenant,
+			string(record.Channel),
+			record.Contact,
+			record.RecipientID,
+			string(record.Purpose),
+			string(record.Status),
+			string(record.LegalBasis),
+			string(record.Source),
+			string(evidence),
+			record.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		h.logger.Error("Failed to write consent export", zap.Error(err))
+	}
+}
+
+// ShowUnsubscribe página de confirmação do descadastro. A revogação só acontece no POST: leitores
+// de email que abrem os links não descadastram o destinatário.
+// @Summary Confirmação de descadastro
+// @Tags consents
+// @Produce html
+// @Param id path string true "ID da notificação"
+// @Param sig query string true "Assinatura"
+// @Success 200
+// @Router /unsubscribe/{id} [get]
+func (h *ConsentHandler) ShowUnsubscribe(c *gin.Context) {
+	if _, ok := h.verifyUnsubscribe(c); !ok {
+		return
+	}
+
+	action := html.EscapeString(c.Request.URL.RequestURI())
+	h.page(c, http.StatusOK, "Descadastrar",
+		`<p>Confirme para não receber mais estas mensagens do Direito Lux neste endereço.</p>`+
+			`<form method="post" action="`+action+`"><button type="submit">Confirmar descadastro</button></form>`)
+}
+
+// Unsubscribe revoga o consentimento do destinatário (formulário de confirmação ou
+// List-Unsubscribe-Post dos clientes de email)
+// @Summary Descadastrar
+// @Tags consents
+// @Produce html
+// @Param id path string true "ID da notificação"
+// @Param sig query string true "Assinatura"
+// @Success 200
+// @Router /unsubscribe/{id} [post]
+func (h *ConsentHandler) Unsubscribe(c *gin.Context) {
+	id, ok := h.verifyUnsubscribe(c)
+	if !ok {
+		return
+	}
+
+	evidence := map[string]interface{}{
+		"request_ip":         c.ClientIP(),
+		"request_user_agent": c.Request.UserAgent(),
+		"one_click":          c.PostForm("List-Unsubscribe") == "One-Click",
+	}
+
+	if _, err := h.consents.Unsubscribe(c.Request.Context(), id, evidence); err != nil {
+		if errors.Is(err, domain.ErrNotificationNotFound) {
+			h.page(c, http.StatusNotFound, "Link inválido", `<p>Este link de descadastro não é mais válido.</p>`)
+			return
+		}
+		h.logger.Error("Failed to unsubscribe recipient", zap.String("notification_id", id.String()), zap.Error(err))
+		h.page(c, http.StatusInternalServerError, "Erro", `<p>Não foi possível concluir o descadastro. Tente novamente em instantes.</p>`)
+		return
+	}
+
+	h.page(c, http.StatusOK, "Descadastro confirmado", `<p>Pronto! Você não receberá mais estas mensagens do Direito Lux.</p>`)
+}
+
+// verifyUnsubscribe valida o ID e a assinatura do link de descadastro
+func (h *ConsentHandler) verifyUnsubscribe(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil || !h.consents.VerifyUnsubscribe(id, c.Query("sig")) {
+		h.page(c, http.StatusBadRequest, "Link inválido", `<p>Este link de descadastro é inválido.</p>`)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// page responde com página HTML simples
+func (h *ConsentHandler) page(c *gin.Context, status int, title, body string) {
+	c.Header("Cache-Control", "no-store")
+	c.Data(status, "text/html; charset=utf-8", []byte(
+		`<!DOCTYPE html><html lang="pt-BR"><head><meta charset="utf-8"><title>`+html.EscapeString(title)+
+			`</title></head><body><h1>`+html.EscapeString(title)+`</h1>`+body+`</body></html>`))
+}
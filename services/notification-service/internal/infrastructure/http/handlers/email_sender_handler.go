@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/application/services"
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// EmailSenderHandler handler do remetente com domínio próprio do tenant
+type EmailSenderHandler struct {
+	senders *services.EmailSenderDomainService
+	logger  *zap.Logger
+}
+
+// NewEmailSenderHandler cria nova instância do handler
+func NewEmailSenderHandler(senders *services.EmailSenderDomainService, logger *zap.Logger) *EmailSenderHandler {
+	return &EmailSenderHandler{
+		senders: senders,
+		logger:  logger,
+	}
+}
+
+// GetSenderDomain retorna o remetente do tenant com o registro DKIM a publicar
+// @Summary Consultar remetente de email
+// @Tags email
+// @Produce json
+// @Success 200 {object} services.EmailSenderDomainView
+// @Failure 404 {object} ErrorResponse
+// @Router /email/sender-domain [get]
+func (h *EmailSenderHandler) GetSenderDomain(c *gin.Context) {
+	tenantID := c.MustGet("tenant_id").(uuid.UUID)
+
+	view, err := h.senders.Get(c.Request.Context(), tenantID)
+	if err != nil {
+		h.respondError(c, "Failed to get email sender domain", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, view)
+}
+
+// ConfigureSenderDomain cadastra ou altera o remetente do tenant. Um novo domínio recebe nova
+// chave DKIM e só é usado nos envios depois da verificação.
+// @Summary Configurar remetente de email
+// @Tags email
+// @Accept json
+// @Produce json
+// @Param request body services.EmailSenderDomainRequest true "Remetente"
+// @Success 200 {object} services.EmailSenderDomainView
+// @Failure 400 {object} ErrorResponse
+// @Router /email/sender-domain [put]
+func (h *EmailSenderHandler) ConfigureSenderDomain(c *gin.Context) {
+	tenantID := c.MustGet("tenant_id").(uuid.UUID)
+
+	var req services.EmailSenderDomainRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	view, err := h.senders.Configure(c.Request.Context(), tenantID, &req)
+	if err != nil {
+		h.respondError(c, "Failed to configure email sender domain", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, view)
+}
+
+// VerifySenderDomain verifica o registro DKIM no DNS e libera o domínio para os envios
+// @Summary Verificar remetente de email
+// @Tags email
+// @Produce json
+// @Success 200 {object} services.EmailSenderDomainView
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Router /email/sender-domain/verify [post]
+func (h *EmailSenderHandler) VerifySenderDomain(c *gin.Context) {
+	tenantID := c.MustGet("tenant_id").(uuid.UUID)
+
+	view, err := h.senders.Verify(c.Request.Context(), tenantID)
+	if err != nil {
+		h.respondError(c, "Failed to verify email sender domain", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, view)
+}
+
+// DeleteSenderDomain remove o remetente do tenant
+// @Summary Remover remetente de email
+// @Tags email
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Router /email/sender-domain [delete]
+func (h *EmailSenderHandler) DeleteSenderDomain(c *gin.Context) {
+	tenantID := c.MustGet("tenant_id").(uuid.UUID)
+
+	if err := h.senders.Delete(c.Request.Context(), tenantID); err != nil {
+		h.respondError(c, "Failed to delete email sender domain", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// respondError traduz os erros do remetente em respostas HTTP
+func (h *EmailSenderHandler) respondError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrEmailSenderDomainNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Email sender domain not found",
+			Message: err.Error(),
+		})
+	case errors.Is(err, domain.ErrEmailSenderDomainInvalid):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid email sender domain",
+			Message: err.Error(),
+		})
+	case errors.Is(err, domain.ErrEmailSenderDomainDNS):
+		c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+			Error:   "DKIM record not published",
+			Message: err.Error(),
+		})
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   message,
+			Message: err.Error(),
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"time"
@@ -62,6 +64,13 @@ func (h *NotificationHandler) CreateNotification(c *gin.Context) {
 
 	notification, err := h.notificationService.CreateNotification(c.Request.Context(), &req)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidAttachment) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid attachments",
+				Message: err.Error(),
+			})
+			return
+		}
 		h.logger.Error("Failed to create notification", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "Failed to create notification",
@@ -114,6 +123,47 @@ func (h *NotificationHandler) GetNotification(c *gin.Context) {
 	c.JSON(http.StatusOK, notification)
 }
 
+// GetNotificationAttempts busca a notificação lógica com as tentativas por canal
+// @Summary Tentativas da notificação
+// @Description Retorna a notificação lógica, o status consolidado da cadeia de fallback e as tentativas por canal
+// @Tags notifications
+// @Produce json
+// @Param id path string true "ID da notificação ou de uma das tentativas"
+// @Success 200 {object} services.NotificationAttempts
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /notifications/{id}/attempts [get]
+func (h *NotificationHandler) GetNotificationAttempts(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid notification ID",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	attempts, err := h.notificationService.GetNotificationAttempts(c.Request.Context(), id)
+	if err != nil {
+		if err == domain.ErrNotificationNotFound {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "Notification not found",
+				Message: err.Error(),
+			})
+			return
+		}
+		h.logger.Error("Failed to get notification attempts", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to get notification attempts",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, attempts)
+}
+
 // ListNotifications lista notificações com filtros
 // @Summary Listar notificações
 // @Description Lista notificações com filtros opcionais
@@ -123,6 +173,7 @@ func (h *NotificationHandler) GetNotification(c *gin.Context) {
 // @Param channel query string false "Canal de notificação"
 // @Param status query string false "Status da notificação"
 // @Param priority query string false "Prioridade"
+// @Param include_attempts query bool false "Incluir tentativas de fallback além da notificação lógica"
 // @Param limit query int false "Limite de resultados" default(50)
 // @Param offset query int false "Offset para paginação" default(0)
 // @Success 200 {object} ListNotificationsResponse
@@ -161,6 +212,9 @@ func (h *NotificationHandler) ListNotifications(c *gin.Context) {
 		}
 	}
 
+	// Por padrão, cadeias de fallback aparecem apenas como a notificação lógica
+	filters.IncludeAttempts = c.Query("include_attempts") == "true"
+
 	// Parse dates
 	if createdAfterStr := c.Query("created_after"); createdAfterStr != "" {
 		if createdAfter, err := time.Parse(time.RFC3339, createdAfterStr); err == nil {
@@ -226,26 +280,13 @@ func (h *NotificationHandler) ListNotifications(c *gin.Context) {
 func (h *NotificationHandler) GetNotificationStatistics(c *gin.Context) {
 	tenantID := c.MustGet("tenant_id").(uuid.UUID)
 
-	periodStr := c.DefaultQuery("period", "7d")
-	period, err := time.ParseDuration(periodStr)
-	if err != nil {
-		// Tentar formatos customizados
-		switch periodStr {
-		case "24h", "1d":
-			period = 24 * time.Hour
-		case "7d", "1w":
-			period = 7 * 24 * time.Hour
-		case "30d", "1m":
-			period = 30 * 24 * time.Hour
-		case "90d", "3m":
-			period = 90 * 24 * time.Hour
-		default:
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "Invalid period format",
-				Message: "Use formats like 24h, 7d, 30d",
-			})
-			return
-		}
+	period, ok := parsePeriod(c.DefaultQuery("period", "7d"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid period format",
+			Message: "Use formats like 24h, 7d, 30d",
+		})
+		return
 	}
 
 	stats, err := h.notificationService.GetNotificationStatistics(c.Request.Context(), tenantID, period)
@@ -261,6 +302,173 @@ func (h *NotificationHandler) GetNotificationStatistics(c *gin.Context) {
 	c.JSON(http.StatusOK, stats)
 }
 
+// GetNotificationAnalytics obtém as análises de notificações do tenant
+// @Summary Análises de notificações
+// @Description Funil criada → enviada → entregue → lida, tempo até a entrega (p50/p90/p99), falhas por categoria e custo, por tipo, canal ou template
+// @Tags notifications
+// @Produce json
+// @Param period query string false "Período até agora (24h, 7d, 30d, 90d)" default(30d)
+// @Param from query string false "Início (RFC 3339 ou AAAA-MM-DD); substitui o período"
+// @Param to query string false "Fim (RFC 3339 ou AAAA-MM-DD)"
+// @Param group_by query string false "Dimensão: type, channel ou template"
+// @Param channel query string false "Canal"
+// @Param type query string false "Tipo de notificação"
+// @Param template_id query string false "ID do template"
+// @Success 200 {object} domain.NotificationAnalytics
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /notifications/analytics [get]
+func (h *NotificationHandler) GetNotificationAnalytics(c *gin.Context) {
+	tenantID := c.MustGet("tenant_id").(uuid.UUID)
+
+	filters, err := parseAnalyticsFilters(c)
+	if err == nil && filters.GroupBy == domain.AnalyticsByTenant {
+		err = fmt.Errorf("%w: group_by=tenant disponível apenas na rota administrativa", domain.ErrInvalidAnalyticsFilter)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid analytics filter",
+			Message: err.Error(),
+		})
+		return
+	}
+	filters.TenantID = &tenantID
+
+	h.respondAnalytics(c, filters)
+}
+
+// GetPlatformAnalytics obtém as análises de notificações de todos os tenants ou de um tenant
+// @Summary Análises de notificações da plataforma
+// @Description Mesmas métricas da rota do tenant, com agrupamento por tenant
+// @Tags admin
+// @Produce json
+// @Param tenant_id query string false "ID do tenant"
+// @Param group_by query string false "Dimensão: type, channel, template ou tenant"
+// @Success 200 {object} domain.NotificationAnalytics
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/notifications/analytics [get]
+func (h *NotificationHandler) GetPlatformAnalytics(c *gin.Context) {
+	filters, err := parseAnalyticsFilters(c)
+	if err == nil && c.Query("tenant_id") != "" {
+		var tenantID uuid.UUID
+		if tenantID, err = uuid.Parse(c.Query("tenant_id")); err == nil {
+			filters.TenantID = &tenantID
+		}
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid analytics filter",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	h.respondAnalytics(c, filters)
+}
+
+// respondAnalytics consulta as análises e escreve a resposta
+func (h *NotificationHandler) respondAnalytics(c *gin.Context, filters domain.AnalyticsFilters) {
+	analytics, err := h.notificationService.GetNotificationAnalytics(c.Request.Context(), filters)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidAnalyticsFilter) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid analytics filter",
+				Message: err.Error(),
+			})
+			return
+		}
+		h.logger.Error("Failed to get notification analytics", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to get analytics",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, analytics)
+}
+
+// parseAnalyticsFilters lê período, dimensão e filtros da query string
+func parseAnalyticsFilters(c *gin.Context) (domain.AnalyticsFilters, error) {
+	var filters domain.AnalyticsFilters
+
+	filters.To = time.Now()
+	if to := c.Query("to"); to != "" {
+		parsed, err := parseAnalyticsTime(to)
+		if err != nil {
+			return filters, fmt.Errorf("%w: to inválido", domain.ErrInvalidAnalyticsFilter)
+		}
+		filters.To = parsed
+	}
+
+	if from := c.Query("from"); from != "" {
+		parsed, err := parseAnalyticsTime(from)
+		if err != nil {
+			return filters, fmt.Errorf("%w: from inválido", domain.ErrInvalidAnalyticsFilter)
+		}
+		filters.From = parsed
+	} else {
+		period, ok := parsePeriod(c.DefaultQuery("period", "30d"))
+		if !ok {
+			return filters, fmt.Errorf("%w: use períodos como 24h, 7d, 30d", domain.ErrInvalidAnalyticsFilter)
+		}
+		filters.From = filters.To.Add(-period)
+	}
+
+	groupBy, err := domain.ParseAnalyticsDimension(c.Query("group_by"))
+	if err != nil {
+		return filters, err
+	}
+	filters.GroupBy = groupBy
+
+	if channel := c.Query("channel"); channel != "" {
+		ch := domain.NotificationChannel(channel)
+		filters.Channel = &ch
+	}
+	if notificationType := c.Query("type"); notificationType != "" {
+		t := domain.NotificationType(notificationType)
+		filters.Type = &t
+	}
+	if templateID := c.Query("template_id"); templateID != "" {
+		id, err := uuid.Parse(templateID)
+		if err != nil {
+			return filters, fmt.Errorf("%w: template_id inválido", domain.ErrInvalidAnalyticsFilter)
+		}
+		filters.TemplateID = &id
+	}
+
+	return filters, nil
+}
+
+// parseAnalyticsTime aceita RFC 3339 ou apenas a data (início do dia em UTC)
+func parseAnalyticsTime(value string) (time.Time, error) {
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+// parsePeriod interpreta o período das estatísticas: durações do Go ou dias/semanas/meses
+func parsePeriod(value string) (time.Duration, bool) {
+	if period, err := time.ParseDuration(value); err == nil && period > 0 {
+		return period, true
+	}
+
+	switch value {
+	case "24h", "1d":
+		return 24 * time.Hour, true
+	case "7d", "1w":
+		return 7 * 24 * time.Hour, true
+	case "30d", "1m":
+		return 30 * 24 * time.Hour, true
+	case "90d", "3m":
+		return 90 * 24 * time.Hour, true
+	default:
+		return 0, false
+	}
+}
+
 // CancelNotification cancela uma notificação
 // @Summary Cancelar notificação
 // @Description Cancela uma notificação pendente ou agendada
@@ -1,27 +1,36 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 
+	"github.com/direito-lux/notification-service/internal/application/services"
 	"github.com/direito-lux/notification-service/internal/domain"
 	"github.com/direito-lux/notification-service/internal/infrastructure/repository"
 )
 
 // PreferenceHandler handler para preferências de notificação
 type PreferenceHandler struct {
-	preferenceRepo domain.NotificationPreferenceRepository
-	logger         *zap.Logger
+	preferenceRepo  domain.NotificationPreferenceRepository
+	deliveryWindows *services.DeliveryWindowService
+	logger          *zap.Logger
 }
 
 // NewPreferenceHandler cria nova instância do handler
-func NewPreferenceHandler(preferenceRepo domain.NotificationPreferenceRepository, logger *zap.Logger) *PreferenceHandler {
+func NewPreferenceHandler(
+	preferenceRepo domain.NotificationPreferenceRepository,
+	deliveryWindows *services.DeliveryWindowService,
+	logger *zap.Logger,
+) *PreferenceHandler {
 	return &PreferenceHandler{
-		preferenceRepo: preferenceRepo,
-		logger:         logger,
+		preferenceRepo:  preferenceRepo,
+		deliveryWindows: deliveryWindows,
+		logger:          logger,
 	}
 }
 
@@ -112,6 +121,14 @@ func (h *PreferenceHandler) UpdateUserPreferences(c *gin.Context) {
 	// Converter request para domain objects
 	preferences := make([]*domain.NotificationPreference, len(req.Preferences))
 	for i, prefReq := range req.Preferences {
+		if err := domain.ValidatePreferenceDigest(prefReq.Type, prefReq.DigestFrequency); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid digest frequency",
+				Message: err.Error(),
+			})
+			return
+		}
+
 		preference := &domain.NotificationPreference{
 			ID:       uuid.New(),
 			TenantID: tenantID,
@@ -119,6 +136,7 @@ func (h *PreferenceHandler) UpdateUserPreferences(c *gin.Context) {
 			Type:     prefReq.Type,
 			Channels: prefReq.Channels,
 			Enabled:  prefReq.Enabled,
+			DigestFrequency: prefReq.DigestFrequency,
 		}
 		preferences[i] = preference
 	}
@@ -297,6 +315,16 @@ func (h *PreferenceHandler) UpdatePreferenceByType(c *gin.Context) {
 	if req.Enabled != nil {
 		preference.Enabled = *req.Enabled
 	}
+	if req.DigestFrequency != nil {
+		if err := domain.ValidatePreferenceDigest(preference.Type, *req.DigestFrequency); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid digest frequency",
+				Message: err.Error(),
+			})
+			return
+		}
+		preference.DigestFrequency = *req.DigestFrequency
+	}
 
 	err = h.preferenceRepo.Update(c.Request.Context(), preference)
 	if err != nil {
@@ -415,6 +443,163 @@ func (h *PreferenceHandler) EnableAllNotifications(c *gin.Context) {
 	})
 }
 
+// GetUserDeliverySettings obtém a janela de entrega do usuário
+// @Summary Obter janela de entrega do usuário
+// @Description Obtém horário de silêncio, horário comercial, fuso horário e horário dos resumos do usuário
+// @Tags preferences
+// @Produce json
+// @Param user_id path string true "ID do usuário"
+// @Success 200 {object} domain.DeliverySettings
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /preferences/users/{user_id}/delivery-settings [get]
+func (h *PreferenceHandler) GetUserDeliverySettings(c *gin.Context) {
+	userIDStr := c.Param("user_id")
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid user ID",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	tenantID := c.MustGet("tenant_id").(uuid.UUID)
+
+	settings, err := h.deliveryWindows.GetUserSettings(c.Request.Context(), tenantID, userID)
+	if err != nil {
+		h.logger.Error("Failed to get delivery settings", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to get delivery settings",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// UpdateUserDeliverySettings atualiza a janela de entrega do usuário
+// @Summary Atualizar janela de entrega do usuário
+// @Description Atualiza horário de silêncio, horário comercial, prioridades que ignoram as restrições e horário dos resumos
+// @Tags preferences
+// @Accept json
+// @Produce json
+// @Param user_id path string true "ID do usuário"
+// @Param request body DeliverySettingsRequest true "Campos a alterar"
+// @Success 200 {object} domain.DeliverySettings
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /preferences/users/{user_id}/delivery-settings [put]
+func (h *PreferenceHandler) UpdateUserDeliverySettings(c *gin.Context) {
+	userIDStr := c.Param("user_id")
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid user ID",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	tenantID := c.MustGet("tenant_id").(uuid.UUID)
+
+	settings, err := h.deliveryWindows.GetUserSettings(c.Request.Context(), tenantID, userID)
+	if err != nil {
+		h.logger.Error("Failed to get delivery settings", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to get delivery settings",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	h.saveDeliverySettings(c, settings)
+}
+
+// GetTenantDeliverySettings obtém a janela de entrega padrão do tenant
+// @Summary Obter janela de entrega do tenant
+// @Description Obtém a janela de entrega padrão do tenant; horário comercial obrigatório vale para todos os usuários
+// @Tags preferences
+// @Produce json
+// @Success 200 {object} domain.DeliverySettings
+// @Failure 500 {object} ErrorResponse
+// @Router /preferences/delivery-settings [get]
+func (h *PreferenceHandler) GetTenantDeliverySettings(c *gin.Context) {
+	tenantID := c.MustGet("tenant_id").(uuid.UUID)
+
+	settings, err := h.deliveryWindows.GetTenantSettings(c.Request.Context(), tenantID)
+	if err != nil {
+		h.logger.Error("Failed to get tenant delivery settings", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to get delivery settings",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// UpdateTenantDeliverySettings atualiza a janela de entrega padrão do tenant
+// @Summary Atualizar janela de entrega do tenant
+// @Description Atualiza a janela de entrega padrão do tenant (ex.: business_hours_only das preferências do tenant)
+// @Tags preferences
+// @Accept json
+// @Produce json
+// @Param request body DeliverySettingsRequest true "Campos a alterar"
+// @Success 200 {object} domain.DeliverySettings
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /preferences/delivery-settings [put]
+func (h *PreferenceHandler) UpdateTenantDeliverySettings(c *gin.Context) {
+	tenantID := c.MustGet("tenant_id").(uuid.UUID)
+
+	settings, err := h.deliveryWindows.GetTenantSettings(c.Request.Context(), tenantID)
+	if err != nil {
+		h.logger.Error("Failed to get tenant delivery settings", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to get delivery settings",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	h.saveDeliverySettings(c, settings)
+}
+
+// saveDeliverySettings aplica o request sobre a configuração atual e salva
+func (h *PreferenceHandler) saveDeliverySettings(c *gin.Context, settings *domain.DeliverySettings) {
+	var req DeliverySettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	req.apply(settings)
+
+	if err := h.deliveryWindows.SaveSettings(c.Request.Context(), settings); err != nil {
+		if errors.Is(err, domain.ErrPreferenceInvalid) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid delivery settings",
+				Message: err.Error(),
+			})
+			return
+		}
+		h.logger.Error("Failed to save delivery settings", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to save delivery settings",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
 // Request/Response types
 type GetUserPreferencesResponse struct {
 	UserID      uuid.UUID                                              `json:"user_id"`
@@ -430,6 +615,7 @@ type PreferenceRequest struct {
 	Type     domain.NotificationType     `json:"type" validate:"required"`
 	Channels []domain.NotificationChannel `json:"channels" validate:"required"`
 	Enabled  bool                        `json:"enabled"`
+	DigestFrequency domain.DigestFrequency `json:"digest_frequency,omitempty"`
 }
 
 type CreateDefaultPreferencesRequest struct {
@@ -439,4 +625,61 @@ type CreateDefaultPreferencesRequest struct {
 type UpdatePreferenceRequest struct {
 	Channels *[]domain.NotificationChannel `json:"channels,omitempty"`
 	Enabled  *bool                         `json:"enabled,omitempty"`
+	DigestFrequency *domain.DigestFrequency `json:"digest_frequency,omitempty"`
+}
+
+// DeliverySettingsRequest campos da janela de entrega a alterar (horários em HH:MM)
+type DeliverySettingsRequest struct {
+	Timezone           *string                        `json:"timezone,omitempty"`
+	Language           *string                        `json:"language,omitempty"`
+	QuietHoursEnabled  *bool                          `json:"quiet_hours_enabled,omitempty"`
+	QuietHoursStart    *string                        `json:"quiet_hours_start,omitempty"`
+	QuietHoursEnd      *string                        `json:"quiet_hours_end,omitempty"`
+	BusinessHoursOnly  *bool                          `json:"business_hours_only,omitempty"`
+	BusinessHoursStart *string                        `json:"business_hours_start,omitempty"`
+	BusinessHoursEnd   *string                        `json:"business_hours_end,omitempty"`
+	BusinessDays       *[]time.Weekday                `json:"business_days,omitempty"`
+	OverridePriorities *[]domain.NotificationPriority `json:"override_priorities,omitempty"`
+	DigestHour         *int                           `json:"digest_hour,omitempty"`
+	DigestWeekday      *time.Weekday                  `json:"digest_weekday,omitempty"`
+}
+
+// apply aplica os campos informados sobre a configuração
+func (r *DeliverySettingsRequest) apply(settings *domain.DeliverySettings) {
+	if r.Timezone != nil {
+		settings.Timezone = *r.Timezone
+	}
+	if r.Language != nil {
+		settings.Language = *r.Language
+	}
+	if r.QuietHoursEnabled != nil {
+		settings.QuietHoursEnabled = *r.QuietHoursEnabled
+	}
+	if r.QuietHoursStart != nil {
+		settings.QuietHoursStart = *r.QuietHoursStart
+	}
+	if r.QuietHoursEnd != nil {
+		settings.QuietHoursEnd = *r.QuietHoursEnd
+	}
+	if r.BusinessHoursOnly != nil {
+		settings.BusinessHoursOnly = *r.BusinessHoursOnly
+	}
+	if r.BusinessHoursStart != nil {
+		settings.BusinessHoursStart = *r.BusinessHoursStart
+	}
+	if r.BusinessHoursEnd != nil {
+		settings.BusinessHoursEnd = *r.BusinessHoursEnd
+	}
+	if r.BusinessDays != nil {
+		settings.BusinessDays = *r.BusinessDays
+	}
+	if r.OverridePriorities != nil {
+		settings.OverridePriorities = *r.OverridePriorities
+	}
+	if r.DigestHour != nil {
+		settings.DigestHour = *r.DigestHour
+	}
+	if r.DigestWeekday != nil {
+		settings.DigestWeekday = *r.DigestWeekday
+	}
 }
\ No newline at end of file
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// ProcessWatcherHandler handler dos destinatários dos alertas de movimentação dos processos
+type ProcessWatcherHandler struct {
+	watchers domain.ProcessWatcherRepository
+	logger   *zap.Logger
+}
+
+// NewProcessWatcherHandler cria nova instância do handler
+func NewProcessWatcherHandler(watchers domain.ProcessWatcherRepository, logger *zap.Logger) *ProcessWatcherHandler {
+	return &ProcessWatcherHandler{
+		watchers: watchers,
+		logger:   logger,
+	}
+}
+
+// ProcessWatcherRequest corpo da requisição de cadastro do destinatário
+type ProcessWatcherRequest struct {
+	Role     domain.ProcessWatcherRole             `json:"role" binding:"required"`
+	Name     string                                `json:"name,omitempty"`
+	Contacts map[domain.NotificationChannel]string `json:"contacts" binding:"required"`
+	OptedIn  bool                                  `json:"opted_in"`
+}
+
+// ListWatchers lista os destinatários dos alertas do processo
+// @Summary Listar destinatários do processo
+// @Tags processes
+// @Produce json
+// @Param process_id path string true "ID do processo"
+// @Success 200 {array} domain.ProcessWatcher
+// @Failure 400 {object} ErrorResponse
+// @Router /processes/{process_id}/watchers [get]
+func (h *ProcessWatcherHandler) ListWatchers(c *gin.Context) {
+	tenantID := c.MustGet("tenant_id").(uuid.UUID)
+	processID, ok := parseProcessID(c)
+	if !ok {
+		return
+	}
+
+	watchers, err := h.watchers.FindByProcess(c.Request.Context(), tenantID, processID)
+	if err != nil {
+		h.logger.Error("Failed to list process watchers", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to list process watchers",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, watchers)
+}
+
+// SetWatcher cadastra ou atualiza o destinatário: advogado responsável, membro da equipe ou
+// cliente (que só recebe alertas com opt-in)
+// @Summary Cadastrar destinatário do processo
+// @Tags processes
+// @Accept json
+// @Produce json
+// @Param process_id path string true "ID do processo"
+// @Param recipient_id path string true "ID do usuário ou do cliente"
+// @Param request body ProcessWatcherRequest true "Papel e contatos"
+// @Success 200 {object} domain.ProcessWatcher
+// @Failure 400 {object} ErrorResponse
+// @Router /processes/{process_id}/watchers/{recipient_id} [put]
+func (h *ProcessWatcherHandler) SetWatcher(c *gin.Context) {
+	tenantID := c.MustGet("tenant_id").(uuid.UUID)
+	processID, ok := parseProcessID(c)
+	if !ok {
+		return
+	}
+
+	var req ProcessWatcherRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	watcher, err := domain.NewProcessWatcher(tenantID, processID, c.Param("recipient_id"), req.Role, req.Name, req.Contacts, req.OptedIn)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid process watcher",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.watchers.Upsert(c.Request.Context(), watcher); err != nil {
+		h.logger.Error("Failed to save process watcher", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to save process watcher",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, watcher)
+}
+
+// RemoveWatcher remove o destinatário do processo
+// @Summary Remover destinatário do processo
+// @Tags processes
+// @Param process_id path string true "ID do processo"
+// @Param recipient_id path string true "ID do usuário ou do cliente"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Router /processes/{process_id}/watchers/{recipient_id} [delete]
+func (h *ProcessWatcherHandler) RemoveWatcher(c *gin.Context) {
+	tenantID := c.MustGet("tenant_id").(uuid.UUID)
+	processID, ok := parseProcessID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.watchers.Delete(c.Request.Context(), tenantID, processID, c.Param("recipient_id")); err != nil {
+		if errors.Is(err, domain.ErrProcessWatcherNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "Process watcher not found",
+				Message: err.Error(),
+			})
+			return
+		}
+		h.logger.Error("Failed to remove process watcher", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to remove process watcher",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// parseProcessID lê o ID do processo da rota
+func parseProcessID(c *gin.Context) (uuid.UUID, bool) {
+	processID, err := uuid.Parse(c.Param("process_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid process ID",
+			Message: err.Error(),
+		})
+		return uuid.Nil, false
+	}
+	return processID, true
+}
@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// PushHandler handler para inscrições Web Push
+type PushHandler struct {
+	subscriptions  domain.PushSubscriptionRepository
+	vapidPublicKey string
+	logger         *zap.Logger
+}
+
+// NewPushHandler cria nova instância do handler
+func NewPushHandler(subscriptions domain.PushSubscriptionRepository, vapidPublicKey string, logger *zap.Logger) *PushHandler {
+	return &PushHandler{
+		subscriptions:  subscriptions,
+		vapidPublicKey: vapidPublicKey,
+		logger:         logger,
+	}
+}
+
+// PushSubscriptionRequest inscrição gerada pelo navegador (PushSubscription.toJSON())
+type PushSubscriptionRequest struct {
+	Endpoint string `json:"endpoint" binding:"required"`
+	Keys     struct {
+		P256dh string `json:"p256dh" binding:"required"`
+		Auth   string `json:"auth" binding:"required"`
+	} `json:"keys" binding:"required"`
+	DeviceName string `json:"device_name,omitempty"`
+}
+
+// GetVAPIDPublicKey retorna a chave pública VAPID
+// @Summary Chave pública VAPID
+// @Description Chave usada como applicationServerKey em pushManager.subscribe
+// @Tags push
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} ErrorResponse
+// @Router /push/vapid-public-key [get]
+func (h *PushHandler) GetVAPIDPublicKey(c *gin.Context) {
+	if h.vapidPublicKey == "" {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Push not configured",
+			Message: "VAPID keys are not configured",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"public_key": h.vapidPublicKey})
+}
+
+// ListSubscriptions lista os dispositivos inscritos do usuário
+// @Summary Listar inscrições push
+// @Tags push
+// @Produce json
+// @Param user_id path string true "ID do usuário"
+// @Success 200 {array} domain.PushSubscription
+// @Failure 400 {object} ErrorResponse
+// @Router /push/users/{user_id}/subscriptions [get]
+func (h *PushHandler) ListSubscriptions(c *gin.Context) {
+	tenantID := c.MustGet("tenant_id").(uuid.UUID)
+	userID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid user ID",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	subscriptions, err := h.subscriptions.FindByUser(c.Request.Context(), tenantID, userID)
+	if err != nil {
+		h.logger.Error("Failed to list push subscriptions", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to list subscriptions",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, subscriptions)
+}
+
+// Subscribe registra (ou renova) a inscrição do dispositivo
+// @Summary Registrar inscrição push
+// @Tags push
+// @Accept json
+// @Produce json
+// @Param user_id path string true "ID do usuário"
+// @Param request body PushSubscriptionRequest true "Inscrição do navegador"
+// @Success 201 {object} domain.PushSubscription
+// @Failure 400 {object} ErrorResponse
+// @Router /push/users/{user_id}/subscriptions [post]
+func (h *PushHandler) Subscribe(c *gin.Context) {
+	tenantID := c.MustGet("tenant_id").(uuid.UUID)
+	userID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid user ID",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	var req PushSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	subscription, err := domain.NewPushSubscription(tenantID, userID, req.Endpoint, req.Keys.P256dh, req.Keys.Auth)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid subscription",
+			Message: err.Error(),
+		})
+		return
+	}
+	subscription.DeviceName = req.DeviceName
+	subscription.UserAgent = c.GetHeader("User-Agent")
+
+	if err := h.subscriptions.Upsert(c.Request.Context(), subscription); err != nil {
+		h.logger.Error("Failed to save push subscription", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to save subscription",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, subscription)
+}
+
+// Unsubscribe remove a inscrição do dispositivo
+// @Summary Remover inscrição push
+// @Tags push
+// @Param user_id path string true "ID do usuário"
+// @Param id path string true "ID da inscrição"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Router /push/users/{user_id}/subscriptions/{id} [delete]
+func (h *PushHandler) Unsubscribe(c *gin.Context) {
+	tenantID := c.MustGet("tenant_id").(uuid.UUID)
+	userID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid user ID",
+			Message: err.Error(),
+		})
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid subscription ID",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.subscriptions.Delete(c.Request.Context(), tenantID, userID, id); err != nil {
+		if errors.Is(err, domain.ErrPushSubscriptionNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "Subscription not found",
+				Message: err.Error(),
+			})
+			return
+		}
+		h.logger.Error("Failed to delete push subscription", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to delete subscription",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
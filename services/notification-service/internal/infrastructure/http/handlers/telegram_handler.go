@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/application/services"
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// TelegramHandler handler da vinculação de chats do Telegram aos usuários
+type TelegramHandler struct {
+	bot    *services.TelegramBotService
+	logger *zap.Logger
+}
+
+// NewTelegramHandler cria nova instância do handler
+func NewTelegramHandler(bot *services.TelegramBotService, logger *zap.Logger) *TelegramHandler {
+	return &TelegramHandler{
+		bot:    bot,
+		logger: logger,
+	}
+}
+
+// CreateLinkCode gera código de uso único para vincular um chat do Telegram ao usuário
+// @Summary Gerar código de vinculação do Telegram
+// @Description O usuário envia /vincular CÓDIGO ao bot ou abre o deep link
+// @Tags telegram
+// @Produce json
+// @Param user_id path string true "ID do usuário"
+// @Success 201 {object} services.TelegramLinkCodeResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /telegram/users/{user_id}/link-codes [post]
+func (h *TelegramHandler) CreateLinkCode(c *gin.Context) {
+	tenantID := c.MustGet("tenant_id").(uuid.UUID)
+	userID, ok := parseTelegramUserID(c)
+	if !ok {
+		return
+	}
+
+	code, err := h.bot.CreateLinkCode(c.Request.Context(), tenantID, userID)
+	if err != nil {
+		h.logger.Error("Failed to create telegram link code", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to create link code",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, code)
+}
+
+// ListLinks lista os chats do Telegram vinculados ao usuário
+// @Summary Listar chats vinculados
+// @Tags telegram
+// @Produce json
+// @Param user_id path string true "ID do usuário"
+// @Success 200 {array} domain.TelegramLink
+// @Failure 400 {object} ErrorResponse
+// @Router /telegram/users/{user_id}/links [get]
+func (h *TelegramHandler) ListLinks(c *gin.Context) {
+	tenantID := c.MustGet("tenant_id").(uuid.UUID)
+	userID, ok := parseTelegramUserID(c)
+	if !ok {
+		return
+	}
+
+	links, err := h.bot.ListLinks(c.Request.Context(), tenantID, userID)
+	if err != nil {
+		h.logger.Error("Failed to list telegram links", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to list telegram links",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, links)
+}
+
+// Unlink remove o vínculo do chat
+// @Summary Desvincular chat do Telegram
+// @Tags telegram
+// @Param user_id path string true "ID do usuário"
+// @Param chat_id path string true "ID do chat"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Router /telegram/users/{user_id}/links/{chat_id} [delete]
+func (h *TelegramHandler) Unlink(c *gin.Context) {
+	tenantID := c.MustGet("tenant_id").(uuid.UUID)
+	userID, ok := parseTelegramUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.bot.Unlink(c.Request.Context(), tenantID, userID, c.Param("chat_id")); err != nil {
+		if errors.Is(err, domain.ErrTelegramChatNotLinked) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "Telegram chat not linked",
+				Message: err.Error(),
+			})
+			return
+		}
+		h.logger.Error("Failed to unlink telegram chat", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to unlink telegram chat",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// parseTelegramUserID lê o ID do usuário da rota
+func parseTelegramUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid user ID",
+			Message: err.Error(),
+		})
+		return uuid.Nil, false
+	}
+	return userID, true
+}
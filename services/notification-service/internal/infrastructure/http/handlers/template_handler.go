@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -158,6 +159,13 @@ func (h *TemplateHandler) UpdateTemplate(c *gin.Context) {
 			})
 			return
 		}
+		if errors.Is(err, domain.ErrTemplateInvalid) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid template",
+				Message: err.Error(),
+			})
+			return
+		}
 		h.logger.Error("Failed to update template", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "Failed to update template",
@@ -299,7 +307,7 @@ func (h *TemplateHandler) ListTemplates(c *gin.Context) {
 
 // PreviewTemplate faz preview de um template
 // @Summary Preview de template
-// @Description Faz preview de um template com variáveis
+// @Description Faz preview de um template com variáveis; com strict, variáveis ausentes retornam 400
 // @Tags templates
 // @Accept json
 // @Produce json
@@ -331,15 +339,22 @@ func (h *TemplateHandler) PreviewTemplate(c *gin.Context) {
 		return
 	}
 
-	preview, err := h.templateService.PreviewTemplate(c.Request.Context(), id, req.Variables)
+	preview, err := h.templateService.PreviewTemplate(c.Request.Context(), id, req.Variables, req.Strict)
 	if err != nil {
-		if err == domain.ErrTemplateNotFound {
+		if errors.Is(err, domain.ErrTemplateNotFound) {
 			c.JSON(http.StatusNotFound, ErrorResponse{
 				Error:   "Template not found",
 				Message: err.Error(),
 			})
 			return
 		}
+		if errors.Is(err, domain.ErrTemplateMissingVariable) || errors.Is(err, domain.ErrTemplateInvalid) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid template variables",
+				Message: err.Error(),
+			})
+			return
+		}
 		h.logger.Error("Failed to preview template", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "Failed to preview template",
@@ -495,6 +510,285 @@ func (h *TemplateHandler) DeactivateTemplate(c *gin.Context) {
 	})
 }
 
+// ListVersions lista as versões do template
+// @Summary Listar versões
+// @Description Lista as versões do template, da mais recente para a mais antiga
+// @Tags templates
+// @Produce json
+// @Param id path string true "ID do template"
+// @Success 200 {array} domain.TemplateVersion
+// @Failure 404 {object} ErrorResponse
+// @Router /templates/{id}/versions [get]
+func (h *TemplateHandler) ListVersions(c *gin.Context) {
+	id, ok := h.parseTemplateID(c)
+	if !ok {
+		return
+	}
+
+	versions, err := h.templateService.ListVersions(c.Request.Context(), id)
+	if err != nil {
+		h.respondVersionError(c, err, "Failed to list template versions")
+		return
+	}
+
+	c.JSON(http.StatusOK, versions)
+}
+
+// CreateVersion cria nova versão do template
+// @Summary Criar versão
+// @Description Cria versão imutável em um idioma e variante; com publish=true já entra em vigor
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do template"
+// @Param request body services.CreateTemplateVersionRequest true "Conteúdo da versão"
+// @Success 201 {object} domain.TemplateVersion
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /templates/{id}/versions [post]
+func (h *TemplateHandler) CreateVersion(c *gin.Context) {
+	id, ok := h.parseTemplateID(c)
+	if !ok {
+		return
+	}
+
+	var req services.CreateTemplateVersionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	version, err := h.templateService.CreateVersion(c.Request.Context(), id, &req)
+	if err != nil {
+		h.respondVersionError(c, err, "Failed to create template version")
+		return
+	}
+
+	c.JSON(http.StatusCreated, version)
+}
+
+// PublishVersion publica uma versão
+// @Summary Publicar versão
+// @Description Publica a versão, arquivando a publicada no mesmo idioma e variante
+// @Tags templates
+// @Produce json
+// @Param id path string true "ID do template"
+// @Param version_id path string true "ID da versão"
+// @Success 200 {object} domain.TemplateVersion
+// @Failure 404 {object} ErrorResponse
+// @Router /templates/{id}/versions/{version_id}/publish [post]
+func (h *TemplateHandler) PublishVersion(c *gin.Context) {
+	id, versionID, ok := h.parseVersionIDs(c)
+	if !ok {
+		return
+	}
+
+	version, err := h.templateService.PublishVersion(c.Request.Context(), id, versionID)
+	if err != nil {
+		h.respondVersionError(c, err, "Failed to publish template version")
+		return
+	}
+
+	c.JSON(http.StatusOK, version)
+}
+
+// ArchiveVersion arquiva uma versão
+// @Summary Arquivar versão
+// @Description Retira a versão do sorteio de variantes
+// @Tags templates
+// @Produce json
+// @Param id path string true "ID do template"
+// @Param version_id path string true "ID da versão"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /templates/{id}/versions/{version_id}/archive [post]
+func (h *TemplateHandler) ArchiveVersion(c *gin.Context) {
+	id, versionID, ok := h.parseVersionIDs(c)
+	if !ok {
+		return
+	}
+
+	if err := h.templateService.ArchiveVersion(c.Request.Context(), id, versionID); err != nil {
+		h.respondVersionError(c, err, "Failed to archive template version")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Template version archived successfully",
+	})
+}
+
+// UpdateVariantWeight altera o peso da variante
+// @Summary Peso da variante
+// @Description Altera o peso da variante no teste A/B (0 pausa a variante)
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do template"
+// @Param version_id path string true "ID da versão"
+// @Param request body VariantWeightRequest true "Novo peso"
+// @Success 200 {object} domain.TemplateVersion
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /templates/{id}/versions/{version_id}/weight [patch]
+func (h *TemplateHandler) UpdateVariantWeight(c *gin.Context) {
+	id, versionID, ok := h.parseVersionIDs(c)
+	if !ok {
+		return
+	}
+
+	var req VariantWeightRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Weight == nil {
+		message := "weight is required"
+		if err != nil {
+			message = err.Error()
+		}
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request format",
+			Message: message,
+		})
+		return
+	}
+
+	version, err := h.templateService.UpdateVariantWeight(c.Request.Context(), id, versionID, *req.Weight)
+	if err != nil {
+		h.respondVersionError(c, err, "Failed to update variant weight")
+		return
+	}
+
+	c.JSON(http.StatusOK, version)
+}
+
+// RollbackVersion reverte para a versão publicada anteriormente
+// @Summary Rollback de versão
+// @Description Republica a versão anterior do idioma e variante (padrão: idioma padrão, variante default)
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do template"
+// @Param request body services.RollbackTemplateRequest false "Idioma e variante"
+// @Success 200 {object} domain.TemplateVersion
+// @Failure 404 {object} ErrorResponse
+// @Router /templates/{id}/rollback [post]
+func (h *TemplateHandler) RollbackVersion(c *gin.Context) {
+	id, ok := h.parseTemplateID(c)
+	if !ok {
+		return
+	}
+
+	var req services.RollbackTemplateRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid request format",
+				Message: err.Error(),
+			})
+			return
+		}
+	}
+
+	version, err := h.templateService.RollbackVersion(c.Request.Context(), id, &req)
+	if err != nil {
+		h.respondVersionError(c, err, "Failed to rollback template version")
+		return
+	}
+
+	c.JSON(http.StatusOK, version)
+}
+
+// GetVariantStats métricas por versão e variante
+// @Summary Métricas das variantes
+// @Description Envios, aberturas, cliques e leituras por versão do template
+// @Tags templates
+// @Produce json
+// @Param id path string true "ID do template"
+// @Param days query int false "Período em dias" default(30)
+// @Success 200 {array} domain.TemplateVariantStats
+// @Failure 404 {object} ErrorResponse
+// @Router /templates/{id}/variants/stats [get]
+func (h *TemplateHandler) GetVariantStats(c *gin.Context) {
+	id, ok := h.parseTemplateID(c)
+	if !ok {
+		return
+	}
+
+	days := 30
+	if daysStr := c.Query("days"); daysStr != "" {
+		if d, err := strconv.Atoi(daysStr); err == nil && d > 0 && d <= 365 {
+			days = d
+		}
+	}
+
+	stats, err := h.templateService.GetVariantStats(c.Request.Context(), id, days)
+	if err != nil {
+		h.respondVersionError(c, err, "Failed to get variant stats")
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// parseTemplateID lê o ID do template da rota
+func (h *TemplateHandler) parseTemplateID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid template ID",
+			Message: err.Error(),
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// parseVersionIDs lê os IDs do template e da versão da rota
+func (h *TemplateHandler) parseVersionIDs(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	id, ok := h.parseTemplateID(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	versionID, err := uuid.Parse(c.Param("version_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid version ID",
+			Message: err.Error(),
+		})
+		return uuid.Nil, uuid.Nil, false
+	}
+	return id, versionID, true
+}
+
+// respondVersionError mapeia erros das operações de versão para o status HTTP
+func (h *TemplateHandler) respondVersionError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, domain.ErrTemplateNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Template not found",
+			Message: err.Error(),
+		})
+	case errors.Is(err, domain.ErrTemplateVersionNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Template version not found",
+			Message: err.Error(),
+		})
+	case errors.Is(err, domain.ErrTemplateInvalid):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid template",
+			Message: err.Error(),
+		})
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   message,
+			Message: err.Error(),
+		})
+	}
+}
+
 // Response types
 type ListTemplatesResponse struct {
 	Templates []*domain.NotificationTemplate `json:"templates"`
@@ -505,8 +799,13 @@ type ListTemplatesResponse struct {
 
 type TemplatePreviewRequest struct {
 	Variables map[string]interface{} `json:"variables"`
+	Strict    bool                   `json:"strict"` // rejeita variáveis ausentes e filtros inválidos
 }
 
 type DuplicateTemplateRequest struct {
 	Name string `json:"name" validate:"required"`
+}
+
+type VariantWeightRequest struct {
+	Weight *int `json:"weight"` // 0 a 1000
 }
\ No newline at end of file
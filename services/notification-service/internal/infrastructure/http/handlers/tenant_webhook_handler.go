@@ -0,0 +1,309 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/application/services"
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// TenantWebhookHandler handler dos webhooks de saída do tenant
+type TenantWebhookHandler struct {
+	webhooks *services.WebhookService
+	logger   *zap.Logger
+}
+
+// NewTenantWebhookHandler cria nova instância do handler
+func NewTenantWebhookHandler(webhooks *services.WebhookService, logger *zap.Logger) *TenantWebhookHandler {
+	return &TenantWebhookHandler{
+		webhooks: webhooks,
+		logger:   logger,
+	}
+}
+
+// CreateSubscription cadastra o webhook. O segredo de assinatura é exibido apenas nesta resposta.
+// @Summary Cadastrar webhook
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param request body services.WebhookSubscriptionRequest true "URL e eventos"
+// @Success 201 {object} services.WebhookSubscriptionCreated
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /webhooks [post]
+func (h *TenantWebhookHandler) CreateSubscription(c *gin.Context) {
+	tenantID := c.MustGet("tenant_id").(uuid.UUID)
+
+	var req services.WebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	created, err := h.webhooks.CreateSubscription(c.Request.Context(), tenantID, &req)
+	if err != nil {
+		h.respondError(c, "Failed to create webhook", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+// ListSubscriptions lista os webhooks do tenant
+// @Summary Listar webhooks
+// @Tags webhooks
+// @Produce json
+// @Success 200 {array} domain.WebhookSubscription
+// @Router /webhooks [get]
+func (h *TenantWebhookHandler) ListSubscriptions(c *gin.Context) {
+	tenantID := c.MustGet("tenant_id").(uuid.UUID)
+
+	subscriptions, err := h.webhooks.ListSubscriptions(c.Request.Context(), tenantID)
+	if err != nil {
+		h.respondError(c, "Failed to list webhooks", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, subscriptions)
+}
+
+// GetSubscription retorna o webhook
+// @Summary Consultar webhook
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "ID do webhook"
+// @Success 200 {object} domain.WebhookSubscription
+// @Failure 404 {object} ErrorResponse
+// @Router /webhooks/{id} [get]
+func (h *TenantWebhookHandler) GetSubscription(c *gin.Context) {
+	tenantID := c.MustGet("tenant_id").(uuid.UUID)
+	id, ok := parseUUIDParam(c, "id", "Invalid webhook ID")
+	if !ok {
+		return
+	}
+
+	subscription, err := h.webhooks.GetSubscription(c.Request.Context(), tenantID, id)
+	if err != nil {
+		h.respondError(c, "Failed to get webhook", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, subscription)
+}
+
+// UpdateSubscription altera URL, descrição, eventos ou situação do webhook. Reativar um webhook
+// desativado zera as falhas consecutivas.
+// @Summary Alterar webhook
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do webhook"
+// @Param request body services.WebhookSubscriptionUpdate true "Campos alterados"
+// @Success 200 {object} domain.WebhookSubscription
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /webhooks/{id} [patch]
+func (h *TenantWebhookHandler) UpdateSubscription(c *gin.Context) {
+	tenantID := c.MustGet("tenant_id").(uuid.UUID)
+	id, ok := parseUUIDParam(c, "id", "Invalid webhook ID")
+	if !ok {
+		return
+	}
+
+	var req services.WebhookSubscriptionUpdate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	subscription, err := h.webhooks.UpdateSubscription(c.Request.Context(), tenantID, id, &req)
+	if err != nil {
+		h.respondError(c, "Failed to update webhook", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, subscription)
+}
+
+// DeleteSubscription remove o webhook e o histórico de entregas
+// @Summary Remover webhook
+// @Tags webhooks
+// @Param id path string true "ID do webhook"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Router /webhooks/{id} [delete]
+func (h *TenantWebhookHandler) DeleteSubscription(c *gin.Context) {
+	tenantID := c.MustGet("tenant_id").(uuid.UUID)
+	id, ok := parseUUIDParam(c, "id", "Invalid webhook ID")
+	if !ok {
+		return
+	}
+
+	if err := h.webhooks.DeleteSubscription(c.Request.Context(), tenantID, id); err != nil {
+		h.respondError(c, "Failed to delete webhook", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Ping envia o evento de teste webhook.ping e retorna a resposta do destino
+// @Summary Testar webhook
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "ID do webhook"
+// @Success 200 {object} services.WebhookPingResult
+// @Failure 404 {object} ErrorResponse
+// @Router /webhooks/{id}/ping [post]
+func (h *TenantWebhookHandler) Ping(c *gin.Context) {
+	tenantID := c.MustGet("tenant_id").(uuid.UUID)
+	id, ok := parseUUIDParam(c, "id", "Invalid webhook ID")
+	if !ok {
+		return
+	}
+
+	result, err := h.webhooks.Ping(c.Request.Context(), tenantID, id)
+	if err != nil {
+		h.respondError(c, "Failed to ping webhook", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ListDeliveries lista o histórico de entregas do webhook
+// @Summary Listar entregas do webhook
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "ID do webhook"
+// @Param status query string false "pending, succeeded ou failed"
+// @Param event_type query string false "Tipo do evento"
+// @Param limit query int false "Limite (padrão 50, máximo 100)"
+// @Param offset query int false "Offset"
+// @Success 200 {array} domain.WebhookDelivery
+// @Failure 404 {object} ErrorResponse
+// @Router /webhooks/{id}/deliveries [get]
+func (h *TenantWebhookHandler) ListDeliveries(c *gin.Context) {
+	tenantID := c.MustGet("tenant_id").(uuid.UUID)
+	id, ok := parseUUIDParam(c, "id", "Invalid webhook ID")
+	if !ok {
+		return
+	}
+
+	filter := domain.WebhookDeliveryFilter{EventType: c.Query("event_type")}
+	if statusStr := c.Query("status"); statusStr != "" {
+		status := domain.WebhookDeliveryStatus(statusStr)
+		filter.Status = &status
+	}
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+			filter.Limit = l
+		}
+	}
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			filter.Offset = o
+		}
+	}
+
+	deliveries, err := h.webhooks.ListDeliveries(c.Request.Context(), tenantID, id, filter)
+	if err != nil {
+		h.respondError(c, "Failed to list webhook deliveries", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// ReplayDelivery reenvia a entrega com o mesmo corpo em uma nova entrega
+// @Summary Reenviar entrega do webhook
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "ID do webhook"
+// @Param delivery_id path string true "ID da entrega"
+// @Success 202 {object} domain.WebhookDelivery
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /webhooks/{id}/deliveries/{delivery_id}/replay [post]
+func (h *TenantWebhookHandler) ReplayDelivery(c *gin.Context) {
+	tenantID := c.MustGet("tenant_id").(uuid.UUID)
+	id, ok := parseUUIDParam(c, "id", "Invalid webhook ID")
+	if !ok {
+		return
+	}
+	deliveryID, ok := parseUUIDParam(c, "delivery_id", "Invalid delivery ID")
+	if !ok {
+		return
+	}
+
+	replay, err := h.webhooks.ReplayDelivery(c.Request.Context(), tenantID, id, deliveryID)
+	if err != nil {
+		h.respondError(c, "Failed to replay webhook delivery", err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, replay)
+}
+
+// respondError traduz os erros dos webhooks em respostas HTTP
+func (h *TenantWebhookHandler) respondError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrWebhookNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Webhook not found",
+			Message: err.Error(),
+		})
+	case errors.Is(err, domain.ErrWebhookDeliveryNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Webhook delivery not found",
+			Message: err.Error(),
+		})
+	case errors.Is(err, domain.ErrWebhookInvalid):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid webhook",
+			Message: err.Error(),
+		})
+	case errors.Is(err, domain.ErrWebhooksNotAllowed), errors.Is(err, domain.ErrWebhookQuotaExceeded):
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Error:   "Webhook not allowed by plan",
+			Message: err.Error(),
+		})
+	case errors.Is(err, domain.ErrWebhookDisabled):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "Webhook disabled",
+			Message: err.Error(),
+		})
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   message,
+			Message: err.Error(),
+		})
+	}
+}
+
+// parseUUIDParam lê o parâmetro de rota como UUID, respondendo 400 quando inválido
+func parseUUIDParam(c *gin.Context, name, message string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(name))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   message,
+			Message: err.Error(),
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
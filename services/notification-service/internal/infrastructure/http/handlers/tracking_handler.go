@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/application/services"
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// transparentGIF pixel 1x1 transparente retornado na rota de abertura
+var transparentGIF = []byte{
+	0x47, 0x49, 0x46, 0x38, 0x39, 0x61, 0x01, 0x00, 0x01, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0xff, 0xff, 0xff, 0x21, 0xf9, 0x04, 0x01, 0x00, 0x00, 0x00, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x00,
+	0x01, 0x00, 0x01, 0x00, 0x00, 0x02, 0x02, 0x44, 0x01, 0x00, 0x3b,
+}
+
+// TrackingHandler handler do rastreamento de abertura e clique dos emails
+type TrackingHandler struct {
+	deliveryService *services.DeliveryStatusService
+	tracker         *services.EngagementTracker
+	logger          *zap.Logger
+}
+
+// NewTrackingHandler cria nova instância do handler
+func NewTrackingHandler(deliveryService *services.DeliveryStatusService, tracker *services.EngagementTracker, logger *zap.Logger) *TrackingHandler {
+	return &TrackingHandler{
+		deliveryService: deliveryService,
+		tracker:         tracker,
+		logger:          logger,
+	}
+}
+
+// TrackOpen registra a abertura do email. O pixel é sempre retornado, mesmo com assinatura
+// inválida, para não quebrar a exibição da mensagem.
+// @Summary Pixel de abertura
+// @Tags tracking
+// @Produce image/gif
+// @Param id path string true "ID da notificação"
+// @Param sig query string true "Assinatura"
+// @Success 200
+// @Router /track/open/{id} [get]
+func (h *TrackingHandler) TrackOpen(c *gin.Context) {
+	if id, err := uuid.Parse(c.Param("id")); err == nil && h.tracker.Verify(id, "", c.Query("sig")) {
+		h.record(c, id, domain.DeliveryEventOpened)
+	}
+
+	c.Header("Cache-Control", "no-store, no-cache, must-revalidate, private")
+	c.Data(http.StatusOK, "image/gif", transparentGIF)
+}
+
+// TrackClick registra o clique e redireciona para o destino do link
+// @Summary Clique rastreado
+// @Tags tracking
+// @Param id path string true "ID da notificação"
+// @Param url query string true "Destino do link"
+// @Param sig query string true "Assinatura da notificação e do destino"
+// @Success 302
+// @Failure 400 {object} ErrorResponse
+// @Router /track/click/{id} [get]
+func (h *TrackingHandler) TrackClick(c *gin.Context) {
+	target := c.Query("url")
+
+	// Sem assinatura válida não redireciona: a rota não pode servir de redirecionamento aberto
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil || target == "" || !h.tracker.Verify(id, target, c.Query("sig")) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid tracking link",
+			Message: "tracking signature does not match",
+		})
+		return
+	}
+
+	h.record(c, id, domain.DeliveryEventClicked)
+
+	c.Header("Cache-Control", "no-store")
+	c.Redirect(http.StatusFound, target)
+}
+
+// record grava o evento; falhas não impedem a resposta ao destinatário
+func (h *TrackingHandler) record(c *gin.Context, id uuid.UUID, event domain.DeliveryEvent) {
+	if err := h.deliveryService.RecordEngagement(c.Request.Context(), id, event, time.Now()); err != nil {
+		h.logger.Warn("Failed to record notification engagement",
+			zap.String("notification_id", id.String()),
+			zap.String("event", string(event)),
+			zap.Error(err))
+	}
+}
@@ -1,35 +1,105 @@
 package handlers
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"io"
 	"net/http"
-	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/application/services"
+	"github.com/direito-lux/notification-service/internal/domain"
+	"github.com/direito-lux/notification-service/internal/infrastructure/providers"
 )
 
 // WebhookHandler handler para webhooks de provedores externos
 type WebhookHandler struct {
-	logger    *zap.Logger
-	botToken  string
+	deliveryService    *services.DeliveryStatusService
+	emailWebhookSecret string
+	smsGateway         providers.SMSGateway
+	smsWebhookSecret   string
+	whatsAppTemplates  *services.WhatsAppTemplateService
+	whatsAppAssistant  *services.WhatsAppAssistantService
+	whatsAppToken      string
+	whatsAppSecret     string
+	telegramBot        *services.TelegramBotService
+	telegramSecret     string
+	consents           *services.ConsentService
+	logger             *zap.Logger
 }
 
-// NewWebhookHandler cria novo handler de webhooks
-func NewWebhookHandler(logger *zap.Logger) *WebhookHandler {
-	botToken := os.Getenv("TELEGRAM_BOT_TOKEN")
+// NewWebhookHandler cria novo handler de webhooks. smsGateway pode ser nil quando o SMS não
+// está configurado; whatsAppTemplates, quando o WhatsApp não está configurado; whatsAppAssistant,
+// quando o assistente está desabilitado; telegramBot, quando o bot interativo não está configurado.
+// whatsAppVerifyToken confirma a inscrição do webhook (GET) e whatsAppAppSecret confere o
+// X-Hub-Signature-256 das notificações; sem ele, todo POST do WhatsApp é rejeitado.
+// consents registra as respostas SAIR/VOLTAR do SMS e do WhatsApp no histórico de consentimento.
+func NewWebhookHandler(
+	deliveryService *services.DeliveryStatusService,
+	emailWebhookSecret string,
+	smsGateway providers.SMSGateway,
+	smsWebhookSecret string,
+	whatsAppTemplates *services.WhatsAppTemplateService,
+	whatsAppAssistant *services.WhatsAppAssistantService,
+	whatsAppVerifyToken string,
+	whatsAppAppSecret string,
+	telegramBot *services.TelegramBotService,
+	telegramWebhookSecret string,
+	consents *services.ConsentService,
+	logger *zap.Logger,
+) *WebhookHandler {
 	return &WebhookHandler{
-		logger:   logger,
-		botToken: botToken,
+		deliveryService:    deliveryService,
+		emailWebhookSecret: emailWebhookSecret,
+		smsGateway:         smsGateway,
+		smsWebhookSecret:   smsWebhookSecret,
+		whatsAppTemplates:  whatsAppTemplates,
+		whatsAppAssistant:  whatsAppAssistant,
+		whatsAppToken:      whatsAppVerifyToken,
+		whatsAppSecret:     whatsAppAppSecret,
+		telegramBot:        telegramBot,
+		telegramSecret:     telegramWebhookSecret,
+		consents:           consents,
+		logger:             logger,
 	}
 }
 
 // TelegramUpdate estrutura do update do Telegram
 type TelegramUpdate struct {
-	UpdateID int              `json:"update_id"`
-	Message  *TelegramMessage `json:"message,omitempty"`
+	UpdateID     int                        `json:"update_id"`
+	Message      *TelegramMessage           `json:"message,omitempty"`
+	MyChatMember *TelegramChatMemberUpdated `json:"my_chat_member,omitempty"`
+	// Clique em botão do teclado inline
+	CallbackQuery *TelegramCallbackQuery `json:"callback_query,omitempty"`
+}
+
+// TelegramCallbackQuery clique em botão inline; message é a mensagem do bot que contém o botão
+type TelegramCallbackQuery struct {
+	ID      string           `json:"id"`
+	From    *TelegramUser    `json:"from"`
+	Message *TelegramMessage `json:"message,omitempty"`
+	Data    string           `json:"data,omitempty"`
+}
+
+// TelegramChatMemberUpdated mudança do status do bot no chat (ex.: usuário bloqueou o bot)
+type TelegramChatMemberUpdated struct {
+	Chat          *TelegramChat      `json:"chat"`
+	From          *TelegramUser      `json:"from,omitempty"`
+	Date          int64              `json:"date"`
+	NewChatMember TelegramChatMember `json:"new_chat_member"`
+}
+
+// TelegramChatMember membro do chat
+type TelegramChatMember struct {
+	Status string        `json:"status"` // member, kicked, left, ...
+	User   *TelegramUser `json:"user,omitempty"`
 }
 
 // TelegramMessage estrutura da mensagem do Telegram
@@ -67,6 +137,13 @@ func (h *WebhookHandler) HandleTelegramWebhook(c *gin.Context) {
 		zap.String("user_agent", c.GetHeader("User-Agent")),
 		zap.String("content_type", c.GetHeader("Content-Type")))
 
+	// Sem o secret, qualquer um poderia se passar por um chat vinculado
+	received := c.GetHeader("X-Telegram-Bot-Api-Secret-Token")
+	if h.telegramSecret == "" || subtle.ConstantTimeCompare([]byte(received), []byte(h.telegramSecret)) != 1 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid secret token"})
+		return
+	}
+
 	var update TelegramUpdate
 	if err := c.ShouldBindJSON(&update); err != nil {
 		h.logger.Error("Erro ao fazer parse do webhook Telegram", zap.Error(err))
@@ -78,333 +155,424 @@ func (h *WebhookHandler) HandleTelegramWebhook(c *gin.Context) {
 		zap.Int("update_id", update.UpdateID),
 		zap.Any("message", update.Message))
 
-	// Processar mensagem recebida
-	if update.Message != nil {
-		h.processMessage(update.Message)
+	// Comandos, números de processo e botões inline
+	h.processTelegramInteraction(c, &update)
+
+	// Bloqueio/desbloqueio do bot pelo usuário
+	if update.MyChatMember != nil && update.MyChatMember.Chat != nil {
+		if err := h.processChatMemberUpdate(c, update.MyChatMember); err != nil {
+			h.logger.Error("Erro ao processar mudança de status do bot no chat", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process update"})
+			return
+		}
 	}
 
 	// Telegram espera 200 OK
 	c.JSON(http.StatusOK, gin.H{"status": "ok"})
 }
 
-// processMessage processa mensagem recebida do Telegram
-func (h *WebhookHandler) processMessage(message *TelegramMessage) {
-	h.logger.Info("Mensagem recebida do Telegram",
-		zap.Int("message_id", message.MessageID),
-		zap.Int64("chat_id", message.Chat.ID),
-		zap.String("text", message.Text))
-
-	var responseText string
-
-	switch message.Text {
-	case "/start":
-		h.logger.Info("Comando /start recebido", zap.Int64("chat_id", message.Chat.ID))
-		responseText = `🏛️ *Bem-vindo ao Direito Lux!*
+// processChatMemberUpdate suprime o chat quando o usuário bloqueia o bot e libera quando desbloqueia
+func (h *WebhookHandler) processChatMemberUpdate(c *gin.Context, update *TelegramChatMemberUpdated) error {
+	chatID := strconv.FormatInt(update.Chat.ID, 10)
 
-🤖 Sou seu assistente jurídico inteligente para monitoramento de processos.
-
-📋 *Comandos disponíveis:*
-/help - Lista todos os comandos
-/status - Status dos seus processos
-/agenda - Prazos importantes
-/busca - Buscar processos
-/relatorio - Relatório rápido
-/configurar - Configurações
+	switch update.NewChatMember.Status {
+	case "kicked":
+		h.logger.Info("Bot bloqueado pelo usuário", zap.String("chat_id", chatID))
+		return h.deliveryService.SuppressContact(c.Request.Context(), domain.NotificationChannelTelegram, chatID, domain.SuppressionReasonBlocked)
+	case "member":
+		return h.deliveryService.UnsuppressContact(c.Request.Context(), domain.NotificationChannelTelegram, chatID)
+	}
 
-✅ *Ambiente STAGING* - Para testes e validação
+	return nil
+}
 
-Digite /help para ver mais opções!`
+// processTelegramInteraction encaminha mensagens e cliques em botões ao bot. Falhas são apenas
+// registradas: o Telegram reenvia updates sem 200 OK e o usuário receberia respostas duplicadas.
+func (h *WebhookHandler) processTelegramInteraction(c *gin.Context, update *TelegramUpdate) {
+	if h.telegramBot == nil {
+		h.logger.Debug("Bot do Telegram não configurado, update ignorado", zap.Int("update_id", update.UpdateID))
+		return
+	}
 
-	case "/help":
-		h.logger.Info("Comando /help recebido", zap.Int64("chat_id", message.Chat.ID))
-		responseText = `🆘 *Central de Ajuda - Direito Lux*
+	ctx := c.Request.Context()
 
-📋 *Comandos principais:*
-/start - Iniciar conversa
-/help - Esta mensagem de ajuda
-/status - 📊 Status dos processos monitorados
-/agenda - 📅 Próximos prazos e audiências
-/busca - 🔍 Buscar processos por número/parte
-/relatorio - 📈 Relatório resumido dos processos
-/configurar - ⚙️ Configurar notificações
+	if message := update.Message; message != nil && message.Chat != nil && message.Text != "" {
+		chatID := strconv.FormatInt(message.Chat.ID, 10)
+		username := ""
+		if message.From != nil {
+			username = message.From.Username
+		}
+		if err := h.telegramBot.HandleMessage(ctx, chatID, username, message.Text); err != nil {
+			h.logger.Error("Erro ao responder mensagem do Telegram", zap.String("chat_id", chatID), zap.Error(err))
+		}
+	}
 
-💡 *Exemplos de uso:*
-• Digite um número de processo para buscar
-• Use /status para ver resumo dos seus processos
-• Configure alertas com /configurar
+	if callback := update.CallbackQuery; callback != nil && callback.Message != nil && callback.Message.Chat != nil {
+		chatID := strconv.FormatInt(callback.Message.Chat.ID, 10)
+		if err := h.telegramBot.HandleCallback(ctx, chatID, callback.ID, callback.Data); err != nil {
+			h.logger.Error("Erro ao responder botão do Telegram", zap.String("chat_id", chatID), zap.Error(err))
+		}
+	}
+}
 
-🔗 *Links úteis:*
-• Web App: https://direito-lux-staging.loca.lt
-• Suporte: Entre em contato com nossa equipe
+// HandleWhatsAppWebhook processa webhooks do WhatsApp: verificação (GET) e notificações assinadas (POST)
+func (h *WebhookHandler) HandleWhatsAppWebhook(c *gin.Context) {
+	h.logger.Info("Recebido webhook do WhatsApp", 
+		zap.String("method", c.Request.Method),
+		zap.String("user_agent", c.GetHeader("User-Agent")))
 
-✅ *Versão STAGING* - Ambiente de testes`
+	// Verificação do webhook (GET request)
+	if c.Request.Method == "GET" {
+		hubMode := c.Query("hub.mode")
+		hubChallenge := c.Query("hub.challenge")
+		hubVerifyToken := c.Query("hub.verify_token")
 
-	case "/status":
-		h.logger.Info("Comando /status recebido", zap.Int64("chat_id", message.Chat.ID))
-		responseText = `📊 *Status dos Processos*
+		h.logger.Info("Verificação do webhook WhatsApp", zap.String("hub.mode", hubMode))
 
-🟢 *Ativos:* 0 processos monitorados
-🟡 *Pendentes:* 0 atualizações
-🔴 *Alertas:* 0 prazos vencendo
+		// Validar token de verificação
+		if hubMode == "subscribe" && h.whatsAppToken != "" &&
+			subtle.ConstantTimeCompare([]byte(hubVerifyToken), []byte(h.whatsAppToken)) == 1 {
+			h.logger.Info("Webhook WhatsApp verificado com sucesso")
+			c.String(http.StatusOK, hubChallenge)
+			return
+		}
 
-📈 *Resumo último mês:*
-• Movimentações: 0
-• Notificações enviadas: 0
-• Última atualização: --
-
-⚠️ *Ambiente STAGING*
-Para adicionar processos reais, acesse:
-https://direito-lux-staging.loca.lt
+		h.logger.Warn("Token de verificação inválido")
+		c.JSON(http.StatusForbidden, gin.H{"error": "Invalid verify token"})
+		return
+	}
 
-Use /help para ver mais comandos.`
+	// Processar webhook (POST request)
+	payload, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		h.logger.Error("Erro ao ler webhook WhatsApp", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid body"})
+		return
+	}
 
-	case "/agenda":
-		h.logger.Info("Comando /agenda recebido", zap.Int64("chat_id", message.Chat.ID))
-		responseText = `📅 *Agenda de Prazos*
+	// Sem a assinatura, qualquer um poderia forjar recibos, mensagens e descadastros
+	if !validWhatsAppSignature(c.GetHeader("X-Hub-Signature-256"), payload, h.whatsAppSecret) {
+		h.logger.Warn("Assinatura do webhook WhatsApp inválida")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid signature"})
+		return
+	}
 
-🗓️ *Próximos 7 dias:*
-• Nenhum prazo cadastrado
+	updates, err := providers.ParseWhatsAppStatusUpdates(payload)
+	if err != nil {
+		h.logger.Error("Erro ao fazer parse do webhook WhatsApp", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON"})
+		return
+	}
 
-⏰ *Alertas configurados:*
-• 3 dias antes do vencimento
-• 1 dia antes do vencimento
-• No dia do vencimento
+	// Recibos de entrega/leitura: erro devolve 500 para o WhatsApp reenviar o webhook
+	for _, update := range updates {
+		if err := h.deliveryService.ProcessStatusUpdate(c.Request.Context(), update); err != nil {
+			h.logger.Error("Erro ao processar status do WhatsApp",
+				zap.String("message_id", update.ExternalID),
+				zap.String("status", string(update.Event)),
+				zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process status"})
+			return
+		}
+	}
 
-📋 *Para adicionar prazos:*
-1. Acesse a plataforma web
-2. Cadastre seus processos
-3. Configure alertas automáticos
+	if h.whatsAppTemplates != nil {
+		if err := h.processWhatsAppSessionAndTemplates(c, payload); err != nil {
+			h.logger.Error("Erro ao processar mensagens e templates do WhatsApp", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process webhook"})
+			return
+		}
+	}
 
-🔗 Acessar: https://direito-lux-staging.loca.lt
+	if err := h.processWhatsAppKeywords(c, payload); err != nil {
+		h.logger.Error("Erro ao processar descadastro do WhatsApp", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process webhook"})
+		return
+	}
 
-Use /configurar para ajustar notificações.`
+	h.processWhatsAppConversations(c, payload)
 
-	case "/busca":
-		h.logger.Info("Comando /busca recebido", zap.Int64("chat_id", message.Chat.ID))
-		responseText = `🔍 *Busca de Processos*
+	h.logger.Info("Webhook WhatsApp processado", zap.Int("status_updates", len(updates)))
 
-📝 *Como buscar:*
-• Digite o número do processo
-• Formato: 1234567-89.2023.8.26.0001
-• Ou envie apenas os números
-
-🎯 *Exemplo:*
-1234567892023826001
+	// WhatsApp espera 200 OK
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
 
-📊 *Informações retornadas:*
-• Dados do processo
-• Última movimentação
-• Partes envolvidas
-• Status atual
+// processWhatsAppSessionAndTemplates registra as mensagens recebidas (abrem a janela de
+// atendimento de 24 horas) e as mudanças de aprovação dos templates
+func (h *WebhookHandler) processWhatsAppSessionAndTemplates(c *gin.Context, payload []byte) error {
+	messages, err := providers.ParseWhatsAppInboundMessages(payload)
+	if err != nil {
+		return err
+	}
+	for _, message := range messages {
+		if err := h.whatsAppTemplates.RecordInbound(c.Request.Context(), message.From, message.ReceivedAt); err != nil {
+			return err
+		}
+	}
 
-⚠️ *Ambiente STAGING*
-Alguns processos podem não estar disponíveis.
+	templates, err := providers.ParseWhatsAppTemplateStatusUpdates(payload)
+	if err != nil {
+		return err
+	}
+	if len(templates) > 0 {
+		if _, err := h.whatsAppTemplates.ApplyStatusUpdates(c.Request.Context(), templates); err != nil {
+			return err
+		}
+	}
 
-💡 *Dica:* Para busca avançada, use a plataforma web:
-https://direito-lux-staging.loca.lt`
+	return nil
+}
 
-	case "/relatorio":
-		h.logger.Info("Comando /relatorio recebido", zap.Int64("chat_id", message.Chat.ID))
-		responseText = `📈 *Relatório Rápido*
+// processWhatsAppKeywords aplica as respostas SAIR/VOLTAR: suprime ou libera o contato e registra
+// a revogação ou a concessão no histórico de consentimento. Só recebe payloads com a assinatura
+// X-Hub-Signature-256 já conferida: um descadastro forjado revogaria o consentimento de terceiros.
+func (h *WebhookHandler) processWhatsAppKeywords(c *gin.Context, payload []byte) error {
+	messages, err := providers.ParseWhatsAppInboundMessages(payload)
+	if err != nil {
+		return err
+	}
 
-📊 *Resumo Geral:*
-• Total de processos: 0
-• Ativos: 0
-• Arquivados: 0
-• Suspensos: 0
+	for _, message := range messages {
+		keyword := domain.ParseSMSKeyword(message.Text)
+		if keyword == domain.SMSKeywordNone {
+			continue
+		}
 
-📅 *Últimos 30 dias:*
-• Movimentações: 0
-• Notificações: 0
-• Alertas enviados: 0
+		contact := domain.NormalizeWhatsAppContact(message.From)
+		switch keyword {
+		case domain.SMSKeywordOptOut:
+			h.logger.Info("Descadastro de WhatsApp recebido", zap.String("from", contact))
+			err = h.deliveryService.SuppressContact(c.Request.Context(), domain.NotificationChannelWhatsApp, contact, domain.SuppressionReasonOptOut)
+		case domain.SMSKeywordOptIn:
+			h.logger.Info("Recadastro de WhatsApp recebido", zap.String("from", contact))
+			err = h.deliveryService.UnsuppressContact(c.Request.Context(), domain.NotificationChannelWhatsApp, contact)
+		}
+		if err != nil {
+			return err
+		}
 
-🏛️ *Por tribunal:*
-• TJSP: 0 processos
-• TJRJ: 0 processos
-• Outros: 0 processos
+		if h.consents != nil {
+			evidence := map[string]interface{}{"message": message.Text, "received_at": message.ReceivedAt}
+			if err := h.consents.HandleKeyword(c.Request.Context(), domain.NotificationChannelWhatsApp, contact, keyword, evidence); err != nil {
+				return err
+			}
+		}
+	}
 
-📄 *Relatório completo:*
-Para relatórios detalhados, acesse:
-https://direito-lux-staging.loca.lt/relatorios
+	return nil
+}
 
-Use /status para informações atualizadas.`
+// processWhatsAppConversations encaminha as mensagens de texto recebidas ao assistente. Falhas são
+// apenas registradas: o WhatsApp reenvia webhooks sem 200 OK e o contato receberia respostas
+// duplicadas.
+func (h *WebhookHandler) processWhatsAppConversations(c *gin.Context, payload []byte) {
+	if h.whatsAppAssistant == nil {
+		return
+	}
 
-	case "/configurar":
-		h.logger.Info("Comando /configurar recebido", zap.Int64("chat_id", message.Chat.ID))
-		responseText = `⚙️ *Configurações*
+	messages, err := providers.ParseWhatsAppInboundMessages(payload)
+	if err != nil {
+		h.logger.Error("Erro ao ler mensagens do WhatsApp", zap.Error(err))
+		return
+	}
+	for _, message := range messages {
+		// SAIR/VOLTAR já foram respondidos pelo registro de consentimento
+		if message.Text == "" || domain.ParseSMSKeyword(message.Text) != domain.SMSKeywordNone {
+			continue
+		}
+		if err := h.whatsAppAssistant.HandleInbound(c.Request.Context(), message.From, message.Text); err != nil {
+			h.logger.Error("Erro ao responder mensagem do WhatsApp", zap.String("from", message.From), zap.Error(err))
+		}
+	}
+}
 
-🔔 *Notificações ativas:*
-• Telegram: ✅ Ativo
-• Email: ❌ Não configurado
-• WhatsApp: ❌ Não configurado
+// EmailFeedbackRequest bounce ou reclamação enviada pelo serviço de email (formato genérico)
+type EmailFeedbackRequest struct {
+	MessageID  string     `json:"message_id" binding:"required"` // Message-ID do email enviado
+	Recipient  string     `json:"recipient" binding:"required"`
+	BounceType string     `json:"bounce_type,omitempty"` // hard/permanent ou soft/transient (apenas bounces)
+	Reason     string     `json:"reason,omitempty"`
+	Timestamp  *time.Time `json:"timestamp,omitempty"`
+}
 
-⏰ *Frequência de alertas:*
-• Movimentações: Instantâneo
-• Prazos: 3, 1 dia antes e no dia
-• Audiências: 7, 3, 1 dia antes
+// HandleEmailBounce processa bounces de email; hard bounces suprimem o endereço
+func (h *WebhookHandler) HandleEmailBounce(c *gin.Context) {
+	h.handleEmailFeedback(c, domain.DeliveryEventBounced)
+}
 
-🎯 *Personalizar:*
-Para configurações detalhadas, acesse:
-https://direito-lux-staging.loca.lt/configuracoes
+// HandleEmailComplaint processa reclamações (spam) de email; o endereço é suprimido
+func (h *WebhookHandler) HandleEmailComplaint(c *gin.Context) {
+	h.handleEmailFeedback(c, domain.DeliveryEventComplained)
+}
 
-📱 *Canais disponíveis:*
-• Telegram (atual)
-• Email
-• WhatsApp
-• SMS
+// handleEmailFeedback converte o webhook de email em atualização de status
+func (h *WebhookHandler) handleEmailFeedback(c *gin.Context, event domain.DeliveryEvent) {
+	if !h.checkWebhookSecret(c, h.emailWebhookSecret, "EMAIL_WEBHOOK_SECRET") {
+		return
+	}
 
-Use a plataforma web para configurações avançadas.`
+	var req EmailFeedbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
 
-	default:
-		// Verifica se é um número de processo
-		if len(message.Text) > 10 && h.isProcessNumber(message.Text) {
-			h.logger.Info("Possível número de processo recebido", 
-				zap.String("text", message.Text),
-				zap.Int64("chat_id", message.Chat.ID))
-			responseText = fmt.Sprintf(`🔍 *Buscando processo:* %s
-
-⏳ Consultando DataJud CNJ...
-
-⚠️ *Ambiente STAGING*
-Em produção, aqui apareceriam:
-• Dados do processo
-• Última movimentação
-• Partes envolvidas
-• Próximos prazos
-
-💡 Para busca real, acesse:
-https://direito-lux-staging.loca.lt`, message.Text)
-		} else {
-			h.logger.Info("Mensagem de texto livre recebida", 
-				zap.String("text", message.Text),
-				zap.Int64("chat_id", message.Chat.ID))
-			responseText = `🤖 *Direito Lux Bot*
-
-Não entendi sua mensagem. 
-
-📋 *Comandos disponíveis:*
-/help - Ver todos os comandos
-/status - Status dos processos
-/busca - Buscar processo
-
-💡 *Dicas:*
-• Use os comandos com /
-• Envie um número de processo para buscar
-• Digite /help para ver todas as opções
-
-✅ *Bot ativo* - Ambiente STAGING`
-		}
+	update := &domain.DeliveryStatusUpdate{
+		Channel:    domain.NotificationChannelEmail,
+		ExternalID: strings.Trim(strings.TrimSpace(req.MessageID), "<>"),
+		Event:      event,
+		Recipient:  req.Recipient,
+		HardBounce: event == domain.DeliveryEventBounced && isHardBounce(req.BounceType),
+		Reason:     req.Reason,
+	}
+	if req.Timestamp != nil {
+		update.OccurredAt = *req.Timestamp
 	}
 
-	// Enviar resposta
-	if responseText != "" {
-		h.sendTelegramMessage(message.Chat.ID, responseText)
+	if err := h.deliveryService.ProcessStatusUpdate(c.Request.Context(), update); err != nil {
+		h.logger.Error("Erro ao processar webhook de email",
+			zap.String("message_id", update.ExternalID),
+			zap.String("event", string(event)),
+			zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process webhook"})
+		return
 	}
-}
 
-// isProcessNumber verifica se o texto parece ser um número de processo
-func (h *WebhookHandler) isProcessNumber(text string) bool {
-	// Verificação básica para números de processo CNJ
-	// Formato: NNNNNNN-DD.AAAA.J.TR.OOOO ou só números
-	return len(text) >= 15 && len(text) <= 25
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
 }
 
-// TelegramSendMessage estrutura para envio de mensagem
-type TelegramSendMessage struct {
-	ChatID    int64  `json:"chat_id"`
-	Text      string `json:"text"`
-	ParseMode string `json:"parse_mode,omitempty"`
+// isHardBounce indica falha permanente do endereço (nomenclatura genérica e do Amazon SES)
+func isHardBounce(bounceType string) bool {
+	return strings.EqualFold(bounceType, "hard") || strings.EqualFold(bounceType, "permanent")
 }
 
-// sendTelegramMessage envia mensagem de resposta via API do Telegram
-func (h *WebhookHandler) sendTelegramMessage(chatID int64, text string) {
-	if h.botToken == "" {
-		h.logger.Error("Bot token não configurado")
+// HandleSMSStatus processa relatórios de entrega (DLR) do gateway de SMS
+func (h *WebhookHandler) HandleSMSStatus(c *gin.Context) {
+	payload, ok := h.readSMSWebhook(c)
+	if !ok {
 		return
 	}
 
-	message := TelegramSendMessage{
-		ChatID:    chatID,
-		Text:      text,
-		ParseMode: "Markdown",
+	updates, err := h.smsGateway.ParseDeliveryReports(payload)
+	if err != nil {
+		h.logger.Error("Erro ao fazer parse do relatório de entrega SMS", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON"})
+		return
 	}
 
-	jsonData, err := json.Marshal(message)
+	// Erro devolve 500 para o gateway reenviar o relatório
+	for _, update := range updates {
+		if err := h.deliveryService.ProcessStatusUpdate(c.Request.Context(), update); err != nil {
+			h.logger.Error("Erro ao processar status do SMS",
+				zap.String("message_id", update.ExternalID),
+				zap.String("status", string(update.Event)),
+				zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process status"})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// HandleSMSInbound processa respostas dos usuários: SAIR suprime o número e VOLTAR o libera; as
+// duas ficam registradas no histórico de consentimento. Exige SMS_WEBHOOK_SECRET configurado.
+func (h *WebhookHandler) HandleSMSInbound(c *gin.Context) {
+	payload, ok := h.readSMSWebhook(c)
+	if !ok {
+		return
+	}
+
+	message, err := h.smsGateway.ParseInboundMessage(payload)
 	if err != nil {
-		h.logger.Error("Erro ao serializar mensagem", zap.Error(err))
+		h.logger.Error("Erro ao fazer parse da mensagem SMS recebida", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON"})
 		return
 	}
 
-	url := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", h.botToken)
+	keyword := domain.ParseSMSKeyword(message.Body)
+	switch keyword {
+	case domain.SMSKeywordOptOut:
+		h.logger.Info("Descadastro de SMS recebido", zap.String("from", message.From))
+		err = h.deliveryService.SuppressContact(c.Request.Context(), domain.NotificationChannelSMS, message.From, domain.SuppressionReasonOptOut)
+	case domain.SMSKeywordOptIn:
+		h.logger.Info("Recadastro de SMS recebido", zap.String("from", message.From))
+		err = h.deliveryService.UnsuppressContact(c.Request.Context(), domain.NotificationChannelSMS, message.From)
+	default:
+		h.logger.Debug("Mensagem SMS recebida sem palavra-chave", zap.String("from", message.From))
+	}
 
-	resp, err := http.Post(url, "application/json", bytes.NewBuffer(jsonData))
 	if err != nil {
-		h.logger.Error("Erro ao enviar mensagem para Telegram", zap.Error(err))
+		h.logger.Error("Erro ao processar resposta SMS", zap.String("from", message.From), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process message"})
 		return
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode == 200 {
-		h.logger.Info("Mensagem enviada com sucesso para Telegram", 
-			zap.Int64("chat_id", chatID),
-			zap.String("text_preview", text[:min(50, len(text))]))
-	} else {
-		h.logger.Error("Erro ao enviar mensagem para Telegram", 
-			zap.Int("status_code", resp.StatusCode),
-			zap.Int64("chat_id", chatID))
+	if h.consents != nil {
+		evidence := map[string]interface{}{"message": message.Body, "message_id": message.MessageID, "received_at": message.ReceivedAt}
+		if err := h.consents.HandleKeyword(c.Request.Context(), domain.NotificationChannelSMS, message.From, keyword, evidence); err != nil {
+			h.logger.Error("Erro ao registrar consentimento do SMS", zap.String("from", message.From), zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process message"})
+			return
+		}
 	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
 }
 
-// min retorna o menor entre dois inteiros
-func min(a, b int) int {
-	if a < b {
-		return a
+// readSMSWebhook valida o segredo e lê o corpo dos webhooks de SMS
+func (h *WebhookHandler) readSMSWebhook(c *gin.Context) ([]byte, bool) {
+	if h.smsGateway == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "SMS channel not configured"})
+		return nil, false
 	}
-	return b
-}
 
-// HandleWhatsAppWebhook processa webhooks do WhatsApp (placeholder)
-func (h *WebhookHandler) HandleWhatsAppWebhook(c *gin.Context) {
-	h.logger.Info("Recebido webhook do WhatsApp", 
-		zap.String("method", c.Request.Method),
-		zap.String("user_agent", c.GetHeader("User-Agent")))
+	if !h.checkWebhookSecret(c, h.smsWebhookSecret, "SMS_WEBHOOK_SECRET") {
+		return nil, false
+	}
 
-	// Verificação do webhook (GET request)
-	if c.Request.Method == "GET" {
-		hubMode := c.Query("hub.mode")
-		hubChallenge := c.Query("hub.challenge")
-		hubVerifyToken := c.Query("hub.verify_token")
+	payload, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid body"})
+		return nil, false
+	}
 
-		h.logger.Info("Verificação do webhook WhatsApp",
-			zap.String("hub.mode", hubMode),
-			zap.String("hub.challenge", hubChallenge),
-			zap.String("hub.verify_token", hubVerifyToken))
+	return payload, true
+}
 
-		// Validar token de verificação
-		expectedToken := "direito_lux_staging_2025"
-		if hubMode == "subscribe" && hubVerifyToken == expectedToken {
-			h.logger.Info("Webhook WhatsApp verificado com sucesso")
-			c.String(http.StatusOK, hubChallenge)
-			return
-		}
+// checkWebhookSecret valida o header X-Webhook-Secret e responde a recusa. Sem o segredo
+// configurado, a origem não é verificável e nenhuma chamada é aceita (503).
+func (h *WebhookHandler) checkWebhookSecret(c *gin.Context, secret, envVar string) bool {
+	if secret == "" {
+		h.logger.Warn("Webhook recusado: segredo não configurado", zap.String("env", envVar))
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Webhook secret not configured"})
+		return false
+	}
 
-		h.logger.Warn("Token de verificação inválido",
-			zap.String("expected", expectedToken),
-			zap.String("received", hubVerifyToken))
-		c.JSON(http.StatusForbidden, gin.H{"error": "Invalid verify token"})
-		return
+	received := c.GetHeader("X-Webhook-Secret")
+	if subtle.ConstantTimeCompare([]byte(received), []byte(secret)) != 1 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid webhook secret"})
+		return false
 	}
 
-	// Processar webhook (POST request)
-	var payload map[string]interface{}
-	if err := c.ShouldBindJSON(&payload); err != nil {
-		h.logger.Error("Erro ao fazer parse do webhook WhatsApp", zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON"})
-		return
+	return true
+}
+
+// validWhatsAppSignature confere o header X-Hub-Signature-256 ("sha256=<hex>"), o HMAC-SHA256 do
+// corpo bruto com o app secret. Sem secret configurado, nenhuma assinatura é aceita.
+func validWhatsAppSignature(header string, payload []byte, secret string) bool {
+	signature, ok := strings.CutPrefix(header, "sha256=")
+	if secret == "" || !ok {
+		return false
 	}
 
-	h.logger.Info("Webhook WhatsApp processado", zap.Any("payload", payload))
+	received, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
 
-	// WhatsApp espera 200 OK
-	c.JSON(http.StatusOK, gin.H{"status": "ok"})
-}
\ No newline at end of file
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hmac.Equal(received, mac.Sum(nil))
+}
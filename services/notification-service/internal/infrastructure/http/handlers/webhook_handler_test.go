@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/infrastructure/providers"
+)
+
+const testWhatsAppPayload = `{"object":"whatsapp_business_account","entry":[]}`
+
+// testWhatsAppOptOut resposta SAIR recebida pelo WhatsApp
+const testWhatsAppOptOut = `{"object":"whatsapp_business_account","entry":[{"changes":[{"field":"messages","value":{"messages":[{"from":"5541999998888","id":"wamid.1","timestamp":"1735689600","type":"text","text":{"body":"SAIR"}}]}}]}]}`
+
+// newWhatsAppWebhookRouter router com o webhook do WhatsApp e os segredos informados
+func newWhatsAppWebhookRouter(verifyToken, appSecret string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := NewWebhookHandler(nil, "", nil, "", nil, nil, verifyToken, appSecret, nil, "", nil, zap.NewNop())
+
+	router := gin.New()
+	router.GET("/webhook/whatsapp", handler.HandleWhatsAppWebhook)
+	router.POST("/webhook/whatsapp", handler.HandleWhatsAppWebhook)
+	return router
+}
+
+func signWhatsAppPayload(payload, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestHandleWhatsAppWebhookVerification(t *testing.T) {
+	tests := []struct {
+		name        string
+		configured  string
+		received    string
+		wantStatus  int
+		wantPayload string
+	}{
+		{"configured token", "verify-token", "verify-token", http.StatusOK, "challenge"},
+		{"wrong token", "verify-token", "direito_lux_staging_2025", http.StatusForbidden, ""},
+		{"token not configured", "", "", http.StatusForbidden, ""},
+	}
+
+	for _, tt := range tests {
+		router := newWhatsAppWebhookRouter(tt.configured, "app-secret")
+		url := "/webhook/whatsapp?hub.mode=subscribe&hub.challenge=challenge&hub.verify_token=" + tt.received
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, url, nil))
+
+		if recorder.Code != tt.wantStatus {
+			t.Errorf("%s: expected status %d, got %d", tt.name, tt.wantStatus, recorder.Code)
+		}
+		if tt.wantPayload != "" && recorder.Body.String() != tt.wantPayload {
+			t.Errorf("%s: expected challenge %q, got %q", tt.name, tt.wantPayload, recorder.Body.String())
+		}
+	}
+}
+
+func TestHandleWhatsAppWebhookSignature(t *testing.T) {
+	tests := []struct {
+		name       string
+		secret     string
+		signature  string
+		wantStatus int
+	}{
+		{"valid signature", "app-secret", signWhatsAppPayload(testWhatsAppPayload, "app-secret"), http.StatusOK},
+		{"missing signature", "app-secret", "", http.StatusUnauthorized},
+		{"signature with another secret", "app-secret", signWhatsAppPayload(testWhatsAppPayload, "forged"), http.StatusUnauthorized},
+		{"signature without prefix", "app-secret", strings.TrimPrefix(signWhatsAppPayload(testWhatsAppPayload, "app-secret"), "sha256="), http.StatusUnauthorized},
+		{"secret not configured", "", signWhatsAppPayload(testWhatsAppPayload, ""), http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		router := newWhatsAppWebhookRouter("verify-token", tt.secret)
+		request := httptest.NewRequest(http.MethodPost, "/webhook/whatsapp", strings.NewReader(testWhatsAppPayload))
+		if tt.signature != "" {
+			request.Header.Set("X-Hub-Signature-256", tt.signature)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+
+		if recorder.Code != tt.wantStatus {
+			t.Errorf("%s: expected status %d, got %d", tt.name, tt.wantStatus, recorder.Code)
+		}
+	}
+}
+
+func TestHandleWhatsAppWebhookIgnoresForgedOptOut(t *testing.T) {
+	// Sem assinatura válida, nada é processado: o handler não tem serviços para suprimir o
+	// contato ou registrar a revogação do consentimento
+	router := newWhatsAppWebhookRouter("verify-token", "app-secret")
+	request := httptest.NewRequest(http.MethodPost, "/webhook/whatsapp", strings.NewReader(testWhatsAppOptOut))
+	request.Header.Set("X-Hub-Signature-256", signWhatsAppPayload(testWhatsAppOptOut, "forged"))
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusUnauthorized {
+		t.Errorf("expected forged opt-out to be rejected, got status %d", recorder.Code)
+	}
+}
+
+// stubSMSGateway gateway configurado; as respostas recusadas não chegam a ser interpretadas
+type stubSMSGateway struct {
+	providers.SMSGateway
+}
+
+func TestWebhooksRequireWebhookSecret(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		secret     string
+		header     string
+		wantStatus int
+	}{
+		{"sms inbound without secret", "/webhook/sms/inbound", "", "", http.StatusServiceUnavailable},
+		{"sms inbound with wrong secret", "/webhook/sms/inbound", "webhook-secret", "forged", http.StatusUnauthorized},
+		{"sms status without secret", "/webhook/sms/status", "", "", http.StatusServiceUnavailable},
+		{"sms status with wrong secret", "/webhook/sms/status", "webhook-secret", "forged", http.StatusUnauthorized},
+		{"email bounce without secret", "/webhook/email/bounce", "", "", http.StatusServiceUnavailable},
+		{"email bounce with wrong secret", "/webhook/email/bounce", "webhook-secret", "", http.StatusUnauthorized},
+		{"email complaint without secret", "/webhook/email/complaint", "", "", http.StatusServiceUnavailable},
+		{"email complaint with wrong secret", "/webhook/email/complaint", "webhook-secret", "forged", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		gin.SetMode(gin.TestMode)
+		handler := NewWebhookHandler(nil, tt.secret, stubSMSGateway{}, tt.secret, nil, nil, "", "", nil, "", nil, zap.NewNop())
+		router := gin.New()
+		router.POST("/webhook/sms/inbound", handler.HandleSMSInbound)
+		router.POST("/webhook/sms/status", handler.HandleSMSStatus)
+		router.POST("/webhook/email/bounce", handler.HandleEmailBounce)
+		router.POST("/webhook/email/complaint", handler.HandleEmailComplaint)
+
+		request := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(`{"from":"+5541999998888","body":"SAIR","message_id":"msg-1","recipient":"advogado@example.com"}`))
+		if tt.header != "" {
+			request.Header.Set("X-Webhook-Secret", tt.header)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+
+		if recorder.Code != tt.wantStatus {
+			t.Errorf("%s: expected status %d, got %d", tt.name, tt.wantStatus, recorder.Code)
+		}
+	}
+}
+
+func TestHandleTelegramWebhookRequiresSecret(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured string
+		received   string
+	}{
+		{"secret not configured", "", ""},
+		{"missing header", "telegram-secret", ""},
+		{"wrong secret", "telegram-secret", "forged"},
+	}
+
+	for _, tt := range tests {
+		gin.SetMode(gin.TestMode)
+		handler := NewWebhookHandler(nil, "", nil, "", nil, nil, "", "", nil, tt.configured, nil, zap.NewNop())
+		router := gin.New()
+		router.POST("/webhook/telegram", handler.HandleTelegramWebhook)
+
+		request := httptest.NewRequest(http.MethodPost, "/webhook/telegram", strings.NewReader(`{"update_id":1}`))
+		if tt.received != "" {
+			request.Header.Set("X-Telegram-Bot-Api-Secret-Token", tt.received)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+
+		if recorder.Code != http.StatusUnauthorized {
+			t.Errorf("%s: expected status %d, got %d", tt.name, http.StatusUnauthorized, recorder.Code)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/application/services"
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// WhatsAppTemplateHandler handler do mapeamento para templates aprovados do WhatsApp
+type WhatsAppTemplateHandler struct {
+	service *services.WhatsAppTemplateService
+	logger  *zap.Logger
+}
+
+// NewWhatsAppTemplateHandler cria nova instância do handler
+func NewWhatsAppTemplateHandler(service *services.WhatsAppTemplateService, logger *zap.Logger) *WhatsAppTemplateHandler {
+	return &WhatsAppTemplateHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// ListMappings lista os templates do WhatsApp mapeados ao template
+// @Summary Listar templates do WhatsApp
+// @Tags templates
+// @Produce json
+// @Param id path string true "ID do template"
+// @Success 200 {array} domain.WhatsAppTemplateMapping
+// @Failure 404 {object} ErrorResponse
+// @Router /templates/{id}/whatsapp-templates [get]
+func (h *WhatsAppTemplateHandler) ListMappings(c *gin.Context) {
+	templateID, ok := parseWhatsAppTemplateID(c)
+	if !ok {
+		return
+	}
+
+	mappings, err := h.service.ListMappings(c.Request.Context(), templateID)
+	if err != nil {
+		h.respondError(c, err, "Failed to list WhatsApp templates")
+		return
+	}
+
+	c.JSON(http.StatusOK, mappings)
+}
+
+// SetMapping mapeia o template, em um idioma, ao template aprovado do WhatsApp
+// @Summary Mapear template do WhatsApp
+// @Description Usado fora da janela de atendimento de 24 horas; fica pendente até a Meta aprovar
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do template"
+// @Param request body services.SetWhatsAppTemplateRequest true "Template registrado e parâmetros"
+// @Success 200 {object} domain.WhatsAppTemplateMapping
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /templates/{id}/whatsapp-templates [post]
+func (h *WhatsAppTemplateHandler) SetMapping(c *gin.Context) {
+	templateID, ok := parseWhatsAppTemplateID(c)
+	if !ok {
+		return
+	}
+
+	var req services.SetWhatsAppTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	mapping, err := h.service.SetMapping(c.Request.Context(), templateID, &req)
+	if err != nil {
+		h.respondError(c, err, "Failed to map WhatsApp template")
+		return
+	}
+
+	c.JSON(http.StatusOK, mapping)
+}
+
+// DeleteMapping remove o mapeamento
+// @Summary Remover template do WhatsApp
+// @Tags templates
+// @Param id path string true "ID do template"
+// @Param mapping_id path string true "ID do mapeamento"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Router /templates/{id}/whatsapp-templates/{mapping_id} [delete]
+func (h *WhatsAppTemplateHandler) DeleteMapping(c *gin.Context) {
+	templateID, ok := parseWhatsAppTemplateID(c)
+	if !ok {
+		return
+	}
+
+	mappingID, err := uuid.Parse(c.Param("mapping_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid mapping ID",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.service.DeleteMapping(c.Request.Context(), templateID, mappingID); err != nil {
+		h.respondError(c, err, "Failed to delete WhatsApp template")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// SyncStatuses sincroniza imediatamente o status de aprovação com a Meta
+// @Summary Sincronizar templates do WhatsApp
+// @Tags admin
+// @Produce json
+// @Success 200 {object} WhatsAppTemplateSyncResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /admin/whatsapp/templates/sync [post]
+func (h *WhatsAppTemplateHandler) SyncStatuses(c *gin.Context) {
+	updated, err := h.service.SyncStatuses(c.Request.Context())
+	if err != nil {
+		if errors.Is(err, domain.ErrConfigMissing) {
+			c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+				Error:   "WhatsApp template catalog not configured",
+				Message: err.Error(),
+			})
+			return
+		}
+		h.logger.Error("Failed to sync WhatsApp templates", zap.Error(err))
+		c.JSON(http.StatusBadGateway, ErrorResponse{
+			Error:   "Failed to sync WhatsApp templates",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, WhatsAppTemplateSyncResponse{Updated: updated})
+}
+
+// WhatsAppTemplateSyncResponse resultado da sincronização
+type WhatsAppTemplateSyncResponse struct {
+	Updated int `json:"updated"` // mapeamentos atualizados
+}
+
+// parseWhatsAppTemplateID lê o ID do template da rota
+func parseWhatsAppTemplateID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid template ID",
+			Message: err.Error(),
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// respondError mapeia erros do serviço para o status HTTP
+func (h *WhatsAppTemplateHandler) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, domain.ErrTemplateNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Template not found",
+			Message: err.Error(),
+		})
+	case errors.Is(err, domain.ErrWhatsAppTemplateMappingNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "WhatsApp template mapping not found",
+			Message: err.Error(),
+		})
+	case errors.Is(err, domain.ErrTemplateInvalid):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid WhatsApp template",
+			Message: err.Error(),
+		})
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   message,
+			Message: err.Error(),
+		})
+	}
+}
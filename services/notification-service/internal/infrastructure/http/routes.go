@@ -8,6 +8,7 @@ import (
 	"github.com/direito-lux/notification-service/internal/domain"
 	"github.com/direito-lux/notification-service/internal/infrastructure/http/handlers"
 	"github.com/direito-lux/notification-service/internal/infrastructure/http/middleware"
+	"github.com/direito-lux/notification-service/internal/infrastructure/providers"
 )
 
 // RouterConfig configuração do router
@@ -15,6 +16,26 @@ type RouterConfig struct {
 	NotificationService *services.NotificationService
 	TemplateService     *services.TemplateService
 	PreferenceRepo      domain.NotificationPreferenceRepository
+	DeliveryService     *services.DeliveryStatusService
+	DeliveryWindows     *services.DeliveryWindowService
+	EmailWebhookSecret  string
+	SMSGateway          providers.SMSGateway
+	SMSWebhookSecret    string
+	PushSubscriptions   domain.PushSubscriptionRepository
+	VAPIDPublicKey      string
+	Tracker             *services.EngagementTracker
+	WhatsAppTemplates   *services.WhatsAppTemplateService
+	WhatsAppAssistant   *services.WhatsAppAssistantService
+	TelegramBot         *services.TelegramBotService
+	ProcessWatchers     domain.ProcessWatcherRepository
+	Consents            *services.ConsentService
+	EmailSenders        *services.EmailSenderDomainService
+	Webhooks            *services.WebhookService
+	// Secret configurado no setWebhook, conferido em X-Telegram-Bot-Api-Secret-Token
+	TelegramWebhookSecret string
+	// Token da verificação do webhook (GET) e app secret das assinaturas do WhatsApp
+	WhatsAppVerifyToken string
+	WhatsAppAppSecret   string
 	Logger              *zap.Logger
 }
 
@@ -23,7 +44,8 @@ func SetupRoutes(router *gin.Engine, config *RouterConfig) {
 	// Criar handlers
 	notificationHandler := handlers.NewNotificationHandler(config.NotificationService, config.Logger)
 	templateHandler := handlers.NewTemplateHandler(config.TemplateService, config.Logger)
-	preferenceHandler := handlers.NewPreferenceHandler(config.PreferenceRepo, config.Logger)
+	preferenceHandler := handlers.NewPreferenceHandler(config.PreferenceRepo, config.DeliveryWindows, config.Logger)
+	pushHandler := handlers.NewPushHandler(config.PushSubscriptions, config.VAPIDPublicKey, config.Logger)
 
 	// Middleware global
 	router.Use(middleware.Logger(config.Logger))
@@ -51,8 +73,10 @@ func SetupRoutes(router *gin.Engine, config *RouterConfig) {
 			notifications.POST("", notificationHandler.CreateNotification)
 			notifications.GET("", notificationHandler.ListNotifications)
 			notifications.GET("/:id", notificationHandler.GetNotification)
+			notifications.GET("/:id/attempts", notificationHandler.GetNotificationAttempts)
 			notifications.POST("/:id/cancel", notificationHandler.CancelNotification)
 			notifications.GET("/statistics", notificationHandler.GetNotificationStatistics)
+			notifications.GET("/analytics", notificationHandler.GetNotificationAnalytics)
 			notifications.POST("/bulk", notificationHandler.SendBulkNotifications)
 		}
 
@@ -68,12 +92,33 @@ func SetupRoutes(router *gin.Engine, config *RouterConfig) {
 			templates.POST("/:id/duplicate", templateHandler.DuplicateTemplate)
 			templates.POST("/:id/activate", templateHandler.ActivateTemplate)
 			templates.POST("/:id/deactivate", templateHandler.DeactivateTemplate)
+
+			// Versões, idiomas e variantes A/B
+			templates.GET("/:id/versions", templateHandler.ListVersions)
+			templates.POST("/:id/versions", templateHandler.CreateVersion)
+			templates.POST("/:id/versions/:version_id/publish", templateHandler.PublishVersion)
+			templates.POST("/:id/versions/:version_id/archive", templateHandler.ArchiveVersion)
+			templates.PATCH("/:id/versions/:version_id/weight", templateHandler.UpdateVariantWeight)
+			templates.POST("/:id/rollback", templateHandler.RollbackVersion)
+			templates.GET("/:id/variants/stats", templateHandler.GetVariantStats)
+
+			// Templates aprovados do WhatsApp (fora da janela de atendimento de 24 horas)
+			if config.WhatsAppTemplates != nil {
+				whatsAppTemplateHandler := handlers.NewWhatsAppTemplateHandler(config.WhatsAppTemplates, config.Logger)
+				templates.GET("/:id/whatsapp-templates", whatsAppTemplateHandler.ListMappings)
+				templates.POST("/:id/whatsapp-templates", whatsAppTemplateHandler.SetMapping)
+				templates.DELETE("/:id/whatsapp-templates/:mapping_id", whatsAppTemplateHandler.DeleteMapping)
+			}
 		}
 
 		// Rotas de preferências
 		preferences := v1.Group("/preferences")
 		{
 			preferences.POST("/defaults", preferenceHandler.CreateDefaultPreferences)
+
+			// Janela de entrega padrão do tenant
+			preferences.GET("/delivery-settings", preferenceHandler.GetTenantDeliverySettings)
+			preferences.PUT("/delivery-settings", preferenceHandler.UpdateTenantDeliverySettings)
 			
 			// Preferências por usuário
 			userPrefs := preferences.Group("/users/:user_id")
@@ -82,12 +127,86 @@ func SetupRoutes(router *gin.Engine, config *RouterConfig) {
 				userPrefs.PUT("", preferenceHandler.UpdateUserPreferences)
 				userPrefs.POST("/disable-all", preferenceHandler.DisableAllNotifications)
 				userPrefs.POST("/enable-all", preferenceHandler.EnableAllNotifications)
+
+				// Horário de silêncio, horário comercial e resumos do usuário
+				userPrefs.GET("/delivery-settings", preferenceHandler.GetUserDeliverySettings)
+				userPrefs.PUT("/delivery-settings", preferenceHandler.UpdateUserDeliverySettings)
 				
 				// Preferências por tipo
 				userPrefs.GET("/types/:type", preferenceHandler.GetPreferenceByType)
 				userPrefs.PUT("/types/:type", preferenceHandler.UpdatePreferenceByType)
 			}
 		}
+
+		// Inscrições Web Push por usuário e dispositivo
+		push := v1.Group("/push")
+		{
+			push.GET("/vapid-public-key", pushHandler.GetVAPIDPublicKey)
+			push.GET("/users/:user_id/subscriptions", pushHandler.ListSubscriptions)
+			push.POST("/users/:user_id/subscriptions", pushHandler.Subscribe)
+			push.DELETE("/users/:user_id/subscriptions/:id", pushHandler.Unsubscribe)
+		}
+
+		// Destinatários dos alertas de movimentação dos processos
+		if config.ProcessWatchers != nil {
+			processWatcherHandler := handlers.NewProcessWatcherHandler(config.ProcessWatchers, config.Logger)
+			processes := v1.Group("/processes")
+			{
+				processes.GET("/:process_id/watchers", processWatcherHandler.ListWatchers)
+				processes.PUT("/:process_id/watchers/:recipient_id", processWatcherHandler.SetWatcher)
+				processes.DELETE("/:process_id/watchers/:recipient_id", processWatcherHandler.RemoveWatcher)
+			}
+		}
+
+		// Registro de consentimento (LGPD) e exportação para auditoria
+		if config.Consents != nil {
+			consentHandler := handlers.NewConsentHandler(config.Consents, config.Logger)
+			consents := v1.Group("/consents")
+			{
+				consents.POST("", consentHandler.RecordConsent)
+				consents.GET("/status", consentHandler.GetConsentStatus)
+				consents.GET("/export", consentHandler.ExportConsents)
+			}
+		}
+
+		// Remetente com domínio próprio do tenant (DKIM)
+		if config.EmailSenders != nil {
+			emailSenderHandler := handlers.NewEmailSenderHandler(config.EmailSenders, config.Logger)
+			senderDomain := v1.Group("/email/sender-domain")
+			{
+				senderDomain.GET("", emailSenderHandler.GetSenderDomain)
+				senderDomain.PUT("", emailSenderHandler.ConfigureSenderDomain)
+				senderDomain.DELETE("", emailSenderHandler.DeleteSenderDomain)
+				senderDomain.POST("/verify", emailSenderHandler.VerifySenderDomain)
+			}
+		}
+
+		// Webhooks de saída do tenant (eventos de processos, prazos e cobrança)
+		if config.Webhooks != nil {
+			tenantWebhookHandler := handlers.NewTenantWebhookHandler(config.Webhooks, config.Logger)
+			tenantWebhooks := v1.Group("/webhooks")
+			{
+				tenantWebhooks.POST("", tenantWebhookHandler.CreateSubscription)
+				tenantWebhooks.GET("", tenantWebhookHandler.ListSubscriptions)
+				tenantWebhooks.GET("/:id", tenantWebhookHandler.GetSubscription)
+				tenantWebhooks.PATCH("/:id", tenantWebhookHandler.UpdateSubscription)
+				tenantWebhooks.DELETE("/:id", tenantWebhookHandler.DeleteSubscription)
+				tenantWebhooks.POST("/:id/ping", tenantWebhookHandler.Ping)
+				tenantWebhooks.GET("/:id/deliveries", tenantWebhookHandler.ListDeliveries)
+				tenantWebhooks.POST("/:id/deliveries/:delivery_id/replay", tenantWebhookHandler.ReplayDelivery)
+			}
+		}
+
+		// Vinculação de chats ao bot do Telegram
+		if config.TelegramBot != nil {
+			telegramHandler := handlers.NewTelegramHandler(config.TelegramBot, config.Logger)
+			telegram := v1.Group("/telegram")
+			{
+				telegram.POST("/users/:user_id/link-codes", telegramHandler.CreateLinkCode)
+				telegram.GET("/users/:user_id/links", telegramHandler.ListLinks)
+				telegram.DELETE("/users/:user_id/links/:chat_id", telegramHandler.Unlink)
+			}
+		}
 	}
 
 	// Rotas administrativas (sem autenticação de tenant)
@@ -100,10 +219,32 @@ func SetupRoutes(router *gin.Engine, config *RouterConfig) {
 		{
 			systemTemplates.GET("", templateHandler.ListTemplates) // Lista templates do sistema
 		}
+
+		// Análises de todos os tenants (dashboards do report-service)
+		admin.GET("/notifications/analytics", notificationHandler.GetPlatformAnalytics)
+
+		// Sincronização imediata do status dos templates do WhatsApp
+		if config.WhatsAppTemplates != nil {
+			whatsAppTemplateHandler := handlers.NewWhatsAppTemplateHandler(config.WhatsAppTemplates, config.Logger)
+			admin.POST("/whatsapp/templates/sync", whatsAppTemplateHandler.SyncStatuses)
+		}
 	}
 
 	// Webhooks (sem autenticação)
-	webhookHandler := handlers.NewWebhookHandler(config.Logger)
+	webhookHandler := handlers.NewWebhookHandler(
+		config.DeliveryService,
+		config.EmailWebhookSecret,
+		config.SMSGateway,
+		config.SMSWebhookSecret,
+		config.WhatsAppTemplates,
+		config.WhatsAppAssistant,
+		config.WhatsAppVerifyToken,
+		config.WhatsAppAppSecret,
+		config.TelegramBot,
+		config.TelegramWebhookSecret,
+		config.Consents,
+		config.Logger,
+	)
 	webhooks := router.Group("/webhook")
 	{
 		// Telegram webhook
@@ -113,8 +254,29 @@ func SetupRoutes(router *gin.Engine, config *RouterConfig) {
 		webhooks.GET("/whatsapp", webhookHandler.HandleWhatsAppWebhook)
 		webhooks.POST("/whatsapp", webhookHandler.HandleWhatsAppWebhook)
 		
-		// Email bounce/complaint webhooks (futuro)
-		// webhooks.POST("/email/bounce", emailHandler.HandleBounce)
-		// webhooks.POST("/email/complaint", emailHandler.HandleComplaint)
+		// Email bounce/complaint webhooks
+		webhooks.POST("/email/bounce", webhookHandler.HandleEmailBounce)
+		webhooks.POST("/email/complaint", webhookHandler.HandleEmailComplaint)
+
+		// SMS: relatórios de entrega e respostas (SAIR/VOLTAR)
+		webhooks.POST("/sms/status", webhookHandler.HandleSMSStatus)
+		webhooks.POST("/sms/inbound", webhookHandler.HandleSMSInbound)
 	}
-}
\ No newline at end of file
+
+	// Rastreamento de abertura e clique dos emails (sem autenticação; URLs assinadas)
+	if config.Tracker != nil {
+		trackingHandler := handlers.NewTrackingHandler(config.DeliveryService, config.Tracker, config.Logger)
+		tracking := router.Group("/track")
+		{
+			tracking.GET("/open/:id", trackingHandler.TrackOpen)
+			tracking.GET("/click/:id", trackingHandler.TrackClick)
+		}
+	}
+
+	// Descadastro pelo link do email (sem autenticação; URLs assinadas)
+	if config.Consents != nil {
+		consentHandler := handlers.NewConsentHandler(config.Consents, config.Logger)
+		router.GET("/unsubscribe/:id", consentHandler.ShowUnsubscribe)
+		router.POST("/unsubscribe/:id", consentHandler.Unsubscribe)
+	}
+}
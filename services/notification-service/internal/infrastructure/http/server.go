@@ -14,6 +14,7 @@ import (
 	"github.com/direito-lux/notification-service/internal/infrastructure/config"
 	"github.com/direito-lux/notification-service/internal/infrastructure/http/middleware"
 	"github.com/direito-lux/notification-service/internal/infrastructure/metrics"
+	"github.com/direito-lux/notification-service/internal/infrastructure/providers"
 )
 
 // Server representa o servidor HTTP
@@ -25,7 +26,19 @@ type Server struct {
 	metrics             *metrics.Metrics
 	notificationService *services.NotificationService
 	templateService     *services.TemplateService
+	deliveryService     *services.DeliveryStatusService
+	deliveryWindows     *services.DeliveryWindowService
 	preferenceRepo      domain.NotificationPreferenceRepository
+	smsGateway          providers.SMSGateway
+	pushSubscriptions   domain.PushSubscriptionRepository
+	tracker             *services.EngagementTracker
+	whatsAppTemplates   *services.WhatsAppTemplateService
+	whatsAppAssistant   *services.WhatsAppAssistantService
+	telegramBot         *services.TelegramBotService
+	processWatchers     domain.ProcessWatcherRepository
+	consents            *services.ConsentService
+	emailSenders        *services.EmailSenderDomainService
+	webhooks            *services.WebhookService
 }
 
 // NewServer cria novo servidor HTTP
@@ -35,7 +48,19 @@ func NewServer(
 	metrics *metrics.Metrics,
 	notificationService *services.NotificationService,
 	templateService *services.TemplateService,
+	deliveryService *services.DeliveryStatusService,
+	deliveryWindows *services.DeliveryWindowService,
 	preferenceRepo domain.NotificationPreferenceRepository,
+	smsGateway providers.SMSGateway,
+	pushSubscriptions domain.PushSubscriptionRepository,
+	tracker *services.EngagementTracker,
+	whatsAppTemplates *services.WhatsAppTemplateService,
+	whatsAppAssistant *services.WhatsAppAssistantService,
+	telegramBot *services.TelegramBotService,
+	processWatchers domain.ProcessWatcherRepository,
+	consents *services.ConsentService,
+	emailSenders *services.EmailSenderDomainService,
+	webhooks *services.WebhookService,
 ) *Server {
 	// Configurar Gin baseado no ambiente
 	if cfg.IsProduction() {
@@ -58,7 +83,19 @@ func NewServer(
 		metrics:             metrics,
 		notificationService: notificationService,
 		templateService:     templateService,
+		deliveryService:     deliveryService,
+		deliveryWindows:     deliveryWindows,
 		preferenceRepo:      preferenceRepo,
+		smsGateway:          smsGateway,
+		pushSubscriptions:   pushSubscriptions,
+		tracker:             tracker,
+		whatsAppTemplates:   whatsAppTemplates,
+		whatsAppAssistant:   whatsAppAssistant,
+		telegramBot:         telegramBot,
+		processWatchers:     processWatchers,
+		consents:            consents,
+		emailSenders:        emailSenders,
+		webhooks:            webhooks,
 	}
 
 	// Configurar rotas
@@ -83,7 +120,26 @@ func (s *Server) setupRoutes() {
 		NotificationService: s.notificationService,
 		TemplateService:     s.templateService,
 		PreferenceRepo:      s.preferenceRepo,
-		Logger:              s.logger,
+		DeliveryService:     s.deliveryService,
+		DeliveryWindows:     s.deliveryWindows,
+		EmailWebhookSecret:  s.config.SMTP.WebhookSecret,
+		SMSGateway:          s.smsGateway,
+		SMSWebhookSecret:    s.config.SMS.WebhookSecret,
+		PushSubscriptions:   s.pushSubscriptions,
+		VAPIDPublicKey:      s.config.Push.VAPIDPublicKey,
+		Tracker:             s.tracker,
+		WhatsAppTemplates:   s.whatsAppTemplates,
+		WhatsAppAssistant:   s.whatsAppAssistant,
+		TelegramBot:         s.telegramBot,
+		ProcessWatchers:     s.processWatchers,
+		Consents:            s.consents,
+		EmailSenders:        s.emailSenders,
+		Webhooks:            s.webhooks,
+
+		TelegramWebhookSecret: s.config.Telegram.WebhookSecret,
+		WhatsAppVerifyToken:   s.config.WhatsApp.VerifyToken,
+		WhatsAppAppSecret:     s.config.WhatsApp.AppSecret,
+		Logger:                s.logger,
 	}
 	
 	SetupRoutes(s.gin, routerConfig)
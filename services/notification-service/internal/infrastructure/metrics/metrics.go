@@ -23,6 +23,9 @@ type Metrics struct {
 	// Contador de notificações falhadas
 	NotificationsFailed prometheus.CounterVec
 
+	// Contador de notificações reenfileiradas por limite de taxa
+	NotificationsThrottled prometheus.CounterVec
+
 	// Histograma de duração de processamento
 	ProcessingDuration prometheus.HistogramVec
 
@@ -64,6 +67,14 @@ func NewMetrics() *Metrics {
 			[]string{"type", "channel", "tenant_id", "error_type"},
 		),
 
+		NotificationsThrottled: *promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "notifications_throttled_total",
+				Help: "Total de notificações reenfileiradas por limite de taxa",
+			},
+			[]string{"channel", "tenant_id", "scope"},
+		),
+
 		ProcessingDuration: *promauto.NewHistogramVec(
 			prometheus.HistogramOpts{
 				Name:    "notification_processing_duration_seconds",
@@ -154,6 +165,11 @@ func (m *Metrics) RecordNotificationFailed(notificationType, channel, tenantID,
 	m.NotificationsFailed.WithLabelValues(notificationType, channel, tenantID, errorType).Inc()
 }
 
+// RecordNotificationThrottled registra notificação reenfileirada por limite de taxa
+func (m *Metrics) RecordNotificationThrottled(channel, tenantID, scope string) {
+	m.NotificationsThrottled.WithLabelValues(channel, tenantID, scope).Inc()
+}
+
 // RecordProcessingDuration registra duração de processamento
 func (m *Metrics) RecordProcessingDuration(notificationType, channel string, duration time.Duration) {
 	m.ProcessingDuration.WithLabelValues(notificationType, channel).Observe(duration.Seconds())
@@ -0,0 +1,190 @@
+package providers
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// dkimSignedHeaders headers assinados, na ordem da assinatura; os ausentes na mensagem são
+// ignorados
+var dkimSignedHeaders = []string{
+	"From", "To", "Subject", "Date", "Message-ID", "MIME-Version", "Content-Type",
+	"List-Unsubscribe", "List-Unsubscribe-Post",
+}
+
+// dkimSigner assina mensagens com DKIM (RFC 6376): rsa-sha256 e canonicalização relaxed/relaxed
+type dkimSigner struct {
+	domain   string
+	selector string
+	key      *rsa.PrivateKey
+}
+
+// newDKIMSigner cria o assinador a partir da chave RSA em PEM (PKCS#1 ou PKCS#8)
+func newDKIMSigner(domain, selector, privateKeyPEM string) (*dkimSigner, error) {
+	block, _ := pem.Decode([]byte(privateKeyPEM))
+	if block == nil {
+		return nil, fmt.Errorf("invalid DKIM private key: PEM block not found")
+	}
+
+	var key *rsa.PrivateKey
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DKIM private key: %w", err)
+		}
+		key = parsed
+	case "PRIVATE KEY":
+		parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DKIM private key: %w", err)
+		}
+		rsaKey, ok := parsed.(*rsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("invalid DKIM private key: only RSA keys are supported")
+		}
+		key = rsaKey
+	default:
+		return nil, fmt.Errorf("invalid DKIM private key: unexpected PEM type %s", block.Type)
+	}
+
+	return &dkimSigner{
+		domain:   strings.ToLower(domain),
+		selector: selector,
+		key:      key,
+	}, nil
+}
+
+// Sign retorna a mensagem com o header DKIM-Signature no início
+func (s *dkimSigner) Sign(message []byte) ([]byte, error) {
+	header, body := splitMessage(message)
+	fields := parseHeaderFields(header)
+
+	bodyHash := sha256.Sum256(dkimRelaxedBody(body))
+
+	var signed []string
+	var canonical bytes.Buffer
+	for _, name := range dkimSignedHeaders {
+		field, ok := lastHeaderField(fields, name)
+		if !ok {
+			continue
+		}
+		signed = append(signed, strings.ToLower(name))
+		canonical.WriteString(dkimRelaxedHeader(field))
+		canonical.WriteString("\r\n")
+	}
+
+	value := fmt.Sprintf("v=1; a=rsa-sha256; c=relaxed/relaxed; d=%s; s=%s;\r\n\tt=%d; h=%s;\r\n\tbh=%s;\r\n\tb=",
+		s.domain, s.selector, time.Now().Unix(), strings.Join(signed, ":"),
+		base64.StdEncoding.EncodeToString(bodyHash[:]))
+
+	// O próprio DKIM-Signature entra no hash com b= vazio e sem o CRLF final
+	canonical.WriteString(dkimRelaxedHeader("DKIM-Signature: " + value))
+
+	digest := sha256.Sum256(canonical.Bytes())
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign message: %w", err)
+	}
+
+	var out bytes.Buffer
+	out.WriteString("DKIM-Signature: ")
+	out.WriteString(value)
+	out.WriteString(foldBase64(base64.StdEncoding.EncodeToString(signature), 72, "\r\n\t "))
+	out.WriteString("\r\n")
+	out.Write(message)
+	return out.Bytes(), nil
+}
+
+// splitMessage separa cabeçalho (com o CRLF da última linha) e corpo
+func splitMessage(message []byte) ([]byte, []byte) {
+	if i := bytes.Index(message, []byte("\r\n\r\n")); i >= 0 {
+		return message[:i+2], message[i+4:]
+	}
+	return message, nil
+}
+
+// parseHeaderFields separa os campos do cabeçalho, preservando as continuações
+func parseHeaderFields(header []byte) []string {
+	var fields []string
+	for _, line := range strings.SplitAfter(string(header), "\r\n") {
+		if line == "" {
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && len(fields) > 0 {
+			fields[len(fields)-1] += line
+			continue
+		}
+		fields = append(fields, line)
+	}
+	for i := range fields {
+		fields[i] = strings.TrimSuffix(fields[i], "\r\n")
+	}
+	return fields
+}
+
+// lastHeaderField último campo com o nome informado (RFC 6376, 5.4.2)
+func lastHeaderField(fields []string, name string) (string, bool) {
+	for i := len(fields) - 1; i >= 0; i-- {
+		fieldName, _, found := strings.Cut(fields[i], ":")
+		if found && strings.EqualFold(strings.TrimSpace(fieldName), name) {
+			return fields[i], true
+		}
+	}
+	return "", false
+}
+
+// dkimRelaxedHeader canonicalização relaxed de um campo (RFC 6376, 3.4.2), sem o CRLF final
+func dkimRelaxedHeader(field string) string {
+	name, value, _ := strings.Cut(field, ":")
+	value = strings.ReplaceAll(value, "\r\n", "")
+	value = strings.Join(strings.FieldsFunc(value, isWSP), " ")
+	return strings.ToLower(strings.TrimSpace(name)) + ":" + value
+}
+
+// dkimRelaxedBody canonicalização relaxed do corpo (RFC 6376, 3.4.4)
+func dkimRelaxedBody(body []byte) []byte {
+	lines := strings.Split(string(body), "\r\n")
+	for i, line := range lines {
+		compacted := strings.Join(strings.FieldsFunc(line, isWSP), " ")
+		if compacted != "" && isWSP(rune(line[0])) {
+			compacted = " " + compacted
+		}
+		lines[i] = compacted
+	}
+
+	// Linhas vazias do final são ignoradas
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	return []byte(strings.Join(lines, "\r\n") + "\r\n")
+}
+
+// isWSP espaço ou tabulação
+func isWSP(r rune) bool {
+	return r == ' ' || r == '\t'
+}
+
+// foldBase64 quebra o valor em linhas do tamanho informado, com o separador entre elas
+func foldBase64(value string, width int, separator string) string {
+	var out strings.Builder
+	for len(value) > width {
+		out.WriteString(value[:width])
+		out.WriteString(separator)
+		value = value[width:]
+	}
+	out.WriteString(value)
+	return out.String()
+}
@@ -0,0 +1,163 @@
+package providers
+
+import (
+	"bytes"
+	"encoding/base64"
+	"html"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"regexp"
+	"strings"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// emailAttachment anexo pronto para a mensagem
+type emailAttachment struct {
+	filename    string
+	contentType string
+	content     []byte
+}
+
+// mimeEntity parte MIME com os headers e o corpo já codificado
+type mimeEntity struct {
+	header textproto.MIMEHeader
+	body   []byte
+}
+
+// emailBodies separa texto e HTML da notificação. ContentHTML vem dos templates; conteúdo direto
+// em HTML é aceito para as requisições sem template. Sem texto, ele é derivado do HTML.
+func emailBodies(notification *domain.Notification) (text string, htmlContent string) {
+	switch {
+	case notification.ContentHTML != nil && *notification.ContentHTML != "":
+		htmlContent = *notification.ContentHTML
+		text = notification.Content
+		if text == "" || domain.LooksLikeHTML(text) {
+			text = htmlToText(htmlContent)
+		}
+	case domain.LooksLikeHTML(notification.Content):
+		htmlContent = notification.Content
+		text = htmlToText(htmlContent)
+	default:
+		text = notification.Content
+	}
+	return text, htmlContent
+}
+
+// buildEmailBody monta o corpo: text/plain, multipart/alternative (texto e HTML) e, com anexos,
+// multipart/mixed com o conteúdo seguido dos arquivos
+func buildEmailBody(text, htmlContent string, attachments []emailAttachment) (mimeEntity, error) {
+	content := textEntity("text/plain", text)
+	if htmlContent != "" {
+		alternative, err := multipartEntity("alternative", []mimeEntity{content, textEntity("text/html", htmlContent)})
+		if err != nil {
+			return mimeEntity{}, err
+		}
+		content = alternative
+	}
+
+	if len(attachments) == 0 {
+		return content, nil
+	}
+
+	parts := []mimeEntity{content}
+	for _, attachment := range attachments {
+		parts = append(parts, attachmentEntity(attachment))
+	}
+	return multipartEntity("mixed", parts)
+}
+
+// textEntity parte de texto em UTF-8 com quoted-printable
+func textEntity(contentType, content string) mimeEntity {
+	var body bytes.Buffer
+	writer := quotedprintable.NewWriter(&body)
+	writer.Write([]byte(content))
+	writer.Close()
+
+	return mimeEntity{
+		header: textproto.MIMEHeader{
+			"Content-Type":              {mime.FormatMediaType(contentType, map[string]string{"charset": "UTF-8"})},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		},
+		body: body.Bytes(),
+	}
+}
+
+// attachmentEntity anexo em base64, com o nome do arquivo codificado conforme a RFC 2231
+func attachmentEntity(attachment emailAttachment) mimeEntity {
+	contentType := mime.FormatMediaType(attachment.contentType, map[string]string{"name": attachment.filename})
+	if contentType == "" {
+		contentType = mime.FormatMediaType("application/octet-stream", map[string]string{"name": attachment.filename})
+	}
+
+	return mimeEntity{
+		header: textproto.MIMEHeader{
+			"Content-Type":              {contentType},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.filename})},
+			"Content-Transfer-Encoding": {"base64"},
+		},
+		body: []byte(foldBase64(base64.StdEncoding.EncodeToString(attachment.content), 76, "\r\n")),
+	}
+}
+
+// multipartEntity agrupa as partes em multipart/<subtype>
+func multipartEntity(subtype string, parts []mimeEntity) (mimeEntity, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for _, part := range parts {
+		partWriter, err := writer.CreatePart(part.header)
+		if err != nil {
+			return mimeEntity{}, err
+		}
+		if _, err := partWriter.Write(part.body); err != nil {
+			return mimeEntity{}, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return mimeEntity{}, err
+	}
+
+	return mimeEntity{
+		header: textproto.MIMEHeader{
+			"Content-Type": {mime.FormatMediaType("multipart/"+subtype, map[string]string{"boundary": writer.Boundary()})},
+		},
+		body: body.Bytes(),
+	}, nil
+}
+
+var (
+	htmlHiddenPattern = regexp.MustCompile(`(?is)<(head|script|style)[^>]*>.*?</(head|script|style)>`)
+	htmlLinkPattern   = regexp.MustCompile(`(?is)<a\s[^>]*href\s*=\s*"([^"]*)"[^>]*>(.*?)</a>`)
+	htmlItemPattern   = regexp.MustCompile(`(?i)<li[^>]*>`)
+	htmlBreakPattern  = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|h[1-6]|li|tr|table|ul|ol)>`)
+	htmlTagPattern    = regexp.MustCompile(`(?s)<[^>]*>`)
+	blankLinesPattern = regexp.MustCompile(`\n{3,}`)
+)
+
+// htmlToText versão em texto do HTML para a parte text/plain: sem marcação, com quebras de
+// linha nos blocos e o endereço dos links entre parênteses
+func htmlToText(content string) string {
+	text := htmlHiddenPattern.ReplaceAllString(content, "")
+	text = htmlLinkPattern.ReplaceAllStringFunc(text, func(link string) string {
+		match := htmlLinkPattern.FindStringSubmatch(link)
+		href := html.UnescapeString(match[1])
+		label := strings.TrimSpace(htmlTagPattern.ReplaceAllString(match[2], ""))
+		if label == "" || html.UnescapeString(label) == href {
+			return href
+		}
+		return label + " (" + href + ")"
+	})
+	text = htmlItemPattern.ReplaceAllString(text, "• ")
+	text = htmlBreakPattern.ReplaceAllString(text, "\n")
+	text = htmlTagPattern.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.Join(strings.Fields(line), " ")
+	}
+	text = blankLinesPattern.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.TrimSpace(text)
+}
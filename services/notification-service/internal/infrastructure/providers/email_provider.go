@@ -1,43 +1,75 @@
 package providers
 
 import (
+	"bytes"
 	"context"
-	"crypto/tls"
+	"errors"
 	"fmt"
+	"io"
+	"mime"
 	"net"
+	"net/http"
 	"net/mail"
-	"net/smtp"
+	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/direito-lux/notification-service/internal/domain"
+	"github.com/direito-lux/notification-service/internal/infrastructure/config"
 )
 
-// EmailProvider implementação do provedor de email SMTP
+// EmailProvider implementação do provedor de email SMTP. As mensagens são multipart (texto e
+// HTML, com anexos), assinadas com DKIM quando há chave para o domínio do remetente e enviadas
+// por conexões SMTP reaproveitadas entre os envios.
 type EmailProvider struct {
-	config EmailConfig
-	logger *zap.Logger
+	config     EmailConfig
+	dkim       *dkimSigner                        // remetente padrão; nil sem DKIM configurado
+	senders    domain.EmailSenderDomainRepository // domínios próprios dos tenants; pode ser nil
+	httpClient *http.Client                       // download dos anexos por URL
+	logger     *zap.Logger
+
+	poolMu sync.Mutex
+	pool   *smtpPool
 }
 
 // EmailConfig configuração do provedor de email
 type EmailConfig struct {
-	Host         string `json:"host" validate:"required"`
-	Port         int    `json:"port" validate:"required"`
-	Username     string `json:"username" validate:"required"`
-	Password     string `json:"password" validate:"required"`
-	FromEmail    string `json:"from_email" validate:"required,email"`
-	FromName     string `json:"from_name"`
-	UseTLS       bool   `json:"use_tls"`
-	UseStartTLS  bool   `json:"use_starttls"`
-	Timeout      int    `json:"timeout"` // segundos
-	MaxRetries   int    `json:"max_retries"`
-	RateLimit    int    `json:"rate_limit"` // emails per minute
+	Host        string `json:"host" validate:"required"`
+	Port        int    `json:"port" validate:"required"`
+	Username    string `json:"username" validate:"required"`
+	Password    string `json:"password" validate:"required"`
+	FromEmail   string `json:"from_email" validate:"required,email"`
+	FromName    string `json:"from_name"`
+	UseTLS      bool   `json:"use_tls"`
+	UseStartTLS bool   `json:"use_starttls"`
+	Timeout     int    `json:"timeout"` // segundos
+	MaxRetries  int    `json:"max_retries"`
+	RateLimit   int    `json:"rate_limit"` // emails per minute
+
+	// Pool de conexões SMTP
+	PoolSize           int    `json:"pool_size"`         // conexões simultâneas
+	PoolIdleTimeout    int    `json:"pool_idle_timeout"` // segundos
+	MaxMessagesPerConn int    `json:"max_messages_per_conn"`
+	HeloDomain         string `json:"helo_domain"` // padrão: domínio do remetente
+
+	// DKIM do remetente padrão; a chave é o PEM ou o caminho do arquivo
+	DKIMDomain     string `json:"dkim_domain"`
+	DKIMSelector   string `json:"dkim_selector"`
+	DKIMPrivateKey string `json:"-"`
+
+	// Hosts de onde os anexos por URL podem ser baixados (".exemplo.com" inclui os subdomínios);
+	// vazio desabilita os anexos por URL
+	AttachmentHosts []string `json:"attachment_hosts"`
 }
 
-// NewEmailProvider cria nova instância do provedor de email
-func NewEmailProvider(config EmailConfig, logger *zap.Logger) *EmailProvider {
+// NewEmailProvider cria nova instância do provedor de email. senders pode ser nil quando os
+// tenants não usam domínio próprio.
+func NewEmailProvider(config EmailConfig, senders domain.EmailSenderDomainRepository, logger *zap.Logger) *EmailProvider {
 	// Valores padrão
 	if config.Timeout == 0 {
 		config.Timeout = 30
@@ -48,11 +80,79 @@ func NewEmailProvider(config EmailConfig, logger *zap.Logger) *EmailProvider {
 	if config.RateLimit == 0 {
 		config.RateLimit = 60 // 1 email per second
 	}
+	if config.PoolSize == 0 {
+		config.PoolSize = 4
+	}
+	if config.PoolIdleTimeout == 0 {
+		config.PoolIdleTimeout = 30
+	}
+	if config.MaxMessagesPerConn == 0 {
+		config.MaxMessagesPerConn = 100
+	}
+	if config.HeloDomain == "" {
+		config.HeloDomain = extractDomain(config.FromEmail)
+	}
+
+	provider := &EmailProvider{
+		config:  config,
+		senders: senders,
+		logger:  logger,
+		pool:    newSMTPPool(config),
+	}
+	provider.httpClient = &http.Client{
+		Timeout:       time.Duration(config.Timeout) * time.Second,
+		CheckRedirect: provider.checkAttachmentRedirect,
+	}
+
+	if config.DKIMDomain != "" && config.DKIMPrivateKey != "" {
+		signer, err := loadDKIMSigner(config.DKIMDomain, config.DKIMSelector, config.DKIMPrivateKey)
+		if err != nil {
+			logger.Error("Invalid DKIM configuration, sending emails without signature", zap.Error(err))
+		} else {
+			provider.dkim = signer
+		}
+	}
+
+	return provider
+}
+
+// NewEmailProviderConfig converte a configuração do serviço na configuração do provedor
+func NewEmailProviderConfig(cfg config.SMTPConfig) EmailConfig {
+	return EmailConfig{
+		Host:               cfg.Host,
+		Port:               cfg.Port,
+		Username:           cfg.Username,
+		Password:           cfg.Password,
+		FromEmail:          cfg.FromEmail,
+		FromName:           cfg.FromName,
+		UseTLS:             cfg.UseTLS,
+		UseStartTLS:        cfg.UseStartTLS,
+		Timeout:            30,
+		MaxRetries:         3,
+		RateLimit:          60,
+		PoolSize:           cfg.PoolSize,
+		PoolIdleTimeout:    cfg.PoolIdleTimeout,
+		MaxMessagesPerConn: cfg.MaxMessagesPerConn,
+		DKIMDomain:         cfg.DKIMDomain,
+		DKIMSelector:       cfg.DKIMSelector,
+		DKIMPrivateKey:     cfg.DKIMPrivateKey,
+		AttachmentHosts:    cfg.AttachmentHosts,
+	}
+}
 
-	return &EmailProvider{
-		config: config,
-		logger: logger,
+// loadDKIMSigner carrega a chave do PEM informado ou do arquivo
+func loadDKIMSigner(domainName, selector, key string) (*dkimSigner, error) {
+	if !strings.HasPrefix(strings.TrimSpace(key), "-----BEGIN") {
+		data, err := os.ReadFile(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read DKIM private key: %w", err)
+		}
+		key = string(data)
 	}
+	if selector == "" {
+		selector = domain.DefaultDKIMSelector
+	}
+	return newDKIMSigner(domainName, selector, key)
 }
 
 // Send envia uma notificação por email
@@ -66,17 +166,35 @@ func (p *EmailProvider) Send(ctx context.Context, notification *domain.Notificat
 		return fmt.Errorf("invalid recipient email: %w", err)
 	}
 
+	// Remetente: domínio próprio verificado do tenant ou o padrão
+	sender := p.resolveSender(ctx, notification)
+
+	attachments, err := p.loadAttachments(ctx, notification.Attachments)
+	if err != nil {
+		return fmt.Errorf("failed to load attachments: %w", err)
+	}
+
 	// Construir mensagem
-	message, err := p.buildMessage(notification)
+	message, err := p.buildMessage(notification, sender, attachments)
 	if err != nil {
 		return fmt.Errorf("failed to build message: %w", err)
 	}
 
-	// Enviar email
-	if err := p.sendEmail(ctx, notification.RecipientContact, message); err != nil {
+	if sender.dkim != nil {
+		if message, err = sender.dkim.Sign(message); err != nil {
+			return fmt.Errorf("failed to sign message: %w", err)
+		}
+	}
+
+	// Enviar email; o envelope usa sempre o remetente padrão, que recebe os bounces
+	if err := p.smtpPool().send(ctx, p.config.FromEmail, notification.RecipientContact, message); err != nil {
 		return fmt.Errorf("failed to send email: %w", err)
 	}
 
+	// Message-ID identifica a mensagem nos webhooks de bounce/reclamação
+	externalID := p.messageID(notification)
+	notification.ExternalID = &externalID
+
 	p.logger.Info("Email sent successfully", 
 		zap.String("notification_id", notification.ID.String()),
 		zap.String("recipient", notification.RecipientContact))
@@ -135,6 +253,12 @@ func (p *EmailProvider) Configure(ctx context.Context, config map[string]interfa
 		p.config.UseStartTLS = useStartTLS
 	}
 
+	// Conexões abertas com a configuração anterior são descartadas
+	p.poolMu.Lock()
+	p.pool.Close()
+	p.pool = newSMTPPool(p.config)
+	p.poolMu.Unlock()
+
 	return p.ValidateConfiguration(config)
 }
 
@@ -179,6 +303,18 @@ func (p *EmailProvider) IsHealthy(ctx context.Context) bool {
 	return true
 }
 
+// Close encerra as conexões SMTP do pool
+func (p *EmailProvider) Close() {
+	p.smtpPool().Close()
+}
+
+// smtpPool pool de conexões atual
+func (p *EmailProvider) smtpPool() *smtpPool {
+	p.poolMu.Lock()
+	defer p.poolMu.Unlock()
+	return p.pool
+}
+
 // GetChannel retorna o canal do provedor
 func (p *EmailProvider) GetChannel() domain.NotificationChannel {
 	return domain.NotificationChannelEmail
@@ -209,134 +345,223 @@ func (p *EmailProvider) GetRateLimit() int {
 	return p.config.RateLimit
 }
 
+// ConfirmsDeliveryOnSend indica se o envio aceito já confirma a entrega.
+// Email só reporta bounces e reclamações; o aceite SMTP não confirma a entrega.
+func (p *EmailProvider) ConfirmsDeliveryOnSend() bool {
+	return false
+}
+
 // validateEmail valida formato do email
 func (p *EmailProvider) validateEmail(email string) error {
 	_, err := mail.ParseAddress(email)
 	return err
 }
 
-// buildMessage constrói a mensagem de email
-func (p *EmailProvider) buildMessage(notification *domain.Notification) ([]byte, error) {
-	from := p.config.FromEmail
-	if p.config.FromName != "" {
-		from = fmt.Sprintf("%s <%s>", p.config.FromName, p.config.FromEmail)
+// emailSender remetente da mensagem e assinador DKIM do seu domínio
+type emailSender struct {
+	email string
+	name  string
+	dkim  *dkimSigner
+}
+
+// resolveSender usa o domínio próprio do tenant depois de verificado; falhas na consulta ou na
+// chave mantêm o remetente padrão
+func (p *EmailProvider) resolveSender(ctx context.Context, notification *domain.Notification) emailSender {
+	fallback := emailSender{email: p.config.FromEmail, name: p.config.FromName, dkim: p.dkim}
+	if p.senders == nil {
+		return fallback
 	}
 
-	// Headers básicos
-	headers := map[string]string{
-		"From":         from,
-		"To":           notification.RecipientContact,
-		"Subject":      notification.Subject,
-		"MIME-Version": "1.0",
-		"Date":         time.Now().Format(time.RFC1123Z),
-		"Message-ID":   fmt.Sprintf("<%s@%s>", notification.ID.String(), p.extractDomain(p.config.FromEmail)),
+	senderDomain, err := p.senders.GetByTenant(ctx, notification.TenantID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrEmailSenderDomainNotFound) {
+			p.logger.Warn("Failed to get tenant email sender, using default sender",
+				zap.String("tenant_id", notification.TenantID.String()),
+				zap.Error(err))
+		}
+		return fallback
+	}
+	if !senderDomain.Verified {
+		return fallback
+	}
+
+	signer, err := newDKIMSigner(senderDomain.Domain, senderDomain.DKIMSelector, senderDomain.DKIMPrivateKey)
+	if err != nil {
+		p.logger.Error("Invalid tenant DKIM key, using default sender",
+			zap.String("tenant_id", notification.TenantID.String()),
+			zap.Error(err))
+		return fallback
 	}
 
-	// Detectar se o conteúdo é HTML
-	isHTML := p.isHTMLContent(notification.Content)
-	if isHTML {
-		headers["Content-Type"] = "text/html; charset=UTF-8"
-	} else {
-		headers["Content-Type"] = "text/plain; charset=UTF-8"
+	name := senderDomain.FromName
+	if name == "" {
+		name = p.config.FromName
+	}
+	return emailSender{email: senderDomain.FromEmail, name: name, dkim: signer}
+}
+
+// buildMessage constrói a mensagem de email: multipart/alternative com texto e HTML e, com
+// anexos, multipart/mixed
+func (p *EmailProvider) buildMessage(notification *domain.Notification, sender emailSender, attachments []emailAttachment) ([]byte, error) {
+	to, err := mail.ParseAddress(notification.RecipientContact)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recipient email: %w", err)
+	}
+
+	text, htmlContent := emailBodies(notification)
+	body, err := buildEmailBody(text, htmlContent, attachments)
+	if err != nil {
+		return nil, err
+	}
+
+	// Headers na ordem em que são escritos e assinados
+	headers := [][2]string{
+		{"From", (&mail.Address{Name: sender.name, Address: sender.email}).String()},
+		{"To", to.Address},
+		{"Subject", mime.QEncoding.Encode("utf-8", notification.Subject)},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"Message-ID", fmt.Sprintf("<%s>", p.messageID(notification))},
+		{"MIME-Version", "1.0"},
+	}
+
+	// Descadastro com um clique nos clientes de email (RFC 8058)
+	if unsubscribeURL, ok := notification.Metadata["list_unsubscribe"].(string); ok && unsubscribeURL != "" {
+		headers = append(headers,
+			[2]string{"List-Unsubscribe", fmt.Sprintf("<%s>", unsubscribeURL)},
+			[2]string{"List-Unsubscribe-Post", "List-Unsubscribe=One-Click"})
 	}
 
 	// Construir mensagem
-	var message strings.Builder
-	for key, value := range headers {
-		message.WriteString(fmt.Sprintf("%s: %s\r\n", key, value))
+	var message bytes.Buffer
+	for _, header := range headers {
+		message.WriteString(header[0] + ": " + header[1] + "\r\n")
+	}
+	for _, key := range []string{"Content-Type", "Content-Transfer-Encoding"} {
+		if value := body.header.Get(key); value != "" {
+			message.WriteString(key + ": " + value + "\r\n")
+		}
 	}
 	message.WriteString("\r\n")
-	message.WriteString(notification.Content)
+	message.Write(body.body)
 
-	return []byte(message.String()), nil
+	return message.Bytes(), nil
 }
 
-// sendEmail envia o email via SMTP
-func (p *EmailProvider) sendEmail(ctx context.Context, to string, message []byte) error {
-	// Conectar ao servidor SMTP
-	addr := fmt.Sprintf("%s:%d", p.config.Host, p.config.Port)
-	
-	var client *smtp.Client
-	var err error
+// messageID retorna o Message-ID da notificação (sem os sinais < >)
+func (p *EmailProvider) messageID(notification *domain.Notification) string {
+	return fmt.Sprintf("%s@%s", notification.ID.String(), extractDomain(p.config.FromEmail))
+}
 
-	if p.config.UseTLS {
-		// Conexão TLS direta
-		tlsConfig := &tls.Config{
-			ServerName: p.config.Host,
+// loadAttachments prepara os anexos, baixando os informados por URL, dentro do limite de tamanho
+func (p *EmailProvider) loadAttachments(ctx context.Context, attachments []domain.NotificationAttachment) ([]emailAttachment, error) {
+	if err := domain.ValidateAttachments(attachments); err != nil {
+		return nil, err
+	}
+
+	loaded := make([]emailAttachment, 0, len(attachments))
+	remaining := domain.MaxEmailAttachmentsSize
+	for _, attachment := range attachments {
+		content := attachment.Content
+		contentType := attachment.ContentType
+
+		if attachment.URL != "" {
+			downloaded, downloadedType, err := p.downloadAttachment(ctx, attachment.URL, remaining)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", attachment.Filename, err)
+			}
+			content = downloaded
+			if contentType == "" {
+				contentType = downloadedType
+			}
 		}
-		conn, err := tls.Dial("tcp", addr, tlsConfig)
-		if err != nil {
-			return fmt.Errorf("failed to connect with TLS: %w", err)
+
+		remaining -= len(content)
+		if remaining < 0 {
+			return nil, fmt.Errorf("%w: anexos acima de %d bytes", domain.ErrInvalidAttachment, domain.MaxEmailAttachmentsSize)
 		}
-		defer conn.Close()
 
-		client, err = smtp.NewClient(conn, p.config.Host)
-		if err != nil {
-			return fmt.Errorf("failed to create SMTP client: %w", err)
+		if contentType == "" {
+			contentType = mime.TypeByExtension(filepath.Ext(attachment.Filename))
 		}
-	} else {
-		// Conexão normal
-		client, err = smtp.Dial(addr)
-		if err != nil {
-			return fmt.Errorf("failed to connect to SMTP server: %w", err)
+		if contentType == "" {
+			contentType = "application/octet-stream"
 		}
+
+		loaded = append(loaded, emailAttachment{
+			filename:    strings.TrimSpace(attachment.Filename),
+			contentType: contentType,
+			content:     content,
+		})
 	}
-	defer client.Quit()
 
-	// STARTTLS se configurado
-	if p.config.UseStartTLS {
-		tlsConfig := &tls.Config{
-			ServerName: p.config.Host,
-		}
-		if err := client.StartTLS(tlsConfig); err != nil {
-			return fmt.Errorf("failed to start TLS: %w", err)
-		}
+	return loaded, nil
+}
+
+// downloadAttachment baixa o anexo de um host permitido, com no máximo limit bytes
+func (p *EmailProvider) downloadAttachment(ctx context.Context, rawURL string, limit int) ([]byte, string, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || !p.attachmentHostAllowed(parsed.Hostname()) {
+		return nil, "", fmt.Errorf("%w: host não permitido para anexos", domain.ErrInvalidAttachment)
 	}
 
-	// Autenticação
-	auth := smtp.PlainAuth("", p.config.Username, p.config.Password, p.config.Host)
-	if err := client.Auth(auth); err != nil {
-		return fmt.Errorf("failed to authenticate: %w", err)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create request: %w", err)
 	}
 
-	// Enviar email
-	if err := client.Mail(p.config.FromEmail); err != nil {
-		return fmt.Errorf("failed to set sender: %w", err)
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to download attachment: %w", err)
 	}
+	defer resp.Body.Close()
 
-	if err := client.Rcpt(to); err != nil {
-		return fmt.Errorf("failed to set recipient: %w", err)
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("failed to download attachment: status %d", resp.StatusCode)
 	}
 
-	writer, err := client.Data()
+	content, err := io.ReadAll(io.LimitReader(resp.Body, int64(limit)+1))
 	if err != nil {
-		return fmt.Errorf("failed to get data writer: %w", err)
+		return nil, "", fmt.Errorf("failed to read attachment: %w", err)
+	}
+	if len(content) > limit {
+		return nil, "", fmt.Errorf("%w: anexos acima de %d bytes", domain.ErrInvalidAttachment, domain.MaxEmailAttachmentsSize)
 	}
-	defer writer.Close()
 
-	if _, err := writer.Write(message); err != nil {
-		return fmt.Errorf("failed to write message: %w", err)
+	contentType := resp.Header.Get("Content-Type")
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		contentType = mediaType
 	}
+	return content, contentType, nil
+}
 
+// checkAttachmentRedirect impede que o redirecionamento leve o download a outro host
+func (p *EmailProvider) checkAttachmentRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= 5 {
+		return fmt.Errorf("too many redirects")
+	}
+	if !p.attachmentHostAllowed(req.URL.Hostname()) {
+		return fmt.Errorf("%w: redirecionamento para host não permitido", domain.ErrInvalidAttachment)
+	}
 	return nil
 }
 
-// isHTMLContent verifica se o conteúdo é HTML
-func (p *EmailProvider) isHTMLContent(content string) bool {
-	content = strings.ToLower(strings.TrimSpace(content))
-	htmlTags := []string{"<html", "<body", "<div", "<p", "<br", "<span", "<h1", "<h2", "<h3", "<table", "<tr", "<td"}
-	
-	for _, tag := range htmlTags {
-		if strings.Contains(content, tag) {
+// attachmentHostAllowed indica se o host está na lista de hosts dos anexos
+func (p *EmailProvider) attachmentHostAllowed(host string) bool {
+	host = strings.ToLower(host)
+	for _, allowed := range p.config.AttachmentHosts {
+		allowed = strings.ToLower(strings.TrimSpace(allowed))
+		if allowed == "" {
+			continue
+		}
+		if host == allowed || (strings.HasPrefix(allowed, ".") && strings.HasSuffix(host, allowed)) {
 			return true
 		}
 	}
-	
 	return false
 }
 
 // extractDomain extrai o domínio do email
-func (p *EmailProvider) extractDomain(email string) string {
+func extractDomain(email string) string {
 	parts := strings.Split(email, "@")
 	if len(parts) == 2 {
 		return parts[1]
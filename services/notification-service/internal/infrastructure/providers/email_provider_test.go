@@ -0,0 +1,390 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// stubSenderDomains repositório de remetentes em memória
+type stubSenderDomains struct {
+	senderDomain *domain.EmailSenderDomain
+}
+
+func (r *stubSenderDomains) Save(ctx context.Context, senderDomain *domain.EmailSenderDomain) error {
+	r.senderDomain = senderDomain
+	return nil
+}
+
+func (r *stubSenderDomains) GetByTenant(ctx context.Context, tenantID uuid.UUID) (*domain.EmailSenderDomain, error) {
+	if r.senderDomain == nil || r.senderDomain.TenantID != tenantID {
+		return nil, domain.ErrEmailSenderDomainNotFound
+	}
+	return r.senderDomain, nil
+}
+
+func (r *stubSenderDomains) Delete(ctx context.Context, tenantID uuid.UUID) error {
+	r.senderDomain = nil
+	return nil
+}
+
+func newTestDKIMKey(t *testing.T) (*rsa.PrivateKey, string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	return key, string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+}
+
+// verifyDKIM confere a assinatura DKIM-Signature da mensagem com a chave pública
+func verifyDKIM(t *testing.T, message []byte, public *rsa.PublicKey) map[string]string {
+	t.Helper()
+	header, body := splitMessage(message)
+	fields := parseHeaderFields(header)
+
+	signature, ok := lastHeaderField(fields, "DKIM-Signature")
+	if !ok {
+		t.Fatalf("message without DKIM-Signature")
+	}
+
+	tags := make(map[string]string)
+	_, value, _ := strings.Cut(signature, ":")
+	for _, tag := range strings.Split(value, ";") {
+		name, tagValue, found := strings.Cut(tag, "=")
+		if found {
+			tags[strings.TrimSpace(name)] = strings.Join(strings.Fields(tagValue), "")
+		}
+	}
+
+	bodyHash := sha256.Sum256(dkimRelaxedBody(body))
+	if tags["bh"] != base64.StdEncoding.EncodeToString(bodyHash[:]) {
+		t.Fatalf("body hash mismatch")
+	}
+
+	var canonical bytes.Buffer
+	for _, name := range strings.Split(tags["h"], ":") {
+		field, ok := lastHeaderField(fields, name)
+		if !ok {
+			t.Fatalf("signed header %s not found", name)
+		}
+		canonical.WriteString(dkimRelaxedHeader(field) + "\r\n")
+	}
+	unsigned := regexp.MustCompile(`(;\s*b=)[^;]*$`).ReplaceAllString(signature, "$1")
+	canonical.WriteString(dkimRelaxedHeader(unsigned))
+
+	decoded, err := base64.StdEncoding.DecodeString(tags["b"])
+	if err != nil {
+		t.Fatalf("invalid b= tag: %v", err)
+	}
+	digest := sha256.Sum256(canonical.Bytes())
+	if err := rsa.VerifyPKCS1v15(public, crypto.SHA256, digest[:], decoded); err != nil {
+		t.Fatalf("DKIM signature does not verify: %v", err)
+	}
+	return tags
+}
+
+// mimeParts lê as partes de um corpo multipart, decodificando o base64 dos anexos
+func mimeParts(t *testing.T, contentType string, body io.Reader) []*multipart.Part {
+	t.Helper()
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") {
+		t.Fatalf("Content-Type = %q, want multipart", contentType)
+	}
+
+	var parts []*multipart.Part
+	reader := multipart.NewReader(body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return parts
+		}
+		if err != nil {
+			t.Fatalf("NextPart: %v", err)
+		}
+		content, _ := io.ReadAll(part)
+		if part.Header.Get("Content-Transfer-Encoding") == "base64" {
+			content, err = base64.StdEncoding.DecodeString(strings.ReplaceAll(string(content), "\r\n", ""))
+			if err != nil {
+				t.Fatalf("invalid base64 part: %v", err)
+			}
+		}
+		part.Header.Set("X-Test-Body", string(content))
+		parts = append(parts, part)
+	}
+}
+
+func TestEmailProviderSendMultipartWithAttachments(t *testing.T) {
+	server := newSMTPStandIn(t)
+	key, keyPEM := newTestDKIMKey(t)
+
+	report := []byte("%PDF-1.4 relatório")
+	files := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Write(report)
+	}))
+	defer files.Close()
+	filesURL, _ := url.Parse(files.URL)
+
+	provider := newTestEmailProvider(server, EmailConfig{
+		DKIMDomain:      "direitolux.com.br",
+		DKIMSelector:    "s1",
+		DKIMPrivateKey:  keyPEM,
+		AttachmentHosts: []string{filesURL.Hostname()},
+	}, nil)
+	defer provider.Close()
+
+	notification := newEmailNotification("ana@example.com")
+	notification.Metadata = map[string]interface{}{"list_unsubscribe": "https://app.direitolux.com.br/unsubscribe/1?sig=x"}
+	notification.Attachments = []domain.NotificationAttachment{
+		{Filename: "relatório.pdf", URL: files.URL + "/reports/1.pdf"},
+		{Filename: "dados.csv", Content: []byte("processo;status\n1;ativo\n")},
+	}
+
+	if err := provider.Send(context.Background(), notification); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	messages, _ := server.stats()
+	if len(messages) != 1 {
+		t.Fatalf("messages = %d, want 1", len(messages))
+	}
+
+	tags := verifyDKIM(t, messages[0], &key.PublicKey)
+	if tags["d"] != "direitolux.com.br" || tags["s"] != "s1" {
+		t.Errorf("DKIM d=%s s=%s", tags["d"], tags["s"])
+	}
+	if !strings.Contains(tags["h"], "list-unsubscribe") {
+		t.Errorf("DKIM h=%s without List-Unsubscribe", tags["h"])
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(messages[0]))
+	if err != nil {
+		t.Fatalf("ReadMessage: %v", err)
+	}
+	if subject, _ := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject")); subject != notification.Subject {
+		t.Errorf("Subject = %q", subject)
+	}
+	if got := msg.Header.Get("List-Unsubscribe-Post"); got != "List-Unsubscribe=One-Click" {
+		t.Errorf("List-Unsubscribe-Post = %q", got)
+	}
+	if got := msg.Header.Get("Message-ID"); got != "<"+notification.ID.String()+"@direitolux.com.br>" {
+		t.Errorf("Message-ID = %q", got)
+	}
+
+	mixed := mimeParts(t, msg.Header.Get("Content-Type"), msg.Body)
+	if len(mixed) != 3 {
+		t.Fatalf("multipart/mixed parts = %d, want 3", len(mixed))
+	}
+
+	alternative := mimeParts(t, mixed[0].Header.Get("Content-Type"), strings.NewReader(mixed[0].Header.Get("X-Test-Body")))
+	if len(alternative) != 2 {
+		t.Fatalf("multipart/alternative parts = %d, want 2", len(alternative))
+	}
+	if ct := alternative[0].Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("first alternative = %s, want text/plain", ct)
+	}
+	if !strings.Contains(alternative[0].Header.Get("X-Test-Body"), "Nova movimentação no processo.") {
+		t.Errorf("text part = %q", alternative[0].Header.Get("X-Test-Body"))
+	}
+	if ct := alternative[1].Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Errorf("second alternative = %s, want text/html", ct)
+	}
+
+	pdf := mixed[1]
+	if pdf.FileName() != "relatório.pdf" || !strings.HasPrefix(pdf.Header.Get("Content-Type"), "application/pdf") {
+		t.Errorf("attachment = %q (%s)", pdf.FileName(), pdf.Header.Get("Content-Type"))
+	}
+	if pdf.Header.Get("X-Test-Body") != string(report) {
+		t.Errorf("downloaded attachment content mismatch")
+	}
+	if mixed[2].FileName() != "dados.csv" || mixed[2].Header.Get("X-Test-Body") != "processo;status\n1;ativo\n" {
+		t.Errorf("inline attachment = %q", mixed[2].FileName())
+	}
+}
+
+func TestEmailProviderReusesConnections(t *testing.T) {
+	server := newSMTPStandIn(t)
+	server.reject = "unknown@example.com"
+	provider := newTestEmailProvider(server, EmailConfig{PoolSize: 1, MaxMessagesPerConn: 3}, nil)
+	defer provider.Close()
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if err := provider.Send(ctx, newEmailNotification("ana@example.com")); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+
+	// Destinatário recusado não descarta a conexão
+	if err := provider.Send(ctx, newEmailNotification("unknown@example.com")); err == nil {
+		t.Fatalf("Send() to rejected recipient should fail")
+	}
+
+	// Quarta mensagem: a conexão anterior atingiu o limite de mensagens
+	if err := provider.Send(ctx, newEmailNotification("bia@example.com")); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	messages, connections := server.stats()
+	if len(messages) != 3 {
+		t.Errorf("messages = %d, want 3", len(messages))
+	}
+	if connections != 2 {
+		t.Errorf("connections = %d, want 2", connections)
+	}
+	if server.authed != connections {
+		t.Errorf("authentications = %d, want one per connection", server.authed)
+	}
+}
+
+func TestEmailProviderTenantSenderDomain(t *testing.T) {
+	server := newSMTPStandIn(t)
+	key, keyPEM := newTestDKIMKey(t)
+	notification := newEmailNotification("ana@example.com")
+
+	senders := &stubSenderDomains{senderDomain: &domain.EmailSenderDomain{
+		TenantID:       notification.TenantID,
+		Domain:         "silvaadvogados.com.br",
+		FromEmail:      "avisos@silvaadvogados.com.br",
+		FromName:       "Silva Advogados",
+		DKIMSelector:   "direitolux",
+		DKIMPrivateKey: keyPEM,
+	}}
+	provider := newTestEmailProvider(server, EmailConfig{}, senders)
+	defer provider.Close()
+
+	// Domínio ainda não verificado: remetente padrão, sem DKIM configurado
+	if err := provider.Send(context.Background(), notification); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	// Outro tenant, sem domínio próprio
+	senders.senderDomain.MarkVerified()
+	if err := provider.Send(context.Background(), newEmailNotification("ana@example.com")); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	tenantNotification := newEmailNotification("ana@example.com")
+	tenantNotification.TenantID = notification.TenantID
+	if err := provider.Send(context.Background(), tenantNotification); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	messages, _ := server.stats()
+	if len(messages) != 3 {
+		t.Fatalf("messages = %d, want 3", len(messages))
+	}
+
+	for i, want := range []string{"noreply@direitolux.com.br", "noreply@direitolux.com.br", "avisos@silvaadvogados.com.br"} {
+		msg, err := mail.ReadMessage(bytes.NewReader(messages[i]))
+		if err != nil {
+			t.Fatalf("ReadMessage: %v", err)
+		}
+		from, err := mail.ParseAddress(msg.Header.Get("From"))
+		if err != nil || from.Address != want {
+			t.Errorf("message %d From = %q, want %s", i, msg.Header.Get("From"), want)
+		}
+	}
+
+	if bytes.Contains(messages[0], []byte("DKIM-Signature")) {
+		t.Errorf("unverified domain message should not be signed")
+	}
+	tags := verifyDKIM(t, messages[2], &key.PublicKey)
+	if tags["d"] != "silvaadvogados.com.br" {
+		t.Errorf("DKIM d=%s, want tenant domain", tags["d"])
+	}
+}
+
+func TestEmailProviderRejectsAttachmentHost(t *testing.T) {
+	server := newSMTPStandIn(t)
+	provider := newTestEmailProvider(server, EmailConfig{AttachmentHosts: []string{".reports.direitolux.com.br"}}, nil)
+	defer provider.Close()
+
+	notification := newEmailNotification("ana@example.com")
+	notification.Attachments = []domain.NotificationAttachment{{Filename: "x.pdf", URL: "http://169.254.169.254/latest/meta-data"}}
+
+	err := provider.Send(context.Background(), notification)
+	if !errors.Is(err, domain.ErrInvalidAttachment) {
+		t.Fatalf("Send() error = %v, want ErrInvalidAttachment", err)
+	}
+	if messages, connections := server.stats(); len(messages) != 0 || connections != 0 {
+		t.Errorf("messages = %d, connections = %d, want nothing sent", len(messages), connections)
+	}
+
+	if !provider.attachmentHostAllowed("files.reports.direitolux.com.br") || provider.attachmentHostAllowed("reports.direitolux.com.br.evil.io") {
+		t.Errorf("attachment host suffix matching")
+	}
+}
+
+func TestEmailProviderPlainTextOnly(t *testing.T) {
+	server := newSMTPStandIn(t)
+	provider := newTestEmailProvider(server, EmailConfig{}, nil)
+	defer provider.Close()
+
+	notification := newEmailNotification("ana@example.com")
+	notification.ContentHTML = nil
+	if err := provider.Send(context.Background(), notification); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	messages, _ := server.stats()
+	msg, err := mail.ReadMessage(bytes.NewReader(messages[0]))
+	if err != nil {
+		t.Fatalf("ReadMessage: %v", err)
+	}
+	if ct := msg.Header.Get("Content-Type"); ct != "text/plain; charset=UTF-8" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if cte := msg.Header.Get("Content-Transfer-Encoding"); cte != "quoted-printable" {
+		t.Errorf("Content-Transfer-Encoding = %q", cte)
+	}
+}
+
+func TestDKIMRelaxedCanonicalization(t *testing.T) {
+	// Exemplo da RFC 6376, seção 3.4.5
+	fields := parseHeaderFields([]byte("A: X\r\nB : Y\t\r\n\tZ  \r\n"))
+	if got := dkimRelaxedHeader(fields[0]); got != "a:X" {
+		t.Errorf("header A = %q", got)
+	}
+	if got := dkimRelaxedHeader(fields[1]); got != "b:Y Z" {
+		t.Errorf("header B = %q", got)
+	}
+	if got := string(dkimRelaxedBody([]byte(" C \r\nD \t E\r\n\r\n\r\n"))); got != " C\r\nD E\r\n" {
+		t.Errorf("body = %q", got)
+	}
+	if got := dkimRelaxedBody([]byte("\r\n\r\n")); len(got) != 0 {
+		t.Errorf("empty body = %q", got)
+	}
+}
+
+func TestHTMLToText(t *testing.T) {
+	html := `<html><head><style>p{color:red}</style></head><body><h1>Resumo</h1>` +
+		`<ul><li>Processo 1</li><li>Processo 2</li></ul>` +
+		`<p>Veja no <a href="https://app.direitolux.com.br">painel</a> &amp; responda.</p></body></html>`
+	want := "Resumo\n• Processo 1\n• Processo 2\n\nVeja no painel (https://app.direitolux.com.br) & responda."
+	if got := htmlToText(html); got != want {
+		t.Errorf("htmlToText() = %q, want %q", got, want)
+	}
+}
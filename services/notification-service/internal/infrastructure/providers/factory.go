@@ -11,15 +11,30 @@ import (
 
 // ProviderFactory factory para criar provedores de notificação
 type ProviderFactory struct {
-	config *config.Config
-	logger *zap.Logger
+	config            *config.Config
+	pushSubscriptions domain.PushSubscriptionRepository
+	whatsAppSessions  domain.WhatsAppSessionRepository
+	whatsAppTemplates domain.WhatsAppTemplateRepository
+	emailSenders      domain.EmailSenderDomainRepository
+	logger            *zap.Logger
 }
 
 // NewProviderFactory cria nova instância da factory
-func NewProviderFactory(config *config.Config, logger *zap.Logger) *ProviderFactory {
+func NewProviderFactory(
+	config *config.Config,
+	pushSubscriptions domain.PushSubscriptionRepository,
+	whatsAppSessions domain.WhatsAppSessionRepository,
+	whatsAppTemplates domain.WhatsAppTemplateRepository,
+	emailSenders domain.EmailSenderDomainRepository,
+	logger *zap.Logger,
+) *ProviderFactory {
 	return &ProviderFactory{
-		config: config,
-		logger: logger,
+		config:            config,
+		pushSubscriptions: pushSubscriptions,
+		whatsAppSessions:  whatsAppSessions,
+		whatsAppTemplates: whatsAppTemplates,
+		emailSenders:      emailSenders,
+		logger:            logger,
 	}
 }
 
@@ -45,10 +60,24 @@ func (f *ProviderFactory) CreateProviders() map[domain.NotificationChannel]domai
 		providers[domain.NotificationChannelTelegram] = telegramProvider
 	}
 
+	// SMS Provider
+	smsProvider := f.createSMSProvider()
+	if smsProvider != nil {
+		providers[domain.NotificationChannelSMS] = smsProvider
+	}
+
+	// Web Push Provider
+	pushProvider := f.createPushProvider()
+	if pushProvider != nil {
+		providers[domain.NotificationChannelPush] = pushProvider
+	}
+
 	f.logger.Info("Providers created successfully", 
 		zap.Int("email_available", boolToInt(emailProvider != nil)),
 		zap.Int("whatsapp_available", boolToInt(whatsappProvider != nil)),
 		zap.Int("telegram_available", boolToInt(telegramProvider != nil)),
+		zap.Int("sms_available", boolToInt(smsProvider != nil)),
+		zap.Int("push_available", boolToInt(pushProvider != nil)),
 		zap.Int("total_providers", len(providers)))
 
 	return providers
@@ -61,21 +90,7 @@ func (f *ProviderFactory) createEmailProvider() domain.NotificationProvider {
 		return nil
 	}
 
-	emailConfig := EmailConfig{
-		Host:        f.config.SMTP.Host,
-		Port:        f.config.SMTP.Port,
-		Username:    f.config.SMTP.Username,
-		Password:    f.config.SMTP.Password,
-		FromEmail:   f.config.SMTP.FromEmail,
-		FromName:    f.config.SMTP.FromName,
-		UseTLS:      f.config.SMTP.UseTLS,
-		UseStartTLS: f.config.SMTP.UseStartTLS,
-		Timeout:     30,
-		MaxRetries:  3,
-		RateLimit:   60,
-	}
-
-	provider := NewEmailProvider(emailConfig, f.logger)
+	provider := NewEmailProvider(NewEmailProviderConfig(f.config.SMTP), f.emailSenders, f.logger)
 	f.logger.Info("Email provider created successfully")
 	return provider
 }
@@ -97,7 +112,7 @@ func (f *ProviderFactory) createWhatsAppProvider() domain.NotificationProvider {
 		RateLimit:     60,
 	}
 
-	provider := NewWhatsAppProvider(whatsappConfig, f.logger)
+	provider := NewWhatsAppProvider(whatsappConfig, f.whatsAppSessions, f.whatsAppTemplates, f.logger)
 	f.logger.Info("WhatsApp provider created successfully")
 	return provider
 }
@@ -124,6 +139,41 @@ func (f *ProviderFactory) createTelegramProvider() domain.NotificationProvider {
 	return provider
 }
 
+// createSMSProvider cria provedor SMS sobre o gateway configurado
+func (f *ProviderFactory) createSMSProvider() domain.NotificationProvider {
+	if f.config.SMS.GatewayURL == "" {
+		f.logger.Warn("SMS provider not configured - missing gateway URL")
+		return nil
+	}
+
+	gateway, err := NewSMSGateway(f.config.SMS, f.logger)
+	if err != nil {
+		f.logger.Error("Failed to create SMS gateway", zap.Error(err))
+		return nil
+	}
+
+	provider := NewSMSProvider(NewSMSProviderConfig(f.config.SMS), gateway, f.logger)
+	f.logger.Info("SMS provider created successfully", zap.String("gateway", gateway.Name()))
+	return provider
+}
+
+// createPushProvider cria provedor Web Push
+func (f *ProviderFactory) createPushProvider() domain.NotificationProvider {
+	if !f.config.Push.Enabled() || f.pushSubscriptions == nil {
+		f.logger.Warn("Push provider not configured - missing VAPID keys")
+		return nil
+	}
+
+	provider, err := NewPushProvider(NewPushProviderConfig(f.config.Push), f.pushSubscriptions, f.logger)
+	if err != nil {
+		f.logger.Error("Failed to create push provider", zap.Error(err))
+		return nil
+	}
+
+	f.logger.Info("Push provider created successfully")
+	return provider
+}
+
 // GetAvailableChannels retorna canais disponíveis
 func (f *ProviderFactory) GetAvailableChannels() []domain.NotificationChannel {
 	var channels []domain.NotificationChannel
@@ -140,6 +190,14 @@ func (f *ProviderFactory) GetAvailableChannels() []domain.NotificationChannel {
 		channels = append(channels, domain.NotificationChannelTelegram)
 	}
 
+	if f.config.SMS.GatewayURL != "" {
+		channels = append(channels, domain.NotificationChannelSMS)
+	}
+
+	if f.config.Push.Enabled() {
+		channels = append(channels, domain.NotificationChannelPush)
+	}
+
 	return channels
 }
 
@@ -176,6 +234,22 @@ func (f *ProviderFactory) ValidateProviderConfig(channel domain.NotificationChan
 			return fmt.Errorf("bot token is required for Telegram provider")
 		}
 
+	case domain.NotificationChannelSMS:
+		if f.config.SMS.GatewayURL == "" {
+			return fmt.Errorf("gateway URL is required for SMS provider")
+		}
+		if f.config.SMS.MaxSegments < 1 {
+			return fmt.Errorf("max segments must be at least 1 for SMS provider")
+		}
+
+	case domain.NotificationChannelPush:
+		if !f.config.Push.Enabled() {
+			return fmt.Errorf("VAPID public and private keys are required for push provider")
+		}
+		if _, err := parseVAPIDKeys(f.config.Push.VAPIDPublicKey, f.config.Push.VAPIDPrivateKey); err != nil {
+			return err
+		}
+
 	default:
 		return fmt.Errorf("unsupported notification channel: %s", channel)
 	}
@@ -0,0 +1,202 @@
+package providers
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Criptografia de mensagens Web Push (RFC 8291, aes128gcm da RFC 8188) e assinatura VAPID
+// (RFC 8292) apenas com a biblioteca padrão
+
+const (
+	// pushRecordSize tamanho do registro aes128gcm; a mensagem cabe em um único registro
+	pushRecordSize = 4096
+	// pushHeaderSize salt (16) + rs (4) + idlen (1) + chave pública efêmera (65)
+	pushHeaderSize = 86
+	// maxPushPayloadSize maior payload que cabe nos 4096 bytes garantidos pelos serviços de push
+	maxPushPayloadSize = pushRecordSize - pushHeaderSize - 16 - 1
+	// vapidTokenLifetime validade do JWT VAPID (máximo de 24h pela RFC 8292)
+	vapidTokenLifetime = 12 * time.Hour
+)
+
+// vapidKeys par de chaves VAPID do servidor de aplicação
+type vapidKeys struct {
+	private *ecdsa.PrivateKey
+	public  []byte // ponto P-256 não comprimido
+}
+
+// GenerateVAPIDKeys gera um par de chaves VAPID (base64url), no formato de VAPID_PUBLIC_KEY
+// e VAPID_PRIVATE_KEY
+func GenerateVAPIDKeys() (publicKey, privateKey string, err error) {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate VAPID keys: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()),
+		base64.RawURLEncoding.EncodeToString(key.Bytes()), nil
+}
+
+// parseVAPIDKeys interpreta a chave privada (escalar de 32 bytes) e confere a chave pública
+func parseVAPIDKeys(publicKey, privateKey string) (*vapidKeys, error) {
+	d, err := decodeBase64URL(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+
+	key, err := ecdh.P256().NewPrivateKey(d)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+	public := key.PublicKey().Bytes()
+
+	if publicKey != "" {
+		configured, err := decodeBase64URL(publicKey)
+		if err != nil || !hmac.Equal(configured, public) {
+			return nil, fmt.Errorf("VAPID public key does not match private key")
+		}
+	}
+
+	return &vapidKeys{
+		private: &ecdsa.PrivateKey{
+			PublicKey: ecdsa.PublicKey{
+				Curve: elliptic.P256(),
+				X:     new(big.Int).SetBytes(public[1:33]),
+				Y:     new(big.Int).SetBytes(public[33:]),
+			},
+			D: new(big.Int).SetBytes(d),
+		},
+		public: public,
+	}, nil
+}
+
+// publicKeyString chave pública em base64url (applicationServerKey do navegador)
+func (k *vapidKeys) publicKeyString() string {
+	return base64.RawURLEncoding.EncodeToString(k.public)
+}
+
+// authorization header Authorization VAPID para o serviço de push do endpoint
+func (k *vapidKeys) authorization(endpoint, subject string, now time.Time) (string, error) {
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid push endpoint: %w", err)
+	}
+
+	claims, err := json.Marshal(map[string]interface{}{
+		"aud": endpointURL.Scheme + "://" + endpointURL.Host,
+		"exp": now.Add(vapidTokenLifetime).Unix(),
+		"sub": subject,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal VAPID claims: %w", err)
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString([]byte(`{"typ":"JWT","alg":"ES256"}`)) +
+		"." + base64.RawURLEncoding.EncodeToString(claims)
+
+	hash := sha256.Sum256([]byte(signingInput))
+	r, s, err := ecdsa.Sign(rand.Reader, k.private, hash[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign VAPID token: %w", err)
+	}
+
+	// ES256 usa r || s com 32 bytes cada
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+
+	token := signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+	return "vapid t=" + token + ", k=" + k.publicKeyString(), nil
+}
+
+// encryptPushPayload criptografa o payload para a inscrição (Content-Encoding: aes128gcm)
+func encryptPushPayload(uaPublic, authSecret, payload []byte) ([]byte, error) {
+	// Chave efêmera e salt por mensagem
+	asKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate ephemeral key: %w", err)
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	return sealPushRecord(uaPublic, authSecret, payload, asKey, salt)
+}
+
+// sealPushRecord monta o corpo aes128gcm com a chave efêmera e o salt informados
+func sealPushRecord(uaPublic, authSecret, payload []byte, asKey *ecdh.PrivateKey, salt []byte) ([]byte, error) {
+	if len(payload) > maxPushPayloadSize {
+		return nil, fmt.Errorf("push payload too large: %d bytes (max %d)", len(payload), maxPushPayloadSize)
+	}
+
+	uaKey, err := ecdh.P256().NewPublicKey(uaPublic)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subscription public key: %w", err)
+	}
+	asPublic := asKey.PublicKey().Bytes()
+
+	ecdhSecret, err := asKey.ECDH(uaKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute shared secret: %w", err)
+	}
+
+	// IKM = HKDF(auth_secret, ecdh_secret, "WebPush: info" || 0x00 || ua_public || as_public)
+	keyInfo := append([]byte("WebPush: info\x00"), uaPublic...)
+	keyInfo = append(keyInfo, asPublic...)
+	ikm := hkdfSHA256(authSecret, ecdhSecret, keyInfo, 32)
+
+	cek := hkdfSHA256(salt, ikm, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	nonce := hkdfSHA256(salt, ikm, []byte("Content-Encoding: nonce\x00"), 12)
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+
+	// Registro único: delimitador 0x02 marca o último registro
+	plaintext := append(append([]byte{}, payload...), 0x02)
+
+	body := make([]byte, pushHeaderSize, pushHeaderSize+len(plaintext)+gcm.Overhead())
+	copy(body, salt)
+	binary.BigEndian.PutUint32(body[16:20], pushRecordSize)
+	body[20] = byte(len(asPublic))
+	copy(body[21:], asPublic)
+
+	return gcm.Seal(body, nonce, plaintext, nil), nil
+}
+
+// hkdfSHA256 HKDF (RFC 5869) com um único bloco de expansão, suficiente para até 32 bytes
+func hkdfSHA256(salt, ikm, info []byte, length int) []byte {
+	extract := hmac.New(sha256.New, salt)
+	extract.Write(ikm)
+	prk := extract.Sum(nil)
+
+	expand := hmac.New(sha256.New, prk)
+	expand.Write(info)
+	expand.Write([]byte{0x01})
+	return expand.Sum(nil)[:length]
+}
+
+// decodeBase64URL decodifica base64url com ou sem padding
+func decodeBase64URL(value string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(strings.TrimSpace(value), "="))
+}
@@ -0,0 +1,410 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+	"github.com/direito-lux/notification-service/internal/infrastructure/config"
+)
+
+// errPushSubscriptionGone inscrição expirada ou cancelada no navegador (404/410)
+var errPushSubscriptionGone = errors.New("push subscription gone")
+
+// PushProvider implementação do provedor Web Push (VAPID). Envia para todas as inscrições
+// (dispositivos) do usuário e remove as que o serviço de push informa como expiradas.
+type PushProvider struct {
+	config        PushConfig
+	vapid         *vapidKeys
+	subscriptions domain.PushSubscriptionRepository
+	client        *http.Client
+	logger        *zap.Logger
+}
+
+// PushConfig configuração do provedor Web Push
+type PushConfig struct {
+	VAPIDPublicKey  string `json:"vapid_public_key"`
+	VAPIDPrivateKey string `json:"vapid_private_key" validate:"required"`
+	VAPIDSubject    string `json:"vapid_subject" validate:"required"` // mailto: ou https:
+	TTL             int    `json:"ttl"`                                 // segundos no serviço de push
+	ClickBaseURL    string `json:"click_base_url"`                      // URL do frontend
+	IconURL         string `json:"icon_url"`
+	Timeout         int    `json:"timeout"`    // segundos
+	RateLimit       int    `json:"rate_limit"` // mensagens por minuto
+}
+
+// PushPayload conteúdo entregue ao service worker do frontend
+type PushPayload struct {
+	NotificationID string `json:"notification_id"`
+	Type           string `json:"type"`
+	Title          string `json:"title"`
+	Body           string `json:"body"`
+	URL            string `json:"url,omitempty"` // aberta no clique da notificação
+	Tag            string `json:"tag,omitempty"` // substitui a notificação anterior do mesmo processo
+	Icon           string `json:"icon,omitempty"`
+}
+
+// NewPushProvider cria nova instância do provedor Web Push
+func NewPushProvider(config PushConfig, subscriptions domain.PushSubscriptionRepository, logger *zap.Logger) (*PushProvider, error) {
+	// Valores padrão
+	if config.TTL == 0 {
+		config.TTL = 86400
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 30
+	}
+	if config.RateLimit == 0 {
+		config.RateLimit = 600
+	}
+	config.ClickBaseURL = strings.TrimRight(config.ClickBaseURL, "/")
+
+	if config.VAPIDSubject == "" {
+		return nil, fmt.Errorf("VAPID subject is required")
+	}
+
+	keys, err := parseVAPIDKeys(config.VAPIDPublicKey, config.VAPIDPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PushProvider{
+		config:        config,
+		vapid:         keys,
+		subscriptions: subscriptions,
+		client: &http.Client{
+			Timeout: time.Duration(config.Timeout) * time.Second,
+		},
+		logger: logger,
+	}, nil
+}
+
+// Send envia a notificação para todos os dispositivos inscritos do usuário
+func (p *PushProvider) Send(ctx context.Context, notification *domain.Notification) error {
+	userID, err := pushRecipientUserID(notification)
+	if err != nil {
+		return err
+	}
+
+	p.logger.Debug("Sending push notification",
+		zap.String("notification_id", notification.ID.String()),
+		zap.String("user_id", userID.String()))
+
+	subscriptions, err := p.subscriptions.FindByUser(ctx, notification.TenantID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to find push subscriptions: %w", err)
+	}
+	if len(subscriptions) == 0 {
+		return fmt.Errorf("%w: %s", domain.ErrNoPushSubscriptions, userID)
+	}
+
+	payload, err := p.buildPayload(notification)
+	if err != nil {
+		return fmt.Errorf("failed to build push payload: %w", err)
+	}
+
+	delivered, pruned := 0, 0
+	var lastErr error
+	for _, subscription := range subscriptions {
+		messageID, err := p.sendToSubscription(ctx, subscription, payload, pushUrgency(notification.Priority))
+		switch {
+		case err == nil:
+			delivered++
+			if notification.ExternalID == nil && messageID != "" {
+				notification.ExternalID = &messageID
+			}
+			if err := p.subscriptions.MarkUsed(ctx, subscription.ID, time.Now()); err != nil {
+				p.logger.Warn("Failed to mark push subscription as used", zap.Error(err))
+			}
+		case errors.Is(err, errPushSubscriptionGone):
+			pruned++
+			if err := p.subscriptions.DeleteByEndpoint(ctx, subscription.Endpoint); err != nil {
+				p.logger.Error("Failed to prune expired push subscription", zap.Error(err))
+			}
+		default:
+			lastErr = err
+			p.logger.Warn("Failed to send push message",
+				zap.String("notification_id", notification.ID.String()),
+				zap.String("subscription_id", subscription.ID.String()),
+				zap.Error(err))
+		}
+	}
+
+	if pruned > 0 {
+		p.logger.Info("Expired push subscriptions pruned",
+			zap.String("user_id", userID.String()),
+			zap.Int("pruned", pruned))
+	}
+
+	// Entregue em ao menos um dispositivo
+	if delivered > 0 {
+		p.logger.Info("Push notification sent successfully",
+			zap.String("notification_id", notification.ID.String()),
+			zap.Int("devices", delivered))
+		return nil
+	}
+
+	if lastErr != nil {
+		return fmt.Errorf("failed to send push notification: %w", lastErr)
+	}
+	return fmt.Errorf("%w: all subscriptions expired", domain.ErrNoPushSubscriptions)
+}
+
+// sendToSubscription criptografa e envia a mensagem para um dispositivo
+func (p *PushProvider) sendToSubscription(ctx context.Context, subscription *domain.PushSubscription, payload []byte, urgency string) (string, error) {
+	uaPublic, err := subscription.PublicKey()
+	if err != nil {
+		return "", err
+	}
+	authSecret, err := subscription.AuthSecret()
+	if err != nil {
+		return "", err
+	}
+
+	body, err := encryptPushPayload(uaPublic, authSecret, payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt push payload: %w", err)
+	}
+
+	authorization, err := p.vapid.authorization(subscription.Endpoint, p.config.VAPIDSubject, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", subscription.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(p.config.TTL))
+	req.Header.Set("Urgency", urgency)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return "", errPushSubscriptionGone
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return "", domain.NewRateLimitError(domain.RateLimitScopeUpstream, parseRetryAfter(resp.Header.Get("Retry-After")))
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return "", fmt.Errorf("push service returned status: %d", resp.StatusCode)
+	}
+
+	// Location identifica a mensagem no serviço de push
+	return resp.Header.Get("Location"), nil
+}
+
+// buildPayload monta o payload com link para a página do processo
+func (p *PushProvider) buildPayload(notification *domain.Notification) ([]byte, error) {
+	title := notification.Subject
+	if title == "" {
+		title = "Direito Lux"
+	}
+
+	body := strings.TrimSpace(notification.Content)
+	if runes := []rune(body); len(runes) > p.GetMaxContentLength() {
+		body = string(runes[:p.GetMaxContentLength()]) + "…"
+	}
+
+	payload := PushPayload{
+		NotificationID: notification.ID.String(),
+		Type:           string(notification.Type),
+		Title:          title,
+		Body:           body,
+		URL:            p.ClickURL(notification),
+		Tag:            notification.ID.String(),
+		Icon:           p.config.IconURL,
+	}
+	if notification.ProcessID != nil {
+		payload.Tag = "process-" + notification.ProcessID.String()
+	}
+
+	// Texto multibyte pode exceder o limite do serviço de push: reduz o corpo até caber
+	for {
+		data, err := json.Marshal(payload)
+		if err != nil || len(data) <= maxPushPayloadSize || payload.Body == "" {
+			return data, err
+		}
+		runes := []rune(payload.Body)
+		payload.Body = string(runes[:len(runes)/2])
+	}
+}
+
+// ClickURL URL aberta no clique: metadata "url" (absoluta ou relativa ao frontend), a página do
+// processo ou a central de notificações
+func (p *PushProvider) ClickURL(notification *domain.Notification) string {
+	if target, ok := notification.Metadata["url"].(string); ok && target != "" {
+		if strings.HasPrefix(target, "/") {
+			return p.config.ClickBaseURL + target
+		}
+		return target
+	}
+
+	if notification.ProcessID != nil {
+		return p.config.ClickBaseURL + "/processes/" + notification.ProcessID.String()
+	}
+	return p.config.ClickBaseURL + "/notifications"
+}
+
+// pushRecipientUserID usuário destinatário: UserID da notificação ou contato/destinatário como UUID
+func pushRecipientUserID(notification *domain.Notification) (uuid.UUID, error) {
+	if notification.UserID != nil {
+		return *notification.UserID, nil
+	}
+	for _, candidate := range []string{notification.RecipientContact, notification.RecipientID} {
+		if userID, err := uuid.Parse(strings.TrimSpace(candidate)); err == nil {
+			return userID, nil
+		}
+	}
+	return uuid.Nil, fmt.Errorf("push recipient must be a user ID: %s", notification.RecipientContact)
+}
+
+// pushUrgency converte a prioridade no header Urgency (RFC 8030)
+func pushUrgency(priority domain.NotificationPriority) string {
+	switch priority {
+	case domain.NotificationPriorityCritical, domain.NotificationPriorityHigh:
+		return "high"
+	case domain.NotificationPriorityLow:
+		return "low"
+	default:
+		return "normal"
+	}
+}
+
+// SendBatch envia múltiplas notificações em lote
+func (p *PushProvider) SendBatch(ctx context.Context, notifications []*domain.Notification) error {
+	p.logger.Debug("Sending batch push notifications", zap.Int("count", len(notifications)))
+
+	for _, notification := range notifications {
+		if err := p.Send(ctx, notification); err != nil {
+			p.logger.Error("Failed to send push notification in batch",
+				zap.String("notification_id", notification.ID.String()),
+				zap.Error(err))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Configure configura o provedor
+func (p *PushProvider) Configure(ctx context.Context, config map[string]interface{}) error {
+	p.logger.Debug("Configuring push provider")
+
+	if err := p.ValidateConfiguration(config); err != nil {
+		return err
+	}
+
+	if subject, ok := config["vapid_subject"].(string); ok {
+		p.config.VAPIDSubject = subject
+	}
+	if clickBaseURL, ok := config["click_base_url"].(string); ok {
+		p.config.ClickBaseURL = strings.TrimRight(clickBaseURL, "/")
+	}
+	if privateKey, ok := config["vapid_private_key"].(string); ok {
+		publicKey, _ := config["vapid_public_key"].(string)
+		keys, err := parseVAPIDKeys(publicKey, privateKey)
+		if err != nil {
+			return err
+		}
+		p.vapid = keys
+		p.config.VAPIDPrivateKey = privateKey
+		p.config.VAPIDPublicKey = keys.publicKeyString()
+	}
+
+	return nil
+}
+
+// ValidateConfiguration valida a configuração
+func (p *PushProvider) ValidateConfiguration(config map[string]interface{}) error {
+	if subject, ok := config["vapid_subject"].(string); ok {
+		if !strings.HasPrefix(subject, "mailto:") && !strings.HasPrefix(subject, "https://") {
+			return fmt.Errorf("VAPID subject must be a mailto: or https: URL")
+		}
+	}
+
+	if privateKey, ok := config["vapid_private_key"].(string); ok {
+		publicKey, _ := config["vapid_public_key"].(string)
+		if _, err := parseVAPIDKeys(publicKey, privateKey); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IsHealthy verifica se o provedor está saudável. Não há API central: cada inscrição tem o
+// endpoint do serviço de push do navegador.
+func (p *PushProvider) IsHealthy(ctx context.Context) bool {
+	return p.vapid != nil
+}
+
+// GetChannel retorna o canal do provedor
+func (p *PushProvider) GetChannel() domain.NotificationChannel {
+	return domain.NotificationChannelPush
+}
+
+// SupportsHTML indica se suporta HTML
+func (p *PushProvider) SupportsHTML() bool {
+	return false
+}
+
+// SupportsAttachments indica se suporta anexos
+func (p *PushProvider) SupportsAttachments() bool {
+	return false
+}
+
+// SupportsTemplates indica se suporta templates
+func (p *PushProvider) SupportsTemplates() bool {
+	return false
+}
+
+// GetMaxContentLength retorna o tamanho máximo do texto da notificação; o payload criptografado
+// completo é limitado a 4096 bytes
+func (p *PushProvider) GetMaxContentLength() int {
+	return 1000
+}
+
+// GetRateLimit retorna o limite de taxa
+func (p *PushProvider) GetRateLimit() int {
+	return p.config.RateLimit
+}
+
+// ConfirmsDeliveryOnSend indica se o envio aceito já confirma a entrega.
+// Serviços de push não enviam recibos; a mensagem aceita é considerada entregue.
+func (p *PushProvider) ConfirmsDeliveryOnSend() bool {
+	return true
+}
+
+// NewPushProviderConfig converte a configuração do serviço na configuração do provedor
+func NewPushProviderConfig(cfg config.PushConfig) PushConfig {
+	return PushConfig{
+		VAPIDPublicKey:  cfg.VAPIDPublicKey,
+		VAPIDPrivateKey: cfg.VAPIDPrivateKey,
+		VAPIDSubject:    cfg.VAPIDSubject,
+		TTL:             int(cfg.TTL.Seconds()),
+		ClickBaseURL:    cfg.ClickBaseURL,
+		IconURL:         cfg.IconURL,
+	}
+}
+
+// VAPIDPublicKey chave pública usada pelo frontend como applicationServerKey
+func (p *PushProvider) VAPIDPublicKey() string {
+	return p.vapid.publicKeyString()
+}
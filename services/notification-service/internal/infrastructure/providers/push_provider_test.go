@@ -0,0 +1,252 @@
+package providers
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// fakePushSubscriptions repositório em memória
+type fakePushSubscriptions struct {
+	subscriptions []*domain.PushSubscription
+	deleted       []string
+}
+
+func (f *fakePushSubscriptions) Upsert(ctx context.Context, subscription *domain.PushSubscription) error {
+	f.subscriptions = append(f.subscriptions, subscription)
+	return nil
+}
+
+func (f *fakePushSubscriptions) FindByUser(ctx context.Context, tenantID, userID uuid.UUID) ([]*domain.PushSubscription, error) {
+	var found []*domain.PushSubscription
+	for _, s := range f.subscriptions {
+		if s.TenantID == tenantID && s.UserID == userID {
+			found = append(found, s)
+		}
+	}
+	return found, nil
+}
+
+func (f *fakePushSubscriptions) Delete(ctx context.Context, tenantID, userID, id uuid.UUID) error {
+	return nil
+}
+
+func (f *fakePushSubscriptions) DeleteByEndpoint(ctx context.Context, endpoint string) error {
+	f.deleted = append(f.deleted, endpoint)
+	return nil
+}
+
+func (f *fakePushSubscriptions) MarkUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	return nil
+}
+
+func mustDecode(t *testing.T, value string) []byte {
+	t.Helper()
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		t.Fatalf("decode %q: %v", value, err)
+	}
+	return data
+}
+
+// Vetor de teste da RFC 8291, apêndice A
+func TestSealPushRecordRFC8291(t *testing.T) {
+	asKey, err := ecdh.P256().NewPrivateKey(mustDecode(t, "yfWPiYE-n46HLnH0KqZOF1fJJU3MYrct3AELtAQ-oRw"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, err := sealPushRecord(
+		mustDecode(t, "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"),
+		mustDecode(t, "BTBZMqHH6r4Tts7J_aSIgg"),
+		[]byte("When I grow up, I want to be a watermelon"),
+		asKey,
+		mustDecode(t, "DGv6ra1nlYgDCS1FRnbzlw"),
+	)
+	if err != nil {
+		t.Fatalf("sealPushRecord() error = %v", err)
+	}
+
+	want := "DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN"
+	if got := base64.RawURLEncoding.EncodeToString(body); got != want {
+		t.Errorf("sealPushRecord() = %s\nwant %s", got, want)
+	}
+}
+
+// decryptPushRecord decifra o corpo como o navegador faria
+func decryptPushRecord(t *testing.T, uaKey *ecdh.PrivateKey, authSecret, body []byte) []byte {
+	t.Helper()
+	if len(body) < pushHeaderSize || binary.BigEndian.Uint32(body[16:20]) != pushRecordSize || body[20] != 65 {
+		t.Fatalf("invalid aes128gcm header")
+	}
+	salt, asPublic := body[:16], body[21:pushHeaderSize]
+
+	asKey, err := ecdh.P256().NewPublicKey(asPublic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret, err := uaKey.ECDH(asKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyInfo := append([]byte("WebPush: info\x00"), uaKey.PublicKey().Bytes()...)
+	keyInfo = append(keyInfo, asPublic...)
+	ikm := hkdfSHA256(authSecret, secret, keyInfo, 32)
+	block, _ := aes.NewCipher(hkdfSHA256(salt, ikm, []byte("Content-Encoding: aes128gcm\x00"), 16))
+	gcm, _ := cipher.NewGCM(block)
+
+	plaintext, err := gcm.Open(nil, hkdfSHA256(salt, ikm, []byte("Content-Encoding: nonce\x00"), 12), body[pushHeaderSize:], nil)
+	if err != nil {
+		t.Fatalf("decrypt: %v", err)
+	}
+	if plaintext[len(plaintext)-1] != 0x02 {
+		t.Fatalf("missing last record delimiter")
+	}
+	return plaintext[:len(plaintext)-1]
+}
+
+// verifyVAPID confere o JWT ES256 do header Authorization
+func verifyVAPID(t *testing.T, header, publicKey, audience string) {
+	t.Helper()
+	if !strings.HasPrefix(header, "vapid t=") || !strings.HasSuffix(header, ", k="+publicKey) {
+		t.Fatalf("invalid VAPID header: %s", header)
+	}
+	token := strings.TrimSuffix(strings.TrimPrefix(header, "vapid t="), ", k="+publicKey)
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("invalid JWT: %s", token)
+	}
+
+	var claims struct {
+		Aud string `json:"aud"`
+		Exp int64  `json:"exp"`
+		Sub string `json:"sub"`
+	}
+	if err := json.Unmarshal(mustDecode(t, parts[1]), &claims); err != nil {
+		t.Fatal(err)
+	}
+	if claims.Aud != audience || claims.Sub != "mailto:contato@direitolux.com.br" || claims.Exp <= time.Now().Unix() {
+		t.Errorf("invalid claims: %+v", claims)
+	}
+
+	key := mustDecode(t, publicKey)
+	signature := mustDecode(t, parts[2])
+	hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	ecdsaKey := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(key[1:33]), Y: new(big.Int).SetBytes(key[33:])}
+	if !ecdsa.Verify(ecdsaKey, hash[:], new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])) {
+		t.Error("invalid VAPID signature")
+	}
+}
+
+func TestPushProviderSend(t *testing.T) {
+	tenantID, userID, processID := uuid.New(), uuid.New(), uuid.New()
+
+	uaKey, _ := ecdh.P256().GenerateKey(rand.Reader)
+	authSecret := make([]byte, 16)
+	rand.Read(authSecret)
+
+	publicKey, privateKey, err := GenerateVAPIDKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var payload PushPayload
+	active := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		verifyVAPID(t, r.Header.Get("Authorization"), publicKey, "http://"+r.Host)
+		if r.Header.Get("Content-Encoding") != "aes128gcm" || r.Header.Get("Urgency") != "high" {
+			t.Errorf("unexpected headers: %v", r.Header)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(decryptPushRecord(t, uaKey, authSecret, body), &payload); err != nil {
+			t.Errorf("invalid payload: %v", err)
+		}
+		w.Header().Set("Location", "https://push.example.com/m/123")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer active.Close()
+
+	expired := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer expired.Close()
+
+	repo := &fakePushSubscriptions{}
+	for _, endpoint := range []string{active.URL + "/send/abc", expired.URL + "/send/old"} {
+		repo.subscriptions = append(repo.subscriptions, &domain.PushSubscription{
+			ID:       uuid.New(),
+			TenantID: tenantID,
+			UserID:   userID,
+			Endpoint: endpoint,
+			P256dh:   base64.RawURLEncoding.EncodeToString(uaKey.PublicKey().Bytes()),
+			Auth:     base64.RawURLEncoding.EncodeToString(authSecret),
+		})
+	}
+
+	provider, err := NewPushProvider(PushConfig{
+		VAPIDPublicKey:  publicKey,
+		VAPIDPrivateKey: privateKey,
+		VAPIDSubject:    "mailto:contato@direitolux.com.br",
+		ClickBaseURL:    "https://app.direitolux.com.br/",
+	}, repo, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewPushProvider() error = %v", err)
+	}
+
+	notification := &domain.Notification{
+		ID:               uuid.New(),
+		Type:             domain.NotificationTypeMovementAlert,
+		Channel:          domain.NotificationChannelPush,
+		Priority:         domain.NotificationPriorityHigh,
+		Subject:          "Nova movimentação",
+		Content:          "Sentença publicada no processo",
+		TenantID:         tenantID,
+		RecipientContact: userID.String(),
+		ProcessID:        &processID,
+	}
+
+	if err := provider.Send(context.Background(), notification); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if payload.URL != "https://app.direitolux.com.br/processes/"+processID.String() {
+		t.Errorf("URL = %q", payload.URL)
+	}
+	if payload.Title != "Nova movimentação" || payload.Tag != "process-"+processID.String() {
+		t.Errorf("payload = %+v", payload)
+	}
+	if notification.ExternalID == nil || *notification.ExternalID != "https://push.example.com/m/123" {
+		t.Errorf("ExternalID = %v", notification.ExternalID)
+	}
+	if len(repo.deleted) != 1 || repo.deleted[0] != expired.URL+"/send/old" {
+		t.Errorf("pruned = %v, want expired endpoint", repo.deleted)
+	}
+
+	// Sem inscrições restantes
+	repo.subscriptions = repo.subscriptions[1:]
+	err = provider.Send(context.Background(), notification)
+	if !errors.Is(err, domain.ErrNoPushSubscriptions) {
+		t.Errorf("Send() error = %v, want ErrNoPushSubscriptions", err)
+	}
+}
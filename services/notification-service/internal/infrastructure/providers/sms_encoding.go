@@ -0,0 +1,123 @@
+package providers
+
+import (
+	"strings"
+	"unicode/utf16"
+)
+
+// SMSEncoding codificação da mensagem SMS
+type SMSEncoding string
+
+const (
+	SMSEncodingGSM7 SMSEncoding = "GSM-7"
+	SMSEncodingUCS2 SMSEncoding = "UCS-2"
+)
+
+// Tamanho dos segmentos: mensagens concatenadas reservam espaço para o cabeçalho (UDH)
+const (
+	gsm7SingleSegment = 160
+	gsm7MultiSegment  = 153
+	ucs2SingleSegment = 70
+	ucs2MultiSegment  = 67
+)
+
+// gsm7Basic alfabeto padrão GSM 03.38 (um septeto por caractere)
+const gsm7Basic = "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?" +
+	"¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà"
+
+// gsm7Extended tabela de extensão GSM 03.38 (escape + caractere, dois septetos)
+const gsm7Extended = "^{}\\[~]|€\f"
+
+// smsTransliterator troca acentos e pontuação tipográfica fora do GSM-7 por equivalentes,
+// evitando que um único caractere leve a mensagem para UCS-2 (70 caracteres por segmento)
+var smsTransliterator = strings.NewReplacer(
+	"á", "a", "â", "a", "ã", "a", "Á", "A", "Â", "A", "Ã", "A", "À", "A",
+	"ê", "e", "Ê", "E", "È", "E",
+	"í", "i", "Í", "I",
+	"ó", "o", "ô", "o", "õ", "o", "Ó", "O", "Ô", "O", "Õ", "O",
+	"ú", "u", "Ú", "U",
+	"ç", "c",
+	"ª", "a", "º", "o",
+	"–", "-", "—", "-", "•", "-",
+	"“", "\"", "”", "\"", "‘", "'", "’", "'",
+	"…", "...", " ", " ",
+)
+
+// SMSSegmentInfo resultado da análise de uma mensagem SMS
+type SMSSegmentInfo struct {
+	Encoding SMSEncoding
+	Units    int // septetos (GSM-7) ou unidades UTF-16 (UCS-2)
+	Segments int
+}
+
+// TransliterateSMS converte o texto para o alfabeto GSM-7 sempre que houver equivalente
+func TransliterateSMS(text string) string {
+	return smsTransliterator.Replace(text)
+}
+
+// AnalyzeSMS calcula codificação, tamanho e número de segmentos da mensagem. Escapes GSM-7 e
+// pares substitutos UTF-16 não são divididos entre segmentos.
+func AnalyzeSMS(text string) SMSSegmentInfo {
+	if isGSM7(text) {
+		var units []int
+		for _, r := range text {
+			units = append(units, gsm7Septets(r))
+		}
+		return segmentUnits(SMSEncodingGSM7, units, gsm7SingleSegment, gsm7MultiSegment)
+	}
+
+	var units []int
+	for _, r := range text {
+		units = append(units, len(utf16.Encode([]rune{r})))
+	}
+	return segmentUnits(SMSEncodingUCS2, units, ucs2SingleSegment, ucs2MultiSegment)
+}
+
+// isGSM7 verifica se todos os caracteres pertencem ao alfabeto GSM-7
+func isGSM7(text string) bool {
+	for _, r := range text {
+		if gsm7Septets(r) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// gsm7Septets septetos ocupados pelo caractere (0 se não pertence ao GSM-7)
+func gsm7Septets(r rune) int {
+	switch {
+	case strings.ContainsRune(gsm7Basic, r):
+		return 1
+	case strings.ContainsRune(gsm7Extended, r):
+		return 2
+	default:
+		return 0
+	}
+}
+
+// segmentUnits distribui os caracteres em segmentos sem quebrar um caractere ao meio
+func segmentUnits(encoding SMSEncoding, units []int, single, multi int) SMSSegmentInfo {
+	info := SMSSegmentInfo{Encoding: encoding}
+	for _, u := range units {
+		info.Units += u
+	}
+
+	switch {
+	case info.Units == 0:
+		return info
+	case info.Units <= single:
+		info.Segments = 1
+		return info
+	}
+
+	info.Segments = 1
+	used := 0
+	for _, u := range units {
+		if used+u > multi {
+			info.Segments++
+			used = 0
+		}
+		used += u
+	}
+	return info
+}
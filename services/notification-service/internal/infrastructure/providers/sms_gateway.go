@@ -0,0 +1,269 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+	"github.com/direito-lux/notification-service/internal/infrastructure/config"
+)
+
+// SMSGateway adaptador do gateway de SMS. Cada gateway tem sua API de envio e seus formatos de
+// webhook; o SMSProvider cuida de número, codificação e segmentação.
+type SMSGateway interface {
+	// Send envia a mensagem e retorna o ID atribuído pelo gateway
+	Send(ctx context.Context, message *SMSMessage) (*SMSSendResult, error)
+
+	// ParseDeliveryReports extrai os relatórios de entrega de um webhook do gateway
+	ParseDeliveryReports(payload []byte) ([]*domain.DeliveryStatusUpdate, error)
+
+	// ParseInboundMessage extrai a resposta do usuário de um webhook do gateway
+	ParseInboundMessage(payload []byte) (*SMSInboundMessage, error)
+
+	// IsHealthy verifica se o gateway está acessível
+	IsHealthy(ctx context.Context) bool
+
+	// Name nome do gateway
+	Name() string
+}
+
+// SMSMessage mensagem a ser enviada pelo gateway
+type SMSMessage struct {
+	To             string      `json:"to"` // E.164
+	From           string      `json:"from,omitempty"`
+	Body           string      `json:"body"`
+	Encoding       SMSEncoding `json:"encoding"`
+	Segments       int         `json:"segments"`
+	Reference      string      `json:"reference,omitempty"` // ID da notificação
+	StatusCallback string      `json:"status_callback,omitempty"`
+}
+
+// SMSSendResult resultado do envio
+type SMSSendResult struct {
+	MessageID string `json:"id"`
+	Status    string `json:"status"`
+	Segments  int    `json:"segments"`
+}
+
+// SMSInboundMessage mensagem recebida do usuário
+type SMSInboundMessage struct {
+	MessageID  string    `json:"message_id"`
+	From       string    `json:"from"`
+	To         string    `json:"to"`
+	Body       string    `json:"body"`
+	ReceivedAt time.Time `json:"timestamp"`
+}
+
+// NewSMSGateway cria o adaptador configurado em SMS_GATEWAY
+func NewSMSGateway(cfg config.SMSConfig, logger *zap.Logger) (SMSGateway, error) {
+	switch cfg.Gateway {
+	case "", "http":
+		if cfg.GatewayURL == "" {
+			return nil, fmt.Errorf("SMS gateway URL is required")
+		}
+		return NewHTTPSMSGateway(HTTPSMSGatewayConfig{
+			BaseURL: cfg.GatewayURL,
+			APIKey:  cfg.APIKey,
+			Timeout: 30,
+		}, logger), nil
+	default:
+		return nil, fmt.Errorf("unsupported SMS gateway: %s", cfg.Gateway)
+	}
+}
+
+// HTTPSMSGateway adaptador para gateways com API REST/JSON genérica: POST {base}/messages para
+// envio, GET {base}/health para saúde e webhooks JSON de status e de mensagens recebidas.
+// Também atende um stand-in local em desenvolvimento e testes.
+type HTTPSMSGateway struct {
+	config HTTPSMSGatewayConfig
+	client *http.Client
+	logger *zap.Logger
+}
+
+// HTTPSMSGatewayConfig configuração do gateway HTTP
+type HTTPSMSGatewayConfig struct {
+	BaseURL string `json:"base_url" validate:"required"`
+	APIKey  string `json:"api_key"`
+	Timeout int    `json:"timeout"` // segundos
+}
+
+// NewHTTPSMSGateway cria nova instância do gateway HTTP
+func NewHTTPSMSGateway(config HTTPSMSGatewayConfig, logger *zap.Logger) *HTTPSMSGateway {
+	// Valores padrão
+	if config.Timeout == 0 {
+		config.Timeout = 30
+	}
+	config.BaseURL = strings.TrimRight(config.BaseURL, "/")
+
+	return &HTTPSMSGateway{
+		config: config,
+		client: &http.Client{
+			Timeout: time.Duration(config.Timeout) * time.Second,
+		},
+		logger: logger,
+	}
+}
+
+// httpSMSError erro devolvido pelo gateway
+type httpSMSError struct {
+	Error *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// Send envia a mensagem pelo gateway
+func (g *HTTPSMSGateway) Send(ctx context.Context, message *SMSMessage) (*SMSSendResult, error) {
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", g.config.BaseURL+"/messages", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if g.config.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+g.config.APIKey)
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, domain.NewRateLimitError(domain.RateLimitScopeUpstream, parseRetryAfter(resp.Header.Get("Retry-After")))
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr httpSMSError
+		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err == nil && apiErr.Error != nil {
+			return nil, fmt.Errorf("SMS gateway error: %s (%s)", apiErr.Error.Message, apiErr.Error.Code)
+		}
+		return nil, fmt.Errorf("SMS gateway returned status: %d", resp.StatusCode)
+	}
+
+	var result SMSSendResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if result.MessageID == "" {
+		return nil, fmt.Errorf("SMS gateway response without message id")
+	}
+
+	return &result, nil
+}
+
+// IsHealthy verifica se o gateway está acessível
+func (g *HTTPSMSGateway) IsHealthy(ctx context.Context) bool {
+	req, err := http.NewRequestWithContext(ctx, "GET", g.config.BaseURL+"/health", nil)
+	if err != nil {
+		g.logger.Warn("SMS gateway unhealthy - cannot create request", zap.Error(err))
+		return false
+	}
+	if g.config.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+g.config.APIKey)
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		g.logger.Warn("SMS gateway unhealthy - request failed", zap.Error(err))
+		return false
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode == http.StatusOK
+}
+
+// Name nome do gateway
+func (g *HTTPSMSGateway) Name() string {
+	return "http"
+}
+
+// HTTPSMSDeliveryReport relatório de entrega enviado pelo gateway
+type HTTPSMSDeliveryReport struct {
+	MessageID    string     `json:"message_id"`
+	Status       string     `json:"status"`
+	To           string     `json:"to"`
+	ErrorCode    string     `json:"error_code,omitempty"`
+	ErrorMessage string     `json:"error_message,omitempty"`
+	Timestamp    *time.Time `json:"timestamp,omitempty"`
+}
+
+// httpSMSDeliveryEvents status do gateway suportados. Número inexistente ou desativado é falha
+// permanente e suprime o contato, como um hard bounce de email.
+var httpSMSDeliveryEvents = map[string]domain.DeliveryEvent{
+	"accepted":       domain.DeliveryEventSent,
+	"sent":           domain.DeliveryEventSent,
+	"delivered":      domain.DeliveryEventDelivered,
+	"failed":         domain.DeliveryEventFailed,
+	"undelivered":    domain.DeliveryEventFailed,
+	"expired":        domain.DeliveryEventFailed,
+	"rejected":       domain.DeliveryEventFailed,
+	"invalid_number": domain.DeliveryEventBounced,
+}
+
+// ParseDeliveryReports aceita um relatório ou uma lista de relatórios
+func (g *HTTPSMSGateway) ParseDeliveryReports(payload []byte) ([]*domain.DeliveryStatusUpdate, error) {
+	var reports []HTTPSMSDeliveryReport
+	trimmed := bytes.TrimSpace(payload)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &reports); err != nil {
+			return nil, fmt.Errorf("failed to parse delivery reports: %w", err)
+		}
+	} else {
+		var report HTTPSMSDeliveryReport
+		if err := json.Unmarshal(trimmed, &report); err != nil {
+			return nil, fmt.Errorf("failed to parse delivery report: %w", err)
+		}
+		reports = append(reports, report)
+	}
+
+	var updates []*domain.DeliveryStatusUpdate
+	for _, report := range reports {
+		event, ok := httpSMSDeliveryEvents[strings.ToLower(report.Status)]
+		if !ok || report.MessageID == "" {
+			continue
+		}
+
+		update := &domain.DeliveryStatusUpdate{
+			Channel:    domain.NotificationChannelSMS,
+			ExternalID: report.MessageID,
+			Event:      event,
+			Recipient:  report.To,
+			HardBounce: event == domain.DeliveryEventBounced,
+			ErrorCode:  report.ErrorCode,
+			Reason:     report.ErrorMessage,
+		}
+		if report.Timestamp != nil {
+			update.OccurredAt = *report.Timestamp
+		}
+
+		updates = append(updates, update)
+	}
+
+	return updates, nil
+}
+
+// ParseInboundMessage extrai a mensagem recebida
+func (g *HTTPSMSGateway) ParseInboundMessage(payload []byte) (*SMSInboundMessage, error) {
+	var message SMSInboundMessage
+	if err := json.Unmarshal(payload, &message); err != nil {
+		return nil, fmt.Errorf("failed to parse inbound message: %w", err)
+	}
+	if message.From == "" {
+		return nil, fmt.Errorf("inbound message without sender")
+	}
+	return &message, nil
+}
@@ -0,0 +1,226 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+	"github.com/direito-lux/notification-service/internal/infrastructure/config"
+)
+
+// SMSProvider implementação do provedor SMS sobre um SMSGateway
+type SMSProvider struct {
+	config  SMSConfig
+	gateway SMSGateway
+	logger  *zap.Logger
+}
+
+// SMSConfig configuração do provedor SMS
+type SMSConfig struct {
+	SenderID          string `json:"sender_id"`
+	StatusCallbackURL string `json:"status_callback_url"`
+	MaxSegments       int    `json:"max_segments"`  // limite de segmentos por mensagem
+	Transliterate     bool   `json:"transliterate"` // remove acentos fora do GSM-7
+	OptOutHint        string `json:"opt_out_hint"`  // instrução de descadastro ao final da mensagem
+	RateLimit         int    `json:"rate_limit"`    // mensagens por minuto
+}
+
+// NewSMSProvider cria nova instância do provedor SMS
+func NewSMSProvider(config SMSConfig, gateway SMSGateway, logger *zap.Logger) *SMSProvider {
+	// Valores padrão
+	if config.MaxSegments == 0 {
+		config.MaxSegments = 4
+	}
+	if config.RateLimit == 0 {
+		config.RateLimit = 60
+	}
+
+	return &SMSProvider{
+		config:  config,
+		gateway: gateway,
+		logger:  logger,
+	}
+}
+
+// Send envia uma notificação via SMS
+func (p *SMSProvider) Send(ctx context.Context, notification *domain.Notification) error {
+	p.logger.Debug("Sending SMS notification",
+		zap.String("notification_id", notification.ID.String()),
+		zap.String("recipient", notification.RecipientContact))
+
+	to, err := domain.NormalizeBrazilianPhone(notification.RecipientContact)
+	if err != nil {
+		return fmt.Errorf("invalid recipient phone number: %w", err)
+	}
+
+	body := p.BuildBody(notification.Content)
+	info := AnalyzeSMS(body)
+	if info.Segments == 0 {
+		return fmt.Errorf("empty SMS message")
+	}
+	if info.Segments > p.config.MaxSegments {
+		return fmt.Errorf("SMS message too long: %d segments (max %d)", info.Segments, p.config.MaxSegments)
+	}
+
+	result, err := p.gateway.Send(ctx, &SMSMessage{
+		To:             to,
+		From:           p.config.SenderID,
+		Body:           body,
+		Encoding:       info.Encoding,
+		Segments:       info.Segments,
+		Reference:      notification.ID.String(),
+		StatusCallback: p.config.StatusCallbackURL,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send SMS message: %w", err)
+	}
+
+	// Atualizar notificação com ID externo e segmentos cobrados
+	notification.ExternalID = &result.MessageID
+	if notification.Metadata == nil {
+		notification.Metadata = make(map[string]interface{})
+	}
+	notification.Metadata["sms_encoding"] = string(info.Encoding)
+	notification.Metadata["sms_segments"] = info.Segments
+
+	p.logger.Info("SMS message sent successfully",
+		zap.String("notification_id", notification.ID.String()),
+		zap.String("gateway", p.gateway.Name()),
+		zap.String("sms_message_id", result.MessageID),
+		zap.String("encoding", string(info.Encoding)),
+		zap.Int("segments", info.Segments))
+
+	return nil
+}
+
+// BuildBody monta o texto enviado: conteúdo, instrução de descadastro e transliteração para GSM-7
+func (p *SMSProvider) BuildBody(content string) string {
+	body := strings.TrimSpace(content)
+	if p.config.OptOutHint != "" && body != "" {
+		body += "\n" + p.config.OptOutHint
+	}
+	if p.config.Transliterate {
+		body = TransliterateSMS(body)
+	}
+	return body
+}
+
+// SendBatch envia múltiplas notificações em lote
+func (p *SMSProvider) SendBatch(ctx context.Context, notifications []*domain.Notification) error {
+	p.logger.Debug("Sending batch SMS notifications", zap.Int("count", len(notifications)))
+
+	for _, notification := range notifications {
+		if err := p.Send(ctx, notification); err != nil {
+			p.logger.Error("Failed to send SMS message in batch",
+				zap.String("notification_id", notification.ID.String()),
+				zap.Error(err))
+			return err
+		}
+
+		// Rate limiting - aguardar entre envios
+		if len(notifications) > 1 {
+			time.Sleep(time.Minute / time.Duration(p.config.RateLimit))
+		}
+	}
+
+	return nil
+}
+
+// Configure configura o provedor
+func (p *SMSProvider) Configure(ctx context.Context, config map[string]interface{}) error {
+	p.logger.Debug("Configuring SMS provider")
+
+	if senderID, ok := config["sender_id"].(string); ok {
+		p.config.SenderID = senderID
+	}
+	if callbackURL, ok := config["status_callback_url"].(string); ok {
+		p.config.StatusCallbackURL = callbackURL
+	}
+	if optOutHint, ok := config["opt_out_hint"].(string); ok {
+		p.config.OptOutHint = optOutHint
+	}
+
+	return p.ValidateConfiguration(config)
+}
+
+// ValidateConfiguration valida a configuração
+func (p *SMSProvider) ValidateConfiguration(config map[string]interface{}) error {
+	if maxSegments, ok := config["max_segments"].(int); ok {
+		if maxSegments < 1 {
+			return fmt.Errorf("invalid max segments: %d", maxSegments)
+		}
+		p.config.MaxSegments = maxSegments
+	}
+
+	if p.gateway == nil {
+		return fmt.Errorf("SMS gateway is required")
+	}
+
+	return nil
+}
+
+// IsHealthy verifica se o provedor está saudável
+func (p *SMSProvider) IsHealthy(ctx context.Context) bool {
+	return p.gateway.IsHealthy(ctx)
+}
+
+// GetChannel retorna o canal do provedor
+func (p *SMSProvider) GetChannel() domain.NotificationChannel {
+	return domain.NotificationChannelSMS
+}
+
+// SupportsHTML indica se suporta HTML
+func (p *SMSProvider) SupportsHTML() bool {
+	return false
+}
+
+// SupportsAttachments indica se suporta anexos
+func (p *SMSProvider) SupportsAttachments() bool {
+	return false
+}
+
+// SupportsTemplates indica se suporta templates
+func (p *SMSProvider) SupportsTemplates() bool {
+	return false
+}
+
+// GetMaxContentLength retorna o tamanho máximo do conteúdo em caracteres GSM-7, descontada a
+// instrução de descadastro. Conteúdo em UCS-2 (emojis, acentos sem transliteração) comporta
+// menos de metade disso.
+func (p *SMSProvider) GetMaxContentLength() int {
+	capacity := gsm7SingleSegment
+	if p.config.MaxSegments > 1 {
+		capacity = p.config.MaxSegments * gsm7MultiSegment
+	}
+	if p.config.OptOutHint != "" {
+		capacity -= AnalyzeSMS("\n" + p.config.OptOutHint).Units
+	}
+	return capacity
+}
+
+// GetRateLimit retorna o limite de taxa
+func (p *SMSProvider) GetRateLimit() int {
+	return p.config.RateLimit
+}
+
+// ConfirmsDeliveryOnSend indica se o envio aceito já confirma a entrega.
+// A entrega é confirmada pelos relatórios de entrega (DLR) do gateway.
+func (p *SMSProvider) ConfirmsDeliveryOnSend() bool {
+	return false
+}
+
+// NewSMSProviderConfig converte a configuração do serviço na configuração do provedor
+func NewSMSProviderConfig(cfg config.SMSConfig) SMSConfig {
+	return SMSConfig{
+		SenderID:          cfg.SenderID,
+		StatusCallbackURL: cfg.StatusCallbackURL,
+		MaxSegments:       cfg.MaxSegments,
+		Transliterate:     cfg.Transliterate,
+		OptOutHint:        cfg.OptOutHint,
+		RateLimit:         60,
+	}
+}
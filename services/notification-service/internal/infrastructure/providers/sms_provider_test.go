@@ -0,0 +1,149 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+func TestSMSProviderSend(t *testing.T) {
+	var received SMSMessage
+	provider, _ := newSMSStandIn(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/messages" || r.Header.Get("Authorization") != "Bearer test-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(SMSSendResult{MessageID: "sms-123", Status: "accepted", Segments: received.Segments})
+	})
+
+	notification := newSMSNotification("(11) 98765-4321", "Nova movimentação no processo 1234567-89.2024.8.26.0001")
+	if err := provider.Send(context.Background(), notification); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if received.To != "+5511987654321" {
+		t.Errorf("To = %q, want +5511987654321", received.To)
+	}
+	if received.Encoding != SMSEncodingGSM7 {
+		t.Errorf("Encoding = %s, want GSM-7 after transliteration (body %q)", received.Encoding, received.Body)
+	}
+	if !strings.HasSuffix(received.Body, "\nResponda SAIR para cancelar.") {
+		t.Errorf("Body without opt-out hint: %q", received.Body)
+	}
+	if received.Reference != notification.ID.String() {
+		t.Errorf("Reference = %q, want notification ID", received.Reference)
+	}
+	if notification.ExternalID == nil || *notification.ExternalID != "sms-123" {
+		t.Errorf("ExternalID = %v, want sms-123", notification.ExternalID)
+	}
+}
+
+func TestSMSProviderSendRejectsTooManySegments(t *testing.T) {
+	calls := 0
+	provider, _ := newSMSStandIn(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	})
+
+	notification := newSMSNotification("11987654321", strings.Repeat("a", 2*gsm7MultiSegment))
+	if err := provider.Send(context.Background(), notification); err == nil {
+		t.Fatal("Send() error = nil, want too many segments")
+	}
+	if calls != 0 {
+		t.Errorf("gateway called %d times, want 0", calls)
+	}
+}
+
+func TestSMSProviderSendRateLimited(t *testing.T) {
+	provider, _ := newSMSStandIn(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	err := provider.Send(context.Background(), newSMSNotification("+55 11 98765-4321", "Prazo amanhã"))
+	var rateLimitErr *domain.RateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		t.Fatalf("Send() error = %v, want RateLimitError", err)
+	}
+	if rateLimitErr.RetryAfter != 30*time.Second {
+		t.Errorf("RetryAfter = %s, want 30s", rateLimitErr.RetryAfter)
+	}
+}
+
+func TestAnalyzeSMS(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		encoding SMSEncoding
+		units    int
+		segments int
+	}{
+		{"vazio", "", SMSEncodingGSM7, 0, 0},
+		{"gsm7 um segmento", strings.Repeat("a", 160), SMSEncodingGSM7, 160, 1},
+		{"gsm7 concatenado", strings.Repeat("a", 161), SMSEncodingGSM7, 161, 2},
+		{"extensão conta dois septetos", strings.Repeat("€", 80), SMSEncodingGSM7, 160, 1},
+		{"escape não é dividido", strings.Repeat("a", 152) + "€" + strings.Repeat("a", 10), SMSEncodingGSM7, 164, 2},
+		{"ucs2 um segmento", strings.Repeat("ã", 70), SMSEncodingUCS2, 70, 1},
+		{"ucs2 concatenado", strings.Repeat("ã", 71), SMSEncodingUCS2, 71, 2},
+		{"emoji ocupa par substituto", strings.Repeat("a", 66) + "😀", SMSEncodingUCS2, 68, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := AnalyzeSMS(tt.text)
+			if info.Encoding != tt.encoding || info.Units != tt.units || info.Segments != tt.segments {
+				t.Errorf("AnalyzeSMS() = %+v, want {%s %d %d}", info, tt.encoding, tt.units, tt.segments)
+			}
+		})
+	}
+}
+
+func TestTransliterateSMS(t *testing.T) {
+	got := TransliterateSMS("Audiência às 14h – ação nº 123")
+	if got != "Audiencia às 14h - acao no 123" {
+		t.Errorf("TransliterateSMS() = %q", got)
+	}
+	if AnalyzeSMS(got).Encoding != SMSEncodingGSM7 {
+		t.Errorf("transliterated text is not GSM-7: %q", got)
+	}
+}
+
+func TestHTTPSMSGatewayParseDeliveryReports(t *testing.T) {
+	gateway := NewHTTPSMSGateway(HTTPSMSGatewayConfig{BaseURL: "http://localhost"}, zap.NewNop())
+
+	payload := `[
+		{"message_id": "sms-1", "status": "delivered", "to": "+5511987654321", "timestamp": "2024-05-10T12:00:00Z"},
+		{"message_id": "sms-2", "status": "invalid_number", "to": "+5511987654320", "error_code": "21211"},
+		{"message_id": "sms-3", "status": "queued"}
+	]`
+
+	updates, err := gateway.ParseDeliveryReports([]byte(payload))
+	if err != nil {
+		t.Fatalf("ParseDeliveryReports() error = %v", err)
+	}
+	if len(updates) != 2 {
+		t.Fatalf("len(updates) = %d, want 2", len(updates))
+	}
+	if updates[0].Event != domain.DeliveryEventDelivered || updates[0].OccurredAt.IsZero() {
+		t.Errorf("updates[0] = %+v, want delivered with timestamp", updates[0])
+	}
+	if !updates[1].SuppressesContact() {
+		t.Errorf("invalid number should suppress the contact: %+v", updates[1])
+	}
+
+	single, err := gateway.ParseDeliveryReports([]byte(`{"message_id": "sms-4", "status": "failed"}`))
+	if err != nil || len(single) != 1 || single[0].Event != domain.DeliveryEventFailed {
+		t.Errorf("ParseDeliveryReports(single) = %v, %v", single, err)
+	}
+}
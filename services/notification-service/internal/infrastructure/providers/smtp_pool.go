@@ -0,0 +1,235 @@
+package providers
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"sync"
+	"time"
+)
+
+// smtpPool mantém conexões SMTP autenticadas para reuso entre envios. O número de conexões
+// abertas é limitado pelo tamanho do pool; conexões ociosas há mais tempo que o limite, que já
+// enviaram o máximo de mensagens ou que não respondem ao NOOP são descartadas.
+type smtpPool struct {
+	config      EmailConfig
+	slots       chan struct{}
+	idleTimeout time.Duration
+
+	mu     sync.Mutex
+	idle   []*smtpConn
+	closed bool
+}
+
+// smtpConn conexão do pool
+type smtpConn struct {
+	conn     net.Conn
+	client   *smtp.Client
+	messages int
+	lastUsed time.Time
+}
+
+// errSMTPPoolClosed pool encerrado no shutdown
+var errSMTPPoolClosed = errors.New("smtp pool closed")
+
+// newSMTPPool cria o pool com a configuração do provedor
+func newSMTPPool(config EmailConfig) *smtpPool {
+	return &smtpPool{
+		config:      config,
+		slots:       make(chan struct{}, config.PoolSize),
+		idleTimeout: time.Duration(config.PoolIdleTimeout) * time.Second,
+	}
+}
+
+// send envia a mensagem por uma conexão do pool. Erros de protocolo (ex.: destinatário
+// recusado) mantêm a conexão após o RSET; erros de rede a descartam.
+func (p *smtpPool) send(ctx context.Context, from, to string, message []byte) error {
+	select {
+	case p.slots <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-p.slots }()
+
+	c, err := p.get(ctx)
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(time.Duration(p.config.Timeout) * time.Second)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	c.conn.SetDeadline(deadline)
+
+	err = p.transmit(c.client, from, to, message)
+	c.messages++
+	p.put(c, err)
+	return err
+}
+
+// transmit executa a transação MAIL/RCPT/DATA
+func (p *smtpPool) transmit(client *smtp.Client, from, to string, message []byte) error {
+	if err := client.Mail(from); err != nil {
+		return fmt.Errorf("failed to set sender: %w", err)
+	}
+
+	if err := client.Rcpt(to); err != nil {
+		return fmt.Errorf("failed to set recipient: %w", err)
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to get data writer: %w", err)
+	}
+
+	if _, err := writer.Write(message); err != nil {
+		writer.Close()
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+
+	// O servidor só aceita a mensagem na resposta ao ponto final
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to finish message: %w", err)
+	}
+
+	return nil
+}
+
+// get reusa a conexão ociosa mais recente ou abre uma nova
+func (p *smtpPool) get(ctx context.Context) (*smtpConn, error) {
+	for {
+		p.mu.Lock()
+		if p.closed {
+			p.mu.Unlock()
+			return nil, errSMTPPoolClosed
+		}
+		if len(p.idle) == 0 {
+			p.mu.Unlock()
+			return p.dial(ctx)
+		}
+		c := p.idle[len(p.idle)-1]
+		p.idle = p.idle[:len(p.idle)-1]
+		p.mu.Unlock()
+
+		if time.Since(c.lastUsed) > p.idleTimeout {
+			p.discard(c)
+			continue
+		}
+
+		c.conn.SetDeadline(time.Now().Add(time.Duration(p.config.Timeout) * time.Second))
+		if err := c.client.Noop(); err != nil {
+			p.discard(c)
+			continue
+		}
+		return c, nil
+	}
+}
+
+// put devolve a conexão ao pool depois do envio
+func (p *smtpPool) put(c *smtpConn, sendErr error) {
+	var netErr net.Error
+	if sendErr != nil && (errors.As(sendErr, &netErr) || errors.Is(sendErr, net.ErrClosed)) {
+		c.conn.Close()
+		return
+	}
+
+	if c.messages >= p.config.MaxMessagesPerConn {
+		c.client.Quit()
+		return
+	}
+
+	// Transação com erro é desfeita antes do próximo envio
+	if sendErr != nil {
+		if err := c.client.Reset(); err != nil {
+			c.conn.Close()
+			return
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		c.client.Quit()
+		return
+	}
+	c.lastUsed = time.Now()
+	p.idle = append(p.idle, c)
+}
+
+// dial abre a conexão com TLS direto ou STARTTLS e autentica
+func (p *smtpPool) dial(ctx context.Context) (*smtpConn, error) {
+	addr := net.JoinHostPort(p.config.Host, fmt.Sprint(p.config.Port))
+	timeout := time.Duration(p.config.Timeout) * time.Second
+	dialer := &net.Dialer{Timeout: timeout}
+	tlsConfig := &tls.Config{
+		ServerName: p.config.Host,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	var conn net.Conn
+	var err error
+	if p.config.UseTLS {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect with TLS: %w", err)
+		}
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to SMTP server: %w", err)
+		}
+	}
+	conn.SetDeadline(time.Now().Add(timeout))
+
+	client, err := smtp.NewClient(conn, p.config.Host)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to create SMTP client: %w", err)
+	}
+
+	if err := client.Hello(p.config.HeloDomain); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to send EHLO: %w", err)
+	}
+
+	if p.config.UseStartTLS && !p.config.UseTLS {
+		if err := client.StartTLS(tlsConfig); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+
+	if p.config.Username != "" {
+		auth := smtp.PlainAuth("", p.config.Username, p.config.Password, p.config.Host)
+		if err := client.Auth(auth); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("failed to authenticate: %w", err)
+		}
+	}
+
+	return &smtpConn{conn: conn, client: client, lastUsed: time.Now()}, nil
+}
+
+// discard fecha a conexão ociosa
+func (p *smtpPool) discard(c *smtpConn) {
+	c.conn.SetDeadline(time.Now().Add(time.Second))
+	c.client.Quit()
+	c.conn.Close()
+}
+
+// Close encerra as conexões ociosas; as que estão em uso são fechadas ao serem devolvidas
+func (p *smtpPool) Close() {
+	p.mu.Lock()
+	idle := p.idle
+	p.idle = nil
+	p.closed = true
+	p.mu.Unlock()
+
+	for _, c := range idle {
+		p.discard(c)
+	}
+}
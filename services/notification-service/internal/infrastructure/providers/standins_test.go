@@ -0,0 +1,257 @@
+package providers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// Stand-ins locais das APIs dos provedores e notificações de teste compartilhados pelos testes
+// do pacote
+
+// newHTTPStandIn sobe a API local do provedor, encerrada ao fim do teste
+func newHTTPStandIn(t *testing.T, handler http.Handler) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newTestNotification notificação do canal para o contato
+func newTestNotification(channel domain.NotificationChannel, contact, content string) *domain.Notification {
+	return &domain.Notification{
+		ID:               uuid.New(),
+		Channel:          channel,
+		RecipientContact: contact,
+		Content:          content,
+	}
+}
+
+// newSMSStandIn gateway local que registra as mensagens recebidas
+func newSMSStandIn(t *testing.T, handler http.HandlerFunc) (*SMSProvider, *httptest.Server) {
+	t.Helper()
+	server := newHTTPStandIn(t, handler)
+
+	gateway := NewHTTPSMSGateway(HTTPSMSGatewayConfig{BaseURL: server.URL, APIKey: "test-key"}, zap.NewNop())
+	provider := NewSMSProvider(SMSConfig{
+		SenderID:      "DireitoLux",
+		MaxSegments:   2,
+		Transliterate: true,
+		OptOutHint:    "Responda SAIR para cancelar.",
+	}, gateway, zap.NewNop())
+	return provider, server
+}
+
+func newSMSNotification(contact, content string) *domain.Notification {
+	return newTestNotification(domain.NotificationChannelSMS, contact, content)
+}
+
+// memoryWhatsAppSessions janela de atendimento em memória
+type memoryWhatsAppSessions map[string]time.Time
+
+func (m memoryWhatsAppSessions) RecordInbound(ctx context.Context, contact string, at time.Time) error {
+	m[contact] = at
+	return nil
+}
+
+func (m memoryWhatsAppSessions) GetLastInbound(ctx context.Context, contact string) (*time.Time, error) {
+	if at, ok := m[contact]; ok {
+		return &at, nil
+	}
+	return nil, nil
+}
+
+// memoryWhatsAppTemplates mapeamentos em memória
+type memoryWhatsAppTemplates struct {
+	domain.WhatsAppTemplateRepository
+	mappings []*domain.WhatsAppTemplateMapping
+}
+
+func (m *memoryWhatsAppTemplates) FindByTemplate(ctx context.Context, templateID uuid.UUID) ([]*domain.WhatsAppTemplateMapping, error) {
+	var found []*domain.WhatsAppTemplateMapping
+	for _, mapping := range m.mappings {
+		if mapping.TemplateID == templateID {
+			found = append(found, mapping)
+		}
+	}
+	return found, nil
+}
+
+// newWhatsAppStandIn Cloud API local que registra as mensagens enviadas
+func newWhatsAppStandIn(t *testing.T, sessions memoryWhatsAppSessions, templates *memoryWhatsAppTemplates) (*WhatsAppProvider, *[]WhatsAppMessage) {
+	t.Helper()
+	var sent []WhatsAppMessage
+	server := newHTTPStandIn(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var message WhatsAppMessage
+		if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		sent = append(sent, message)
+		json.NewEncoder(w).Encode(WhatsAppResponse{Messages: []WhatsAppMessageResponse{{ID: "wamid.1"}}})
+	}))
+
+	provider := NewWhatsAppProvider(WhatsAppConfig{
+		AccessToken:   "EAA-test",
+		PhoneNumberID: "123",
+		BaseURL:       server.URL,
+	}, sessions, templates, zap.NewNop())
+	return provider, &sent
+}
+
+func newWhatsAppNotification(templateID uuid.UUID) *domain.Notification {
+	notification := newTestNotification(domain.NotificationChannelWhatsApp, "+55 11 98765-4321", "Olá Maria, nova movimentação no processo.")
+	notification.TemplateID = &templateID
+	notification.Variables = map[string]interface{}{"nome": "Maria", "numero": "12345678920248260001"}
+	return notification
+}
+
+// newTelegramStandIn Bot API local que registra método e payload das chamadas
+func newTelegramStandIn(t *testing.T, handler func(w http.ResponseWriter, method string, payload map[string]interface{})) *TelegramProvider {
+	t.Helper()
+	server := newHTTPStandIn(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		handler(w, r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:], payload)
+	}))
+
+	return NewTelegramProvider(TelegramConfig{BotToken: "123:abc", BaseURL: server.URL}, zap.NewNop())
+}
+
+// smtpStandIn servidor SMTP local que registra as mensagens e as conexões recebidas
+type smtpStandIn struct {
+	listener net.Listener
+	reject   string // destinatário recusado com 550
+
+	mu          sync.Mutex
+	messages    [][]byte
+	connections int
+	authed      int
+}
+
+func newSMTPStandIn(t *testing.T) *smtpStandIn {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	server := &smtpStandIn{listener: listener}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			server.mu.Lock()
+			server.connections++
+			server.mu.Unlock()
+			go server.serve(conn)
+		}
+	}()
+	return server
+}
+
+func (s *smtpStandIn) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+
+	reply("220 localhost ESMTP")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(strings.TrimSpace(line))
+
+		switch {
+		case strings.HasPrefix(command, "EHLO"):
+			reply("250-localhost")
+			reply("250 AUTH PLAIN")
+		case strings.HasPrefix(command, "AUTH PLAIN"):
+			s.mu.Lock()
+			s.authed++
+			s.mu.Unlock()
+			reply("235 2.7.0 Authentication successful")
+		case strings.HasPrefix(command, "MAIL FROM"), command == "RSET", command == "NOOP":
+			reply("250 OK")
+		case strings.HasPrefix(command, "RCPT TO"):
+			if s.reject != "" && strings.Contains(strings.ToLower(line), s.reject) {
+				reply("550 5.1.1 User unknown")
+				continue
+			}
+			reply("250 OK")
+		case command == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var message bytes.Buffer
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				message.WriteString(strings.TrimPrefix(dataLine, "."))
+			}
+			s.mu.Lock()
+			s.messages = append(s.messages, message.Bytes())
+			s.mu.Unlock()
+			reply("250 OK queued")
+		case command == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func (s *smtpStandIn) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *smtpStandIn) stats() (messages [][]byte, connections int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([][]byte(nil), s.messages...), s.connections
+}
+
+func newTestEmailProvider(server *smtpStandIn, config EmailConfig, senders domain.EmailSenderDomainRepository) *EmailProvider {
+	config.Host = "127.0.0.1"
+	config.Port = server.port()
+	config.Username = "user"
+	config.Password = "secret"
+	config.FromEmail = "noreply@direitolux.com.br"
+	config.FromName = "Direito Lux"
+	config.Timeout = 5
+	return NewEmailProvider(config, senders, zap.NewNop())
+}
+
+func newEmailNotification(contact string) *domain.Notification {
+	contentHTML := `<h1>Olá, Ana</h1><p>Nova movimentação no <a href="https://app.direitolux.com.br/p/1">processo</a>.</p>`
+	notification := newTestNotification(domain.NotificationChannelEmail, contact, "Olá, Ana\n\nNova movimentação no processo.")
+	notification.TenantID = uuid.New()
+	notification.Subject = "Movimentação: decisão publicada"
+	notification.ContentHTML = &contentHTML
+	return notification
+}
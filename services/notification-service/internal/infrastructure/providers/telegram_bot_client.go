@@ -0,0 +1,111 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// telegramReplyMessage resposta do bot com teclado inline; o chat é sempre o ID numérico em texto
+type telegramReplyMessage struct {
+	ChatID                string                  `json:"chat_id"`
+	Text                  string                  `json:"text"`
+	ParseMode             string                  `json:"parse_mode"`
+	DisableWebPagePreview bool                    `json:"disable_web_page_preview"`
+	ReplyMarkup           *TelegramInlineKeyboard `json:"reply_markup,omitempty"`
+}
+
+// telegramMethodResponse resposta genérica da Bot API (result varia conforme o método)
+type telegramMethodResponse struct {
+	OK          bool                        `json:"ok"`
+	ErrorCode   int                         `json:"error_code,omitempty"`
+	Description string                      `json:"description,omitempty"`
+	Parameters  *TelegramResponseParameters `json:"parameters,omitempty"`
+}
+
+// SendReply responde a conversa do bot (domain.TelegramBotClient). Respostas do bot são sempre HTML.
+func (p *TelegramProvider) SendReply(ctx context.Context, chatID string, reply *domain.TelegramBotReply) error {
+	message := telegramReplyMessage{
+		ChatID:                chatID,
+		Text:                  reply.Text,
+		ParseMode:             "HTML",
+		DisableWebPagePreview: true,
+	}
+
+	if len(reply.Keyboard) > 0 {
+		keyboard := &TelegramInlineKeyboard{InlineKeyboard: make([][]TelegramInlineKeyboardButton, 0, len(reply.Keyboard))}
+		for _, row := range reply.Keyboard {
+			buttons := make([]TelegramInlineKeyboardButton, 0, len(row))
+			for _, button := range row {
+				buttons = append(buttons, TelegramInlineKeyboardButton{
+					Text:         button.Text,
+					CallbackData: button.CallbackData,
+					URL:          button.URL,
+				})
+			}
+			keyboard.InlineKeyboard = append(keyboard.InlineKeyboard, buttons)
+		}
+		message.ReplyMarkup = keyboard
+	}
+
+	return p.callMethod(ctx, "sendMessage", message)
+}
+
+// AnswerCallback confirma o clique no botão inline (domain.TelegramBotClient)
+func (p *TelegramProvider) AnswerCallback(ctx context.Context, callbackID, text string) error {
+	payload := map[string]interface{}{
+		"callback_query_id": callbackID,
+	}
+	if text != "" {
+		payload["text"] = text
+	}
+
+	return p.callMethod(ctx, "answerCallbackQuery", payload)
+}
+
+// callMethod chama um método da Bot API que não precisa do resultado
+func (p *TelegramProvider) callMethod(ctx context.Context, method string, payload interface{}) error {
+	url := fmt.Sprintf("%s/bot%s/%s", p.config.BaseURL, p.config.BotToken, method)
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s payload: %w", method, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var response telegramMethodResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if response.ErrorCode == http.StatusTooManyRequests {
+		retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
+		if response.Parameters != nil && response.Parameters.RetryAfter > 0 {
+			retryAfter = time.Duration(response.Parameters.RetryAfter) * time.Second
+		}
+		return domain.NewRateLimitError(domain.RateLimitScopeUpstream, retryAfter)
+	}
+
+	if !response.OK {
+		return fmt.Errorf("Telegram API error: %s (%d)", response.Description, response.ErrorCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+func TestTelegramProviderSendReply(t *testing.T) {
+	var method string
+	var sent map[string]interface{}
+	provider := newTelegramStandIn(t, func(w http.ResponseWriter, m string, payload map[string]interface{}) {
+		method, sent = m, payload
+		w.Write([]byte(`{"ok":true,"result":{"message_id":1,"chat":{"id":42,"type":"private"}}}`))
+	})
+
+	err := provider.SendReply(context.Background(), "42", &domain.TelegramBotReply{
+		Text: "<b>Prazos</b>",
+		Keyboard: [][]domain.TelegramButton{
+			{{Text: "7 dias", CallbackData: "agenda:7"}, {Text: "15 dias", CallbackData: "agenda:15"}},
+			{{Text: "Abrir", URL: "https://app.direitolux.com.br/processos"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("SendReply() error = %v", err)
+	}
+
+	if method != "sendMessage" || sent["chat_id"] != "42" || sent["parse_mode"] != "HTML" {
+		t.Errorf("sendMessage payload = %v (%s)", sent, method)
+	}
+	keyboard := sent["reply_markup"].(map[string]interface{})["inline_keyboard"].([]interface{})
+	first := keyboard[0].([]interface{})[1].(map[string]interface{})
+	link := keyboard[1].([]interface{})[0].(map[string]interface{})
+	if len(keyboard) != 2 || first["callback_data"] != "agenda:15" || link["url"] == nil || link["callback_data"] != nil {
+		t.Errorf("inline_keyboard = %v", keyboard)
+	}
+}
+
+func TestTelegramProviderAnswerCallbackFloodControl(t *testing.T) {
+	provider := newTelegramStandIn(t, func(w http.ResponseWriter, method string, payload map[string]interface{}) {
+		if method != "answerCallbackQuery" || payload["callback_query_id"] != "cb-1" {
+			t.Errorf("answerCallbackQuery payload = %v (%s)", payload, method)
+		}
+		w.Write([]byte(`{"ok":false,"error_code":429,"description":"Too Many Requests","parameters":{"retry_after":3}}`))
+	})
+
+	err := provider.AnswerCallback(context.Background(), "cb-1", "")
+	var rateLimitErr *domain.RateLimitError
+	if !errors.As(err, &rateLimitErr) || rateLimitErr.RetryAfter != 3*time.Second {
+		t.Errorf("AnswerCallback() error = %v, want RateLimitError with retry_after", err)
+	}
+}
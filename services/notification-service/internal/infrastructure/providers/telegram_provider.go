@@ -236,6 +236,12 @@ func (p *TelegramProvider) GetRateLimit() int {
 	return p.config.RateLimit
 }
 
+// ConfirmsDeliveryOnSend indica se o envio aceito já confirma a entrega.
+// A Bot API não envia recibos: a resposta de sendMessage confirma a entrega no chat.
+func (p *TelegramProvider) ConfirmsDeliveryOnSend() bool {
+	return true
+}
+
 // validateChatID valida o formato do chat ID
 func (p *TelegramProvider) validateChatID(chatID string) (int64, error) {
 	// Tentar converter para int64
@@ -259,6 +265,11 @@ func (p *TelegramProvider) buildMessage(chatID int64, notification *domain.Notif
 		ParseMode: p.config.ParseMode,
 	}
 
+	// Conteúdo de template define o próprio parse_mode (valores escapados para MarkdownV2)
+	if parseMode, ok := notification.Metadata["parse_mode"].(string); ok && parseMode != "" {
+		message.ParseMode = parseMode
+	}
+
 	// Se for username, usar string
 	if chatID == 0 {
 		message.ChatIDString = notification.RecipientContact
@@ -294,6 +305,15 @@ func (p *TelegramProvider) sendMessage(ctx context.Context, message *TelegramMes
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
+	// Flood control: Telegram informa a espera em parameters.retry_after
+	if response.ErrorCode == http.StatusTooManyRequests {
+		retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
+		if response.Parameters != nil && response.Parameters.RetryAfter > 0 {
+			retryAfter = time.Duration(response.Parameters.RetryAfter) * time.Second
+		}
+		return nil, domain.NewRateLimitError(domain.RateLimitScopeUpstream, retryAfter)
+	}
+
 	if !response.OK {
 		return nil, fmt.Errorf("Telegram API error: %s (%d)", response.Description, response.ErrorCode)
 	}
@@ -398,10 +418,16 @@ type TelegramSentMessage struct {
 
 // TelegramResponse resposta da API
 type TelegramResponse struct {
-	OK          bool                  `json:"ok"`
-	Result      *TelegramSentMessage  `json:"result,omitempty"`
-	ErrorCode   int                   `json:"error_code,omitempty"`
-	Description string                `json:"description,omitempty"`
+	OK          bool                        `json:"ok"`
+	Result      *TelegramSentMessage        `json:"result,omitempty"`
+	ErrorCode   int                         `json:"error_code,omitempty"`
+	Description string                      `json:"description,omitempty"`
+	Parameters  *TelegramResponseParameters `json:"parameters,omitempty"`
+}
+
+// TelegramResponseParameters parâmetros adicionais de erro
+type TelegramResponseParameters struct {
+	RetryAfter int `json:"retry_after,omitempty"`
 }
 
 // TelegramUpdate update do webhook
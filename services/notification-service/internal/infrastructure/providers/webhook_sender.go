@@ -0,0 +1,114 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+	"github.com/direito-lux/notification-service/internal/infrastructure/config"
+)
+
+// webhookUserAgent identifica as entregas para o destino
+const webhookUserAgent = "DireitoLux-Webhooks/1.0"
+
+// errPrivateWebhookAddress conexão recusada para endereço local ou privado
+var errPrivateWebhookAddress = errors.New("webhook address resolves to a private network")
+
+// HTTPWebhookSender envia as entregas dos webhooks por POST assinado (domain.WebhookSender)
+type HTTPWebhookSender struct {
+	client *http.Client
+	logger *zap.Logger
+}
+
+// NewHTTPWebhookSender cria nova instância do sender. Fora do ambiente de desenvolvimento a
+// conexão é recusada quando o nome resolve para endereço local ou privado, o que a validação
+// da URL não consegue garantir sozinha.
+func NewHTTPWebhookSender(cfg config.WebhooksConfig, logger *zap.Logger) *HTTPWebhookSender {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	dialer := &net.Dialer{Timeout: timeout}
+	if !cfg.AllowInsecureURLs {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || domain.IsPrivateWebhookAddress(ip) {
+				return errPrivateWebhookAddress
+			}
+			return nil
+		}
+	}
+
+	transport := &http.Transport{
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		TLSHandshakeTimeout:   timeout,
+		ResponseHeaderTimeout: timeout,
+		MaxIdleConnsPerHost:   2,
+		IdleConnTimeout:       90 * time.Second,
+	}
+
+	return &HTTPWebhookSender{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+			// Redirecionamentos não são seguidos: o destino deve responder na URL cadastrada
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		logger: logger,
+	}
+}
+
+// Send envia a entrega e retorna o resultado da tentativa; falhas de rede ficam em Error
+func (s *HTTPWebhookSender) Send(ctx context.Context, url, secret string, delivery *domain.WebhookDelivery) domain.WebhookAttemptResult {
+	started := time.Now()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return domain.WebhookAttemptResult{Error: fmt.Sprintf("failed to create request: %v", err)}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", webhookUserAgent)
+	req.Header.Set(domain.WebhookEventHeader, delivery.EventType)
+	req.Header.Set(domain.WebhookDeliveryHeader, delivery.ID.String())
+	req.Header.Set(domain.WebhookSignatureHeader, domain.SignWebhookPayload(secret, started, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		s.logger.Debug("Webhook delivery attempt failed",
+			zap.String("delivery_id", delivery.ID.String()),
+			zap.Error(err))
+		return domain.WebhookAttemptResult{
+			Error:    err.Error(),
+			Duration: time.Since(started),
+		}
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+
+	result := domain.WebhookAttemptResult{
+		StatusCode: resp.StatusCode,
+		Body:       string(body),
+		Duration:   time.Since(started),
+	}
+	if !result.Succeeded() {
+		result.Error = fmt.Sprintf("webhook endpoint returned status %d", resp.StatusCode)
+	}
+	return result
+}
@@ -0,0 +1,95 @@
+package providers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+	"github.com/direito-lux/notification-service/internal/infrastructure/config"
+)
+
+func newWebhookDelivery() *domain.WebhookDelivery {
+	return &domain.WebhookDelivery{
+		ID:        uuid.New(),
+		EventType: domain.WebhookEventMovementCreated,
+		Payload:   []byte(`{"type":"movement.created","data":{"title":"Juntada de petição"}}`),
+	}
+}
+
+func TestHTTPWebhookSenderSignsDelivery(t *testing.T) {
+	const secret = "whsec_test"
+	delivery := newWebhookDelivery()
+
+	var signature, event, deliveryID string
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signature = r.Header.Get(domain.WebhookSignatureHeader)
+		event = r.Header.Get(domain.WebhookEventHeader)
+		deliveryID = r.Header.Get(domain.WebhookDeliveryHeader)
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sender := NewHTTPWebhookSender(config.WebhooksConfig{Timeout: 5 * time.Second, AllowInsecureURLs: true}, zap.NewNop())
+	result := sender.Send(context.Background(), server.URL, secret, delivery)
+
+	if !result.Succeeded() {
+		t.Fatalf("Send() = %+v, want success", result)
+	}
+	if event != delivery.EventType || deliveryID != delivery.ID.String() {
+		t.Errorf("headers event=%q delivery=%q", event, deliveryID)
+	}
+	if string(body) != string(delivery.Payload) {
+		t.Errorf("body = %s", body)
+	}
+
+	// A assinatura confere com o timestamp informado no próprio cabeçalho
+	parts := strings.SplitN(signature, ",", 2)
+	unix, err := strconv.ParseInt(strings.TrimPrefix(parts[0], "t="), 10, 64)
+	if err != nil {
+		t.Fatalf("signature = %q", signature)
+	}
+	if want := domain.SignWebhookPayload(secret, time.Unix(unix, 0), body); signature != want {
+		t.Errorf("signature = %q, want %q", signature, want)
+	}
+}
+
+func TestHTTPWebhookSenderCapturesFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("indisponível"))
+	}))
+	defer server.Close()
+
+	sender := NewHTTPWebhookSender(config.WebhooksConfig{Timeout: 5 * time.Second, AllowInsecureURLs: true}, zap.NewNop())
+	result := sender.Send(context.Background(), server.URL, "whsec_test", newWebhookDelivery())
+
+	if result.Succeeded() || result.StatusCode != http.StatusInternalServerError || result.Body != "indisponível" {
+		t.Errorf("Send() = %+v, want status 500 captured", result)
+	}
+}
+
+func TestHTTPWebhookSenderBlocksPrivateAddress(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	sender := NewHTTPWebhookSender(config.WebhooksConfig{Timeout: 5 * time.Second}, zap.NewNop())
+	result := sender.Send(context.Background(), server.URL, "whsec_test", newWebhookDelivery())
+
+	if called || result.Succeeded() || !strings.Contains(result.Error, "private network") {
+		t.Errorf("Send() = %+v, want connection refused for loopback", result)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -16,9 +17,11 @@ import (
 
 // WhatsAppProvider implementação do provedor WhatsApp Business API
 type WhatsAppProvider struct {
-	config WhatsAppConfig
-	client *http.Client
-	logger *zap.Logger
+	config    WhatsAppConfig
+	client    *http.Client
+	sessions  domain.WhatsAppSessionRepository
+	templates domain.WhatsAppTemplateRepository
+	logger    *zap.Logger
 }
 
 // WhatsAppConfig configuração do provedor WhatsApp
@@ -34,8 +37,9 @@ type WhatsAppConfig struct {
 	RateLimit     int    `json:"rate_limit"` // mensagens por minuto
 }
 
-// NewWhatsAppProvider cria nova instância do provedor WhatsApp
-func NewWhatsAppProvider(config WhatsAppConfig, logger *zap.Logger) *WhatsAppProvider {
+// NewWhatsAppProvider cria nova instância do provedor WhatsApp. Sem repositório de sessões,
+// todas as mensagens são enviadas como texto livre (comportamento anterior à janela de 24 horas).
+func NewWhatsAppProvider(config WhatsAppConfig, sessions domain.WhatsAppSessionRepository, templates domain.WhatsAppTemplateRepository, logger *zap.Logger) *WhatsAppProvider {
 	// Valores padrão
 	if config.APIVersion == "" {
 		config.APIVersion = "v18.0"
@@ -58,9 +62,11 @@ func NewWhatsAppProvider(config WhatsAppConfig, logger *zap.Logger) *WhatsAppPro
 	}
 
 	return &WhatsAppProvider{
-		config: config,
-		client: client,
-		logger: logger,
+		config:    config,
+		client:    client,
+		sessions:  sessions,
+		templates: templates,
+		logger:    logger,
 	}
 }
 
@@ -76,7 +82,7 @@ func (p *WhatsAppProvider) Send(ctx context.Context, notification *domain.Notifi
 	}
 
 	// Construir mensagem
-	message, err := p.buildMessage(notification)
+	message, err := p.buildMessage(ctx, notification)
 	if err != nil {
 		return fmt.Errorf("failed to build message: %w", err)
 	}
@@ -215,6 +221,12 @@ func (p *WhatsAppProvider) GetRateLimit() int {
 	return p.config.RateLimit
 }
 
+// ConfirmsDeliveryOnSend indica se o envio aceito já confirma a entrega.
+// Entrega e leitura são confirmadas pelos webhooks de status.
+func (p *WhatsAppProvider) ConfirmsDeliveryOnSend() bool {
+	return false
+}
+
 // validatePhoneNumber valida o formato do número de telefone
 func (p *WhatsAppProvider) validatePhoneNumber(phoneNumber string) error {
 	// Remover caracteres não numéricos
@@ -237,8 +249,9 @@ func (p *WhatsAppProvider) validatePhoneNumber(phoneNumber string) error {
 	return nil
 }
 
-// buildMessage constrói a mensagem para o WhatsApp API
-func (p *WhatsAppProvider) buildMessage(notification *domain.Notification) (*WhatsAppMessage, error) {
+// buildMessage constrói a mensagem para o WhatsApp API: texto livre dentro da janela de
+// atendimento de 24 horas e template aprovado fora dela
+func (p *WhatsAppProvider) buildMessage(ctx context.Context, notification *domain.Notification) (*WhatsAppMessage, error) {
 	// Limpar número de telefone
 	phoneNumber := strings.ReplaceAll(notification.RecipientContact, "+", "")
 	phoneNumber = strings.ReplaceAll(phoneNumber, " ", "")
@@ -247,15 +260,113 @@ func (p *WhatsAppProvider) buildMessage(notification *domain.Notification) (*Wha
 	message := &WhatsAppMessage{
 		MessagingProduct: "whatsapp",
 		To:               phoneNumber,
-		Type:             "text",
-		Text: &WhatsAppText{
+	}
+
+	open, err := p.sessionOpen(ctx, notification.RecipientContact)
+	if err != nil {
+		return nil, err
+	}
+	if open {
+		message.Type = "text"
+		message.Text = &WhatsAppText{
 			Body: notification.Content,
-		},
+		}
+		return message, nil
+	}
+
+	template, err := p.buildTemplate(ctx, notification)
+	if err != nil {
+		return nil, err
 	}
 
+	message.Type = "template"
+	message.Template = template
 	return message, nil
 }
 
+// SendText responde o contato com texto livre; usado apenas em resposta a uma mensagem
+// recebida, portanto dentro da janela de atendimento
+func (p *WhatsAppProvider) SendText(ctx context.Context, contact, text string) error {
+	if err := p.validatePhoneNumber(contact); err != nil {
+		return fmt.Errorf("invalid recipient phone number: %w", err)
+	}
+
+	phoneNumber := strings.ReplaceAll(contact, "+", "")
+	phoneNumber = strings.ReplaceAll(phoneNumber, " ", "")
+	phoneNumber = strings.ReplaceAll(phoneNumber, "-", "")
+
+	message := &WhatsAppMessage{
+		MessagingProduct: "whatsapp",
+		To:               phoneNumber,
+		Type:             "text",
+		Text:             &WhatsAppText{Body: text},
+	}
+
+	if _, err := p.sendMessage(ctx, message); err != nil {
+		return fmt.Errorf("failed to send WhatsApp message: %w", err)
+	}
+	return nil
+}
+
+// sessionOpen verifica se o contato enviou mensagem nas últimas 24 horas
+func (p *WhatsAppProvider) sessionOpen(ctx context.Context, contact string) (bool, error) {
+	if p.sessions == nil {
+		return true, nil
+	}
+
+	lastInbound, err := p.sessions.GetLastInbound(ctx, domain.NormalizeWhatsAppContact(contact))
+	if err != nil {
+		return false, fmt.Errorf("failed to get whatsapp session: %w", err)
+	}
+	return domain.WhatsAppSessionOpen(lastInbound, time.Now()), nil
+}
+
+// buildTemplate monta a mensagem de template aprovado mapeado ao template da notificação
+func (p *WhatsAppProvider) buildTemplate(ctx context.Context, notification *domain.Notification) (*WhatsAppTemplate, error) {
+	if notification.TemplateID == nil || p.templates == nil {
+		return nil, fmt.Errorf("%w: notification has no template", domain.ErrWhatsAppTemplateRequired)
+	}
+
+	mappings, err := p.templates.FindByTemplate(ctx, *notification.TemplateID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find whatsapp templates: %w", err)
+	}
+
+	locale := notification.Locale
+	if locale == "" {
+		locale = domain.DefaultLocale
+	}
+
+	mapping := domain.SelectWhatsAppTemplate(mappings, locale)
+	if mapping == nil {
+		return nil, fmt.Errorf("%w: template %s", domain.ErrWhatsAppTemplateRequired, notification.TemplateID.String())
+	}
+
+	values, err := mapping.RenderParameters(notification.Variables)
+	if err != nil {
+		return nil, err
+	}
+
+	template := &WhatsAppTemplate{
+		Name:     mapping.Name,
+		Language: WhatsAppLanguage{Code: mapping.Language},
+	}
+	if len(values) > 0 {
+		body := WhatsAppComponent{Type: "body"}
+		for _, value := range values {
+			body.Parameters = append(body.Parameters, WhatsAppParameter{Type: "text", Text: value})
+		}
+		template.Components = []WhatsAppComponent{body}
+	}
+
+	p.logger.Debug("WhatsApp session window closed, sending approved template",
+		zap.String("notification_id", notification.ID.String()),
+		zap.String("template_name", mapping.Name),
+		zap.String("language", mapping.Language))
+
+	return template, nil
+}
+
 // sendMessage envia a mensagem via WhatsApp API
 func (p *WhatsAppProvider) sendMessage(ctx context.Context, message *WhatsAppMessage) (*WhatsAppResponse, error) {
 	url := fmt.Sprintf("%s/%s/%s/messages", p.config.BaseURL, p.config.APIVersion, p.config.PhoneNumberID)
@@ -280,10 +391,15 @@ func (p *WhatsAppProvider) sendMessage(ctx context.Context, message *WhatsAppMes
 	defer resp.Body.Close()
 
 	var response WhatsAppResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil && resp.StatusCode != http.StatusTooManyRequests {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
+	// Limite de throughput da Cloud API: HTTP 429 ou códigos de rate limit no corpo
+	if resp.StatusCode == http.StatusTooManyRequests || (response.Error != nil && isWhatsAppRateLimitCode(response.Error.Code)) {
+		return nil, domain.NewRateLimitError(domain.RateLimitScopeUpstream, parseRetryAfter(resp.Header.Get("Retry-After")))
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		if response.Error != nil {
 			return nil, fmt.Errorf("WhatsApp API error: %s (%d)", response.Error.Message, response.Error.Code)
@@ -294,6 +410,16 @@ func (p *WhatsAppProvider) sendMessage(ctx context.Context, message *WhatsAppMes
 	return &response, nil
 }
 
+// isWhatsAppRateLimitCode verifica códigos de erro de limite de taxa da Cloud API
+func isWhatsAppRateLimitCode(code int) bool {
+	switch code {
+	case 4, 80007, 130429, 131056: // app, WABA, throughput e par remetente/destinatário
+		return true
+	default:
+		return false
+	}
+}
+
 // Estruturas para a API do WhatsApp
 
 // WhatsAppMessage estrutura da mensagem
@@ -364,6 +490,8 @@ func (p *WhatsAppProvider) HandleWebhook(ctx context.Context, payload []byte) er
 	// Processar cada entrada no webhook
 	for _, entry := range webhook.Entry {
 		for _, change := range entry.Changes {
+			// Mensagens recebidas abrem a janela de atendimento; são registradas pelo
+			// WhatsAppTemplateService a partir de ParseWhatsAppInboundMessages
 			if change.Value.Messages != nil {
 				for _, message := range change.Value.Messages {
 					p.logger.Info("Received WhatsApp message", 
@@ -372,15 +500,12 @@ func (p *WhatsAppProvider) HandleWebhook(ctx context.Context, payload []byte) er
 				}
 			}
 
-			if change.Value.Statuses != nil {
-				for _, status := range change.Value.Statuses {
-					p.logger.Info("Received WhatsApp status update", 
-						zap.String("message_id", status.ID),
-						zap.String("status", status.Status))
-					
-					// Aqui seria atualizado o status da notificação no banco de dados
-					// baseado no status recebido (sent, delivered, read, failed)
-				}
+			// Status (sent, delivered, read, failed) são aplicados pelo DeliveryStatusService
+			// a partir de ParseWhatsAppStatusUpdates
+			for _, status := range change.Value.Statuses {
+				p.logger.Debug("Received WhatsApp status update", 
+					zap.String("message_id", status.ID),
+					zap.String("status", status.Status))
 			}
 		}
 	}
@@ -408,24 +533,151 @@ type WhatsAppWebhookChange struct {
 	Field string               `json:"field"`
 }
 
-// WhatsAppWebhookValue valor do webhook
+// WhatsAppWebhookValue valor do webhook. Os campos de template vêm no evento
+// message_template_status_update.
 type WhatsAppWebhookValue struct {
 	Messages []WhatsAppWebhookMessage `json:"messages,omitempty"`
 	Statuses []WhatsAppWebhookStatus  `json:"statuses,omitempty"`
+
+	Event                   string `json:"event,omitempty"`
+	MessageTemplateName     string `json:"message_template_name,omitempty"`
+	MessageTemplateLanguage string `json:"message_template_language,omitempty"`
+	Reason                  string `json:"reason,omitempty"`
 }
 
 // WhatsAppWebhookMessage mensagem do webhook
 type WhatsAppWebhookMessage struct {
-	ID   string `json:"id"`
-	From string `json:"from"`
-	Type string `json:"type"`
-	Text struct {
+	ID        string `json:"id"`
+	From      string `json:"from"`
+	Timestamp string `json:"timestamp"` // unix em segundos
+	Type      string `json:"type"`
+	Text      struct {
 		Body string `json:"body"`
 	} `json:"text,omitempty"`
 }
 
 // WhatsAppWebhookStatus status do webhook
 type WhatsAppWebhookStatus struct {
-	ID     string `json:"id"`
-	Status string `json:"status"`
-}
\ No newline at end of file
+	ID          string          `json:"id"`
+	Status      string          `json:"status"`
+	Timestamp   string          `json:"timestamp"` // unix em segundos
+	RecipientID string          `json:"recipient_id"`
+	Errors      []WhatsAppError `json:"errors,omitempty"`
+}
+
+// whatsAppDeliveryEvents status do WhatsApp suportados
+var whatsAppDeliveryEvents = map[string]domain.DeliveryEvent{
+	"sent":      domain.DeliveryEventSent,
+	"delivered": domain.DeliveryEventDelivered,
+	"read":      domain.DeliveryEventRead,
+	"failed":    domain.DeliveryEventFailed,
+}
+
+// ParseWhatsAppStatusUpdates extrai as atualizações de status de um webhook do WhatsApp
+func ParseWhatsAppStatusUpdates(payload []byte) ([]*domain.DeliveryStatusUpdate, error) {
+	var webhook WhatsAppWebhook
+	if err := json.Unmarshal(payload, &webhook); err != nil {
+		return nil, fmt.Errorf("failed to parse webhook: %w", err)
+	}
+
+	var updates []*domain.DeliveryStatusUpdate
+	for _, entry := range webhook.Entry {
+		for _, change := range entry.Changes {
+			for _, status := range change.Value.Statuses {
+				event, ok := whatsAppDeliveryEvents[status.Status]
+				if !ok || status.ID == "" {
+					continue
+				}
+
+				update := &domain.DeliveryStatusUpdate{
+					Channel:    domain.NotificationChannelWhatsApp,
+					ExternalID: status.ID,
+					Event:      event,
+					Recipient:  status.RecipientID,
+				}
+
+				if seconds, err := strconv.ParseInt(status.Timestamp, 10, 64); err == nil {
+					update.OccurredAt = time.Unix(seconds, 0)
+				}
+
+				if len(status.Errors) > 0 {
+					update.ErrorCode = strconv.Itoa(status.Errors[0].Code)
+					update.Reason = status.Errors[0].Message
+				}
+
+				updates = append(updates, update)
+			}
+		}
+	}
+
+	return updates, nil
+}
+
+// WhatsAppInboundMessage mensagem recebida do contato, que abre a janela de atendimento
+type WhatsAppInboundMessage struct {
+	From       string
+	Text       string // apenas mensagens de texto; vazio para mídia, localização etc.
+	ReceivedAt time.Time
+}
+
+// ParseWhatsAppInboundMessages extrai as mensagens recebidas de um webhook do WhatsApp
+func ParseWhatsAppInboundMessages(payload []byte) ([]WhatsAppInboundMessage, error) {
+	var webhook WhatsAppWebhook
+	if err := json.Unmarshal(payload, &webhook); err != nil {
+		return nil, fmt.Errorf("failed to parse webhook: %w", err)
+	}
+
+	var messages []WhatsAppInboundMessage
+	for _, entry := range webhook.Entry {
+		for _, change := range entry.Changes {
+			for _, message := range change.Value.Messages {
+				if message.From == "" {
+					continue
+				}
+
+				inbound := WhatsAppInboundMessage{From: message.From, ReceivedAt: time.Now()}
+				if message.Type == "text" {
+					inbound.Text = message.Text.Body
+				}
+				if seconds, err := strconv.ParseInt(message.Timestamp, 10, 64); err == nil {
+					inbound.ReceivedAt = time.Unix(seconds, 0)
+				}
+				messages = append(messages, inbound)
+			}
+		}
+	}
+
+	return messages, nil
+}
+
+// ParseWhatsAppTemplateStatusUpdates extrai as mudanças de aprovação de templates de um webhook
+// do WhatsApp (campo message_template_status_update)
+func ParseWhatsAppTemplateStatusUpdates(payload []byte) ([]domain.WhatsAppRegisteredTemplate, error) {
+	var webhook WhatsAppWebhook
+	if err := json.Unmarshal(payload, &webhook); err != nil {
+		return nil, fmt.Errorf("failed to parse webhook: %w", err)
+	}
+
+	var updates []domain.WhatsAppRegisteredTemplate
+	for _, entry := range webhook.Entry {
+		for _, change := range entry.Changes {
+			value := change.Value
+			if change.Field != "message_template_status_update" || value.MessageTemplateName == "" {
+				continue
+			}
+
+			update := domain.WhatsAppRegisteredTemplate{
+				Name:     value.MessageTemplateName,
+				Language: value.MessageTemplateLanguage,
+				Status:   domain.WhatsAppTemplateStatus(strings.ToUpper(value.Event)),
+			}
+			// A Meta envia "NONE" quando não há motivo de rejeição
+			if value.Reason != "" && value.Reason != "NONE" {
+				update.RejectedReason = value.Reason
+			}
+			updates = append(updates, update)
+		}
+	}
+
+	return updates, nil
+}
@@ -0,0 +1,92 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+func TestWhatsAppProviderSessionWindow(t *testing.T) {
+	templateID := uuid.New()
+	templates := &memoryWhatsAppTemplates{mappings: []*domain.WhatsAppTemplateMapping{{
+		TemplateID: templateID,
+		Locale:     "pt-BR",
+		Name:       "nova_movimentacao",
+		Language:   "pt_BR",
+		Parameters: []string{"nome", "numero | cnj"},
+		Status:     domain.WhatsAppTemplateStatusApproved,
+	}}}
+	sessions := memoryWhatsAppSessions{}
+	provider, sent := newWhatsAppStandIn(t, sessions, templates)
+
+	// Sem mensagem do contato: template aprovado
+	if err := provider.Send(context.Background(), newWhatsAppNotification(templateID)); err != nil {
+		t.Fatalf("Send() outside session error = %v", err)
+	}
+	message := (*sent)[0]
+	if message.Type != "template" || message.Template == nil || message.Template.Name != "nova_movimentacao" {
+		t.Fatalf("message outside session = %+v", message)
+	}
+	params := message.Template.Components[0].Parameters
+	if message.Template.Language.Code != "pt_BR" || len(params) != 2 || params[1].Text != "1234567-89.2024.8.26.0001" {
+		t.Errorf("template parameters = %+v", message.Template)
+	}
+
+	// Contato respondeu (wa_id sem "+"): texto livre
+	sessions.RecordInbound(context.Background(), domain.NormalizeWhatsAppContact("5511987654321"), time.Now().Add(-time.Hour))
+	if err := provider.Send(context.Background(), newWhatsAppNotification(templateID)); err != nil {
+		t.Fatalf("Send() inside session error = %v", err)
+	}
+	if message := (*sent)[1]; message.Type != "text" || message.Text == nil || message.Template != nil {
+		t.Errorf("message inside session = %+v", message)
+	}
+}
+
+func TestWhatsAppProviderRequiresApprovedTemplate(t *testing.T) {
+	templateID := uuid.New()
+	templates := &memoryWhatsAppTemplates{mappings: []*domain.WhatsAppTemplateMapping{{
+		TemplateID: templateID,
+		Locale:     "pt-BR",
+		Name:       "nova_movimentacao",
+		Language:   "pt_BR",
+		Status:     domain.WhatsAppTemplateStatusPending,
+	}}}
+	provider, sent := newWhatsAppStandIn(t, memoryWhatsAppSessions{}, templates)
+
+	err := provider.Send(context.Background(), newWhatsAppNotification(templateID))
+	if !errors.Is(err, domain.ErrWhatsAppTemplateRequired) {
+		t.Errorf("Send() error = %v, want ErrWhatsAppTemplateRequired", err)
+	}
+	if len(*sent) != 0 {
+		t.Errorf("message sent without approved template: %+v", *sent)
+	}
+}
+
+func TestParseWhatsAppWebhookInboundAndTemplateStatus(t *testing.T) {
+	payload := []byte(`{"object":"whatsapp_business_account","entry":[{"id":"1","changes":[
+		{"field":"messages","value":{"messages":[{"id":"wamid.2","from":"5511987654321","timestamp":"1700000000","type":"text","text":{"body":"oi"}}]}},
+		{"field":"message_template_status_update","value":{"event":"REJECTED","message_template_name":"prazo","message_template_language":"pt_BR","reason":"INVALID_FORMAT"}}
+	]}]}`)
+
+	messages, err := ParseWhatsAppInboundMessages(payload)
+	if err != nil {
+		t.Fatalf("ParseWhatsAppInboundMessages() error = %v", err)
+	}
+	if len(messages) != 1 || messages[0].From != "5511987654321" || messages[0].Text != "oi" || !messages[0].ReceivedAt.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("inbound messages = %+v", messages)
+	}
+
+	templates, err := ParseWhatsAppTemplateStatusUpdates(payload)
+	if err != nil {
+		t.Fatalf("ParseWhatsAppTemplateStatusUpdates() error = %v", err)
+	}
+	want := domain.WhatsAppRegisteredTemplate{Name: "prazo", Language: "pt_BR", Status: domain.WhatsAppTemplateStatusRejected, RejectedReason: "INVALID_FORMAT"}
+	if len(templates) != 1 || templates[0] != want {
+		t.Errorf("template status updates = %+v", templates)
+	}
+}
@@ -0,0 +1,191 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+	"github.com/direito-lux/notification-service/internal/infrastructure/config"
+)
+
+// whatsAppTemplatePageLimit templates por página na Graph API
+const whatsAppTemplatePageLimit = 100
+
+// NewWhatsAppTemplateCatalog cria o catálogo de templates aprovados conforme a configuração.
+// Retorna nil (sincronização desativada) no catálogo "graph" sem conta WhatsApp Business.
+func NewWhatsAppTemplateCatalog(cfg config.WhatsAppConfig, templates domain.WhatsAppTemplateRepository, logger *zap.Logger) (domain.WhatsAppTemplateCatalog, error) {
+	switch cfg.TemplateCatalog {
+	case "", "graph":
+		if cfg.BusinessAccountID == "" || cfg.AccessToken == "" {
+			return nil, nil
+		}
+		return NewGraphWhatsAppTemplateCatalog(WhatsAppConfig{
+			AccessToken: cfg.AccessToken,
+			Timeout:     30,
+		}, cfg.BusinessAccountID, logger), nil
+	case "stub":
+		return NewStubWhatsAppTemplateCatalog(templates, logger), nil
+	default:
+		return nil, fmt.Errorf("unsupported WhatsApp template catalog: %s", cfg.TemplateCatalog)
+	}
+}
+
+// GraphWhatsAppTemplateCatalog consulta os templates da conta WhatsApp Business na Graph API:
+// GET {base}/{versão}/{waba_id}/message_templates, seguindo a paginação
+type GraphWhatsAppTemplateCatalog struct {
+	config            WhatsAppConfig
+	businessAccountID string
+	client            *http.Client
+	logger            *zap.Logger
+}
+
+// NewGraphWhatsAppTemplateCatalog cria nova instância do catálogo
+func NewGraphWhatsAppTemplateCatalog(config WhatsAppConfig, businessAccountID string, logger *zap.Logger) *GraphWhatsAppTemplateCatalog {
+	// Valores padrão
+	if config.APIVersion == "" {
+		config.APIVersion = "v18.0"
+	}
+	if config.BaseURL == "" {
+		config.BaseURL = "https://graph.facebook.com"
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 30
+	}
+
+	return &GraphWhatsAppTemplateCatalog{
+		config:            config,
+		businessAccountID: businessAccountID,
+		client: &http.Client{
+			Timeout: time.Duration(config.Timeout) * time.Second,
+		},
+		logger: logger,
+	}
+}
+
+// graphTemplatesResponse página de templates da Graph API
+type graphTemplatesResponse struct {
+	Data []struct {
+		Name           string `json:"name"`
+		Language       string `json:"language"`
+		Status         string `json:"status"`
+		Category       string `json:"category"`
+		RejectedReason string `json:"rejected_reason"`
+	} `json:"data"`
+	Paging struct {
+		Next string `json:"next"`
+	} `json:"paging"`
+	Error *WhatsAppError `json:"error,omitempty"`
+}
+
+// ListTemplates lista os templates registrados e o status de aprovação
+func (c *GraphWhatsAppTemplateCatalog) ListTemplates(ctx context.Context) ([]domain.WhatsAppRegisteredTemplate, error) {
+	query := url.Values{
+		"fields": {"name,language,status,category,rejected_reason"},
+		"limit":  {fmt.Sprint(whatsAppTemplatePageLimit)},
+	}
+	next := fmt.Sprintf("%s/%s/%s/message_templates?%s", c.config.BaseURL, c.config.APIVersion, c.businessAccountID, query.Encode())
+
+	var templates []domain.WhatsAppRegisteredTemplate
+	for next != "" {
+		page, err := c.fetchPage(ctx, next)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, t := range page.Data {
+			template := domain.WhatsAppRegisteredTemplate{
+				Name:     t.Name,
+				Language: t.Language,
+				Status:   domain.WhatsAppTemplateStatus(strings.ToUpper(t.Status)),
+				Category: t.Category,
+			}
+			if t.RejectedReason != "" && t.RejectedReason != "NONE" {
+				template.RejectedReason = t.RejectedReason
+			}
+			templates = append(templates, template)
+		}
+
+		next = page.Paging.Next
+	}
+
+	c.logger.Debug("WhatsApp templates listed", zap.Int("count", len(templates)))
+	return templates, nil
+}
+
+// fetchPage busca uma página de templates
+func (c *GraphWhatsAppTemplateCatalog) fetchPage(ctx context.Context, pageURL string) (*graphTemplatesResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", pageURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.config.AccessToken)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list WhatsApp templates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var page graphTemplatesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		if page.Error != nil {
+			return nil, fmt.Errorf("WhatsApp API error: %s (%d)", page.Error.Message, page.Error.Code)
+		}
+		return nil, fmt.Errorf("WhatsApp API returned status: %d", resp.StatusCode)
+	}
+
+	return &page, nil
+}
+
+// StubWhatsAppTemplateCatalog catálogo local que considera aprovados todos os templates
+// mapeados. Substitui a Graph API em desenvolvimento e testes.
+type StubWhatsAppTemplateCatalog struct {
+	templates domain.WhatsAppTemplateRepository
+	logger    *zap.Logger
+}
+
+// NewStubWhatsAppTemplateCatalog cria nova instância do catálogo local
+func NewStubWhatsAppTemplateCatalog(templates domain.WhatsAppTemplateRepository, logger *zap.Logger) *StubWhatsAppTemplateCatalog {
+	return &StubWhatsAppTemplateCatalog{
+		templates: templates,
+		logger:    logger,
+	}
+}
+
+// ListTemplates retorna os templates mapeados como aprovados
+func (c *StubWhatsAppTemplateCatalog) ListTemplates(ctx context.Context) ([]domain.WhatsAppRegisteredTemplate, error) {
+	mappings, err := c.templates.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(mappings))
+	templates := make([]domain.WhatsAppRegisteredTemplate, 0, len(mappings))
+	for _, m := range mappings {
+		key := m.Name + "/" + m.Language
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		templates = append(templates, domain.WhatsAppRegisteredTemplate{
+			Name:     m.Name,
+			Language: m.Language,
+			Status:   domain.WhatsAppTemplateStatusApproved,
+			Category: "UTILITY",
+		})
+	}
+
+	return templates, nil
+}
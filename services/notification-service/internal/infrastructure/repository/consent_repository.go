@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// PostgresConsentRepository implementação PostgreSQL do ConsentRepository
+type PostgresConsentRepository struct {
+	db     *sqlx.DB
+	logger *zap.Logger
+}
+
+// NewPostgresConsentRepository cria nova instância do repositório
+func NewPostgresConsentRepository(db *sqlx.DB, logger *zap.Logger) domain.ConsentRepository {
+	return &PostgresConsentRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// consentRecordDB representa o registro de consentimento no banco de dados
+type consentRecordDB struct {
+	ID           string    `db:"id"`
+	TenantID     *string   `db:"tenant_id"`
+	Channel      string    `db:"channel"`
+	Contact      string    `db:"contact"`
+	RecipientID  *string   `db:"recipient_id"`
+	Purpose      string    `db:"purpose"`
+	Status       string    `db:"status"`
+	LegalBasis   string    `db:"legal_basis"`
+	Source       string    `db:"source"`
+	EvidenceJSON string    `db:"evidence"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+// Record grava o registro de consentimento
+func (r *PostgresConsentRepository) Record(ctx context.Context, record *domain.ConsentRecord) error {
+	evidence := record.Evidence
+	if evidence == nil {
+		evidence = map[string]interface{}{}
+	}
+	evidenceJSON, err := json.Marshal(evidence)
+	if err != nil {
+		return fmt.Errorf("failed to marshal evidence: %w", err)
+	}
+
+	recordDB := &consentRecordDB{
+		ID:           record.ID.String(),
+		Channel:      string(record.Channel),
+		Contact:      domain.NormalizeConsentContact(record.Channel, record.Contact),
+		Purpose:      string(record.Purpose),
+		Status:       string(record.Status),
+		LegalBasis:   string(record.LegalBasis),
+		Source:       string(record.Source),
+		EvidenceJSON: string(evidenceJSON),
+		CreatedAt:    record.CreatedAt,
+	}
+	if record.TenantID != nil {
+		tenantID := record.TenantID.String()
+		recordDB.TenantID = &tenantID
+	}
+	if record.RecipientID != "" {
+		recordDB.RecipientID = &record.RecipientID
+	}
+
+	query := `
+		INSERT INTO consent_records (
+			id, tenant_id, channel, contact, recipient_id, purpose, status,
+			legal_basis, source, evidence, created_at
+		) VALUES (
+			:id, :tenant_id, :channel, :contact, :recipient_id, :purpose, :status,
+			:legal_basis, :source, :evidence, :created_at
+		)`
+
+	if _, err := r.db.NamedExecContext(ctx, query, recordDB); err != nil {
+		r.logger.Error("Failed to record consent", zap.Error(err))
+		return fmt.Errorf("failed to record consent: %w", err)
+	}
+
+	r.logger.Info("Consent recorded",
+		zap.String("channel", string(record.Channel)),
+		zap.String("purpose", string(record.Purpose)),
+		zap.String("status", string(record.Status)),
+		zap.String("source", string(record.Source)))
+	return nil
+}
+
+// FindByContact lista o histórico do contato no canal em ordem cronológica
+func (r *PostgresConsentRepository) FindByContact(ctx context.Context, tenantID uuid.UUID, channel domain.NotificationChannel, contact string) ([]*domain.ConsentRecord, error) {
+	query := `
+		SELECT * FROM consent_records
+		WHERE channel = $1 AND contact = $2 AND (tenant_id = $3 OR tenant_id IS NULL)
+		ORDER BY created_at ASC`
+
+	var recordsDB []consentRecordDB
+	err := r.db.SelectContext(ctx, &recordsDB, query,
+		string(channel), domain.NormalizeConsentContact(channel, contact), tenantID.String())
+	if err != nil {
+		r.logger.Error("Failed to find consent records", zap.Error(err))
+		return nil, fmt.Errorf("failed to find consent records: %w", err)
+	}
+
+	return r.fromDatabaseList(recordsDB)
+}
+
+// Export lista o histórico do tenant para auditoria. Os registros sem tenant entram apenas para
+// contatos que têm registro no tenant.
+func (r *PostgresConsentRepository) Export(ctx context.Context, tenantID uuid.UUID, filter domain.ConsentExportFilter) ([]*domain.ConsentRecord, error) {
+	args := []interface{}{tenantID.String()}
+	conditions := []string{`(c.tenant_id = $1 OR (c.tenant_id IS NULL AND EXISTS (
+		SELECT 1 FROM consent_records t
+		WHERE t.tenant_id = $1 AND t.channel = c.channel AND t.contact = c.contact)))`}
+	argIndex := 2
+
+	if filter.Channel != nil {
+		conditions = append(conditions, fmt.Sprintf("c.channel = $%d", argIndex))
+		args = append(args, string(*filter.Channel))
+		argIndex++
+	}
+
+	if filter.Contact != "" {
+		conditions = append(conditions, fmt.Sprintf("c.contact = $%d", argIndex))
+		contact := strings.TrimSpace(filter.Contact)
+		if filter.Channel != nil {
+			contact = domain.NormalizeConsentContact(*filter.Channel, contact)
+		}
+		args = append(args, contact)
+		argIndex++
+	}
+
+	if filter.From != nil {
+		conditions = append(conditions, fmt.Sprintf("c.created_at >= $%d", argIndex))
+		args = append(args, *filter.From)
+		argIndex++
+	}
+
+	if filter.To != nil {
+		conditions = append(conditions, fmt.Sprintf("c.created_at < $%d", argIndex))
+		args = append(args, *filter.To)
+	}
+
+	query := `SELECT c.* FROM consent_records c WHERE ` + strings.Join(conditions, " AND ") +
+		` ORDER BY c.created_at ASC`
+
+	var recordsDB []consentRecordDB
+	if err := r.db.SelectContext(ctx, &recordsDB, query, args...); err != nil {
+		r.logger.Error("Failed to export consent records", zap.Error(err))
+		return nil, fmt.Errorf("failed to export consent records: %w", err)
+	}
+
+	return r.fromDatabaseList(recordsDB)
+}
+
+// fromDatabaseList converte a lista do modelo do banco
+func (r *PostgresConsentRepository) fromDatabaseList(recordsDB []consentRecordDB) ([]*domain.ConsentRecord, error) {
+	records := make([]*domain.ConsentRecord, 0, len(recordsDB))
+	for i := range recordsDB {
+		record, err := r.fromDatabase(&recordsDB[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert consent record from database: %w", err)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// fromDatabase converte do modelo do banco
+func (r *PostgresConsentRepository) fromDatabase(recordDB *consentRecordDB) (*domain.ConsentRecord, error) {
+	id, err := uuid.Parse(recordDB.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid consent record ID: %w", err)
+	}
+
+	record := &domain.ConsentRecord{
+		ID:         id,
+		Channel:    domain.NotificationChannel(recordDB.Channel),
+		Contact:    recordDB.Contact,
+		Purpose:    domain.ConsentPurpose(recordDB.Purpose),
+		Status:     domain.ConsentStatus(recordDB.Status),
+		LegalBasis: domain.ConsentLegalBasis(recordDB.LegalBasis),
+		Source:     domain.ConsentSource(recordDB.Source),
+		CreatedAt:  recordDB.CreatedAt,
+	}
+
+	if recordDB.TenantID != nil {
+		tenantID, err := uuid.Parse(*recordDB.TenantID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tenant_id: %w", err)
+		}
+		record.TenantID = &tenantID
+	}
+	if recordDB.RecipientID != nil {
+		record.RecipientID = *recordDB.RecipientID
+	}
+
+	if recordDB.EvidenceJSON != "" {
+		if err := json.Unmarshal([]byte(recordDB.EvidenceJSON), &record.Evidence); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal evidence: %w", err)
+		}
+	}
+
+	return record, nil
+}
@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// PostgresDeliveryHistoryRepository implementação PostgreSQL do DeliveryHistoryRepository
+type PostgresDeliveryHistoryRepository struct {
+	db     *sqlx.DB
+	logger *zap.Logger
+}
+
+// NewPostgresDeliveryHistoryRepository cria nova instância do repositório
+func NewPostgresDeliveryHistoryRepository(db *sqlx.DB, logger *zap.Logger) domain.DeliveryHistoryRepository {
+	return &PostgresDeliveryHistoryRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// deliveryRecordDB representa um registro de entrega no banco de dados
+type deliveryRecordDB struct {
+	ID               string     `db:"id"`
+	NotificationID   string     `db:"notification_id"`
+	Channel          string     `db:"channel"`
+	Status           string     `db:"status"`
+	ProviderID       *string    `db:"provider_id"`
+	ProviderResponse *string    `db:"provider_response"`
+	DeliveredAt      *time.Time `db:"delivered_at"`
+	FailedAt         *time.Time `db:"failed_at"`
+	ErrorCode        *string    `db:"error_code"`
+	ErrorMessage     *string    `db:"error_message"`
+	MetadataJSON     string     `db:"metadata"`
+	CreatedAt        time.Time  `db:"created_at"`
+}
+
+// Record grava um evento de entrega
+func (r *PostgresDeliveryHistoryRepository) Record(ctx context.Context, record *domain.DeliveryRecord) error {
+	metadataJSON, _ := json.Marshal(record.Metadata)
+
+	var providerID *string
+	if record.ProviderID != "" {
+		providerID = &record.ProviderID
+	}
+
+	recordDB := &deliveryRecordDB{
+		ID:             record.ID.String(),
+		NotificationID: record.NotificationID.String(),
+		Channel:        string(record.Channel),
+		Status:         string(record.Status),
+		ProviderID:     providerID,
+		DeliveredAt:    record.DeliveredAt,
+		FailedAt:       record.FailedAt,
+		ErrorCode:      record.ErrorCode,
+		ErrorMessage:   record.ErrorMessage,
+		MetadataJSON:   string(metadataJSON),
+		CreatedAt:      record.CreatedAt,
+	}
+
+	query := `
+		INSERT INTO notification_delivery_history (
+			id, notification_id, channel, status, provider_id, delivered_at,
+			failed_at, error_code, error_message, metadata, created_at
+		) VALUES (
+			:id, :notification_id, :channel, :status, :provider_id, :delivered_at,
+			:failed_at, :error_code, :error_message, :metadata, :created_at
+		)`
+
+	if _, err := r.db.NamedExecContext(ctx, query, recordDB); err != nil {
+		r.logger.Error("Failed to record delivery event", zap.Error(err))
+		return fmt.Errorf("failed to record delivery event: %w", err)
+	}
+
+	return nil
+}
+
+// FindByNotificationID lista o histórico de entrega da notificação em ordem cronológica
+func (r *PostgresDeliveryHistoryRepository) FindByNotificationID(ctx context.Context, notificationID uuid.UUID) ([]*domain.DeliveryRecord, error) {
+	query := `SELECT * FROM notification_delivery_history WHERE notification_id = $1 ORDER BY created_at ASC`
+
+	var recordsDB []deliveryRecordDB
+	if err := r.db.SelectContext(ctx, &recordsDB, query, notificationID.String()); err != nil {
+		r.logger.Error("Failed to find delivery history", zap.Error(err))
+		return nil, fmt.Errorf("failed to find delivery history: %w", err)
+	}
+
+	records := make([]*domain.DeliveryRecord, len(recordsDB))
+	for i, recordDB := range recordsDB {
+		record, err := r.fromDatabase(&recordDB)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert delivery record from database: %w", err)
+		}
+		records[i] = record
+	}
+
+	return records, nil
+}
+
+// fromDatabase converte deliveryRecordDB para domain.DeliveryRecord
+func (r *PostgresDeliveryHistoryRepository) fromDatabase(recordDB *deliveryRecordDB) (*domain.DeliveryRecord, error) {
+	id, err := uuid.Parse(recordDB.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid delivery record ID: %w", err)
+	}
+
+	notificationID, err := uuid.Parse(recordDB.NotificationID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid notification ID: %w", err)
+	}
+
+	var metadata map[string]interface{}
+	if recordDB.MetadataJSON != "" {
+		if err := json.Unmarshal([]byte(recordDB.MetadataJSON), &metadata); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+		}
+	}
+
+	record := &domain.DeliveryRecord{
+		ID:             id,
+		NotificationID: notificationID,
+		Channel:        domain.NotificationChannel(recordDB.Channel),
+		Status:         domain.DeliveryEvent(recordDB.Status),
+		DeliveredAt:    recordDB.DeliveredAt,
+		FailedAt:       recordDB.FailedAt,
+		ErrorCode:      recordDB.ErrorCode,
+		ErrorMessage:   recordDB.ErrorMessage,
+		Metadata:       metadata,
+		CreatedAt:      recordDB.CreatedAt,
+	}
+	if recordDB.ProviderID != nil {
+		record.ProviderID = *recordDB.ProviderID
+	}
+
+	return record, nil
+}
+
+// PostgresSuppressionRepository implementação PostgreSQL do SuppressionRepository
+type PostgresSuppressionRepository struct {
+	db     *sqlx.DB
+	logger *zap.Logger
+}
+
+// NewPostgresSuppressionRepository cria nova instância do repositório
+func NewPostgresSuppressionRepository(db *sqlx.DB, logger *zap.Logger) domain.SuppressionRepository {
+	return &PostgresSuppressionRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Suppress registra o contato na lista de supressão
+func (r *PostgresSuppressionRepository) Suppress(ctx context.Context, suppression *domain.SuppressedContact) error {
+	var notificationID *string
+	if suppression.NotificationID != nil {
+		id := suppression.NotificationID.String()
+		notificationID = &id
+	}
+
+	query := `
+		INSERT INTO notification_suppressions (id, channel, contact, reason, details, notification_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (channel, contact) DO NOTHING`
+
+	_, err := r.db.ExecContext(ctx, query,
+		suppression.ID.String(),
+		string(suppression.Channel),
+		domain.NormalizeContact(suppression.Channel, suppression.Contact),
+		string(suppression.Reason),
+		suppression.Details,
+		notificationID,
+		suppression.CreatedAt,
+	)
+	if err != nil {
+		r.logger.Error("Failed to suppress contact", zap.Error(err))
+		return fmt.Errorf("failed to suppress contact: %w", err)
+	}
+
+	r.logger.Info("Contact suppressed",
+		zap.String("channel", string(suppression.Channel)),
+		zap.String("reason", string(suppression.Reason)))
+	return nil
+}
+
+// IsSuppressed verifica se o contato está suprimido no canal
+func (r *PostgresSuppressionRepository) IsSuppressed(ctx context.Context, channel domain.NotificationChannel, contact string) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM notification_suppressions WHERE channel = $1 AND contact = $2)`
+
+	if err := r.db.GetContext(ctx, &exists, query, string(channel), domain.NormalizeContact(channel, contact)); err != nil {
+		return false, fmt.Errorf("failed to check suppression: %w", err)
+	}
+
+	return exists, nil
+}
+
+// Remove retira o contato da lista de supressão
+func (r *PostgresSuppressionRepository) Remove(ctx context.Context, channel domain.NotificationChannel, contact string) error {
+	query := `DELETE FROM notification_suppressions WHERE channel = $1 AND contact = $2`
+
+	if _, err := r.db.ExecContext(ctx, query, string(channel), domain.NormalizeContact(channel, contact)); err != nil {
+		return fmt.Errorf("failed to remove suppression: %w", err)
+	}
+
+	return nil
+}
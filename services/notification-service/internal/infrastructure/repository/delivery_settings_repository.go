@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// PostgresDeliverySettingsRepository implementação PostgreSQL do DeliverySettingsRepository
+type PostgresDeliverySettingsRepository struct {
+	db     *sqlx.DB
+	logger *zap.Logger
+}
+
+// NewPostgresDeliverySettingsRepository cria nova instância do repositório
+func NewPostgresDeliverySettingsRepository(db *sqlx.DB, logger *zap.Logger) domain.DeliverySettingsRepository {
+	return &PostgresDeliverySettingsRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// deliverySettingsDB representa a configuração de entrega no banco de dados
+type deliverySettingsDB struct {
+	ID                 string         `db:"id"`
+	TenantID           string         `db:"tenant_id"`
+	UserID             *string        `db:"user_id"`
+	Timezone           string         `db:"timezone"`
+	Language           string         `db:"language"`
+	QuietHoursEnabled  bool           `db:"quiet_hours_enabled"`
+	QuietHoursStart    string         `db:"quiet_hours_start"`
+	QuietHoursEnd      string         `db:"quiet_hours_end"`
+	BusinessHoursOnly  bool           `db:"business_hours_only"`
+	BusinessHoursStart string         `db:"business_hours_start"`
+	BusinessHoursEnd   string         `db:"business_hours_end"`
+	BusinessDays       pq.Int64Array  `db:"business_days"`
+	OverridePriorities pq.StringArray `db:"override_priorities"`
+	DigestHour         int            `db:"digest_hour"`
+	DigestWeekday      int            `db:"digest_weekday"`
+	CreatedAt          time.Time      `db:"created_at"`
+	UpdatedAt          time.Time      `db:"updated_at"`
+}
+
+// FindByUser busca a configuração do usuário
+func (r *PostgresDeliverySettingsRepository) FindByUser(ctx context.Context, tenantID, userID uuid.UUID) (*domain.DeliverySettings, error) {
+	query := `SELECT * FROM notification_delivery_settings WHERE tenant_id = $1 AND user_id = $2`
+	return r.get(ctx, query, tenantID.String(), userID.String())
+}
+
+// FindTenantDefault busca a configuração padrão do tenant
+func (r *PostgresDeliverySettingsRepository) FindTenantDefault(ctx context.Context, tenantID uuid.UUID) (*domain.DeliverySettings, error) {
+	query := `SELECT * FROM notification_delivery_settings WHERE tenant_id = $1 AND user_id IS NULL`
+	return r.get(ctx, query, tenantID.String())
+}
+
+// Upsert cria ou atualiza a configuração do usuário ou o padrão do tenant
+func (r *PostgresDeliverySettingsRepository) Upsert(ctx context.Context, settings *domain.DeliverySettings) error {
+	settingsDB := r.toDatabase(settings)
+	settingsDB.UpdatedAt = time.Now()
+
+	conflict := `(tenant_id, user_id) WHERE user_id IS NOT NULL`
+	if settings.UserID == nil {
+		conflict = `(tenant_id) WHERE user_id IS NULL`
+	}
+
+	query := `
+		INSERT INTO notification_delivery_settings (
+			id, tenant_id, user_id, timezone, language, quiet_hours_enabled, quiet_hours_start, quiet_hours_end,
+			business_hours_only, business_hours_start, business_hours_end, business_days,
+			override_priorities, digest_hour, digest_weekday, created_at, updated_at
+		) VALUES (
+			:id, :tenant_id, :user_id, :timezone, :language, :quiet_hours_enabled, :quiet_hours_start, :quiet_hours_end,
+			:business_hours_only, :business_hours_start, :business_hours_end, :business_days,
+			:override_priorities, :digest_hour, :digest_weekday, :created_at, :updated_at
+		) ON CONFLICT ` + conflict + ` DO UPDATE SET
+			timezone = EXCLUDED.timezone,
+			language = EXCLUDED.language,
+			quiet_hours_enabled = EXCLUDED.quiet_hours_enabled,
+			quiet_hours_start = EXCLUDED.quiet_hours_start,
+			quiet_hours_end = EXCLUDED.quiet_hours_end,
+			business_hours_only = EXCLUDED.business_hours_only,
+			business_hours_start = EXCLUDED.business_hours_start,
+			business_hours_end = EXCLUDED.business_hours_end,
+			business_days = EXCLUDED.business_days,
+			override_priorities = EXCLUDED.override_priorities,
+			digest_hour = EXCLUDED.digest_hour,
+			digest_weekday = EXCLUDED.digest_weekday,
+			updated_at = EXCLUDED.updated_at`
+
+	if _, err := r.db.NamedExecContext(ctx, query, settingsDB); err != nil {
+		r.logger.Error("Failed to upsert delivery settings", zap.Error(err))
+		return fmt.Errorf("failed to upsert delivery settings: %w", err)
+	}
+
+	return nil
+}
+
+// get executa a busca de uma configuração
+func (r *PostgresDeliverySettingsRepository) get(ctx context.Context, query string, args ...interface{}) (*domain.DeliverySettings, error) {
+	var settingsDB deliverySettingsDB
+	if err := r.db.GetContext(ctx, &settingsDB, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrDeliverySettingsNotFound
+		}
+		r.logger.Error("Failed to get delivery settings", zap.Error(err))
+		return nil, fmt.Errorf("failed to get delivery settings: %w", err)
+	}
+
+	settings, err := r.fromDatabase(&settingsDB)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert delivery settings from database: %w", err)
+	}
+
+	return settings, nil
+}
+
+// toDatabase converte domain.DeliverySettings para deliverySettingsDB
+func (r *PostgresDeliverySettingsRepository) toDatabase(settings *domain.DeliverySettings) *deliverySettingsDB {
+	var userID *string
+	if settings.UserID != nil {
+		id := settings.UserID.String()
+		userID = &id
+	}
+
+	businessDays := make(pq.Int64Array, len(settings.BusinessDays))
+	for i, day := range settings.BusinessDays {
+		businessDays[i] = int64(day)
+	}
+
+	priorities := make(pq.StringArray, len(settings.OverridePriorities))
+	for i, priority := range settings.OverridePriorities {
+		priorities[i] = string(priority)
+	}
+
+	return &deliverySettingsDB{
+		ID:                 settings.ID.String(),
+		TenantID:           settings.TenantID.String(),
+		UserID:             userID,
+		Timezone:           settings.Timezone,
+		Language:           settings.Language,
+		QuietHoursEnabled:  settings.QuietHoursEnabled,
+		QuietHoursStart:    settings.QuietHoursStart,
+		QuietHoursEnd:      settings.QuietHoursEnd,
+		BusinessHoursOnly:  settings.BusinessHoursOnly,
+		BusinessHoursStart: settings.BusinessHoursStart,
+		BusinessHoursEnd:   settings.BusinessHoursEnd,
+		BusinessDays:       businessDays,
+		OverridePriorities: priorities,
+		DigestHour:         settings.DigestHour,
+		DigestWeekday:      int(settings.DigestWeekday),
+		CreatedAt:          settings.CreatedAt,
+		UpdatedAt:          settings.UpdatedAt,
+	}
+}
+
+// fromDatabase converte deliverySettingsDB para domain.DeliverySettings
+func (r *PostgresDeliverySettingsRepository) fromDatabase(settingsDB *deliverySettingsDB) (*domain.DeliverySettings, error) {
+	id, err := uuid.Parse(settingsDB.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid delivery settings ID: %w", err)
+	}
+
+	tenantID, err := uuid.Parse(settingsDB.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tenant ID: %w", err)
+	}
+
+	var userID *uuid.UUID
+	if settingsDB.UserID != nil {
+		id, err := uuid.Parse(*settingsDB.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid user ID: %w", err)
+		}
+		userID = &id
+	}
+
+	businessDays := make([]time.Weekday, len(settingsDB.BusinessDays))
+	for i, day := range settingsDB.BusinessDays {
+		businessDays[i] = time.Weekday(day)
+	}
+
+	priorities := make([]domain.NotificationPriority, len(settingsDB.OverridePriorities))
+	for i, priority := range settingsDB.OverridePriorities {
+		priorities[i] = domain.NotificationPriority(priority)
+	}
+
+	return &domain.DeliverySettings{
+		ID:                 id,
+		TenantID:           tenantID,
+		UserID:             userID,
+		Timezone:           settingsDB.Timezone,
+		Language:           settingsDB.Language,
+		QuietHoursEnabled:  settingsDB.QuietHoursEnabled,
+		QuietHoursStart:    settingsDB.QuietHoursStart,
+		QuietHoursEnd:      settingsDB.QuietHoursEnd,
+		BusinessHoursOnly:  settingsDB.BusinessHoursOnly,
+		BusinessHoursStart: settingsDB.BusinessHoursStart,
+		BusinessHoursEnd:   settingsDB.BusinessHoursEnd,
+		BusinessDays:       businessDays,
+		OverridePriorities: priorities,
+		DigestHour:         settingsDB.DigestHour,
+		DigestWeekday:      time.Weekday(settingsDB.DigestWeekday),
+		CreatedAt:          settingsDB.CreatedAt,
+		UpdatedAt:          settingsDB.UpdatedAt,
+	}, nil
+}
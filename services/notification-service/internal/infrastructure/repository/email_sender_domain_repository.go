@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// PostgresEmailSenderDomainRepository implementação PostgreSQL do EmailSenderDomainRepository
+type PostgresEmailSenderDomainRepository struct {
+	db     *sqlx.DB
+	logger *zap.Logger
+}
+
+// NewPostgresEmailSenderDomainRepository cria nova instância do repositório
+func NewPostgresEmailSenderDomainRepository(db *sqlx.DB, logger *zap.Logger) domain.EmailSenderDomainRepository {
+	return &PostgresEmailSenderDomainRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// emailSenderDomainDB representa o remetente do tenant no banco de dados
+type emailSenderDomainDB struct {
+	TenantID       string     `db:"tenant_id"`
+	Domain         string     `db:"domain"`
+	FromEmail      string     `db:"from_email"`
+	FromName       *string    `db:"from_name"`
+	DKIMSelector   string     `db:"dkim_selector"`
+	DKIMPrivateKey string     `db:"dkim_private_key"`
+	DKIMPublicKey  string     `db:"dkim_public_key"`
+	Verified       bool       `db:"verified"`
+	VerifiedAt     *time.Time `db:"verified_at"`
+	CreatedAt      time.Time  `db:"created_at"`
+	UpdatedAt      time.Time  `db:"updated_at"`
+}
+
+// Save cria ou substitui o remetente do tenant
+func (r *PostgresEmailSenderDomainRepository) Save(ctx context.Context, senderDomain *domain.EmailSenderDomain) error {
+	senderDB := &emailSenderDomainDB{
+		TenantID:       senderDomain.TenantID.String(),
+		Domain:         senderDomain.Domain,
+		FromEmail:      senderDomain.FromEmail,
+		DKIMSelector:   senderDomain.DKIMSelector,
+		DKIMPrivateKey: senderDomain.DKIMPrivateKey,
+		DKIMPublicKey:  senderDomain.DKIMPublicKey,
+		Verified:       senderDomain.Verified,
+		VerifiedAt:     senderDomain.VerifiedAt,
+		CreatedAt:      senderDomain.CreatedAt,
+		UpdatedAt:      senderDomain.UpdatedAt,
+	}
+	if senderDomain.FromName != "" {
+		senderDB.FromName = &senderDomain.FromName
+	}
+
+	query := `
+		INSERT INTO email_sender_domains (
+			tenant_id, domain, from_email, from_name, dkim_selector, dkim_private_key,
+			dkim_public_key, verified, verified_at, created_at, updated_at
+		) VALUES (
+			:tenant_id, :domain, :from_email, :from_name, :dkim_selector, :dkim_private_key,
+			:dkim_public_key, :verified, :verified_at, :created_at, :updated_at
+		) ON CONFLICT (tenant_id) DO UPDATE SET
+			domain = EXCLUDED.domain,
+			from_email = EXCLUDED.from_email,
+			from_name = EXCLUDED.from_name,
+			dkim_selector = EXCLUDED.dkim_selector,
+			dkim_private_key = EXCLUDED.dkim_private_key,
+			dkim_public_key = EXCLUDED.dkim_public_key,
+			verified = EXCLUDED.verified,
+			verified_at = EXCLUDED.verified_at,
+			updated_at = EXCLUDED.updated_at`
+
+	if _, err := r.db.NamedExecContext(ctx, query, senderDB); err != nil {
+		r.logger.Error("Failed to save email sender domain", zap.Error(err))
+		return fmt.Errorf("failed to save email sender domain: %w", err)
+	}
+
+	r.logger.Info("Email sender domain saved",
+		zap.String("tenant_id", senderDomain.TenantID.String()),
+		zap.String("domain", senderDomain.Domain),
+		zap.Bool("verified", senderDomain.Verified))
+	return nil
+}
+
+// GetByTenant busca o remetente do tenant
+func (r *PostgresEmailSenderDomainRepository) GetByTenant(ctx context.Context, tenantID uuid.UUID) (*domain.EmailSenderDomain, error) {
+	var senderDB emailSenderDomainDB
+	query := `SELECT * FROM email_sender_domains WHERE tenant_id = $1`
+
+	if err := r.db.GetContext(ctx, &senderDB, query, tenantID.String()); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrEmailSenderDomainNotFound
+		}
+		r.logger.Error("Failed to get email sender domain", zap.Error(err))
+		return nil, fmt.Errorf("failed to get email sender domain: %w", err)
+	}
+
+	return r.fromDatabase(&senderDB)
+}
+
+// Delete remove o remetente do tenant; os emails voltam a sair pelo remetente padrão
+func (r *PostgresEmailSenderDomainRepository) Delete(ctx context.Context, tenantID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM email_sender_domains WHERE tenant_id = $1`, tenantID.String())
+	if err != nil {
+		r.logger.Error("Failed to delete email sender domain", zap.Error(err))
+		return fmt.Errorf("failed to delete email sender domain: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrEmailSenderDomainNotFound
+	}
+
+	return nil
+}
+
+// fromDatabase converte do modelo do banco
+func (r *PostgresEmailSenderDomainRepository) fromDatabase(senderDB *emailSenderDomainDB) (*domain.EmailSenderDomain, error) {
+	tenantID, err := uuid.Parse(senderDB.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tenant_id: %w", err)
+	}
+
+	senderDomain := &domain.EmailSenderDomain{
+		TenantID:       tenantID,
+		Domain:         senderDB.Domain,
+		FromEmail:      senderDB.FromEmail,
+		DKIMSelector:   senderDB.DKIMSelector,
+		DKIMPrivateKey: senderDB.DKIMPrivateKey,
+		DKIMPublicKey:  senderDB.DKIMPublicKey,
+		Verified:       senderDB.Verified,
+		VerifiedAt:     senderDB.VerifiedAt,
+		CreatedAt:      senderDB.CreatedAt,
+		UpdatedAt:      senderDB.UpdatedAt,
+	}
+	if senderDB.FromName != nil {
+		senderDomain.FromName = *senderDB.FromName
+	}
+
+	return senderDomain, nil
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// PostgresMovementAlertRepository implementação PostgreSQL do MovementAlertRepository
+type PostgresMovementAlertRepository struct {
+	db     *sqlx.DB
+	logger *zap.Logger
+}
+
+// NewPostgresMovementAlertRepository cria nova instância do repositório
+func NewPostgresMovementAlertRepository(db *sqlx.DB, logger *zap.Logger) domain.MovementAlertRepository {
+	return &PostgresMovementAlertRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Claim reserva o alerta da movimentação para o destinatário. Um alerta importante ainda é
+// reservado quando o destinatário só recebeu o alerta comum da mesma movimentação.
+func (r *PostgresMovementAlertRepository) Claim(ctx context.Context, movementID, recipientID string, important bool, at time.Time) (bool, error) {
+	query := `
+		INSERT INTO movement_alerts (movement_id, recipient_id, important, alerted_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (movement_id, recipient_id) DO UPDATE SET
+			important = TRUE,
+			alerted_at = EXCLUDED.alerted_at
+		WHERE movement_alerts.important = FALSE AND EXCLUDED.important = TRUE`
+
+	result, err := r.db.ExecContext(ctx, query, movementID, recipientID, important, at)
+	if err != nil {
+		r.logger.Error("Failed to claim movement alert", zap.Error(err))
+		return false, fmt.Errorf("failed to claim movement alert: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Release desfaz a reserva: o alerta importante volta a comum e o comum é removido
+func (r *PostgresMovementAlertRepository) Release(ctx context.Context, movementID, recipientID string, important bool) error {
+	query := `DELETE FROM movement_alerts WHERE movement_id = $1 AND recipient_id = $2 AND important = FALSE`
+	if important {
+		query = `UPDATE movement_alerts SET important = FALSE WHERE movement_id = $1 AND recipient_id = $2`
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, movementID, recipientID); err != nil {
+		r.logger.Error("Failed to release movement alert", zap.Error(err))
+		return fmt.Errorf("failed to release movement alert: %w", err)
+	}
+
+	return nil
+}
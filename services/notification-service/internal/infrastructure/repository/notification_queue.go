@@ -0,0 +1,230 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// PostgresNotificationQueue implementação PostgreSQL da NotificationQueue.
+// Os itens são consumidos com SELECT ... FOR UPDATE SKIP LOCKED, permitindo vários
+// workers e instâncias concorrentes sem entregar a mesma notificação duas vezes.
+type PostgresNotificationQueue struct {
+	db                *sqlx.DB
+	notificationRepo  domain.NotificationRepository
+	visibilityTimeout time.Duration
+	logger            *zap.Logger
+}
+
+// NewPostgresNotificationQueue cria nova instância da fila
+func NewPostgresNotificationQueue(
+	db *sqlx.DB,
+	notificationRepo domain.NotificationRepository,
+	visibilityTimeout time.Duration,
+	logger *zap.Logger,
+) domain.NotificationQueue {
+	return &PostgresNotificationQueue{
+		db:                db,
+		notificationRepo:  notificationRepo,
+		visibilityTimeout: visibilityTimeout,
+		logger:            logger,
+	}
+}
+
+// enqueueQuery insere ou reagenda o item. Itens em processamento (travados) não são alterados.
+const enqueueQuery = `
+	INSERT INTO notification_queue (notification_id, channel, priority, priority_rank, available_at)
+	VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (notification_id) DO UPDATE SET
+		priority = EXCLUDED.priority,
+		priority_rank = EXCLUDED.priority_rank,
+		available_at = EXCLUDED.available_at,
+		locked_until = NULL
+	WHERE notification_queue.locked_until IS NULL OR notification_queue.locked_until <= CURRENT_TIMESTAMP`
+
+// Enqueue enfileira notificação para envio imediato
+func (q *PostgresNotificationQueue) Enqueue(ctx context.Context, notification *domain.Notification) error {
+	return q.enqueue(ctx, q.db, notification, time.Now())
+}
+
+// EnqueueBatch enfileira várias notificações em uma transação
+func (q *PostgresNotificationQueue) EnqueueBatch(ctx context.Context, notifications []*domain.Notification) error {
+	tx, err := q.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	for _, notification := range notifications {
+		if err := q.enqueue(ctx, tx, notification, now); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// EnqueueScheduled enfileira notificação que só fica visível a partir de scheduledAt
+func (q *PostgresNotificationQueue) EnqueueScheduled(ctx context.Context, notification *domain.Notification, scheduledAt time.Time) error {
+	return q.enqueue(ctx, q.db, notification, scheduledAt)
+}
+
+// enqueue grava o item na fila
+func (q *PostgresNotificationQueue) enqueue(ctx context.Context, exec sqlx.ExecerContext, notification *domain.Notification, availableAt time.Time) error {
+	_, err := exec.ExecContext(ctx, enqueueQuery,
+		notification.ID.String(),
+		string(notification.Channel),
+		string(notification.Priority),
+		notification.Priority.Rank(),
+		availableAt,
+	)
+	if err != nil {
+		q.logger.Error("Failed to enqueue notification",
+			zap.String("notification_id", notification.ID.String()),
+			zap.Error(err))
+		return fmt.Errorf("failed to enqueue notification: %w", err)
+	}
+
+	return nil
+}
+
+// Dequeue desenfileira a próxima notificação do canal (sempre respeitando a prioridade)
+func (q *PostgresNotificationQueue) Dequeue(ctx context.Context, channel domain.NotificationChannel) (*domain.Notification, error) {
+	return q.DequeueByPriority(ctx, channel)
+}
+
+// DequeueByPriority desenfileira a notificação de maior prioridade disponível no canal
+func (q *PostgresNotificationQueue) DequeueByPriority(ctx context.Context, channel domain.NotificationChannel) (*domain.Notification, error) {
+	notifications, err := q.DequeueBatch(ctx, channel, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(notifications) == 0 {
+		return nil, domain.ErrQueueEmpty
+	}
+
+	return notifications[0], nil
+}
+
+// DequeueBatch desenfileira até limit notificações do canal, da maior para a menor prioridade.
+// Os itens ficam invisíveis pelo visibility timeout até serem confirmados com Ack ou Nack.
+func (q *PostgresNotificationQueue) DequeueBatch(ctx context.Context, channel domain.NotificationChannel, limit int) ([]*domain.Notification, error) {
+	query := `
+		UPDATE notification_queue q SET
+			locked_until = CURRENT_TIMESTAMP + make_interval(secs => $2),
+			attempts = q.attempts + 1
+		FROM (
+			SELECT notification_id FROM notification_queue
+			WHERE channel = $1
+				AND available_at <= CURRENT_TIMESTAMP
+				AND (locked_until IS NULL OR locked_until <= CURRENT_TIMESTAMP)
+			ORDER BY priority_rank DESC, available_at ASC
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		) next
+		WHERE q.notification_id = next.notification_id
+		RETURNING q.notification_id`
+
+	var ids []string
+	if err := q.db.SelectContext(ctx, &ids, query, string(channel), q.visibilityTimeout.Seconds(), limit); err != nil {
+		q.logger.Error("Failed to dequeue notifications", zap.String("channel", string(channel)), zap.Error(err))
+		return nil, fmt.Errorf("failed to dequeue notifications: %w", err)
+	}
+
+	notifications := make([]*domain.Notification, 0, len(ids))
+	for _, id := range ids {
+		notificationID, err := uuid.Parse(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid notification id in queue: %w", err)
+		}
+
+		notification, err := q.notificationRepo.GetByID(ctx, notificationID)
+		if err == domain.ErrNotificationNotFound {
+			// Notificação removida: descartar item órfão
+			if ackErr := q.Ack(ctx, notificationID); ackErr != nil {
+				q.logger.Error("Failed to remove orphan queue item", zap.Error(ackErr))
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		notifications = append(notifications, notification)
+	}
+
+	// RETURNING não garante ordem
+	sort.SliceStable(notifications, func(i, j int) bool {
+		return notifications[i].Priority.Rank() > notifications[j].Priority.Rank()
+	})
+
+	return notifications, nil
+}
+
+// Ack confirma o processamento e remove o item da fila
+func (q *PostgresNotificationQueue) Ack(ctx context.Context, notificationID uuid.UUID) error {
+	if _, err := q.db.ExecContext(ctx, `DELETE FROM notification_queue WHERE notification_id = $1`, notificationID.String()); err != nil {
+		return fmt.Errorf("failed to ack notification: %w", err)
+	}
+	return nil
+}
+
+// Nack devolve o item à fila, visível novamente a partir de retryAt
+func (q *PostgresNotificationQueue) Nack(ctx context.Context, notificationID uuid.UUID, retryAt time.Time) error {
+	query := `UPDATE notification_queue SET available_at = $2, locked_until = NULL WHERE notification_id = $1`
+	if _, err := q.db.ExecContext(ctx, query, notificationID.String(), retryAt); err != nil {
+		return fmt.Errorf("failed to nack notification: %w", err)
+	}
+	return nil
+}
+
+// QueueSize retorna a quantidade de itens do canal
+func (q *PostgresNotificationQueue) QueueSize(ctx context.Context, channel domain.NotificationChannel) (int64, error) {
+	var size int64
+	query := `SELECT COUNT(*) FROM notification_queue WHERE channel = $1`
+	if err := q.db.GetContext(ctx, &size, query, string(channel)); err != nil {
+		return 0, fmt.Errorf("failed to get queue size: %w", err)
+	}
+	return size, nil
+}
+
+// QueueSizeByPriority retorna a quantidade de itens do canal com a prioridade
+func (q *PostgresNotificationQueue) QueueSizeByPriority(ctx context.Context, channel domain.NotificationChannel, priority domain.NotificationPriority) (int64, error) {
+	var size int64
+	query := `SELECT COUNT(*) FROM notification_queue WHERE channel = $1 AND priority = $2`
+	if err := q.db.GetContext(ctx, &size, query, string(channel), string(priority)); err != nil {
+		return 0, fmt.Errorf("failed to get queue size by priority: %w", err)
+	}
+	return size, nil
+}
+
+// Clear remove todos os itens do canal
+func (q *PostgresNotificationQueue) Clear(ctx context.Context, channel domain.NotificationChannel) error {
+	if _, err := q.db.ExecContext(ctx, `DELETE FROM notification_queue WHERE channel = $1`, string(channel)); err != nil {
+		return fmt.Errorf("failed to clear queue: %w", err)
+	}
+
+	q.logger.Info("Queue cleared", zap.String("channel", string(channel)))
+	return nil
+}
+
+// Health verifica a conexão com o banco
+func (q *PostgresNotificationQueue) Health(ctx context.Context) error {
+	if err := q.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrQueueUnavailable, err)
+	}
+	return nil
+}
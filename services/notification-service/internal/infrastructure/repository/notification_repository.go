@@ -40,20 +40,34 @@ type notificationDB struct {
 	Status               string         `db:"status"`
 	Subject              string         `db:"subject"`
 	Content              string         `db:"content"`
+	ContentHTML          *string        `db:"content_html"`
 	RecipientID          string         `db:"recipient_id"`
 	RecipientType        string         `db:"recipient_type"`
 	RecipientContact     string         `db:"recipient_contact"`
 	TemplateID           *string        `db:"template_id"`
+	TemplateVersionID    *string        `db:"template_version_id"`
+	TemplateVariant      *string        `db:"template_variant"`
+	Locale               *string        `db:"locale"`
+	FallbackRootID       *string        `db:"fallback_root_id"`
+	FallbackStep         int            `db:"fallback_step"`
+	DigestAt             *time.Time     `db:"digest_at"`
+	DigestID             *string        `db:"digest_id"`
 	VariablesJSON        string         `db:"variables"`
 	MetadataJSON         string         `db:"metadata"`
+	AttachmentsJSON      string         `db:"attachments"`
 	RetryCount           int            `db:"retry_count"`
 	MaxRetries           int            `db:"max_retries"`
 	NextRetryAt          *time.Time     `db:"next_retry_at"`
 	ScheduledAt          *time.Time     `db:"scheduled_at"`
 	SentAt               *time.Time     `db:"sent_at"`
 	DeliveredAt          *time.Time     `db:"delivered_at"`
+	ReadAt               *time.Time     `db:"read_at"`
+	OpenedAt             *time.Time     `db:"opened_at"`
+	ClickedAt            *time.Time     `db:"clicked_at"`
 	FailedAt             *time.Time     `db:"failed_at"`
 	ErrorMessage         *string        `db:"error_message"`
+	FailureCategory      *string        `db:"failure_category"`
+	Cost                 float64        `db:"cost"`
 	ExternalID           *string        `db:"external_id"`
 	ExternalStatus       *string        `db:"external_status"`
 	ProcessingStartedAt  *time.Time     `db:"processing_started_at"`
@@ -71,15 +85,17 @@ func (r *PostgresNotificationRepository) Create(ctx context.Context, notificatio
 
 	query := `
 		INSERT INTO notifications (
-			id, tenant_id, type, channel, priority, status, subject, content,
+			id, tenant_id, type, channel, priority, status, subject, content, content_html,
 			recipient_id, recipient_type, recipient_contact, template_id,
-			variables, metadata, retry_count, max_retries, next_retry_at,
-			scheduled_at, tags, created_at, updated_at
+			template_version_id, template_variant, locale,
+			variables, metadata, attachments, retry_count, max_retries, next_retry_at,
+			scheduled_at, tags, fallback_root_id, fallback_step, digest_at, digest_id, created_at, updated_at
 		) VALUES (
-			:id, :tenant_id, :type, :channel, :priority, :status, :subject, :content,
+			:id, :tenant_id, :type, :channel, :priority, :status, :subject, :content, :content_html,
 			:recipient_id, :recipient_type, :recipient_contact, :template_id,
-			:variables, :metadata, :retry_count, :max_retries, :next_retry_at,
-			:scheduled_at, :tags, :created_at, :updated_at
+			:template_version_id, :template_variant, :locale,
+			:variables, :metadata, :attachments, :retry_count, :max_retries, :next_retry_at,
+			:scheduled_at, :tags, :fallback_root_id, :fallback_step, :digest_at, :digest_id, :created_at, :updated_at
 		)`
 
 	_, err := r.db.NamedExecContext(ctx, query, notifDB)
@@ -126,8 +142,11 @@ func (r *PostgresNotificationRepository) Update(ctx context.Context, notificatio
 	query := `
 		UPDATE notifications SET
 			status = :status, retry_count = :retry_count, next_retry_at = :next_retry_at,
-			sent_at = :sent_at, delivered_at = :delivered_at, failed_at = :failed_at,
-			error_message = :error_message, external_id = :external_id,
+			scheduled_at = :scheduled_at, digest_at = :digest_at, digest_id = :digest_id,
+			sent_at = :sent_at, delivered_at = :delivered_at, read_at = :read_at, failed_at = :failed_at,
+			opened_at = :opened_at, clicked_at = :clicked_at,
+			error_message = :error_message, failure_category = :failure_category, cost = :cost,
+			external_id = :external_id,
 			external_status = :external_status, processing_started_at = :processing_started_at,
 			processing_finished_at = :processing_finished_at, metadata = :metadata,
 			updated_at = :updated_at
@@ -344,6 +363,87 @@ func (r *PostgresNotificationRepository) FindForRetry(ctx context.Context, limit
 	return notifications, nil
 }
 
+// FindByExternalID busca notificação pelo ID da mensagem no provedor
+func (r *PostgresNotificationRepository) FindByExternalID(ctx context.Context, channel domain.NotificationChannel, externalID string) (*domain.Notification, error) {
+	r.logger.Debug("Finding notification by external ID",
+		zap.String("channel", string(channel)),
+		zap.String("external_id", externalID))
+
+	var notifDB notificationDB
+	query := `SELECT * FROM notifications WHERE channel = $1 AND external_id = $2 ORDER BY created_at DESC LIMIT 1`
+
+	err := r.db.GetContext(ctx, &notifDB, query, string(channel), externalID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotificationNotFound
+		}
+		r.logger.Error("Failed to find notification by external ID", zap.Error(err))
+		return nil, fmt.Errorf("failed to find notification by external ID: %w", err)
+	}
+
+	notification, err := r.fromDatabase(&notifDB)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert notification from database: %w", err)
+	}
+
+	return notification, nil
+}
+
+// FindFallbackAttempts busca todas as tentativas da cadeia de fallback (incluindo a raiz) em ordem
+func (r *PostgresNotificationRepository) FindFallbackAttempts(ctx context.Context, rootID uuid.UUID) ([]*domain.Notification, error) {
+	r.logger.Debug("Finding fallback attempts", zap.String("root_id", rootID.String()))
+
+	query := `SELECT * FROM notifications WHERE fallback_root_id = $1 ORDER BY fallback_step ASC`
+
+	var notificationsDB []notificationDB
+	err := r.db.SelectContext(ctx, &notificationsDB, query, rootID.String())
+	if err != nil {
+		r.logger.Error("Failed to find fallback attempts", zap.Error(err))
+		return nil, fmt.Errorf("failed to find fallback attempts: %w", err)
+	}
+
+	notifications := make([]*domain.Notification, len(notificationsDB))
+	for i, notifDB := range notificationsDB {
+		notification, err := r.fromDatabase(&notifDB)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert notification from database: %w", err)
+		}
+		notifications[i] = notification
+	}
+
+	return notifications, nil
+}
+
+// FindDueDigestItems busca notificações aguardando resumo com envio previsto até dueBefore,
+// ordenadas por destinatário e canal para agrupamento
+func (r *PostgresNotificationRepository) FindDueDigestItems(ctx context.Context, dueBefore time.Time, limit int) ([]*domain.Notification, error) {
+	r.logger.Debug("Finding due digest items", zap.Time("due_before", dueBefore))
+
+	query := `
+		SELECT * FROM notifications 
+		WHERE status = 'batched' AND digest_at <= $1 
+		ORDER BY tenant_id, recipient_id, channel, recipient_contact, created_at ASC 
+		LIMIT $2`
+
+	var notificationsDB []notificationDB
+	err := r.db.SelectContext(ctx, &notificationsDB, query, dueBefore, limit)
+	if err != nil {
+		r.logger.Error("Failed to find due digest items", zap.Error(err))
+		return nil, fmt.Errorf("failed to find due digest items: %w", err)
+	}
+
+	notifications := make([]*domain.Notification, len(notificationsDB))
+	for i, notifDB := range notificationsDB {
+		notification, err := r.fromDatabase(&notifDB)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert notification from database: %w", err)
+		}
+		notifications[i] = notification
+	}
+
+	return notifications, nil
+}
+
 // CountByTenant conta notificações por tenant
 func (r *PostgresNotificationRepository) CountByTenant(ctx context.Context, tenantID uuid.UUID, filters domain.NotificationFilters) (int64, error) {
 	r.logger.Debug("Counting notifications by tenant", zap.String("tenant_id", tenantID.String()))
@@ -405,14 +505,16 @@ func (r *PostgresNotificationRepository) GetStatistics(ctx context.Context, tena
 	query := `
 		SELECT 
 			COUNT(*) as total,
-			COUNT(CASE WHEN status = 'sent' THEN 1 END) as sent,
+			COUNT(CASE WHEN status IN ('sent', 'delivered', 'read') THEN 1 END) as sent,
+			COUNT(CASE WHEN status IN ('delivered', 'read') THEN 1 END) as delivered,
+			COUNT(CASE WHEN status = 'read' THEN 1 END) as read,
 			COUNT(CASE WHEN status = 'failed' THEN 1 END) as failed,
 			COUNT(CASE WHEN status = 'pending' THEN 1 END) as pending,
-			COUNT(CASE WHEN channel = 'whatsapp' AND status = 'sent' THEN 1 END) as whatsapp_sent,
-			COUNT(CASE WHEN channel = 'email' AND status = 'sent' THEN 1 END) as email_sent,
-			COUNT(CASE WHEN channel = 'telegram' AND status = 'sent' THEN 1 END) as telegram_sent,
-			COUNT(CASE WHEN channel = 'push' AND status = 'sent' THEN 1 END) as push_sent,
-			COUNT(CASE WHEN channel = 'sms' AND status = 'sent' THEN 1 END) as sms_sent,
+			COUNT(CASE WHEN channel = 'whatsapp' AND status IN ('sent', 'delivered', 'read') THEN 1 END) as whatsapp_sent,
+			COUNT(CASE WHEN channel = 'email' AND status IN ('sent', 'delivered', 'read') THEN 1 END) as email_sent,
+			COUNT(CASE WHEN channel = 'telegram' AND status IN ('sent', 'delivered', 'read') THEN 1 END) as telegram_sent,
+			COUNT(CASE WHEN channel = 'push' AND status IN ('sent', 'delivered', 'read') THEN 1 END) as push_sent,
+			COUNT(CASE WHEN channel = 'sms' AND status IN ('sent', 'delivered', 'read') THEN 1 END) as sms_sent,
 			COUNT(CASE WHEN type = 'process_update' THEN 1 END) as process_updates,
 			COUNT(CASE WHEN type = 'movement_alert' THEN 1 END) as movement_alerts,
 			COUNT(CASE WHEN type = 'deadline_reminder' THEN 1 END) as deadline_reminders,
@@ -425,6 +527,8 @@ func (r *PostgresNotificationRepository) GetStatistics(ctx context.Context, tena
 	type statsRow struct {
 		Total              int64   `db:"total"`
 		Sent               int64   `db:"sent"`
+		Delivered          int64   `db:"delivered"`
+		Read               int64   `db:"read"`
 		Failed             int64   `db:"failed"`
 		Pending            int64   `db:"pending"`
 		WhatsAppSent       int64   `db:"whatsapp_sent"`
@@ -453,6 +557,8 @@ func (r *PostgresNotificationRepository) GetStatistics(ctx context.Context, tena
 		TotalSent:          row.Sent,
 		TotalFailed:        row.Failed,
 		TotalPending:       row.Pending,
+		TotalDelivered:     row.Delivered,
+		TotalRead:          row.Read,
 		WhatsAppSent:       row.WhatsAppSent,
 		EmailSent:          row.EmailSent,
 		TelegramSent:       row.TelegramSent,
@@ -467,10 +573,17 @@ func (r *PostgresNotificationRepository) GetStatistics(ctx context.Context, tena
 
 	// Calcular taxas
 	if row.Total > 0 {
-		stats.DeliveryRate = float64(row.Sent) / float64(row.Total) * 100
 		stats.ErrorRate = float64(row.Failed) / float64(row.Total) * 100
 	}
 
+	// Entrega e leitura confirmadas pelos recibos dos provedores
+	if row.Sent > 0 {
+		stats.DeliveryRate = float64(row.Delivered) / float64(row.Sent) * 100
+	}
+	if row.Delivered > 0 {
+		stats.ReadRate = float64(row.Read) / float64(row.Delivered) * 100
+	}
+
 	if row.AvgRetries != nil {
 		stats.AverageRetries = *row.AvgRetries
 		stats.RetryRate = *row.AvgRetries / float64(row.Total) * 100
@@ -483,6 +596,158 @@ func (r *PostgresNotificationRepository) GetStatistics(ctx context.Context, tena
 	return stats, nil
 }
 
+// analyticsKeys expressão de agrupamento de cada dimensão das análises
+var analyticsKeys = map[domain.AnalyticsDimension]string{
+	"":                         "'total'",
+	domain.AnalyticsByType:     "n.type",
+	domain.AnalyticsByChannel:  "n.channel",
+	domain.AnalyticsByTemplate: "COALESCE(n.template_id::text, '')",
+	domain.AnalyticsByTenant:   "n.tenant_id::text",
+}
+
+// analyticsLatency segundos entre o envio previsto (agendamento ou criação) e a entrega
+const analyticsLatency = `EXTRACT(EPOCH FROM (n.delivered_at - GREATEST(n.created_at, COALESCE(n.scheduled_at, n.created_at))))`
+
+// GetAnalytics obtém funil, tempo até a entrega, custo e falhas por categoria, agrupados pela
+// dimensão dos filtros
+func (r *PostgresNotificationRepository) GetAnalytics(ctx context.Context, filters domain.AnalyticsFilters) ([]*domain.AnalyticsGroup, error) {
+	r.logger.Debug("Getting notification analytics", zap.String("group_by", string(filters.GroupBy)))
+
+	key, ok := analyticsKeys[filters.GroupBy]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", domain.ErrInvalidAnalyticsFilter, filters.GroupBy)
+	}
+
+	conditions := []string{"n.created_at >= $1", "n.created_at < $2"}
+	args := []interface{}{filters.From, filters.To}
+	if filters.TenantID != nil {
+		args = append(args, filters.TenantID.String())
+		conditions = append(conditions, fmt.Sprintf("n.tenant_id = $%d", len(args)))
+	}
+	if filters.Channel != nil {
+		args = append(args, string(*filters.Channel))
+		conditions = append(conditions, fmt.Sprintf("n.channel = $%d", len(args)))
+	}
+	if filters.Type != nil {
+		args = append(args, string(*filters.Type))
+		conditions = append(conditions, fmt.Sprintf("n.type = $%d", len(args)))
+	}
+	if filters.TemplateID != nil {
+		args = append(args, filters.TemplateID.String())
+		conditions = append(conditions, fmt.Sprintf("n.template_id = $%d", len(args)))
+	}
+	where := strings.Join(conditions, " AND ")
+
+	label, join := "''", ""
+	if filters.GroupBy == domain.AnalyticsByTemplate {
+		label, join = "COALESCE(MAX(t.name), '')", "LEFT JOIN notification_templates t ON t.id = n.template_id"
+	}
+
+	funnelQuery := fmt.Sprintf(`
+		SELECT
+			%[1]s AS key,
+			%[2]s AS label,
+			COUNT(*) AS created,
+			COUNT(CASE WHEN n.sent_at IS NOT NULL OR n.delivered_at IS NOT NULL THEN 1 END) AS sent,
+			COUNT(n.delivered_at) AS delivered,
+			COUNT(n.read_at) AS read,
+			COUNT(CASE WHEN n.status = 'failed' OR n.failure_category IS NOT NULL THEN 1 END) AS failed,
+			COALESCE(SUM(n.cost), 0) AS cost,
+			COUNT(%[3]s) AS latency_samples,
+			AVG(%[3]s) AS latency_avg,
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY %[3]s) AS latency_p50,
+			percentile_cont(0.9) WITHIN GROUP (ORDER BY %[3]s) AS latency_p90,
+			percentile_cont(0.99) WITHIN GROUP (ORDER BY %[3]s) AS latency_p99
+		FROM notifications n %[4]s
+		WHERE %[5]s
+		GROUP BY 1
+		ORDER BY created DESC`, key, label, analyticsLatency, join, where)
+
+	type funnelRow struct {
+		Key            string   `db:"key"`
+		Label          string   `db:"label"`
+		Created        int64    `db:"created"`
+		Sent           int64    `db:"sent"`
+		Delivered      int64    `db:"delivered"`
+		Read           int64    `db:"read"`
+		Failed         int64    `db:"failed"`
+		Cost           float64  `db:"cost"`
+		LatencySamples int64    `db:"latency_samples"`
+		LatencyAvg     *float64 `db:"latency_avg"`
+		LatencyP50     *float64 `db:"latency_p50"`
+		LatencyP90     *float64 `db:"latency_p90"`
+		LatencyP99     *float64 `db:"latency_p99"`
+	}
+
+	var rows []funnelRow
+	if err := r.db.SelectContext(ctx, &rows, funnelQuery, args...); err != nil {
+		r.logger.Error("Failed to get notification analytics", zap.Error(err))
+		return nil, fmt.Errorf("failed to get notification analytics: %w", err)
+	}
+
+	groups := make([]*domain.AnalyticsGroup, 0, len(rows))
+	byKey := make(map[string]*domain.AnalyticsGroup, len(rows))
+	for _, row := range rows {
+		group := &domain.AnalyticsGroup{
+			Key:   row.Key,
+			Label: row.Label,
+			Funnel: domain.NotificationFunnel{
+				Created:   row.Created,
+				Sent:      row.Sent,
+				Delivered: row.Delivered,
+				Read:      row.Read,
+				Failed:    row.Failed,
+			},
+			Latency: domain.DeliveryLatency{
+				Samples: row.LatencySamples,
+				Average: valueOrZero(row.LatencyAvg),
+				P50:     valueOrZero(row.LatencyP50),
+				P90:     valueOrZero(row.LatencyP90),
+				P99:     valueOrZero(row.LatencyP99),
+			},
+			Failures: make(map[domain.FailureCategory]int64),
+			Cost:     row.Cost,
+		}
+		group.Funnel.CalculateRates()
+		groups = append(groups, group)
+		byKey[row.Key] = group
+	}
+
+	failuresQuery := fmt.Sprintf(`
+		SELECT %s AS key, COALESCE(n.failure_category, 'other') AS category, COUNT(*) AS total
+		FROM notifications n
+		WHERE %s AND (n.status = 'failed' OR n.failure_category IS NOT NULL)
+		GROUP BY 1, 2`, key, where)
+
+	type failureRow struct {
+		Key      string `db:"key"`
+		Category string `db:"category"`
+		Total    int64  `db:"total"`
+	}
+
+	var failures []failureRow
+	if err := r.db.SelectContext(ctx, &failures, failuresQuery, args...); err != nil {
+		r.logger.Error("Failed to get notification failures", zap.Error(err))
+		return nil, fmt.Errorf("failed to get notification failures: %w", err)
+	}
+
+	for _, failure := range failures {
+		if group, ok := byKey[failure.Key]; ok {
+			group.Failures[domain.FailureCategory(failure.Category)] = failure.Total
+		}
+	}
+
+	return groups, nil
+}
+
+// valueOrZero valor de agregação que pode ser NULL (grupo sem amostras)
+func valueOrZero(value *float64) float64 {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
+
 // CreateBatch cria múltiplas notificações em lote
 func (r *PostgresNotificationRepository) CreateBatch(ctx context.Context, notifications []*domain.Notification) error {
 	if len(notifications) == 0 {
@@ -499,15 +764,17 @@ func (r *PostgresNotificationRepository) CreateBatch(ctx context.Context, notifi
 
 	query := `
 		INSERT INTO notifications (
-			id, tenant_id, type, channel, priority, status, subject, content,
+			id, tenant_id, type, channel, priority, status, subject, content, content_html,
 			recipient_id, recipient_type, recipient_contact, template_id,
-			variables, metadata, retry_count, max_retries, next_retry_at,
-			scheduled_at, tags, created_at, updated_at
+			template_version_id, template_variant, locale,
+			variables, metadata, attachments, retry_count, max_retries, next_retry_at,
+			scheduled_at, tags, fallback_root_id, fallback_step, digest_at, digest_id, created_at, updated_at
 		) VALUES (
-			:id, :tenant_id, :type, :channel, :priority, :status, :subject, :content,
+			:id, :tenant_id, :type, :channel, :priority, :status, :subject, :content, :content_html,
 			:recipient_id, :recipient_type, :recipient_contact, :template_id,
-			:variables, :metadata, :retry_count, :max_retries, :next_retry_at,
-			:scheduled_at, :tags, :created_at, :updated_at
+			:template_version_id, :template_variant, :locale,
+			:variables, :metadata, :attachments, :retry_count, :max_retries, :next_retry_at,
+			:scheduled_at, :tags, :fallback_root_id, :fallback_step, :digest_at, :digest_id, :created_at, :updated_at
 		)`
 
 	for _, notification := range notifications {
@@ -544,8 +811,11 @@ func (r *PostgresNotificationRepository) UpdateBatch(ctx context.Context, notifi
 	query := `
 		UPDATE notifications SET
 			status = :status, retry_count = :retry_count, next_retry_at = :next_retry_at,
-			sent_at = :sent_at, delivered_at = :delivered_at, failed_at = :failed_at,
-			error_message = :error_message, external_id = :external_id,
+			scheduled_at = :scheduled_at, digest_at = :digest_at, digest_id = :digest_id,
+			sent_at = :sent_at, delivered_at = :delivered_at, read_at = :read_at, failed_at = :failed_at,
+			opened_at = :opened_at, clicked_at = :clicked_at,
+			error_message = :error_message, failure_category = :failure_category, cost = :cost,
+			external_id = :external_id,
 			external_status = :external_status, processing_started_at = :processing_started_at,
 			processing_finished_at = :processing_finished_at, metadata = :metadata,
 			updated_at = :updated_at
@@ -585,10 +855,12 @@ func (r *PostgresNotificationRepository) DeleteExpired(ctx context.Context, expi
 
 	query := `
 		DELETE FROM notifications 
-		WHERE status IN ($1, $2, $3) AND created_at < $4`
+		WHERE status IN ($1, $2, $3, $4, $5) AND created_at < $6`
 
 	result, err := r.db.ExecContext(ctx, query, 
 		string(domain.NotificationStatusSent),
+		string(domain.NotificationStatusDelivered),
+		string(domain.NotificationStatusRead),
 		string(domain.NotificationStatusFailed),
 		string(domain.NotificationStatusExpired),
 		expiredBefore)
@@ -667,6 +939,11 @@ func (r *PostgresNotificationRepository) buildFilterQuery(baseQuery string, base
 		argIndex++
 	}
 
+	// Cadeias de fallback aparecem como uma única notificação lógica (a raiz)
+	if !filters.IncludeAttempts {
+		conditions = append(conditions, "(fallback_root_id IS NULL OR fallback_root_id = id)")
+	}
+
 	if len(conditions) > 0 {
 		query += " AND " + strings.Join(conditions, " AND ")
 	}
@@ -704,12 +981,36 @@ func (r *PostgresNotificationRepository) toDatabase(notification *domain.Notific
 	variablesJSON, _ := json.Marshal(notification.Variables)
 	metadataJSON, _ := json.Marshal(notification.Metadata)
 
+	attachments := notification.Attachments
+	if attachments == nil {
+		attachments = []domain.NotificationAttachment{}
+	}
+	attachmentsJSON, _ := json.Marshal(attachments)
+
 	var templateID *string
 	if notification.TemplateID != nil {
 		id := notification.TemplateID.String()
 		templateID = &id
 	}
 
+	var templateVersionID *string
+	if notification.TemplateVersionID != nil {
+		id := notification.TemplateVersionID.String()
+		templateVersionID = &id
+	}
+
+	var fallbackRootID *string
+	if notification.FallbackRootID != nil {
+		id := notification.FallbackRootID.String()
+		fallbackRootID = &id
+	}
+
+	var digestID *string
+	if notification.DigestID != nil {
+		id := notification.DigestID.String()
+		digestID = &id
+	}
+
 	return &notificationDB{
 		ID:                   notification.ID.String(),
 		TenantID:             notification.TenantID.String(),
@@ -719,20 +1020,34 @@ func (r *PostgresNotificationRepository) toDatabase(notification *domain.Notific
 		Status:               string(notification.Status),
 		Subject:              notification.Subject,
 		Content:              notification.Content,
+		ContentHTML:          notification.ContentHTML,
 		RecipientID:          notification.RecipientID,
 		RecipientType:        notification.RecipientType,
 		RecipientContact:     notification.RecipientContact,
 		TemplateID:           templateID,
+		TemplateVersionID:    templateVersionID,
+		TemplateVariant:      nullableString(notification.TemplateVariant),
+		Locale:               nullableString(notification.Locale),
+		FallbackRootID:       fallbackRootID,
+		FallbackStep:         notification.FallbackStep,
+		DigestAt:             notification.DigestAt,
+		DigestID:             digestID,
 		VariablesJSON:        string(variablesJSON),
 		MetadataJSON:         string(metadataJSON),
+		AttachmentsJSON:      string(attachmentsJSON),
 		RetryCount:           notification.RetryCount,
 		MaxRetries:           notification.MaxRetries,
 		NextRetryAt:          notification.NextRetryAt,
 		ScheduledAt:          notification.ScheduledAt,
 		SentAt:               notification.SentAt,
 		DeliveredAt:          notification.DeliveredAt,
+		ReadAt:               notification.ReadAt,
+		OpenedAt:             notification.OpenedAt,
+		ClickedAt:            notification.ClickedAt,
 		FailedAt:             notification.FailedAt,
 		ErrorMessage:         notification.ErrorMessage,
+		FailureCategory:      nullableString(string(notification.FailureCategory)),
+		Cost:                 notification.Cost,
 		ExternalID:           notification.ExternalID,
 		ExternalStatus:       notification.ExternalStatus,
 		ProcessingStartedAt:  notification.ProcessingStartedAt,
@@ -764,6 +1079,41 @@ func (r *PostgresNotificationRepository) fromDatabase(notifDB *notificationDB) (
 		templateID = &id
 	}
 
+	var templateVersionID *uuid.UUID
+	if notifDB.TemplateVersionID != nil {
+		id, err := uuid.Parse(*notifDB.TemplateVersionID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template version ID: %w", err)
+		}
+		templateVersionID = &id
+	}
+
+	var templateVariant, locale string
+	if notifDB.TemplateVariant != nil {
+		templateVariant = *notifDB.TemplateVariant
+	}
+	if notifDB.Locale != nil {
+		locale = *notifDB.Locale
+	}
+
+	var fallbackRootID *uuid.UUID
+	if notifDB.FallbackRootID != nil {
+		id, err := uuid.Parse(*notifDB.FallbackRootID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fallback root ID: %w", err)
+		}
+		fallbackRootID = &id
+	}
+
+	var digestID *uuid.UUID
+	if notifDB.DigestID != nil {
+		id, err := uuid.Parse(*notifDB.DigestID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid digest ID: %w", err)
+		}
+		digestID = &id
+	}
+
 	var variables map[string]interface{}
 	if notifDB.VariablesJSON != "" {
 		if err := json.Unmarshal([]byte(notifDB.VariablesJSON), &variables); err != nil {
@@ -778,6 +1128,18 @@ func (r *PostgresNotificationRepository) fromDatabase(notifDB *notificationDB) (
 		}
 	}
 
+	var failureCategory domain.FailureCategory
+	if notifDB.FailureCategory != nil {
+		failureCategory = domain.FailureCategory(*notifDB.FailureCategory)
+	}
+
+	var attachments []domain.NotificationAttachment
+	if notifDB.AttachmentsJSON != "" {
+		if err := json.Unmarshal([]byte(notifDB.AttachmentsJSON), &attachments); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal attachments: %w", err)
+		}
+	}
+
 	return &domain.Notification{
 		ID:                   id,
 		TenantID:             tenantID,
@@ -787,20 +1149,34 @@ func (r *PostgresNotificationRepository) fromDatabase(notifDB *notificationDB) (
 		Status:               domain.NotificationStatus(notifDB.Status),
 		Subject:              notifDB.Subject,
 		Content:              notifDB.Content,
+		ContentHTML:          notifDB.ContentHTML,
 		RecipientID:          notifDB.RecipientID,
 		RecipientType:        notifDB.RecipientType,
 		RecipientContact:     notifDB.RecipientContact,
 		TemplateID:           templateID,
+		TemplateVersionID:    templateVersionID,
+		TemplateVariant:      templateVariant,
+		Locale:               locale,
+		FallbackRootID:       fallbackRootID,
+		FallbackStep:         notifDB.FallbackStep,
+		DigestAt:             notifDB.DigestAt,
+		DigestID:             digestID,
 		Variables:            variables,
 		Metadata:             metadata,
+		Attachments:          attachments,
 		RetryCount:           notifDB.RetryCount,
 		MaxRetries:           notifDB.MaxRetries,
 		NextRetryAt:          notifDB.NextRetryAt,
 		ScheduledAt:          notifDB.ScheduledAt,
 		SentAt:               notifDB.SentAt,
 		DeliveredAt:          notifDB.DeliveredAt,
+		ReadAt:               notifDB.ReadAt,
+		OpenedAt:             notifDB.OpenedAt,
+		ClickedAt:            notifDB.ClickedAt,
 		FailedAt:             notifDB.FailedAt,
 		ErrorMessage:         notifDB.ErrorMessage,
+		FailureCategory:      failureCategory,
+		Cost:                 notifDB.Cost,
 		ExternalID:           notifDB.ExternalID,
 		ExternalStatus:       notifDB.ExternalStatus,
 		ProcessingStartedAt:  notifDB.ProcessingStartedAt,
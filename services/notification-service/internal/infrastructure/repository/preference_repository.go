@@ -36,6 +36,7 @@ type preferenceDB struct {
 	Type      string         `db:"type"`
 	Channels  pq.StringArray `db:"channels"`
 	Enabled   bool           `db:"enabled"`
+	DigestFrequency string   `db:"digest_frequency"`
 	CreatedAt time.Time      `db:"created_at"`
 	UpdatedAt time.Time      `db:"updated_at"`
 }
@@ -48,9 +49,9 @@ func (r *PostgresPreferenceRepository) Create(ctx context.Context, preference *d
 
 	query := `
 		INSERT INTO notification_preferences (
-			id, tenant_id, user_id, type, channels, enabled, created_at, updated_at
+			id, tenant_id, user_id, type, channels, enabled, digest_frequency, created_at, updated_at
 		) VALUES (
-			:id, :tenant_id, :user_id, :type, :channels, :enabled, :created_at, :updated_at
+			:id, :tenant_id, :user_id, :type, :channels, :enabled, :digest_frequency, :created_at, :updated_at
 		)`
 
 	_, err := r.db.NamedExecContext(ctx, query, prefDB)
@@ -96,7 +97,8 @@ func (r *PostgresPreferenceRepository) Update(ctx context.Context, preference *d
 
 	query := `
 		UPDATE notification_preferences SET
-			channels = :channels, enabled = :enabled, updated_at = :updated_at
+			channels = :channels, enabled = :enabled, digest_frequency = :digest_frequency,
+			updated_at = :updated_at
 		WHERE id = :id`
 
 	result, err := r.db.NamedExecContext(ctx, query, prefDB)
@@ -217,12 +219,13 @@ func (r *PostgresPreferenceRepository) UpsertUserPreferences(ctx context.Context
 	// Query para upsert (INSERT ... ON CONFLICT ... DO UPDATE)
 	query := `
 		INSERT INTO notification_preferences (
-			id, tenant_id, user_id, type, channels, enabled, created_at, updated_at
+			id, tenant_id, user_id, type, channels, enabled, digest_frequency, created_at, updated_at
 		) VALUES (
-			:id, :tenant_id, :user_id, :type, :channels, :enabled, :created_at, :updated_at
+			:id, :tenant_id, :user_id, :type, :channels, :enabled, :digest_frequency, :created_at, :updated_at
 		) ON CONFLICT (user_id, type) DO UPDATE SET
 			channels = EXCLUDED.channels,
 			enabled = EXCLUDED.enabled,
+			digest_frequency = EXCLUDED.digest_frequency,
 			updated_at = EXCLUDED.updated_at`
 
 	for _, preference := range preferences {
@@ -349,6 +352,11 @@ func (r *PostgresPreferenceRepository) toDatabase(preference *domain.Notificatio
 		channels[i] = string(channel)
 	}
 
+	digestFrequency := preference.DigestFrequency
+	if digestFrequency == "" {
+		digestFrequency = domain.DigestFrequencyNone
+	}
+
 	return &preferenceDB{
 		ID:        preference.ID.String(),
 		TenantID:  preference.TenantID.String(),
@@ -356,6 +364,7 @@ func (r *PostgresPreferenceRepository) toDatabase(preference *domain.Notificatio
 		Type:      string(preference.Type),
 		Channels:  pq.StringArray(channels),
 		Enabled:   preference.Enabled,
+		DigestFrequency: string(digestFrequency),
 		CreatedAt: preference.CreatedAt,
 		UpdatedAt: preference.UpdatedAt,
 	}
@@ -390,6 +399,7 @@ func (r *PostgresPreferenceRepository) fromDatabase(prefDB *preferenceDB) (*doma
 		Type:      domain.NotificationType(prefDB.Type),
 		Channels:  channels,
 		Enabled:   prefDB.Enabled,
+		DigestFrequency: domain.DigestFrequency(prefDB.DigestFrequency),
 		CreatedAt: prefDB.CreatedAt,
 		UpdatedAt: prefDB.UpdatedAt,
 	}, nil
@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// PostgresProcessWatcherRepository implementação PostgreSQL do ProcessWatcherRepository
+type PostgresProcessWatcherRepository struct {
+	db     *sqlx.DB
+	logger *zap.Logger
+}
+
+// NewPostgresProcessWatcherRepository cria nova instância do repositório
+func NewPostgresProcessWatcherRepository(db *sqlx.DB, logger *zap.Logger) domain.ProcessWatcherRepository {
+	return &PostgresProcessWatcherRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// processWatcherDB representa o destinatário do processo no banco de dados
+type processWatcherDB struct {
+	TenantID     string    `db:"tenant_id"`
+	ProcessID    string    `db:"process_id"`
+	RecipientID  string    `db:"recipient_id"`
+	Role         string    `db:"role"`
+	Name         string    `db:"name"`
+	ContactsJSON string    `db:"contacts"`
+	OptedIn      bool      `db:"opted_in"`
+	CreatedAt    time.Time `db:"created_at"`
+	UpdatedAt    time.Time `db:"updated_at"`
+}
+
+// Upsert cria ou substitui o destinatário do processo
+func (r *PostgresProcessWatcherRepository) Upsert(ctx context.Context, watcher *domain.ProcessWatcher) error {
+	query := `
+		INSERT INTO process_watchers (tenant_id, process_id, recipient_id, role, name, contacts, opted_in, created_at, updated_at)
+		VALUES (:tenant_id, :process_id, :recipient_id, :role, :name, :contacts, :opted_in, :created_at, :updated_at)
+		ON CONFLICT (tenant_id, process_id, recipient_id) DO UPDATE SET
+			role = EXCLUDED.role,
+			name = EXCLUDED.name,
+			contacts = EXCLUDED.contacts,
+			opted_in = EXCLUDED.opted_in,
+			updated_at = EXCLUDED.updated_at`
+
+	contactsJSON, err := json.Marshal(watcher.Contacts)
+	if err != nil {
+		return fmt.Errorf("failed to marshal contacts: %w", err)
+	}
+
+	watcherDB := &processWatcherDB{
+		TenantID:     watcher.TenantID.String(),
+		ProcessID:    watcher.ProcessID.String(),
+		RecipientID:  watcher.RecipientID,
+		Role:         string(watcher.Role),
+		Name:         watcher.Name,
+		ContactsJSON: string(contactsJSON),
+		OptedIn:      watcher.OptedIn,
+		CreatedAt:    watcher.CreatedAt,
+		UpdatedAt:    watcher.UpdatedAt,
+	}
+
+	if _, err := r.db.NamedExecContext(ctx, query, watcherDB); err != nil {
+		r.logger.Error("Failed to upsert process watcher", zap.Error(err))
+		return fmt.Errorf("failed to upsert process watcher: %w", err)
+	}
+
+	return nil
+}
+
+// FindByProcess lista os destinatários do processo
+func (r *PostgresProcessWatcherRepository) FindByProcess(ctx context.Context, tenantID, processID uuid.UUID) ([]*domain.ProcessWatcher, error) {
+	query := `SELECT * FROM process_watchers WHERE tenant_id = $1 AND process_id = $2 ORDER BY created_at`
+
+	var watchersDB []processWatcherDB
+	if err := r.db.SelectContext(ctx, &watchersDB, query, tenantID.String(), processID.String()); err != nil {
+		r.logger.Error("Failed to find process watchers", zap.Error(err))
+		return nil, fmt.Errorf("failed to find process watchers: %w", err)
+	}
+
+	watchers := make([]*domain.ProcessWatcher, 0, len(watchersDB))
+	for i := range watchersDB {
+		watcher, err := r.fromDatabase(&watchersDB[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert process watcher from database: %w", err)
+		}
+		watchers = append(watchers, watcher)
+	}
+
+	return watchers, nil
+}
+
+// Delete remove o destinatário do processo
+func (r *PostgresProcessWatcherRepository) Delete(ctx context.Context, tenantID, processID uuid.UUID, recipientID string) error {
+	query := `DELETE FROM process_watchers WHERE tenant_id = $1 AND process_id = $2 AND recipient_id = $3`
+
+	result, err := r.db.ExecContext(ctx, query, tenantID.String(), processID.String(), recipientID)
+	if err != nil {
+		r.logger.Error("Failed to delete process watcher", zap.Error(err))
+		return fmt.Errorf("failed to delete process watcher: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrProcessWatcherNotFound
+	}
+
+	return nil
+}
+
+// fromDatabase converte do modelo do banco
+func (r *PostgresProcessWatcherRepository) fromDatabase(watcherDB *processWatcherDB) (*domain.ProcessWatcher, error) {
+	tenantID, err := uuid.Parse(watcherDB.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tenant_id: %w", err)
+	}
+	processID, err := uuid.Parse(watcherDB.ProcessID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid process_id: %w", err)
+	}
+
+	contacts := make(map[domain.NotificationChannel]string)
+	if watcherDB.ContactsJSON != "" {
+		if err := json.Unmarshal([]byte(watcherDB.ContactsJSON), &contacts); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal contacts: %w", err)
+		}
+	}
+
+	return &domain.ProcessWatcher{
+		TenantID:    tenantID,
+		ProcessID:   processID,
+		RecipientID: watcherDB.RecipientID,
+		Role:        domain.ProcessWatcherRole(watcherDB.Role),
+		Name:        watcherDB.Name,
+		Contacts:    contacts,
+		OptedIn:     watcherDB.OptedIn,
+		CreatedAt:   watcherDB.CreatedAt,
+		UpdatedAt:   watcherDB.UpdatedAt,
+	}, nil
+}
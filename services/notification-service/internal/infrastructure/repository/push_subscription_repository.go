@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// PostgresPushSubscriptionRepository implementação PostgreSQL do PushSubscriptionRepository
+type PostgresPushSubscriptionRepository struct {
+	db     *sqlx.DB
+	logger *zap.Logger
+}
+
+// NewPostgresPushSubscriptionRepository cria nova instância do repositório
+func NewPostgresPushSubscriptionRepository(db *sqlx.DB, logger *zap.Logger) domain.PushSubscriptionRepository {
+	return &PostgresPushSubscriptionRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// pushSubscriptionDB representa a inscrição Web Push no banco de dados
+type pushSubscriptionDB struct {
+	ID         string     `db:"id"`
+	TenantID   string     `db:"tenant_id"`
+	UserID     string     `db:"user_id"`
+	Endpoint   string     `db:"endpoint"`
+	P256dh     string     `db:"p256dh"`
+	Auth       string     `db:"auth"`
+	DeviceName *string    `db:"device_name"`
+	UserAgent  *string    `db:"user_agent"`
+	LastUsedAt *time.Time `db:"last_used_at"`
+	CreatedAt  time.Time  `db:"created_at"`
+	UpdatedAt  time.Time  `db:"updated_at"`
+}
+
+// Upsert cria a inscrição ou atualiza as chaves do endpoint. O endpoint é único por navegador:
+// reinscrição (ou troca de usuário no mesmo navegador) substitui o dono e as chaves.
+func (r *PostgresPushSubscriptionRepository) Upsert(ctx context.Context, subscription *domain.PushSubscription) error {
+	subscriptionDB := r.toDatabase(subscription)
+	subscriptionDB.UpdatedAt = time.Now()
+
+	query := `
+		INSERT INTO push_subscriptions (
+			id, tenant_id, user_id, endpoint, p256dh, auth, device_name, user_agent,
+			last_used_at, created_at, updated_at
+		) VALUES (
+			:id, :tenant_id, :user_id, :endpoint, :p256dh, :auth, :device_name, :user_agent,
+			:last_used_at, :created_at, :updated_at
+		) ON CONFLICT (endpoint) DO UPDATE SET
+			tenant_id = EXCLUDED.tenant_id,
+			user_id = EXCLUDED.user_id,
+			p256dh = EXCLUDED.p256dh,
+			auth = EXCLUDED.auth,
+			device_name = EXCLUDED.device_name,
+			user_agent = EXCLUDED.user_agent,
+			updated_at = EXCLUDED.updated_at
+		RETURNING id, created_at`
+
+	rows, err := r.db.NamedQueryContext(ctx, query, subscriptionDB)
+	if err != nil {
+		r.logger.Error("Failed to upsert push subscription", zap.Error(err))
+		return fmt.Errorf("failed to upsert push subscription: %w", err)
+	}
+	defer rows.Close()
+
+	// Em reinscrição, devolve o ID e a criação do registro existente
+	if rows.Next() {
+		var id string
+		if err := rows.Scan(&id, &subscription.CreatedAt); err != nil {
+			return fmt.Errorf("failed to scan push subscription: %w", err)
+		}
+		if subscription.ID, err = uuid.Parse(id); err != nil {
+			return fmt.Errorf("failed to parse push subscription id: %w", err)
+		}
+	}
+	subscription.UpdatedAt = subscriptionDB.UpdatedAt
+
+	return rows.Err()
+}
+
+// FindByUser lista as inscrições do usuário
+func (r *PostgresPushSubscriptionRepository) FindByUser(ctx context.Context, tenantID, userID uuid.UUID) ([]*domain.PushSubscription, error) {
+	query := `SELECT * FROM push_subscriptions WHERE tenant_id = $1 AND user_id = $2 ORDER BY created_at`
+
+	var subscriptionsDB []pushSubscriptionDB
+	if err := r.db.SelectContext(ctx, &subscriptionsDB, query, tenantID.String(), userID.String()); err != nil {
+		r.logger.Error("Failed to find push subscriptions", zap.Error(err))
+		return nil, fmt.Errorf("failed to find push subscriptions: %w", err)
+	}
+
+	subscriptions := make([]*domain.PushSubscription, 0, len(subscriptionsDB))
+	for i := range subscriptionsDB {
+		subscription, err := r.fromDatabase(&subscriptionsDB[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert push subscription from database: %w", err)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions, nil
+}
+
+// Delete remove a inscrição do usuário
+func (r *PostgresPushSubscriptionRepository) Delete(ctx context.Context, tenantID, userID, id uuid.UUID) error {
+	query := `DELETE FROM push_subscriptions WHERE id = $1 AND tenant_id = $2 AND user_id = $3`
+
+	result, err := r.db.ExecContext(ctx, query, id.String(), tenantID.String(), userID.String())
+	if err != nil {
+		r.logger.Error("Failed to delete push subscription", zap.Error(err))
+		return fmt.Errorf("failed to delete push subscription: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrPushSubscriptionNotFound
+	}
+
+	return nil
+}
+
+// DeleteByEndpoint remove a inscrição expirada
+func (r *PostgresPushSubscriptionRepository) DeleteByEndpoint(ctx context.Context, endpoint string) error {
+	query := `DELETE FROM push_subscriptions WHERE endpoint = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, endpoint); err != nil {
+		r.logger.Error("Failed to delete push subscription by endpoint", zap.Error(err))
+		return fmt.Errorf("failed to delete push subscription: %w", err)
+	}
+
+	return nil
+}
+
+// MarkUsed registra o último envio aceito pelo serviço de push
+func (r *PostgresPushSubscriptionRepository) MarkUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	query := `UPDATE push_subscriptions SET last_used_at = $2 WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, id.String(), usedAt); err != nil {
+		return fmt.Errorf("failed to mark push subscription as used: %w", err)
+	}
+
+	return nil
+}
+
+// toDatabase converte domain para database
+func (r *PostgresPushSubscriptionRepository) toDatabase(subscription *domain.PushSubscription) *pushSubscriptionDB {
+	return &pushSubscriptionDB{
+		ID:         subscription.ID.String(),
+		TenantID:   subscription.TenantID.String(),
+		UserID:     subscription.UserID.String(),
+		Endpoint:   subscription.Endpoint,
+		P256dh:     subscription.P256dh,
+		Auth:       subscription.Auth,
+		DeviceName: nullableString(subscription.DeviceName),
+		UserAgent:  nullableString(subscription.UserAgent),
+		LastUsedAt: subscription.LastUsedAt,
+		CreatedAt:  subscription.CreatedAt,
+		UpdatedAt:  subscription.UpdatedAt,
+	}
+}
+
+// fromDatabase converte database para domain
+func (r *PostgresPushSubscriptionRepository) fromDatabase(subscriptionDB *pushSubscriptionDB) (*domain.PushSubscription, error) {
+	id, err := uuid.Parse(subscriptionDB.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id: %w", err)
+	}
+	tenantID, err := uuid.Parse(subscriptionDB.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tenant_id: %w", err)
+	}
+	userID, err := uuid.Parse(subscriptionDB.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user_id: %w", err)
+	}
+
+	subscription := &domain.PushSubscription{
+		ID:         id,
+		TenantID:   tenantID,
+		UserID:     userID,
+		Endpoint:   subscriptionDB.Endpoint,
+		P256dh:     subscriptionDB.P256dh,
+		Auth:       subscriptionDB.Auth,
+		LastUsedAt: subscriptionDB.LastUsedAt,
+		CreatedAt:  subscriptionDB.CreatedAt,
+		UpdatedAt:  subscriptionDB.UpdatedAt,
+	}
+	if subscriptionDB.DeviceName != nil {
+		subscription.DeviceName = *subscriptionDB.DeviceName
+	}
+	if subscriptionDB.UserAgent != nil {
+		subscription.UserAgent = *subscriptionDB.UserAgent
+	}
+
+	return subscription, nil
+}
+
+// nullableString converte string vazia em NULL
+func nullableString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
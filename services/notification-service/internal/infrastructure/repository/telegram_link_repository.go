@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// PostgresTelegramLinkRepository implementação PostgreSQL do TelegramLinkRepository
+type PostgresTelegramLinkRepository struct {
+	db     *sqlx.DB
+	logger *zap.Logger
+}
+
+// NewPostgresTelegramLinkRepository cria nova instância do repositório
+func NewPostgresTelegramLinkRepository(db *sqlx.DB, logger *zap.Logger) domain.TelegramLinkRepository {
+	return &PostgresTelegramLinkRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// telegramLinkCodeDB representa o código de vinculação no banco de dados
+type telegramLinkCodeDB struct {
+	Code      string     `db:"code"`
+	TenantID  string     `db:"tenant_id"`
+	UserID    string     `db:"user_id"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	UsedAt    *time.Time `db:"used_at"`
+	CreatedAt time.Time  `db:"created_at"`
+}
+
+// telegramLinkDB representa o chat vinculado no banco de dados
+type telegramLinkDB struct {
+	ChatID   string    `db:"chat_id"`
+	TenantID string    `db:"tenant_id"`
+	UserID   string    `db:"user_id"`
+	Username *string   `db:"username"`
+	LinkedAt time.Time `db:"linked_at"`
+}
+
+// CreateCode salva o código gerado para o usuário
+func (r *PostgresTelegramLinkRepository) CreateCode(ctx context.Context, code *domain.TelegramLinkCode) error {
+	query := `
+		INSERT INTO telegram_link_codes (code, tenant_id, user_id, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	if _, err := r.db.ExecContext(ctx, query, code.Code, code.TenantID.String(), code.UserID.String(), code.ExpiresAt, code.CreatedAt); err != nil {
+		r.logger.Error("Failed to create telegram link code", zap.Error(err))
+		return fmt.Errorf("failed to create telegram link code: %w", err)
+	}
+
+	return nil
+}
+
+// ConsumeCode marca o código como usado em uma única instrução, evitando que dois chats usem o
+// mesmo código
+func (r *PostgresTelegramLinkRepository) ConsumeCode(ctx context.Context, code string, now time.Time) (*domain.TelegramLinkCode, error) {
+	query := `
+		UPDATE telegram_link_codes SET used_at = $2
+		WHERE code = $1 AND used_at IS NULL AND expires_at > $2
+		RETURNING *`
+
+	var codeDB telegramLinkCodeDB
+	if err := r.db.GetContext(ctx, &codeDB, query, code, now); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrTelegramLinkCodeInvalid
+		}
+		r.logger.Error("Failed to consume telegram link code", zap.Error(err))
+		return nil, fmt.Errorf("failed to consume telegram link code: %w", err)
+	}
+
+	tenantID, err := uuid.Parse(codeDB.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tenant_id: %w", err)
+	}
+	userID, err := uuid.Parse(codeDB.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user_id: %w", err)
+	}
+
+	return &domain.TelegramLinkCode{
+		Code:      codeDB.Code,
+		TenantID:  tenantID,
+		UserID:    userID,
+		ExpiresAt: codeDB.ExpiresAt,
+		UsedAt:    codeDB.UsedAt,
+		CreatedAt: codeDB.CreatedAt,
+	}, nil
+}
+
+// Link vincula o chat ao usuário; um novo código substitui o vínculo anterior do chat
+func (r *PostgresTelegramLinkRepository) Link(ctx context.Context, link *domain.TelegramLink) error {
+	query := `
+		INSERT INTO telegram_links (chat_id, tenant_id, user_id, username, linked_at)
+		VALUES (:chat_id, :tenant_id, :user_id, :username, :linked_at)
+		ON CONFLICT (chat_id) DO UPDATE SET
+			tenant_id = EXCLUDED.tenant_id,
+			user_id = EXCLUDED.user_id,
+			username = EXCLUDED.username,
+			linked_at = EXCLUDED.linked_at`
+
+	linkDB := &telegramLinkDB{
+		ChatID:   link.ChatID,
+		TenantID: link.TenantID.String(),
+		UserID:   link.UserID.String(),
+		Username: nullableString(link.Username),
+		LinkedAt: link.LinkedAt,
+	}
+
+	if _, err := r.db.NamedExecContext(ctx, query, linkDB); err != nil {
+		r.logger.Error("Failed to link telegram chat", zap.Error(err))
+		return fmt.Errorf("failed to link telegram chat: %w", err)
+	}
+
+	return nil
+}
+
+// GetByChat busca o vínculo do chat
+func (r *PostgresTelegramLinkRepository) GetByChat(ctx context.Context, chatID string) (*domain.TelegramLink, error) {
+	var linkDB telegramLinkDB
+	query := `SELECT * FROM telegram_links WHERE chat_id = $1`
+
+	if err := r.db.GetContext(ctx, &linkDB, query, chatID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrTelegramChatNotLinked
+		}
+		r.logger.Error("Failed to get telegram link", zap.Error(err))
+		return nil, fmt.Errorf("failed to get telegram link: %w", err)
+	}
+
+	return r.fromDatabase(&linkDB)
+}
+
+// FindByUser lista os chats vinculados ao usuário
+func (r *PostgresTelegramLinkRepository) FindByUser(ctx context.Context, tenantID, userID uuid.UUID) ([]*domain.TelegramLink, error) {
+	query := `SELECT * FROM telegram_links WHERE tenant_id = $1 AND user_id = $2 ORDER BY linked_at`
+
+	var linksDB []telegramLinkDB
+	if err := r.db.SelectContext(ctx, &linksDB, query, tenantID.String(), userID.String()); err != nil {
+		r.logger.Error("Failed to find telegram links", zap.Error(err))
+		return nil, fmt.Errorf("failed to find telegram links: %w", err)
+	}
+
+	links := make([]*domain.TelegramLink, 0, len(linksDB))
+	for i := range linksDB {
+		link, err := r.fromDatabase(&linksDB[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert telegram link from database: %w", err)
+		}
+		links = append(links, link)
+	}
+
+	return links, nil
+}
+
+// Unlink remove o vínculo do chat com o usuário
+func (r *PostgresTelegramLinkRepository) Unlink(ctx context.Context, tenantID, userID uuid.UUID, chatID string) error {
+	query := `DELETE FROM telegram_links WHERE chat_id = $1 AND tenant_id = $2 AND user_id = $3`
+
+	result, err := r.db.ExecContext(ctx, query, chatID, tenantID.String(), userID.String())
+	if err != nil {
+		r.logger.Error("Failed to unlink telegram chat", zap.Error(err))
+		return fmt.Errorf("failed to unlink telegram chat: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrTelegramChatNotLinked
+	}
+
+	return nil
+}
+
+// fromDatabase converte database para domain
+func (r *PostgresTelegramLinkRepository) fromDatabase(linkDB *telegramLinkDB) (*domain.TelegramLink, error) {
+	tenantID, err := uuid.Parse(linkDB.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tenant_id: %w", err)
+	}
+	userID, err := uuid.Parse(linkDB.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user_id: %w", err)
+	}
+
+	link := &domain.TelegramLink{
+		ChatID:   linkDB.ChatID,
+		TenantID: tenantID,
+		UserID:   userID,
+		LinkedAt: linkDB.LinkedAt,
+	}
+	if linkDB.Username != nil {
+		link.Username = *linkDB.Username
+	}
+
+	return link, nil
+}
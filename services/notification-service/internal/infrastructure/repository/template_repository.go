@@ -3,13 +3,13 @@ package repository
 import (
 	"context"
 	"database/sql"
-	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"go.uber.org/zap"
 
 	"github.com/direito-lux/notification-service/internal/domain"
@@ -38,9 +38,11 @@ type templateDB struct {
 	Status       string         `db:"status"`
 	Subject      string         `db:"subject"`
 	Content      string         `db:"content"`
-	VariablesJSON string        `db:"variables"`
+	ContentHTML  *string        `db:"content_html"`
+	Variables    pq.StringArray `db:"variables"`
 	TenantID     *string        `db:"tenant_id"`
 	IsSystem     bool           `db:"is_system"`
+	DefaultLocale string        `db:"default_locale"`
 	CreatedAt    time.Time      `db:"created_at"`
 	UpdatedAt    time.Time      `db:"updated_at"`
 }
@@ -53,11 +55,11 @@ func (r *PostgresTemplateRepository) Create(ctx context.Context, template *domai
 
 	query := `
 		INSERT INTO notification_templates (
-			id, name, type, channel, status, subject, content,
-			variables, tenant_id, is_system, created_at, updated_at
+			id, name, type, channel, status, subject, content, content_html,
+			variables, tenant_id, is_system, default_locale, created_at, updated_at
 		) VALUES (
-			:id, :name, :type, :channel, :status, :subject, :content,
-			:variables, :tenant_id, :is_system, :created_at, :updated_at
+			:id, :name, :type, :channel, :status, :subject, :content, :content_html,
+			:variables, :tenant_id, :is_system, :default_locale, :created_at, :updated_at
 		)`
 
 	_, err := r.db.NamedExecContext(ctx, query, templateDB)
@@ -104,7 +106,8 @@ func (r *PostgresTemplateRepository) Update(ctx context.Context, template *domai
 	query := `
 		UPDATE notification_templates SET
 			name = :name, type = :type, channel = :channel, status = :status,
-			subject = :subject, content = :content, variables = :variables,
+			subject = :subject, content = :content, content_html = :content_html,
+			variables = :variables, default_locale = :default_locale,
 			updated_at = :updated_at
 		WHERE id = :id`
 
@@ -445,8 +448,6 @@ func (r *PostgresTemplateRepository) buildFilterQuery(baseQuery string, baseArgs
 
 // toDatabase converte domain.NotificationTemplate para templateDB
 func (r *PostgresTemplateRepository) toDatabase(template *domain.NotificationTemplate) *templateDB {
-	variablesJSON, _ := json.Marshal(template.Variables)
-
 	var tenantID *string
 	if template.TenantID != nil {
 		id := template.TenantID.String()
@@ -461,9 +462,11 @@ func (r *PostgresTemplateRepository) toDatabase(template *domain.NotificationTem
 		Status:       string(template.Status),
 		Subject:      template.Subject,
 		Content:      template.Content,
-		VariablesJSON: string(variablesJSON),
+		ContentHTML:  template.ContentHTML,
+		Variables:    pq.StringArray(template.Variables),
 		TenantID:     tenantID,
 		IsSystem:     template.IsSystem,
+		DefaultLocale: template.DefaultLocale,
 		CreatedAt:    template.CreatedAt,
 		UpdatedAt:    template.UpdatedAt,
 	}
@@ -485,11 +488,9 @@ func (r *PostgresTemplateRepository) fromDatabase(templateDB *templateDB) (*doma
 		tenantID = &id
 	}
 
-	var variables []string
-	if templateDB.VariablesJSON != "" {
-		if err := json.Unmarshal([]byte(templateDB.VariablesJSON), &variables); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal variables: %w", err)
-		}
+	defaultLocale := templateDB.DefaultLocale
+	if defaultLocale == "" {
+		defaultLocale = domain.DefaultLocale
 	}
 
 	return &domain.NotificationTemplate{
@@ -500,9 +501,11 @@ func (r *PostgresTemplateRepository) fromDatabase(templateDB *templateDB) (*doma
 		Status:    domain.TemplateStatus(templateDB.Status),
 		Subject:   templateDB.Subject,
 		Content:   templateDB.Content,
-		Variables: variables,
+		ContentHTML: templateDB.ContentHTML,
+		Variables: []string(templateDB.Variables),
 		TenantID:  tenantID,
 		IsSystem:  templateDB.IsSystem,
+		DefaultLocale: defaultLocale,
 		CreatedAt: templateDB.CreatedAt,
 		UpdatedAt: templateDB.UpdatedAt,
 	}, nil
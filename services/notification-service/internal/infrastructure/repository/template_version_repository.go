@@ -0,0 +1,290 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// PostgresTemplateVersionRepository implementação PostgreSQL do TemplateVersionRepository
+type PostgresTemplateVersionRepository struct {
+	db     *sqlx.DB
+	logger *zap.Logger
+}
+
+// NewPostgresTemplateVersionRepository cria nova instância do repositório
+func NewPostgresTemplateVersionRepository(db *sqlx.DB, logger *zap.Logger) domain.TemplateVersionRepository {
+	return &PostgresTemplateVersionRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// templateVersionDB representa uma versão de template no banco de dados
+type templateVersionDB struct {
+	ID          string         `db:"id"`
+	TemplateID  string         `db:"template_id"`
+	Version     int            `db:"version"`
+	Locale      string         `db:"locale"`
+	Variant     string         `db:"variant"`
+	Weight      int            `db:"weight"`
+	Status      string         `db:"status"`
+	Subject     string         `db:"subject"`
+	Content     string         `db:"content"`
+	ContentHTML *string        `db:"content_html"`
+	Variables   pq.StringArray `db:"variables"`
+	CreatedAt   time.Time      `db:"created_at"`
+	PublishedAt *time.Time     `db:"published_at"`
+}
+
+// Create grava a versão com o próximo número sequencial do template
+func (r *PostgresTemplateVersionRepository) Create(ctx context.Context, version *domain.TemplateVersion) error {
+	r.logger.Debug("Creating template version", zap.String("template_id", version.TemplateID.String()))
+
+	versionDB := r.toDatabase(version)
+
+	// O índice único (template_id, version) protege contra criação concorrente
+	query := `
+		INSERT INTO notification_template_versions (
+			id, template_id, version, locale, variant, weight, status,
+			subject, content, content_html, variables, created_at, published_at
+		)
+		SELECT
+			:id, :template_id, COALESCE(MAX(version), 0) + 1, :locale, :variant, :weight, :status,
+			:subject, :content, :content_html, :variables, :created_at, :published_at
+		FROM notification_template_versions
+		WHERE template_id = :template_id
+		RETURNING version`
+
+	rows, err := r.db.NamedQueryContext(ctx, query, versionDB)
+	if err != nil {
+		r.logger.Error("Failed to create template version", zap.Error(err))
+		return fmt.Errorf("failed to create template version: %w", err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		if err := rows.Scan(&version.Version); err != nil {
+			return fmt.Errorf("failed to scan template version: %w", err)
+		}
+	}
+
+	return rows.Err()
+}
+
+// GetByID busca uma versão por ID
+func (r *PostgresTemplateVersionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.TemplateVersion, error) {
+	var versionDB templateVersionDB
+	query := `SELECT * FROM notification_template_versions WHERE id = $1`
+
+	if err := r.db.GetContext(ctx, &versionDB, query, id.String()); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrTemplateVersionNotFound
+		}
+		r.logger.Error("Failed to get template version", zap.Error(err))
+		return nil, fmt.Errorf("failed to get template version: %w", err)
+	}
+
+	return r.fromDatabase(&versionDB)
+}
+
+// FindByTemplate lista todas as versões do template, da mais recente para a mais antiga
+func (r *PostgresTemplateVersionRepository) FindByTemplate(ctx context.Context, templateID uuid.UUID) ([]*domain.TemplateVersion, error) {
+	query := `SELECT * FROM notification_template_versions WHERE template_id = $1 ORDER BY version DESC`
+	return r.findVersions(ctx, query, templateID.String())
+}
+
+// FindPublished lista as versões publicadas do template, em ordem estável para o sorteio A/B
+func (r *PostgresTemplateVersionRepository) FindPublished(ctx context.Context, templateID uuid.UUID) ([]*domain.TemplateVersion, error) {
+	query := `
+		SELECT * FROM notification_template_versions
+		WHERE template_id = $1 AND status = 'published'
+		ORDER BY locale, variant`
+	return r.findVersions(ctx, query, templateID.String())
+}
+
+// Publish publica a versão e arquiva a publicada anteriormente no mesmo idioma e variante
+func (r *PostgresTemplateVersionRepository) Publish(ctx context.Context, version *domain.TemplateVersion) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	archiveQuery := `
+		UPDATE notification_template_versions SET status = 'archived'
+		WHERE template_id = $1 AND locale = $2 AND variant = $3 AND status = 'published' AND id <> $4`
+	if _, err := tx.ExecContext(ctx, archiveQuery,
+		version.TemplateID.String(), version.Locale, version.Variant, version.ID.String()); err != nil {
+		r.logger.Error("Failed to archive published template version", zap.Error(err))
+		return fmt.Errorf("failed to archive published template version: %w", err)
+	}
+
+	now := time.Now()
+	publishQuery := `
+		UPDATE notification_template_versions SET status = 'published', published_at = $2
+		WHERE id = $1`
+	result, err := tx.ExecContext(ctx, publishQuery, version.ID.String(), now)
+	if err != nil {
+		r.logger.Error("Failed to publish template version", zap.Error(err))
+		return fmt.Errorf("failed to publish template version: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrTemplateVersionNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	version.Status = domain.TemplateVersionStatusPublished
+	version.PublishedAt = &now
+
+	r.logger.Info("Template version published",
+		zap.String("template_id", version.TemplateID.String()),
+		zap.Int("version", version.Version),
+		zap.String("locale", version.Locale),
+		zap.String("variant", version.Variant))
+	return nil
+}
+
+// Archive arquiva a versão, retirando-a do sorteio
+func (r *PostgresTemplateVersionRepository) Archive(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE notification_template_versions SET status = 'archived' WHERE id = $1`
+	return r.execVersionUpdate(ctx, query, id.String())
+}
+
+// UpdateWeight altera o peso da variante no sorteio A/B
+func (r *PostgresTemplateVersionRepository) UpdateWeight(ctx context.Context, id uuid.UUID, weight int) error {
+	query := `UPDATE notification_template_versions SET weight = $2 WHERE id = $1`
+	return r.execVersionUpdate(ctx, query, id.String(), weight)
+}
+
+// GetVariantStats agrega envio e engajamento das notificações por versão do template
+func (r *PostgresTemplateVersionRepository) GetVariantStats(ctx context.Context, templateID uuid.UUID, since time.Time) ([]*domain.TemplateVariantStats, error) {
+	query := `
+		SELECT
+			v.id AS template_version_id, v.version, v.locale, v.variant,
+			COUNT(*) FILTER (WHERE n.sent_at IS NOT NULL OR n.status IN ('sent', 'delivered', 'read')) AS sent,
+			COUNT(n.delivered_at) AS delivered,
+			COUNT(n.opened_at) AS opened,
+			COUNT(n.clicked_at) AS clicked,
+			COUNT(n.read_at) AS read
+		FROM notifications n
+		JOIN notification_template_versions v ON v.id = n.template_version_id
+		WHERE v.template_id = $1 AND n.created_at >= $2
+		GROUP BY v.id, v.version, v.locale, v.variant
+		ORDER BY v.locale, v.variant, v.version`
+
+	var stats []*domain.TemplateVariantStats
+	if err := r.db.SelectContext(ctx, &stats, query, templateID.String(), since); err != nil {
+		r.logger.Error("Failed to get template variant stats", zap.Error(err))
+		return nil, fmt.Errorf("failed to get template variant stats: %w", err)
+	}
+
+	for _, s := range stats {
+		s.CalculateRates()
+	}
+
+	return stats, nil
+}
+
+// findVersions executa consulta de versões e converte o resultado
+func (r *PostgresTemplateVersionRepository) findVersions(ctx context.Context, query string, args ...interface{}) ([]*domain.TemplateVersion, error) {
+	var versionsDB []templateVersionDB
+	if err := r.db.SelectContext(ctx, &versionsDB, query, args...); err != nil {
+		r.logger.Error("Failed to find template versions", zap.Error(err))
+		return nil, fmt.Errorf("failed to find template versions: %w", err)
+	}
+
+	versions := make([]*domain.TemplateVersion, 0, len(versionsDB))
+	for i := range versionsDB {
+		version, err := r.fromDatabase(&versionsDB[i])
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, version)
+	}
+
+	return versions, nil
+}
+
+// execVersionUpdate executa atualização de uma versão e verifica se ela existe
+func (r *PostgresTemplateVersionRepository) execVersionUpdate(ctx context.Context, query string, args ...interface{}) error {
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		r.logger.Error("Failed to update template version", zap.Error(err))
+		return fmt.Errorf("failed to update template version: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrTemplateVersionNotFound
+	}
+
+	return nil
+}
+
+// toDatabase converte domain.TemplateVersion para templateVersionDB
+func (r *PostgresTemplateVersionRepository) toDatabase(version *domain.TemplateVersion) *templateVersionDB {
+	return &templateVersionDB{
+		ID:          version.ID.String(),
+		TemplateID:  version.TemplateID.String(),
+		Version:     version.Version,
+		Locale:      version.Locale,
+		Variant:     version.Variant,
+		Weight:      version.Weight,
+		Status:      string(version.Status),
+		Subject:     version.Subject,
+		Content:     version.Content,
+		ContentHTML: version.ContentHTML,
+		Variables:   pq.StringArray(version.Variables),
+		CreatedAt:   version.CreatedAt,
+		PublishedAt: version.PublishedAt,
+	}
+}
+
+// fromDatabase converte templateVersionDB para domain.TemplateVersion
+func (r *PostgresTemplateVersionRepository) fromDatabase(versionDB *templateVersionDB) (*domain.TemplateVersion, error) {
+	id, err := uuid.Parse(versionDB.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template version ID: %w", err)
+	}
+
+	templateID, err := uuid.Parse(versionDB.TemplateID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template ID: %w", err)
+	}
+
+	return &domain.TemplateVersion{
+		ID:          id,
+		TemplateID:  templateID,
+		Version:     versionDB.Version,
+		Locale:      versionDB.Locale,
+		Variant:     versionDB.Variant,
+		Weight:      versionDB.Weight,
+		Status:      domain.TemplateVersionStatus(versionDB.Status),
+		Subject:     versionDB.Subject,
+		Content:     versionDB.Content,
+		ContentHTML: versionDB.ContentHTML,
+		Variables:   []string(versionDB.Variables),
+		CreatedAt:   versionDB.CreatedAt,
+		PublishedAt: versionDB.PublishedAt,
+	}, nil
+}
@@ -0,0 +1,502 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// PostgresWebhookSubscriptionRepository implementação PostgreSQL do WebhookSubscriptionRepository
+type PostgresWebhookSubscriptionRepository struct {
+	db     *sqlx.DB
+	logger *zap.Logger
+}
+
+// NewPostgresWebhookSubscriptionRepository cria nova instância do repositório
+func NewPostgresWebhookSubscriptionRepository(db *sqlx.DB, logger *zap.Logger) domain.WebhookSubscriptionRepository {
+	return &PostgresWebhookSubscriptionRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// webhookSubscriptionDB representa a inscrição no banco de dados
+type webhookSubscriptionDB struct {
+	ID                  string     `db:"id"`
+	TenantID            string     `db:"tenant_id"`
+	URL                 string     `db:"url"`
+	Description         *string    `db:"description"`
+	EventsJSON          string     `db:"events"`
+	Secret              string     `db:"secret"`
+	Active              bool       `db:"active"`
+	ConsecutiveFailures int        `db:"consecutive_failures"`
+	DisabledAt          *time.Time `db:"disabled_at"`
+	DisabledReason      *string    `db:"disabled_reason"`
+	LastDeliveryAt      *time.Time `db:"last_delivery_at"`
+	CreatedAt           time.Time  `db:"created_at"`
+	UpdatedAt           time.Time  `db:"updated_at"`
+}
+
+// Create grava a inscrição
+func (r *PostgresWebhookSubscriptionRepository) Create(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	subscriptionDB, err := r.toDatabase(subscription)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO webhook_subscriptions (
+			id, tenant_id, url, description, events, secret, active, consecutive_failures,
+			disabled_at, disabled_reason, last_delivery_at, created_at, updated_at
+		) VALUES (
+			:id, :tenant_id, :url, :description, :events, :secret, :active, :consecutive_failures,
+			:disabled_at, :disabled_reason, :last_delivery_at, :created_at, :updated_at
+		)`
+
+	if _, err := r.db.NamedExecContext(ctx, query, subscriptionDB); err != nil {
+		r.logger.Error("Failed to create webhook subscription", zap.Error(err))
+		return fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+
+	r.logger.Info("Webhook subscription created",
+		zap.String("subscription_id", subscription.ID.String()),
+		zap.String("tenant_id", subscription.TenantID.String()))
+	return nil
+}
+
+// Update atualiza a inscrição
+func (r *PostgresWebhookSubscriptionRepository) Update(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	subscriptionDB, err := r.toDatabase(subscription)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE webhook_subscriptions SET
+			url = :url,
+			description = :description,
+			events = :events,
+			secret = :secret,
+			active = :active,
+			consecutive_failures = :consecutive_failures,
+			disabled_at = :disabled_at,
+			disabled_reason = :disabled_reason,
+			last_delivery_at = :last_delivery_at,
+			updated_at = :updated_at
+		WHERE id = :id AND tenant_id = :tenant_id`
+
+	result, err := r.db.NamedExecContext(ctx, query, subscriptionDB)
+	if err != nil {
+		r.logger.Error("Failed to update webhook subscription", zap.Error(err))
+		return fmt.Errorf("failed to update webhook subscription: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// Delete remove a inscrição e o histórico de entregas
+func (r *PostgresWebhookSubscriptionRepository) Delete(ctx context.Context, tenantID, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM webhook_subscriptions WHERE id = $1 AND tenant_id = $2`,
+		id.String(), tenantID.String())
+	if err != nil {
+		r.logger.Error("Failed to delete webhook subscription", zap.Error(err))
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// FindByID busca a inscrição do tenant
+func (r *PostgresWebhookSubscriptionRepository) FindByID(ctx context.Context, tenantID, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	var subscriptionDB webhookSubscriptionDB
+	query := `SELECT * FROM webhook_subscriptions WHERE id = $1 AND tenant_id = $2`
+
+	if err := r.db.GetContext(ctx, &subscriptionDB, query, id.String(), tenantID.String()); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrWebhookNotFound
+		}
+		r.logger.Error("Failed to get webhook subscription", zap.Error(err))
+		return nil, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+
+	return r.fromDatabase(&subscriptionDB)
+}
+
+// ListByTenant lista as inscrições do tenant, das mais recentes para as mais antigas
+func (r *PostgresWebhookSubscriptionRepository) ListByTenant(ctx context.Context, tenantID uuid.UUID) ([]*domain.WebhookSubscription, error) {
+	var subscriptionsDB []webhookSubscriptionDB
+	query := `SELECT * FROM webhook_subscriptions WHERE tenant_id = $1 ORDER BY created_at DESC`
+
+	if err := r.db.SelectContext(ctx, &subscriptionsDB, query, tenantID.String()); err != nil {
+		r.logger.Error("Failed to list webhook subscriptions", zap.Error(err))
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+
+	subscriptions := make([]*domain.WebhookSubscription, 0, len(subscriptionsDB))
+	for i := range subscriptionsDB {
+		subscription, err := r.fromDatabase(&subscriptionsDB[i])
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions, nil
+}
+
+// CountActive conta as inscrições ativas do tenant (limite max_webhooks do plano)
+func (r *PostgresWebhookSubscriptionRepository) CountActive(ctx context.Context, tenantID uuid.UUID) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM webhook_subscriptions WHERE tenant_id = $1 AND active`
+
+	if err := r.db.GetContext(ctx, &count, query, tenantID.String()); err != nil {
+		r.logger.Error("Failed to count webhook subscriptions", zap.Error(err))
+		return 0, fmt.Errorf("failed to count webhook subscriptions: %w", err)
+	}
+
+	return count, nil
+}
+
+// toDatabase converte para o modelo do banco
+func (r *PostgresWebhookSubscriptionRepository) toDatabase(subscription *domain.WebhookSubscription) (*webhookSubscriptionDB, error) {
+	eventsJSON, err := json.Marshal(subscription.Events)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal events: %w", err)
+	}
+
+	return &webhookSubscriptionDB{
+		ID:                  subscription.ID.String(),
+		TenantID:            subscription.TenantID.String(),
+		URL:                 subscription.URL,
+		Description:         nullableString(subscription.Description),
+		EventsJSON:          string(eventsJSON),
+		Secret:              subscription.Secret,
+		Active:              subscription.Active,
+		ConsecutiveFailures: subscription.ConsecutiveFailures,
+		DisabledAt:          subscription.DisabledAt,
+		DisabledReason:      nullableString(subscription.DisabledReason),
+		LastDeliveryAt:      subscription.LastDeliveryAt,
+		CreatedAt:           subscription.CreatedAt,
+		UpdatedAt:           subscription.UpdatedAt,
+	}, nil
+}
+
+// fromDatabase converte do modelo do banco
+func (r *PostgresWebhookSubscriptionRepository) fromDatabase(subscriptionDB *webhookSubscriptionDB) (*domain.WebhookSubscription, error) {
+	id, err := uuid.Parse(subscriptionDB.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id: %w", err)
+	}
+	tenantID, err := uuid.Parse(subscriptionDB.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tenant_id: %w", err)
+	}
+
+	subscription := &domain.WebhookSubscription{
+		ID:                  id,
+		TenantID:            tenantID,
+		URL:                 subscriptionDB.URL,
+		Secret:              subscriptionDB.Secret,
+		Active:              subscriptionDB.Active,
+		ConsecutiveFailures: subscriptionDB.ConsecutiveFailures,
+		DisabledAt:          subscriptionDB.DisabledAt,
+		LastDeliveryAt:      subscriptionDB.LastDeliveryAt,
+		CreatedAt:           subscriptionDB.CreatedAt,
+		UpdatedAt:           subscriptionDB.UpdatedAt,
+	}
+	if subscriptionDB.Description != nil {
+		subscription.Description = *subscriptionDB.Description
+	}
+	if subscriptionDB.DisabledReason != nil {
+		subscription.DisabledReason = *subscriptionDB.DisabledReason
+	}
+	if err := json.Unmarshal([]byte(subscriptionDB.EventsJSON), &subscription.Events); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal events: %w", err)
+	}
+
+	return subscription, nil
+}
+
+// PostgresWebhookDeliveryRepository implementação PostgreSQL do WebhookDeliveryRepository
+type PostgresWebhookDeliveryRepository struct {
+	db     *sqlx.DB
+	logger *zap.Logger
+}
+
+// NewPostgresWebhookDeliveryRepository cria nova instância do repositório
+func NewPostgresWebhookDeliveryRepository(db *sqlx.DB, logger *zap.Logger) domain.WebhookDeliveryRepository {
+	return &PostgresWebhookDeliveryRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// webhookDeliveryDB representa a entrega no banco de dados
+type webhookDeliveryDB struct {
+	ID             string     `db:"id"`
+	SubscriptionID string     `db:"subscription_id"`
+	TenantID       string     `db:"tenant_id"`
+	EventID        string     `db:"event_id"`
+	EventType      string     `db:"event_type"`
+	Payload        string     `db:"payload"`
+	Status         string     `db:"status"`
+	Attempts       int        `db:"attempts"`
+	NextAttemptAt  *time.Time `db:"next_attempt_at"`
+	ResponseStatus *int       `db:"response_status"`
+	ResponseBody   *string    `db:"response_body"`
+	LastError      *string    `db:"last_error"`
+	DurationMs     int64      `db:"duration_ms"`
+	ReplayOf       *string    `db:"replay_of"`
+	CreatedAt      time.Time  `db:"created_at"`
+	UpdatedAt      time.Time  `db:"updated_at"`
+	DeliveredAt    *time.Time `db:"delivered_at"`
+}
+
+// Create grava a entrega. O índice único por inscrição e evento descarta as reentregas da
+// mesma mensagem do RabbitMQ.
+func (r *PostgresWebhookDeliveryRepository) Create(ctx context.Context, delivery *domain.WebhookDelivery) (bool, error) {
+	query := `
+		INSERT INTO webhook_deliveries (
+			id, subscription_id, tenant_id, event_id, event_type, payload, status, attempts,
+			next_attempt_at, response_status, response_body, last_error, duration_ms, replay_of,
+			created_at, updated_at, delivered_at
+		) VALUES (
+			:id, :subscription_id, :tenant_id, :event_id, :event_type, :payload, :status, :attempts,
+			:next_attempt_at, :response_status, :response_body, :last_error, :duration_ms, :replay_of,
+			:created_at, :updated_at, :delivered_at
+		) ON CONFLICT DO NOTHING`
+
+	result, err := r.db.NamedExecContext(ctx, query, r.toDatabase(delivery))
+	if err != nil {
+		r.logger.Error("Failed to create webhook delivery", zap.Error(err))
+		return false, fmt.Errorf("failed to create webhook delivery: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Update grava o resultado da tentativa
+func (r *PostgresWebhookDeliveryRepository) Update(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	query := `
+		UPDATE webhook_deliveries SET
+			status = :status,
+			attempts = :attempts,
+			next_attempt_at = :next_attempt_at,
+			response_status = :response_status,
+			response_body = :response_body,
+			last_error = :last_error,
+			duration_ms = :duration_ms,
+			updated_at = :updated_at,
+			delivered_at = :delivered_at
+		WHERE id = :id`
+
+	if _, err := r.db.NamedExecContext(ctx, query, r.toDatabase(delivery)); err != nil {
+		r.logger.Error("Failed to update webhook delivery",
+			zap.String("delivery_id", delivery.ID.String()),
+			zap.Error(err))
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID busca a entrega do tenant
+func (r *PostgresWebhookDeliveryRepository) FindByID(ctx context.Context, tenantID, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	var deliveryDB webhookDeliveryDB
+	query := `SELECT * FROM webhook_deliveries WHERE id = $1 AND tenant_id = $2`
+
+	if err := r.db.GetContext(ctx, &deliveryDB, query, id.String(), tenantID.String()); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrWebhookDeliveryNotFound
+		}
+		r.logger.Error("Failed to get webhook delivery", zap.Error(err))
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	return r.fromDatabase(&deliveryDB)
+}
+
+// ListBySubscription lista o histórico de entregas da inscrição, das mais recentes para as mais
+// antigas
+func (r *PostgresWebhookDeliveryRepository) ListBySubscription(ctx context.Context, tenantID, subscriptionID uuid.UUID, filter domain.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, error) {
+	args := []interface{}{tenantID.String(), subscriptionID.String()}
+	query := `SELECT * FROM webhook_deliveries WHERE tenant_id = $1 AND subscription_id = $2`
+	argIndex := 3
+
+	if filter.Status != nil {
+		query += fmt.Sprintf(" AND status = $%d", argIndex)
+		args = append(args, string(*filter.Status))
+		argIndex++
+	}
+	if filter.EventType != "" {
+		query += fmt.Sprintf(" AND event_type = $%d", argIndex)
+		args = append(args, filter.EventType)
+		argIndex++
+	}
+
+	limit := filter.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	query += fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+	args = append(args, limit, filter.Offset)
+
+	var deliveriesDB []webhookDeliveryDB
+	if err := r.db.SelectContext(ctx, &deliveriesDB, query, args...); err != nil {
+		r.logger.Error("Failed to list webhook deliveries", zap.Error(err))
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	return r.fromDatabaseList(deliveriesDB)
+}
+
+// ClaimDue reserva as entregas pendentes vencidas com SELECT ... FOR UPDATE SKIP LOCKED,
+// adiando next_attempt_at pelo lease para que outras instâncias não repitam a tentativa
+func (r *PostgresWebhookDeliveryRepository) ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*domain.WebhookDelivery, error) {
+	query := `
+		UPDATE webhook_deliveries d SET
+			next_attempt_at = $2
+		FROM (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at ASC
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		) due
+		WHERE d.id = due.id
+		RETURNING d.*`
+
+	var deliveriesDB []webhookDeliveryDB
+	if err := r.db.SelectContext(ctx, &deliveriesDB, query, now, now.Add(lease), limit); err != nil {
+		r.logger.Error("Failed to claim webhook deliveries", zap.Error(err))
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+
+	return r.fromDatabaseList(deliveriesDB)
+}
+
+// toDatabase converte para o modelo do banco
+func (r *PostgresWebhookDeliveryRepository) toDatabase(delivery *domain.WebhookDelivery) *webhookDeliveryDB {
+	deliveryDB := &webhookDeliveryDB{
+		ID:             delivery.ID.String(),
+		SubscriptionID: delivery.SubscriptionID.String(),
+		TenantID:       delivery.TenantID.String(),
+		EventID:        delivery.EventID.String(),
+		EventType:      delivery.EventType,
+		Payload:        string(delivery.Payload),
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		ResponseBody:   nullableString(delivery.ResponseBody),
+		LastError:      nullableString(delivery.LastError),
+		DurationMs:     delivery.DurationMs,
+		CreatedAt:      delivery.CreatedAt,
+		UpdatedAt:      delivery.UpdatedAt,
+		DeliveredAt:    delivery.DeliveredAt,
+	}
+	if delivery.ResponseStatus != 0 {
+		deliveryDB.ResponseStatus = &delivery.ResponseStatus
+	}
+	if delivery.ReplayOf != nil {
+		replayOf := delivery.ReplayOf.String()
+		deliveryDB.ReplayOf = &replayOf
+	}
+	return deliveryDB
+}
+
+// fromDatabase converte do modelo do banco
+func (r *PostgresWebhookDeliveryRepository) fromDatabase(deliveryDB *webhookDeliveryDB) (*domain.WebhookDelivery, error) {
+	id, err := uuid.Parse(deliveryDB.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id: %w", err)
+	}
+	subscriptionID, err := uuid.Parse(deliveryDB.SubscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subscription_id: %w", err)
+	}
+	tenantID, err := uuid.Parse(deliveryDB.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tenant_id: %w", err)
+	}
+	eventID, err := uuid.Parse(deliveryDB.EventID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid event_id: %w", err)
+	}
+
+	delivery := &domain.WebhookDelivery{
+		ID:             id,
+		SubscriptionID: subscriptionID,
+		TenantID:       tenantID,
+		EventID:        eventID,
+		EventType:      deliveryDB.EventType,
+		Payload:        json.RawMessage(deliveryDB.Payload),
+		Status:         domain.WebhookDeliveryStatus(deliveryDB.Status),
+		Attempts:       deliveryDB.Attempts,
+		NextAttemptAt:  deliveryDB.NextAttemptAt,
+		DurationMs:     deliveryDB.DurationMs,
+		CreatedAt:      deliveryDB.CreatedAt,
+		UpdatedAt:      deliveryDB.UpdatedAt,
+		DeliveredAt:    deliveryDB.DeliveredAt,
+	}
+	if deliveryDB.ResponseStatus != nil {
+		delivery.ResponseStatus = *deliveryDB.ResponseStatus
+	}
+	if deliveryDB.ResponseBody != nil {
+		delivery.ResponseBody = *deliveryDB.ResponseBody
+	}
+	if deliveryDB.LastError != nil {
+		delivery.LastError = *deliveryDB.LastError
+	}
+	if deliveryDB.ReplayOf != nil {
+		replayOf, err := uuid.Parse(*deliveryDB.ReplayOf)
+		if err != nil {
+			return nil, fmt.Errorf("invalid replay_of: %w", err)
+		}
+		delivery.ReplayOf = &replayOf
+	}
+
+	return delivery, nil
+}
+
+// fromDatabaseList converte a lista do modelo do banco
+func (r *PostgresWebhookDeliveryRepository) fromDatabaseList(deliveriesDB []webhookDeliveryDB) ([]*domain.WebhookDelivery, error) {
+	deliveries := make([]*domain.WebhookDelivery, 0, len(deliveriesDB))
+	for i := range deliveriesDB {
+		delivery, err := r.fromDatabase(&deliveriesDB[i])
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// PostgresWhatsAppLinkRepository implementação PostgreSQL do WhatsAppLinkRepository
+type PostgresWhatsAppLinkRepository struct {
+	db     *sqlx.DB
+	logger *zap.Logger
+}
+
+// NewPostgresWhatsAppLinkRepository cria nova instância do repositório
+func NewPostgresWhatsAppLinkRepository(db *sqlx.DB, logger *zap.Logger) domain.WhatsAppLinkRepository {
+	return &PostgresWhatsAppLinkRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// whatsAppLinkDB representa o contato vinculado no banco de dados
+type whatsAppLinkDB struct {
+	Contact  string    `db:"contact"`
+	TenantID string    `db:"tenant_id"`
+	UserID   string    `db:"user_id"`
+	LinkedAt time.Time `db:"linked_at"`
+}
+
+// Link vincula o contato ao usuário; um novo código substitui o vínculo anterior do contato
+func (r *PostgresWhatsAppLinkRepository) Link(ctx context.Context, link *domain.WhatsAppLink) error {
+	query := `
+		INSERT INTO whatsapp_links (contact, tenant_id, user_id, linked_at)
+		VALUES (:contact, :tenant_id, :user_id, :linked_at)
+		ON CONFLICT (contact) DO UPDATE SET
+			tenant_id = EXCLUDED.tenant_id,
+			user_id = EXCLUDED.user_id,
+			linked_at = EXCLUDED.linked_at`
+
+	linkDB := &whatsAppLinkDB{
+		Contact:  link.Contact,
+		TenantID: link.TenantID.String(),
+		UserID:   link.UserID.String(),
+		LinkedAt: link.LinkedAt,
+	}
+
+	if _, err := r.db.NamedExecContext(ctx, query, linkDB); err != nil {
+		r.logger.Error("Failed to link whatsapp contact", zap.Error(err))
+		return fmt.Errorf("failed to link whatsapp contact: %w", err)
+	}
+
+	return nil
+}
+
+// GetByContact busca o vínculo do contato
+func (r *PostgresWhatsAppLinkRepository) GetByContact(ctx context.Context, contact string) (*domain.WhatsAppLink, error) {
+	var linkDB whatsAppLinkDB
+	query := `SELECT * FROM whatsapp_links WHERE contact = $1`
+
+	if err := r.db.GetContext(ctx, &linkDB, query, contact); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrWhatsAppContactNotLinked
+		}
+		r.logger.Error("Failed to get whatsapp link", zap.Error(err))
+		return nil, fmt.Errorf("failed to get whatsapp link: %w", err)
+	}
+
+	tenantID, err := uuid.Parse(linkDB.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tenant_id: %w", err)
+	}
+	userID, err := uuid.Parse(linkDB.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user_id: %w", err)
+	}
+
+	return &domain.WhatsAppLink{
+		Contact:  linkDB.Contact,
+		TenantID: tenantID,
+		UserID:   userID,
+		LinkedAt: linkDB.LinkedAt,
+	}, nil
+}
+
+// Unlink remove o vínculo do contato
+func (r *PostgresWhatsAppLinkRepository) Unlink(ctx context.Context, contact string) error {
+	query := `DELETE FROM whatsapp_links WHERE contact = $1`
+
+	result, err := r.db.ExecContext(ctx, query, contact)
+	if err != nil {
+		r.logger.Error("Failed to unlink whatsapp contact", zap.Error(err))
+		return fmt.Errorf("failed to unlink whatsapp contact: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrWhatsAppContactNotLinked
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// PostgresWhatsAppSessionRepository implementação PostgreSQL do WhatsAppSessionRepository
+type PostgresWhatsAppSessionRepository struct {
+	db     *sqlx.DB
+	logger *zap.Logger
+}
+
+// NewPostgresWhatsAppSessionRepository cria nova instância do repositório
+func NewPostgresWhatsAppSessionRepository(db *sqlx.DB, logger *zap.Logger) domain.WhatsAppSessionRepository {
+	return &PostgresWhatsAppSessionRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// RecordInbound registra a mensagem recebida; webhooks fora de ordem não recuam a janela
+func (r *PostgresWhatsAppSessionRepository) RecordInbound(ctx context.Context, contact string, at time.Time) error {
+	query := `
+		INSERT INTO whatsapp_sessions (contact, last_inbound_at, updated_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (contact) DO UPDATE SET
+			last_inbound_at = GREATEST(whatsapp_sessions.last_inbound_at, EXCLUDED.last_inbound_at),
+			updated_at = NOW()`
+
+	if _, err := r.db.ExecContext(ctx, query, contact, at); err != nil {
+		r.logger.Error("Failed to record whatsapp inbound message", zap.Error(err))
+		return fmt.Errorf("failed to record whatsapp inbound message: %w", err)
+	}
+
+	return nil
+}
+
+// GetLastInbound retorna a última mensagem recebida do contato
+func (r *PostgresWhatsAppSessionRepository) GetLastInbound(ctx context.Context, contact string) (*time.Time, error) {
+	var lastInbound time.Time
+	query := `SELECT last_inbound_at FROM whatsapp_sessions WHERE contact = $1`
+
+	if err := r.db.GetContext(ctx, &lastInbound, query, contact); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		r.logger.Error("Failed to get whatsapp session", zap.Error(err))
+		return nil, fmt.Errorf("failed to get whatsapp session: %w", err)
+	}
+
+	return &lastInbound, nil
+}
@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/notification-service/internal/domain"
+)
+
+// PostgresWhatsAppTemplateRepository implementação PostgreSQL do WhatsAppTemplateRepository
+type PostgresWhatsAppTemplateRepository struct {
+	db     *sqlx.DB
+	logger *zap.Logger
+}
+
+// NewPostgresWhatsAppTemplateRepository cria nova instância do repositório
+func NewPostgresWhatsAppTemplateRepository(db *sqlx.DB, logger *zap.Logger) domain.WhatsAppTemplateRepository {
+	return &PostgresWhatsAppTemplateRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// whatsAppTemplateMappingDB representa o mapeamento de template do WhatsApp no banco de dados
+type whatsAppTemplateMappingDB struct {
+	ID             string         `db:"id"`
+	TemplateID     string         `db:"template_id"`
+	Locale         string         `db:"locale"`
+	Name           string         `db:"name"`
+	Language       string         `db:"language"`
+	Parameters     pq.StringArray `db:"parameters"`
+	Status         string         `db:"status"`
+	RejectedReason *string        `db:"rejected_reason"`
+	SyncedAt       *time.Time     `db:"synced_at"`
+	CreatedAt      time.Time      `db:"created_at"`
+	UpdatedAt      time.Time      `db:"updated_at"`
+}
+
+// Upsert cria o mapeamento ou substitui o do mesmo template e idioma. Trocar o template
+// registrado volta o status para o informado (pendente até a próxima sincronização).
+func (r *PostgresWhatsAppTemplateRepository) Upsert(ctx context.Context, mapping *domain.WhatsAppTemplateMapping) error {
+	mappingDB := r.toDatabase(mapping)
+	mappingDB.UpdatedAt = time.Now()
+
+	query := `
+		INSERT INTO whatsapp_template_mappings (
+			id, template_id, locale, name, language, parameters, status,
+			rejected_reason, synced_at, created_at, updated_at
+		) VALUES (
+			:id, :template_id, :locale, :name, :language, :parameters, :status,
+			:rejected_reason, :synced_at, :created_at, :updated_at
+		) ON CONFLICT (template_id, locale) DO UPDATE SET
+			name = EXCLUDED.name,
+			language = EXCLUDED.language,
+			parameters = EXCLUDED.parameters,
+			status = EXCLUDED.status,
+			rejected_reason = EXCLUDED.rejected_reason,
+			synced_at = EXCLUDED.synced_at,
+			updated_at = EXCLUDED.updated_at
+		RETURNING id, created_at`
+
+	rows, err := r.db.NamedQueryContext(ctx, query, mappingDB)
+	if err != nil {
+		r.logger.Error("Failed to upsert whatsapp template mapping", zap.Error(err))
+		return fmt.Errorf("failed to upsert whatsapp template mapping: %w", err)
+	}
+	defer rows.Close()
+
+	// Em substituição, devolve o ID e a criação do registro existente
+	if rows.Next() {
+		var id string
+		if err := rows.Scan(&id, &mapping.CreatedAt); err != nil {
+			return fmt.Errorf("failed to scan whatsapp template mapping: %w", err)
+		}
+		if mapping.ID, err = uuid.Parse(id); err != nil {
+			return fmt.Errorf("failed to parse whatsapp template mapping id: %w", err)
+		}
+	}
+	mapping.UpdatedAt = mappingDB.UpdatedAt
+
+	return rows.Err()
+}
+
+// FindByTemplate lista os mapeamentos do template
+func (r *PostgresWhatsAppTemplateRepository) FindByTemplate(ctx context.Context, templateID uuid.UUID) ([]*domain.WhatsAppTemplateMapping, error) {
+	query := `SELECT * FROM whatsapp_template_mappings WHERE template_id = $1 ORDER BY locale`
+	return r.find(ctx, query, templateID.String())
+}
+
+// FindAll lista todos os mapeamentos
+func (r *PostgresWhatsAppTemplateRepository) FindAll(ctx context.Context) ([]*domain.WhatsAppTemplateMapping, error) {
+	query := `SELECT * FROM whatsapp_template_mappings ORDER BY name, language`
+	return r.find(ctx, query)
+}
+
+// UpdateStatusByName atualiza o status de aprovação dos mapeamentos do template registrado
+func (r *PostgresWhatsAppTemplateRepository) UpdateStatusByName(ctx context.Context, name, language string, status domain.WhatsAppTemplateStatus, reason *string, syncedAt time.Time) (int64, error) {
+	query := `
+		UPDATE whatsapp_template_mappings
+		SET status = $3, rejected_reason = $4, synced_at = $5
+		WHERE name = $1 AND language = $2`
+
+	result, err := r.db.ExecContext(ctx, query, name, language, string(status), reason, syncedAt)
+	if err != nil {
+		r.logger.Error("Failed to update whatsapp template status", zap.Error(err))
+		return 0, fmt.Errorf("failed to update whatsapp template status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected, nil
+}
+
+// Delete remove o mapeamento do template
+func (r *PostgresWhatsAppTemplateRepository) Delete(ctx context.Context, templateID, id uuid.UUID) error {
+	query := `DELETE FROM whatsapp_template_mappings WHERE id = $1 AND template_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, id.String(), templateID.String())
+	if err != nil {
+		r.logger.Error("Failed to delete whatsapp template mapping", zap.Error(err))
+		return fmt.Errorf("failed to delete whatsapp template mapping: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrWhatsAppTemplateMappingNotFound
+	}
+
+	return nil
+}
+
+// find executa a consulta e converte os mapeamentos
+func (r *PostgresWhatsAppTemplateRepository) find(ctx context.Context, query string, args ...interface{}) ([]*domain.WhatsAppTemplateMapping, error) {
+	var mappingsDB []whatsAppTemplateMappingDB
+	if err := r.db.SelectContext(ctx, &mappingsDB, query, args...); err != nil {
+		r.logger.Error("Failed to find whatsapp template mappings", zap.Error(err))
+		return nil, fmt.Errorf("failed to find whatsapp template mappings: %w", err)
+	}
+
+	mappings := make([]*domain.WhatsAppTemplateMapping, 0, len(mappingsDB))
+	for i := range mappingsDB {
+		mapping, err := r.fromDatabase(&mappingsDB[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert whatsapp template mapping from database: %w", err)
+		}
+		mappings = append(mappings, mapping)
+	}
+
+	return mappings, nil
+}
+
+// toDatabase converte domain para database
+func (r *PostgresWhatsAppTemplateRepository) toDatabase(mapping *domain.WhatsAppTemplateMapping) *whatsAppTemplateMappingDB {
+	return &whatsAppTemplateMappingDB{
+		ID:             mapping.ID.String(),
+		TemplateID:     mapping.TemplateID.String(),
+		Locale:         mapping.Locale,
+		Name:           mapping.Name,
+		Language:       mapping.Language,
+		Parameters:     pq.StringArray(mapping.Parameters),
+		Status:         string(mapping.Status),
+		RejectedReason: mapping.RejectedReason,
+		SyncedAt:       mapping.SyncedAt,
+		CreatedAt:      mapping.CreatedAt,
+		UpdatedAt:      mapping.UpdatedAt,
+	}
+}
+
+// fromDatabase converte database para domain
+func (r *PostgresWhatsAppTemplateRepository) fromDatabase(mappingDB *whatsAppTemplateMappingDB) (*domain.WhatsAppTemplateMapping, error) {
+	id, err := uuid.Parse(mappingDB.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id: %w", err)
+	}
+	templateID, err := uuid.Parse(mappingDB.TemplateID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template_id: %w", err)
+	}
+
+	return &domain.WhatsAppTemplateMapping{
+		ID:             id,
+		TemplateID:     templateID,
+		Locale:         mappingDB.Locale,
+		Name:           mappingDB.Name,
+		Language:       mappingDB.Language,
+		Parameters:     []string(mappingDB.Parameters),
+		Status:         domain.WhatsAppTemplateStatus(mappingDB.Status),
+		RejectedReason: mappingDB.RejectedReason,
+		SyncedAt:       mappingDB.SyncedAt,
+		CreatedAt:      mappingDB.CreatedAt,
+		UpdatedAt:      mappingDB.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,19 @@
+-- Criar tabela da fila de notificações
+CREATE TABLE IF NOT EXISTS notification_queue (
+    notification_id UUID PRIMARY KEY REFERENCES notifications(id) ON DELETE CASCADE,
+    channel VARCHAR(20) NOT NULL,
+    priority VARCHAR(20) NOT NULL DEFAULT 'normal',
+    priority_rank SMALLINT NOT NULL DEFAULT 2, -- critical=4, high=3, normal=2, low=1
+    available_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP, -- entrega atrasada (agendadas e retries)
+    locked_until TIMESTAMP WITH TIME ZONE, -- visibility timeout do item em processamento
+    attempts INTEGER NOT NULL DEFAULT 0,
+    enqueued_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Índice para desenfileirar por canal e prioridade
+CREATE INDEX IF NOT EXISTS idx_notification_queue_dequeue ON notification_queue(channel, priority_rank DESC, available_at);
+CREATE INDEX IF NOT EXISTS idx_notification_queue_locked_until ON notification_queue(locked_until) WHERE locked_until IS NOT NULL;
+
+-- Comentários
+COMMENT ON TABLE notification_queue IS 'Fila durável de notificações consumida com SELECT ... FOR UPDATE SKIP LOCKED';
+COMMENT ON COLUMN notification_queue.locked_until IS 'Itens não confirmados voltam a ficar visíveis após este instante';
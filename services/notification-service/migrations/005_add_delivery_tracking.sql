@@ -0,0 +1,46 @@
+-- Rastreamento de entrega: colunas de recibo do provedor e lista de supressão
+
+-- Colunas usadas pelo repositório para recibos e reenvio
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS next_retry_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS delivered_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS read_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS external_id VARCHAR(255);
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS external_status VARCHAR(50);
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS processing_started_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS processing_finished_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS tags TEXT[] DEFAULT '{}';
+
+-- Índice para localizar a notificação a partir do webhook do provedor
+CREATE INDEX IF NOT EXISTS idx_notifications_external_id ON notifications(channel, external_id) WHERE external_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_notifications_delivered_at ON notifications(delivered_at) WHERE delivered_at IS NOT NULL;
+
+-- Status de entrega e leitura confirmados pelo provedor
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS check_notification_status;
+ALTER TABLE notifications ADD CONSTRAINT check_notification_status
+CHECK (status IN ('pending', 'scheduled', 'processing', 'sent', 'delivered', 'read', 'failed', 'expired', 'cancelled'));
+
+-- Criar tabela de contatos suprimidos
+CREATE TABLE IF NOT EXISTS notification_suppressions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    channel VARCHAR(20) NOT NULL,
+    contact VARCHAR(255) NOT NULL,
+    reason VARCHAR(20) NOT NULL,
+    details TEXT,
+    notification_id UUID REFERENCES notifications(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_notification_suppressions_contact UNIQUE (channel, contact)
+);
+
+ALTER TABLE notification_suppressions ADD CONSTRAINT check_suppression_channel
+CHECK (channel IN ('whatsapp', 'email', 'telegram', 'push', 'sms'));
+
+ALTER TABLE notification_suppressions ADD CONSTRAINT check_suppression_reason
+CHECK (reason IN ('hard_bounce', 'complaint', 'blocked'));
+
+-- Comentários para documentação
+COMMENT ON COLUMN notifications.external_id IS 'ID da mensagem no provedor (wamid do WhatsApp, Message-ID do email, etc.)';
+COMMENT ON COLUMN notifications.external_status IS 'Último status reportado pelo provedor via webhook';
+COMMENT ON COLUMN notifications.delivered_at IS 'Data/hora da entrega confirmada pelo provedor';
+COMMENT ON COLUMN notifications.read_at IS 'Data/hora da leitura confirmada pelo provedor';
+COMMENT ON TABLE notification_suppressions IS 'Contatos que não recebem mais notificações no canal (hard bounce, reclamação, bloqueio)';
+COMMENT ON COLUMN notification_suppressions.contact IS 'Contato normalizado (emails em minúsculas)';
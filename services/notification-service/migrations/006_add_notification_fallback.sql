@@ -0,0 +1,13 @@
+-- Cadeias de fallback de canal: cada tentativa é uma notificação ligada à raiz (notificação lógica)
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS fallback_root_id UUID REFERENCES notifications(id) ON DELETE CASCADE;
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS fallback_step INTEGER NOT NULL DEFAULT 0;
+
+-- Índice para buscar as tentativas da cadeia
+CREATE INDEX IF NOT EXISTS idx_notifications_fallback_root ON notifications(fallback_root_id, fallback_step) WHERE fallback_root_id IS NOT NULL;
+
+ALTER TABLE notifications ADD CONSTRAINT check_fallback_step_positive
+CHECK (fallback_step >= 0);
+
+-- Comentários para documentação
+COMMENT ON COLUMN notifications.fallback_root_id IS 'Notificação lógica da cadeia de fallback (a raiz aponta para si mesma; NULL = sem fallback)';
+COMMENT ON COLUMN notifications.fallback_step IS 'Posição da tentativa na cadeia de fallback (0 = primeiro canal)';
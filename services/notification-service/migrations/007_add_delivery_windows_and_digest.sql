@@ -0,0 +1,78 @@
+-- Janelas de entrega (horário de silêncio, horário comercial) e resumos de notificações
+
+-- Configuração de entrega por usuário; user_id NULL é o padrão do tenant
+CREATE TABLE IF NOT EXISTS notification_delivery_settings (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    user_id UUID,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'America/Sao_Paulo',
+    quiet_hours_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    quiet_hours_start VARCHAR(5) NOT NULL DEFAULT '22:00',
+    quiet_hours_end VARCHAR(5) NOT NULL DEFAULT '07:00',
+    business_hours_only BOOLEAN NOT NULL DEFAULT FALSE,
+    business_hours_start VARCHAR(5) NOT NULL DEFAULT '08:00',
+    business_hours_end VARCHAR(5) NOT NULL DEFAULT '18:00',
+    business_days INTEGER[] NOT NULL DEFAULT ARRAY[1, 2, 3, 4, 5],
+    override_priorities TEXT[] NOT NULL DEFAULT ARRAY['critical']::TEXT[],
+    digest_hour INTEGER NOT NULL DEFAULT 8,
+    digest_weekday INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Uma configuração por usuário e uma padrão por tenant
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notification_delivery_settings_user
+ON notification_delivery_settings(tenant_id, user_id) WHERE user_id IS NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notification_delivery_settings_tenant
+ON notification_delivery_settings(tenant_id) WHERE user_id IS NULL;
+
+-- Trigger para atualizar updated_at automaticamente
+CREATE TRIGGER trigger_notification_delivery_settings_updated_at
+    BEFORE UPDATE ON notification_delivery_settings
+    FOR EACH ROW
+    EXECUTE FUNCTION update_notifications_updated_at();
+
+ALTER TABLE notification_delivery_settings ADD CONSTRAINT check_delivery_settings_digest
+CHECK (digest_hour BETWEEN 0 AND 23 AND digest_weekday BETWEEN 0 AND 6);
+
+ALTER TABLE notification_delivery_settings ADD CONSTRAINT check_delivery_settings_override_priorities
+CHECK (override_priorities <@ ARRAY['low', 'normal', 'high', 'critical']::TEXT[]);
+
+-- Frequência do resumo por tipo de notificação
+ALTER TABLE notification_preferences ADD COLUMN IF NOT EXISTS digest_frequency VARCHAR(10) NOT NULL DEFAULT 'none';
+
+ALTER TABLE notification_preferences ADD CONSTRAINT check_preference_digest_frequency
+CHECK (
+    digest_frequency IN ('none', 'hourly', 'daily', 'weekly')
+    AND (digest_frequency = 'none' OR type IN ('movement_alert', 'process_update'))
+);
+
+-- Notificações agrupadas em resumo
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS digest_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS digest_id UUID REFERENCES notifications(id) ON DELETE SET NULL;
+
+-- Índice para o envio dos resumos vencidos
+CREATE INDEX IF NOT EXISTS idx_notifications_digest_due ON notifications(digest_at) WHERE status = 'batched';
+CREATE INDEX IF NOT EXISTS idx_notifications_digest_id ON notifications(digest_id) WHERE digest_id IS NOT NULL;
+
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS check_notification_status;
+ALTER TABLE notifications ADD CONSTRAINT check_notification_status
+CHECK (status IN ('pending', 'scheduled', 'processing', 'sent', 'delivered', 'read', 'failed', 'expired', 'cancelled', 'batched', 'digested'));
+
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS check_notification_type;
+ALTER TABLE notifications ADD CONSTRAINT check_notification_type
+CHECK (type IN (
+    'process_update', 'movement_alert', 'deadline_reminder',
+    'trial_expiring', 'subscription_due', 'system_alert',
+    'welcome', 'password_reset', 'digest'
+));
+
+-- Comentários para documentação
+COMMENT ON TABLE notification_delivery_settings IS 'Janelas de entrega por usuário (user_id NULL = padrão do tenant)';
+COMMENT ON COLUMN notification_delivery_settings.business_hours_only IS 'No padrão do tenant, obrigatório para todos os usuários';
+COMMENT ON COLUMN notification_delivery_settings.business_days IS 'Dias úteis (0 = domingo ... 6 = sábado)';
+COMMENT ON COLUMN notification_delivery_settings.override_priorities IS 'Prioridades entregues imediatamente, ignorando silêncio, horário comercial e resumo';
+COMMENT ON COLUMN notification_preferences.digest_frequency IS 'Frequência do resumo (none = envio imediato)';
+COMMENT ON COLUMN notifications.digest_at IS 'Envio previsto do resumo que agrupa a notificação';
+COMMENT ON COLUMN notifications.digest_id IS 'Notificação de resumo em que foi incluída';
@@ -0,0 +1,9 @@
+-- Canal SMS: descadastro por palavra-chave (SAIR) na lista de supressão
+
+ALTER TABLE notification_suppressions DROP CONSTRAINT IF EXISTS check_suppression_reason;
+ALTER TABLE notification_suppressions ADD CONSTRAINT check_suppression_reason
+CHECK (reason IN ('hard_bounce', 'complaint', 'blocked', 'opt_out'));
+
+-- Comentários para documentação
+COMMENT ON COLUMN notification_suppressions.reason IS 'hard_bounce, complaint, blocked (bot bloqueado) ou opt_out (resposta SAIR por SMS)';
+COMMENT ON COLUMN notification_suppressions.contact IS 'Contato normalizado: email em minúsculas, telefone de SMS em E.164';
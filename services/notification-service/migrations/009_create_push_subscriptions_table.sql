@@ -0,0 +1,30 @@
+-- Inscrições Web Push (VAPID) por usuário e dispositivo
+
+CREATE TABLE IF NOT EXISTS push_subscriptions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    endpoint TEXT NOT NULL,
+    p256dh VARCHAR(128) NOT NULL,
+    auth VARCHAR(64) NOT NULL,
+    device_name VARCHAR(100),
+    user_agent TEXT,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_push_subscriptions_endpoint UNIQUE (endpoint)
+);
+
+CREATE INDEX IF NOT EXISTS idx_push_subscriptions_user ON push_subscriptions(tenant_id, user_id);
+
+-- Trigger para atualizar updated_at automaticamente
+CREATE TRIGGER trigger_push_subscriptions_updated_at
+    BEFORE UPDATE ON push_subscriptions
+    FOR EACH ROW
+    EXECUTE FUNCTION update_notifications_updated_at();
+
+-- Comentários para documentação
+COMMENT ON TABLE push_subscriptions IS 'Inscrições Web Push dos navegadores; removidas quando o serviço de push responde 404/410';
+COMMENT ON COLUMN push_subscriptions.endpoint IS 'URL do serviço de push do navegador (única por dispositivo)';
+COMMENT ON COLUMN push_subscriptions.p256dh IS 'Chave pública P-256 do navegador (base64url) usada na criptografia RFC 8291';
+COMMENT ON COLUMN push_subscriptions.auth IS 'Segredo de autenticação do navegador (base64url)';
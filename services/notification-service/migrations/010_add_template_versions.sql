@@ -0,0 +1,92 @@
+-- Versionamento de templates, variantes por idioma, testes A/B e métricas de engajamento
+
+-- Idioma usado quando não há versão no idioma do destinatário
+ALTER TABLE notification_templates ADD COLUMN IF NOT EXISTS default_locale VARCHAR(16) NOT NULL DEFAULT 'pt-BR';
+
+-- Versões imutáveis do conteúdo, por idioma e variante
+CREATE TABLE IF NOT EXISTS notification_template_versions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    template_id UUID NOT NULL REFERENCES notification_templates(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    locale VARCHAR(16) NOT NULL DEFAULT 'pt-BR',
+    variant VARCHAR(32) NOT NULL DEFAULT 'default',
+    weight INTEGER NOT NULL DEFAULT 100,
+    status VARCHAR(20) NOT NULL DEFAULT 'draft',
+    subject TEXT NOT NULL,
+    content TEXT NOT NULL,
+    content_html TEXT,
+    variables TEXT[] DEFAULT ARRAY[]::TEXT[],
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    published_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT uq_notification_template_versions_version UNIQUE (template_id, version)
+);
+
+-- Apenas uma versão publicada por idioma e variante
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notification_template_versions_published
+ON notification_template_versions(template_id, locale, variant) WHERE status = 'published';
+
+ALTER TABLE notification_template_versions ADD CONSTRAINT check_template_version_status
+CHECK (status IN ('draft', 'published', 'archived'));
+
+ALTER TABLE notification_template_versions ADD CONSTRAINT check_template_version_weight
+CHECK (weight BETWEEN 0 AND 1000);
+
+-- Conteúdo da versão é imutável: apenas status, peso e data de publicação mudam
+CREATE OR REPLACE FUNCTION prevent_template_version_content_update()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.template_id <> OLD.template_id
+        OR NEW.version <> OLD.version
+        OR NEW.locale <> OLD.locale
+        OR NEW.variant <> OLD.variant
+        OR NEW.subject <> OLD.subject
+        OR NEW.content <> OLD.content
+        OR NEW.content_html IS DISTINCT FROM OLD.content_html THEN
+        RAISE EXCEPTION 'template version % is immutable', OLD.id;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_notification_template_versions_immutable
+    BEFORE UPDATE ON notification_template_versions
+    FOR EACH ROW
+    EXECUTE FUNCTION prevent_template_version_content_update();
+
+-- Conteúdo atual dos templates vira a versão 1 publicada no idioma padrão
+INSERT INTO notification_template_versions (
+    template_id, version, locale, variant, weight, status,
+    subject, content, content_html, variables, created_at, published_at
+)
+SELECT id, 1, default_locale, 'default', 100, 'published',
+    subject, content, content_html, variables, created_at, CURRENT_TIMESTAMP
+FROM notification_templates t
+WHERE NOT EXISTS (SELECT 1 FROM notification_template_versions v WHERE v.template_id = t.id);
+
+-- Versão, variante e idioma usados em cada notificação, e engajamento rastreado
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS template_version_id UUID REFERENCES notification_template_versions(id) ON DELETE SET NULL;
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS template_variant VARCHAR(32);
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS locale VARCHAR(16);
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS opened_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS clicked_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_notifications_template_version_id ON notifications(template_version_id) WHERE template_version_id IS NOT NULL;
+
+-- Idioma do destinatário (user_id NULL = idioma padrão do tenant)
+ALTER TABLE notification_delivery_settings ADD COLUMN IF NOT EXISTS language VARCHAR(16) NOT NULL DEFAULT 'pt-BR';
+
+-- Abertura e clique rastreados pelo próprio serviço
+ALTER TABLE notification_delivery_history DROP CONSTRAINT IF EXISTS check_delivery_status;
+ALTER TABLE notification_delivery_history ADD CONSTRAINT check_delivery_status
+CHECK (status IN ('sent', 'delivered', 'read', 'failed', 'bounced', 'complained', 'opened', 'clicked'));
+
+-- Comentários para documentação
+COMMENT ON TABLE notification_template_versions IS 'Versões imutáveis do conteúdo dos templates, por idioma e variante A/B';
+COMMENT ON COLUMN notification_template_versions.version IS 'Número sequencial da versão dentro do template';
+COMMENT ON COLUMN notification_template_versions.weight IS 'Peso da variante no sorteio entre versões publicadas do mesmo idioma (0 = pausada)';
+COMMENT ON COLUMN notification_template_versions.status IS 'draft, published (uma por idioma e variante) ou archived';
+COMMENT ON COLUMN notification_templates.default_locale IS 'Idioma usado quando não há versão publicada no idioma do destinatário';
+COMMENT ON COLUMN notifications.template_version_id IS 'Versão do template renderizada, para atribuir métricas à variante';
+COMMENT ON COLUMN notifications.opened_at IS 'Primeira abertura registrada pelo pixel de rastreamento';
+COMMENT ON COLUMN notifications.clicked_at IS 'Primeiro clique registrado em link rastreado';
+COMMENT ON COLUMN notification_delivery_settings.language IS 'Idioma do destinatário para escolha da versão do template';
@@ -0,0 +1,44 @@
+-- Janela de atendimento de 24 horas e templates aprovados do WhatsApp Business
+
+-- Última mensagem recebida de cada contato (abre a janela de mensagens livres)
+CREATE TABLE IF NOT EXISTS whatsapp_sessions (
+    contact VARCHAR(20) PRIMARY KEY,
+    last_inbound_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Mapeamento dos templates internos para os templates registrados na Meta
+CREATE TABLE IF NOT EXISTS whatsapp_template_mappings (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    template_id UUID NOT NULL REFERENCES notification_templates(id) ON DELETE CASCADE,
+    locale VARCHAR(16) NOT NULL DEFAULT 'pt-BR',
+    name VARCHAR(512) NOT NULL,
+    language VARCHAR(16) NOT NULL DEFAULT 'pt_BR',
+    parameters TEXT[] DEFAULT ARRAY[]::TEXT[],
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    rejected_reason TEXT,
+    synced_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_whatsapp_template_mappings_locale UNIQUE (template_id, locale)
+);
+
+CREATE INDEX IF NOT EXISTS idx_whatsapp_template_mappings_name ON whatsapp_template_mappings(name, language);
+
+ALTER TABLE whatsapp_template_mappings ADD CONSTRAINT check_whatsapp_template_status
+CHECK (status IN ('PENDING', 'APPROVED', 'REJECTED', 'PAUSED', 'DISABLED'));
+
+-- Trigger para atualizar updated_at automaticamente
+CREATE TRIGGER trigger_whatsapp_template_mappings_updated_at
+    BEFORE UPDATE ON whatsapp_template_mappings
+    FOR EACH ROW
+    EXECUTE FUNCTION update_notifications_updated_at();
+
+-- Comentários para documentação
+COMMENT ON TABLE whatsapp_sessions IS 'Última mensagem recebida por contato; mensagens livres só são aceitas pela Meta até 24h depois';
+COMMENT ON COLUMN whatsapp_sessions.contact IS 'Telefone do contato em E.164';
+COMMENT ON TABLE whatsapp_template_mappings IS 'Templates aprovados do WhatsApp usados fora da janela de atendimento';
+COMMENT ON COLUMN whatsapp_template_mappings.name IS 'Nome do template registrado na conta WhatsApp Business';
+COMMENT ON COLUMN whatsapp_template_mappings.language IS 'Código de idioma da Meta (pt_BR, en_US)';
+COMMENT ON COLUMN whatsapp_template_mappings.parameters IS 'Expressões do motor de templates renderizadas em ordem para {{1}}, {{2}}, ...';
+COMMENT ON COLUMN whatsapp_template_mappings.status IS 'Status de aprovação sincronizado da Graph API';
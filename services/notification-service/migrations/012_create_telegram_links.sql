@@ -0,0 +1,30 @@
+-- Vinculação de chats do Telegram aos usuários do web app (bot interativo)
+
+-- Códigos de uso único gerados no web app e enviados ao bot
+CREATE TABLE IF NOT EXISTS telegram_link_codes (
+    code VARCHAR(16) PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_telegram_link_codes_expires_at ON telegram_link_codes(expires_at);
+
+-- Chats vinculados; um usuário pode ter vários chats, cada chat pertence a um usuário
+CREATE TABLE IF NOT EXISTS telegram_links (
+    chat_id VARCHAR(32) PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    username VARCHAR(64),
+    linked_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_telegram_links_user ON telegram_links(tenant_id, user_id);
+
+-- Comentários para documentação
+COMMENT ON TABLE telegram_link_codes IS 'Códigos de vinculação do bot do Telegram, válidos por poucos minutos e usados uma única vez';
+COMMENT ON COLUMN telegram_link_codes.used_at IS 'Preenchido quando o código é enviado ao bot; impede reutilização';
+COMMENT ON TABLE telegram_links IS 'Chats do Telegram autorizados a consultar os processos do tenant pelo bot';
+COMMENT ON COLUMN telegram_links.chat_id IS 'ID do chat privado do Telegram';
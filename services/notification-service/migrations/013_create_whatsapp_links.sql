@@ -0,0 +1,16 @@
+-- Vinculação de contatos do WhatsApp aos usuários do web app (assistente conversacional)
+-- O contato envia "vincular CÓDIGO" com o código gerado no web app (telegram_link_codes)
+
+CREATE TABLE IF NOT EXISTS whatsapp_links (
+    contact VARCHAR(20) PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    linked_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_whatsapp_links_user ON whatsapp_links(tenant_id, user_id);
+
+-- Comentários para documentação
+COMMENT ON TABLE whatsapp_links IS 'Contatos do WhatsApp autorizados a consultar os processos do tenant pelo assistente';
+COMMENT ON COLUMN whatsapp_links.contact IS 'Telefone normalizado em E.164 (+5511987654321)';
+COMMENT ON TABLE telegram_link_codes IS 'Códigos de vinculação do bot do Telegram e do assistente do WhatsApp, válidos por poucos minutos e usados uma única vez';
@@ -0,0 +1,132 @@
+-- Alertas de movimentação processual a partir dos eventos do process-service
+-- (movement.created e movement.important.detected)
+
+-- Destinatários dos alertas de cada processo: advogado responsável, equipe e cliente (com opt-in)
+CREATE TABLE IF NOT EXISTS process_watchers (
+    tenant_id UUID NOT NULL,
+    process_id UUID NOT NULL,
+    recipient_id VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    contacts JSONB NOT NULL DEFAULT '{}',
+    opted_in BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (tenant_id, process_id, recipient_id)
+);
+
+ALTER TABLE process_watchers ADD CONSTRAINT check_process_watcher_role
+CHECK (role IN ('responsible', 'watcher', 'client'));
+
+CREATE INDEX IF NOT EXISTS idx_process_watchers_recipient ON process_watchers(tenant_id, recipient_id);
+
+-- Alertas já enviados: um por movimentação e destinatário, mais um quando a movimentação
+-- alertada como comum é depois detectada como importante
+CREATE TABLE IF NOT EXISTS movement_alerts (
+    movement_id VARCHAR(255) NOT NULL,
+    recipient_id VARCHAR(255) NOT NULL,
+    important BOOLEAN NOT NULL DEFAULT FALSE,
+    alerted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (movement_id, recipient_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_movement_alerts_alerted_at ON movement_alerts(alerted_at);
+
+-- Templates de sistema do alerta de movimentação
+INSERT INTO notification_templates (id, name, type, channel, status, subject, content, content_html, variables, is_system, created_at, updated_at) VALUES
+(
+    gen_random_uuid(),
+    'Alerta de Movimentação - Email',
+    'movement_alert',
+    'email',
+    'active',
+    '{{if importante}}[Importante] {{end}}Nova movimentação no processo {{numero_processo}}',
+    'Olá {{nome | padrao:"cliente"}}, houve uma nova movimentação no processo {{numero_processo}}: {{titulo_movimento}} ({{tipo_movimento}}), em {{data_movimento | data}}.{{if importante}} Movimentação importante (nível {{nivel_importancia}}).{{end}}{{if palavras_chave}} Palavras-chave: {{palavras_chave}}.{{end}}',
+    '<p>Olá {{nome | padrao:"cliente"}},</p><p>Houve uma nova movimentação no processo <strong>{{numero_processo}}</strong>:</p><p><strong>{{titulo_movimento}}</strong> ({{tipo_movimento}})<br>Data: {{data_movimento | data}}</p>{{if importante}}<p><strong>Movimentação importante</strong> (nível {{nivel_importancia}}).</p>{{end}}{{if palavras_chave}}<p>Palavras-chave: {{palavras_chave}}</p>{{end}}',
+    ARRAY['nome', 'numero_processo', 'titulo_movimento', 'tipo_movimento', 'data_movimento', 'importante', 'nivel_importancia', 'palavras_chave'],
+    TRUE,
+    CURRENT_TIMESTAMP,
+    CURRENT_TIMESTAMP
+),
+(
+    gen_random_uuid(),
+    'Alerta de Movimentação - WhatsApp',
+    'movement_alert',
+    'whatsapp',
+    'active',
+    'Nova movimentação no processo {{numero_processo}}',
+    '{{if importante}}⚠️ *Movimentação importante*{{else}}📄 *Nova movimentação*{{end}}
+Processo {{numero_processo}}
+{{titulo_movimento}} ({{tipo_movimento}})
+Data: {{data_movimento | data}}',
+    NULL,
+    ARRAY['numero_processo', 'titulo_movimento', 'tipo_movimento', 'data_movimento', 'importante'],
+    TRUE,
+    CURRENT_TIMESTAMP,
+    CURRENT_TIMESTAMP
+),
+(
+    gen_random_uuid(),
+    'Alerta de Movimentação - Telegram',
+    'movement_alert',
+    'telegram',
+    'active',
+    'Nova movimentação no processo {{numero_processo}}',
+    '{{if importante}}⚠️ *Movimentação importante*{{else}}📄 *Nova movimentação*{{end}}
+Processo {{numero_processo}}
+{{titulo_movimento}} \({{tipo_movimento}}\)
+Data: {{data_movimento | data}}',
+    NULL,
+    ARRAY['numero_processo', 'titulo_movimento', 'tipo_movimento', 'data_movimento', 'importante'],
+    TRUE,
+    CURRENT_TIMESTAMP,
+    CURRENT_TIMESTAMP
+),
+(
+    gen_random_uuid(),
+    'Alerta de Movimentação - Push',
+    'movement_alert',
+    'push',
+    'active',
+    '{{if importante}}Movimentação importante{{else}}Nova movimentação{{end}} - {{numero_processo}}',
+    '{{titulo_movimento | truncar:120}}',
+    NULL,
+    ARRAY['numero_processo', 'titulo_movimento', 'importante'],
+    TRUE,
+    CURRENT_TIMESTAMP,
+    CURRENT_TIMESTAMP
+),
+(
+    gen_random_uuid(),
+    'Alerta de Movimentação - SMS',
+    'movement_alert',
+    'sms',
+    'active',
+    'Nova movimentação no processo {{numero_processo}}',
+    'Direito Lux: {{if importante}}movimentação importante{{else}}nova movimentação{{end}} no processo {{numero_processo}}: {{titulo_movimento | truncar:80}}',
+    NULL,
+    ARRAY['numero_processo', 'titulo_movimento', 'importante'],
+    TRUE,
+    CURRENT_TIMESTAMP,
+    CURRENT_TIMESTAMP
+)
+ON CONFLICT DO NOTHING;
+
+-- Versão 1 publicada dos novos templates
+INSERT INTO notification_template_versions (
+    template_id, version, locale, variant, weight, status,
+    subject, content, content_html, variables, created_at, published_at
+)
+SELECT id, 1, default_locale, 'default', 100, 'published',
+    subject, content, content_html, variables, created_at, CURRENT_TIMESTAMP
+FROM notification_templates t
+WHERE t.type = 'movement_alert'
+  AND NOT EXISTS (SELECT 1 FROM notification_template_versions v WHERE v.template_id = t.id);
+
+-- Comentários para documentação
+COMMENT ON TABLE process_watchers IS 'Destinatários dos alertas de movimentação de cada processo';
+COMMENT ON COLUMN process_watchers.recipient_id IS 'ID do usuário (responsável e equipe) ou identificador do cliente no tenant';
+COMMENT ON COLUMN process_watchers.contacts IS 'Contato por canal: {"email": "...", "whatsapp": "+55..."}';
+COMMENT ON COLUMN process_watchers.opted_in IS 'Clientes só recebem alertas com opt-in';
+COMMENT ON TABLE movement_alerts IS 'Deduplicação dos alertas de movimentação entre eventos e canais';
+COMMENT ON COLUMN movement_alerts.important IS 'TRUE quando o destinatário já recebeu o alerta de movimentação importante';
@@ -0,0 +1,43 @@
+-- Registro de consentimento (LGPD) para comunicações com clientes: histórico imutável de
+-- concessões e revogações por contato, canal e finalidade
+
+CREATE TABLE IF NOT EXISTS consent_records (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID,
+    channel VARCHAR(20) NOT NULL,
+    contact VARCHAR(255) NOT NULL,
+    recipient_id VARCHAR(255),
+    purpose VARCHAR(30) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    legal_basis VARCHAR(30) NOT NULL,
+    source VARCHAR(30) NOT NULL,
+    evidence JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE consent_records ADD CONSTRAINT check_consent_channel
+CHECK (channel IN ('whatsapp', 'email', 'telegram', 'push', 'sms'));
+
+ALTER TABLE consent_records ADD CONSTRAINT check_consent_purpose
+CHECK (purpose IN ('process_updates', 'billing', 'service', 'all'));
+
+ALTER TABLE consent_records ADD CONSTRAINT check_consent_status
+CHECK (status IN ('granted', 'revoked'));
+
+ALTER TABLE consent_records ADD CONSTRAINT check_consent_legal_basis
+CHECK (legal_basis IN ('consent', 'contract', 'legal_obligation', 'legitimate_interest'));
+
+ALTER TABLE consent_records ADD CONSTRAINT check_consent_source
+CHECK (source IN ('web_app', 'api', 'import', 'unsubscribe_link', 'sms_keyword', 'whatsapp_keyword'));
+
+-- Consulta no envio e na exportação
+CREATE INDEX IF NOT EXISTS idx_consent_records_contact ON consent_records(channel, contact, created_at);
+CREATE INDEX IF NOT EXISTS idx_consent_records_tenant ON consent_records(tenant_id, created_at);
+
+-- Comentários para documentação
+COMMENT ON TABLE consent_records IS 'Histórico de consentimento (LGPD): cada concessão ou revogação é um novo registro';
+COMMENT ON COLUMN consent_records.tenant_id IS 'Tenant do registro; NULL para respostas SAIR/VOLTAR, que valem para todos os tenants';
+COMMENT ON COLUMN consent_records.contact IS 'Contato normalizado: email em minúsculas, telefone em E.164';
+COMMENT ON COLUMN consent_records.purpose IS 'Finalidade: process_updates, billing, service ou all';
+COMMENT ON COLUMN consent_records.legal_basis IS 'Base legal (LGPD, art. 7º): consent, contract, legal_obligation ou legitimate_interest';
+COMMENT ON COLUMN consent_records.evidence IS 'Evidência do registro: IP, user agent, texto aceito, mensagem recebida';
@@ -0,0 +1,26 @@
+-- Anexos dos emails (ex.: PDFs do report-service): conteúdo embutido em base64 ou URL baixada no envio
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS attachments JSONB NOT NULL DEFAULT '[]';
+
+-- Domínio próprio do tenant para o remetente dos emails, assinado com DKIM
+CREATE TABLE IF NOT EXISTS email_sender_domains (
+    tenant_id UUID PRIMARY KEY,
+    domain VARCHAR(253) NOT NULL,
+    from_email VARCHAR(255) NOT NULL,
+    from_name VARCHAR(255),
+    dkim_selector VARCHAR(63) NOT NULL,
+    dkim_private_key TEXT NOT NULL,
+    dkim_public_key TEXT NOT NULL,
+    verified BOOLEAN NOT NULL DEFAULT false,
+    verified_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_sender_domains_domain ON email_sender_domains(domain);
+
+-- Comentários para documentação
+COMMENT ON COLUMN notifications.attachments IS 'Anexos do email: [{filename, content_type, content (base64) | url}]';
+COMMENT ON TABLE email_sender_domains IS 'Remetente com domínio próprio do tenant; usado somente após a verificação do registro DKIM no DNS';
+COMMENT ON COLUMN email_sender_domains.dkim_selector IS 'Seletor do registro TXT <seletor>._domainkey.<domínio>';
+COMMENT ON COLUMN email_sender_domains.dkim_private_key IS 'Chave RSA gerada pelo serviço (PEM PKCS#1) para assinar os emails do domínio';
+COMMENT ON COLUMN email_sender_domains.dkim_public_key IS 'Chave pública (base64 DER) publicada no p= do registro DKIM';
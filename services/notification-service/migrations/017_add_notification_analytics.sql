@@ -0,0 +1,21 @@
+-- Análises de notificações: categoria normalizada das falhas e custo de cada envio por canal
+
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS failure_category VARCHAR(30);
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS cost NUMERIC(12, 6) NOT NULL DEFAULT 0;
+
+ALTER TABLE notifications ADD CONSTRAINT check_notification_failure_category
+CHECK (failure_category IS NULL OR failure_category IN (
+    'invalid_recipient', 'blocked', 'opted_out', 'rate_limited', 'provider_unavailable', 'rejected', 'other'
+));
+
+-- Falhas anteriores à categorização
+UPDATE notifications SET failure_category = 'other' WHERE status = 'failed' AND failure_category IS NULL;
+
+-- Consultas por período dos dashboards (por tenant e entre tenants)
+CREATE INDEX IF NOT EXISTS idx_notifications_tenant_created_at ON notifications(tenant_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_notifications_failure_category ON notifications(failure_category, created_at)
+WHERE failure_category IS NOT NULL;
+
+-- Comentários para documentação
+COMMENT ON COLUMN notifications.failure_category IS 'Categoria da falha: invalid_recipient, blocked, opted_out, rate_limited, provider_unavailable, rejected, other';
+COMMENT ON COLUMN notifications.cost IS 'Custo do envio pelo preço unitário do canal (SMS por segmento), na moeda configurada em NOTIFICATION_COST_CURRENCY';
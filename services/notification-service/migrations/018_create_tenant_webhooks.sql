@@ -0,0 +1,57 @@
+-- Webhooks de saída dos tenants: eventos de processos, movimentações, prazos e cobrança
+-- entregues por POST assinado com HMAC-SHA256
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    url TEXT NOT NULL,
+    description VARCHAR(255),
+    events JSONB NOT NULL DEFAULT '[]',
+    secret VARCHAR(100) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT true,
+    consecutive_failures INTEGER NOT NULL DEFAULT 0,
+    disabled_at TIMESTAMP WITH TIME ZONE,
+    disabled_reason VARCHAR(255),
+    last_delivery_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_tenant ON webhook_subscriptions(tenant_id, active);
+
+-- Histórico e fila das entregas
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id UUID PRIMARY KEY,
+    subscription_id UUID NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL,
+    event_id UUID NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'succeeded', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE,
+    response_status INTEGER,
+    response_body TEXT,
+    last_error TEXT,
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    replay_of UUID REFERENCES webhook_deliveries(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Um evento por inscrição: reentregas da mensagem no RabbitMQ não duplicam a entrega
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_deliveries_event
+    ON webhook_deliveries(subscription_id, event_id) WHERE replay_of IS NULL;
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due
+    ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription
+    ON webhook_deliveries(subscription_id, created_at DESC);
+
+-- Comentários para documentação
+COMMENT ON TABLE webhook_subscriptions IS 'Inscrições de webhook dos tenants (recurso webhooks_enabled e limite max_webhooks do plano)';
+COMMENT ON COLUMN webhook_subscriptions.events IS 'Filtros de eventos: "*", nome do evento ou prefixo como "movement.*"';
+COMMENT ON COLUMN webhook_subscriptions.secret IS 'Segredo do HMAC-SHA256 do cabeçalho X-DireitoLux-Signature (t=<unix>,v1=<hex>)';
+COMMENT ON COLUMN webhook_subscriptions.consecutive_failures IS 'Tentativas com falha desde a última entrega aceita; ao atingir o limite a inscrição é desativada';
+COMMENT ON TABLE webhook_deliveries IS 'Entregas dos eventos aos webhooks, com novas tentativas em backoff exponencial';
+COMMENT ON COLUMN webhook_deliveries.next_attempt_at IS 'Próxima tentativa das entregas pendentes; também serve de lease enquanto a entrega está em andamento';
+COMMENT ON COLUMN webhook_deliveries.replay_of IS 'Entrega original quando a entrega foi reenviada manualmente';
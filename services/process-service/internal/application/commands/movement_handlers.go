@@ -172,6 +172,9 @@ func (h *MovementCommandHandler) HandleAnalyzeMovement(ctx context.Context, cmd
 
 	// Aplicar análise
 	movement.SetAnalysis(cmd.Sentiment, cmd.Importance, cmd.Category, cmd.Confidence, cmd.ProcessedBy)
+	if cmd.DeadlineDate != nil {
+		movement.SetDeadline(*cmd.DeadlineDate)
+	}
 
 	// Persistir mudanças
 	if err := h.movementRepo.Update(movement); err != nil {
@@ -198,6 +201,14 @@ func (h *MovementCommandHandler) HandleAnalyzeMovement(ctx context.Context, cmd
 		}
 	}
 
+	// Prazo identificado: publicar para alertas e webhooks dos tenants
+	if movement.Metadata.Analysis.HasDeadline && movement.Metadata.Analysis.DeadlineDate != nil {
+		deadlineEvent := domain.NewDeadlineDetectedEvent(movement, process.Number)
+		if err := h.eventPublisher.Publish(deadlineEvent); err != nil {
+			return fmt.Errorf("erro ao publicar evento de prazo: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -205,6 +205,9 @@ type AnalyzeMovementCommand struct {
 	Category    string  `json:"category" validate:"required"`
 	Confidence  float64 `json:"confidence" validate:"min=0,max=1"`
 	ProcessedBy string  `json:"processed_by" validate:"required"`
+
+	// Prazo identificado na movimentação, quando houver
+	DeadlineDate *time.Time `json:"deadline_date,omitempty"`
 }
 
 // AddPartyCommand comando para adicionar parte ao processo
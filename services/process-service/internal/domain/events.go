@@ -217,6 +217,7 @@ func NewProcessSyncedEvent(process *Process, newMovements, updatedMovements int,
 // MovementCreatedEvent evento quando uma movimentação é criada
 type MovementCreatedEvent struct {
 	BaseDomainEvent
+	ProcessID     string       `json:"process_id"`
 	ProcessNumber string       `json:"process_number"`
 	MovementID    string       `json:"movement_id"`
 	Sequence      int          `json:"sequence"`
@@ -236,6 +237,7 @@ func NewMovementCreatedEvent(movement *Movement, processNumber, source string) *
 			OccurredAt: time.Now(),
 			TenantID:   movement.TenantID,
 		},
+		ProcessID:     movement.ProcessID,
 		ProcessNumber: processNumber,
 		MovementID:    movement.ID,
 		Sequence:      movement.Sequence,
@@ -285,13 +287,15 @@ func NewMovementAnalyzedEvent(movement *Movement, processNumber string) *Movemen
 // ImportantMovementDetectedEvent evento quando movimentação importante é detectada
 type ImportantMovementDetectedEvent struct {
 	BaseDomainEvent
-	ProcessNumber        string   `json:"process_number"`
-	MovementID           string   `json:"movement_id"`
-	MovementTitle        string   `json:"movement_title"`
-	MovementType         string   `json:"movement_type"`
-	ImportanceLevel      int      `json:"importance_level"`
-	NotificationChannels []string `json:"notification_channels"`
-	Keywords             []string `json:"keywords"`
+	ProcessID            string    `json:"process_id"`
+	ProcessNumber        string    `json:"process_number"`
+	MovementID           string    `json:"movement_id"`
+	MovementTitle        string    `json:"movement_title"`
+	MovementType         string    `json:"movement_type"`
+	MovementDate         time.Time `json:"movement_date"`
+	ImportanceLevel      int       `json:"importance_level"`
+	NotificationChannels []string  `json:"notification_channels"`
+	Keywords             []string  `json:"keywords"`
 }
 
 func NewImportantMovementDetectedEvent(movement *Movement, processNumber string, notificationChannels []string) *ImportantMovementDetectedEvent {
@@ -303,16 +307,47 @@ func NewImportantMovementDetectedEvent(movement *Movement, processNumber string,
 			OccurredAt: time.Now(),
 			TenantID:   movement.TenantID,
 		},
+		ProcessID:            movement.ProcessID,
 		ProcessNumber:        processNumber,
 		MovementID:           movement.ID,
 		MovementTitle:        movement.Title,
 		MovementType:         string(movement.Type),
+		MovementDate:         movement.Date,
 		ImportanceLevel:      movement.Metadata.Analysis.Importance,
 		NotificationChannels: notificationChannels,
 		Keywords:             movement.Metadata.Keywords,
 	}
 }
 
+// DeadlineDetectedEvent evento quando a análise identifica prazo na movimentação
+type DeadlineDetectedEvent struct {
+	BaseDomainEvent
+	ProcessID     string    `json:"process_id"`
+	ProcessNumber string    `json:"process_number"`
+	MovementID    string    `json:"movement_id"`
+	MovementTitle string    `json:"movement_title"`
+	DeadlineDate  time.Time `json:"deadline_date"`
+	ActionType    string    `json:"action_type,omitempty"`
+}
+
+func NewDeadlineDetectedEvent(movement *Movement, processNumber string) *DeadlineDetectedEvent {
+	return &DeadlineDetectedEvent{
+		BaseDomainEvent: BaseDomainEvent{
+			Type:       "deadline.detected",
+			Version:    "1.0",
+			ID:         movement.ID,
+			OccurredAt: time.Now(),
+			TenantID:   movement.TenantID,
+		},
+		ProcessID:     movement.ProcessID,
+		ProcessNumber: processNumber,
+		MovementID:    movement.ID,
+		MovementTitle: movement.Title,
+		DeadlineDate:  *movement.Metadata.Analysis.DeadlineDate,
+		ActionType:    movement.Metadata.Analysis.ActionType,
+	}
+}
+
 // PartyAddedEvent evento quando uma parte é adicionada ao processo
 type PartyAddedEvent struct {
 	BaseDomainEvent
@@ -324,6 +324,13 @@ func (m *Movement) SetAnalysis(sentiment string, importance int, category string
 	m.UpdatedAt = time.Now()
 }
 
+// SetDeadline registra o prazo identificado na análise
+func (m *Movement) SetDeadline(deadline time.Time) {
+	m.Metadata.Analysis.HasDeadline = true
+	m.Metadata.Analysis.DeadlineDate = &deadline
+	m.UpdatedAt = time.Now()
+}
+
 // SetCustomField define um campo customizado
 func (m *Movement) SetCustomField(key, value string) {
 	if m.Metadata.CustomFields == nil {
@@ -248,6 +248,9 @@ func (p *RabbitMQEventPublisher) getRoutingKey(eventType string) string {
 		"movement.analyzed":          "movement.analyzed",
 		"movement.important.detected": "movement.important.detected",
 
+		// Eventos de prazos
+		"deadline.detected": "deadline.detected",
+
 		// Eventos de partes
 		"party.added":   "party.added",
 		"party.updated": "party.updated",
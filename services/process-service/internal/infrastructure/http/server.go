@@ -2,8 +2,11 @@ package http
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -119,6 +122,8 @@ func (s *Server) setupRoutes() {
 			processes.PUT("/:id", s.updateProcess())
 			processes.DELETE("/:id", s.deleteProcess())
 			processes.GET("/stats", s.getProcessStats()) // CRÍTICO para dashboard
+			processes.GET("/deadlines", s.listUpcomingDeadlines())
+			processes.GET("/number/:number", s.getProcessByNumber())
 		}
 	}
 
@@ -240,7 +245,126 @@ func (s *Server) getProcessStats() gin.HandlerFunc {
 	}
 }
 
-// listProcesses lista processos
+// listUpcomingDeadlines lista os prazos identificados nas movimentações dos próximos dias
+func (s *Server) listUpcomingDeadlines() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.GetHeader("X-Tenant-ID")
+		if tenantID == "" {
+			c.JSON(400, gin.H{"error": "X-Tenant-ID header é obrigatório"})
+			return
+		}
+
+		days, err := strconv.Atoi(c.DefaultQuery("days", "7"))
+		if err != nil || days < 1 || days > 90 {
+			c.JSON(400, gin.H{"error": "days deve estar entre 1 e 90"})
+			return
+		}
+
+		db := s.db
+		if db == nil {
+			c.JSON(500, gin.H{"error": "Database connection not available"})
+			return
+		}
+
+		var deadlines []struct {
+			MovementID    string    `db:"movement_id" json:"movement_id"`
+			ProcessID     string    `db:"process_id" json:"process_id"`
+			ProcessNumber string    `db:"process_number" json:"process_number"`
+			ProcessTitle  string    `db:"process_title" json:"process_title"`
+			Title         string    `db:"title" json:"title"`
+			DeadlineDate  time.Time `db:"deadline_date" json:"deadline_date"`
+		}
+
+		// Prazos vêm da análise das movimentações (metadata.analysis.deadline_date)
+		query := `
+			SELECT * FROM (
+				SELECT
+					m.id AS movement_id,
+					p.id AS process_id,
+					p.number AS process_number,
+					p.title AS process_title,
+					m.title,
+					NULLIF(m.metadata->'analysis'->>'deadline_date', '')::timestamptz AS deadline_date
+				FROM movements m
+				JOIN processes p ON p.id = m.process_id
+				WHERE m.tenant_id = $1
+				  AND p.status = 'active'
+				  AND (m.metadata->'analysis'->>'has_deadline')::boolean
+			) d
+			WHERE deadline_date >= CURRENT_DATE
+			  AND deadline_date < CURRENT_DATE + make_interval(days => $2)
+			ORDER BY deadline_date
+			LIMIT 50
+		`
+
+		if err := db.Select(&deadlines, query, tenantID, days); err != nil {
+			s.logger.Error("Erro ao buscar prazos", zap.Error(err))
+			c.JSON(500, gin.H{"error": "Database query failed"})
+			return
+		}
+
+		c.JSON(200, gin.H{
+			"data":  deadlines,
+			"total": len(deadlines),
+		})
+	}
+}
+
+// getProcessByNumber busca processo do tenant pelo número CNJ, com a última movimentação
+func (s *Server) getProcessByNumber() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.GetHeader("X-Tenant-ID")
+		if tenantID == "" {
+			c.JSON(400, gin.H{"error": "X-Tenant-ID header é obrigatório"})
+			return
+		}
+
+		db := s.db
+		if db == nil {
+			c.JSON(500, gin.H{"error": "Database connection not available"})
+			return
+		}
+
+		var process struct {
+			ID                string     `db:"id" json:"id"`
+			Number            string     `db:"number" json:"number"`
+			Title             string     `db:"title" json:"title"`
+			Status            string     `db:"status" json:"status"`
+			CourtID           string     `db:"court_id" json:"court_id"`
+			LastMovementAt    *time.Time `db:"last_movement_at" json:"last_movement_at,omitempty"`
+			LastMovementTitle *string    `db:"last_movement_title" json:"last_movement_title,omitempty"`
+			LastMovementDate  *time.Time `db:"last_movement_date" json:"last_movement_date,omitempty"`
+		}
+
+		query := `
+			SELECT
+				p.id, p.number, p.title, p.status, p.court_id, p.last_movement_at,
+				m.title AS last_movement_title, m.date AS last_movement_date
+			FROM processes p
+			LEFT JOIN LATERAL (
+				SELECT title, date FROM movements
+				WHERE process_id = p.id
+				ORDER BY sequence DESC
+				LIMIT 1
+			) m ON true
+			WHERE p.tenant_id = $1 AND p.number = $2
+		`
+
+		if err := db.Get(&process, query, tenantID, c.Param("number")); err != nil {
+			if err == sql.ErrNoRows {
+				c.JSON(404, gin.H{"error": "Processo não encontrado"})
+				return
+			}
+			s.logger.Error("Erro ao buscar processo por número", zap.Error(err))
+			c.JSON(500, gin.H{"error": "Database query failed"})
+			return
+		}
+
+		c.JSON(200, gin.H{"data": process})
+	}
+}
+
+// listProcesses lista processos do tenant; search filtra por número, título ou descrição
 func (s *Server) listProcesses() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		tenantID := c.GetHeader("X-Tenant-ID")
@@ -249,15 +373,41 @@ func (s *Server) listProcesses() gin.HandlerFunc {
 			return
 		}
 
-		// Implementação temporária - TODO: conectar DB
-		processes := []gin.H{
-			{
-				"id":     "proc-1",
-				"number": "5001234-12.2024.8.26.0100",
-				"title":  "Ação de Cobrança - Cliente XYZ",
-				"court":  "TJSP",
-				"status": "active",
-			},
+		limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+		if err != nil || limit < 1 || limit > 100 {
+			c.JSON(400, gin.H{"error": "limit deve estar entre 1 e 100"})
+			return
+		}
+
+		db := s.db
+		if db == nil {
+			c.JSON(500, gin.H{"error": "Database connection not available"})
+			return
+		}
+
+		processes := []struct {
+			ID             string     `db:"id" json:"id"`
+			Number         string     `db:"number" json:"number"`
+			Title          string     `db:"title" json:"title"`
+			Status         string     `db:"status" json:"status"`
+			CourtID        string     `db:"court_id" json:"court_id"`
+			LastMovementAt *time.Time `db:"last_movement_at" json:"last_movement_at,omitempty"`
+		}{}
+
+		query := `
+			SELECT id, number, title, status, court_id, last_movement_at
+			FROM processes
+			WHERE tenant_id = $1
+			  AND ($2 = '' OR status = $2)
+			  AND ($3 = '' OR number ILIKE '%' || $3 || '%' OR title ILIKE '%' || $3 || '%' OR description ILIKE '%' || $3 || '%')
+			ORDER BY COALESCE(last_movement_at, updated_at) DESC
+			LIMIT $4
+		`
+
+		if err := db.Select(&processes, query, tenantID, c.Query("status"), c.Query("search"), limit); err != nil {
+			s.logger.Error("Erro ao listar processos", zap.Error(err))
+			c.JSON(500, gin.H{"error": "Database query failed"})
+			return
 		}
 
 		c.JSON(200, gin.H{
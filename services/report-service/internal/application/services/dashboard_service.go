@@ -227,7 +227,7 @@ func (s *DashboardService) GetDashboardData(ctx context.Context, dashboardID uui
 	widgetData := make(map[string]interface{})
 	for _, widget := range dashboard.Widgets {
 		if widget.IsVisible {
-			wData, err := s.dataCollector.CollectDashboardData(ctx, &widget)
+			wData, err := s.dataCollector.CollectDashboardData(ctx, dashboard.TenantID, &widget)
 			if err != nil {
 				s.logger.Warn("Failed to collect widget data",
 					zap.String("widget_id", widget.ID.String()),
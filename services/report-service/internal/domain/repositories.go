@@ -92,7 +92,8 @@ type DataCollector interface {
 	CollectProductivityData(ctx context.Context, tenantID uuid.UUID, filters map[string]interface{}) (interface{}, error)
 	CollectFinancialData(ctx context.Context, tenantID uuid.UUID, filters map[string]interface{}) (interface{}, error)
 	CollectJurisprudenceData(ctx context.Context, tenantID uuid.UUID, filters map[string]interface{}) (interface{}, error)
-	CollectDashboardData(ctx context.Context, widget *DashboardWidget) (interface{}, error)
+	CollectNotificationData(ctx context.Context, tenantID uuid.UUID, filters map[string]interface{}) (interface{}, error)
+	CollectDashboardData(ctx context.Context, tenantID uuid.UUID, widget *DashboardWidget) (interface{}, error)
 	CalculateKPIs(ctx context.Context, tenantID uuid.UUID) ([]*KPI, error)
 }
 
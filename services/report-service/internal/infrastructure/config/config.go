@@ -123,11 +123,12 @@ type MetricsConfig struct {
 
 // ServicesConfig configurações de serviços externos
 type ServicesConfig struct {
-	ProcessServiceURL       string `envconfig:"PROCESS_SERVICE_URL" default:"http://localhost:8083"`
-	TenantServiceURL        string `envconfig:"TENANT_SERVICE_URL" default:"http://localhost:8082"`
-	NotificationServiceURL  string `envconfig:"NOTIFICATION_SERVICE_URL" default:"http://localhost:8085"`
-	AIServiceURL            string `envconfig:"AI_SERVICE_URL" default:"http://localhost:8000"`
-	SearchServiceURL        string `envconfig:"SEARCH_SERVICE_URL" default:"http://localhost:8086"`
+	ProcessServiceURL        string `envconfig:"PROCESS_SERVICE_URL" default:"http://localhost:8083"`
+	TenantServiceURL         string `envconfig:"TENANT_SERVICE_URL" default:"http://localhost:8082"`
+	NotificationServiceURL   string `envconfig:"NOTIFICATION_SERVICE_URL" default:"http://localhost:8085"`
+	AIServiceURL             string `envconfig:"AI_SERVICE_URL" default:"http://localhost:8000"`
+	SearchServiceURL         string `envconfig:"SEARCH_SERVICE_URL" default:"http://localhost:8086"`
+	NotificationServiceToken string `envconfig:"NOTIFICATION_SERVICE_TOKEN"` // Bearer das análises de notificações
 }
 
 // Load carrega a configuração a partir de variáveis de ambiente
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/google/uuid"
@@ -130,9 +131,59 @@ func (c *DataCollector) CollectJurisprudenceData(ctx context.Context, tenantID u
 	return data, nil
 }
 
-// CollectDashboardData implementa domain.DataCollector
-func (c *DataCollector) CollectDashboardData(ctx context.Context, widget *domain.DashboardWidget) (interface{}, error) {
+// CollectNotificationData implementa domain.DataCollector
+func (c *DataCollector) CollectNotificationData(ctx context.Context, tenantID uuid.UUID, filters map[string]interface{}) (interface{}, error) {
+	c.logger.Info("Collecting notification data", zap.String("tenant_id", tenantID.String()))
+
+	url := fmt.Sprintf("%s/api/v1/notifications/analytics?%s", c.config.Services.NotificationServiceURL, notificationAnalyticsQuery(filters).Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("X-Tenant-ID", tenantID.String())
+	req.Header.Set("Authorization", "Bearer "+c.config.Services.NotificationServiceToken)
+
+	return c.fetchNotificationAnalytics(req)
+}
+
+// fetchNotificationAnalytics chama o Notification Service. Falhas são devolvidas: dados simulados
+// mostrariam custos que o tenant não teve.
+func (c *DataCollector) fetchNotificationAnalytics(req *http.Request) (interface{}, error) {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call notification service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("notification service returned status %d", resp.StatusCode)
+	}
+
+	var data interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return data, nil
+}
+
+// notificationAnalyticsQuery repassa os filtros aceitos pelas análises de notificações
+func notificationAnalyticsQuery(filters map[string]interface{}) url.Values {
+	query := url.Values{}
+	for _, key := range []string{"period", "from", "to", "group_by", "channel", "type", "template_id"} {
+		if value, ok := filters[key]; ok && value != nil {
+			query.Set(key, fmt.Sprint(value))
+		}
+	}
+	return query
+}
+
+// CollectDashboardData implementa domain.DataCollector. tenantID é o dono do dashboard e
+// restringe os dados de todos os widgets.
+func (c *DataCollector) CollectDashboardData(ctx context.Context, tenantID uuid.UUID, widget *domain.DashboardWidget) (interface{}, error) {
 	c.logger.Info("Collecting dashboard widget data", 
+		zap.String("tenant_id", tenantID.String()),
 		zap.String("widget_id", widget.ID.String()),
 		zap.String("data_source", widget.DataSource))
 
@@ -145,8 +196,10 @@ func (c *DataCollector) CollectDashboardData(ctx context.Context, widget *domain
 			"trend": "up",
 			"change": "+5.3%",
 		}, nil
+	case "notifications":
+		return c.CollectNotificationData(ctx, tenantID, widget.Filters)
 	case "kpis":
-		kpis, _ := c.CalculateKPIs(ctx, uuid.MustParse("00000000-0000-0000-0000-000000000000"))
+		kpis, _ := c.CalculateKPIs(ctx, tenantID)
 		return kpis, nil
 	default:
 		return map[string]interface{}{
@@ -303,4 +356,4 @@ func (c *DataCollector) getMockJurisprudenceData() map[string]interface{} {
 		},
 		"prediction_confidence": 85.7,
 	}
-}
\ No newline at end of file
+}
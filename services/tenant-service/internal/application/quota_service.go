@@ -51,6 +51,8 @@ type QuotaUsageResponse struct {
 	WebhooksCount         int       `json:"webhooks_count"`
 	APICallsDaily         int       `json:"api_calls_daily"`
 	APICallsMonthly       int       `json:"api_calls_monthly"`
+	DataJudOverageTotal   int       `json:"datajud_overage_total"`
+	AIOverageTotal        int       `json:"ai_overage_total"`
 	LastUpdated           time.Time `json:"last_updated"`
 	LastResetDaily        time.Time `json:"last_reset_daily"`
 	LastResetMonthly      time.Time `json:"last_reset_monthly"`
@@ -67,6 +69,8 @@ type QuotaLimitResponse struct {
 	StorageGB             int       `json:"storage_gb"`
 	MaxWebhooks           int       `json:"max_webhooks"`
 	MaxAPICallsDaily      int       `json:"max_api_calls_daily"`
+	OverageEnabled        bool      `json:"overage_enabled"`
+	OverageBlocked        bool      `json:"overage_blocked"`
 	UpdatedAt             time.Time `json:"updated_at"`
 }
 
@@ -86,6 +90,12 @@ type IncrementQuotaRequest struct {
 	AmountGB  float64 `json:"amount_gb,omitempty"`
 }
 
+// UpdateOverageRequest request para configurar a cobrança por excedente
+type UpdateOverageRequest struct {
+	Enabled *bool `json:"enabled,omitempty"`
+	Blocked *bool `json:"blocked,omitempty"`
+}
+
 // UpdateStorageRequest request para atualizar armazenamento
 type UpdateStorageRequest struct {
 	UsageGB float64 `json:"usage_gb" validate:"min=0"`
@@ -182,6 +192,17 @@ func (s *QuotaService) CanIncrementQuota(ctx context.Context, tenantID, quotaTyp
 	}
 
 	// Verifica quota específica
+	canIncrement, err := canIncrementWithinLimit(usage, limits, quotaType, amount)
+	if err != nil || canIncrement {
+		return canIncrement, err
+	}
+
+	// No modo de cobrança por excedente o limite pode ser ultrapassado
+	return limits.AllowsOverage(quotaType), nil
+}
+
+// canIncrementWithinLimit verifica se o incremento cabe no limite do plano
+func canIncrementWithinLimit(usage *domain.QuotaUsage, limits *domain.QuotaLimit, quotaType string, amount int) (bool, error) {
 	switch quotaType {
 	case "processes":
 		return usage.CanIncrementProcesses(limits.MaxProcesses, amount), nil
@@ -249,12 +270,25 @@ func (s *QuotaService) IncrementQuota(ctx context.Context, tenantID string, req
 		return domain.ErrQuotaExceeded
 	}
 
+	// Calcula a parcela excedente antes de incrementar
+	overage, err := s.calculateOverage(tenantID, req.QuotaType, req.Amount)
+	if err != nil {
+		return err
+	}
+
 	// Incrementa quota no repositório
 	if err := s.quotaRepo.IncrementCounter(tenantID, req.QuotaType, req.Amount); err != nil {
 		s.logger.Error("Failed to increment quota", zap.Error(err))
 		return fmt.Errorf("erro ao incrementar quota: %w", err)
 	}
 
+	if overage > 0 {
+		if err := s.recordOverage(tenantID, req.QuotaType, overage); err != nil {
+			s.logger.Error("Failed to record quota overage", zap.Error(err))
+			return fmt.Errorf("erro ao registrar excedente de quota: %w", err)
+		}
+	}
+
 	// Verifica se deve publicar warning
 	if err := s.checkAndPublishWarning(tenantID, req.QuotaType); err != nil {
 		s.logger.Error("Failed to check quota warning", zap.Error(err))
@@ -269,6 +303,101 @@ func (s *QuotaService) IncrementQuota(ctx context.Context, tenantID string, req
 	return nil
 }
 
+// calculateOverage calcula a parcela do incremento cobrada como excedente
+func (s *QuotaService) calculateOverage(tenantID, quotaType string, amount int) (int, error) {
+	if _, billable := domain.OverageCounterFor(quotaType); !billable {
+		return 0, nil
+	}
+
+	usage, err := s.quotaRepo.GetUsage(tenantID)
+	if err != nil {
+		return 0, err
+	}
+
+	limits, err := s.quotaRepo.GetLimits(tenantID)
+	if err != nil {
+		return 0, err
+	}
+
+	if !limits.AllowsOverage(quotaType) {
+		return 0, nil
+	}
+
+	switch quotaType {
+	case "datajud_daily":
+		return domain.OverageAmount(usage.DataJudQueriesDaily, limits.DataJudQueriesDaily, amount), nil
+	case "ai_monthly":
+		return domain.OverageAmount(usage.AIQueriesMonthly, limits.AIQueriesMonthly, amount), nil
+	default:
+		return 0, nil
+	}
+}
+
+// recordOverage registra o excedente no contador acumulado consumido pelo billing-service
+func (s *QuotaService) recordOverage(tenantID, quotaType string, overage int) error {
+	counterType, _ := domain.OverageCounterFor(quotaType)
+
+	if err := s.quotaRepo.IncrementCounter(tenantID, counterType, overage); err != nil {
+		return err
+	}
+
+	usage, err := s.quotaRepo.GetUsage(tenantID)
+	if err != nil {
+		return err
+	}
+
+	limits, err := s.quotaRepo.GetLimits(tenantID)
+	if err != nil {
+		return err
+	}
+
+	var currentUsage, limit, overageTotal int
+	switch quotaType {
+	case "datajud_daily":
+		currentUsage, limit, overageTotal = usage.DataJudQueriesDaily, limits.DataJudQueriesDaily, usage.DataJudOverageTotal
+	case "ai_monthly":
+		currentUsage, limit, overageTotal = usage.AIQueriesMonthly, limits.AIQueriesMonthly, usage.AIOverageTotal
+	}
+
+	event := domain.NewQuotaOverageEvent(tenantID, quotaType, currentUsage, limit, overage, overageTotal)
+	if err := s.eventPublisher.Publish(event); err != nil {
+		s.logger.Error("Failed to publish quota overage event", zap.Error(err))
+	}
+
+	return nil
+}
+
+// SetOverageMode ativa, desativa ou bloqueia a cobrança por excedente do tenant
+func (s *QuotaService) SetOverageMode(ctx context.Context, tenantID string, req *UpdateOverageRequest) (*QuotaLimitResponse, error) {
+	s.logger.Info("Updating overage mode", zap.String("tenant_id", tenantID))
+
+	limits, err := s.quotaRepo.GetLimits(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Enabled != nil {
+		limits.OverageEnabled = *req.Enabled
+	}
+	if req.Blocked != nil {
+		limits.OverageBlocked = *req.Blocked
+	}
+	limits.UpdatedAt = time.Now()
+
+	if err := s.quotaRepo.UpdateLimits(limits); err != nil {
+		s.logger.Error("Failed to update overage mode", zap.Error(err))
+		return nil, fmt.Errorf("erro ao atualizar cobrança por excedente: %w", err)
+	}
+
+	s.logger.Info("Overage mode updated successfully",
+		zap.String("tenant_id", tenantID),
+		zap.Bool("overage_enabled", limits.OverageEnabled),
+		zap.Bool("overage_blocked", limits.OverageBlocked),
+	)
+
+	return s.toQuotaLimitResponse(limits), nil
+}
+
 // UpdateStorageUsage atualiza uso de armazenamento
 func (s *QuotaService) UpdateStorageUsage(ctx context.Context, tenantID string, req *UpdateStorageRequest) error {
 	s.logger.Info("Updating storage usage", 
@@ -349,6 +478,12 @@ func (s *QuotaService) UpdateQuotaLimits(ctx context.Context, tenantID string, q
 	s.logger.Info("Updating quota limits", zap.String("tenant_id", tenantID))
 
 	limits := domain.GetQuotaLimitFromPlan(tenantID, quotas)
+
+	// Mantém a configuração de cobrança por excedente do tenant
+	if current, err := s.quotaRepo.GetLimits(tenantID); err == nil {
+		limits.OverageEnabled = current.OverageEnabled
+		limits.OverageBlocked = current.OverageBlocked
+	}
 	
 	if err := s.quotaRepo.UpdateLimits(limits); err != nil {
 		s.logger.Error("Failed to update quota limits", zap.Error(err))
@@ -415,6 +550,8 @@ func (s *QuotaService) toQuotaUsageResponse(usage *domain.QuotaUsage) *QuotaUsag
 		WebhooksCount:         usage.WebhooksCount,
 		APICallsDaily:         usage.APICallsDaily,
 		APICallsMonthly:       usage.APICallsMonthly,
+		DataJudOverageTotal:   usage.DataJudOverageTotal,
+		AIOverageTotal:        usage.AIOverageTotal,
 		LastUpdated:           usage.LastUpdated,
 		LastResetDaily:        usage.LastResetDaily,
 		LastResetMonthly:      usage.LastResetMonthly,
@@ -433,6 +570,8 @@ func (s *QuotaService) toQuotaLimitResponse(limits *domain.QuotaLimit) *QuotaLim
 		StorageGB:             limits.StorageGB,
 		MaxWebhooks:           limits.MaxWebhooks,
 		MaxAPICallsDaily:      limits.MaxAPICallsDaily,
+		OverageEnabled:        limits.OverageEnabled,
+		OverageBlocked:        limits.OverageBlocked,
 		UpdatedAt:             limits.UpdatedAt,
 	}
 }
\ No newline at end of file
@@ -313,6 +313,33 @@ func NewQuotaWarningEvent(tenantID, quotaType string, currentUsage, limit int, t
 	}
 }
 
+// QuotaOverageEvent evento quando uma quota é excedida no modo de cobrança por excedente
+type QuotaOverageEvent struct {
+	BaseDomainEvent
+	QuotaType     string `json:"quota_type"`
+	CurrentUsage  int    `json:"current_usage"`
+	Limit         int    `json:"limit"`
+	OverageAmount int    `json:"overage_amount"`
+	OverageTotal  int    `json:"overage_total"`
+}
+
+func NewQuotaOverageEvent(tenantID, quotaType string, currentUsage, limit, overageAmount, overageTotal int) *QuotaOverageEvent {
+	return &QuotaOverageEvent{
+		BaseDomainEvent: BaseDomainEvent{
+			Type:       "tenant.quota_overage",
+			Version:    "1.0",
+			ID:         tenantID,
+			OccurredAt: time.Now(),
+			TenantID:   tenantID,
+		},
+		QuotaType:     quotaType,
+		CurrentUsage:  currentUsage,
+		Limit:         limit,
+		OverageAmount: overageAmount,
+		OverageTotal:  overageTotal,
+	}
+}
+
 // EventPublisher interface para publicação de eventos
 type EventPublisher interface {
 	Publish(event DomainEvent) error
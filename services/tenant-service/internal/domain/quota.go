@@ -19,6 +19,8 @@ type QuotaUsage struct {
 	WebhooksCount         int       `json:"webhooks_count" db:"webhooks_count"`
 	APICallsDaily         int       `json:"api_calls_daily" db:"api_calls_daily"`
 	APICallsMonthly       int       `json:"api_calls_monthly" db:"api_calls_monthly"`
+	DataJudOverageTotal   int       `json:"datajud_overage_total" db:"datajud_overage_total"` // acumulado, nunca resetado
+	AIOverageTotal        int       `json:"ai_overage_total" db:"ai_overage_total"`           // acumulado, nunca resetado
 	LastUpdated           time.Time `json:"last_updated" db:"last_updated"`
 	LastResetDaily        time.Time `json:"last_reset_daily" db:"last_reset_daily"`
 	LastResetMonthly      time.Time `json:"last_reset_monthly" db:"last_reset_monthly"`
@@ -35,9 +37,38 @@ type QuotaLimit struct {
 	StorageGB             int       `json:"storage_gb"`
 	MaxWebhooks           int       `json:"max_webhooks"`
 	MaxAPICallsDaily      int       `json:"max_api_calls_daily"`
+	OverageEnabled        bool      `json:"overage_enabled"` // cobrança por excedente em vez de bloqueio
+	OverageBlocked        bool      `json:"overage_blocked"` // teto de gastos atingido, volta a bloquear
 	UpdatedAt             time.Time `json:"updated_at"`
 }
 
+// Contadores de excedente faturável
+const (
+	OverageCounterDataJud = "datajud_overage"
+	OverageCounterAI      = "ai_overage"
+)
+
+// OverageCounterFor retorna o contador de excedente de uma quota faturável por uso
+func OverageCounterFor(quotaType string) (string, bool) {
+	switch quotaType {
+	case "datajud_daily":
+		return OverageCounterDataJud, true
+	case "ai_monthly":
+		return OverageCounterAI, true
+	default:
+		return "", false
+	}
+}
+
+// AllowsOverage verifica se a quota pode ser excedida mediante cobrança
+func (ql *QuotaLimit) AllowsOverage(quotaType string) bool {
+	if !ql.OverageEnabled || ql.OverageBlocked {
+		return false
+	}
+	_, billable := OverageCounterFor(quotaType)
+	return billable
+}
+
 // QuotaCheck resultado de verificação de quota
 type QuotaCheck struct {
 	QuotaType    string  `json:"quota_type"`
@@ -289,6 +320,20 @@ func (qu *QuotaUsage) IncrementDataJudDaily(amount int) error {
 	return nil
 }
 
+// OverageAmount calcula quanto de um incremento ultrapassa o limite
+func OverageAmount(current, limit, increment int) int {
+	if limit <= 0 { // Ilimitado
+		return 0
+	}
+	if current >= limit {
+		return increment
+	}
+	if excess := current + increment - limit; excess > 0 {
+		return excess
+	}
+	return 0
+}
+
 // IncrementAIMonthly incrementa contador IA mensal
 func (qu *QuotaUsage) IncrementAIMonthly(amount int) error {
 	if amount < 0 {
@@ -124,6 +124,9 @@ type ExternalServicesConfig struct {
 	NotificationServiceURL string        `envconfig:"NOTIFICATION_SERVICE_URL" default:"http://notification-service:8080"`
 	AIServiceURL           string        `envconfig:"AI_SERVICE_URL" default:"http://ai-service:8000"`
 	
+	// Token das chamadas internas entre serviços (billing-service)
+	InternalServiceToken string `envconfig:"INTERNAL_SERVICE_TOKEN"`
+	
 	// Timeouts
 	DefaultTimeout time.Duration `envconfig:"EXTERNAL_SERVICE_TIMEOUT" default:"30s"`
 	
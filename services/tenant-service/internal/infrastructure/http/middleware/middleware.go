@@ -1,8 +1,10 @@
 package middleware
 
 import (
+	"crypto/subtle"
 	"net/http"
 	"os"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -108,6 +110,38 @@ func RateLimit(cfg *config.Config) gin.HandlerFunc {
 	}
 }
 
+// InternalAuth middleware para rotas chamadas apenas por outros serviços. Sem token configurado
+// as rotas ficam indisponíveis.
+func InternalAuth(token string, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token == "" {
+			logger.Error("INTERNAL_SERVICE_TOKEN não configurado, rota interna indisponível",
+				zap.String("path", c.Request.URL.Path),
+			)
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+				"error":   "Service unavailable",
+				"message": "Autenticação entre serviços não configurada",
+			})
+			return
+		}
+		
+		provided := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			logger.Warn("Token interno inválido",
+				zap.String("path", c.Request.URL.Path),
+				zap.String("user_id", c.GetHeader("X-User-ID")),
+			)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error":   "Unauthorized",
+				"message": "Token interno inválido",
+			})
+			return
+		}
+		
+		c.Next()
+	}
+}
+
 // joinStrings utilitário para juntar strings
 func joinStrings(strs []string, sep string) string {
 	if len(strs) == 0 {
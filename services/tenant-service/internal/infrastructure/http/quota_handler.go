@@ -130,6 +130,30 @@ func (h *QuotaHandler) UpdateStorageUsage(w http.ResponseWriter, r *http.Request
 	writeJSONResponse(w, http.StatusOK, map[string]string{"message": "Storage usage updated successfully"})
 }
 
+// UpdateOverageMode configura a cobrança por excedente do tenant
+func (h *QuotaHandler) UpdateOverageMode(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	tenantID := vars["tenant_id"]
+
+	h.logger.Info("Updating overage mode", zap.String("tenant_id", tenantID))
+
+	var req application.UpdateOverageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("Failed to decode request", zap.Error(err))
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	limits, err := h.quotaService.SetOverageMode(r.Context(), tenantID, &req)
+	if err != nil {
+		h.logger.Error("Failed to update overage mode", zap.Error(err))
+		writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, limits)
+}
+
 // ResetDailyQuotas reseta quotas diárias
 func (h *QuotaHandler) ResetDailyQuotas(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -209,6 +233,7 @@ func (h *QuotaHandler) RegisterRoutes(router *mux.Router) {
 	quotaRouter.HandleFunc("/check", h.CheckQuotas).Methods("GET")
 	quotaRouter.HandleFunc("/increment", h.IncrementQuota).Methods("POST")
 	quotaRouter.HandleFunc("/storage", h.UpdateStorageUsage).Methods("PUT")
+	quotaRouter.HandleFunc("/overage", h.UpdateOverageMode).Methods("PUT")
 	quotaRouter.HandleFunc("/reset/daily", h.ResetDailyQuotas).Methods("POST")
 	quotaRouter.HandleFunc("/reset/monthly", h.ResetMonthlyQuotas).Methods("POST")
 	quotaRouter.HandleFunc("/can-increment/{quota_type}", h.CanIncrementQuota).Methods("GET")
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -24,6 +25,7 @@ type Server struct {
 	server         *http.Server
 	router         *gin.Engine
 	tenantRepo     domain.TenantRepository
+	quotaRepo      domain.QuotaRepository
 }
 
 // NewServer cria nova instância do servidor HTTP
@@ -116,12 +118,20 @@ func (s *Server) setupRoutes() {
 		tenants := api.Group("/tenants")
 		{
 			tenants.GET("/:id", s.getTenantByID)          // REAL DATABASE QUERY
+			
+			// Rotas internas (billing-service)
+			internal := tenants.Group("", middleware.InternalAuth(s.config.Services.InternalServiceToken, s.logger))
+			internal.POST("/:id/suspend", s.suspendTenant)
+			internal.POST("/:id/activate", s.activateTenant)
+			internal.GET("/:id/quotas/usage", s.getQuotaUsage)
+			internal.PUT("/:id/quotas/overage", s.setOverageMode)
 		}
 		
 		// Tenant-specific endpoints (direct routes) - FORCE COMPILATION
 		api.GET("/tenant/current", s.getCurrentTenant)     // Get current tenant from X-Tenant-ID
 		api.GET("/tenant/subscription", s.getSubscription) // Get subscription details
 		api.GET("/tenant/quotas", s.getQuotas)            // Get usage quotas
+		api.GET("/tenant/plan", s.getPlan)                // Plan features and limits (used by other services)
 	}
 
 	// Swagger documentation
@@ -224,6 +234,188 @@ func (s *Server) getTenantByID(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"data": response})
 }
 
+// suspendTenant suspende o tenant (inadimplência)
+func (s *Server) suspendTenant(c *gin.Context) {
+	tenantID := c.Param("id")
+	
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid request body",
+				"message": err.Error(),
+			})
+			return
+		}
+	}
+	
+	tenant, ok := s.loadTenant(c, tenantID)
+	if !ok {
+		return
+	}
+	
+	if tenant.Status != domain.TenantStatusSuspended {
+		tenant.Suspend()
+		if err := s.tenantRepo.Update(tenant); err != nil {
+			s.logger.Error("❌ Failed to suspend tenant", zap.String("tenant_id", tenantID), zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal server error",
+				"message": "Erro ao suspender escritório",
+			})
+			return
+		}
+	}
+	
+	s.logger.Info("✅ Tenant suspended",
+		zap.String("tenant_id", tenantID),
+		zap.String("reason", req.Reason),
+		zap.String("requested_by", c.GetHeader("X-User-ID")),
+	)
+	
+	c.JSON(http.StatusOK, gin.H{"id": tenant.ID, "status": tenant.Status})
+}
+
+// activateTenant reativa o tenant. Reativar um tenant já ativo não é erro.
+func (s *Server) activateTenant(c *gin.Context) {
+	tenantID := c.Param("id")
+	
+	tenant, ok := s.loadTenant(c, tenantID)
+	if !ok {
+		return
+	}
+	
+	if tenant.Status != domain.TenantStatusActive {
+		tenant.Activate()
+		if err := s.tenantRepo.Update(tenant); err != nil {
+			s.logger.Error("❌ Failed to activate tenant", zap.String("tenant_id", tenantID), zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal server error",
+				"message": "Erro ao reativar escritório",
+			})
+			return
+		}
+	}
+	
+	s.logger.Info("✅ Tenant activated",
+		zap.String("tenant_id", tenantID),
+		zap.String("requested_by", c.GetHeader("X-User-ID")),
+	)
+	
+	c.JSON(http.StatusOK, gin.H{"id": tenant.ID, "status": tenant.Status})
+}
+
+// getQuotaUsage retorna o uso de quotas do tenant, incluindo os contadores de excedente
+func (s *Server) getQuotaUsage(c *gin.Context) {
+	tenantID := c.Param("id")
+	
+	usage, err := s.quotaRepo.GetUsage(tenantID)
+	if err != nil {
+		if err != domain.ErrQuotaNotFound {
+			s.logger.Error("❌ Failed to get quota usage", zap.String("tenant_id", tenantID), zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal server error",
+				"message": "Erro ao buscar uso de quotas",
+			})
+			return
+		}
+		
+		// Tenant ainda sem consumo registrado
+		if _, ok := s.loadTenant(c, tenantID); !ok {
+			return
+		}
+		usage = domain.NewQuotaUsage(tenantID)
+	}
+	
+	c.JSON(http.StatusOK, usage)
+}
+
+// setOverageMode configura a cobrança por excedente nas quotas do tenant
+func (s *Server) setOverageMode(c *gin.Context) {
+	tenantID := c.Param("id")
+	
+	var req struct {
+		Enabled *bool `json:"enabled"`
+		Blocked *bool `json:"blocked"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+			"message": err.Error(),
+		})
+		return
+	}
+	
+	limits, err := s.quotaRepo.GetLimits(tenantID)
+	if err != nil {
+		if err != domain.ErrQuotaNotFound {
+			s.logger.Error("❌ Failed to get quota limits", zap.String("tenant_id", tenantID), zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal server error",
+				"message": "Erro ao buscar limites de quota",
+			})
+			return
+		}
+		
+		// Sem limites gravados: parte dos limites do plano do tenant
+		tenant, ok := s.loadTenant(c, tenantID)
+		if !ok {
+			return
+		}
+		limits = domain.GetQuotaLimitFromPlan(tenantID, domain.GetDefaultPlanQuotas(tenant.PlanType))
+	}
+	
+	if req.Enabled != nil {
+		limits.OverageEnabled = *req.Enabled
+	}
+	if req.Blocked != nil {
+		limits.OverageBlocked = *req.Blocked
+	}
+	limits.UpdatedAt = time.Now()
+	
+	if err := s.quotaRepo.UpdateLimits(limits); err != nil {
+		s.logger.Error("❌ Failed to update overage mode", zap.String("tenant_id", tenantID), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"message": "Erro ao atualizar cobrança por excedente",
+		})
+		return
+	}
+	
+	s.logger.Info("✅ Overage mode updated",
+		zap.String("tenant_id", tenantID),
+		zap.Bool("overage_enabled", limits.OverageEnabled),
+		zap.Bool("overage_blocked", limits.OverageBlocked),
+		zap.String("requested_by", c.GetHeader("X-User-ID")),
+	)
+	
+	c.JSON(http.StatusOK, limits)
+}
+
+// loadTenant busca o tenant e responde com o erro adequado quando não é possível
+func (s *Server) loadTenant(c *gin.Context, tenantID string) (*domain.Tenant, bool) {
+	tenant, err := s.tenantRepo.GetByID(tenantID)
+	if err != nil {
+		if err == domain.ErrTenantNotFound {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Tenant not found",
+				"message": "O escritório especificado não foi encontrado no sistema",
+			})
+			return nil, false
+		}
+		
+		s.logger.Error("❌ Database error fetching tenant", zap.String("tenant_id", tenantID), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"message": "Erro ao buscar dados do escritório",
+		})
+		return nil, false
+	}
+	
+	return tenant, true
+}
+
 // getCurrentTenant - Get current tenant from X-Tenant-ID header
 func (s *Server) getCurrentTenant(c *gin.Context) {
 	tenantID := c.GetHeader("X-Tenant-ID")
@@ -376,6 +568,39 @@ func (s *Server) getQuotas(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"data": quotas})
 }
 
+// getPlan - Get plan features and quotas for current tenant
+func (s *Server) getPlan(c *gin.Context) {
+	tenantID := c.GetHeader("X-Tenant-ID")
+	if tenantID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "X-Tenant-ID header é obrigatório",
+		})
+		return
+	}
+
+	tenant, err := s.tenantRepo.GetByID(tenantID)
+	if err != nil {
+		if err == domain.ErrTenantNotFound {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Tenant not found",
+			})
+			return
+		}
+
+		s.logger.Error("❌ Database error fetching tenant for plan", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{
+		"plan":     tenant.PlanType,
+		"features": domain.GetDefaultPlanFeatures(tenant.PlanType),
+		"quotas":   domain.GetDefaultPlanQuotas(tenant.PlanType),
+	}})
+}
+
 // Helper methods for plan data
 func (s *Server) getPlanAmount(plan domain.PlanType) float64 {
 	switch plan {
@@ -471,6 +696,7 @@ func (s *Server) Start() error {
 	
 	// Initialize repository
 	s.tenantRepo = repository.NewPostgresTenantRepository(db, s.logger)
+	s.quotaRepo = repository.NewPostgresQuotaRepository(db, s.logger)
 	s.logger.Info("✅ Database and repository initialized successfully")
 
 	s.logger.Info("Iniciando servidor HTTP",
@@ -0,0 +1,283 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+
+	"github.com/direito-lux/tenant-service/internal/domain"
+)
+
+// quotaCounterColumns colunas de quota_usage incrementadas por cada tipo de contador
+var quotaCounterColumns = map[string][]string{
+	"processes":                  {"processes_count"},
+	"users":                      {"users_count"},
+	"clients":                    {"clients_count"},
+	"webhooks":                   {"webhooks_count"},
+	"datajud_daily":              {"datajud_queries_daily", "datajud_queries_month"},
+	"ai_monthly":                 {"ai_queries_monthly"},
+	"api_daily":                  {"api_calls_daily", "api_calls_monthly"},
+	domain.OverageCounterDataJud: {"datajud_overage_total"},
+	domain.OverageCounterAI:      {"ai_overage_total"},
+}
+
+// PostgresQuotaRepository implementação PostgreSQL do QuotaRepository
+type PostgresQuotaRepository struct {
+	db     *sqlx.DB
+	logger *zap.Logger
+}
+
+// NewPostgresQuotaRepository cria nova instância do repositório
+func NewPostgresQuotaRepository(db *sqlx.DB, logger *zap.Logger) domain.QuotaRepository {
+	return &PostgresQuotaRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// quotaLimitDB representa os limites de quota no banco de dados
+type quotaLimitDB struct {
+	TenantID            string    `db:"tenant_id"`
+	MaxProcesses        int       `db:"max_processes"`
+	MaxUsers            int       `db:"max_users"`
+	MaxClients          int       `db:"max_clients"`
+	DataJudQueriesDaily int       `db:"datajud_queries_daily"`
+	AIQueriesMonthly    int       `db:"ai_queries_monthly"`
+	StorageGB           int       `db:"storage_gb"`
+	MaxWebhooks         int       `db:"max_webhooks"`
+	MaxAPICallsDaily    int       `db:"max_api_calls_daily"`
+	OverageEnabled      bool      `db:"overage_enabled"`
+	OverageBlocked      bool      `db:"overage_blocked"`
+	UpdatedAt           time.Time `db:"updated_at"`
+}
+
+// GetUsage busca o uso de quotas do tenant
+func (r *PostgresQuotaRepository) GetUsage(tenantID string) (*domain.QuotaUsage, error) {
+	query := `
+		SELECT id, tenant_id, processes_count, users_count, clients_count,
+		       datajud_queries_daily, datajud_queries_month, ai_queries_monthly,
+		       storage_used_gb, webhooks_count, api_calls_daily, api_calls_monthly,
+		       datajud_overage_total, ai_overage_total,
+		       last_updated, last_reset_daily, last_reset_monthly
+		FROM quota_usage
+		WHERE tenant_id = $1`
+
+	var usage domain.QuotaUsage
+	if err := r.db.Get(&usage, query, tenantID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrQuotaNotFound
+		}
+		r.logger.Error("Failed to get quota usage", zap.Error(err))
+		return nil, fmt.Errorf("erro ao buscar uso de quotas: %w", err)
+	}
+
+	return &usage, nil
+}
+
+// UpdateUsage cria ou atualiza o uso de quotas do tenant
+func (r *PostgresQuotaRepository) UpdateUsage(usage *domain.QuotaUsage) error {
+	query := `
+		INSERT INTO quota_usage (
+			tenant_id, processes_count, users_count, clients_count,
+			datajud_queries_daily, datajud_queries_month, ai_queries_monthly,
+			storage_used_gb, webhooks_count, api_calls_daily, api_calls_monthly,
+			datajud_overage_total, ai_overage_total,
+			last_updated, last_reset_daily, last_reset_monthly
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16
+		)
+		ON CONFLICT (tenant_id) DO UPDATE SET
+			processes_count = EXCLUDED.processes_count,
+			users_count = EXCLUDED.users_count,
+			clients_count = EXCLUDED.clients_count,
+			datajud_queries_daily = EXCLUDED.datajud_queries_daily,
+			datajud_queries_month = EXCLUDED.datajud_queries_month,
+			ai_queries_monthly = EXCLUDED.ai_queries_monthly,
+			storage_used_gb = EXCLUDED.storage_used_gb,
+			webhooks_count = EXCLUDED.webhooks_count,
+			api_calls_daily = EXCLUDED.api_calls_daily,
+			api_calls_monthly = EXCLUDED.api_calls_monthly,
+			datajud_overage_total = EXCLUDED.datajud_overage_total,
+			ai_overage_total = EXCLUDED.ai_overage_total,
+			last_updated = EXCLUDED.last_updated,
+			last_reset_daily = EXCLUDED.last_reset_daily,
+			last_reset_monthly = EXCLUDED.last_reset_monthly`
+
+	_, err := r.db.Exec(query,
+		usage.TenantID,
+		usage.ProcessesCount,
+		usage.UsersCount,
+		usage.ClientsCount,
+		usage.DataJudQueriesDaily,
+		usage.DataJudQueriesMonth,
+		usage.AIQueriesMonthly,
+		usage.StorageUsedGB,
+		usage.WebhooksCount,
+		usage.APICallsDaily,
+		usage.APICallsMonthly,
+		usage.DataJudOverageTotal,
+		usage.AIOverageTotal,
+		usage.LastUpdated,
+		usage.LastResetDaily,
+		usage.LastResetMonthly,
+	)
+	if err != nil {
+		r.logger.Error("Failed to update quota usage", zap.Error(err))
+		return fmt.Errorf("erro ao atualizar uso de quotas: %w", err)
+	}
+
+	return nil
+}
+
+// IncrementCounter incrementa um contador de uso de forma atômica, criando o registro do tenant
+// se ainda não existir
+func (r *PostgresQuotaRepository) IncrementCounter(tenantID, counterType string, amount int) error {
+	columns, ok := quotaCounterColumns[counterType]
+	if !ok {
+		return domain.ErrInvalidQuotaType
+	}
+	if amount < 0 {
+		return domain.ErrNegativeUsage
+	}
+
+	updates := make([]string, len(columns))
+	for i, column := range columns {
+		updates[i] = fmt.Sprintf("%s = quota_usage.%s + EXCLUDED.%s", column, column, column)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO quota_usage (tenant_id, %s, last_updated)
+		VALUES ($1, %s, NOW())
+		ON CONFLICT (tenant_id) DO UPDATE SET %s, last_updated = NOW()`,
+		strings.Join(columns, ", "),
+		strings.TrimSuffix(strings.Repeat("$2, ", len(columns)), ", "),
+		strings.Join(updates, ", "),
+	)
+
+	if _, err := r.db.Exec(query, tenantID, amount); err != nil {
+		r.logger.Error("Failed to increment quota counter",
+			zap.String("tenant_id", tenantID),
+			zap.String("counter_type", counterType),
+			zap.Error(err),
+		)
+		return fmt.Errorf("erro ao incrementar contador de quota: %w", err)
+	}
+
+	return nil
+}
+
+// ResetDailyCounters reseta os contadores diários do tenant
+func (r *PostgresQuotaRepository) ResetDailyCounters(tenantID string) error {
+	query := `
+		UPDATE quota_usage
+		SET datajud_queries_daily = 0, api_calls_daily = 0,
+		    last_reset_daily = NOW(), last_updated = NOW()
+		WHERE tenant_id = $1`
+
+	if _, err := r.db.Exec(query, tenantID); err != nil {
+		r.logger.Error("Failed to reset daily quota counters", zap.Error(err))
+		return fmt.Errorf("erro ao resetar contadores diários: %w", err)
+	}
+
+	return nil
+}
+
+// ResetMonthlyCounters reseta os contadores mensais do tenant. Os contadores de excedente são
+// acumulados e não são resetados.
+func (r *PostgresQuotaRepository) ResetMonthlyCounters(tenantID string) error {
+	query := `
+		UPDATE quota_usage
+		SET datajud_queries_month = 0, ai_queries_monthly = 0, api_calls_monthly = 0,
+		    last_reset_monthly = NOW(), last_updated = NOW()
+		WHERE tenant_id = $1`
+
+	if _, err := r.db.Exec(query, tenantID); err != nil {
+		r.logger.Error("Failed to reset monthly quota counters", zap.Error(err))
+		return fmt.Errorf("erro ao resetar contadores mensais: %w", err)
+	}
+
+	return nil
+}
+
+// GetLimits busca os limites de quota do tenant
+func (r *PostgresQuotaRepository) GetLimits(tenantID string) (*domain.QuotaLimit, error) {
+	query := `
+		SELECT tenant_id, max_processes, max_users, max_clients, datajud_queries_daily,
+		       ai_queries_monthly, storage_gb, max_webhooks, max_api_calls_daily,
+		       overage_enabled, overage_blocked, updated_at
+		FROM quota_limits
+		WHERE tenant_id = $1`
+
+	var limitsDB quotaLimitDB
+	if err := r.db.Get(&limitsDB, query, tenantID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrQuotaNotFound
+		}
+		r.logger.Error("Failed to get quota limits", zap.Error(err))
+		return nil, fmt.Errorf("erro ao buscar limites de quota: %w", err)
+	}
+
+	return &domain.QuotaLimit{
+		TenantID:            limitsDB.TenantID,
+		MaxProcesses:        limitsDB.MaxProcesses,
+		MaxUsers:            limitsDB.MaxUsers,
+		MaxClients:          limitsDB.MaxClients,
+		DataJudQueriesDaily: limitsDB.DataJudQueriesDaily,
+		AIQueriesMonthly:    limitsDB.AIQueriesMonthly,
+		StorageGB:           limitsDB.StorageGB,
+		MaxWebhooks:         limitsDB.MaxWebhooks,
+		MaxAPICallsDaily:    limitsDB.MaxAPICallsDaily,
+		OverageEnabled:      limitsDB.OverageEnabled,
+		OverageBlocked:      limitsDB.OverageBlocked,
+		UpdatedAt:           limitsDB.UpdatedAt,
+	}, nil
+}
+
+// UpdateLimits cria ou atualiza os limites de quota do tenant
+func (r *PostgresQuotaRepository) UpdateLimits(limits *domain.QuotaLimit) error {
+	query := `
+		INSERT INTO quota_limits (
+			tenant_id, max_processes, max_users, max_clients, datajud_queries_daily,
+			ai_queries_monthly, storage_gb, max_webhooks, max_api_calls_daily,
+			overage_enabled, overage_blocked, updated_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+		)
+		ON CONFLICT (tenant_id) DO UPDATE SET
+			max_processes = EXCLUDED.max_processes,
+			max_users = EXCLUDED.max_users,
+			max_clients = EXCLUDED.max_clients,
+			datajud_queries_daily = EXCLUDED.datajud_queries_daily,
+			ai_queries_monthly = EXCLUDED.ai_queries_monthly,
+			storage_gb = EXCLUDED.storage_gb,
+			max_webhooks = EXCLUDED.max_webhooks,
+			max_api_calls_daily = EXCLUDED.max_api_calls_daily,
+			overage_enabled = EXCLUDED.overage_enabled,
+			overage_blocked = EXCLUDED.overage_blocked,
+			updated_at = EXCLUDED.updated_at`
+
+	_, err := r.db.Exec(query,
+		limits.TenantID,
+		limits.MaxProcesses,
+		limits.MaxUsers,
+		limits.MaxClients,
+		limits.DataJudQueriesDaily,
+		limits.AIQueriesMonthly,
+		limits.StorageGB,
+		limits.MaxWebhooks,
+		limits.MaxAPICallsDaily,
+		limits.OverageEnabled,
+		limits.OverageBlocked,
+		limits.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.Error("Failed to update quota limits", zap.Error(err))
+		return fmt.Errorf("erro ao atualizar limites de quota: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,7 @@
+-- Migration: Remove overage billing from quotas
+-- Description: Remove as colunas de cobrança por excedente
+
+ALTER TABLE quota_limits DROP COLUMN IF EXISTS overage_blocked;
+ALTER TABLE quota_limits DROP COLUMN IF EXISTS overage_enabled;
+ALTER TABLE quota_usage DROP COLUMN IF EXISTS ai_overage_total;
+ALTER TABLE quota_usage DROP COLUMN IF EXISTS datajud_overage_total;
@@ -0,0 +1,15 @@
+-- Migration: Add overage billing to quotas
+-- Description: Cobrança por excedente de consultas DataJud e IA (opt-in por tenant)
+
+-- Contadores acumulados de excedente (nunca resetados; o billing-service fatura a diferença)
+ALTER TABLE quota_usage ADD COLUMN IF NOT EXISTS datajud_overage_total INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE quota_usage ADD COLUMN IF NOT EXISTS ai_overage_total INTEGER NOT NULL DEFAULT 0;
+
+-- Configuração da cobrança por excedente
+ALTER TABLE quota_limits ADD COLUMN IF NOT EXISTS overage_enabled BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE quota_limits ADD COLUMN IF NOT EXISTS overage_blocked BOOLEAN NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN quota_usage.datajud_overage_total IS 'Consultas DataJud acima do limite, acumuladas';
+COMMENT ON COLUMN quota_usage.ai_overage_total IS 'Consultas IA acima do limite, acumuladas';
+COMMENT ON COLUMN quota_limits.overage_enabled IS 'Cobra o excedente em vez de bloquear';
+COMMENT ON COLUMN quota_limits.overage_blocked IS 'Teto de gastos com excedente atingido';